@@ -33,6 +33,7 @@ const (
 const (
 	ErrBadRequestTitle          = "Bad Request"
 	ErrConflictTitle            = "Conflict Error"
+	ErrConcurrencyTitle         = "Concurrency Conflict Error"
 	ErrNotFoundTitle            = "Not Found"
 	ErrUnauthorizedTitle        = "Unauthorized"
 	ErrForbiddenTitle           = "Forbidden"
@@ -48,3 +49,10 @@ const (
 const (
 	TokenBlackPrefixKey = "invalid:token:cache:"
 )
+
+const (
+	// ServiceRole the role of the service credentials of the service to service calls
+	ServiceRole = "service"
+	// AdminRole the role of the administrators of the platform
+	AdminRole = "管理员"
+)
@@ -1,7 +1,13 @@
 package core
 
 import (
+	"fmt"
+
+	"github.com/reoden/go-NFT/pkg/core/messaging/versioning"
+	"github.com/reoden/go-NFT/pkg/core/serializer"
 	"github.com/reoden/go-NFT/pkg/core/serializer/json"
+	"github.com/reoden/go-NFT/pkg/core/serializer/msgpack"
+	"github.com/reoden/go-NFT/pkg/core/serializer/protobuf"
 
 	"go.uber.org/fx"
 )
@@ -15,5 +21,21 @@ var Module = fx.Module(
 		json.NewDefaultEventJsonSerializer,
 		json.NewDefaultMessageJsonSerializer,
 		json.NewDefaultMetadataJsonSerializer,
+		fx.Annotate(
+			protobuf.NewDefaultMessageProtobufSerializer,
+			fx.ResultTags(fmt.Sprintf(`group:"%s"`, serializer.MessageSerializersGroupName)),
+		),
+		fx.Annotate(
+			msgpack.NewDefaultMessageMsgpackSerializer,
+			fx.ResultTags(fmt.Sprintf(`group:"%s"`, serializer.MessageSerializersGroupName)),
+		),
+		fx.Annotate(
+			serializer.NewMessageSerializerRegistry,
+			fx.ParamTags(``, fmt.Sprintf(`group:"%s"`, serializer.MessageSerializersGroupName)),
+		),
+		fx.Annotate(
+			versioning.NewUpcasterRegistry,
+			fx.ParamTags(``, fmt.Sprintf(`group:"%s"`, versioning.UpcastersGroupName)),
+		),
 	),
 )
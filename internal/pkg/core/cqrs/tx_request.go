@@ -1,9 +1,18 @@
 package cqrs
 
+// TxRequest marks the requests that run inside a database transaction of the mediatr transaction pipeline, the
+// messages published by their handlers are stored in the outbox of the same transaction.
 // https://www.mohitkhare.com/blog/go-naming-conventions/
-// https://github.com/EventStore/EventStore-Client-Go/blob/master/esdb/position.go
 type TxRequest interface {
 	Request
 
-	isTxRequest()
+	IsTxRequest()
+}
+
+func IsTxRequest(obj interface{}) bool {
+	if _, ok := obj.(TxRequest); ok {
+		return true
+	}
+
+	return false
 }
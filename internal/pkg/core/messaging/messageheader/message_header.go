@@ -7,4 +7,8 @@ const (
 	Type          string = "type"
 	ContentType   string = "content-type"
 	Created       string = "created"
+	ReplyTo       string = "reply-to"
+	ReplyError    string = "reply-error"
+	SchemaVersion string = "schema-version"
+	SagaId        string = "saga-id"
 )
@@ -39,7 +39,7 @@ func SetMessageType(m metadata.Metadata, val string) {
 }
 
 func SetMessageContentType(m metadata.Metadata, val string) {
-	m.Set(Type, val)
+	m.Set(ContentType, val)
 }
 
 func GetMessageContentType(m metadata.Metadata) string {
@@ -53,3 +53,35 @@ func GetMessageCreated(m metadata.Metadata) time.Time {
 func SetMessageCreated(m metadata.Metadata, val time.Time) {
 	m.Set(Created, val)
 }
+
+func GetReplyTo(m metadata.Metadata) string {
+	return m.GetString(ReplyTo)
+}
+
+func SetReplyTo(m metadata.Metadata, val string) {
+	m.Set(ReplyTo, val)
+}
+
+func GetReplyError(m metadata.Metadata) string {
+	return m.GetString(ReplyError)
+}
+
+func SetReplyError(m metadata.Metadata, val string) {
+	m.Set(ReplyError, val)
+}
+
+func GetSchemaVersion(m metadata.Metadata) int {
+	return m.GetInt(SchemaVersion)
+}
+
+func SetSchemaVersion(m metadata.Metadata, val int) {
+	m.Set(SchemaVersion, val)
+}
+
+func GetSagaId(m metadata.Metadata) string {
+	return m.GetString(SagaId)
+}
+
+func SetSagaId(m metadata.Metadata, val string) {
+	m.Set(SagaId, val)
+}
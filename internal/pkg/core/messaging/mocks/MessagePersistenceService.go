@@ -9,6 +9,8 @@ import (
 	types "github.com/reoden/go-NFT/pkg/core/messaging/types"
 	mock "github.com/stretchr/testify/mock"
 
+	time "time"
+
 	uuid "github.com/satori/go.uuid"
 )
 
@@ -214,6 +216,53 @@ func (_c *MessagePersistenceService_ChangeState_Call) RunAndReturn(run func(cont
 	return _c
 }
 
+// CleanupInboxMessages provides a mock function with given fields: ctx, retention
+func (_m *MessagePersistenceService) CleanupInboxMessages(ctx context.Context, retention time.Duration) error {
+	ret := _m.Called(ctx, retention)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CleanupInboxMessages")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) error); ok {
+		r0 = rf(ctx, retention)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MessagePersistenceService_CleanupInboxMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CleanupInboxMessages'
+type MessagePersistenceService_CleanupInboxMessages_Call struct {
+	*mock.Call
+}
+
+// CleanupInboxMessages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - retention time.Duration
+func (_e *MessagePersistenceService_Expecter) CleanupInboxMessages(ctx interface{}, retention interface{}) *MessagePersistenceService_CleanupInboxMessages_Call {
+	return &MessagePersistenceService_CleanupInboxMessages_Call{Call: _e.mock.On("CleanupInboxMessages", ctx, retention)}
+}
+
+func (_c *MessagePersistenceService_CleanupInboxMessages_Call) Run(run func(ctx context.Context, retention time.Duration)) *MessagePersistenceService_CleanupInboxMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MessagePersistenceService_CleanupInboxMessages_Call) Return(_a0 error) *MessagePersistenceService_CleanupInboxMessages_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MessagePersistenceService_CleanupInboxMessages_Call) RunAndReturn(run func(context.Context, time.Duration) error) *MessagePersistenceService_CleanupInboxMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CleanupMessages provides a mock function with given fields: ctx
 func (_m *MessagePersistenceService) CleanupMessages(ctx context.Context) error {
 	ret := _m.Called(ctx)
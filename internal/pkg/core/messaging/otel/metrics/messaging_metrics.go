@@ -0,0 +1,127 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/otel/metrics"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// MessagingMeter without registering `NewOtelMetrics` it uses the global noop MeterProvider, the instruments are
+// delegated to the MeterProvider of `NewOtelMetrics` after it is registered
+var MessagingMeter metrics.AppMetrics
+
+var instruments struct {
+	published      metric.Int64Counter
+	publishFailed  metric.Int64Counter
+	consumed       metric.Int64Counter
+	consumeFailed  metric.Int64Counter
+	retried        metric.Int64Counter
+	deadLettered   metric.Int64Counter
+	redelivered    metric.Int64Counter
+	inFlight       metric.Int64UpDownCounter
+	handleDuration metric.Float64Histogram
+}
+
+func init() {
+	MessagingMeter = metrics.NewAppMeter(
+		"github.com/reoden/go-NFT/pkg/messaging",
+	) // instrumentation name
+
+	instruments.published = int64Counter("messaging.published_total", "Measures the number of the published messages")
+	instruments.publishFailed = int64Counter(
+		"messaging.publish_failed_total",
+		"Measures the number of the messages that failed to publish",
+	)
+	instruments.consumed = int64Counter("messaging.consumed_total", "Measures the number of the consumed messages")
+	instruments.consumeFailed = int64Counter(
+		"messaging.consume_failed_total",
+		"Measures the number of the messages that failed in the consumer handlers",
+	)
+	instruments.retried = int64Counter(
+		"messaging.retried_total",
+		"Measures the number of the failed messages that are sent to a retry queue",
+	)
+	instruments.deadLettered = int64Counter(
+		"messaging.dead_lettered_total",
+		"Measures the number of the failed messages that are moved to a dead-letter queue",
+	)
+	instruments.redelivered = int64Counter(
+		"messaging.redelivered_total",
+		"Measures the number of the messages that are redelivered by the broker",
+	)
+
+	var err error
+
+	instruments.inFlight, err = MessagingMeter.Int64UpDownCounter(
+		"messaging.in_flight",
+		metric.WithUnit("count"),
+		metric.WithDescription("Measures the number of the messages that are handled by the consumers"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	instruments.handleDuration, err = MessagingMeter.Float64Histogram(
+		"messaging.handler.duration",
+		metric.WithUnit("ms"),
+		metric.WithDescription("Measures the duration of handling the consumed messages"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+}
+
+// RecordPublished records a published message or a failed publish when err is not nil
+func RecordPublished(ctx context.Context, options *MessagingMetricsOptions, err error) {
+	if err != nil {
+		instruments.publishFailed.Add(ctx, 1, options.attributes())
+		return
+	}
+
+	instruments.published.Add(ctx, 1, options.attributes())
+}
+
+// StartConsume records the message as in flight, the returned func records the result and the duration of the handling
+func StartConsume(ctx context.Context, options *MessagingMetricsOptions) func(err error) {
+	attributes := options.attributes()
+	startTime := time.Now()
+
+	instruments.inFlight.Add(ctx, 1, attributes)
+
+	return func(err error) {
+		instruments.inFlight.Add(ctx, -1, attributes)
+		instruments.handleDuration.Record(ctx, float64(time.Since(startTime).Microseconds())/1000, attributes)
+
+		if err != nil {
+			instruments.consumeFailed.Add(ctx, 1, attributes)
+			return
+		}
+
+		instruments.consumed.Add(ctx, 1, attributes)
+	}
+}
+
+func RecordRetried(ctx context.Context, options *MessagingMetricsOptions) {
+	instruments.retried.Add(ctx, 1, options.attributes())
+}
+
+func RecordDeadLettered(ctx context.Context, options *MessagingMetricsOptions) {
+	instruments.deadLettered.Add(ctx, 1, options.attributes())
+}
+
+func RecordRedelivered(ctx context.Context, options *MessagingMetricsOptions) {
+	instruments.redelivered.Add(ctx, 1, options.attributes())
+}
+
+func int64Counter(name string, description string) metric.Int64Counter {
+	counter, err := MessagingMeter.Int64Counter(name, metric.WithUnit("count"), metric.WithDescription(description))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return counter
+}
@@ -0,0 +1,25 @@
+package metrics
+
+import (
+	"github.com/reoden/go-NFT/pkg/core/messaging/otel/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// MessagingMetricsOptions the attributes of the recorded messaging metrics
+type MessagingMetricsOptions struct {
+	MessagingSystem string
+	// Destination exchange of the published messages and queue of the consumed messages
+	Destination string
+	MessageType string
+}
+
+func (o *MessagingMetricsOptions) attributes() metric.MeasurementOption {
+	return metric.WithAttributes(
+		semconv.MessagingSystemKey.String(o.MessagingSystem),
+		semconv.MessagingDestinationName(o.Destination),
+		attribute.Key(tracing.MessageType).String(o.MessageType),
+	)
+}
@@ -0,0 +1,82 @@
+//go:build unit
+// +build unit
+
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func Test_Messaging_Metrics_Are_Recorded(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	ctx := context.Background()
+	options := &MessagingMetricsOptions{
+		MessagingSystem: "rabbitmq",
+		Destination:     "products",
+		MessageType:     "*ProductCreated",
+	}
+
+	RecordPublished(ctx, options, nil)
+	RecordPublished(ctx, options, errors.New("connection is closed"))
+
+	StartConsume(ctx, options)(nil)
+	StartConsume(ctx, options)(errors.New("handler failed"))
+	finish := StartConsume(ctx, options)
+
+	RecordRetried(ctx, options)
+	RecordDeadLettered(ctx, options)
+
+	var resourceMetrics metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &resourceMetrics))
+
+	assert.Equal(t, int64(1), sum(t, resourceMetrics, "messaging.published_total"))
+	assert.Equal(t, int64(1), sum(t, resourceMetrics, "messaging.publish_failed_total"))
+	assert.Equal(t, int64(1), sum(t, resourceMetrics, "messaging.consumed_total"))
+	assert.Equal(t, int64(1), sum(t, resourceMetrics, "messaging.consume_failed_total"))
+	assert.Equal(t, int64(1), sum(t, resourceMetrics, "messaging.retried_total"))
+	assert.Equal(t, int64(1), sum(t, resourceMetrics, "messaging.dead_lettered_total"))
+	assert.Equal(t, int64(1), sum(t, resourceMetrics, "messaging.in_flight"))
+
+	histogram, ok := find(resourceMetrics, "messaging.handler.duration").Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, histogram.DataPoints, 1)
+	assert.Equal(t, uint64(2), histogram.DataPoints[0].Count)
+
+	finish(nil)
+	require.NoError(t, reader.Collect(ctx, &resourceMetrics))
+	assert.Equal(t, int64(0), sum(t, resourceMetrics, "messaging.in_flight"))
+}
+
+func find(resourceMetrics metricdata.ResourceMetrics, name string) metricdata.Metrics {
+	for _, scopeMetrics := range resourceMetrics.ScopeMetrics {
+		for _, m := range scopeMetrics.Metrics {
+			if m.Name == name {
+				return m
+			}
+		}
+	}
+
+	return metricdata.Metrics{}
+}
+
+func sum(t *testing.T, resourceMetrics metricdata.ResourceMetrics, name string) int64 {
+	data, ok := find(resourceMetrics, name).Data.(metricdata.Sum[int64])
+	require.True(t, ok, "metric %s not found", name)
+
+	var total int64
+	for _, point := range data.DataPoints {
+		total += point.Value
+	}
+
+	return total
+}
@@ -2,6 +2,7 @@ package persistmessage
 
 import (
 	"context"
+	"time"
 
 	"github.com/reoden/go-NFT/pkg/core/messaging/types"
 
@@ -24,6 +25,8 @@ type MessagePersistenceService interface {
 	GetById(ctx context.Context, id uuid.UUID) (*StoreMessage, error)
 	Remove(ctx context.Context, storeMessage *StoreMessage) (bool, error)
 	CleanupMessages(ctx context.Context) error
+	// CleanupInboxMessages removes the inbox messages that are older than the retention period
+	CleanupInboxMessages(ctx context.Context, retention time.Duration) error
 	Process(messageID string, ctx context.Context) error
 	ProcessAll(ctx context.Context) error
 	AddPublishMessage(
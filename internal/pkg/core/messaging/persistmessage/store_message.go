@@ -22,13 +22,21 @@ const (
 )
 
 type StoreMessage struct {
-	ID            uuid.UUID `gorm:"primaryKey"`
-	DataType      string
-	Data          string
+	ID       uuid.UUID `gorm:"primaryKey"`
+	DataType string
+	Data     string
+	// Metadata json serialized headers of the message
+	Metadata string
+	// Destination topic or exchange name of the message, empty for the default destination of the message type
+	Destination   string
 	CreatedAt     time.Time `gorm:"default:current_timestamp"`
+	ProcessedAt   *time.Time
 	RetryCount    int
-	MessageStatus MessageStatus
+	MessageStatus MessageStatus `gorm:"index"`
 	DeliveryType  MessageDeliveryType
+	// ClaimedUntil end of the lease of the dispatcher that claimed the outbox message for publishing, the other
+	// dispatchers skip the message until the lease ends
+	ClaimedUntil *time.Time
 }
 
 func NewStoreMessage(
@@ -50,6 +58,10 @@ func NewStoreMessage(
 
 func (sm *StoreMessage) ChangeState(messageStatus MessageStatus) {
 	sm.MessageStatus = messageStatus
+	if messageStatus == Processed {
+		now := time.Now()
+		sm.ProcessedAt = &now
+	}
 }
 
 func (sm *StoreMessage) IncreaseRetry() {
@@ -13,3 +13,6 @@ type ConsumerHandlerFunc func(ctx context.Context) error
 type ConsumerPipeline interface {
 	Handle(ctx context.Context, consumerContext types.MessageConsumeContext, next ConsumerHandlerFunc) error
 }
+
+// InboxPipelineFactory creates the inbox pipeline of a consumer, the consumer name scopes the deduplication of the received messages
+type InboxPipelineFactory func(consumerName string) ConsumerPipeline
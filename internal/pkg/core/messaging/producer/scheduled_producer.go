@@ -0,0 +1,29 @@
+package producer
+
+import (
+	"context"
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	"github.com/reoden/go-NFT/pkg/core/metadata"
+)
+
+// ScheduledProducer publishes the messages on a later time or on a recurring cron schedule
+type ScheduledProducer interface {
+	Producer
+	// PublishAt publishes the message on the time and returns the schedule id for cancelling it
+	PublishAt(ctx context.Context, message types.IMessage, meta metadata.Metadata, at time.Time) (string, error)
+	// PublishAfter publishes the message after the delay and returns the schedule id for cancelling it
+	PublishAfter(ctx context.Context, message types.IMessage, meta metadata.Metadata, delay time.Duration) (string, error)
+	// PublishRecurring publishes the message on each time of the cron expression, the schedule with the same schedule
+	// id is replaced, so all the instances of a service can register their recurring schedules on the startup
+	PublishRecurring(
+		ctx context.Context,
+		scheduleId string,
+		cronExpression string,
+		message types.IMessage,
+		meta metadata.Metadata,
+	) error
+	// CancelScheduled removes the schedule, the messages of the schedule are not published anymore
+	CancelScheduled(ctx context.Context, scheduleId string) error
+}
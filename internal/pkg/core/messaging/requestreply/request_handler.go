@@ -0,0 +1,48 @@
+package requestreply
+
+import (
+	"context"
+
+	"github.com/reoden/go-NFT/pkg/core/messaging/consumer"
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+
+	"emperror.dev/errors"
+)
+
+// RequestHandlerFunc handles a request and returns its response
+type RequestHandlerFunc[TReq types.IMessage, TRes types.IMessage] func(ctx context.Context, request TReq) (TRes, error)
+
+type requestHandler[TReq types.IMessage, TRes types.IMessage] struct {
+	responder Responder
+	handle    RequestHandlerFunc[TReq, TRes]
+}
+
+// NewRequestHandler creates a consumer handler that replies the response of the request to its requester. The error of
+// the handler is replied to the requester, so the request is not retried by the consumer.
+func NewRequestHandler[TReq types.IMessage, TRes types.IMessage](
+	responder Responder,
+	handle RequestHandlerFunc[TReq, TRes],
+) consumer.ConsumerHandler {
+	return &requestHandler[TReq, TRes]{responder: responder, handle: handle}
+}
+
+func (h *requestHandler[TReq, TRes]) Handle(ctx context.Context, consumeContext types.MessageConsumeContext) error {
+	request, ok := consumeContext.Message().(TReq)
+	if !ok {
+		return errors.Errorf("message is not a request of type `%s`", typeMapper.GetGenericTypeNameByT[TReq]())
+	}
+
+	response, err := h.handle(ctx, request)
+
+	// a request that is published without a requester doesn't wait for a reply
+	if !IsRequest(consumeContext) {
+		return err
+	}
+
+	if err != nil {
+		return h.responder.ReplyError(ctx, consumeContext, err)
+	}
+
+	return h.responder.Reply(ctx, consumeContext, response)
+}
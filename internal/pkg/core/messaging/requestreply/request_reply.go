@@ -0,0 +1,109 @@
+package requestreply
+
+import (
+	"context"
+	"fmt"
+
+	messageHeader "github.com/reoden/go-NFT/pkg/core/messaging/messageheader"
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	"github.com/reoden/go-NFT/pkg/core/messaging/utils"
+	"github.com/reoden/go-NFT/pkg/core/messaging/versioning"
+	"github.com/reoden/go-NFT/pkg/core/metadata"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+
+	"emperror.dev/errors"
+)
+
+var (
+	// ErrRequestTimeout the reply of the request is not received before the timeout of the request
+	ErrRequestTimeout = errors.New("request timed out before receiving the reply")
+	// ErrNoReplyAddress the request message doesn't have the reply address of its requester
+	ErrNoReplyAddress = errors.New("request message doesn't have a reply address")
+)
+
+// Requester sends the request messages over the bus and waits for their replies
+type Requester interface {
+	// Request publishes the request with a new correlation id and the reply address of the requester, and waits for
+	// the reply until the context is done or the request timeout expires
+	Request(
+		ctx context.Context,
+		request types.IMessage,
+		meta metadata.Metadata,
+	) (types.MessageConsumeContext, error)
+}
+
+// Responder sends the replies of the request messages to their requesters
+type Responder interface {
+	// Reply sends the response to the requester of the request with the correlation id of the request
+	Reply(ctx context.Context, requestContext types.MessageConsumeContext, response types.IMessage) error
+	// ReplyError sends the error of handling the request to the requester of the request
+	ReplyError(ctx context.Context, requestContext types.MessageConsumeContext, replyErr error) error
+}
+
+// ReplyError error of a request that is returned by the responder
+type ReplyError struct {
+	Message string
+}
+
+func (e *ReplyError) Error() string {
+	return e.Message
+}
+
+// Request sends the request and returns its typed response, the error reply of the responder is returned as a *ReplyError
+func Request[TReq types.IMessage, TRes types.IMessage](
+	ctx context.Context,
+	requester Requester,
+	request TReq,
+	meta metadata.Metadata,
+) (TRes, error) {
+	var response TRes
+
+	replyContext, err := requester.Request(ctx, request, meta)
+	if err != nil {
+		return response, err
+	}
+
+	if replyErr := messageHeader.GetReplyError(replyContext.Metadata()); replyErr != "" {
+		return response, &ReplyError{Message: replyErr}
+	}
+
+	response, ok := replyContext.Message().(TRes)
+	if !ok {
+		return response, errors.Errorf(
+			"reply of the request `%s` is not of type `%s`",
+			typeMapper.GetGenericTypeNameByT[TReq](),
+			typeMapper.GetGenericTypeNameByT[TRes](),
+		)
+	}
+
+	return response, nil
+}
+
+// IsRequest returns true when the consumed message is a request that waits for a reply
+func IsRequest(consumeContext types.MessageConsumeContext) bool {
+	return messageHeader.GetReplyTo(consumeContext.Metadata()) != ""
+}
+
+// ReplyMetadata returns the metadata of a reply, the reply keeps the correlation id of its request
+func ReplyMetadata(requestContext types.MessageConsumeContext, response types.IMessage) (metadata.Metadata, error) {
+	replyTo := messageHeader.GetReplyTo(requestContext.Metadata())
+	if replyTo == "" {
+		return nil, errors.WithMessage(
+			ErrNoReplyAddress,
+			fmt.Sprintf("message id `%s`", requestContext.MessageId()),
+		)
+	}
+
+	meta := metadata.Metadata{}
+	messageHeader.SetCorrelationId(meta, requestContext.CorrelationId())
+	messageHeader.SetReplyTo(meta, replyTo)
+
+	if response != nil {
+		messageHeader.SetMessageId(meta, response.GeMessageId())
+		messageHeader.SetMessageCreated(meta, response.GetCreated())
+		messageHeader.SetMessageType(meta, utils.GetMessageTypeName(response))
+		messageHeader.SetSchemaVersion(meta, versioning.GetSchemaVersion(response))
+	}
+
+	return meta, nil
+}
@@ -0,0 +1,129 @@
+package saga
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type inMemorySagaStore struct {
+	mu       sync.Mutex
+	sagas    map[string]*StoredSaga
+	timeouts map[uuid.UUID]*StoredTimeout
+}
+
+// NewInMemorySagaStore creates a saga store that keeps the sagas in memory, for the tests and the services without a
+// database
+func NewInMemorySagaStore() SagaStore {
+	return &inMemorySagaStore{
+		sagas:    map[string]*StoredSaga{},
+		timeouts: map[uuid.UUID]*StoredTimeout{},
+	}
+}
+
+func (s *inMemorySagaStore) Get(ctx context.Context, sagaType string, sagaId string) (*StoredSaga, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	saga, exists := s.sagas[sagaKey(sagaType, sagaId)]
+	if !exists {
+		return nil, ErrSagaNotFound
+	}
+
+	stored := *saga
+
+	return &stored, nil
+}
+
+func (s *inMemorySagaStore) Save(ctx context.Context, saga *StoredSaga) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := sagaKey(saga.SagaType, saga.SagaId)
+	existing, exists := s.sagas[key]
+
+	switch {
+	case saga.Version == 0 && exists:
+		return ErrConcurrencyConflict
+	case saga.Version != 0 && (!exists || existing.Version != saga.Version):
+		return ErrConcurrencyConflict
+	}
+
+	now := time.Now()
+	if saga.Version == 0 {
+		saga.CreatedAt = now
+	}
+	saga.UpdatedAt = now
+	saga.Version++
+
+	stored := *saga
+	s.sagas[key] = &stored
+
+	return nil
+}
+
+func (s *inMemorySagaStore) AddTimeout(ctx context.Context, timeout *StoredTimeout) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := *timeout
+	s.timeouts[timeout.ID] = &stored
+
+	return nil
+}
+
+func (s *inMemorySagaStore) ClaimDueTimeouts(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+	claimFor time.Duration,
+) ([]*StoredTimeout, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var timeouts []*StoredTimeout
+	for _, timeout := range s.timeouts {
+		if !timeout.DueAt.After(now) && (timeout.ClaimedUntil == nil || timeout.ClaimedUntil.Before(now)) {
+			timeouts = append(timeouts, timeout)
+		}
+	}
+
+	sort.Slice(timeouts, func(i, j int) bool {
+		return timeouts[i].DueAt.Before(timeouts[j].DueAt)
+	})
+
+	if limit > 0 && len(timeouts) > limit {
+		timeouts = timeouts[:limit]
+	}
+
+	claimedUntil := now.Add(claimFor)
+	claimed := make([]*StoredTimeout, 0, len(timeouts))
+	for _, timeout := range timeouts {
+		timeout.ClaimedUntil = &claimedUntil
+
+		stored := *timeout
+		claimed = append(claimed, &stored)
+	}
+
+	return claimed, nil
+}
+
+func (s *inMemorySagaStore) RemoveTimeout(ctx context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.timeouts, id)
+
+	return nil
+}
+
+func (s *inMemorySagaStore) RunInTx(ctx context.Context, action func(ctx context.Context) error) error {
+	return action(ctx)
+}
+
+func sagaKey(sagaType string, sagaId string) string {
+	return sagaType + "/" + sagaId
+}
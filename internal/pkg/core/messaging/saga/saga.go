@@ -0,0 +1,38 @@
+package saga
+
+import (
+	"time"
+)
+
+// Status state of a saga instance
+type Status string
+
+const (
+	// Running the saga is waiting for the messages of its next steps
+	Running Status = "running"
+	// Completed the saga finished all of its steps
+	Completed Status = "completed"
+	// Compensating the saga failed and runs the compensations of its completed steps
+	Compensating Status = "compensating"
+	// Compensated the compensations of all the completed steps are done
+	Compensated Status = "compensated"
+)
+
+// Instance a running or finished saga with its typed state
+type Instance[T any] struct {
+	SagaId   string
+	SagaType string
+	Status   Status
+	State    *T
+	// CompletedSteps the completed steps in their order, the failed saga compensates them in the reverse order
+	CompletedSteps []string
+	FailureReason  string
+	Version        int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// IsFinished returns true when the saga doesn't handle the messages anymore
+func (i *Instance[T]) IsFinished() bool {
+	return i.Status == Completed || i.Status == Compensated
+}
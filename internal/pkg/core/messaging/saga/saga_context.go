@@ -0,0 +1,84 @@
+package saga
+
+import (
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	"github.com/reoden/go-NFT/pkg/core/metadata"
+)
+
+// Context the saga instance of a handled message, the sent messages and the timeouts are kept until the state of the
+// saga is saved, so the replies of the sent messages always find the saved state
+type Context[T any] struct {
+	instance       *Instance[T]
+	consumeContext types.MessageConsumeContext
+	outgoing       []*outgoingMessage
+	timeouts       []*timeoutMessage
+	completed      bool
+	failed         bool
+	failureReason  string
+}
+
+type outgoingMessage struct {
+	message types.IMessage
+	meta    metadata.Metadata
+}
+
+type timeoutMessage struct {
+	message types.IMessage
+	dueAt   time.Time
+}
+
+func newContext[T any](instance *Instance[T], consumeContext types.MessageConsumeContext) *Context[T] {
+	return &Context[T]{instance: instance, consumeContext: consumeContext}
+}
+
+func (c *Context[T]) SagaId() string {
+	return c.instance.SagaId
+}
+
+// State returns the state of the saga, the changes of the state are saved after the handler
+func (c *Context[T]) State() *T {
+	return c.instance.State
+}
+
+func (c *Context[T]) Status() Status {
+	return c.instance.Status
+}
+
+// CompletedSteps returns the completed steps that are not compensated yet
+func (c *Context[T]) CompletedSteps() []string {
+	return append([]string{}, c.instance.CompletedSteps...)
+}
+
+// ConsumeContext returns the consume context of the handled message, it is nil in the compensations that are resumed
+func (c *Context[T]) ConsumeContext() types.MessageConsumeContext {
+	return c.consumeContext
+}
+
+// Send publishes the message with the saga id header after the state of the saga is saved
+func (c *Context[T]) Send(message types.IMessage, meta metadata.Metadata) {
+	c.outgoing = append(c.outgoing, &outgoingMessage{message: message, meta: meta})
+}
+
+// RequestTimeout schedules the timeout message to the saga after the duration, the saga handles the timeout message
+// like its other messages
+func (c *Context[T]) RequestTimeout(message types.IMessage, after time.Duration) {
+	c.timeouts = append(c.timeouts, &timeoutMessage{message: message, dueAt: time.Now().Add(after)})
+}
+
+// CompleteStep marks the step as completed, the completed steps are compensated when the saga fails
+func (c *Context[T]) CompleteStep(step string) {
+	c.instance.CompletedSteps = append(c.instance.CompletedSteps, step)
+}
+
+// Complete finishes the saga, the later messages of the saga are ignored
+func (c *Context[T]) Complete() {
+	c.completed = true
+}
+
+// Fail starts the compensations of the completed steps after the handler
+func (c *Context[T]) Fail(reason string) {
+	c.failed = true
+	c.failureReason = reason
+}
@@ -0,0 +1,179 @@
+package saga
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	"github.com/reoden/go-NFT/pkg/core/messaging/utils"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+
+	"emperror.dev/errors"
+)
+
+// HandleFunc handles a message of the saga, the changes of the state and the sent messages are saved when it returns
+// without an error. An error is returned to the consumer, so the message is retried with the saved state.
+type HandleFunc[T any, TMessage types.IMessage] func(ctx context.Context, sagaContext *Context[T], message TMessage) error
+
+// CorrelateFunc returns the saga id of the message
+type CorrelateFunc[TMessage types.IMessage] func(message TMessage) string
+
+// CompensateFunc undoes a completed step of a failed saga
+type CompensateFunc[T any] func(ctx context.Context, sagaContext *Context[T]) error
+
+// MessageHandler a handler of a message type of the saga with state T
+type MessageHandler[T any] struct {
+	message     types.IMessage
+	messageType reflect.Type
+	correlate   func(message types.IMessage) string
+	handle      func(ctx context.Context, sagaContext *Context[T], message types.IMessage) error
+}
+
+// Handle creates the handler of the message TMessage, a nil correlate func correlates the message by the saga id header
+// that is set on the messages that are sent by the saga
+func Handle[T any, TMessage types.IMessage](
+	correlate CorrelateFunc[TMessage],
+	handle HandleFunc[T, TMessage],
+) *MessageHandler[T] {
+	message := typeMapper.GenericInstanceByT[TMessage]()
+
+	handler := &MessageHandler[T]{
+		message:     message,
+		messageType: utils.GetMessageBaseReflectType(message),
+		handle: func(ctx context.Context, sagaContext *Context[T], message types.IMessage) error {
+			typed, ok := message.(TMessage)
+			if !ok {
+				return errors.Errorf("message is not of type `%s`", typeMapper.GetGenericTypeNameByT[TMessage]())
+			}
+
+			return handle(ctx, sagaContext, typed)
+		},
+	}
+
+	if correlate != nil {
+		handler.correlate = func(message types.IMessage) string {
+			typed, ok := message.(TMessage)
+			if !ok {
+				return ""
+			}
+
+			return correlate(typed)
+		}
+	}
+
+	return handler
+}
+
+// Definition the message handlers and the compensations of a saga type
+type Definition[T any] struct {
+	sagaType      string
+	handlers      map[reflect.Type]*registeredHandler[T]
+	compensations map[string]CompensateFunc[T]
+}
+
+type registeredHandler[T any] struct {
+	*MessageHandler[T]
+	startsSaga bool
+}
+
+// SagaType name of the saga type, the states of the saga types are stored separately
+func (d *Definition[T]) SagaType() string {
+	return d.sagaType
+}
+
+// Messages returns the instances of the message types that the saga handles
+func (d *Definition[T]) Messages() []types.IMessage {
+	var messages []types.IMessage
+	for _, handler := range d.handlers {
+		messages = append(messages, handler.message)
+	}
+
+	return messages
+}
+
+func (d *Definition[T]) handler(message types.IMessage) (*registeredHandler[T], bool) {
+	handler, exists := d.handlers[utils.GetMessageBaseReflectType(message)]
+
+	return handler, exists
+}
+
+type DefinitionBuilder[T any] interface {
+	// StartedBy adds a handler that creates the saga when there is no saga with the saga id of the message
+	StartedBy(handler *MessageHandler[T]) DefinitionBuilder[T]
+	// Handles adds a handler of the messages of a running saga, the messages without a saga are ignored
+	Handles(handler *MessageHandler[T]) DefinitionBuilder[T]
+	// Compensate sets the compensation of a step, the completed steps are compensated in the reverse order when the
+	// saga fails
+	Compensate(step string, compensate CompensateFunc[T]) DefinitionBuilder[T]
+	Build() (*Definition[T], error)
+}
+
+type definitionBuilder[T any] struct {
+	definition *Definition[T]
+	errs       []error
+}
+
+func NewDefinitionBuilder[T any](sagaType string) DefinitionBuilder[T] {
+	return &definitionBuilder[T]{
+		definition: &Definition[T]{
+			sagaType:      sagaType,
+			handlers:      map[reflect.Type]*registeredHandler[T]{},
+			compensations: map[string]CompensateFunc[T]{},
+		},
+	}
+}
+
+func (b *definitionBuilder[T]) StartedBy(handler *MessageHandler[T]) DefinitionBuilder[T] {
+	b.addHandler(handler, true)
+
+	return b
+}
+
+func (b *definitionBuilder[T]) Handles(handler *MessageHandler[T]) DefinitionBuilder[T] {
+	b.addHandler(handler, false)
+
+	return b
+}
+
+func (b *definitionBuilder[T]) Compensate(step string, compensate CompensateFunc[T]) DefinitionBuilder[T] {
+	if _, exists := b.definition.compensations[step]; exists {
+		b.errs = append(b.errs, errors.Errorf("compensation of the step `%s` is already added", step))
+	}
+	b.definition.compensations[step] = compensate
+
+	return b
+}
+
+func (b *definitionBuilder[T]) Build() (*Definition[T], error) {
+	if b.definition.sagaType == "" {
+		b.errs = append(b.errs, errors.New("saga type is required"))
+	}
+
+	startsSaga := false
+	for _, handler := range b.definition.handlers {
+		startsSaga = startsSaga || handler.startsSaga
+	}
+	if !startsSaga {
+		b.errs = append(b.errs, errors.Errorf("saga `%s` doesn't have a message that starts it", b.definition.sagaType))
+	}
+
+	if len(b.errs) > 0 {
+		return nil, errors.Combine(b.errs...)
+	}
+
+	return b.definition, nil
+}
+
+func (b *definitionBuilder[T]) addHandler(handler *MessageHandler[T], startsSaga bool) {
+	if handler == nil {
+		b.errs = append(b.errs, errors.New("saga message handler is required"))
+		return
+	}
+
+	if _, exists := b.definition.handlers[handler.messageType]; exists {
+		b.errs = append(b.errs, errors.Errorf("handler of the message `%s` is already added", handler.messageType))
+		return
+	}
+
+	b.definition.handlers[handler.messageType] = &registeredHandler[T]{MessageHandler: handler, startsSaga: startsSaga}
+}
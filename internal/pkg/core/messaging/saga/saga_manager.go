@@ -0,0 +1,307 @@
+package saga
+
+import (
+	"context"
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/core/messaging/consumer"
+	messageHeader "github.com/reoden/go-NFT/pkg/core/messaging/messageheader"
+	"github.com/reoden/go-NFT/pkg/core/messaging/producer"
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	"github.com/reoden/go-NFT/pkg/core/messaging/utils"
+	"github.com/reoden/go-NFT/pkg/core/metadata"
+	"github.com/reoden/go-NFT/pkg/core/serializer"
+	"github.com/reoden/go-NFT/pkg/logger"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// Manager runs the sagas of a definition, it handles the messages of the saga as a consumer handler. The state of the
+// saga, its timeouts and its sent messages are committed in one transaction of the store, the sent messages are part
+// of the transaction only with an outbox producer.
+type Manager[T any] struct {
+	definition        *Definition[T]
+	store             SagaStore
+	producer          producer.Producer
+	messageSerializer serializer.MessageSerializer
+	logger            logger.Logger
+}
+
+func NewSagaManager[T any](
+	definition *Definition[T],
+	store SagaStore,
+	producer producer.Producer,
+	messageSerializer serializer.MessageSerializer,
+	l logger.Logger,
+) *Manager[T] {
+	return &Manager[T]{
+		definition:        definition,
+		store:             store,
+		producer:          producer,
+		messageSerializer: messageSerializer,
+		logger:            l,
+	}
+}
+
+// ConnectTo connects the manager as the handler of all the messages of the saga
+func (m *Manager[T]) ConnectTo(connector consumer.ConsumerConnector) error {
+	for _, message := range m.definition.Messages() {
+		if err := connector.ConnectConsumerHandler(message, m); err != nil {
+			return errors.WrapIf(err, "error in connecting the saga handler")
+		}
+	}
+
+	return nil
+}
+
+// Get returns the saga with the saga id or ErrSagaNotFound
+func (m *Manager[T]) Get(ctx context.Context, sagaId string) (*Instance[T], error) {
+	return m.load(ctx, sagaId)
+}
+
+func (m *Manager[T]) Handle(ctx context.Context, consumeContext types.MessageConsumeContext) error {
+	message := consumeContext.Message()
+
+	handler, exists := m.definition.handler(message)
+	if !exists {
+		return errors.Errorf(
+			"saga `%s` doesn't handle the message `%s`",
+			m.definition.sagaType,
+			utils.GetMessageTypeName(message),
+		)
+	}
+
+	sagaId := messageHeader.GetSagaId(consumeContext.Metadata())
+	if handler.correlate != nil {
+		sagaId = handler.correlate(message)
+	}
+	if sagaId == "" {
+		return errors.Errorf(
+			"saga id of the message `%s` with id `%s` not found",
+			utils.GetMessageTypeName(message),
+			consumeContext.MessageId(),
+		)
+	}
+
+	instance, err := m.load(ctx, sagaId)
+	if errors.Is(err, ErrSagaNotFound) {
+		if !handler.startsSaga {
+			m.logger.Infof(
+				"saga `%s` with id `%s` not found, the message `%s` is ignored",
+				m.definition.sagaType,
+				sagaId,
+				consumeContext.MessageId(),
+			)
+
+			return nil
+		}
+
+		instance = &Instance[T]{SagaId: sagaId, SagaType: m.definition.sagaType, Status: Running, State: new(T)}
+	} else if err != nil {
+		return err
+	}
+
+	if instance.IsFinished() {
+		m.logger.Infof(
+			"saga `%s` with id `%s` is %s, the message `%s` is ignored",
+			m.definition.sagaType,
+			sagaId,
+			instance.Status,
+			consumeContext.MessageId(),
+		)
+
+		return nil
+	}
+
+	sagaContext := newContext(instance, consumeContext)
+
+	// the compensations of a failed saga are resumed before the saga handles any other message
+	if instance.Status == Compensating {
+		return m.compensate(ctx, sagaContext)
+	}
+
+	if err := handler.handle(ctx, sagaContext, message); err != nil {
+		return errors.WrapIf(err, "error in handling the saga message")
+	}
+
+	if sagaContext.failed {
+		instance.FailureReason = sagaContext.failureReason
+
+		return m.compensate(ctx, sagaContext)
+	}
+
+	if sagaContext.completed {
+		instance.Status = Completed
+	}
+
+	return m.commit(ctx, sagaContext)
+}
+
+func (m *Manager[T]) compensate(ctx context.Context, sagaContext *Context[T]) error {
+	instance := sagaContext.instance
+	instance.Status = Compensating
+
+	for len(instance.CompletedSteps) > 0 {
+		step := instance.CompletedSteps[len(instance.CompletedSteps)-1]
+
+		if compensate, exists := m.definition.compensations[step]; exists {
+			outgoing, timeouts := len(sagaContext.outgoing), len(sagaContext.timeouts)
+
+			if err := compensate(ctx, sagaContext); err != nil {
+				// the messages of the failed compensation are sent when the compensation is retried
+				sagaContext.outgoing = sagaContext.outgoing[:outgoing]
+				sagaContext.timeouts = sagaContext.timeouts[:timeouts]
+
+				err = errors.WrapIff(err, "error in compensating the step `%s` of the saga `%s`", step, instance.SagaId)
+				if commitErr := m.commit(ctx, sagaContext); commitErr != nil {
+					return errors.Combine(err, commitErr)
+				}
+
+				return err
+			}
+		}
+
+		instance.CompletedSteps = instance.CompletedSteps[:len(instance.CompletedSteps)-1]
+	}
+
+	instance.Status = Compensated
+
+	return m.commit(ctx, sagaContext)
+}
+
+func (m *Manager[T]) commit(ctx context.Context, sagaContext *Context[T]) error {
+	instance := sagaContext.instance
+
+	stored, err := m.toStoredSaga(instance)
+	if err != nil {
+		return err
+	}
+
+	err = m.store.RunInTx(ctx, func(ctx context.Context) error {
+		return m.save(ctx, sagaContext, stored)
+	})
+	if err != nil {
+		return err
+	}
+
+	instance.Version = stored.Version
+	instance.CreatedAt = stored.CreatedAt
+	instance.UpdatedAt = stored.UpdatedAt
+
+	return nil
+}
+
+// save saves the saga and its timeouts and publishes its sent messages on the transaction of the context
+func (m *Manager[T]) save(ctx context.Context, sagaContext *Context[T], stored *StoredSaga) error {
+	instance := sagaContext.instance
+
+	if err := m.store.Save(ctx, stored); err != nil {
+		return errors.WrapIff(err, "error in saving the saga `%s` with id `%s`", instance.SagaType, instance.SagaId)
+	}
+
+	for _, timeout := range sagaContext.timeouts {
+		storedTimeout, err := m.toStoredTimeout(instance, timeout)
+		if err != nil {
+			return err
+		}
+
+		if err := m.store.AddTimeout(ctx, storedTimeout); err != nil {
+			return errors.WrapIf(err, "error in adding the saga timeout")
+		}
+	}
+
+	for _, outgoing := range sagaContext.outgoing {
+		meta := metadata.FromMetadata(outgoing.meta)
+		messageHeader.SetSagaId(meta, instance.SagaId)
+
+		if err := m.producer.PublishMessage(ctx, outgoing.message, meta); err != nil {
+			return errors.WrapIf(err, "error in publishing the saga message")
+		}
+	}
+
+	return nil
+}
+
+func (m *Manager[T]) load(ctx context.Context, sagaId string) (*Instance[T], error) {
+	stored, err := m.store.Get(ctx, m.definition.sagaType, sagaId)
+	if err != nil {
+		return nil, err
+	}
+
+	instance := &Instance[T]{
+		SagaId:        stored.SagaId,
+		SagaType:      stored.SagaType,
+		Status:        stored.Status,
+		State:         new(T),
+		FailureReason: stored.FailureReason,
+		Version:       stored.Version,
+		CreatedAt:     stored.CreatedAt,
+		UpdatedAt:     stored.UpdatedAt,
+	}
+
+	if stored.State != "" {
+		if err := m.messageSerializer.Serializer().UnmarshalFromJson(stored.State, instance.State); err != nil {
+			return nil, errors.WrapIf(err, "error in deserializing the saga state")
+		}
+	}
+
+	if stored.CompletedSteps != "" {
+		if err := m.messageSerializer.Serializer().UnmarshalFromJson(stored.CompletedSteps, &instance.CompletedSteps); err != nil {
+			return nil, errors.WrapIf(err, "error in deserializing the saga completed steps")
+		}
+	}
+
+	return instance, nil
+}
+
+func (m *Manager[T]) toStoredSaga(instance *Instance[T]) (*StoredSaga, error) {
+	state, err := m.messageSerializer.Serializer().Marshal(instance.State)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in serializing the saga state")
+	}
+
+	completedSteps, err := m.messageSerializer.Serializer().Marshal(instance.CompletedSteps)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in serializing the saga completed steps")
+	}
+
+	return &StoredSaga{
+		SagaId:         instance.SagaId,
+		SagaType:       instance.SagaType,
+		Status:         instance.Status,
+		State:          string(state),
+		CompletedSteps: string(completedSteps),
+		FailureReason:  instance.FailureReason,
+		Version:        instance.Version,
+		CreatedAt:      instance.CreatedAt,
+		UpdatedAt:      instance.UpdatedAt,
+	}, nil
+}
+
+func (m *Manager[T]) toStoredTimeout(instance *Instance[T], timeout *timeoutMessage) (*StoredTimeout, error) {
+	result, err := m.messageSerializer.Serialize(timeout.message)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in serializing the saga timeout")
+	}
+
+	meta := metadata.Metadata{}
+	messageHeader.SetSagaId(meta, instance.SagaId)
+
+	metaData, err := m.messageSerializer.Serializer().Marshal(meta)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in serializing the saga timeout metadata")
+	}
+
+	return &StoredTimeout{
+		ID:          uuid.NewV4(),
+		SagaId:      instance.SagaId,
+		SagaType:    instance.SagaType,
+		MessageType: utils.GetMessageTypeName(timeout.message),
+		ContentType: result.ContentType,
+		Data:        string(result.Data),
+		Metadata:    string(metaData),
+		DueAt:       timeout.dueAt,
+		CreatedAt:   time.Now(),
+	}, nil
+}
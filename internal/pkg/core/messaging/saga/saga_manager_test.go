@@ -0,0 +1,367 @@
+//go:build unit
+// +build unit
+
+package saga
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	messageHeader "github.com/reoden/go-NFT/pkg/core/messaging/messageheader"
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	"github.com/reoden/go-NFT/pkg/core/messaging/utils"
+	"github.com/reoden/go-NFT/pkg/core/metadata"
+	"github.com/reoden/go-NFT/pkg/core/serializer/json"
+	defaultLogger "github.com/reoden/go-NFT/pkg/logger/defaultlogger"
+	inMemory "github.com/reoden/go-NFT/pkg/rabbitmq/test/in-memory"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type purchaseState struct {
+	OrderId string
+	Amount  int
+}
+
+type orderPlaced struct {
+	*types.Message
+	OrderId string
+	Amount  int
+}
+
+type reserveStock struct {
+	*types.Message
+	OrderId string
+}
+
+type stockReserved struct {
+	*types.Message
+}
+
+type chargePayment struct {
+	*types.Message
+	Amount int
+}
+
+type paymentCharged struct {
+	*types.Message
+}
+
+type paymentFailed struct {
+	*types.Message
+	Reason string
+}
+
+type paymentTimedOut struct {
+	*types.Message
+}
+
+type releaseStock struct {
+	*types.Message
+	OrderId string
+}
+
+// serviceHandler replies to the commands of the saga like a remote service
+type serviceHandler func(ctx context.Context, consumeContext types.MessageConsumeContext) error
+
+func (h serviceHandler) Handle(ctx context.Context, consumeContext types.MessageConsumeContext) error {
+	return h(ctx, consumeContext)
+}
+
+type purchaseSagaFixture struct {
+	harnesses  *inMemory.RabbitmqInMemoryHarnesses
+	store      SagaStore
+	manager    *Manager[purchaseState]
+	dispatcher TimeoutDispatcher
+}
+
+func newMessage() *types.Message {
+	return types.NewMessage(uuid.NewV4().String())
+}
+
+func newPurchaseDefinition(t *testing.T, paymentTimeout time.Duration) *Definition[purchaseState] {
+	definition, err := NewDefinitionBuilder[purchaseState]("purchase").
+		StartedBy(Handle[purchaseState](
+			func(message *orderPlaced) string { return message.OrderId },
+			func(ctx context.Context, sagaContext *Context[purchaseState], message *orderPlaced) error {
+				sagaContext.State().OrderId = message.OrderId
+				sagaContext.State().Amount = message.Amount
+				sagaContext.Send(&reserveStock{Message: newMessage(), OrderId: message.OrderId}, nil)
+				sagaContext.RequestTimeout(&paymentTimedOut{Message: newMessage()}, paymentTimeout)
+
+				return nil
+			},
+		)).
+		Handles(Handle[purchaseState, *stockReserved](
+			nil,
+			func(ctx context.Context, sagaContext *Context[purchaseState], message *stockReserved) error {
+				sagaContext.CompleteStep("reserve-stock")
+				sagaContext.Send(&chargePayment{Message: newMessage(), Amount: sagaContext.State().Amount}, nil)
+
+				return nil
+			},
+		)).
+		Handles(Handle[purchaseState, *paymentCharged](
+			nil,
+			func(ctx context.Context, sagaContext *Context[purchaseState], message *paymentCharged) error {
+				sagaContext.CompleteStep("charge-payment")
+				sagaContext.Complete()
+
+				return nil
+			},
+		)).
+		Handles(Handle[purchaseState, *paymentFailed](
+			nil,
+			func(ctx context.Context, sagaContext *Context[purchaseState], message *paymentFailed) error {
+				sagaContext.Fail(message.Reason)
+
+				return nil
+			},
+		)).
+		Handles(Handle[purchaseState, *paymentTimedOut](
+			nil,
+			func(ctx context.Context, sagaContext *Context[purchaseState], message *paymentTimedOut) error {
+				sagaContext.Fail("payment timed out")
+
+				return nil
+			},
+		)).
+		Compensate("reserve-stock", func(ctx context.Context, sagaContext *Context[purchaseState]) error {
+			sagaContext.Send(&releaseStock{Message: newMessage(), OrderId: sagaContext.State().OrderId}, nil)
+
+			return nil
+		}).
+		Build()
+	require.NoError(t, err)
+
+	return definition
+}
+
+func newPurchaseSagaFixture(
+	t *testing.T,
+	paymentTimeout time.Duration,
+	payment func(sagaContext metadata.Metadata) types.IMessage,
+) *purchaseSagaFixture {
+	l := defaultLogger.GetLogger()
+	messageSerializer := json.NewDefaultMessageJsonSerializer(json.NewDefaultJsonSerializer())
+	harnesses := inMemory.NewRabbitmqInMemoryHarnesses(l, nil)
+	store := NewInMemorySagaStore()
+
+	manager := NewSagaManager(newPurchaseDefinition(t, paymentTimeout), store, harnesses, messageSerializer, l)
+	require.NoError(t, manager.ConnectTo(harnesses))
+
+	reply := func(reply func(meta metadata.Metadata) types.IMessage) serviceHandler {
+		return func(ctx context.Context, consumeContext types.MessageConsumeContext) error {
+			message := reply(consumeContext.Metadata())
+			if message == nil {
+				return nil
+			}
+
+			meta := metadata.Metadata{}
+			messageHeader.SetSagaId(meta, messageHeader.GetSagaId(consumeContext.Metadata()))
+
+			return harnesses.PublishMessage(ctx, message, meta)
+		}
+	}
+
+	require.NoError(t, harnesses.ConnectConsumerHandler(&reserveStock{}, reply(func(meta metadata.Metadata) types.IMessage {
+		return &stockReserved{Message: newMessage()}
+	})))
+	require.NoError(t, harnesses.ConnectConsumerHandler(&chargePayment{}, reply(payment)))
+	require.NoError(t, harnesses.ConnectConsumerHandler(&releaseStock{}, reply(func(meta metadata.Metadata) types.IMessage {
+		return nil
+	})))
+
+	require.NoError(t, harnesses.Start(context.Background()))
+	t.Cleanup(func() {
+		_ = harnesses.Stop()
+	})
+
+	return &purchaseSagaFixture{
+		harnesses:  harnesses,
+		store:      store,
+		manager:    manager,
+		dispatcher: NewTimeoutDispatcher(store, harnesses, messageSerializer, &SagaOptions{}, l),
+	}
+}
+
+func (f *purchaseSagaFixture) publishedOf(message types.IMessage) int {
+	count := 0
+	for _, published := range f.harnesses.PublishedMessages() {
+		if utils.GetMessageTypeName(published) == utils.GetMessageTypeName(message) {
+			count++
+		}
+	}
+
+	return count
+}
+
+func Test_Saga_Completes_All_Steps(t *testing.T) {
+	fixture := newPurchaseSagaFixture(t, -time.Second, func(meta metadata.Metadata) types.IMessage {
+		return &paymentCharged{Message: newMessage()}
+	})
+
+	err := fixture.harnesses.PublishMessage(
+		context.Background(),
+		&orderPlaced{Message: newMessage(), OrderId: "order-1", Amount: 20},
+		nil,
+	)
+	require.NoError(t, err)
+
+	instance, err := fixture.manager.Get(context.Background(), "order-1")
+	require.NoError(t, err)
+	assert.Equal(t, Completed, instance.Status)
+	assert.Equal(t, []string{"reserve-stock", "charge-payment"}, instance.CompletedSteps)
+	assert.Equal(t, &purchaseState{OrderId: "order-1", Amount: 20}, instance.State)
+	assert.Len(t, fixture.harnesses.FailedMessages(), 0)
+
+	// the timeout of a completed saga is ignored
+	require.NoError(t, fixture.dispatcher.DispatchDueTimeouts(context.Background()))
+
+	instance, err = fixture.manager.Get(context.Background(), "order-1")
+	require.NoError(t, err)
+	assert.Equal(t, Completed, instance.Status)
+	assert.Equal(t, 0, fixture.publishedOf(&releaseStock{}))
+}
+
+func Test_Saga_Compensates_Completed_Steps_On_Failure(t *testing.T) {
+	fixture := newPurchaseSagaFixture(t, time.Hour, func(meta metadata.Metadata) types.IMessage {
+		return &paymentFailed{Message: newMessage(), Reason: "card declined"}
+	})
+
+	err := fixture.harnesses.PublishMessage(
+		context.Background(),
+		&orderPlaced{Message: newMessage(), OrderId: "order-2", Amount: 20},
+		nil,
+	)
+	require.NoError(t, err)
+
+	instance, err := fixture.manager.Get(context.Background(), "order-2")
+	require.NoError(t, err)
+	assert.Equal(t, Compensated, instance.Status)
+	assert.Equal(t, "card declined", instance.FailureReason)
+	assert.Empty(t, instance.CompletedSteps)
+	assert.Equal(t, 1, fixture.publishedOf(&releaseStock{}))
+}
+
+func Test_Saga_Fails_On_Timeout(t *testing.T) {
+	fixture := newPurchaseSagaFixture(t, -time.Second, func(meta metadata.Metadata) types.IMessage {
+		// the payment service doesn't reply
+		return nil
+	})
+
+	err := fixture.harnesses.PublishMessage(
+		context.Background(),
+		&orderPlaced{Message: newMessage(), OrderId: "order-3", Amount: 20},
+		nil,
+	)
+	require.NoError(t, err)
+
+	instance, err := fixture.manager.Get(context.Background(), "order-3")
+	require.NoError(t, err)
+	assert.Equal(t, Running, instance.Status)
+
+	require.NoError(t, fixture.dispatcher.DispatchDueTimeouts(context.Background()))
+
+	instance, err = fixture.manager.Get(context.Background(), "order-3")
+	require.NoError(t, err)
+	assert.Equal(t, Compensated, instance.Status)
+	assert.Equal(t, "payment timed out", instance.FailureReason)
+	assert.Equal(t, 1, fixture.publishedOf(&releaseStock{}))
+
+	timeouts, err := fixture.store.ClaimDueTimeouts(context.Background(), time.Now(), 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, timeouts)
+}
+
+func Test_Timeout_Dispatcher_Dispatches_The_Other_Timeouts_After_A_Failed_Timeout(t *testing.T) {
+	l := defaultLogger.GetLogger()
+	messageSerializer := json.NewDefaultMessageJsonSerializer(json.NewDefaultJsonSerializer())
+	harnesses := inMemory.NewRabbitmqInMemoryHarnesses(l, nil)
+	store := NewInMemorySagaStore()
+	dispatcher := NewTimeoutDispatcher(store, harnesses, messageSerializer, &SagaOptions{}, l)
+
+	result, err := messageSerializer.Serialize(&paymentTimedOut{Message: newMessage()})
+	require.NoError(t, err)
+
+	poison := &StoredTimeout{
+		ID:          uuid.NewV4(),
+		SagaId:      "order-5",
+		SagaType:    "purchase",
+		MessageType: utils.GetMessageTypeName(&paymentTimedOut{}),
+		ContentType: result.ContentType,
+		Data:        "{not json",
+		DueAt:       time.Now().Add(-2 * time.Minute),
+	}
+	valid := &StoredTimeout{
+		ID:          uuid.NewV4(),
+		SagaId:      "order-6",
+		SagaType:    "purchase",
+		MessageType: utils.GetMessageTypeName(&paymentTimedOut{}),
+		ContentType: result.ContentType,
+		Data:        string(result.Data),
+		DueAt:       time.Now().Add(-time.Minute),
+	}
+	require.NoError(t, store.AddTimeout(context.Background(), poison))
+	require.NoError(t, store.AddTimeout(context.Background(), valid))
+
+	require.Error(t, dispatcher.DispatchDueTimeouts(context.Background()))
+	assert.Len(t, harnesses.PublishedMessages(), 1)
+
+	// the failed timeout is kept and claimed again after its claim duration
+	timeouts, err := store.ClaimDueTimeouts(context.Background(), time.Now(), 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, timeouts)
+
+	timeouts, err = store.ClaimDueTimeouts(context.Background(), time.Now().Add(time.Hour), 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, timeouts, 1)
+	assert.Equal(t, poison.ID, timeouts[0].ID)
+}
+
+func Test_Saga_Ignores_Messages_Without_Saga(t *testing.T) {
+	fixture := newPurchaseSagaFixture(t, time.Hour, func(meta metadata.Metadata) types.IMessage {
+		return nil
+	})
+
+	meta := metadata.Metadata{}
+	messageHeader.SetSagaId(meta, "unknown-order")
+
+	err := fixture.harnesses.PublishMessage(context.Background(), &paymentCharged{Message: newMessage()}, meta)
+	require.NoError(t, err)
+
+	_, err = fixture.manager.Get(context.Background(), "unknown-order")
+	assert.ErrorIs(t, err, ErrSagaNotFound)
+	assert.Len(t, fixture.harnesses.FailedMessages(), 0)
+}
+
+func Test_Saga_Store_Rejects_Stale_Version(t *testing.T) {
+	store := NewInMemorySagaStore()
+	ctx := context.Background()
+
+	stored := &StoredSaga{SagaId: "1", SagaType: "purchase", Status: Running}
+	require.NoError(t, store.Save(ctx, stored))
+	assert.Equal(t, 1, stored.Version)
+
+	stale := &StoredSaga{SagaId: "1", SagaType: "purchase", Status: Running}
+	assert.ErrorIs(t, store.Save(ctx, stale), ErrConcurrencyConflict)
+
+	require.NoError(t, store.Save(ctx, stored))
+	assert.Equal(t, 2, stored.Version)
+}
+
+func Test_Definition_Requires_Start_Message(t *testing.T) {
+	_, err := NewDefinitionBuilder[purchaseState]("purchase").
+		Handles(Handle[purchaseState, *paymentCharged](
+			nil,
+			func(ctx context.Context, sagaContext *Context[purchaseState], message *paymentCharged) error {
+				return nil
+			},
+		)).
+		Build()
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,51 @@
+package saga
+
+import (
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/config"
+	"github.com/reoden/go-NFT/pkg/config/environment"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[SagaOptions]())
+
+type SagaOptions struct {
+	// TimeoutPollingInterval interval of polling the store for the due timeouts in milliseconds
+	TimeoutPollingInterval int `mapstructure:"timeoutPollingInterval" default:"1000"`
+	// TimeoutBatchSize maximum number of the timeouts that are dispatched in each polling
+	TimeoutBatchSize int `mapstructure:"timeoutBatchSize"       default:"100"`
+	// TimeoutClaimDuration duration of the claim of the dispatched timeouts in milliseconds, a timeout that is not
+	// published in this duration is dispatched again
+	TimeoutClaimDuration int `mapstructure:"timeoutClaimDuration"   default:"30000"`
+}
+
+func (o *SagaOptions) TimeoutPollingIntervalDuration() time.Duration {
+	if o.TimeoutPollingInterval <= 0 {
+		return time.Second
+	}
+
+	return time.Duration(o.TimeoutPollingInterval) * time.Millisecond
+}
+
+func (o *SagaOptions) TimeoutBatchSizeOrDefault() int {
+	if o.TimeoutBatchSize <= 0 {
+		return 100
+	}
+
+	return o.TimeoutBatchSize
+}
+
+func (o *SagaOptions) TimeoutClaimDurationOrDefault() time.Duration {
+	if o.TimeoutClaimDuration <= 0 {
+		return 30 * time.Second
+	}
+
+	return time.Duration(o.TimeoutClaimDuration) * time.Millisecond
+}
+
+func ProvideSagaConfig(environment environment.Environment) (*SagaOptions, error) {
+	return config.BindConfigKey[*SagaOptions](optionName, environment)
+}
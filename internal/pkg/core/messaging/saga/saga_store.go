@@ -0,0 +1,70 @@
+package saga
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+var (
+	// ErrSagaNotFound there is no saga with the saga id
+	ErrSagaNotFound = errors.New("saga not found")
+	// ErrConcurrencyConflict the saga is changed by another message after it is loaded
+	ErrConcurrencyConflict = errors.New("saga is changed by another message")
+)
+
+// SagaStore persists the states and the scheduled timeouts of the sagas
+type SagaStore interface {
+	// Get returns the saga of the type with the saga id or ErrSagaNotFound
+	Get(ctx context.Context, sagaType string, sagaId string) (*StoredSaga, error)
+	// Save inserts the saga with version 0 and updates the saga with its loaded version, the version of the saga is
+	// increased on save and ErrConcurrencyConflict is returned when the stored version is changed
+	Save(ctx context.Context, saga *StoredSaga) error
+	AddTimeout(ctx context.Context, timeout *StoredTimeout) error
+	// ClaimDueTimeouts claims the timeouts that are due at the time in the order of their due time, a claimed timeout is
+	// not returned to the other dispatchers until the claim duration is passed
+	ClaimDueTimeouts(ctx context.Context, now time.Time, limit int, claimFor time.Duration) ([]*StoredTimeout, error)
+	RemoveTimeout(ctx context.Context, id uuid.UUID) error
+	// RunInTx runs the action in a transaction of the store, the sagas and the timeouts that are saved by the action are
+	// committed with the messages of an outbox producer
+	RunInTx(ctx context.Context, action func(ctx context.Context) error) error
+}
+
+// StoredSaga persisted state of a saga, the state and the completed steps are json serialized
+type StoredSaga struct {
+	SagaId         string `gorm:"primaryKey"`
+	SagaType       string `gorm:"primaryKey"`
+	Status         Status `gorm:"index"`
+	State          string
+	CompletedSteps string
+	FailureReason  string
+	Version        int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (s *StoredSaga) TableName() string {
+	return "sagas"
+}
+
+// StoredTimeout a scheduled timeout message of a saga, the message is published to the saga on its due time
+type StoredTimeout struct {
+	ID          uuid.UUID `gorm:"primaryKey"`
+	SagaId      string
+	SagaType    string
+	MessageType string
+	ContentType string
+	Data        string
+	// Metadata json serialized headers of the message
+	Metadata  string
+	DueAt     time.Time `gorm:"index"`
+	CreatedAt time.Time
+	// ClaimedUntil end of the claim of the dispatcher that is publishing the timeout
+	ClaimedUntil *time.Time
+}
+
+func (t *StoredTimeout) TableName() string {
+	return "saga_timeouts"
+}
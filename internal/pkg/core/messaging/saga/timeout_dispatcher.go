@@ -0,0 +1,111 @@
+package saga
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/core/messaging/producer"
+	"github.com/reoden/go-NFT/pkg/core/metadata"
+	"github.com/reoden/go-NFT/pkg/core/serializer"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/web"
+
+	"emperror.dev/errors"
+)
+
+// TimeoutDispatcher a background worker that publishes the due timeouts of the sagas
+type TimeoutDispatcher interface {
+	web.Worker
+	// DispatchDueTimeouts publishes the timeouts that are due now and removes them from the store, a failed timeout is
+	// dispatched again after its claim and doesn't stop the other timeouts
+	DispatchDueTimeouts(ctx context.Context) error
+}
+
+type timeoutDispatcher struct {
+	web.Worker
+	store             SagaStore
+	producer          producer.Producer
+	messageSerializer serializer.MessageSerializer
+	options           *SagaOptions
+}
+
+func NewTimeoutDispatcher(
+	store SagaStore,
+	producer producer.Producer,
+	messageSerializer serializer.MessageSerializer,
+	options *SagaOptions,
+	l logger.Logger,
+) TimeoutDispatcher {
+	d := &timeoutDispatcher{
+		store:             store,
+		producer:          producer,
+		messageSerializer: messageSerializer,
+		options:           options,
+	}
+
+	d.Worker = web.NewBackgroundWorker(
+		func(ctx context.Context) error {
+			ticker := time.NewTicker(options.TimeoutPollingIntervalDuration())
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-ticker.C:
+					if err := d.DispatchDueTimeouts(ctx); err != nil && ctx.Err() == nil {
+						l.Error(fmt.Sprintf("[TimeoutDispatcher.DispatchDueTimeouts] error in dispatching the saga timeouts: %v", err))
+					}
+				}
+			}
+		},
+		nil,
+	)
+
+	return d
+}
+
+func (d *timeoutDispatcher) DispatchDueTimeouts(ctx context.Context) error {
+	timeouts, err := d.store.ClaimDueTimeouts(
+		ctx,
+		time.Now(),
+		d.options.TimeoutBatchSizeOrDefault(),
+		d.options.TimeoutClaimDurationOrDefault(),
+	)
+	if err != nil {
+		return errors.WrapIf(err, "error in claiming the due saga timeouts")
+	}
+
+	var dispatchErr error
+	for _, timeout := range timeouts {
+		if err := d.dispatch(ctx, timeout); err != nil {
+			dispatchErr = errors.Append(
+				dispatchErr,
+				errors.WrapIff(err, "error in dispatching the timeout `%s` of the saga `%s`", timeout.ID, timeout.SagaId),
+			)
+		}
+	}
+
+	return dispatchErr
+}
+
+func (d *timeoutDispatcher) dispatch(ctx context.Context, timeout *StoredTimeout) error {
+	message, err := d.messageSerializer.Deserialize([]byte(timeout.Data), timeout.MessageType, timeout.ContentType)
+	if err != nil {
+		return err
+	}
+
+	meta := metadata.Metadata{}
+	if timeout.Metadata != "" {
+		if err := d.messageSerializer.Serializer().UnmarshalFromJson(timeout.Metadata, &meta); err != nil {
+			return err
+		}
+	}
+
+	if err := d.producer.PublishMessage(ctx, message, meta); err != nil {
+		return err
+	}
+
+	return d.store.RemoveTimeout(ctx, timeout.ID)
+}
@@ -0,0 +1,55 @@
+package scheduling
+
+import (
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/robfig/cron/v3"
+)
+
+// ErrScheduleNotFound there is no scheduled message with the schedule id
+var ErrScheduleNotFound = errors.New("schedule not found")
+
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// ScheduledMessage a message that is published on its due time, the recurring messages are published on each time of
+// their cron expression
+type ScheduledMessage struct {
+	ScheduleId  string `gorm:"primaryKey"`
+	MessageType string
+	ContentType string
+	Data        string
+	// Metadata json serialized headers of the message
+	Metadata string
+	// CronExpression the standard cron expression of a recurring message, it is empty for the one time messages
+	CronExpression string
+	DueAt          time.Time `gorm:"index"`
+	// LastPublishedAt the last time that a recurring message is published
+	LastPublishedAt *time.Time
+	// Attempts number of the failed dispatches of the message since its last publish, the due time of a failed message
+	// is moved back by the retry delay of the attempts
+	Attempts int
+	// LastError error of the last failed dispatch of the message
+	LastError string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (s *ScheduledMessage) TableName() string {
+	return "scheduled_messages"
+}
+
+// IsRecurring returns true when the message is published on a cron schedule
+func (s *ScheduledMessage) IsRecurring() bool {
+	return s.CronExpression != ""
+}
+
+// NextDueAt returns the first time of the cron expression after the given time
+func NextDueAt(cronExpression string, after time.Time) (time.Time, error) {
+	schedule, err := cronParser.Parse(cronExpression)
+	if err != nil {
+		return time.Time{}, errors.WrapIff(err, "invalid cron expression `%s`", cronExpression)
+	}
+
+	return schedule.Next(after), nil
+}
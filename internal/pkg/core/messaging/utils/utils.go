@@ -10,6 +10,12 @@ import (
 	"github.com/iancoleman/strcase"
 )
 
+// GetMessageTypeName returns the short type name of the message, the messages embed `*types.Message`, so their
+// GetMessageTypeName returns the name of the embedded type instead of the type of the message
+func GetMessageTypeName(message interface{}) string {
+	return typeMapper.GetTypeName(message)
+}
+
 func GetMessageName(message interface{}) string {
 	if reflect.TypeOf(message).Kind() == reflect.Pointer {
 		return strcase.ToSnake(reflect.TypeOf(message).Elem().Name())
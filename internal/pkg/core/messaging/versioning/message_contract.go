@@ -0,0 +1,196 @@
+package versioning
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+)
+
+// MessageContract the json schema of a message type in a schema version, the fields are the json paths of the payload
+// with the json kind of their values
+type MessageContract struct {
+	MessageType   string            `json:"messageType"`
+	SchemaVersion int               `json:"schemaVersion"`
+	Fields        map[string]string `json:"fields"`
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// DescribeContract returns the contract of the message with the json fields that the serializer writes for it
+func DescribeContract(message interface{}) *MessageContract {
+	contract := &MessageContract{
+		MessageType:   typeMapper.GetTypeName(message),
+		SchemaVersion: GetSchemaVersion(message),
+		Fields:        map[string]string{},
+	}
+
+	describeFields(reflect.TypeOf(message), "", contract.Fields, map[reflect.Type]bool{})
+
+	return contract
+}
+
+// CheckCompatibility returns the breaking changes of the current contract against the published contract, the removed
+// fields and the fields with a changed kind break the consumers of the published contract. The added fields are
+// compatible.
+func CheckCompatibility(published *MessageContract, current *MessageContract) []string {
+	var breakingChanges []string
+
+	for _, field := range sortedFields(published.Fields) {
+		currentKind, exists := current.Fields[field]
+		if !exists {
+			breakingChanges = append(breakingChanges, fmt.Sprintf("field `%s` is removed", field))
+			continue
+		}
+
+		if currentKind != published.Fields[field] {
+			breakingChanges = append(breakingChanges, fmt.Sprintf(
+				"kind of the field `%s` is changed from `%s` to `%s`",
+				field,
+				published.Fields[field],
+				currentKind,
+			))
+		}
+	}
+
+	return breakingChanges
+}
+
+// AddedFields returns the fields of the current contract that are not in the published contract
+func AddedFields(published *MessageContract, current *MessageContract) []string {
+	var added []string
+
+	for _, field := range sortedFields(current.Fields) {
+		if _, exists := published.Fields[field]; !exists {
+			added = append(added, field)
+		}
+	}
+
+	return added
+}
+
+func describeFields(typ reflect.Type, prefix string, fields map[string]string, visiting map[reflect.Type]bool) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct || visiting[typ] {
+		return
+	}
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		name, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		// the fields of the embedded structs without a json name are promoted to the parent object
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			describeFields(fieldType, prefix, fields, visiting)
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		path := prefix + name
+
+		kind := jsonKind(fieldType)
+		fields[path] = kind
+
+		switch kind {
+		case "object":
+			describeFields(fieldType, path+".", fields, visiting)
+		case "array":
+			elem := fieldType.Elem()
+			for elem.Kind() == reflect.Ptr {
+				elem = elem.Elem()
+			}
+			fields[path] = "array<" + jsonKind(elem) + ">"
+			if jsonKind(elem) == "object" {
+				describeFields(elem, path+"[].", fields, visiting)
+			}
+		}
+	}
+}
+
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	tag, ok := field.Tag.Lookup("json")
+	if !ok {
+		return "", false
+	}
+
+	name := strings.Split(tag, ",")[0]
+	if name == "-" {
+		return "", true
+	}
+
+	return name, false
+}
+
+func jsonKind(typ reflect.Type) string {
+	if typ == timeType {
+		return "string<date-time>"
+	}
+
+	// the custom json and text marshalers decide the json kind of their values, so their type name is the contract
+	if typ.Implements(jsonMarshalerType) || reflect.PointerTo(typ).Implements(jsonMarshalerType) ||
+		typ.Implements(textMarshalerType) || reflect.PointerTo(typ).Implements(textMarshalerType) {
+		return "custom<" + typ.String() + ">"
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return "string<base64>"
+		}
+		return "array"
+	case reflect.Map, reflect.Interface:
+		return "any"
+	case reflect.Struct:
+		return "object"
+	default:
+		return typ.Kind().String()
+	}
+}
+
+func sortedFields(fields map[string]string) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
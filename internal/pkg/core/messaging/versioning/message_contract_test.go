@@ -0,0 +1,61 @@
+//go:build unit
+// +build unit
+
+package versioning
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type shipment struct {
+	Carrier string    `json:"carrier"`
+	Sent    time.Time `json:"sent"`
+}
+
+type orderDelivered struct {
+	*types.Message
+	OrderId   string      `json:"orderId"`
+	Lines     []*shipment `json:"lines"`
+	Shipment  shipment    `json:"shipment"`
+	Ignored   string      `json:"-"`
+	Untagged  bool
+	unexposed string
+}
+
+func Test_Describe_Contract(t *testing.T) {
+	contract := DescribeContract(&orderDelivered{})
+
+	assert.Equal(t, "*orderDelivered", contract.MessageType)
+	assert.Equal(t, DefaultSchemaVersion, contract.SchemaVersion)
+	assert.Equal(t, map[string]string{
+		"messageId":        "string",
+		"created":          "string<date-time>",
+		"eventType":        "string",
+		"orderId":          "string",
+		"lines":            "array<object>",
+		"lines[].carrier":  "string",
+		"lines[].sent":     "string<date-time>",
+		"shipment":         "object",
+		"shipment.carrier": "string",
+		"shipment.sent":    "string<date-time>",
+		"Untagged":         "boolean",
+	}, contract.Fields)
+}
+
+func Test_Check_Compatibility(t *testing.T) {
+	published := &MessageContract{Fields: map[string]string{"orderId": "string", "quantity": "integer", "address": "string"}}
+	current := &MessageContract{Fields: map[string]string{"orderId": "string", "quantity": "string", "status": "string"}}
+
+	breakingChanges := CheckCompatibility(published, current)
+
+	assert.Equal(t, []string{
+		"field `address` is removed",
+		"kind of the field `quantity` is changed from `integer` to `string`",
+	}, breakingChanges)
+	assert.Equal(t, []string{"status"}, AddedFields(published, current))
+}
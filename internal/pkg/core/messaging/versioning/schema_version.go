@@ -0,0 +1,35 @@
+package versioning
+
+import (
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+)
+
+// DefaultSchemaVersion schema version of the messages that don't declare their version
+const DefaultSchemaVersion = 1
+
+// VersionedMessage a message contract that declares the version of its schema, the version should be increased on every
+// incompatible change of the contract together with an upcaster from the previous version
+type VersionedMessage interface {
+	SchemaVersion() int
+}
+
+// GetSchemaVersion returns the schema version of the message
+func GetSchemaVersion(message interface{}) int {
+	versioned, ok := message.(VersionedMessage)
+	if !ok || versioned.SchemaVersion() < DefaultSchemaVersion {
+		return DefaultSchemaVersion
+	}
+
+	return versioned.SchemaVersion()
+}
+
+// GetSchemaVersionByTypeName returns the current schema version of the registered message type
+func GetSchemaVersionByTypeName(messageType string) int {
+	message := typeMapper.EmptyInstanceByTypeNameAndImplementedInterface[types.IMessage](messageType)
+	if message == nil {
+		return DefaultSchemaVersion
+	}
+
+	return GetSchemaVersion(message)
+}
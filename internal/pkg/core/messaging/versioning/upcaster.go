@@ -0,0 +1,59 @@
+package versioning
+
+import (
+	"fmt"
+
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+
+	"go.uber.org/fx"
+)
+
+// Upcaster transforms the payload of a message type from a schema version to the next version
+type Upcaster interface {
+	// MessageType short type name of the message, like the type of the message headers
+	MessageType() string
+	// FromVersion the schema version of the payloads that the upcaster transforms to FromVersion + 1
+	FromVersion() int
+	Upcast(payload map[string]interface{}) (map[string]interface{}, error)
+}
+
+// UpcastFunc transforms the payload of a schema version to the next version
+type UpcastFunc func(payload map[string]interface{}) (map[string]interface{}, error)
+
+type upcaster struct {
+	messageType string
+	fromVersion int
+	upcast      UpcastFunc
+}
+
+// NewUpcaster creates an upcaster of the message type from the fromVersion to the next version
+func NewUpcaster(messageType string, fromVersion int, upcast UpcastFunc) Upcaster {
+	return &upcaster{messageType: messageType, fromVersion: fromVersion, upcast: upcast}
+}
+
+// NewUpcasterT creates an upcaster of the message T from the fromVersion to the next version
+func NewUpcasterT[T types.IMessage](fromVersion int, upcast UpcastFunc) Upcaster {
+	return NewUpcaster(typeMapper.GetGenericTypeNameByT[T](), fromVersion, upcast)
+}
+
+func (u *upcaster) MessageType() string {
+	return u.messageType
+}
+
+func (u *upcaster) FromVersion() int {
+	return u.fromVersion
+}
+
+func (u *upcaster) Upcast(payload map[string]interface{}) (map[string]interface{}, error) {
+	return u.upcast(payload)
+}
+
+// AsUpcaster annotates the upcaster constructor to register the upcaster in the upcasters group of the registry
+func AsUpcaster(upcaster interface{}) interface{} {
+	return fx.Annotate(
+		upcaster,
+		fx.As(new(Upcaster)),
+		fx.ResultTags(fmt.Sprintf(`group:"%s"`, UpcastersGroupName)),
+	)
+}
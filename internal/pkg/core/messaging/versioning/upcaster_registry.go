@@ -0,0 +1,124 @@
+package versioning
+
+import (
+	"sync"
+
+	"github.com/reoden/go-NFT/pkg/core/serializer"
+
+	"emperror.dev/errors"
+)
+
+// UpcastersGroupName fx group of the upcasters that are registered in the registry
+const UpcastersGroupName = "upcasters"
+
+// ErrMissingUpcaster there is no upcaster from a schema version of the message to its next version
+var ErrMissingUpcaster = errors.New("upcaster of the message schema version is not registered")
+
+// UpcasterRegistry keeps the upcasters of the message types and transforms the older payloads to the current schema
+// version of their message type before the deserialization
+type UpcasterRegistry interface {
+	Register(upcasters ...Upcaster) error
+	// Upcast transforms the payload of the schema version to the current version of the message type, the payloads of
+	// the current or a newer version are returned without changes
+	Upcast(data []byte, messageType string, version int) ([]byte, error)
+}
+
+type upcasterRegistry struct {
+	mu         sync.RWMutex
+	serializer serializer.Serializer
+	upcasters  map[string]map[int]Upcaster
+}
+
+func NewUpcasterRegistry(s serializer.Serializer, upcasters []Upcaster) (UpcasterRegistry, error) {
+	registry := &upcasterRegistry{serializer: s, upcasters: map[string]map[int]Upcaster{}}
+
+	if err := registry.Register(upcasters...); err != nil {
+		return nil, err
+	}
+
+	return registry, nil
+}
+
+func (r *upcasterRegistry) Register(upcasters ...Upcaster) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, upcaster := range upcasters {
+		if upcaster == nil {
+			continue
+		}
+
+		versions, exists := r.upcasters[upcaster.MessageType()]
+		if !exists {
+			versions = map[int]Upcaster{}
+			r.upcasters[upcaster.MessageType()] = versions
+		}
+
+		if _, exists := versions[upcaster.FromVersion()]; exists {
+			return errors.Errorf(
+				"upcaster of the message `%s` from the version `%d` is already registered",
+				upcaster.MessageType(),
+				upcaster.FromVersion(),
+			)
+		}
+		versions[upcaster.FromVersion()] = upcaster
+	}
+
+	return nil
+}
+
+func (r *upcasterRegistry) Upcast(data []byte, messageType string, version int) ([]byte, error) {
+	if len(data) == 0 {
+		return data, nil
+	}
+
+	// the messages without the version header are published before the versioning of the contracts
+	if version < DefaultSchemaVersion {
+		version = DefaultSchemaVersion
+	}
+
+	currentVersion := GetSchemaVersionByTypeName(messageType)
+	if version >= currentVersion {
+		return data, nil
+	}
+
+	payload := map[string]interface{}{}
+	if err := r.serializer.UnmarshalToMap(data, &payload); err != nil {
+		return nil, errors.WrapIff(err, "error in unmarshaling the payload of `%s` for upcasting", messageType)
+	}
+
+	r.mu.RLock()
+	versions := r.upcasters[messageType]
+	r.mu.RUnlock()
+
+	for ; version < currentVersion; version++ {
+		upcaster, exists := versions[version]
+		if !exists {
+			return nil, errors.WithMessagef(
+				ErrMissingUpcaster,
+				"message `%s` from the version `%d` to `%d`",
+				messageType,
+				version,
+				version+1,
+			)
+		}
+
+		upcasted, err := upcaster.Upcast(payload)
+		if err != nil {
+			return nil, errors.WrapIff(
+				err,
+				"error in upcasting the message `%s` from the version `%d`",
+				messageType,
+				version,
+			)
+		}
+		payload = upcasted
+	}
+
+	result, err := r.serializer.Marshal(payload)
+	if err != nil {
+		return nil, errors.WrapIff(err, "error in marshaling the upcasted payload of `%s`", messageType)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,81 @@
+//go:build unit
+// +build unit
+
+package versioning
+
+import (
+	"testing"
+
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	"github.com/reoden/go-NFT/pkg/core/serializer/json"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type orderShipped struct {
+	*types.Message
+	OrderId  string `json:"orderId"`
+	Address  string `json:"address"`
+	Quantity int    `json:"quantity"`
+}
+
+func (o *orderShipped) SchemaVersion() int {
+	return 3
+}
+
+func newRegistry(t *testing.T, upcasters ...Upcaster) UpcasterRegistry {
+	registry, err := NewUpcasterRegistry(json.NewDefaultJsonSerializer(), upcasters)
+	require.NoError(t, err)
+
+	return registry
+}
+
+func Test_Upcast_Runs_Upcasters_Of_The_Versions_In_Order(t *testing.T) {
+	registry := newRegistry(
+		t,
+		NewUpcasterT[*orderShipped](2, func(payload map[string]interface{}) (map[string]interface{}, error) {
+			payload["quantity"] = 1
+			return payload, nil
+		}),
+		NewUpcasterT[*orderShipped](1, func(payload map[string]interface{}) (map[string]interface{}, error) {
+			payload["address"] = payload["shippingAddress"]
+			delete(payload, "shippingAddress")
+			return payload, nil
+		}),
+	)
+
+	data, err := registry.Upcast([]byte(`{"orderId":"1","shippingAddress":"street"}`), "*orderShipped", 0)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"orderId":"1","address":"street","quantity":1}`, string(data))
+}
+
+func Test_Upcast_Returns_Current_Version_Without_Changes(t *testing.T) {
+	registry := newRegistry(t)
+	payload := []byte(`{"orderId":"1"}`)
+
+	data, err := registry.Upcast(payload, "*orderShipped", 3)
+	require.NoError(t, err)
+
+	assert.Equal(t, payload, data)
+}
+
+func Test_Upcast_Fails_Without_Upcaster_Of_A_Version(t *testing.T) {
+	registry := newRegistry(t)
+
+	_, err := registry.Upcast([]byte(`{"orderId":"1"}`), "*orderShipped", 2)
+
+	assert.ErrorIs(t, err, ErrMissingUpcaster)
+}
+
+func Test_Register_Fails_On_Duplicate_Upcaster(t *testing.T) {
+	upcast := func(payload map[string]interface{}) (map[string]interface{}, error) { return payload, nil }
+
+	_, err := NewUpcasterRegistry(
+		json.NewDefaultJsonSerializer(),
+		[]Upcaster{NewUpcasterT[*orderShipped](1, upcast), NewUpcasterT[*orderShipped](1, upcast)},
+	)
+
+	assert.Error(t, err)
+}
@@ -1,6 +1,7 @@
 package metadata
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/goccy/go-json"
@@ -15,6 +16,32 @@ func (m Metadata) GetString(key string) string {
 	return ""
 }
 
+// GetInt returns the integer value of the key, the integers of the transports come back with different sizes or as the
+// json numbers, so all of them are converted to int
+func (m Metadata) GetInt(key string) int {
+	switch val := m.Get(key).(type) {
+	case int:
+		return val
+	case int8:
+		return int(val)
+	case int16:
+		return int(val)
+	case int32:
+		return int(val)
+	case int64:
+		return int(val)
+	case float64:
+		return int(val)
+	case string:
+		i, err := strconv.Atoi(val)
+		if err == nil {
+			return i
+		}
+	}
+
+	return 0
+}
+
 func (m Metadata) GetTime(key string) time.Time {
 	val, ok := m.Get(key).(time.Time)
 	if ok {
@@ -0,0 +1,58 @@
+package serializer
+
+import (
+	"emperror.dev/errors"
+)
+
+// MessageSerializersGroupName fx group of the message serializers of the other content types than the default serializer
+const MessageSerializersGroupName = "message-serializers"
+
+// MessageSerializerRegistry keeps the message serializers of the content types, the producers serialize the messages
+// with the serializer of their configured content type and the consumers pick the deserializer by the content type
+// of the delivered messages
+type MessageSerializerRegistry interface {
+	// Default the serializer of the messages without a configured content type
+	Default() MessageSerializer
+	Get(contentType string) (MessageSerializer, error)
+}
+
+type messageSerializerRegistry struct {
+	defaultSerializer MessageSerializer
+	serializers       map[string]MessageSerializer
+}
+
+func NewMessageSerializerRegistry(
+	defaultSerializer MessageSerializer,
+	serializers []MessageSerializer,
+) MessageSerializerRegistry {
+	registry := &messageSerializerRegistry{
+		defaultSerializer: defaultSerializer,
+		serializers:       map[string]MessageSerializer{},
+	}
+
+	for _, serializer := range serializers {
+		if serializer != nil {
+			registry.serializers[serializer.ContentType()] = serializer
+		}
+	}
+	registry.serializers[defaultSerializer.ContentType()] = defaultSerializer
+
+	return registry
+}
+
+func (r *messageSerializerRegistry) Default() MessageSerializer {
+	return r.defaultSerializer
+}
+
+func (r *messageSerializerRegistry) Get(contentType string) (MessageSerializer, error) {
+	if contentType == "" {
+		return r.defaultSerializer, nil
+	}
+
+	serializer, exists := r.serializers[contentType]
+	if !exists {
+		return nil, errors.Errorf("there is no message serializer for the content type `%s`", contentType)
+	}
+
+	return serializer, nil
+}
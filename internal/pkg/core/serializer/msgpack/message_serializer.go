@@ -0,0 +1,130 @@
+package msgpack
+
+import (
+	"reflect"
+
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	"github.com/reoden/go-NFT/pkg/core/serializer"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+
+	"emperror.dev/errors"
+)
+
+type DefaultMessageMsgpackSerializer struct {
+	serializer serializer.Serializer
+}
+
+func NewDefaultMessageMsgpackSerializer() serializer.MessageSerializer {
+	return &DefaultMessageMsgpackSerializer{serializer: NewDefaultMsgpackSerializer()}
+}
+
+func (m *DefaultMessageMsgpackSerializer) Serialize(message types.IMessage) (*serializer.EventSerializationResult, error) {
+	return m.SerializeObject(message)
+}
+
+func (m *DefaultMessageMsgpackSerializer) SerializeObject(
+	message interface{},
+) (*serializer.EventSerializationResult, error) {
+	if message == nil {
+		return &serializer.EventSerializationResult{Data: nil, ContentType: m.ContentType()}, nil
+	}
+
+	// we use message short type name instead of full type name because this message in other receiver packages could have different package name
+	eventType := typeMapper.GetTypeName(message)
+
+	data, err := m.serializer.Marshal(message)
+	if err != nil {
+		return nil, errors.WrapIff(err, "error in Marshaling: `%s`", eventType)
+	}
+
+	result := &serializer.EventSerializationResult{Data: data, ContentType: m.ContentType()}
+
+	return result, nil
+}
+
+func (m *DefaultMessageMsgpackSerializer) SerializeEnvelop(
+	messageEnvelop types.MessageEnvelope,
+) (*serializer.EventSerializationResult, error) {
+	// the headers of the messages are sent as the headers of the transport, there is no msgpack envelope of the headers
+	return nil, errors.Errorf(
+		"serializing the envelope of `%T` is not supported by the msgpack serializer",
+		messageEnvelop.Message,
+	)
+}
+
+func (m *DefaultMessageMsgpackSerializer) Deserialize(
+	data []byte,
+	messageType string,
+	contentType string,
+) (types.IMessage, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	targetMessagePointer := typeMapper.EmptyInstanceByTypeNameAndImplementedInterface[types.IMessage](
+		messageType,
+	)
+
+	if targetMessagePointer == nil {
+		return nil, errors.Errorf("message type `%s` is not impelemted IMessage or can't be instansiated", messageType)
+	}
+
+	if contentType != m.ContentType() {
+		return nil, errors.Errorf("contentType: %s is not supported", contentType)
+	}
+
+	if err := m.serializer.Unmarshal(data, targetMessagePointer); err != nil {
+		return nil, errors.WrapIff(err, "error in Unmarshaling: `%s`", messageType)
+	}
+
+	return targetMessagePointer.(types.IMessage), nil
+}
+
+func (m *DefaultMessageMsgpackSerializer) DeserializeObject(
+	data []byte,
+	messageType string,
+	contentType string,
+) (interface{}, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	targetMessagePointer := typeMapper.InstanceByTypeName(messageType)
+
+	if targetMessagePointer == nil {
+		return nil, errors.Errorf("message type `%s` can't be instansiated", messageType)
+	}
+
+	if contentType != m.ContentType() {
+		return nil, errors.Errorf("contentType: %s is not supported", contentType)
+	}
+
+	if err := m.serializer.Unmarshal(data, targetMessagePointer); err != nil {
+		return nil, errors.WrapIff(err, "error in Unmarshaling: `%s`", messageType)
+	}
+
+	return targetMessagePointer, nil
+}
+
+func (m *DefaultMessageMsgpackSerializer) DeserializeType(
+	data []byte,
+	messageType reflect.Type,
+	contentType string,
+) (types.IMessage, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	// we use message short type name instead of full type name because this message in other receiver packages could have different package name
+	messageTypeName := typeMapper.GetTypeName(messageType)
+
+	return m.Deserialize(data, messageTypeName, contentType)
+}
+
+func (m *DefaultMessageMsgpackSerializer) ContentType() string {
+	return ContentType
+}
+
+func (m *DefaultMessageMsgpackSerializer) Serializer() serializer.Serializer {
+	return m.serializer
+}
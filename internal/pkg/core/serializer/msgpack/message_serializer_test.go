@@ -0,0 +1,84 @@
+//go:build unit
+// +build unit
+
+package msgpack
+
+import (
+	"testing"
+
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ArtworkDto struct {
+	Id    uuid.UUID       `json:"id"`
+	Name  string          `json:"name"`
+	Price decimal.Decimal `json:"price"`
+	Tags  []string        `json:"tags"`
+}
+
+type artworkListed struct {
+	*types.Message
+	*ArtworkDto
+}
+
+func Test_Serialize_Message(t *testing.T) {
+	messageSerializer := NewDefaultMessageMsgpackSerializer()
+	message := &artworkListed{
+		Message: types.NewMessage(uuid.NewV4().String()),
+		ArtworkDto: &ArtworkDto{
+			Id:    uuid.NewV4(),
+			Name:  "sunset",
+			Price: decimal.RequireFromString("12.50"),
+			Tags:  []string{"oil"},
+		},
+	}
+
+	result, err := messageSerializer.Serialize(message)
+	require.NoError(t, err)
+	assert.Equal(t, ContentType, result.ContentType)
+
+	deserialized, err := messageSerializer.Deserialize(result.Data, typeMapper.GetTypeName(message), ContentType)
+	require.NoError(t, err)
+
+	listed := deserialized.(*artworkListed)
+	assert.Equal(t, message.MessageId, listed.MessageId)
+	assert.Equal(t, message.Id, listed.Id)
+	assert.Equal(t, "sunset", listed.Name)
+	assert.True(t, message.Price.Equal(listed.Price))
+	assert.Equal(t, []string{"oil"}, listed.Tags)
+}
+
+func Test_Unmarshal_To_Map_Uses_Json_Names(t *testing.T) {
+	s := NewDefaultMsgpackSerializer()
+
+	data, err := s.Marshal(&ArtworkDto{Name: "sunset"})
+	require.NoError(t, err)
+
+	payload := map[string]interface{}{}
+	require.NoError(t, s.UnmarshalToMap(data, &payload))
+
+	assert.Equal(t, "sunset", payload["name"])
+}
+
+func Test_Deserialize_Rejects_Other_Content_Type(t *testing.T) {
+	messageSerializer := NewDefaultMessageMsgpackSerializer()
+
+	_, err := messageSerializer.Deserialize([]byte{0x80}, "*artworkListed", "application/json")
+
+	assert.Error(t, err)
+}
+
+func Test_Serialize_Envelop_Is_Not_Supported(t *testing.T) {
+	messageSerializer := NewDefaultMessageMsgpackSerializer()
+	message := &artworkListed{Message: types.NewMessage(uuid.NewV4().String())}
+
+	_, err := messageSerializer.SerializeEnvelop(*types.NewMessageEnvelope(message, nil))
+
+	assert.Error(t, err)
+}
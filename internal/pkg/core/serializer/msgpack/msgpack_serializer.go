@@ -0,0 +1,86 @@
+package msgpack
+
+import (
+	"bytes"
+
+	"github.com/reoden/go-NFT/pkg/core/serializer"
+	"github.com/reoden/go-NFT/pkg/core/serializer/json"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// ContentType content type of the MessagePack payloads
+const ContentType = "application/x-msgpack"
+
+type msgpackSerializer struct{}
+
+// NewDefaultMsgpackSerializer creates a MessagePack serializer, the fields are named by their json tags, so the same
+// types are serialized with the same field names as the json serializer
+func NewDefaultMsgpackSerializer() serializer.Serializer {
+	return &msgpackSerializer{}
+}
+
+// https://msgpack.uptrace.dev/guide/
+
+func (s *msgpackSerializer) Marshal(v interface{}) ([]byte, error) {
+	return Marshal(v)
+}
+
+func (s *msgpackSerializer) Unmarshal(data []byte, v interface{}) error {
+	return Unmarshal(data, v)
+}
+
+// UnmarshalFromJson unmarshals the json data, the MessagePack payloads are binary and can't be a json string
+func (s *msgpackSerializer) UnmarshalFromJson(data string, v interface{}) error {
+	return json.UnmarshalFromJSON(data, v)
+}
+
+func (s *msgpackSerializer) DecodeWithMapStructure(
+	input interface{},
+	output interface{},
+) error {
+	return json.DecodeWithMapStructure(input, output)
+}
+
+func (s *msgpackSerializer) UnmarshalToMap(
+	data []byte,
+	v *map[string]interface{},
+) error {
+	return Unmarshal(data, v)
+}
+
+func (s *msgpackSerializer) UnmarshalToMapFromJson(
+	data string,
+	v *map[string]interface{},
+) error {
+	return json.UnmarshalToMapFromJson(data, v)
+}
+
+// PrettyPrint print input object as a formatted json string
+func (s *msgpackSerializer) PrettyPrint(data interface{}) string {
+	return json.PrettyPrint(data)
+}
+
+// ColoredPrettyPrint print input object as a formatted json string with color
+func (s *msgpackSerializer) ColoredPrettyPrint(data interface{}) string {
+	return json.ColoredPrettyPrint(data)
+}
+
+func Marshal(v interface{}) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	encoder := msgpack.NewEncoder(&buffer)
+	encoder.SetCustomStructTag("json")
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func Unmarshal(data []byte, v interface{}) error {
+	decoder := msgpack.NewDecoder(bytes.NewReader(data))
+	decoder.SetCustomStructTag("json")
+
+	return decoder.Decode(v)
+}
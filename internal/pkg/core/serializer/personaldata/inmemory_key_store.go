@@ -0,0 +1,67 @@
+package personaldata
+
+import (
+	"context"
+	"crypto/rand"
+	"sync"
+
+	"emperror.dev/errors"
+)
+
+type inMemoryKeyStore struct {
+	mu   sync.RWMutex
+	keys map[string][]byte
+}
+
+// NewInMemoryKeyStore creates a key store in the memory for the tests
+func NewInMemoryKeyStore() KeyStore {
+	return &inMemoryKeyStore{keys: make(map[string][]byte)}
+}
+
+func (i *inMemoryKeyStore) GetOrCreateKey(ctx context.Context, subjectId string) ([]byte, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if key, ok := i.keys[subjectId]; ok {
+		return key, nil
+	}
+
+	key, err := NewKey()
+	if err != nil {
+		return nil, err
+	}
+	i.keys[subjectId] = key
+
+	return key, nil
+}
+
+func (i *inMemoryKeyStore) GetKey(ctx context.Context, subjectId string) ([]byte, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	key, ok := i.keys[subjectId]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+
+	return key, nil
+}
+
+func (i *inMemoryKeyStore) DeleteKey(ctx context.Context, subjectId string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	delete(i.keys, subjectId)
+
+	return nil
+}
+
+// NewKey generates a random key for a new subject
+func NewKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, errors.WrapIf(err, "error in generating the personal data key")
+	}
+
+	return key, nil
+}
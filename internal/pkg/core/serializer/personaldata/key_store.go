@@ -0,0 +1,24 @@
+package personaldata
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+)
+
+// ErrKeyNotFound returned when the subject has no key, the personal data of the subject is forgotten
+var ErrKeyNotFound = errors.New("personal data key not found")
+
+// KeySize size of the AES-256 keys of the subjects
+const KeySize = 32
+
+// KeyStore keeps the encryption keys of the personal data subjects, deleting the key of a subject makes its personal
+// data unreadable in all of the stored events without rewriting them
+type KeyStore interface {
+	// GetOrCreateKey returns the key of the subject and creates a new key for a new subject
+	GetOrCreateKey(ctx context.Context, subjectId string) ([]byte, error)
+	// GetKey returns the key of the subject or ErrKeyNotFound
+	GetKey(ctx context.Context, subjectId string) ([]byte, error)
+	// DeleteKey forgets the personal data of the subject, deleting a missing key is not an error
+	DeleteKey(ctx context.Context, subjectId string) error
+}
@@ -0,0 +1,364 @@
+package personaldata
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/reoden/go-NFT/pkg/core/domain"
+	"github.com/reoden/go-NFT/pkg/core/serializer"
+
+	"emperror.dev/errors"
+)
+
+const jsonContentType = "application/json"
+
+// envelopeDataKey the json key of the encrypted fields
+const envelopeDataKey = "$personalData"
+
+// encryptedField the stored form of a personal data field, the subject is kept next to the encrypted value, so the
+// fields are decrypted without the event type
+type encryptedField struct {
+	Data    string `json:"$personalData"`
+	Subject string `json:"$subject"`
+}
+
+type personalDataEventSerializer struct {
+	serializer.EventSerializer
+	keyStore KeyStore
+}
+
+// NewPersonalDataEventSerializer decorates the json event serializer with the crypto-shredding of the personal data, the
+// fields tagged with `personalData:"true"`, also in the nested structs and their slices, are encrypted with AES-GCM by the
+// key of their subject on the serialize and decrypted on the deserialize. The fields of the forgotten subjects are deserialized with their zero values.
+func NewPersonalDataEventSerializer(
+	eventSerializer serializer.EventSerializer,
+	keyStore KeyStore,
+) serializer.EventSerializer {
+	return &personalDataEventSerializer{EventSerializer: eventSerializer, keyStore: keyStore}
+}
+
+// Serializer returns the serializer of the wrapped event serializer with the crypto-shredding of the personal data, the
+// objects without a subject field use their `aggregate_id` field as the subject like the events
+func (p *personalDataEventSerializer) Serializer() serializer.Serializer {
+	return &personalDataSerializer{Serializer: p.EventSerializer.Serializer(), eventSerializer: p}
+}
+
+func (p *personalDataEventSerializer) Serialize(
+	event domain.IDomainEvent,
+) (*serializer.EventSerializationResult, error) {
+	return p.SerializeObject(event)
+}
+
+func (p *personalDataEventSerializer) SerializeObject(event interface{}) (*serializer.EventSerializationResult, error) {
+	result, err := p.EventSerializer.SerializeObject(event)
+	if err != nil || event == nil || result.ContentType != jsonContentType {
+		return result, err
+	}
+
+	fields := fieldsOf(reflect.TypeOf(event))
+	if fields == nil {
+		return result, nil
+	}
+
+	data, err := p.encrypt(result.Data, fields)
+	if err != nil {
+		return nil, errors.WrapIff(err, "error in encrypting the personal data of `%T`", event)
+	}
+
+	return &serializer.EventSerializationResult{Data: data, ContentType: result.ContentType}, nil
+}
+
+func (p *personalDataEventSerializer) Deserialize(
+	data []byte,
+	eventType string,
+	contentType string,
+) (domain.IDomainEvent, error) {
+	data, err := p.decrypt(data, contentType)
+	if err != nil {
+		return nil, errors.WrapIff(err, "error in decrypting the personal data of `%s`", eventType)
+	}
+
+	return p.EventSerializer.Deserialize(data, eventType, contentType)
+}
+
+func (p *personalDataEventSerializer) DeserializeObject(
+	data []byte,
+	eventType string,
+	contentType string,
+) (interface{}, error) {
+	data, err := p.decrypt(data, contentType)
+	if err != nil {
+		return nil, errors.WrapIff(err, "error in decrypting the personal data of `%s`", eventType)
+	}
+
+	return p.EventSerializer.DeserializeObject(data, eventType, contentType)
+}
+
+func (p *personalDataEventSerializer) DeserializeType(
+	data []byte,
+	eventType reflect.Type,
+	contentType string,
+) (domain.IDomainEvent, error) {
+	data, err := p.decrypt(data, contentType)
+	if err != nil {
+		return nil, errors.WrapIff(err, "error in decrypting the personal data of `%s`", eventType.String())
+	}
+
+	return p.EventSerializer.DeserializeType(data, eventType, contentType)
+}
+
+func (p *personalDataEventSerializer) encrypt(data []byte, fields *personalDataFields) ([]byte, error) {
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+
+	subject, err := subjectOf(values, fields.subject)
+	if err != nil {
+		return nil, err
+	}
+
+	// the serializer has no context, the keys are read with a background context
+	key, err := p.keyStore.GetOrCreateKey(context.Background(), subject)
+	if err != nil {
+		return nil, errors.WrapIff(err, "error in getting the key of subject `%s`", subject)
+	}
+
+	sealField := func(value json.RawMessage) (json.RawMessage, error) {
+		sealed, err := seal(key, subject, value)
+		if err != nil {
+			return nil, err
+		}
+
+		return json.Marshal(&encryptedField{Data: sealed, Subject: subject})
+	}
+
+	for _, path := range fields.fields {
+		data, err = sealPath(data, path, sealField)
+		if err != nil {
+			return nil, errors.WrapIff(err, "error in encrypting the field `%s`", strings.Join(path, "."))
+		}
+	}
+
+	return data, nil
+}
+
+// sealPath seals the values at the path of the json value, the `*` segments walk all the elements of the arrays and all
+// the values of the objects. The missing and the null values are left as they are.
+func sealPath(
+	value json.RawMessage,
+	path []string,
+	sealField func(value json.RawMessage) (json.RawMessage, error),
+) (json.RawMessage, error) {
+	trimmed := bytes.TrimSpace(value)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return value, nil
+	}
+	if len(path) == 0 {
+		return sealField(trimmed)
+	}
+
+	switch {
+	case trimmed[0] == '[' && path[0] == eachElement:
+		var elements []json.RawMessage
+		if err := json.Unmarshal(trimmed, &elements); err != nil {
+			return nil, err
+		}
+		for i, element := range elements {
+			sealed, err := sealPath(element, path[1:], sealField)
+			if err != nil {
+				return nil, err
+			}
+			elements[i] = sealed
+		}
+
+		return json.Marshal(elements)
+	case trimmed[0] == '{':
+		var members map[string]json.RawMessage
+		if err := json.Unmarshal(trimmed, &members); err != nil {
+			return nil, err
+		}
+		for name, member := range members {
+			if path[0] != eachElement && path[0] != name {
+				continue
+			}
+			sealed, err := sealPath(member, path[1:], sealField)
+			if err != nil {
+				return nil, err
+			}
+			members[name] = sealed
+		}
+
+		return json.Marshal(members)
+	default:
+		return value, nil
+	}
+}
+
+func (p *personalDataEventSerializer) decrypt(data []byte, contentType string) ([]byte, error) {
+	// the events without personal data are passed as they are
+	if contentType != jsonContentType || !bytes.Contains(data, []byte(envelopeDataKey)) {
+		return data, nil
+	}
+
+	plain, _, err := p.open(data, map[string][]byte{})
+
+	return plain, err
+}
+
+// open decrypts the encrypted fields in the json value and its nested objects and arrays, forgotten is true for an
+// encrypted field of a forgotten subject
+func (p *personalDataEventSerializer) open(
+	value json.RawMessage,
+	keys map[string][]byte,
+) (plain json.RawMessage, forgotten bool, err error) {
+	trimmed := bytes.TrimSpace(value)
+	if !bytes.Contains(trimmed, []byte(envelopeDataKey)) {
+		return value, false, nil
+	}
+
+	if encrypted, ok := asEncryptedField(trimmed); ok {
+		key, found := keys[encrypted.Subject]
+		if !found {
+			key, err = p.keyStore.GetKey(context.Background(), encrypted.Subject)
+			if err != nil && !errors.Is(err, ErrKeyNotFound) {
+				return nil, false, errors.WrapIff(err, "error in getting the key of subject `%s`", encrypted.Subject)
+			}
+			keys[encrypted.Subject] = key
+		}
+
+		// the subject is forgotten, the field gets its zero value
+		if key == nil {
+			return nil, true, nil
+		}
+
+		plain, err = open(key, encrypted.Subject, encrypted.Data)
+
+		return plain, false, err
+	}
+
+	switch trimmed[0] {
+	case '{':
+		var members map[string]json.RawMessage
+		if err := json.Unmarshal(trimmed, &members); err != nil {
+			return nil, false, err
+		}
+		for name, member := range members {
+			plainMember, forgottenMember, err := p.open(member, keys)
+			if err != nil {
+				return nil, false, errors.WrapIff(err, "error in decrypting the field `%s`", name)
+			}
+			if forgottenMember {
+				delete(members, name)
+				continue
+			}
+			members[name] = plainMember
+		}
+
+		plain, err = json.Marshal(members)
+
+		return plain, false, err
+	case '[':
+		var elements []json.RawMessage
+		if err := json.Unmarshal(trimmed, &elements); err != nil {
+			return nil, false, err
+		}
+		for i, element := range elements {
+			plainElement, forgottenElement, err := p.open(element, keys)
+			if err != nil {
+				return nil, false, err
+			}
+			// the elements of an array keep their positions
+			if forgottenElement {
+				plainElement = json.RawMessage("null")
+			}
+			elements[i] = plainElement
+		}
+
+		plain, err = json.Marshal(elements)
+
+		return plain, false, err
+	default:
+		return value, false, nil
+	}
+}
+
+func asEncryptedField(value json.RawMessage) (*encryptedField, bool) {
+	trimmed := bytes.TrimSpace(value)
+	if len(trimmed) == 0 || trimmed[0] != '{' || !bytes.Contains(trimmed, []byte(envelopeDataKey)) {
+		return nil, false
+	}
+
+	encrypted := &encryptedField{}
+	if err := json.Unmarshal(trimmed, encrypted); err != nil || encrypted.Data == "" || encrypted.Subject == "" {
+		return nil, false
+	}
+
+	return encrypted, true
+}
+
+func subjectOf(values map[string]json.RawMessage, subjectField string) (string, error) {
+	value, ok := values[subjectField]
+	if !ok {
+		return "", errors.Errorf("the personal data subject field `%s` is missing", subjectField)
+	}
+
+	var subject string
+	if err := json.Unmarshal(value, &subject); err != nil {
+		// the non-string ids like the numbers are used with their json text
+		subject = string(bytes.TrimSpace(value))
+	}
+	if subject == "" || subject == "null" {
+		return "", errors.Errorf("the personal data subject field `%s` is empty", subjectField)
+	}
+
+	return subject, nil
+}
+
+// seal encrypts the value with AES-GCM, the subject is the additional data, so a value can't be moved to another subject
+func seal(key []byte, subject string, value []byte) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", errors.WrapIf(err, "error in generating the nonce")
+	}
+
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, value, []byte(subject))), nil
+}
+
+func open(key []byte, subject string, sealed string) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("the encrypted value is too short")
+	}
+
+	return gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], []byte(subject))
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.WrapIf(err, "invalid personal data key")
+	}
+
+	return cipher.NewGCM(block)
+}
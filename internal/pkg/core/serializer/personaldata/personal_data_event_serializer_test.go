@@ -0,0 +1,218 @@
+//go:build unit
+// +build unit
+
+package personaldata
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reoden/go-NFT/pkg/core/domain"
+	"github.com/reoden/go-NFT/pkg/core/serializer/json"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type address struct {
+	City   string `json:"city"`
+	Street string `json:"street"`
+}
+
+type customerRegistered struct {
+	*domain.DomainEvent
+	CustomerId string   `json:"customerId" personalData:"subject"`
+	Name       string   `json:"name"       personalData:"true"`
+	Email      string   `json:"email"      personalData:"true"`
+	Address    *address `json:"address"    personalData:"true"`
+	Plan       string   `json:"plan"`
+}
+
+type customerEmailChanged struct {
+	*domain.DomainEvent
+	Email string `json:"email" personalData:"true"`
+}
+
+type contact struct {
+	Phone string `json:"phone" personalData:"true"`
+	Label string `json:"label"`
+}
+
+type customerContactsChanged struct {
+	*domain.DomainEvent
+	CustomerId string     `json:"customerId" personalData:"subject"`
+	Primary    contact    `json:"primary"`
+	Backup     *contact   `json:"backup"`
+	Others     []*contact `json:"others"`
+}
+
+type planChanged struct {
+	*domain.DomainEvent
+	Plan string `json:"plan"`
+}
+
+func newCustomerRegistered(customerId string) *customerRegistered {
+	return &customerRegistered{
+		DomainEvent: domain.NewDomainEvent(typeMapper.GetTypeName(&customerRegistered{})),
+		CustomerId:  customerId,
+		Name:        "Jane Doe",
+		Email:       "jane@example.com",
+		Address:     &address{City: "Berlin", Street: "Main street 1"},
+		Plan:        "gold",
+	}
+}
+
+func Test_Personal_Data_Is_Encrypted_And_Decrypted(t *testing.T) {
+	keyStore := NewInMemoryKeyStore()
+	eventSerializer := NewPersonalDataEventSerializer(
+		json.NewDefaultEventJsonSerializer(json.NewDefaultJsonSerializer()),
+		keyStore,
+	)
+
+	event := newCustomerRegistered("customer-1")
+	result, err := eventSerializer.Serialize(event)
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(result.Data), "Jane Doe")
+	assert.NotContains(t, string(result.Data), "jane@example.com")
+	assert.NotContains(t, string(result.Data), "Berlin")
+	assert.Contains(t, string(result.Data), "gold")
+
+	deserialized, err := eventSerializer.Deserialize(
+		result.Data,
+		typeMapper.GetTypeName(event),
+		result.ContentType,
+	)
+	require.NoError(t, err)
+
+	registered := deserialized.(*customerRegistered)
+	assert.Equal(t, "Jane Doe", registered.Name)
+	assert.Equal(t, "jane@example.com", registered.Email)
+	assert.Equal(t, &address{City: "Berlin", Street: "Main street 1"}, registered.Address)
+	assert.Equal(t, "gold", registered.Plan)
+	assert.Equal(t, event.GetEventId(), registered.GetEventId())
+}
+
+func Test_Forgotten_Subject_Fields_Are_Deserialized_With_Zero_Values(t *testing.T) {
+	ctx := context.Background()
+	keyStore := NewInMemoryKeyStore()
+	eventSerializer := NewPersonalDataEventSerializer(
+		json.NewDefaultEventJsonSerializer(json.NewDefaultJsonSerializer()),
+		keyStore,
+	)
+
+	forgotten, err := eventSerializer.Serialize(newCustomerRegistered("customer-1"))
+	require.NoError(t, err)
+	kept, err := eventSerializer.Serialize(newCustomerRegistered("customer-2"))
+	require.NoError(t, err)
+
+	require.NoError(t, keyStore.DeleteKey(ctx, "customer-1"))
+
+	deserialized, err := eventSerializer.Deserialize(
+		forgotten.Data,
+		typeMapper.GetTypeName(&customerRegistered{}),
+		forgotten.ContentType,
+	)
+	require.NoError(t, err)
+
+	registered := deserialized.(*customerRegistered)
+	assert.Empty(t, registered.Name)
+	assert.Empty(t, registered.Email)
+	assert.Nil(t, registered.Address)
+	assert.Equal(t, "customer-1", registered.CustomerId)
+	assert.Equal(t, "gold", registered.Plan)
+
+	deserialized, err = eventSerializer.Deserialize(
+		kept.Data,
+		typeMapper.GetTypeName(&customerRegistered{}),
+		kept.ContentType,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "Jane Doe", deserialized.(*customerRegistered).Name)
+}
+
+func Test_Events_Without_Subject_Field_Use_Aggregate_Id(t *testing.T) {
+	ctx := context.Background()
+	keyStore := NewInMemoryKeyStore()
+	eventSerializer := NewPersonalDataEventSerializer(
+		json.NewDefaultEventJsonSerializer(json.NewDefaultJsonSerializer()),
+		keyStore,
+	)
+
+	aggregateId := uuid.NewV4()
+	event := &customerEmailChanged{
+		DomainEvent: domain.NewDomainEvent(typeMapper.GetTypeName(&customerEmailChanged{})),
+		Email:       "jane@example.com",
+	}
+	event.WithAggregate(aggregateId, 1)
+
+	result, err := eventSerializer.Serialize(event)
+	require.NoError(t, err)
+	assert.NotContains(t, string(result.Data), "jane@example.com")
+
+	_, err = keyStore.GetKey(ctx, aggregateId.String())
+	require.NoError(t, err)
+
+	// the events without personal data are not changed
+	plain := &planChanged{DomainEvent: domain.NewDomainEvent(typeMapper.GetTypeName(&planChanged{})), Plan: "gold"}
+	plainResult, err := eventSerializer.Serialize(plain)
+	require.NoError(t, err)
+
+	expected, err := json.NewDefaultEventJsonSerializer(json.NewDefaultJsonSerializer()).Serialize(plain)
+	require.NoError(t, err)
+	assert.Equal(t, expected.Data, plainResult.Data)
+}
+
+func Test_Personal_Data_Of_Nested_Structs_Is_Encrypted_And_Decrypted(t *testing.T) {
+	ctx := context.Background()
+	keyStore := NewInMemoryKeyStore()
+	eventSerializer := NewPersonalDataEventSerializer(
+		json.NewDefaultEventJsonSerializer(json.NewDefaultJsonSerializer()),
+		keyStore,
+	)
+
+	event := &customerContactsChanged{
+		DomainEvent: domain.NewDomainEvent(typeMapper.GetTypeName(&customerContactsChanged{})),
+		CustomerId:  "customer-1",
+		Primary:     contact{Phone: "+49 111", Label: "home"},
+		Backup:      &contact{Phone: "+49 222", Label: "work"},
+		Others:      []*contact{{Phone: "+49 333", Label: "mobile"}, nil},
+	}
+
+	result, err := eventSerializer.Serialize(event)
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(result.Data), "+49 111")
+	assert.NotContains(t, string(result.Data), "+49 222")
+	assert.NotContains(t, string(result.Data), "+49 333")
+	assert.Contains(t, string(result.Data), "home")
+	assert.Contains(t, string(result.Data), "mobile")
+
+	deserialized, err := eventSerializer.Deserialize(
+		result.Data,
+		typeMapper.GetTypeName(event),
+		result.ContentType,
+	)
+	require.NoError(t, err)
+
+	changed := deserialized.(*customerContactsChanged)
+	assert.Equal(t, event.Primary, changed.Primary)
+	assert.Equal(t, event.Backup, changed.Backup)
+	assert.Equal(t, event.Others, changed.Others)
+
+	require.NoError(t, keyStore.DeleteKey(ctx, "customer-1"))
+
+	deserialized, err = eventSerializer.Deserialize(
+		result.Data,
+		typeMapper.GetTypeName(event),
+		result.ContentType,
+	)
+	require.NoError(t, err)
+
+	changed = deserialized.(*customerContactsChanged)
+	assert.Equal(t, contact{Label: "home"}, changed.Primary)
+	assert.Equal(t, &contact{Label: "work"}, changed.Backup)
+	assert.Equal(t, []*contact{{Label: "mobile"}, nil}, changed.Others)
+}
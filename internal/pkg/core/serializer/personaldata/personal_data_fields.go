@@ -0,0 +1,161 @@
+package personaldata
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// TagName the struct tag of the personal data fields, `personalData:"true"` marks a field as personal data and
+// `personalData:"subject"` marks the field that keeps the id of the data subject:
+//
+//	type UserRegistered struct {
+//		*domain.DomainEvent
+//		UserId string `json:"userId" personalData:"subject"`
+//		Email  string `json:"email"  personalData:"true"`
+//	}
+//
+// The events without a subject field use their aggregate id as the subject.
+const TagName = "personalData"
+
+const (
+	personalDataTagValue = "true"
+	subjectTagValue      = "subject"
+	// aggregateIdField the json field of the aggregate id of the domain events
+	aggregateIdField = "aggregate_id"
+)
+
+// eachElement the segment of a field path that selects every element of an array or every value of a map
+const eachElement = "*"
+
+// personalDataFields the json paths of the personal data fields of a type, a path has the json names of the fields from
+// the root object and the `*` segments of the arrays and the maps on the way
+type personalDataFields struct {
+	subject string
+	fields  [][]string
+}
+
+var fieldsCache sync.Map
+
+// fieldsOf returns the personal data fields of the type or nil for the types without personal data, the fields of the
+// embedded structs are flattened like the json encoding and the fields of the nested structs, their pointers, slices and
+// maps are collected with their paths. The subject is only read from the fields of the root object.
+func fieldsOf(typ reflect.Type) *personalDataFields {
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil
+	}
+
+	if cached, ok := fieldsCache.Load(typ); ok {
+		return cached.(*personalDataFields)
+	}
+
+	fields := &personalDataFields{}
+	collectFields(typ, nil, fields, map[reflect.Type]bool{})
+	if len(fields.fields) == 0 {
+		fields = nil
+	} else if fields.subject == "" {
+		fields.subject = aggregateIdField
+	}
+
+	fieldsCache.Store(typ, fields)
+
+	return fields
+}
+
+// collectFields collects the personal data fields of the struct under the path, the types on the path are visited once
+// so the recursive types end
+func collectFields(typ reflect.Type, path []string, fields *personalDataFields, visiting map[reflect.Type]bool) {
+	if visiting[typ] {
+		return
+	}
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		name, skip := jsonName(field)
+		if skip {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				collectFields(embedded, path, fields, visiting)
+			}
+
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldPath := append(append([]string{}, path...), name)
+
+		switch field.Tag.Get(TagName) {
+		case personalDataTagValue:
+			fields.fields = append(fields.fields, fieldPath)
+		case subjectTagValue:
+			if len(path) == 0 {
+				fields.subject = name
+			}
+		default:
+			// the whole value of a tagged field is encrypted, so only the untagged fields are walked
+			if nested, nestedPath := nestedStruct(field.Type, fieldPath); nested != nil {
+				collectFields(nested, nestedPath, fields, visiting)
+			}
+		}
+	}
+}
+
+// nestedStruct returns the struct behind the pointers, slices, arrays and maps of the field type with the path of its
+// fields, or nil for the other types
+func nestedStruct(typ reflect.Type, path []string) (reflect.Type, []string) {
+	for {
+		switch typ.Kind() {
+		case reflect.Ptr:
+			typ = typ.Elem()
+		case reflect.Slice, reflect.Array, reflect.Map:
+			// the byte slices are encoded as strings
+			if typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8 {
+				return nil, nil
+			}
+			typ = typ.Elem()
+			path = append(path, eachElement)
+		case reflect.Struct:
+			// the types with their own json encoding like the times are not walked
+			if typ.Implements(jsonMarshalerType) || reflect.PointerTo(typ).Implements(jsonMarshalerType) {
+				return nil, nil
+			}
+
+			return typ, path
+		default:
+			return nil, nil
+		}
+	}
+}
+
+// jsonName returns the name of the field in its json tag and whether the field is ignored by the json encoding
+func jsonName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+
+	name, _, _ := strings.Cut(tag, ",")
+
+	return name, false
+}
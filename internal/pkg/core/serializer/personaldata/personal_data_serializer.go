@@ -0,0 +1,48 @@
+package personaldata
+
+import (
+	"reflect"
+
+	"github.com/reoden/go-NFT/pkg/core/serializer"
+
+	"emperror.dev/errors"
+)
+
+// personalDataSerializer encrypts the personal data of the objects marshaled by the serializer of the event serializer,
+// like the snapshot states of the aggregates, so they are forgotten with the events of their subjects
+type personalDataSerializer struct {
+	serializer.Serializer
+	eventSerializer *personalDataEventSerializer
+}
+
+func (p *personalDataSerializer) Marshal(v interface{}) ([]byte, error) {
+	data, err := p.Serializer.Marshal(v)
+	if err != nil || v == nil || p.eventSerializer.ContentType() != jsonContentType {
+		return data, err
+	}
+
+	fields := fieldsOf(reflect.TypeOf(v))
+	if fields == nil {
+		return data, nil
+	}
+
+	data, err = p.eventSerializer.encrypt(data, fields)
+	if err != nil {
+		return nil, errors.WrapIff(err, "error in encrypting the personal data of `%T`", v)
+	}
+
+	return data, nil
+}
+
+func (p *personalDataSerializer) Unmarshal(data []byte, v interface{}) error {
+	data, err := p.eventSerializer.decrypt(data, p.eventSerializer.ContentType())
+	if err != nil {
+		return errors.WrapIff(err, "error in decrypting the personal data of `%T`", v)
+	}
+
+	return p.Serializer.Unmarshal(data, v)
+}
+
+func (p *personalDataSerializer) UnmarshalFromJson(data string, v interface{}) error {
+	return p.Unmarshal([]byte(data), v)
+}
@@ -0,0 +1,121 @@
+package protobuf
+
+import (
+	"reflect"
+
+	"github.com/reoden/go-NFT/pkg/core/domain"
+	"github.com/reoden/go-NFT/pkg/core/serializer"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+
+	"emperror.dev/errors"
+)
+
+type DefaultEventProtobufSerializer struct {
+	serializer serializer.Serializer
+}
+
+// NewDefaultEventProtobufSerializer creates a serializer of the events with a protobuf payload field
+func NewDefaultEventProtobufSerializer() serializer.EventSerializer {
+	return &DefaultEventProtobufSerializer{serializer: NewDefaultProtobufSerializer()}
+}
+
+func (s *DefaultEventProtobufSerializer) Serialize(
+	event domain.IDomainEvent,
+) (*serializer.EventSerializationResult, error) {
+	return s.SerializeObject(event)
+}
+
+func (s *DefaultEventProtobufSerializer) SerializeObject(event interface{}) (*serializer.EventSerializationResult, error) {
+	if event == nil {
+		return &serializer.EventSerializationResult{Data: nil, ContentType: s.ContentType()}, nil
+	}
+
+	// we use event short type name instead of full type name because this event in other receiver packages could have different package name
+	eventType := typeMapper.GetTypeName(event)
+
+	data, err := marshalEnvelope(event)
+	if err != nil {
+		return nil, errors.WrapIff(err, "error in Marshaling: `%s`", eventType)
+	}
+
+	result := &serializer.EventSerializationResult{Data: data, ContentType: s.ContentType()}
+
+	return result, nil
+}
+
+func (s *DefaultEventProtobufSerializer) Deserialize(
+	data []byte,
+	eventType string,
+	contentType string,
+) (domain.IDomainEvent, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	targetEventPointer := typeMapper.EmptyInstanceByTypeNameAndImplementedInterface[domain.IDomainEvent](
+		eventType,
+	)
+
+	if targetEventPointer == nil {
+		return nil, errors.Errorf("event type `%s` is not impelemted IDomainEvent or can't be instansiated", eventType)
+	}
+
+	if contentType != s.ContentType() {
+		return nil, errors.Errorf("contentType: %s is not supported", contentType)
+	}
+
+	if err := unmarshalEnvelope(data, targetEventPointer); err != nil {
+		return nil, errors.WrapIff(err, "error in Unmarshaling: `%s`", eventType)
+	}
+
+	return targetEventPointer.(domain.IDomainEvent), nil
+}
+
+func (s *DefaultEventProtobufSerializer) DeserializeObject(
+	data []byte,
+	eventType string,
+	contentType string,
+) (interface{}, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	targetEventPointer := typeMapper.InstanceByTypeName(eventType)
+
+	if targetEventPointer == nil {
+		return nil, errors.Errorf("event type `%s` can't be instansiated", eventType)
+	}
+
+	if contentType != s.ContentType() {
+		return nil, errors.Errorf("contentType: %s is not supported", contentType)
+	}
+
+	if err := unmarshalEnvelope(data, targetEventPointer); err != nil {
+		return nil, errors.WrapIff(err, "error in Unmarshaling: `%s`", eventType)
+	}
+
+	return targetEventPointer, nil
+}
+
+func (s *DefaultEventProtobufSerializer) DeserializeType(
+	data []byte,
+	eventType reflect.Type,
+	contentType string,
+) (domain.IDomainEvent, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	// we use event short type name instead of full type name because this event in other receiver packages could have different package name
+	eventTypeName := typeMapper.GetTypeName(eventType)
+
+	return s.Deserialize(data, eventTypeName, contentType)
+}
+
+func (s *DefaultEventProtobufSerializer) ContentType() string {
+	return ContentType
+}
+
+func (s *DefaultEventProtobufSerializer) Serializer() serializer.Serializer {
+	return s.serializer
+}
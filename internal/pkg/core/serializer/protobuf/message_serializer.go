@@ -0,0 +1,132 @@
+package protobuf
+
+import (
+	"reflect"
+
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	"github.com/reoden/go-NFT/pkg/core/serializer"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+
+	"emperror.dev/errors"
+)
+
+type DefaultMessageProtobufSerializer struct {
+	serializer serializer.Serializer
+}
+
+// NewDefaultMessageProtobufSerializer creates a serializer of the messages with a protobuf payload, the payload is a
+// field of a generated protobuf type in the message, so the `api/protobuf` types can be embedded in the messages
+func NewDefaultMessageProtobufSerializer() serializer.MessageSerializer {
+	return &DefaultMessageProtobufSerializer{serializer: NewDefaultProtobufSerializer()}
+}
+
+func (m *DefaultMessageProtobufSerializer) Serialize(message types.IMessage) (*serializer.EventSerializationResult, error) {
+	return m.SerializeObject(message)
+}
+
+func (m *DefaultMessageProtobufSerializer) SerializeObject(
+	message interface{},
+) (*serializer.EventSerializationResult, error) {
+	if message == nil {
+		return &serializer.EventSerializationResult{Data: nil, ContentType: m.ContentType()}, nil
+	}
+
+	// we use message short type name instead of full type name because this message in other receiver packages could have different package name
+	eventType := typeMapper.GetTypeName(message)
+
+	data, err := marshalEnvelope(message)
+	if err != nil {
+		return nil, errors.WrapIff(err, "error in Marshaling: `%s`", eventType)
+	}
+
+	result := &serializer.EventSerializationResult{Data: data, ContentType: m.ContentType()}
+
+	return result, nil
+}
+
+func (m *DefaultMessageProtobufSerializer) SerializeEnvelop(
+	messageEnvelop types.MessageEnvelope,
+) (*serializer.EventSerializationResult, error) {
+	// the headers of the messages are sent as the headers of the transport, there is no protobuf envelope of the headers
+	return nil, errors.Errorf(
+		"serializing the envelope of `%T` is not supported by the protobuf serializer",
+		messageEnvelop.Message,
+	)
+}
+
+func (m *DefaultMessageProtobufSerializer) Deserialize(
+	data []byte,
+	messageType string,
+	contentType string,
+) (types.IMessage, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	targetMessagePointer := typeMapper.EmptyInstanceByTypeNameAndImplementedInterface[types.IMessage](
+		messageType,
+	)
+
+	if targetMessagePointer == nil {
+		return nil, errors.Errorf("message type `%s` is not impelemted IMessage or can't be instansiated", messageType)
+	}
+
+	if contentType != m.ContentType() {
+		return nil, errors.Errorf("contentType: %s is not supported", contentType)
+	}
+
+	if err := unmarshalEnvelope(data, targetMessagePointer); err != nil {
+		return nil, errors.WrapIff(err, "error in Unmarshaling: `%s`", messageType)
+	}
+
+	return targetMessagePointer.(types.IMessage), nil
+}
+
+func (m *DefaultMessageProtobufSerializer) DeserializeObject(
+	data []byte,
+	messageType string,
+	contentType string,
+) (interface{}, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	targetMessagePointer := typeMapper.InstanceByTypeName(messageType)
+
+	if targetMessagePointer == nil {
+		return nil, errors.Errorf("message type `%s` can't be instansiated", messageType)
+	}
+
+	if contentType != m.ContentType() {
+		return nil, errors.Errorf("contentType: %s is not supported", contentType)
+	}
+
+	if err := unmarshalEnvelope(data, targetMessagePointer); err != nil {
+		return nil, errors.WrapIff(err, "error in Unmarshaling: `%s`", messageType)
+	}
+
+	return targetMessagePointer, nil
+}
+
+func (m *DefaultMessageProtobufSerializer) DeserializeType(
+	data []byte,
+	messageType reflect.Type,
+	contentType string,
+) (types.IMessage, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	// we use message short type name instead of full type name because this message in other receiver packages could have different package name
+	messageTypeName := typeMapper.GetTypeName(messageType)
+
+	return m.Deserialize(data, messageTypeName, contentType)
+}
+
+func (m *DefaultMessageProtobufSerializer) ContentType() string {
+	return ContentType
+}
+
+func (m *DefaultMessageProtobufSerializer) Serializer() serializer.Serializer {
+	return m.serializer
+}
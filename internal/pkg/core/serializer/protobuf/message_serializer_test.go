@@ -0,0 +1,90 @@
+//go:build unit
+// +build unit
+
+package protobuf
+
+import (
+	"testing"
+
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type artworkRenamed struct {
+	*types.Message
+	*wrapperspb.StringValue
+}
+
+type artworkPriced struct {
+	*types.Message
+	Price *wrapperspb.DoubleValue
+}
+
+type artworkWithoutPayload struct {
+	*types.Message
+	Name string
+}
+
+var messageSerializer = NewDefaultMessageProtobufSerializer()
+
+func Test_Serialize_Message_With_Embedded_Payload(t *testing.T) {
+	message := &artworkRenamed{Message: types.NewMessage(uuid.NewV4().String()), StringValue: wrapperspb.String("sunset")}
+
+	result, err := messageSerializer.Serialize(message)
+	require.NoError(t, err)
+	assert.Equal(t, ContentType, result.ContentType)
+
+	deserialized, err := messageSerializer.Deserialize(result.Data, typeMapper.GetTypeName(message), ContentType)
+	require.NoError(t, err)
+
+	renamed := deserialized.(*artworkRenamed)
+	assert.Equal(t, message.MessageId, renamed.MessageId)
+	assert.True(t, message.Created.Equal(renamed.Created))
+	assert.Equal(t, "sunset", renamed.StringValue.GetValue())
+}
+
+func Test_Serialize_Message_With_Payload_Field(t *testing.T) {
+	message := &artworkPriced{Message: types.NewMessage(uuid.NewV4().String()), Price: wrapperspb.Double(12.5)}
+
+	result, err := messageSerializer.Serialize(message)
+	require.NoError(t, err)
+
+	deserialized, err := messageSerializer.Deserialize(result.Data, typeMapper.GetTypeName(message), ContentType)
+	require.NoError(t, err)
+
+	priced := deserialized.(*artworkPriced)
+	assert.Equal(t, message.MessageId, priced.MessageId)
+	assert.Equal(t, 12.5, priced.Price.GetValue())
+}
+
+func Test_Serialize_Message_Without_Payload_Fails(t *testing.T) {
+	_, err := messageSerializer.Serialize(&artworkWithoutPayload{Message: types.NewMessage(uuid.NewV4().String())})
+
+	assert.Error(t, err)
+}
+
+func Test_Serialize_Envelop_Is_Not_Supported(t *testing.T) {
+	message := &artworkWithoutPayload{Message: types.NewMessage(uuid.NewV4().String())}
+
+	_, err := messageSerializer.SerializeEnvelop(*types.NewMessageEnvelope(message, nil))
+
+	assert.Error(t, err)
+}
+
+func Test_Serialize_Generated_Protobuf_Object(t *testing.T) {
+	timestamp := timestamppb.Now()
+
+	result, err := messageSerializer.SerializeObject(timestamp)
+	require.NoError(t, err)
+
+	deserialized, err := messageSerializer.DeserializeObject(result.Data, "*Timestamp", ContentType)
+	require.NoError(t, err)
+
+	assert.True(t, timestamp.AsTime().Equal(deserialized.(*timestamppb.Timestamp).AsTime()))
+}
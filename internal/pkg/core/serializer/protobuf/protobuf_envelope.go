@@ -0,0 +1,164 @@
+package protobuf
+
+import (
+	"reflect"
+
+	"github.com/reoden/go-NFT/pkg/core/serializer/json"
+
+	"emperror.dev/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// the messages and the events carry their protobuf payload in a field of a generated protobuf type, like the embedded
+// types of the `api/protobuf` definitions. The envelope keeps the other fields of the message like the message id
+// beside the protobuf payload.
+const (
+	envelopeHeaderField  protowire.Number = 1
+	envelopePayloadField protowire.Number = 2
+)
+
+var protoMessageType = reflect.TypeOf((*proto.Message)(nil)).Elem()
+
+// marshalEnvelope writes the json of the fields without the payload and the protobuf payload of the value
+func marshalEnvelope(v interface{}) ([]byte, error) {
+	if message, ok := generatedMessage(v); ok {
+		payload, err := proto.Marshal(message)
+		if err != nil {
+			return nil, err
+		}
+
+		return protowire.AppendBytes(protowire.AppendTag(nil, envelopePayloadField, protowire.BytesType), payload), nil
+	}
+
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return nil, errors.Errorf("type `%T` is not a pointer of a struct", v)
+	}
+
+	fieldIndex := payloadField(value.Type())
+	if fieldIndex < 0 {
+		return nil, errors.Errorf("type `%T` doesn't have a protobuf payload field", v)
+	}
+
+	// the header is the copy of the value without the payload
+	header := reflect.New(value.Elem().Type())
+	header.Elem().Set(value.Elem())
+	header.Elem().Field(fieldIndex).Set(reflect.Zero(value.Elem().Field(fieldIndex).Type()))
+
+	headerData, err := json.Marshal(header.Interface())
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in marshaling the envelope header")
+	}
+
+	var payload []byte
+	if field := value.Elem().Field(fieldIndex); !field.IsNil() {
+		payload, err = proto.Marshal(field.Interface().(proto.Message))
+		if err != nil {
+			return nil, errors.WrapIf(err, "error in marshaling the protobuf payload")
+		}
+	}
+
+	data := protowire.AppendTag(nil, envelopeHeaderField, protowire.BytesType)
+	data = protowire.AppendBytes(data, headerData)
+	data = protowire.AppendTag(data, envelopePayloadField, protowire.BytesType)
+	data = protowire.AppendBytes(data, payload)
+
+	return data, nil
+}
+
+// unmarshalEnvelope reads the fields and the protobuf payload of the envelope to the pointer v
+func unmarshalEnvelope(data []byte, v interface{}) error {
+	var headerData, payload []byte
+
+	for len(data) > 0 {
+		number, wireType, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return errors.WrapIf(protowire.ParseError(n), "error in reading the envelope")
+		}
+		data = data[n:]
+
+		if wireType != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(number, wireType, data)
+			if n < 0 {
+				return errors.WrapIf(protowire.ParseError(n), "error in reading the envelope")
+			}
+			data = data[n:]
+			continue
+		}
+
+		value, n := protowire.ConsumeBytes(data)
+		if n < 0 {
+			return errors.WrapIf(protowire.ParseError(n), "error in reading the envelope")
+		}
+		data = data[n:]
+
+		switch number {
+		case envelopeHeaderField:
+			headerData = value
+		case envelopePayloadField:
+			payload = value
+		}
+	}
+
+	if message, ok := generatedMessage(v); ok {
+		return proto.Unmarshal(payload, message)
+	}
+
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return errors.Errorf("type `%T` is not a pointer of a struct", v)
+	}
+
+	fieldIndex := payloadField(value.Type())
+	if fieldIndex < 0 {
+		return errors.Errorf("type `%T` doesn't have a protobuf payload field", v)
+	}
+
+	if len(headerData) > 0 {
+		if err := json.Unmarshal(headerData, v); err != nil {
+			return errors.WrapIf(err, "error in unmarshaling the envelope header")
+		}
+	}
+
+	field := value.Elem().Field(fieldIndex)
+	message := reflect.New(field.Type().Elem())
+	if err := proto.Unmarshal(payload, message.Interface().(proto.Message)); err != nil {
+		return errors.WrapIf(err, "error in unmarshaling the protobuf payload")
+	}
+	field.Set(message)
+
+	return nil
+}
+
+// generatedMessage returns the value when it is a generated protobuf type, the types that embed a generated type have
+// its protobuf methods too, but their protobuf message is the embedded value
+func generatedMessage(v interface{}) (proto.Message, bool) {
+	message, ok := v.(proto.Message)
+	if !ok || reflect.ValueOf(v).IsNil() {
+		return nil, false
+	}
+
+	if reflect.TypeOf(message.ProtoReflect().Interface()) != reflect.TypeOf(v) {
+		return nil, false
+	}
+
+	return message, true
+}
+
+// payloadField returns the index of the exported field of the struct with a generated protobuf type
+func payloadField(typ reflect.Type) int {
+	if typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Struct {
+		return -1
+	}
+	typ = typ.Elem()
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.IsExported() && field.Type.Kind() == reflect.Ptr && field.Type.Implements(protoMessageType) {
+			return i
+		}
+	}
+
+	return -1
+}
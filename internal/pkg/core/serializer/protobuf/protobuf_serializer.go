@@ -0,0 +1,84 @@
+package protobuf
+
+import (
+	"github.com/reoden/go-NFT/pkg/core/serializer"
+	"github.com/reoden/go-NFT/pkg/core/serializer/json"
+
+	"emperror.dev/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// ContentType content type of the protobuf payloads
+const ContentType = "application/x-protobuf"
+
+type protobufSerializer struct{}
+
+// NewDefaultProtobufSerializer creates a serializer of the generated protobuf types, the other types are not supported
+func NewDefaultProtobufSerializer() serializer.Serializer {
+	return &protobufSerializer{}
+}
+
+func (s *protobufSerializer) Marshal(v interface{}) ([]byte, error) {
+	message, ok := v.(proto.Message)
+	if !ok {
+		return nil, errors.Errorf("type `%T` is not a protobuf message", v)
+	}
+
+	return proto.Marshal(message)
+}
+
+func (s *protobufSerializer) Unmarshal(data []byte, v interface{}) error {
+	message, ok := v.(proto.Message)
+	if !ok {
+		return errors.Errorf("type `%T` is not a protobuf message", v)
+	}
+
+	return proto.Unmarshal(data, message)
+}
+
+// UnmarshalFromJson unmarshals the protojson data of the protobuf messages
+func (s *protobufSerializer) UnmarshalFromJson(data string, v interface{}) error {
+	message, ok := v.(proto.Message)
+	if !ok {
+		return json.UnmarshalFromJSON(data, v)
+	}
+
+	return protojson.Unmarshal([]byte(data), message)
+}
+
+func (s *protobufSerializer) DecodeWithMapStructure(
+	input interface{},
+	output interface{},
+) error {
+	return json.DecodeWithMapStructure(input, output)
+}
+
+// UnmarshalToMap is not supported, the protobuf payloads don't have the field names without their schema
+func (s *protobufSerializer) UnmarshalToMap(
+	data []byte,
+	v *map[string]interface{},
+) error {
+	return errors.New("unmarshaling the protobuf payloads to a map is not supported")
+}
+
+func (s *protobufSerializer) UnmarshalToMapFromJson(
+	data string,
+	v *map[string]interface{},
+) error {
+	return json.UnmarshalToMapFromJson(data, v)
+}
+
+// PrettyPrint print input object as a formatted json string
+func (s *protobufSerializer) PrettyPrint(data interface{}) string {
+	if message, ok := data.(proto.Message); ok {
+		return protojson.MarshalOptions{Multiline: true, Indent: "    "}.Format(message)
+	}
+
+	return json.PrettyPrint(data)
+}
+
+// ColoredPrettyPrint print input object as a formatted json string with color
+func (s *protobufSerializer) ColoredPrettyPrint(data interface{}) string {
+	return json.ColoredPrettyPrint(data)
+}
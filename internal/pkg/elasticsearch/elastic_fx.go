@@ -1,6 +1,10 @@
 package elasticsearch
 
 import (
+	"fmt"
+
+	"github.com/reoden/go-NFT/pkg/health/contracts"
+
 	"go.uber.org/fx"
 )
 
@@ -9,4 +13,9 @@ import (
 var Module = fx.Module("elasticfx",
 	fx.Provide(provideConfig),
 	fx.Provide(NewElasticClient),
+	fx.Provide(fx.Annotate(
+		NewElasticHealthChecker,
+		fx.As(new(contracts.Health)),
+		fx.ResultTags(fmt.Sprintf(`group:"%s"`, "healths")),
+	)),
 )
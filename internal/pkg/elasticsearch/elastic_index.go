@@ -0,0 +1,473 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/goccy/go-json"
+)
+
+// externalVersionType the documents are versioned by the versions of their source
+const externalVersionType = "external"
+
+// BulkDocument is a single document in a bulk index request
+type BulkDocument struct {
+	Id       string
+	Document interface{}
+	// Version the external version of the document, the document is only written when its version is greater than the
+	// indexed version. The document is not versioned when it is nil.
+	Version *int64
+}
+
+type SearchResponse struct {
+	Took         int64                      `json:"took"`
+	Hits         SearchHits                 `json:"hits"`
+	Aggregations map[string]json.RawMessage `json:"aggregations"`
+}
+
+type SearchHits struct {
+	Total    SearchTotal `json:"total"`
+	MaxScore float64     `json:"max_score"`
+	Hits     []SearchHit `json:"hits"`
+}
+
+type SearchTotal struct {
+	Value    int64  `json:"value"`
+	Relation string `json:"relation"`
+}
+
+type SearchHit struct {
+	Index     string              `json:"_index"`
+	Id        string              `json:"_id"`
+	Score     float64             `json:"_score"`
+	Source    json.RawMessage     `json:"_source"`
+	Highlight map[string][]string `json:"highlight"`
+}
+
+// CreateIndex creates an index with the given settings and mappings body
+func CreateIndex(
+	ctx context.Context,
+	client *elasticsearch.Client,
+	indexName string,
+	body interface{},
+) error {
+	reader, err := toReader(body)
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Indices.Create(
+		indexName,
+		client.Indices.Create.WithContext(ctx),
+		client.Indices.Create.WithBody(reader),
+	)
+	if err != nil {
+		return errors.WrapIf(err, "error in creating elasticsearch index")
+	}
+
+	return checkResponse(res, fmt.Sprintf("create index `%s`", indexName))
+}
+
+// IndexExists checks an index or alias with the given name exists
+func IndexExists(
+	ctx context.Context,
+	client *elasticsearch.Client,
+	indexName string,
+) (bool, error) {
+	res, err := client.Indices.Exists(
+		[]string{indexName},
+		client.Indices.Exists.WithContext(ctx),
+	)
+	if err != nil {
+		return false, errors.WrapIf(err, "error in checking elasticsearch index existence")
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, errors.Errorf(
+			"unexpected status `%d` in checking index `%s` existence",
+			res.StatusCode,
+			indexName,
+		)
+	}
+}
+
+// GetAliasIndices returns the indices that currently the alias points to
+func GetAliasIndices(
+	ctx context.Context,
+	client *elasticsearch.Client,
+	alias string,
+) ([]string, error) {
+	res, err := client.Indices.GetAlias(
+		client.Indices.GetAlias.WithContext(ctx),
+		client.Indices.GetAlias.WithName(alias),
+	)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in getting elasticsearch alias")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if res.IsError() {
+		return nil, responseError(res, fmt.Sprintf("get alias `%s`", alias))
+	}
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, errors.WrapIf(err, "error in decoding elasticsearch alias response")
+	}
+
+	indices := make([]string, 0, len(result))
+	for index := range result {
+		indices = append(indices, index)
+	}
+
+	return indices, nil
+}
+
+// SwapAlias points the alias atomically to the new index and returns the indices that the alias was pointing to before
+func SwapAlias(
+	ctx context.Context,
+	client *elasticsearch.Client,
+	alias string,
+	newIndex string,
+) ([]string, error) {
+	oldIndices, err := GetAliasIndices(ctx, client, alias)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := make([]map[string]interface{}, 0, len(oldIndices)+1)
+	for _, index := range oldIndices {
+		actions = append(actions, map[string]interface{}{
+			"remove": map[string]interface{}{"index": index, "alias": alias},
+		})
+	}
+	actions = append(actions, map[string]interface{}{
+		"add": map[string]interface{}{"index": newIndex, "alias": alias, "is_write_index": true},
+	})
+
+	reader, err := toReader(map[string]interface{}{"actions": actions})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Indices.UpdateAliases(
+		reader,
+		client.Indices.UpdateAliases.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in updating elasticsearch aliases")
+	}
+
+	if err := checkResponse(res, fmt.Sprintf("swap alias `%s`", alias)); err != nil {
+		return nil, err
+	}
+
+	return oldIndices, nil
+}
+
+// DeleteIndices deletes the given indices
+func DeleteIndices(
+	ctx context.Context,
+	client *elasticsearch.Client,
+	indices ...string,
+) error {
+	if len(indices) == 0 {
+		return nil
+	}
+
+	res, err := client.Indices.Delete(
+		indices,
+		client.Indices.Delete.WithContext(ctx),
+		client.Indices.Delete.WithIgnoreUnavailable(true),
+	)
+	if err != nil {
+		return errors.WrapIf(err, "error in deleting elasticsearch indices")
+	}
+
+	return checkResponse(res, fmt.Sprintf("delete indices `%s`", strings.Join(indices, ",")))
+}
+
+// RefreshIndex makes all operations performed on the index available for search
+func RefreshIndex(
+	ctx context.Context,
+	client *elasticsearch.Client,
+	indexName string,
+) error {
+	res, err := client.Indices.Refresh(
+		client.Indices.Refresh.WithContext(ctx),
+		client.Indices.Refresh.WithIndex(indexName),
+	)
+	if err != nil {
+		return errors.WrapIf(err, "error in refreshing elasticsearch index")
+	}
+
+	return checkResponse(res, fmt.Sprintf("refresh index `%s`", indexName))
+}
+
+// IndexDocument adds or replaces a document with the given id
+func IndexDocument(
+	ctx context.Context,
+	client *elasticsearch.Client,
+	indexName string,
+	id string,
+	document interface{},
+) error {
+	reader, err := toReader(document)
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Index(
+		indexName,
+		reader,
+		client.Index.WithContext(ctx),
+		client.Index.WithDocumentID(id),
+	)
+	if err != nil {
+		return errors.WrapIf(err, "error in indexing elasticsearch document")
+	}
+
+	return checkResponse(res, fmt.Sprintf("index document `%s`", id))
+}
+
+// IndexVersionedDocument adds or replaces a document with the given id when the version is greater than the version of
+// the indexed document, it returns false when a newer version is already indexed - https://www.elastic.co/guide/en/elasticsearch/reference/current/docs-index_.html#index-versioning
+func IndexVersionedDocument(
+	ctx context.Context,
+	client *elasticsearch.Client,
+	indexName string,
+	id string,
+	version int64,
+	document interface{},
+) (bool, error) {
+	reader, err := toReader(document)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := client.Index(
+		indexName,
+		reader,
+		client.Index.WithContext(ctx),
+		client.Index.WithDocumentID(id),
+		client.Index.WithVersion(int(version)),
+		client.Index.WithVersionType(externalVersionType),
+	)
+	if err != nil {
+		return false, errors.WrapIf(err, "error in indexing elasticsearch document")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusConflict {
+		return false, nil
+	}
+
+	if res.IsError() {
+		return false, responseError(res, fmt.Sprintf("index document `%s`", id))
+	}
+
+	return true, nil
+}
+
+// DeleteDocument removes the document with the given id, a missing document is not an error
+func DeleteDocument(
+	ctx context.Context,
+	client *elasticsearch.Client,
+	indexName string,
+	id string,
+) error {
+	res, err := client.Delete(
+		indexName,
+		id,
+		client.Delete.WithContext(ctx),
+	)
+	if err != nil {
+		return errors.WrapIf(err, "error in deleting elasticsearch document")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	if res.IsError() {
+		return responseError(res, fmt.Sprintf("delete document `%s`", id))
+	}
+
+	return nil
+}
+
+// BulkIndex adds or replaces the documents in a single bulk request, the versioned documents are skipped when a newer
+// version is already indexed
+func BulkIndex(
+	ctx context.Context,
+	client *elasticsearch.Client,
+	indexName string,
+	documents []BulkDocument,
+) error {
+	if len(documents) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for _, document := range documents {
+		action := map[string]interface{}{"_index": indexName, "_id": document.Id}
+		if document.Version != nil {
+			action["version"] = *document.Version
+			action["version_type"] = externalVersionType
+		}
+
+		meta, err := json.Marshal(map[string]interface{}{"index": action})
+		if err != nil {
+			return errors.WrapIf(err, "error in marshaling bulk metadata")
+		}
+
+		data, err := json.Marshal(document.Document)
+		if err != nil {
+			return errors.WrapIf(err, "error in marshaling bulk document")
+		}
+
+		buf.Write(meta)
+		buf.WriteByte('\n')
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+
+	res, err := client.Bulk(
+		&buf,
+		client.Bulk.WithContext(ctx),
+		client.Bulk.WithIndex(indexName),
+	)
+	if err != nil {
+		return errors.WrapIf(err, "error in bulk indexing elasticsearch documents")
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return responseError(res, "bulk index")
+	}
+
+	var bulkResponse struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			Id     string `json:"_id"`
+			Status int    `json:"status"`
+			Error  struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&bulkResponse); err != nil {
+		return errors.WrapIf(err, "error in decoding elasticsearch bulk response")
+	}
+
+	if !bulkResponse.Errors {
+		return nil
+	}
+
+	for _, item := range bulkResponse.Items {
+		for _, result := range item {
+			// a newer version of the versioned document is already indexed
+			if result.Status == http.StatusConflict {
+				continue
+			}
+			if result.Status > 299 {
+				return errors.Errorf(
+					"error in bulk indexing document `%s`: [%s] %s",
+					result.Id,
+					result.Error.Type,
+					result.Error.Reason,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Search runs the search body against the index or alias
+func Search(
+	ctx context.Context,
+	client *elasticsearch.Client,
+	indexName string,
+	body interface{},
+) (*SearchResponse, error) {
+	reader, err := toReader(body)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Search(
+		client.Search.WithContext(ctx),
+		client.Search.WithIndex(indexName),
+		client.Search.WithBody(reader),
+		client.Search.WithTrackTotalHits(true),
+	)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in searching elasticsearch")
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, responseError(res, fmt.Sprintf("search index `%s`", indexName))
+	}
+
+	searchResponse := &SearchResponse{}
+	if err := json.NewDecoder(res.Body).Decode(searchResponse); err != nil {
+		return nil, errors.WrapIf(err, "error in decoding elasticsearch search response")
+	}
+
+	return searchResponse, nil
+}
+
+func toReader(body interface{}) (io.Reader, error) {
+	if body == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in marshaling elasticsearch request body")
+	}
+
+	return bytes.NewReader(data), nil
+}
+
+func checkResponse(res *esapi.Response, operation string) error {
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return responseError(res, operation)
+	}
+
+	return nil
+}
+
+func responseError(res *esapi.Response, operation string) error {
+	body, _ := io.ReadAll(res.Body)
+
+	return errors.Errorf(
+		"elasticsearch %s failed with status `%d`: %s",
+		operation,
+		res.StatusCode,
+		string(body),
+	)
+}
@@ -0,0 +1,36 @@
+package elasticsearch
+
+import (
+	"context"
+
+	"github.com/reoden/go-NFT/pkg/health/contracts"
+
+	"emperror.dev/errors"
+	"github.com/elastic/go-elasticsearch/v8"
+)
+
+type ElasticHealthChecker struct {
+	client *elasticsearch.Client
+}
+
+func NewElasticHealthChecker(client *elasticsearch.Client) contracts.Health {
+	return &ElasticHealthChecker{client}
+}
+
+func (healthChecker *ElasticHealthChecker) CheckHealth(ctx context.Context) error {
+	res, err := healthChecker.client.Ping(healthChecker.client.Ping.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("elasticsearch ping failed with status `%d`", res.StatusCode)
+	}
+
+	return nil
+}
+
+func (healthChecker *ElasticHealthChecker) GetHealthName() string {
+	return "elasticsearch"
+}
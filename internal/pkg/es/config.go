@@ -1,6 +1,21 @@
 package es
 
-// Config of es package.
-type Config struct {
-	SnapshotFrequency int64 `json:"snapshotFrequency" validate:"required,gte=0"`
+import (
+	"github.com/reoden/go-NFT/pkg/config"
+	"github.com/reoden/go-NFT/pkg/config/environment"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[EventStoreConfig]())
+
+// EventStoreConfig of es package.
+type EventStoreConfig struct {
+	// SnapshotFrequency number of the events between the snapshots of an aggregate, the snapshots are disabled with 0
+	SnapshotFrequency int64 `mapstructure:"snapshotFrequency" json:"snapshotFrequency" validate:"gte=0"`
+}
+
+func ProvideConfig(environment environment.Environment) (*EventStoreConfig, error) {
+	return config.BindConfigKey[*EventStoreConfig](optionName, environment)
 }
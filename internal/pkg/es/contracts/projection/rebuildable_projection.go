@@ -0,0 +1,23 @@
+package projection
+
+import (
+	"context"
+)
+
+// IRebuildableProjection a projection that its read model can be rebuilt from the start of the store, the events are replayed
+// into an empty shadow of the read model that replaces the read model after the replay.
+type IRebuildableProjection interface {
+	IProjection
+
+	// ProjectionName the unique name of projection, it is the key of the projection checkpoint
+	ProjectionName() string
+
+	// PrepareRebuild creates an empty shadow of the read model and returns the projection that writes to the shadow
+	PrepareRebuild(ctx context.Context) (IProjection, error)
+
+	// SwapRebuild replaces the read model with its shadow atomically
+	SwapRebuild(ctx context.Context) error
+
+	// CleanupRebuild removes the shadow of a failed rebuild
+	CleanupRebuild(ctx context.Context) error
+}
@@ -0,0 +1,22 @@
+package store
+
+import (
+	"context"
+
+	"github.com/reoden/go-NFT/pkg/es/models"
+)
+
+// AllEventsReader reads the events of all streams in the order of their global positions
+type AllEventsReader interface {
+	// ReadAllEvents Read the events of all streams after the global position with specified events count, the events are filtered
+	// by the stream prefixes when the prefixes are provided.
+	ReadAllEvents(
+		ctx context.Context,
+		fromGlobalPosition uint64,
+		count int,
+		prefixes []string,
+	) ([]*models.StreamEvent, error)
+
+	// ReadHeadPosition Read the global position of the last event of the store, it is 0 for an empty store.
+	ReadHeadPosition(ctx context.Context) (uint64, error)
+}
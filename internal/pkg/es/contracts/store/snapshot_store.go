@@ -0,0 +1,21 @@
+package store
+
+import (
+	"context"
+
+	"github.com/reoden/go-NFT/pkg/es/models"
+
+	"emperror.dev/errors"
+)
+
+// ErrSnapshotNotFound there is no snapshot for the stream
+var ErrSnapshotNotFound = errors.New("snapshot not found")
+
+// SnapshotStore is responsible for loading and saving the latest snapshot of the aggregate streams.
+type SnapshotStore interface {
+	// Load returns the latest snapshot of the stream or ErrSnapshotNotFound
+	Load(ctx context.Context, streamId string) (*models.Snapshot, error)
+
+	// Save replaces the latest snapshot of the stream
+	Save(ctx context.Context, snapshot *models.Snapshot) error
+}
@@ -0,0 +1,24 @@
+package store
+
+import (
+	"context"
+
+	"github.com/reoden/go-NFT/pkg/es/models"
+	streamName "github.com/reoden/go-NFT/pkg/es/models/stream_name"
+)
+
+// StreamArchive keeps the events of the cold streams out of the event store, the archived streams keep their versions
+// and their events are restored on demand.
+type StreamArchive interface {
+	// Rehydrate restores the archived events of the stream into the event store, it returns false when the stream is not
+	// archived.
+	Rehydrate(ctx context.Context, streamName streamName.StreamName) (bool, error)
+
+	// ArchivedStreams returns the archived streams with the prefixes, all of the archived streams without prefixes.
+	ArchivedStreams(ctx context.Context, prefixes []string) ([]streamName.StreamName, error)
+
+	// ReadArchivedEvents reads the events of an archived stream from the archive with their global positions, the stream
+	// stays archived. It returns no events when the stream is not archived, the full replays of the store like the
+	// projection rebuilds read the archived streams with it.
+	ReadArchivedEvents(ctx context.Context, streamName streamName.StreamName) ([]*models.StreamEvent, error)
+}
@@ -14,3 +14,10 @@ var (
 	)
 	InvalidEventTypeError = errors.New("invalid event type")
 )
+
+var (
+	// ErrStreamNotFound there is no stream with the stream name in the event store
+	ErrStreamNotFound = errors.New("stream not found")
+	// ErrWrongExpectedVersion the version of the stream is different from the expected stream version
+	ErrWrongExpectedVersion = errors.New("wrong expected stream version")
+)
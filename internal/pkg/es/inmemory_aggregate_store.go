@@ -0,0 +1,173 @@
+package es
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/reoden/go-NFT/pkg/core/domain"
+	"github.com/reoden/go-NFT/pkg/core/metadata"
+	"github.com/reoden/go-NFT/pkg/core/serializer"
+	"github.com/reoden/go-NFT/pkg/es/contracts/store"
+	esErrors "github.com/reoden/go-NFT/pkg/es/errors"
+	"github.com/reoden/go-NFT/pkg/es/models"
+	appendResult "github.com/reoden/go-NFT/pkg/es/models/append_result"
+	streamName "github.com/reoden/go-NFT/pkg/es/models/stream_name"
+	readPosition "github.com/reoden/go-NFT/pkg/es/models/stream_position/read_position"
+	expectedStreamVersion "github.com/reoden/go-NFT/pkg/es/models/stream_version"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+type inMemoryAggregateStore[T models.IHaveEventSourcedAggregate] struct {
+	eventStore      store.EventStore
+	snapshotStore   store.SnapshotStore
+	eventSerializer serializer.EventSerializer
+	config          *EventStoreConfig
+}
+
+// NewInMemoryAggregateStore creates an aggregate store for the tests on an event store like the InMemoryEventStore, the
+// aggregates are stored and loaded like the postgres aggregate store without snapshots
+func NewInMemoryAggregateStore[T models.IHaveEventSourcedAggregate](
+	eventStore store.EventStore,
+) store.AggregateStore[T] {
+	return &inMemoryAggregateStore[T]{eventStore: eventStore}
+}
+
+// NewInMemoryAggregateStoreWithSnapshots creates an in memory aggregate store that snapshots the aggregates that implement
+// models.IHaveSnapshot every `SnapshotFrequency` events into the snapshot store
+func NewInMemoryAggregateStoreWithSnapshots[T models.IHaveEventSourcedAggregate](
+	eventStore store.EventStore,
+	snapshotStore store.SnapshotStore,
+	eventSerializer serializer.EventSerializer,
+	config *EventStoreConfig,
+) store.AggregateStore[T] {
+	return &inMemoryAggregateStore[T]{
+		eventStore:      eventStore,
+		snapshotStore:   snapshotStore,
+		eventSerializer: eventSerializer,
+		config:          config,
+	}
+}
+
+func (a *inMemoryAggregateStore[T]) StoreWithVersion(
+	aggregate T,
+	metadata metadata.Metadata,
+	expectedVersion expectedStreamVersion.ExpectedStreamVersion,
+	ctx context.Context,
+) (*appendResult.AppendEventsResult, error) {
+	if len(aggregate.UncommittedEvents()) == 0 {
+		return appendResult.NoOp, nil
+	}
+
+	streamId := streamName.For[T](aggregate)
+	previousVersion := aggregate.CurrentVersion() - int64(len(aggregate.UncommittedEvents()))
+
+	streamEvents := make([]*models.StreamEvent, 0, len(aggregate.UncommittedEvents()))
+	for i, domainEvent := range aggregate.UncommittedEvents() {
+		streamEvents = append(streamEvents, &models.StreamEvent{
+			EventID:  uuid.NewV4(),
+			Event:    domainEvent,
+			Metadata: metadata,
+			Version:  previousVersion + int64(i) + 1,
+		})
+	}
+
+	result, err := a.eventStore.AppendEvents(streamId, expectedVersion, streamEvents, ctx)
+	if err != nil {
+		return nil, errors.WrapIff(err, "error in storing aggregate with id {%s}", aggregate.Id().String())
+	}
+
+	aggregate.MarkUncommittedEventAsCommitted()
+
+	if a.snapshotStore != nil && ShouldTakeSnapshot(a.config, previousVersion, aggregate.CurrentVersion()) {
+		snapshot, err := CreateSnapshot(aggregate, streamId.String(), aggregate.CurrentVersion(), a.eventSerializer)
+		if err == nil && snapshot != nil {
+			err = a.snapshotStore.Save(ctx, snapshot)
+		}
+		if err != nil {
+			return nil, errors.WrapIff(err, "error in saving snapshot of stream %s", streamId.String())
+		}
+	}
+
+	return result, nil
+}
+
+func (a *inMemoryAggregateStore[T]) Store(
+	aggregate T,
+	metadata metadata.Metadata,
+	ctx context.Context,
+) (*appendResult.AppendEventsResult, error) {
+	expectedVersion := expectedStreamVersion.FromInt64(aggregate.OriginalVersion())
+
+	return a.StoreWithVersion(aggregate, metadata, expectedVersion, ctx)
+}
+
+func (a *inMemoryAggregateStore[T]) Load(ctx context.Context, aggregateId uuid.UUID) (T, error) {
+	return a.LoadWithReadPosition(ctx, aggregateId, readPosition.Start)
+}
+
+func (a *inMemoryAggregateStore[T]) LoadWithReadPosition(
+	ctx context.Context,
+	aggregateId uuid.UUID,
+	position readPosition.StreamReadPosition,
+) (T, error) {
+	aggregate := newEmptyAggregate[T]()
+	streamId := streamName.ForID[T](aggregateId)
+
+	restoredFromSnapshot := false
+	if position.IsStart() && a.snapshotStore != nil {
+		snapshot, err := a.snapshotStore.Load(ctx, streamId.String())
+		if err != nil && !errors.Is(err, store.ErrSnapshotNotFound) {
+			return *new(T), err
+		}
+		if err == nil {
+			restoredFromSnapshot, err = RestoreSnapshot(aggregate, snapshot, a.eventSerializer)
+			if err != nil {
+				return *new(T), err
+			}
+		}
+		if restoredFromSnapshot {
+			position = readPosition.FromInt64(snapshot.Version + 1)
+		}
+	}
+
+	streamEvents, err := a.eventStore.ReadEventsWithMaxCount(streamId, position, ctx)
+	if errors.Is(err, esErrors.ErrStreamNotFound) || (err == nil && len(streamEvents) == 0 && !restoredFromSnapshot) {
+		return *new(T), customErrors.NewNotFoundErrorWrap(
+			esErrors.ErrStreamNotFound,
+			fmt.Sprintf("aggregate with id %s not found", aggregateId.String()),
+		)
+	}
+	if err != nil {
+		return *new(T), errors.WrapIff(err, "error in loading aggregate {%s}", aggregateId.String())
+	}
+
+	var meta metadata.Metadata
+	domainEvents := make([]domain.IDomainEvent, 0, len(streamEvents))
+	for _, streamEvent := range streamEvents {
+		meta = streamEvent.Metadata
+		domainEvents = append(domainEvents, streamEvent.Event)
+	}
+
+	err = aggregate.LoadFromHistory(domainEvents, meta)
+	if err != nil {
+		return *new(T), err
+	}
+
+	return aggregate, nil
+}
+
+func (a *inMemoryAggregateStore[T]) Exists(ctx context.Context, aggregateId uuid.UUID) (bool, error) {
+	return a.eventStore.StreamExists(streamName.ForID[T](aggregateId), ctx)
+}
+
+// newEmptyAggregate creates a new instance of the aggregate pointer type with its empty state
+func newEmptyAggregate[T models.IHaveEventSourcedAggregate]() T {
+	aggregate := reflect.New(reflect.TypeOf(*new(T)).Elem()).Interface().(T)
+	aggregate.NewEmptyAggregate()
+
+	return aggregate
+}
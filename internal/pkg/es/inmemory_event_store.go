@@ -0,0 +1,416 @@
+package es
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/reoden/go-NFT/pkg/es/contracts/store"
+	esErrors "github.com/reoden/go-NFT/pkg/es/errors"
+	"github.com/reoden/go-NFT/pkg/es/models"
+	appendResult "github.com/reoden/go-NFT/pkg/es/models/append_result"
+	streamName "github.com/reoden/go-NFT/pkg/es/models/stream_name"
+	readPosition "github.com/reoden/go-NFT/pkg/es/models/stream_position/read_position"
+	"github.com/reoden/go-NFT/pkg/es/models/stream_position/truncatePosition"
+	expectedStreamVersion "github.com/reoden/go-NFT/pkg/es/models/stream_version"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// InMemoryEventStore an event store in the memory for the tests, it has the behaviour of the postgres event store: the
+// stream versions start from 0, the global positions start from 1 and the appends check the expected stream version.
+type InMemoryEventStore interface {
+	store.EventStore
+	store.AllEventsReader
+
+	// SubscribeToAll sends the events of the streams with the prefixes after the global position to the returned channel,
+	// first the stored events and then the appended events, the channel is closed when the context is done.
+	SubscribeToAll(ctx context.Context, fromGlobalPosition uint64, prefixes []string) <-chan *models.StreamEvent
+}
+
+type inMemoryStream struct {
+	version int64
+	events  []*models.StreamEvent
+}
+
+type inMemoryEventStore struct {
+	mu           sync.RWMutex
+	streams      map[string]*inMemoryStream
+	all          []*inMemoryStoredEvent
+	lastPosition int64
+	// appended is closed and replaced on each append to wake up the subscriptions
+	appended chan struct{}
+}
+
+type inMemoryStoredEvent struct {
+	streamId string
+	event    *models.StreamEvent
+}
+
+func NewInMemoryEventStore() InMemoryEventStore {
+	return &inMemoryEventStore{
+		streams:  make(map[string]*inMemoryStream),
+		appended: make(chan struct{}),
+	}
+}
+
+func (i *inMemoryEventStore) StreamExists(streamName streamName.StreamName, ctx context.Context) (bool, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	_, ok := i.streams[streamName.String()]
+
+	return ok, nil
+}
+
+func (i *inMemoryEventStore) ReadEventsFromStart(
+	streamName streamName.StreamName,
+	count uint64,
+	ctx context.Context,
+) ([]*models.StreamEvent, error) {
+	return i.ReadEvents(streamName, readPosition.Start, count, ctx)
+}
+
+func (i *inMemoryEventStore) ReadEvents(
+	streamName streamName.StreamName,
+	readPosition readPosition.StreamReadPosition,
+	count uint64,
+	ctx context.Context,
+) ([]*models.StreamEvent, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	stream, ok := i.streams[streamName.String()]
+	if !ok {
+		return nil, newInMemoryStreamNotFoundError(streamName)
+	}
+
+	var events []*models.StreamEvent
+	for _, event := range stream.events {
+		if uint64(len(events)) >= count {
+			break
+		}
+		if event.Version >= readPosition.Value() {
+			events = append(events, copyStreamEvent(event))
+		}
+	}
+
+	return events, nil
+}
+
+func (i *inMemoryEventStore) ReadEventsWithMaxCount(
+	streamName streamName.StreamName,
+	readPosition readPosition.StreamReadPosition,
+	ctx context.Context,
+) ([]*models.StreamEvent, error) {
+	return i.ReadEvents(streamName, readPosition, ^uint64(0), ctx)
+}
+
+func (i *inMemoryEventStore) ReadEventsBackwards(
+	streamName streamName.StreamName,
+	readPosition readPosition.StreamReadPosition,
+	count uint64,
+	ctx context.Context,
+) ([]*models.StreamEvent, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	stream, ok := i.streams[streamName.String()]
+	if !ok {
+		return nil, newInMemoryStreamNotFoundError(streamName)
+	}
+
+	var events []*models.StreamEvent
+	for index := len(stream.events) - 1; index >= 0 && uint64(len(events)) < count; index-- {
+		event := stream.events[index]
+		if readPosition.IsEnd() || event.Version <= readPosition.Value() {
+			events = append(events, copyStreamEvent(event))
+		}
+	}
+
+	return events, nil
+}
+
+func (i *inMemoryEventStore) ReadEventsBackwardsFromEnd(
+	streamName streamName.StreamName,
+	count uint64,
+	ctx context.Context,
+) ([]*models.StreamEvent, error) {
+	return i.ReadEventsBackwards(streamName, readPosition.End, count, ctx)
+}
+
+func (i *inMemoryEventStore) ReadEventsBackwardsWithMaxCount(
+	stream streamName.StreamName,
+	readPosition readPosition.StreamReadPosition,
+	ctx context.Context,
+) ([]*models.StreamEvent, error) {
+	return i.ReadEventsBackwards(stream, readPosition, ^uint64(0), ctx)
+}
+
+func (i *inMemoryEventStore) AppendEvents(
+	streamName streamName.StreamName,
+	expectedVersion expectedStreamVersion.ExpectedStreamVersion,
+	events []*models.StreamEvent,
+	ctx context.Context,
+) (*appendResult.AppendEventsResult, error) {
+	if len(events) == 0 {
+		return appendResult.NoOp, nil
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	stream, exists := i.streams[streamName.String()]
+	if err := checkInMemoryExpectedVersion(streamName, stream, expectedVersion); err != nil {
+		return nil, err
+	}
+	if !exists {
+		stream = &inMemoryStream{version: expectedStreamVersion.NoStream.Value()}
+		i.streams[streamName.String()] = stream
+	}
+
+	for _, event := range events {
+		i.lastPosition++
+		stream.version++
+
+		stored := copyStreamEvent(event)
+		if stored.EventID == uuid.Nil {
+			stored.EventID = uuid.NewV4()
+		}
+		stored.Version = stream.version
+		stored.Position = i.lastPosition
+
+		stream.events = append(stream.events, stored)
+		i.all = append(i.all, &inMemoryStoredEvent{streamId: streamName.String(), event: stored})
+	}
+
+	close(i.appended)
+	i.appended = make(chan struct{})
+
+	return appendResult.From(uint64(i.lastPosition), uint64(stream.version)), nil
+}
+
+func (i *inMemoryEventStore) AppendNewEvents(
+	streamName streamName.StreamName,
+	events []*models.StreamEvent,
+	ctx context.Context,
+) (*appendResult.AppendEventsResult, error) {
+	return i.AppendEvents(streamName, expectedStreamVersion.NoStream, events, ctx)
+}
+
+func (i *inMemoryEventStore) TruncateStream(
+	streamName streamName.StreamName,
+	truncatePosition truncatePosition.StreamTruncatePosition,
+	expectedVersion expectedStreamVersion.ExpectedStreamVersion,
+	ctx context.Context,
+) (*appendResult.AppendEventsResult, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	stream, err := i.existingStream(streamName, expectedVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	// the stream keeps its version, so the next appends continue after the truncated events
+	var events []*models.StreamEvent
+	for _, event := range stream.events {
+		if event.Version >= truncatePosition.Value() {
+			events = append(events, event)
+		}
+	}
+	stream.events = events
+	i.removeFromAll(streamName.String(), func(event *models.StreamEvent) bool {
+		return event.Version < truncatePosition.Value()
+	})
+
+	return appendResult.From(0, uint64(stream.version)), nil
+}
+
+func (i *inMemoryEventStore) DeleteStream(
+	streamName streamName.StreamName,
+	expectedVersion expectedStreamVersion.ExpectedStreamVersion,
+	ctx context.Context,
+) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	_, err := i.existingStream(streamName, expectedVersion)
+	if err != nil {
+		return err
+	}
+
+	delete(i.streams, streamName.String())
+	i.removeFromAll(streamName.String(), func(event *models.StreamEvent) bool {
+		return true
+	})
+
+	return nil
+}
+
+func (i *inMemoryEventStore) ReadAllEvents(
+	ctx context.Context,
+	fromGlobalPosition uint64,
+	count int,
+	prefixes []string,
+) ([]*models.StreamEvent, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return i.readAll(fromGlobalPosition, count, prefixes), nil
+}
+
+func (i *inMemoryEventStore) ReadHeadPosition(ctx context.Context) (uint64, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	if len(i.all) == 0 {
+		return 0, nil
+	}
+
+	return uint64(i.all[len(i.all)-1].event.Position), nil
+}
+
+func (i *inMemoryEventStore) SubscribeToAll(
+	ctx context.Context,
+	fromGlobalPosition uint64,
+	prefixes []string,
+) <-chan *models.StreamEvent {
+	events := make(chan *models.StreamEvent)
+
+	go func() {
+		defer close(events)
+
+		position := fromGlobalPosition
+		for {
+			i.mu.RLock()
+			streamEvents := i.readAll(position, len(i.all), prefixes)
+			appended := i.appended
+			i.mu.RUnlock()
+
+			for _, streamEvent := range streamEvents {
+				select {
+				case events <- streamEvent:
+					position = uint64(streamEvent.Position)
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if len(streamEvents) > 0 {
+				continue
+			}
+
+			select {
+			case <-appended:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events
+}
+
+func (i *inMemoryEventStore) readAll(fromGlobalPosition uint64, count int, prefixes []string) []*models.StreamEvent {
+	var events []*models.StreamEvent
+	for _, stored := range i.all {
+		if len(events) >= count {
+			break
+		}
+		if uint64(stored.event.Position) <= fromGlobalPosition || !hasStreamPrefix(stored.streamId, prefixes) {
+			continue
+		}
+		events = append(events, copyStreamEvent(stored.event))
+	}
+
+	return events
+}
+
+func (i *inMemoryEventStore) existingStream(
+	streamName streamName.StreamName,
+	expectedVersion expectedStreamVersion.ExpectedStreamVersion,
+) (*inMemoryStream, error) {
+	stream, ok := i.streams[streamName.String()]
+	if !ok {
+		return nil, newInMemoryStreamNotFoundError(streamName)
+	}
+
+	if err := checkInMemoryExpectedVersion(streamName, stream, expectedVersion); err != nil {
+		return nil, err
+	}
+
+	return stream, nil
+}
+
+func (i *inMemoryEventStore) removeFromAll(streamId string, remove func(event *models.StreamEvent) bool) {
+	all := make([]*inMemoryStoredEvent, 0, len(i.all))
+	for _, stored := range i.all {
+		if stored.streamId != streamId || !remove(stored.event) {
+			all = append(all, stored)
+		}
+	}
+	i.all = all
+}
+
+func hasStreamPrefix(streamId string, prefixes []string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(streamId, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// copyStreamEvent copies the stream event, so the readers can't change the stored positions and versions
+func copyStreamEvent(streamEvent *models.StreamEvent) *models.StreamEvent {
+	copied := *streamEvent
+
+	return &copied
+}
+
+func checkInMemoryExpectedVersion(
+	streamName streamName.StreamName,
+	stream *inMemoryStream,
+	expectedVersion expectedStreamVersion.ExpectedStreamVersion,
+) error {
+	switch {
+	case expectedVersion.IsAny():
+		return nil
+	case expectedVersion.IsNoStream():
+		if stream == nil {
+			return nil
+		}
+	case expectedVersion.IsStreamExists():
+		if stream != nil {
+			return nil
+		}
+	default:
+		if stream != nil && stream.version == expectedVersion.Value() {
+			return nil
+		}
+	}
+
+	return customErrors.NewConflictErrorWrap(
+		esErrors.ErrWrongExpectedVersion,
+		fmt.Sprintf(
+			"stream %s is not at the expected version %d",
+			streamName.String(),
+			expectedVersion.Value(),
+		),
+	)
+}
+
+func newInMemoryStreamNotFoundError(streamName streamName.StreamName) error {
+	return customErrors.NewNotFoundErrorWrap(
+		esErrors.ErrStreamNotFound,
+		fmt.Sprintf("stream %s not found", streamName.String()),
+	)
+}
@@ -0,0 +1,282 @@
+//go:build unit
+// +build unit
+
+package es
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/core/domain"
+	esErrors "github.com/reoden/go-NFT/pkg/es/errors"
+	"github.com/reoden/go-NFT/pkg/es/models"
+	streamName "github.com/reoden/go-NFT/pkg/es/models/stream_name"
+	readPosition "github.com/reoden/go-NFT/pkg/es/models/stream_position/read_position"
+	"github.com/reoden/go-NFT/pkg/es/models/stream_position/truncatePosition"
+	expectedStreamVersion "github.com/reoden/go-NFT/pkg/es/models/stream_version"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type counterIncremented struct {
+	*domain.DomainEvent
+	By int `json:"by"`
+}
+
+type testCounter struct {
+	*models.EventSourcedAggregateRoot
+	Count int
+}
+
+func newTestCounter(id uuid.UUID) *testCounter {
+	counter := &testCounter{}
+	counter.EventSourcedAggregateRoot = models.NewEventSourcedAggregateRootWithId(
+		id,
+		typeMapper.GetTypeName(counter),
+		counter.When,
+	)
+
+	return counter
+}
+
+func (c *testCounter) NewEmptyAggregate() {
+	c.EventSourcedAggregateRoot = models.NewEventSourcedAggregateRoot(typeMapper.GetTypeName(c), c.When)
+}
+
+func (c *testCounter) When(event domain.IDomainEvent) error {
+	// the loaded aggregates get their id from the events
+	if uuid.Equal(c.Id(), uuid.Nil) {
+		c.SetId(event.GetAggregateId())
+	}
+
+	if e, ok := event.(*counterIncremented); ok {
+		c.Count += e.By
+	}
+
+	return nil
+}
+
+func (c *testCounter) Increment(by int) error {
+	return c.Apply(&counterIncremented{
+		DomainEvent: domain.NewDomainEvent(typeMapper.GetTypeName(&counterIncremented{})),
+		By:          by,
+	}, true)
+}
+
+func newCounterEvents(values ...int) []*models.StreamEvent {
+	events := make([]*models.StreamEvent, 0, len(values))
+	for _, value := range values {
+		events = append(events, &models.StreamEvent{
+			Event: &counterIncremented{
+				DomainEvent: domain.NewDomainEvent(typeMapper.GetTypeName(&counterIncremented{})),
+				By:          value,
+			},
+		})
+	}
+
+	return events
+}
+
+func Test_InMemory_Append_Checks_Expected_Version(t *testing.T) {
+	ctx := context.Background()
+	eventStore := NewInMemoryEventStore()
+	stream := streamName.StreamName("counter-1")
+
+	result, err := eventStore.AppendNewEvents(stream, newCounterEvents(1, 2), ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), result.GlobalPosition)
+	assert.Equal(t, uint64(1), result.NextExpectedVersion)
+
+	_, err = eventStore.AppendNewEvents(stream, newCounterEvents(3), ctx)
+	assert.True(t, errors.Is(err, esErrors.ErrWrongExpectedVersion))
+	assert.True(t, customErrors.IsConflictError(err))
+
+	_, err = eventStore.AppendEvents(stream, expectedStreamVersion.FromInt64(0), newCounterEvents(3), ctx)
+	assert.True(t, errors.Is(err, esErrors.ErrWrongExpectedVersion))
+
+	_, err = eventStore.AppendEvents(stream, expectedStreamVersion.FromInt64(1), newCounterEvents(3), ctx)
+	require.NoError(t, err)
+
+	_, err = eventStore.AppendEvents(stream, expectedStreamVersion.Any, newCounterEvents(4), ctx)
+	require.NoError(t, err)
+
+	_, err = eventStore.AppendEvents(
+		streamName.StreamName("counter-2"),
+		expectedStreamVersion.StreamExists,
+		newCounterEvents(1),
+		ctx,
+	)
+	assert.True(t, errors.Is(err, esErrors.ErrWrongExpectedVersion))
+}
+
+func Test_InMemory_Reads_Streams_Forwards_And_Backwards(t *testing.T) {
+	ctx := context.Background()
+	eventStore := NewInMemoryEventStore()
+	stream := streamName.StreamName("counter-1")
+
+	_, err := eventStore.AppendNewEvents(stream, newCounterEvents(1, 2, 3, 4), ctx)
+	require.NoError(t, err)
+	_, err = eventStore.AppendNewEvents(streamName.StreamName("other-1"), newCounterEvents(5), ctx)
+	require.NoError(t, err)
+
+	events, err := eventStore.ReadEvents(stream, readPosition.FromInt64(1), 2, ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, versions(events))
+
+	events, err = eventStore.ReadEventsWithMaxCount(stream, readPosition.Start, ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{0, 1, 2, 3}, versions(events))
+
+	events, err = eventStore.ReadEventsBackwardsFromEnd(stream, 3, ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3, 2, 1}, versions(events))
+
+	events, err = eventStore.ReadEventsBackwards(stream, readPosition.FromInt64(1), 5, ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 0}, versions(events))
+
+	_, err = eventStore.ReadEventsFromStart(streamName.StreamName("missing-1"), 10, ctx)
+	assert.True(t, errors.Is(err, esErrors.ErrStreamNotFound))
+	assert.True(t, customErrors.IsNotFoundError(err))
+
+	all, err := eventStore.ReadAllEvents(ctx, 2, 10, []string{"counter-"})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3, 4}, positions(all))
+
+	head, err := eventStore.ReadHeadPosition(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), head)
+}
+
+func Test_InMemory_Truncate_And_Delete_Streams(t *testing.T) {
+	ctx := context.Background()
+	eventStore := NewInMemoryEventStore()
+	stream := streamName.StreamName("counter-1")
+
+	_, err := eventStore.AppendNewEvents(stream, newCounterEvents(1, 2, 3), ctx)
+	require.NoError(t, err)
+
+	_, err = eventStore.TruncateStream(stream, truncatePosition.FromInt64(2), expectedStreamVersion.FromInt64(1), ctx)
+	assert.True(t, errors.Is(err, esErrors.ErrWrongExpectedVersion))
+
+	result, err := eventStore.TruncateStream(stream, truncatePosition.FromInt64(2), expectedStreamVersion.Any, ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), result.NextExpectedVersion)
+
+	events, err := eventStore.ReadEventsWithMaxCount(stream, readPosition.Start, ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2}, versions(events))
+
+	// the appends continue after the truncated events
+	_, err = eventStore.AppendEvents(stream, expectedStreamVersion.FromInt64(2), newCounterEvents(4), ctx)
+	require.NoError(t, err)
+
+	err = eventStore.DeleteStream(stream, expectedStreamVersion.Any, ctx)
+	require.NoError(t, err)
+
+	exists, err := eventStore.StreamExists(stream, ctx)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	all, err := eventStore.ReadAllEvents(ctx, 0, 10, nil)
+	require.NoError(t, err)
+	assert.Empty(t, all)
+
+	err = eventStore.DeleteStream(stream, expectedStreamVersion.Any, ctx)
+	assert.True(t, errors.Is(err, esErrors.ErrStreamNotFound))
+}
+
+func Test_InMemory_Subscribe_To_All_Receives_Stored_And_Appended_Events(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	eventStore := NewInMemoryEventStore()
+	_, err := eventStore.AppendNewEvents(streamName.StreamName("counter-1"), newCounterEvents(1, 2), ctx)
+	require.NoError(t, err)
+
+	subscriptionCtx, stop := context.WithCancel(ctx)
+	events := eventStore.SubscribeToAll(subscriptionCtx, 1, []string{"counter-"})
+
+	_, err = eventStore.AppendNewEvents(streamName.StreamName("other-1"), newCounterEvents(3), ctx)
+	require.NoError(t, err)
+	_, err = eventStore.AppendNewEvents(streamName.StreamName("counter-2"), newCounterEvents(4), ctx)
+	require.NoError(t, err)
+
+	var received []int64
+	for len(received) < 2 {
+		select {
+		case event := <-events:
+			received = append(received, event.Position)
+		case <-ctx.Done():
+			t.Fatal("timeout in receiving the events")
+		}
+	}
+	assert.Equal(t, []int64{2, 4}, received)
+
+	stop()
+	for range events {
+	}
+}
+
+func Test_InMemory_Aggregate_Store_Stores_And_Loads_Aggregates(t *testing.T) {
+	ctx := context.Background()
+	eventStore := NewInMemoryEventStore()
+	aggregateStore := NewInMemoryAggregateStore[*testCounter](eventStore)
+
+	counter := newTestCounter(uuid.NewV4())
+	require.NoError(t, counter.Increment(2))
+	require.NoError(t, counter.Increment(3))
+
+	_, err := aggregateStore.Store(counter, nil, ctx)
+	require.NoError(t, err)
+	assert.False(t, counter.HasUncommittedEvents())
+
+	loaded, err := aggregateStore.Load(ctx, counter.Id())
+	require.NoError(t, err)
+	assert.Equal(t, counter.Id(), loaded.Id())
+	assert.Equal(t, 5, loaded.Count)
+	assert.Equal(t, int64(1), loaded.OriginalVersion())
+
+	// a concurrent change of the loaded aggregate
+	stale, err := aggregateStore.Load(ctx, counter.Id())
+	require.NoError(t, err)
+
+	require.NoError(t, loaded.Increment(1))
+	_, err = aggregateStore.Store(loaded, nil, ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, stale.Increment(1))
+	_, err = aggregateStore.Store(stale, nil, ctx)
+	assert.True(t, errors.Is(err, esErrors.ErrWrongExpectedVersion))
+
+	exists, err := aggregateStore.Exists(ctx, counter.Id())
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	_, err = aggregateStore.Load(ctx, uuid.NewV4())
+	assert.True(t, customErrors.IsNotFoundError(err))
+}
+
+func versions(events []*models.StreamEvent) []int64 {
+	result := make([]int64, 0, len(events))
+	for _, event := range events {
+		result = append(result, event.Version)
+	}
+
+	return result
+}
+
+func positions(events []*models.StreamEvent) []int64 {
+	result := make([]int64, 0, len(events))
+	for _, event := range events {
+		result = append(result, event.Position)
+	}
+
+	return result
+}
@@ -0,0 +1,43 @@
+package es
+
+import (
+	"context"
+	"sync"
+
+	"github.com/reoden/go-NFT/pkg/es/contracts/store"
+	"github.com/reoden/go-NFT/pkg/es/models"
+
+	"emperror.dev/errors"
+)
+
+type inMemorySnapshotStore struct {
+	mu        sync.RWMutex
+	snapshots map[string]*models.Snapshot
+}
+
+func NewInMemorySnapshotStore() store.SnapshotStore {
+	return &inMemorySnapshotStore{snapshots: make(map[string]*models.Snapshot)}
+}
+
+func (i *inMemorySnapshotStore) Load(ctx context.Context, streamId string) (*models.Snapshot, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	snapshot, ok := i.snapshots[streamId]
+	if !ok {
+		return nil, errors.WithStack(store.ErrSnapshotNotFound)
+	}
+	stored := *snapshot
+
+	return &stored, nil
+}
+
+func (i *inMemorySnapshotStore) Save(ctx context.Context, snapshot *models.Snapshot) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	stored := *snapshot
+	i.snapshots[snapshot.StreamId] = &stored
+
+	return nil
+}
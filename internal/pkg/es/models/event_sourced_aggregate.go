@@ -141,6 +141,12 @@ func (a *EventSourcedAggregateRoot) CurrentVersion() int64 {
 	return a.currentVersion
 }
 
+// RestoreVersion sets the original and the current version of the aggregate after restoring its state from a snapshot
+func (a *EventSourcedAggregateRoot) RestoreVersion(version int64) {
+	a.originalVersion = version
+	a.currentVersion = version
+}
+
 func (a *EventSourcedAggregateRoot) AddDomainEvents(event domain.IDomainEvent) error {
 	exists := linq.From(a.uncommittedEvents).AnyWithT(func(e domain.IDomainEvent) bool {
 		return e.GetEventId() == event.GetEventId()
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+)
+
+// IHaveSnapshot this interface should implement by the aggregates that their state can be restored from a snapshot instead of replaying their whole stream
+type IHaveSnapshot interface {
+	// SnapshotSchemaVersion Gets the version of the snapshot state schema, it should increase when the snapshot state changes in an incompatible way
+	// and the snapshots with a different schema version will be ignored on loading.
+	SnapshotSchemaVersion() int
+
+	// CreateSnapshot Gets the current state of the aggregate that will be persisted in the snapshot.
+	CreateSnapshot() interface{}
+
+	// RestoreSnapshot Restores the aggregate state from a snapshot state with the same type as CreateSnapshot result.
+	RestoreSnapshot(snapshot interface{}) error
+
+	// RestoreVersion Sets the original and the current version of the aggregate to the version of the restored snapshot.
+	RestoreVersion(version int64)
+}
+
+// Snapshot persisted state of an aggregate stream at a version, the state is serialized with the event serializer
+type Snapshot struct {
+	StreamId      string `gorm:"primaryKey"`
+	AggregateType string
+	// Version the stream version of the last event that is applied on the snapshot state
+	Version       int64
+	SchemaVersion int
+	ContentType   string
+	Data          []byte
+	CreatedAt     time.Time
+}
+
+func (s *Snapshot) TableName() string {
+	return "snapshots"
+}
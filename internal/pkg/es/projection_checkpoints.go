@@ -0,0 +1,67 @@
+package es
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/reoden/go-NFT/pkg/es/contracts"
+
+	"emperror.dev/errors"
+)
+
+// ProjectionCheckpoints keeps the checkpoints of the rebuildable projections, the events at or before the checkpoint of a
+// projection are already applied to its read model and they are skipped by the projection publisher. The checkpoints are
+// stored in the subscription checkpoints with the `projection-` prefix.
+type ProjectionCheckpoints struct {
+	repository contracts.SubscriptionCheckpointRepository
+	locker     ProjectionLocker
+}
+
+// NewProjectionCheckpoints creates the checkpoints of the projections fed by a single process
+func NewProjectionCheckpoints(repository contracts.SubscriptionCheckpointRepository) *ProjectionCheckpoints {
+	return NewProjectionCheckpointsWithLocker(repository, NewInProcessProjectionLocker())
+}
+
+// NewProjectionCheckpointsWithLocker creates the checkpoints of the projections with the locker shared by the instances
+// of the service
+func NewProjectionCheckpointsWithLocker(
+	repository contracts.SubscriptionCheckpointRepository,
+	locker ProjectionLocker,
+) *ProjectionCheckpoints {
+	return &ProjectionCheckpoints{repository: repository, locker: locker}
+}
+
+// Lock locks a projection for applying the events to its read model, the checkpoint should be loaded after the lock is
+// taken.
+func (p *ProjectionCheckpoints) Lock(ctx context.Context, projectionName string) (unlock func(), err error) {
+	unlock, err = p.locker.Lock(ctx, projectionName)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to lock the projection")
+	}
+
+	return unlock, nil
+}
+
+// Load loads the checkpoint of a projection, it is not cached because a rebuild on another instance moves it
+func (p *ProjectionCheckpoints) Load(ctx context.Context, projectionName string) (uint64, error) {
+	position, err := p.repository.Load(checkpointId(projectionName), ctx)
+	if err != nil {
+		return 0, errors.WrapIf(err, "failed to load projection checkpoint")
+	}
+
+	return position, nil
+}
+
+// Store stores the checkpoint of a projection
+func (p *ProjectionCheckpoints) Store(ctx context.Context, projectionName string, position uint64) error {
+	err := p.repository.Store(checkpointId(projectionName), position, ctx)
+	if err != nil {
+		return errors.WrapIf(err, "failed to store projection checkpoint")
+	}
+
+	return nil
+}
+
+func checkpointId(projectionName string) string {
+	return fmt.Sprintf("projection-%s", projectionName)
+}
@@ -0,0 +1,39 @@
+package es
+
+import (
+	"context"
+	"sync"
+)
+
+// ProjectionLocker locks a projection for applying the events to its read model, the live events of a projection wait
+// for the final phase of its rebuild. The lock should be shared by all the instances of a service that feed the
+// projection.
+type ProjectionLocker interface {
+	// Lock blocks until the lock of projection is taken or the context is done
+	Lock(ctx context.Context, projectionName string) (unlock func(), err error)
+}
+
+type inProcessProjectionLocker struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// NewInProcessProjectionLocker creates a projection locker for the stores fed by a single process, the lock is not
+// shared with the other instances of the service.
+func NewInProcessProjectionLocker() ProjectionLocker {
+	return &inProcessProjectionLocker{locks: make(map[string]*sync.Mutex)}
+}
+
+func (l *inProcessProjectionLocker) Lock(_ context.Context, projectionName string) (func(), error) {
+	l.mu.Lock()
+	lock, ok := l.locks[projectionName]
+	if !ok {
+		lock = &sync.Mutex{}
+		l.locks[projectionName] = lock
+	}
+	l.mu.Unlock()
+
+	lock.Lock()
+
+	return lock.Unlock, nil
+}
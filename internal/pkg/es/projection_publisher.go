@@ -11,12 +11,22 @@ import (
 
 type projectionPublisher struct {
 	projections []projection.IProjection
+	checkpoints *ProjectionCheckpoints
 }
 
 func NewProjectionPublisher(projections []projection.IProjection) projection.IProjectionPublisher {
 	return &projectionPublisher{projections: projections}
 }
 
+// NewProjectionPublisherWithCheckpoints creates a projection publisher that keeps the checkpoints of the rebuildable
+// projections, an event is skipped for a rebuildable projection when it is at or before the checkpoint of projection.
+func NewProjectionPublisherWithCheckpoints(
+	projections []projection.IProjection,
+	checkpoints *ProjectionCheckpoints,
+) projection.IProjectionPublisher {
+	return &projectionPublisher{projections: projections, checkpoints: checkpoints}
+}
+
 func (p projectionPublisher) Publish(ctx context.Context, streamEvent *models.StreamEvent) error {
 	if streamEvent == nil {
 		return nil
@@ -27,6 +37,16 @@ func (p projectionPublisher) Publish(ctx context.Context, streamEvent *models.St
 	}
 
 	for _, pj := range p.projections {
+		rebuildable, ok := pj.(projection.IRebuildableProjection)
+		if ok && p.checkpoints != nil {
+			err := p.publishWithCheckpoint(ctx, rebuildable, streamEvent)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
 		err := pj.ProcessEvent(ctx, streamEvent)
 		if err != nil {
 			return errors.WrapIf(err, "error in processing projection")
@@ -35,3 +55,32 @@ func (p projectionPublisher) Publish(ctx context.Context, streamEvent *models.St
 
 	return nil
 }
+
+func (p projectionPublisher) publishWithCheckpoint(
+	ctx context.Context,
+	pj projection.IRebuildableProjection,
+	streamEvent *models.StreamEvent,
+) error {
+	unlock, err := p.checkpoints.Lock(ctx, pj.ProjectionName())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	checkpoint, err := p.checkpoints.Load(ctx, pj.ProjectionName())
+	if err != nil {
+		return err
+	}
+
+	position := uint64(streamEvent.Position)
+	if position <= checkpoint {
+		return nil
+	}
+
+	err = pj.ProcessEvent(ctx, streamEvent)
+	if err != nil {
+		return errors.WrapIf(err, "error in processing projection")
+	}
+
+	return p.checkpoints.Store(ctx, pj.ProjectionName(), position)
+}
@@ -0,0 +1,479 @@
+package es
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/es/contracts/projection"
+	"github.com/reoden/go-NFT/pkg/es/contracts/store"
+	"github.com/reoden/go-NFT/pkg/es/models"
+	streamName "github.com/reoden/go-NFT/pkg/es/models/stream_name"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/logger"
+
+	"emperror.dev/errors"
+	"github.com/samber/lo"
+)
+
+// ProjectionRebuildState the state of a projection rebuild
+type ProjectionRebuildState string
+
+const (
+	ProjectionRebuildRunning   ProjectionRebuildState = "running"
+	ProjectionRebuildCompleted ProjectionRebuildState = "completed"
+	ProjectionRebuildFailed    ProjectionRebuildState = "failed"
+)
+
+// ProjectionRebuildProgress the progress of the last rebuild of a projection
+type ProjectionRebuildProgress struct {
+	ProjectionName string                 `json:"projectionName"`
+	State          ProjectionRebuildState `json:"state"`
+	// Position the global position of the last replayed event
+	Position uint64 `json:"position"`
+	// HeadPosition the global position of the last event of the store at the start of rebuild
+	HeadPosition    uint64    `json:"headPosition"`
+	ProcessedEvents int64     `json:"processedEvents"`
+	StartedAt       time.Time `json:"startedAt"`
+	CompletedAt     time.Time `json:"completedAt,omitempty"`
+	Error           string    `json:"error,omitempty"`
+}
+
+type ProjectionRebuildOptions struct {
+	// BatchSize number of the events that are read in each read of the store
+	BatchSize int
+	// MaxEventsPerSecond maximum replayed events per second, the replay is not throttled with 0
+	MaxEventsPerSecond int
+}
+
+type ProjectionRebuilder interface {
+	// Rebuild replays the events from the start of the store into an empty shadow of the projection read model and swaps
+	// it with the read model, it blocks until the end of rebuild.
+	Rebuild(
+		ctx context.Context,
+		projectionName string,
+		options *ProjectionRebuildOptions,
+	) (*ProjectionRebuildProgress, error)
+
+	// StartRebuild starts the rebuild of a projection in the background and returns its initial progress
+	StartRebuild(projectionName string, options *ProjectionRebuildOptions) (*ProjectionRebuildProgress, error)
+
+	// Progress returns the progress of the last rebuild of a projection
+	Progress(projectionName string) (*ProjectionRebuildProgress, error)
+
+	// ProjectionNames returns the names of the rebuildable projections
+	ProjectionNames() []string
+}
+
+type projectionRebuilder struct {
+	log         logger.Logger
+	reader      store.AllEventsReader
+	archive     store.StreamArchive
+	checkpoints *ProjectionCheckpoints
+	projections map[string]projection.IRebuildableProjection
+	prefixes    []string
+
+	mu       sync.Mutex
+	progress map[string]*ProjectionRebuildProgress
+}
+
+// NewProjectionRebuilder creates the rebuilder of the rebuildable projections, the events are read with the stream
+// `prefixes` of the subscription that feeds the projections. The events of the archived streams of the `archive` are read
+// from the archive and replayed in the order of their global positions, the streams stay archived. The archive is nil
+// for the stores without the archival.
+func NewProjectionRebuilder(
+	log logger.Logger,
+	reader store.AllEventsReader,
+	archive store.StreamArchive,
+	checkpoints *ProjectionCheckpoints,
+	projections []projection.IProjection,
+	prefixes []string,
+) ProjectionRebuilder {
+	rebuildableProjections := make(map[string]projection.IRebuildableProjection)
+	for _, pj := range projections {
+		if rebuildable, ok := pj.(projection.IRebuildableProjection); ok {
+			rebuildableProjections[rebuildable.ProjectionName()] = rebuildable
+		}
+	}
+
+	return &projectionRebuilder{
+		log:         log,
+		reader:      reader,
+		archive:     archive,
+		checkpoints: checkpoints,
+		projections: rebuildableProjections,
+		prefixes:    prefixes,
+		progress:    make(map[string]*ProjectionRebuildProgress),
+	}
+}
+
+func (r *projectionRebuilder) Rebuild(
+	ctx context.Context,
+	projectionName string,
+	options *ProjectionRebuildOptions,
+) (*ProjectionRebuildProgress, error) {
+	target, err := r.start(projectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.rebuild(ctx, target, options)
+	progress := r.finish(projectionName, err)
+
+	return progress, err
+}
+
+func (r *projectionRebuilder) StartRebuild(
+	projectionName string,
+	options *ProjectionRebuildOptions,
+) (*ProjectionRebuildProgress, error) {
+	target, err := r.start(projectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		// the rebuild is not bound to the request that started it
+		err := r.rebuild(context.Background(), target, options)
+		r.finish(projectionName, err)
+	}()
+
+	return r.Progress(projectionName)
+}
+
+func (r *projectionRebuilder) Progress(projectionName string) (*ProjectionRebuildProgress, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	progress, ok := r.progress[projectionName]
+	if !ok {
+		return nil, customErrors.NewNotFoundError(
+			fmt.Sprintf("no rebuild found for the projection '%s'", projectionName),
+		)
+	}
+	result := *progress
+
+	return &result, nil
+}
+
+func (r *projectionRebuilder) ProjectionNames() []string {
+	names := make([]string, 0, len(r.projections))
+	for name := range r.projections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// start registers a running rebuild for the projection, only one rebuild of a projection runs at a time
+func (r *projectionRebuilder) start(projectionName string) (projection.IRebuildableProjection, error) {
+	target, ok := r.projections[projectionName]
+	if !ok {
+		return nil, customErrors.NewNotFoundError(
+			fmt.Sprintf("rebuildable projection '%s' not found", projectionName),
+		)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if progress, ok := r.progress[projectionName]; ok && progress.State == ProjectionRebuildRunning {
+		return nil, customErrors.NewConflictError(
+			fmt.Sprintf("projection '%s' is already rebuilding", projectionName),
+		)
+	}
+
+	r.progress[projectionName] = &ProjectionRebuildProgress{
+		ProjectionName: projectionName,
+		State:          ProjectionRebuildRunning,
+		StartedAt:      time.Now(),
+	}
+
+	return target, nil
+}
+
+func (r *projectionRebuilder) finish(projectionName string, err error) *ProjectionRebuildProgress {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	progress := r.progress[projectionName]
+	progress.CompletedAt = time.Now()
+	if err != nil {
+		progress.State = ProjectionRebuildFailed
+		progress.Error = err.Error()
+		r.log.Errorf("rebuild of projection '%s' failed: %v", projectionName, err)
+	} else {
+		progress.State = ProjectionRebuildCompleted
+		r.log.Infof(
+			"rebuild of projection '%s' completed, %d events replayed up to position %d",
+			projectionName,
+			progress.ProcessedEvents,
+			progress.Position,
+		)
+	}
+	result := *progress
+
+	return &result
+}
+
+func (r *projectionRebuilder) rebuild(
+	ctx context.Context,
+	target projection.IRebuildableProjection,
+	options *ProjectionRebuildOptions,
+) error {
+	projectionName := target.ProjectionName()
+
+	// the archived events are not read from the store, they are merged into the replay by their global positions
+	archivedStreams, archivedEvents, err := r.readArchivedStreams(ctx)
+	if err != nil {
+		return errors.WrapIf(err, "failed to read the archived streams")
+	}
+	if len(archivedStreams) > 0 {
+		r.log.Infof(
+			"rebuild of projection '%s': %d events of %d archived streams are replayed from the archive",
+			projectionName,
+			len(archivedEvents),
+			len(archivedStreams),
+		)
+	}
+
+	headPosition, err := r.reader.ReadHeadPosition(ctx)
+	if err != nil {
+		return errors.WrapIf(err, "failed to read the head of the store")
+	}
+	r.updateProgress(projectionName, func(progress *ProjectionRebuildProgress) {
+		progress.HeadPosition = headPosition
+	})
+
+	shadow, err := target.PrepareRebuild(ctx)
+	if err != nil {
+		return errors.WrapIf(err, "failed to prepare the shadow of projection")
+	}
+
+	r.log.Infof("rebuild of projection '%s' started, replaying events up to position %d", projectionName, headPosition)
+
+	position, archivedEvents, err := r.replay(ctx, projectionName, shadow, 0, archivedEvents, options)
+	if err != nil {
+		return r.cleanup(ctx, target, err)
+	}
+
+	// the live events of projection wait for the final catch up and the swap, so no event is lost between the replay
+	// and the checkpoint of the rebuilt read model
+	unlock, err := r.checkpoints.Lock(ctx, projectionName)
+	if err != nil {
+		return r.cleanup(ctx, target, err)
+	}
+	defer unlock()
+
+	position, _, err = r.replay(ctx, projectionName, shadow, position, archivedEvents, options)
+	if err != nil {
+		return r.cleanup(ctx, target, err)
+	}
+
+	// the events of a stream archived during the replay may be skipped, so the shadow is not swapped
+	newlyArchived, err := r.newlyArchivedStreams(ctx, archivedStreams)
+	if err != nil {
+		return r.cleanup(ctx, target, errors.WrapIf(err, "failed to read the archived streams"))
+	}
+	if newlyArchived > 0 {
+		return r.cleanup(
+			ctx,
+			target,
+			errors.Errorf("%d streams are archived during the rebuild, the rebuild should be retried", newlyArchived),
+		)
+	}
+
+	err = target.SwapRebuild(ctx)
+	if err != nil {
+		return r.cleanup(ctx, target, errors.WrapIf(err, "failed to swap the shadow of projection"))
+	}
+
+	return r.checkpoints.Store(ctx, projectionName, position)
+}
+
+// readArchivedStreams reads the events of the archived streams in the order of their global positions
+func (r *projectionRebuilder) readArchivedStreams(
+	ctx context.Context,
+) (map[streamName.StreamName]bool, []*models.StreamEvent, error) {
+	archivedStreams := make(map[streamName.StreamName]bool)
+	if r.archive == nil {
+		return archivedStreams, nil, nil
+	}
+
+	streamNames, err := r.archive.ArchivedStreams(ctx, r.prefixes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var archivedEvents []*models.StreamEvent
+	for _, name := range streamNames {
+		streamEvents, err := r.archive.ReadArchivedEvents(ctx, name)
+		if err != nil {
+			return nil, nil, err
+		}
+		archivedStreams[name] = true
+		archivedEvents = append(archivedEvents, streamEvents...)
+	}
+
+	sort.Slice(archivedEvents, func(i, j int) bool {
+		return archivedEvents[i].Position < archivedEvents[j].Position
+	})
+
+	return archivedStreams, archivedEvents, nil
+}
+
+// newlyArchivedStreams returns the number of the streams that are archived after the start of the rebuild
+func (r *projectionRebuilder) newlyArchivedStreams(
+	ctx context.Context,
+	archivedStreams map[streamName.StreamName]bool,
+) (int, error) {
+	if r.archive == nil {
+		return 0, nil
+	}
+
+	streamNames, err := r.archive.ArchivedStreams(ctx, r.prefixes)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(lo.Filter(streamNames, func(name streamName.StreamName, _ int) bool {
+		return !archivedStreams[name]
+	})), nil
+}
+
+// replay replays the events after the position into the shadow until the head of the store, the archived events are
+// merged by their global positions. It returns the position of the last replayed event and the archived events that
+// are not replayed yet.
+func (r *projectionRebuilder) replay(
+	ctx context.Context,
+	projectionName string,
+	shadow projection.IProjection,
+	position uint64,
+	archivedEvents []*models.StreamEvent,
+	options *ProjectionRebuildOptions,
+) (uint64, []*models.StreamEvent, error) {
+	batchSize := options.BatchSize
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+
+	for {
+		streamEvents, err := r.reader.ReadAllEvents(ctx, position, batchSize, r.prefixes)
+		if err != nil {
+			return position, archivedEvents, errors.WrapIf(err, "failed to read the events")
+		}
+
+		// the archived events are replayed up to the last read event, all of them after the last batch
+		var batch []*models.StreamEvent
+		if len(streamEvents) < batchSize {
+			batch, archivedEvents = mergeEvents(streamEvents, archivedEvents, math.MaxInt64)
+		} else {
+			batch, archivedEvents = mergeEvents(streamEvents, archivedEvents, streamEvents[len(streamEvents)-1].Position)
+		}
+
+		for _, streamEvent := range batch {
+			err := shadow.ProcessEvent(ctx, streamEvent)
+			if err != nil {
+				return position, archivedEvents, errors.WrapIf(err, "error in processing projection")
+			}
+			if uint64(streamEvent.Position) > position {
+				position = uint64(streamEvent.Position)
+			}
+		}
+
+		var progress ProjectionRebuildProgress
+		r.updateProgress(projectionName, func(p *ProjectionRebuildProgress) {
+			p.Position = position
+			p.ProcessedEvents += int64(len(batch))
+			progress = *p
+		})
+
+		if len(streamEvents) < batchSize {
+			return position, archivedEvents, nil
+		}
+
+		r.log.Infof(
+			"rebuild of projection '%s': %d events replayed, position %d of %d",
+			projectionName,
+			progress.ProcessedEvents,
+			progress.Position,
+			progress.HeadPosition,
+		)
+
+		err = throttle(ctx, progress.StartedAt, progress.ProcessedEvents, options.MaxEventsPerSecond)
+		if err != nil {
+			return position, archivedEvents, err
+		}
+	}
+}
+
+// mergeEvents merges the read events with the archived events up to the position in the order of their global
+// positions and returns the archived events after the position. An event of a stream that is rehydrated during the
+// rebuild is read from both of them, it is replayed once.
+func mergeEvents(
+	streamEvents []*models.StreamEvent,
+	archivedEvents []*models.StreamEvent,
+	upToPosition int64,
+) ([]*models.StreamEvent, []*models.StreamEvent) {
+	merged := make([]*models.StreamEvent, 0, len(streamEvents))
+	for _, streamEvent := range streamEvents {
+		for len(archivedEvents) > 0 && archivedEvents[0].Position <= streamEvent.Position {
+			if archivedEvents[0].Position < streamEvent.Position {
+				merged = append(merged, archivedEvents[0])
+			}
+			archivedEvents = archivedEvents[1:]
+		}
+		merged = append(merged, streamEvent)
+	}
+
+	for len(archivedEvents) > 0 && archivedEvents[0].Position <= upToPosition {
+		merged = append(merged, archivedEvents[0])
+		archivedEvents = archivedEvents[1:]
+	}
+
+	return merged, archivedEvents
+}
+
+func (r *projectionRebuilder) cleanup(
+	ctx context.Context,
+	target projection.IRebuildableProjection,
+	rebuildErr error,
+) error {
+	err := target.CleanupRebuild(ctx)
+	if err != nil {
+		r.log.Errorf("failed to cleanup the shadow of projection '%s': %v", target.ProjectionName(), err)
+	}
+
+	return rebuildErr
+}
+
+func (r *projectionRebuilder) updateProgress(projectionName string, update func(progress *ProjectionRebuildProgress)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	update(r.progress[projectionName])
+}
+
+// throttle waits until the rate of the processed events is under the maximum events per second
+func throttle(ctx context.Context, startedAt time.Time, processedEvents int64, maxEventsPerSecond int) error {
+	if maxEventsPerSecond <= 0 {
+		return nil
+	}
+
+	expected := time.Duration(processedEvents) * time.Second / time.Duration(maxEventsPerSecond)
+	wait := expected - time.Since(startedAt)
+	if wait <= 0 {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(wait):
+		return nil
+	}
+}
@@ -0,0 +1,260 @@
+//go:build unit
+// +build unit
+
+package es
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/es/contracts/projection"
+	"github.com/reoden/go-NFT/pkg/es/models"
+	streamName "github.com/reoden/go-NFT/pkg/es/models/stream_name"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/logger/empty"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAllEventsReader struct {
+	events []*models.StreamEvent
+}
+
+func (f *fakeAllEventsReader) ReadAllEvents(
+	ctx context.Context,
+	fromGlobalPosition uint64,
+	count int,
+	prefixes []string,
+) ([]*models.StreamEvent, error) {
+	var result []*models.StreamEvent
+	for _, event := range f.events {
+		if uint64(event.Position) > fromGlobalPosition && len(result) < count {
+			result = append(result, event)
+		}
+	}
+
+	return result, nil
+}
+
+func (f *fakeAllEventsReader) ReadHeadPosition(ctx context.Context) (uint64, error) {
+	if len(f.events) == 0 {
+		return 0, nil
+	}
+
+	return uint64(f.events[len(f.events)-1].Position), nil
+}
+
+// fakeStreamArchive keeps the events of the archived streams out of the reader, a stream is archived after the first
+// read of the archived streams when archiveDuringRebuild is set
+type fakeStreamArchive struct {
+	reader               *fakeAllEventsReader
+	archived             map[streamName.StreamName][]*models.StreamEvent
+	archiveDuringRebuild bool
+}
+
+func (f *fakeStreamArchive) Rehydrate(ctx context.Context, streamName streamName.StreamName) (bool, error) {
+	return false, nil
+}
+
+func (f *fakeStreamArchive) ArchivedStreams(ctx context.Context, prefixes []string) ([]streamName.StreamName, error) {
+	streamNames := make([]streamName.StreamName, 0, len(f.archived))
+	for name := range f.archived {
+		streamNames = append(streamNames, name)
+	}
+
+	if f.archiveDuringRebuild {
+		f.archived["archived-during-rebuild"] = f.reader.events[:1]
+		f.reader.events = f.reader.events[1:]
+		f.archiveDuringRebuild = false
+	}
+
+	return streamNames, nil
+}
+
+func (f *fakeStreamArchive) ReadArchivedEvents(
+	ctx context.Context,
+	streamName streamName.StreamName,
+) ([]*models.StreamEvent, error) {
+	return f.archived[streamName], nil
+}
+
+// positionsProjection keeps the positions of the projected events in a live and a shadow read model
+type positionsProjection struct {
+	live      []int64
+	shadow    []int64
+	failAt    int64
+	cleanedUp bool
+}
+
+type shadowPositionsProjection struct {
+	parent *positionsProjection
+}
+
+func (p *positionsProjection) ProcessEvent(ctx context.Context, streamEvent *models.StreamEvent) error {
+	p.live = append(p.live, streamEvent.Position)
+
+	return nil
+}
+
+func (p *positionsProjection) ProjectionName() string {
+	return "positions"
+}
+
+func (p *positionsProjection) PrepareRebuild(ctx context.Context) (projection.IProjection, error) {
+	p.shadow = []int64{}
+
+	return &shadowPositionsProjection{parent: p}, nil
+}
+
+func (p *positionsProjection) SwapRebuild(ctx context.Context) error {
+	p.live, p.shadow = p.shadow, nil
+
+	return nil
+}
+
+func (p *positionsProjection) CleanupRebuild(ctx context.Context) error {
+	p.shadow = nil
+	p.cleanedUp = true
+
+	return nil
+}
+
+func (s *shadowPositionsProjection) ProcessEvent(ctx context.Context, streamEvent *models.StreamEvent) error {
+	if s.parent.failAt == streamEvent.Position {
+		return errors.New("projection failed")
+	}
+	s.parent.shadow = append(s.parent.shadow, streamEvent.Position)
+
+	return nil
+}
+
+func newStreamEvents(positions ...int64) []*models.StreamEvent {
+	events := make([]*models.StreamEvent, 0, len(positions))
+	for _, position := range positions {
+		events = append(events, &models.StreamEvent{Position: position})
+	}
+
+	return events
+}
+
+func Test_Projection_Rebuild_Replays_Into_Shadow_And_Swaps(t *testing.T) {
+	ctx := context.Background()
+	reader := &fakeAllEventsReader{events: newStreamEvents(1, 2, 3, 4, 5)}
+	checkpoints := NewProjectionCheckpoints(NewInMemorySubscriptionCheckpointRepository())
+	positions := &positionsProjection{live: []int64{1, 3}}
+
+	rebuilder := NewProjectionRebuilder(
+		empty.EmptyLogger,
+		reader,
+		nil,
+		checkpoints,
+		[]projection.IProjection{positions},
+		nil,
+	)
+	assert.Equal(t, []string{"positions"}, rebuilder.ProjectionNames())
+
+	progress, err := rebuilder.Rebuild(ctx, "positions", &ProjectionRebuildOptions{BatchSize: 2})
+	require.NoError(t, err)
+
+	assert.Equal(t, ProjectionRebuildCompleted, progress.State)
+	assert.Equal(t, int64(5), progress.ProcessedEvents)
+	assert.Equal(t, uint64(5), progress.Position)
+	assert.Equal(t, uint64(5), progress.HeadPosition)
+	assert.Equal(t, []int64{1, 2, 3, 4, 5}, positions.live)
+
+	checkpoint, err := checkpoints.Load(ctx, "positions")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), checkpoint)
+
+	// the live subscription skips the replayed events
+	publisher := NewProjectionPublisherWithCheckpoints([]projection.IProjection{positions}, checkpoints)
+	for _, event := range newStreamEvents(4, 5, 6) {
+		require.NoError(t, publisher.Publish(ctx, event))
+	}
+	assert.Equal(t, []int64{1, 2, 3, 4, 5, 6}, positions.live)
+}
+
+func Test_Projection_Rebuild_Failure_Keeps_Read_Model(t *testing.T) {
+	ctx := context.Background()
+	reader := &fakeAllEventsReader{events: newStreamEvents(1, 2, 3)}
+	checkpoints := NewProjectionCheckpoints(NewInMemorySubscriptionCheckpointRepository())
+	positions := &positionsProjection{live: []int64{1, 2, 3}, failAt: 2}
+
+	rebuilder := NewProjectionRebuilder(
+		empty.EmptyLogger,
+		reader,
+		nil,
+		checkpoints,
+		[]projection.IProjection{positions},
+		nil,
+	)
+
+	progress, err := rebuilder.Rebuild(ctx, "positions", &ProjectionRebuildOptions{})
+	require.Error(t, err)
+
+	assert.Equal(t, ProjectionRebuildFailed, progress.State)
+	assert.True(t, positions.cleanedUp)
+	assert.Equal(t, []int64{1, 2, 3}, positions.live)
+
+	_, err = rebuilder.Rebuild(ctx, "unknown", &ProjectionRebuildOptions{})
+	assert.True(t, customErrors.IsNotFoundError(err))
+}
+
+func Test_Projection_Rebuild_Replays_Archived_Streams(t *testing.T) {
+	ctx := context.Background()
+	reader := &fakeAllEventsReader{events: newStreamEvents(2, 4, 5, 7)}
+	archive := &fakeStreamArchive{
+		reader: reader,
+		archived: map[streamName.StreamName][]*models.StreamEvent{
+			"account-1": newStreamEvents(1, 3),
+			// a stream rehydrated during the rebuild is read from both of the store and the archive
+			"account-2": newStreamEvents(5, 6),
+		},
+	}
+	checkpoints := NewProjectionCheckpoints(NewInMemorySubscriptionCheckpointRepository())
+	positions := &positionsProjection{}
+
+	rebuilder := NewProjectionRebuilder(
+		empty.EmptyLogger,
+		reader,
+		archive,
+		checkpoints,
+		[]projection.IProjection{positions},
+		nil,
+	)
+
+	progress, err := rebuilder.Rebuild(ctx, "positions", &ProjectionRebuildOptions{BatchSize: 2})
+	require.NoError(t, err)
+	assert.Equal(t, ProjectionRebuildCompleted, progress.State)
+	assert.Equal(t, int64(7), progress.ProcessedEvents)
+	assert.Equal(t, uint64(7), progress.Position)
+	assert.Equal(t, []int64{1, 2, 3, 4, 5, 6, 7}, positions.live)
+
+	// the archived streams are replayed from the archive and stay archived
+	assert.Len(t, archive.archived, 2)
+	assert.Len(t, reader.events, 4)
+
+	// a stream archived during the replay fails the rebuild instead of swapping a read model without its events
+	archive.archiveDuringRebuild = true
+	progress, err = rebuilder.Rebuild(ctx, "positions", &ProjectionRebuildOptions{})
+	require.Error(t, err)
+	assert.Equal(t, ProjectionRebuildFailed, progress.State)
+	assert.True(t, positions.cleanedUp)
+	assert.Equal(t, []int64{1, 2, 3, 4, 5, 6, 7}, positions.live)
+}
+
+func Test_Projection_Rebuild_Is_Throttled(t *testing.T) {
+	ctx := context.Background()
+	startedAt := time.Now()
+
+	require.NoError(t, throttle(ctx, startedAt, 10, 100))
+	assert.GreaterOrEqual(t, time.Since(startedAt), 100*time.Millisecond)
+
+	// no wait without a maximum rate
+	startedAt = time.Now()
+	require.NoError(t, throttle(ctx, startedAt, 1000, 0))
+	assert.Less(t, time.Since(startedAt), 50*time.Millisecond)
+}
@@ -0,0 +1,91 @@
+package es
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/core/serializer"
+	"github.com/reoden/go-NFT/pkg/es/models"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+
+	"emperror.dev/errors"
+)
+
+// ShouldTakeSnapshot reports whether the stored events of an aggregate crossed a multiple of the snapshot frequency,
+// the stream versions start from 0 so the number of the events in the stream is the version + 1.
+func ShouldTakeSnapshot(cfg *EventStoreConfig, previousVersion int64, currentVersion int64) bool {
+	if cfg == nil || cfg.SnapshotFrequency <= 0 || currentVersion <= previousVersion {
+		return false
+	}
+
+	return (currentVersion+1)/cfg.SnapshotFrequency > (previousVersion+1)/cfg.SnapshotFrequency
+}
+
+// CreateSnapshot creates a snapshot of the aggregate state at the version, it returns nil when the aggregate doesn't support snapshots
+func CreateSnapshot(
+	aggregate models.IHaveEventSourcedAggregate,
+	streamId string,
+	version int64,
+	eventSerializer serializer.EventSerializer,
+) (*models.Snapshot, error) {
+	snapshotAggregate, ok := aggregate.(models.IHaveSnapshot)
+	if !ok {
+		return nil, nil
+	}
+
+	data, err := eventSerializer.Serializer().Marshal(snapshotAggregate.CreateSnapshot())
+	if err != nil {
+		return nil, errors.WrapIf(err, "[CreateSnapshot:Marshal] error in serializing the snapshot state")
+	}
+
+	return &models.Snapshot{
+		StreamId:      streamId,
+		AggregateType: typeMapper.GetNonePointerTypeName(aggregate),
+		Version:       version,
+		SchemaVersion: snapshotAggregate.SnapshotSchemaVersion(),
+		ContentType:   eventSerializer.ContentType(),
+		Data:          data,
+		CreatedAt:     time.Now(),
+	}, nil
+}
+
+// RestoreSnapshot restores the aggregate state and version from the snapshot, it returns false without changing the aggregate
+// when the aggregate doesn't support snapshots or the snapshot is created with another schema version.
+func RestoreSnapshot(
+	aggregate models.IHaveEventSourcedAggregate,
+	snapshot *models.Snapshot,
+	eventSerializer serializer.EventSerializer,
+) (bool, error) {
+	snapshotAggregate, ok := aggregate.(models.IHaveSnapshot)
+	if !ok || snapshot == nil || snapshot.SchemaVersion != snapshotAggregate.SnapshotSchemaVersion() {
+		return false, nil
+	}
+
+	// the snapshot state is deserialized to a new instance with the type of the aggregate snapshot state
+	stateType := reflect.TypeOf(snapshotAggregate.CreateSnapshot())
+	if stateType == nil {
+		return false, nil
+	}
+
+	var state interface{}
+	if stateType.Kind() == reflect.Ptr {
+		statePtr := reflect.New(stateType.Elem()).Interface()
+		if err := eventSerializer.Serializer().Unmarshal(snapshot.Data, statePtr); err != nil {
+			return false, errors.WrapIf(err, "[RestoreSnapshot:Unmarshal] error in deserializing the snapshot state")
+		}
+		state = statePtr
+	} else {
+		statePtr := reflect.New(stateType)
+		if err := eventSerializer.Serializer().Unmarshal(snapshot.Data, statePtr.Interface()); err != nil {
+			return false, errors.WrapIf(err, "[RestoreSnapshot:Unmarshal] error in deserializing the snapshot state")
+		}
+		state = statePtr.Elem().Interface()
+	}
+
+	if err := snapshotAggregate.RestoreSnapshot(state); err != nil {
+		return false, errors.WrapIf(err, "[RestoreSnapshot:RestoreSnapshot] error in restoring the snapshot state")
+	}
+	snapshotAggregate.RestoreVersion(snapshot.Version)
+
+	return true, nil
+}
@@ -0,0 +1,139 @@
+package es
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/es/contracts"
+
+	"emperror.dev/errors"
+)
+
+// SubscriptionCheckpointer stores the checkpoints of a subscription in batches, a checkpoint is stored after every
+// `interval` handled events or when `flushInterval` is passed from the last stored checkpoint. The events after the last
+// stored checkpoint are handled again after a restart, so the projections should be idempotent.
+type SubscriptionCheckpointer struct {
+	repository     contracts.SubscriptionCheckpointRepository
+	subscriptionId string
+	interval       int
+	flushInterval  time.Duration
+
+	mu               sync.Mutex
+	position         uint64
+	storedPosition   uint64
+	pendingEvents    int
+	lastCheckpointAt time.Time
+}
+
+func NewSubscriptionCheckpointer(
+	repository contracts.SubscriptionCheckpointRepository,
+	subscriptionId string,
+	interval int,
+	flushInterval time.Duration,
+) *SubscriptionCheckpointer {
+	if interval <= 0 {
+		interval = 1
+	}
+
+	return &SubscriptionCheckpointer{
+		repository:       repository,
+		subscriptionId:   subscriptionId,
+		interval:         interval,
+		flushInterval:    flushInterval,
+		lastCheckpointAt: time.Now(),
+	}
+}
+
+// Load loads the stored checkpoint of the subscription
+func (c *SubscriptionCheckpointer) Load(ctx context.Context) (uint64, error) {
+	position, err := c.repository.Load(c.subscriptionId, ctx)
+	if err != nil {
+		return 0, errors.WrapIf(err, "failed to load subscription checkpoint")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.position = position
+	c.storedPosition = position
+	c.pendingEvents = 0
+
+	return position, nil
+}
+
+// EventHandled records the position of a handled event and stores the checkpoint when the batch is full
+func (c *SubscriptionCheckpointer) EventHandled(ctx context.Context, position uint64) error {
+	c.mu.Lock()
+	c.position = position
+	c.pendingEvents++
+	shouldStore := c.pendingEvents >= c.interval ||
+		(c.flushInterval > 0 && time.Since(c.lastCheckpointAt) >= c.flushInterval)
+	c.mu.Unlock()
+
+	if !shouldStore {
+		return nil
+	}
+
+	return c.Flush(ctx)
+}
+
+// PositionReached records a position of the store without a handled event, like the checkpoints of a filtered subscription,
+// it is only stored with the next flush.
+func (c *SubscriptionCheckpointer) PositionReached(ctx context.Context, position uint64) error {
+	c.mu.Lock()
+	if position <= c.position {
+		c.mu.Unlock()
+
+		return nil
+	}
+	c.position = position
+	shouldStore := c.flushInterval > 0 && time.Since(c.lastCheckpointAt) >= c.flushInterval
+	c.mu.Unlock()
+
+	if !shouldStore {
+		return nil
+	}
+
+	return c.Flush(ctx)
+}
+
+// Flush stores the position of the last handled event when it is not stored yet
+func (c *SubscriptionCheckpointer) Flush(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.position == c.storedPosition {
+		c.pendingEvents = 0
+		c.lastCheckpointAt = time.Now()
+
+		return nil
+	}
+
+	err := c.repository.Store(c.subscriptionId, c.position, ctx)
+	if err != nil {
+		return errors.WrapIf(err, "failed to store subscription checkpoint")
+	}
+
+	c.storedPosition = c.position
+	c.pendingEvents = 0
+	c.lastCheckpointAt = time.Now()
+
+	return nil
+}
+
+// Position the position of the last handled event
+func (c *SubscriptionCheckpointer) Position() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.position
+}
+
+// StoredPosition the last stored checkpoint
+func (c *SubscriptionCheckpointer) StoredPosition() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.storedPosition
+}
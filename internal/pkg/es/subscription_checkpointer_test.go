@@ -0,0 +1,69 @@
+//go:build unit
+// +build unit
+
+package es
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Subscription_Checkpointer_Stores_Checkpoints_In_Batches(t *testing.T) {
+	ctx := context.Background()
+	repository := NewInMemorySubscriptionCheckpointRepository()
+	require.NoError(t, repository.Store("orders", 10, ctx))
+
+	checkpointer := NewSubscriptionCheckpointer(repository, "orders", 3, time.Hour)
+
+	position, err := checkpointer.Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), position)
+
+	require.NoError(t, checkpointer.EventHandled(ctx, 11))
+	require.NoError(t, checkpointer.EventHandled(ctx, 12))
+
+	stored, err := repository.Load("orders", ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), stored)
+	assert.Equal(t, uint64(12), checkpointer.Position())
+
+	require.NoError(t, checkpointer.EventHandled(ctx, 13))
+
+	stored, err = repository.Load("orders", ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(13), stored)
+	assert.Equal(t, uint64(13), checkpointer.StoredPosition())
+}
+
+func Test_Subscription_Checkpointer_Stores_Reached_Positions_On_Flush(t *testing.T) {
+	ctx := context.Background()
+	repository := NewInMemorySubscriptionCheckpointRepository()
+	checkpointer := NewSubscriptionCheckpointer(repository, "orders", 100, time.Hour)
+
+	require.NoError(t, checkpointer.EventHandled(ctx, 5))
+	require.NoError(t, checkpointer.PositionReached(ctx, 20))
+	// the older positions are ignored
+	require.NoError(t, checkpointer.PositionReached(ctx, 15))
+
+	stored, err := repository.Load("orders", ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), stored)
+
+	require.NoError(t, checkpointer.Flush(ctx))
+
+	stored, err = repository.Load("orders", ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(20), stored)
+}
+
+func Test_Resubscribe_Delay_Is_Exponential_And_Capped(t *testing.T) {
+	assert.Equal(t, 100*time.Millisecond, ResubscribeDelay(1, 100*time.Millisecond, time.Second))
+	assert.Equal(t, 200*time.Millisecond, ResubscribeDelay(2, 100*time.Millisecond, time.Second))
+	assert.Equal(t, 800*time.Millisecond, ResubscribeDelay(4, 100*time.Millisecond, time.Second))
+	assert.Equal(t, time.Second, ResubscribeDelay(5, 100*time.Millisecond, time.Second))
+	assert.Equal(t, time.Second, ResubscribeDelay(50, 100*time.Millisecond, time.Second))
+}
@@ -0,0 +1,54 @@
+package es
+
+import (
+	"context"
+
+	"github.com/reoden/go-NFT/pkg/health/contracts"
+
+	"emperror.dev/errors"
+)
+
+type subscriptionLagHealthChecker struct {
+	name     string
+	provider SubscriptionStatusProvider
+	maxLag   uint64
+}
+
+// NewSubscriptionLagHealthChecker creates a health check that is down when the subscription is dropped or when it is behind
+// the head of the store by more than `maxLag` positions, the lag is not checked with 0 `maxLag`.
+func NewSubscriptionLagHealthChecker(
+	name string,
+	provider SubscriptionStatusProvider,
+	maxLag uint64,
+) contracts.Health {
+	return &subscriptionLagHealthChecker{name: name, provider: provider, maxLag: maxLag}
+}
+
+func (s *subscriptionLagHealthChecker) CheckHealth(ctx context.Context) error {
+	status, err := s.provider.SubscriptionStatus(ctx)
+	if err != nil {
+		return errors.WrapIf(err, "error in getting the subscription status")
+	}
+
+	if status.State == SubscriptionResubscribing {
+		return errors.Errorf(
+			"subscription '%s' is dropped and resubscribing: %s",
+			status.SubscriptionId,
+			status.LastError,
+		)
+	}
+
+	if s.maxLag > 0 && status.Lag() > s.maxLag {
+		return errors.Errorf(
+			"subscription '%s' is %d positions behind the head of the store",
+			status.SubscriptionId,
+			status.Lag(),
+		)
+	}
+
+	return nil
+}
+
+func (s *subscriptionLagHealthChecker) GetHealthName() string {
+	return s.name
+}
@@ -0,0 +1,43 @@
+//go:build unit
+// +build unit
+
+package es
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type staticSubscriptionStatusProvider struct {
+	status *SubscriptionStatus
+}
+
+func (s *staticSubscriptionStatusProvider) SubscriptionStatus(ctx context.Context) (*SubscriptionStatus, error) {
+	return s.status, nil
+}
+
+func Test_Subscription_Lag_Health(t *testing.T) {
+	provider := &staticSubscriptionStatusProvider{
+		status: &SubscriptionStatus{
+			SubscriptionId: "orders",
+			State:          SubscriptionLive,
+			Position:       90,
+			HeadPosition:   100,
+		},
+	}
+	checker := NewSubscriptionLagHealthChecker("orders-lag", provider, 10)
+
+	assert.NoError(t, checker.CheckHealth(context.Background()))
+
+	provider.status.Position = 80
+	assert.Error(t, checker.CheckHealth(context.Background()))
+
+	// the lag is not checked without a maximum lag
+	assert.NoError(t, NewSubscriptionLagHealthChecker("orders-lag", provider, 0).CheckHealth(context.Background()))
+
+	provider.status.Position = 100
+	provider.status.State = SubscriptionResubscribing
+	assert.Error(t, checker.CheckHealth(context.Background()))
+}
@@ -0,0 +1,64 @@
+package es
+
+import (
+	"context"
+	"time"
+)
+
+// SubscriptionState the state of a subscription to all
+type SubscriptionState string
+
+const (
+	// SubscriptionStopped the subscription is not started or its context is done
+	SubscriptionStopped SubscriptionState = "stopped"
+	// SubscriptionCatchingUp the subscription is reading the events that are stored before its start
+	SubscriptionCatchingUp SubscriptionState = "catching-up"
+	// SubscriptionLive the subscription reached the head of the store and receives the new events
+	SubscriptionLive SubscriptionState = "live"
+	// SubscriptionResubscribing the subscription is dropped and waits for its next attempt
+	SubscriptionResubscribing SubscriptionState = "resubscribing"
+)
+
+// SubscriptionStatus the progress of a subscription to all, the positions are the global positions of the store
+type SubscriptionStatus struct {
+	SubscriptionId string
+	State          SubscriptionState
+	// Position the position of the last handled event
+	Position uint64
+	// CheckpointPosition the last stored checkpoint of the subscription
+	CheckpointPosition uint64
+	// HeadPosition the position of the last event of the store
+	HeadPosition uint64
+	// Resubscriptions number of the resubscriptions after the drops of subscription
+	Resubscriptions int
+	LastError       string
+	LastEventAt     time.Time
+}
+
+// Lag the distance between the head of the store and the last handled event
+func (s *SubscriptionStatus) Lag() uint64 {
+	if s.HeadPosition <= s.Position {
+		return 0
+	}
+
+	return s.HeadPosition - s.Position
+}
+
+// SubscriptionStatusProvider provides the current status of a subscription to all
+type SubscriptionStatusProvider interface {
+	SubscriptionStatus(ctx context.Context) (*SubscriptionStatus, error)
+}
+
+// ResubscribeDelay the exponential backoff delay of a resubscribe attempt, the attempts start from 1
+func ResubscribeDelay(attempt int, minDelay time.Duration, maxDelay time.Duration) time.Duration {
+	delay := minDelay
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > maxDelay {
+		return maxDelay
+	}
+
+	return delay
+}
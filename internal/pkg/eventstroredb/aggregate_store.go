@@ -7,6 +7,7 @@ import (
 
 	"github.com/reoden/go-NFT/pkg/core/domain"
 	"github.com/reoden/go-NFT/pkg/core/metadata"
+	"github.com/reoden/go-NFT/pkg/es"
 	"github.com/reoden/go-NFT/pkg/es/contracts/store"
 	"github.com/reoden/go-NFT/pkg/es/models"
 	appendResult "github.com/reoden/go-NFT/pkg/es/models/append_result"
@@ -28,23 +29,31 @@ import (
 )
 
 type esdbAggregateStore[T models.IHaveEventSourcedAggregate] struct {
-	log        logger.Logger
-	eventStore store.EventStore
-	serializer *EsdbSerializer
-	tracer     trace.Tracer
+	log           logger.Logger
+	eventStore    store.EventStore
+	serializer    *EsdbSerializer
+	tracer        trace.Tracer
+	snapshotStore store.SnapshotStore
+	config        *es.EventStoreConfig
 }
 
+// NewEventStoreAggregateStore creates an aggregate store on the event store, when a snapshot store is provided the aggregates
+// that implement models.IHaveSnapshot are snapshotted every `SnapshotFrequency` events and loaded from their latest snapshot.
 func NewEventStoreAggregateStore[T models.IHaveEventSourcedAggregate](
 	log logger.Logger,
 	eventStore store.EventStore,
 	serializer *EsdbSerializer,
 	tracer trace.Tracer,
+	snapshotStore store.SnapshotStore,
+	config *es.EventStoreConfig,
 ) store.AggregateStore[T] {
 	return &esdbAggregateStore[T]{
-		log:        log,
-		eventStore: eventStore,
-		serializer: serializer,
-		tracer:     tracer,
+		log:           log,
+		eventStore:    eventStore,
+		serializer:    serializer,
+		tracer:        tracer,
+		snapshotStore: snapshotStore,
+		config:        config,
 	}
 }
 
@@ -92,6 +101,8 @@ func (a *esdbAggregateStore[T]) StoreWithVersion(
 		}).
 		ToSlice(&streamEvents)
 
+	previousVersion := aggregate.CurrentVersion() - int64(len(aggregate.UncommittedEvents()))
+
 	streamAppendResult, err := a.eventStore.AppendEvents(
 		streamId,
 		expectedVersion,
@@ -111,6 +122,8 @@ func (a *esdbAggregateStore[T]) StoreWithVersion(
 
 	aggregate.MarkUncommittedEventAsCommitted()
 
+	a.saveSnapshot(ctx, aggregate, streamId, previousVersion)
+
 	span.SetAttributes(attribute.Object("Aggregate", aggregate))
 
 	a.log.Infow(
@@ -206,8 +219,22 @@ func (a *esdbAggregateStore[T]) LoadWithReadPosition(
 	streamId := streamName.ForID[T](aggregateId)
 	span.SetAttributes(attribute2.String("StreamId", streamId.String()))
 
+	// snapshots only used when the whole state of aggregate is requested
+	restoredFromSnapshot := false
+	if position.IsStart() {
+		snapshotVersion, restored := a.restoreSnapshot(ctx, aggregate, streamId)
+		if restored {
+			restoredFromSnapshot = true
+			position = readPosition.FromInt64(snapshotVersion + 1)
+			span.SetAttributes(attribute2.Int64("SnapshotVersion", snapshotVersion))
+		} else {
+			// the state of a failed restore is discarded
+			method.Call([]reflect.Value{})
+		}
+	}
+
 	streamEvents, err := a.getStreamEvents(streamId, position, ctx)
-	if errors.Is(err, esdb.ErrStreamNotFound) || len(streamEvents) == 0 {
+	if errors.Is(err, esdb.ErrStreamNotFound) || (len(streamEvents) == 0 && !restoredFromSnapshot) {
 		return *new(T), utils.TraceErrStatusFromSpan(
 			span,
 			errors.WithMessage(
@@ -270,6 +297,77 @@ func (a *esdbAggregateStore[T]) Exists(
 	return a.eventStore.StreamExists(streamId, ctx)
 }
 
+func (a *esdbAggregateStore[T]) saveSnapshot(
+	ctx context.Context,
+	aggregate T,
+	streamId streamName.StreamName,
+	previousVersion int64,
+) {
+	if a.snapshotStore == nil ||
+		!es.ShouldTakeSnapshot(a.config, previousVersion, aggregate.CurrentVersion()) {
+		return
+	}
+
+	snapshot, err := es.CreateSnapshot(
+		aggregate,
+		streamId.String(),
+		aggregate.CurrentVersion(),
+		a.serializer.eventSerializer,
+	)
+	if err == nil && snapshot != nil {
+		err = a.snapshotStore.Save(ctx, snapshot)
+	}
+
+	// the events are already stored, so a failed snapshot only makes the next loads slower
+	if err != nil {
+		a.log.Errorw(
+			fmt.Sprintf(
+				"[esdbAggregateStore.saveSnapshot] error in saving snapshot of stream %s: %v",
+				streamId.String(),
+				err,
+			),
+			logger.Fields{"StreamId": streamId.String(), "Version": aggregate.CurrentVersion()},
+		)
+	}
+}
+
+func (a *esdbAggregateStore[T]) restoreSnapshot(
+	ctx context.Context,
+	aggregate T,
+	streamId streamName.StreamName,
+) (int64, bool) {
+	if a.snapshotStore == nil {
+		return 0, false
+	}
+
+	snapshot, err := a.snapshotStore.Load(ctx, streamId.String())
+	if errors.Is(err, store.ErrSnapshotNotFound) {
+		return 0, false
+	}
+
+	restored := false
+	if err == nil {
+		restored, err = es.RestoreSnapshot(aggregate, snapshot, a.serializer.eventSerializer)
+	}
+	if err != nil {
+		a.log.Errorw(
+			fmt.Sprintf(
+				"[esdbAggregateStore.restoreSnapshot] error in restoring snapshot of stream %s, the whole stream will be replayed: %v",
+				streamId.String(),
+				err,
+			),
+			logger.Fields{"StreamId": streamId.String()},
+		)
+
+		return 0, false
+	}
+	if !restored {
+		return 0, false
+	}
+
+	return snapshot.Version, true
+}
+
 func (a *esdbAggregateStore[T]) getStreamEvents(
 	streamId streamName.StreamName,
 	position readPosition.StreamReadPosition,
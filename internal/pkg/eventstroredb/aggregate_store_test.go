@@ -0,0 +1,218 @@
+//go:build unit
+// +build unit
+
+package eventstroredb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reoden/go-NFT/pkg/core/domain"
+	"github.com/reoden/go-NFT/pkg/core/metadata"
+	"github.com/reoden/go-NFT/pkg/core/serializer/json"
+	"github.com/reoden/go-NFT/pkg/es"
+	"github.com/reoden/go-NFT/pkg/es/contracts/store"
+	"github.com/reoden/go-NFT/pkg/es/mocks"
+	"github.com/reoden/go-NFT/pkg/es/models"
+	appendResult "github.com/reoden/go-NFT/pkg/es/models/append_result"
+	streamName "github.com/reoden/go-NFT/pkg/es/models/stream_name"
+	readPosition "github.com/reoden/go-NFT/pkg/es/models/stream_position/read_position"
+	"github.com/reoden/go-NFT/pkg/logger/empty"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// testCounterSchemaVersion is changed by the tests for making the stored snapshots stale
+var testCounterSchemaVersion = 1
+
+type testCounterIncremented struct {
+	*domain.DomainEvent
+	Amount int
+}
+
+type testCounterState struct {
+	Id    uuid.UUID
+	Total int
+}
+
+type testCounter struct {
+	*models.EventSourcedAggregateRoot
+	Total int
+}
+
+func newTestCounter(id uuid.UUID) *testCounter {
+	counter := &testCounter{}
+	counter.EventSourcedAggregateRoot = models.NewEventSourcedAggregateRootWithId(
+		id,
+		typeMapper.GetTypeName(counter),
+		counter.When,
+	)
+
+	return counter
+}
+
+func (c *testCounter) NewEmptyAggregate() {
+	c.EventSourcedAggregateRoot = models.NewEventSourcedAggregateRoot(typeMapper.GetTypeName(c), c.When)
+}
+
+func (c *testCounter) When(event domain.IDomainEvent) error {
+	if e, ok := event.(*testCounterIncremented); ok {
+		c.Total += e.Amount
+	}
+
+	return nil
+}
+
+func (c *testCounter) Increment(amount int) error {
+	return c.Apply(&testCounterIncremented{
+		DomainEvent: domain.NewDomainEvent(typeMapper.GetTypeName(&testCounterIncremented{})),
+		Amount:      amount,
+	}, true)
+}
+
+func (c *testCounter) SnapshotSchemaVersion() int {
+	return testCounterSchemaVersion
+}
+
+func (c *testCounter) CreateSnapshot() interface{} {
+	return &testCounterState{Id: c.Id(), Total: c.Total}
+}
+
+func (c *testCounter) RestoreSnapshot(snapshot interface{}) error {
+	state := snapshot.(*testCounterState)
+	c.SetId(state.Id)
+	c.Total = state.Total
+
+	return nil
+}
+
+func incrementedEvents(fromVersion int64, amounts ...int) []*models.StreamEvent {
+	var streamEvents []*models.StreamEvent
+	for i, amount := range amounts {
+		streamEvents = append(streamEvents, &models.StreamEvent{
+			EventID: uuid.NewV4(),
+			Event: &testCounterIncremented{
+				DomainEvent: domain.NewDomainEvent(typeMapper.GetTypeName(&testCounterIncremented{})),
+				Amount:      amount,
+			},
+			Version: fromVersion + int64(i),
+		})
+	}
+
+	return streamEvents
+}
+
+func newTestAggregateStore(
+	eventStore store.EventStore,
+	snapshotStore store.SnapshotStore,
+	snapshotFrequency int64,
+) store.AggregateStore[*testCounter] {
+	jsonSerializer := json.NewDefaultJsonSerializer()
+
+	return NewEventStoreAggregateStore[*testCounter](
+		empty.EmptyLogger,
+		eventStore,
+		NewEsdbSerializer(
+			json.NewDefaultMetadataJsonSerializer(jsonSerializer),
+			json.NewDefaultEventJsonSerializer(jsonSerializer),
+		),
+		trace.NewNoopTracerProvider().Tracer(""),
+		snapshotStore,
+		&es.EventStoreConfig{SnapshotFrequency: snapshotFrequency},
+	)
+}
+
+func Test_StoreWithVersion_Saves_Snapshot_Every_Snapshot_Frequency_Events(t *testing.T) {
+	testCounterSchemaVersion = 1
+	ctx := context.Background()
+	eventStore := &mocks.EventStore{}
+	eventStore.On("AppendEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(appendResult.NoOp, nil)
+	snapshotStore := es.NewInMemorySnapshotStore()
+	aggregateStore := newTestAggregateStore(eventStore, snapshotStore, 3)
+
+	counter := newTestCounter(uuid.NewV4())
+	require.NoError(t, counter.Increment(1))
+	require.NoError(t, counter.Increment(2))
+
+	_, err := aggregateStore.Store(counter, metadata.Metadata{}, ctx)
+	require.NoError(t, err)
+
+	streamId := streamName.For[*testCounter](counter).String()
+	_, err = snapshotStore.Load(ctx, streamId)
+	assert.ErrorIs(t, err, store.ErrSnapshotNotFound)
+
+	require.NoError(t, counter.Increment(3))
+	require.NoError(t, counter.Increment(4))
+
+	_, err = aggregateStore.Store(counter, metadata.Metadata{}, ctx)
+	require.NoError(t, err)
+
+	snapshot, err := snapshotStore.Load(ctx, streamId)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), snapshot.Version)
+	assert.Equal(t, 1, snapshot.SchemaVersion)
+	assert.Contains(t, string(snapshot.Data), `"Total":10`)
+}
+
+func Test_Load_Replays_Only_Events_After_Snapshot(t *testing.T) {
+	testCounterSchemaVersion = 1
+	ctx := context.Background()
+	id := uuid.NewV4()
+	streamId := streamName.ForID[*testCounter](id)
+
+	snapshotStore := es.NewInMemorySnapshotStore()
+	require.NoError(t, snapshotStore.Save(ctx, &models.Snapshot{
+		StreamId:      streamId.String(),
+		Version:       4,
+		SchemaVersion: 1,
+		Data:          []byte(`{"Id":"` + id.String() + `","Total":15}`),
+	}))
+
+	eventStore := &mocks.EventStore{}
+	eventStore.On("ReadEvents", streamId, readPosition.FromInt64(5), mock.Anything, mock.Anything).
+		Return(incrementedEvents(5, 6), nil)
+	aggregateStore := newTestAggregateStore(eventStore, snapshotStore, 5)
+
+	counter, err := aggregateStore.Load(ctx, id)
+	require.NoError(t, err)
+
+	assert.Equal(t, id, counter.Id())
+	assert.Equal(t, 21, counter.Total)
+	assert.Equal(t, int64(5), counter.OriginalVersion())
+	eventStore.AssertExpectations(t)
+}
+
+func Test_Load_Ignores_Snapshot_With_Stale_Schema_Version(t *testing.T) {
+	testCounterSchemaVersion = 2
+	defer func() { testCounterSchemaVersion = 1 }()
+
+	ctx := context.Background()
+	id := uuid.NewV4()
+	streamId := streamName.ForID[*testCounter](id)
+
+	snapshotStore := es.NewInMemorySnapshotStore()
+	require.NoError(t, snapshotStore.Save(ctx, &models.Snapshot{
+		StreamId:      streamId.String(),
+		Version:       1,
+		SchemaVersion: 1,
+		Data:          []byte(`{"Id":"` + id.String() + `","Total":100}`),
+	}))
+
+	eventStore := &mocks.EventStore{}
+	eventStore.On("ReadEvents", streamId, readPosition.Start, mock.Anything, mock.Anything).
+		Return(incrementedEvents(0, 1, 2, 3), nil)
+	aggregateStore := newTestAggregateStore(eventStore, snapshotStore, 2)
+
+	counter, err := aggregateStore.Load(ctx, id)
+	require.NoError(t, err)
+
+	assert.Equal(t, 6, counter.Total)
+	assert.Equal(t, int64(2), counter.OriginalVersion())
+	eventStore.AssertExpectations(t)
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/reoden/go-NFT/pkg/config"
 	"github.com/reoden/go-NFT/pkg/config/environment"
@@ -47,6 +48,48 @@ func (e *EventStoreDbOptions) HttpEndPoint() string {
 type Subscription struct {
 	Prefix         []string `mapstructure:"prefix"         validate:"required"`
 	SubscriptionId string   `mapstructure:"subscriptionId" validate:"required"`
+	// CheckpointInterval number of the handled events between the stored checkpoints
+	CheckpointInterval int `mapstructure:"checkpointInterval"`
+	// CheckpointFlushInterval maximum time in milliseconds that a handled event waits for its checkpoint
+	CheckpointFlushInterval int `mapstructure:"checkpointFlushInterval"`
+	// MinResubscribeDelay delay of the first resubscribe attempt after a drop in milliseconds, it doubles for the next attempts
+	MinResubscribeDelay int `mapstructure:"minResubscribeDelay"`
+	// MaxResubscribeDelay maximum delay of the resubscribe attempts in milliseconds
+	MaxResubscribeDelay int `mapstructure:"maxResubscribeDelay"`
+	// MaxLag maximum distance between the last handled event and the head of $all in commit positions for a healthy subscription
+	MaxLag uint64 `mapstructure:"maxLag"`
+}
+
+func (s *Subscription) CheckpointIntervalOrDefault() int {
+	if s.CheckpointInterval <= 0 {
+		return 100
+	}
+
+	return s.CheckpointInterval
+}
+
+func (s *Subscription) CheckpointFlushIntervalDuration() time.Duration {
+	if s.CheckpointFlushInterval <= 0 {
+		return 5 * time.Second
+	}
+
+	return time.Duration(s.CheckpointFlushInterval) * time.Millisecond
+}
+
+func (s *Subscription) MinResubscribeDelayDuration() time.Duration {
+	if s.MinResubscribeDelay <= 0 {
+		return 500 * time.Millisecond
+	}
+
+	return time.Duration(s.MinResubscribeDelay) * time.Millisecond
+}
+
+func (s *Subscription) MaxResubscribeDelayDuration() time.Duration {
+	if s.MaxResubscribeDelay <= 0 {
+		return 30 * time.Second
+	}
+
+	return time.Duration(s.MaxResubscribeDelay) * time.Millisecond
 }
 
 func ProvideConfig(environment environment.Environment) (*EventStoreDbOptions, error) {
@@ -0,0 +1,127 @@
+package eventstroredb
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/reoden/go-NFT/pkg/es/contracts/store"
+	"github.com/reoden/go-NFT/pkg/es/models"
+	"github.com/reoden/go-NFT/pkg/logger"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+
+	"emperror.dev/errors"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/gofrs/uuid"
+)
+
+type esdbSnapshotStore struct {
+	client        *esdb.Client
+	log           logger.Logger
+	esdbSerilizer *EsdbSerializer
+}
+
+// NewEsdbSnapshotStore creates a snapshot store that keeps the latest snapshot of each aggregate stream in a separate
+// snapshot stream with at most 1 event.
+func NewEsdbSnapshotStore(
+	client *esdb.Client,
+	logger logger.Logger,
+	esdbSerializer *EsdbSerializer,
+) store.SnapshotStore {
+	return &esdbSnapshotStore{
+		client:        client,
+		log:           logger,
+		esdbSerilizer: esdbSerializer,
+	}
+}
+
+func (e *esdbSnapshotStore) Load(ctx context.Context, streamId string) (*models.Snapshot, error) {
+	stream, err := e.client.ReadStream(
+		ctx,
+		getSnapshotStreamName(streamId),
+		esdb.ReadStreamOptions{
+			Direction: esdb.Backwards,
+			From:      esdb.End{},
+		}, 1)
+	if errors.Is(err, esdb.ErrStreamNotFound) {
+		return nil, errors.WithStack(store.ErrSnapshotNotFound)
+	} else if err != nil {
+		return nil, errors.WrapIf(err, "db.ReadStream")
+	}
+	defer stream.Close()
+
+	event, err := stream.Recv()
+	if errors.Is(err, esdb.ErrStreamNotFound) || errors.Is(err, io.EOF) {
+		return nil, errors.WithStack(store.ErrSnapshotNotFound)
+	}
+	if err != nil {
+		return nil, errors.WrapIf(err, "stream.Recv")
+	}
+
+	var snapshot *models.Snapshot
+	err = e.esdbSerilizer.eventSerializer.Serializer().Unmarshal(event.Event.Data, &snapshot)
+	if err != nil {
+		return nil, errors.WrapIf(err, "serializer.Unmarshal")
+	}
+
+	return snapshot, nil
+}
+
+func (e *esdbSnapshotStore) Save(ctx context.Context, snapshot *models.Snapshot) error {
+	data, err := e.esdbSerilizer.eventSerializer.Serializer().Marshal(snapshot)
+	if err != nil {
+		return errors.WrapIf(err, "serializer.Marshal")
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return errors.WrapIf(err, "uuid.NewV4")
+	}
+
+	eventData := esdb.EventData{
+		EventID:     id,
+		EventType:   typeMapper.GetTypeName(snapshot),
+		ContentType: esdb.JsonContentType,
+		Data:        data,
+	}
+	streamName := getSnapshotStreamName(snapshot.StreamId)
+
+	_, err = e.client.AppendToStream(
+		ctx,
+		streamName,
+		esdb.AppendToStreamOptions{ExpectedRevision: esdb.StreamExists{}},
+		eventData,
+	)
+	if !errors.Is(err, esdb.ErrWrongExpectedStreamRevision) {
+		return err
+	}
+
+	// WrongExpectedVersionException means that stream did not exist
+	// Set the snapshot stream to have at most 1 event
+	// using stream metadata $maxCount property
+	streamMeta := esdb.StreamMetadata{}
+	streamMeta.SetMaxCount(1)
+
+	_, err = e.client.SetStreamMetadata(
+		ctx,
+		streamName,
+		esdb.AppendToStreamOptions{ExpectedRevision: esdb.NoStream{}},
+		streamMeta)
+	if err != nil {
+		return errors.WrapIf(err, "client.SetStreamMetadata")
+	}
+
+	// append event again expecting stream to not exist
+	_, err = e.client.AppendToStream(
+		ctx,
+		streamName,
+		esdb.AppendToStreamOptions{ExpectedRevision: esdb.NoStream{}},
+		eventData,
+	)
+
+	return err
+}
+
+func getSnapshotStreamName(streamId string) string {
+	return fmt.Sprintf("snapshot-%s", streamId)
+}
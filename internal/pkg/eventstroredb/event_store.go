@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"strings"
 
 	"github.com/reoden/go-NFT/pkg/es/contracts/store"
 	"github.com/reoden/go-NFT/pkg/es/models"
@@ -24,6 +25,13 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
+// EsdbEventStore the event store on EventStoreDB, in addition to the streams it reads the events of $all in the order of
+// their commit positions.
+type EsdbEventStore interface {
+	store.EventStore
+	store.AllEventsReader
+}
+
 // https://developers.eventstore.com/clients/grpc/reading-events.html#reading-from-a-stream
 // https://developers.eventstore.com/clients/grpc/appending-events.html#append-your-first-event
 type eventStoreDbEventStore struct {
@@ -38,7 +46,7 @@ func NewEventStoreDbEventStore(
 	client *esdb.Client,
 	serializer *EsdbSerializer,
 	tracer trace.Tracer,
-) store.EventStore {
+) EsdbEventStore {
 	return &eventStoreDbEventStore{
 		log:        log,
 		client:     client,
@@ -233,6 +241,118 @@ func (e *eventStoreDbEventStore) ReadEvents(
 	return events, nil
 }
 
+// ReadAllEvents reads $all forward from the commit position, the system events and the events of the other prefixes are
+// filtered on the client, so $all is read until `count` events are found or its end is reached.
+func (e *eventStoreDbEventStore) ReadAllEvents(
+	ctx context.Context,
+	fromGlobalPosition uint64,
+	count int,
+	prefixes []string,
+) ([]*models.StreamEvent, error) {
+	ctx, span := e.tracer.Start(ctx, "eventStoreDbEventStore.ReadAllEvents")
+	span.SetAttributes(attribute2.Int64("FromGlobalPosition", int64(fromGlobalPosition)))
+	defer span.End()
+
+	var events []*models.StreamEvent
+	position := fromGlobalPosition
+	// the event at the start position is read again, so one more event is read for making progress in each read
+	readCount := count + 1
+
+	for len(events) < count {
+		var from esdb.AllPosition = esdb.Start{}
+		if position > 0 {
+			from = esdb.Position{Commit: position, Prepare: position}
+		}
+
+		readStream, err := e.client.ReadAll(
+			ctx,
+			esdb.ReadAllOptions{
+				Direction:      esdb.Forwards,
+				From:           from,
+				ResolveLinkTos: true,
+			},
+			uint64(readCount),
+		)
+		if err != nil {
+			return nil, utils.TraceErrStatusFromSpan(
+				span,
+				errors.WithMessage(esErrors.NewReadStreamError(err), "error in reading $all"),
+			)
+		}
+
+		resolvedEvents, err := e.serializer.EsdbReadStreamToResolvedEvents(readStream)
+		readStream.Close()
+		if err != nil {
+			return nil, utils.TraceErrStatusFromSpan(
+				span,
+				errors.WrapIf(err, "error in converting to resolved events"),
+			)
+		}
+
+		for _, resolvedEvent := range resolvedEvents {
+			eventPosition := resolvedEvent.OriginalEvent().Position.Commit
+			if eventPosition <= position && position > 0 {
+				continue
+			}
+			position = eventPosition
+
+			if !isAllEventIncluded(resolvedEvent, prefixes) || len(events) == count {
+				continue
+			}
+
+			event, err := e.serializer.ResolvedEventToStreamEvent(resolvedEvent)
+			if err != nil {
+				return nil, utils.TraceErrStatusFromSpan(
+					span,
+					errors.WrapIf(err, "error in converting to stream event"),
+				)
+			}
+			events = append(events, event)
+		}
+
+		if len(resolvedEvents) < readCount {
+			break
+		}
+	}
+
+	return events, nil
+}
+
+func (e *eventStoreDbEventStore) ReadHeadPosition(ctx context.Context) (uint64, error) {
+	ctx, span := e.tracer.Start(ctx, "eventStoreDbEventStore.ReadHeadPosition")
+	defer span.End()
+
+	readStream, err := e.client.ReadAll(
+		ctx,
+		esdb.ReadAllOptions{
+			Direction: esdb.Backwards,
+			From:      esdb.End{},
+		},
+		1,
+	)
+	if err != nil {
+		return 0, utils.TraceErrStatusFromSpan(
+			span,
+			errors.WithMessage(esErrors.NewReadStreamError(err), "error in reading the head of $all"),
+		)
+	}
+	defer readStream.Close()
+
+	resolvedEvents, err := e.serializer.EsdbReadStreamToResolvedEvents(readStream)
+	if err != nil {
+		return 0, utils.TraceErrStatusFromSpan(
+			span,
+			errors.WrapIf(err, "error in converting to resolved events"),
+		)
+	}
+
+	if len(resolvedEvents) == 0 {
+		return 0, nil
+	}
+
+	return resolvedEvents[0].OriginalEvent().Position.Commit, nil
+}
+
 func (e *eventStoreDbEventStore) ReadEventsWithMaxCount(
 	streamName streamName.StreamName,
 	readPosition readPosition.StreamReadPosition,
@@ -452,3 +572,23 @@ func (e *eventStoreDbEventStore) DeleteStream(
 
 	return nil
 }
+
+// isAllEventIncluded checks a resolved event of $all is not a system event and its stream has one of the prefixes
+func isAllEventIncluded(resolvedEvent *esdb.ResolvedEvent, prefixes []string) bool {
+	if resolvedEvent.Event == nil || strings.HasPrefix(resolvedEvent.Event.EventType, "$") ||
+		strings.HasPrefix(resolvedEvent.Event.StreamID, "$") {
+		return false
+	}
+
+	if len(prefixes) == 0 {
+		return true
+	}
+
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(resolvedEvent.Event.StreamID, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -2,9 +2,12 @@ package eventstroredb
 
 import (
 	"context"
-	"time"
+	"fmt"
 
+	"github.com/reoden/go-NFT/pkg/es"
+	"github.com/reoden/go-NFT/pkg/es/contracts/store"
 	"github.com/reoden/go-NFT/pkg/eventstroredb/config"
+	"github.com/reoden/go-NFT/pkg/health/contracts"
 	"github.com/reoden/go-NFT/pkg/logger"
 
 	"github.com/EventStore/EventStore-Client-Go/esdb"
@@ -28,11 +31,25 @@ var (
 	// - execute its func only if it requested
 	eventstoreProviders = fx.Options(fx.Provide( //nolint:gochecknoglobals
 		config.ProvideConfig,
+		es.ProvideConfig,
 		NewEsdbSerializer,
 		NewEventStoreDB,
-		NewEventStoreDbEventStore,
+		fx.Annotate(
+			NewEventStoreDbEventStore,
+			fx.As(fx.Self()),
+			fx.As(new(store.EventStore)),
+		),
 		NewEsdbSubscriptionCheckpointRepository,
+		NewEsdbSnapshotStore,
+		NewProjectionsConfigurations,
+		es.NewProjectionCheckpoints,
 		NewEsdbSubscriptionAllWorker,
+		NewEsdbProjectionRebuilder,
+		fx.Annotate(
+			NewEsdbSubscriptionLagHealthChecker,
+			fx.As(new(contracts.Health)),
+			fx.ResultTags(fmt.Sprintf(`group:"%s"`, "healths")),
+		),
 	))
 
 	// FiberInvokes - execute after registering all of our provided
@@ -42,6 +59,31 @@ var (
 	eventstoreInvokes = fx.Options(fx.Invoke(registerHooks)) //nolint:gochecknoglobals
 )
 
+// NewEsdbProjectionRebuilder creates the rebuilder of the projections, the events are replayed with the prefixes of the
+// configured subscription
+func NewEsdbProjectionRebuilder(
+	log logger.Logger,
+	eventStore EsdbEventStore,
+	checkpoints *es.ProjectionCheckpoints,
+	cfg *config.EventStoreDbOptions,
+	projectionConfigurations *ProjectionsConfigurations,
+) es.ProjectionRebuilder {
+	var prefixes []string
+	if cfg.Subscription != nil {
+		prefixes = cfg.Subscription.Prefix
+	}
+
+	// the esdb streams are not archived
+	return es.NewProjectionRebuilder(
+		log,
+		eventStore,
+		nil,
+		checkpoints,
+		projectionConfigurations.Projections,
+		prefixes,
+	)
+}
+
 // we don't want to register any dependencies here, its func body should execute always even we don't request for that, so we should use `invoke`
 func registerHooks(
 	lc fx.Lifecycle,
@@ -49,7 +91,7 @@ func registerHooks(
 	logger logger.Logger,
 	cfg *config.EventStoreDbOptions,
 ) {
-	lifetimeCtx := context.Background()
+	lifetimeCtx, cancel := context.WithCancel(context.Background())
 
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
@@ -64,7 +106,8 @@ func registerHooks(
 					},
 					SubscriptionId: cfg.Subscription.SubscriptionId,
 				}
-				if err := worker.SubscribeAll(lifetimeCtx, option); err != nil {
+				// the dropped subscriptions are resubscribed by the worker, so it only returns after the stop of app
+				if err := worker.SubscribeAll(lifetimeCtx, option); err != nil && lifetimeCtx.Err() == nil {
 					logger.Errorf(
 						"(worker.SubscribeAll) error in running esdb subscription worker: {%v}",
 						err,
@@ -79,8 +122,7 @@ func registerHooks(
 		OnStop: func(ctx context.Context) error {
 			// https://github.com/uber-go/fx/blob/v1.20.0/app.go#L573
 			// this ctx is just for stopping callbacks or OnStop callbacks, and it has short timeout 15s, and it is not alive in whole lifetime app
-			_, cancel := context.WithTimeout(lifetimeCtx, 5*time.Second)
-			defer cancel()
+			cancel()
 
 			return nil
 		},
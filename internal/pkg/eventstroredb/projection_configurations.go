@@ -7,3 +7,14 @@ import (
 type ProjectionsConfigurations struct {
 	Projections []projection.IProjection
 }
+
+// NewProjectionsConfigurations builds the projections of the module once, so the subscription worker and the projection
+// rebuilder share the same projections
+func NewProjectionsConfigurations(projectionBuilderFunc ProjectionBuilderFuc) *ProjectionsConfigurations {
+	builder := NewProjectionsBuilder()
+	if projectionBuilderFunc != nil {
+		projectionBuilderFunc(builder)
+	}
+
+	return builder.Build()
+}
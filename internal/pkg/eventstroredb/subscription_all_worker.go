@@ -3,6 +3,8 @@ package eventstroredb
 import (
 	"context"
 	"fmt"
+	"io"
+	"sync"
 	"time"
 
 	"github.com/reoden/go-NFT/pkg/es"
@@ -26,13 +28,20 @@ type esdbSubscriptionAllWorker struct {
 	subscriptionCheckpointRepository contracts.SubscriptionCheckpointRepository
 	subscriptionId                   string
 	projectionPublisher              projection.IProjectionPublisher
+	checkpointer                     *es.SubscriptionCheckpointer
+	statusLock                       sync.RWMutex
+	status                           es.SubscriptionStatus
 }
 
 type EsdbSubscriptionAllWorker interface {
+	// SubscribeAll subscribes to all events after the checkpoint of subscription and publishes them to the projections,
+	// a dropped subscription is resubscribed with a backoff from its last handled event until the context is done.
 	SubscribeAll(
 		ctx context.Context,
 		subscriptionOption *EventStoreDBSubscriptionToAllOptions,
 	) error
+
+	es.SubscriptionStatusProvider
 }
 
 type EventStoreDBSubscriptionToAllOptions struct {
@@ -50,14 +59,13 @@ func NewEsdbSubscriptionAllWorker(
 	cfg *config.EventStoreDbOptions,
 	esdbSerializer *EsdbSerializer,
 	subscriptionRepository contracts.SubscriptionCheckpointRepository,
-	projectionBuilderFunc ProjectionBuilderFuc,
+	projectionCheckpoints *es.ProjectionCheckpoints,
+	projectionConfigurations *ProjectionsConfigurations,
 ) EsdbSubscriptionAllWorker {
-	builder := NewProjectionsBuilder()
-	if projectionBuilderFunc != nil {
-		projectionBuilderFunc(builder)
-	}
-	projectionConfigurations := builder.Build()
-	projectionPublisher := es.NewProjectionPublisher(projectionConfigurations.Projections)
+	projectionPublisher := es.NewProjectionPublisherWithCheckpoints(
+		projectionConfigurations.Projections,
+		projectionCheckpoints,
+	)
 
 	return &esdbSubscriptionAllWorker{
 		db:                               db,
@@ -66,6 +74,7 @@ func NewEsdbSubscriptionAllWorker(
 		esdbSerializer:                   esdbSerializer,
 		subscriptionCheckpointRepository: subscriptionRepository,
 		projectionPublisher:              projectionPublisher,
+		status:                           es.SubscriptionStatus{State: es.SubscriptionStopped},
 	}
 }
 
@@ -84,87 +93,183 @@ func (s *esdbSubscriptionAllWorker) SubscribeAll(
 	s.subscriptionOption = subscriptionOption
 	s.subscriptionId = subscriptionOption.SubscriptionId
 
-	s.log.Info(fmt.Sprintf("starting subscription to all '%s'.", subscriptionOption.SubscriptionId))
-
-	checkpoint, err := s.subscriptionCheckpointRepository.Load(
+	subscriptionCfg := s.subscriptionConfig()
+	s.checkpointer = es.NewSubscriptionCheckpointer(
+		s.subscriptionCheckpointRepository,
 		subscriptionOption.SubscriptionId,
-		ctx,
+		subscriptionCfg.CheckpointIntervalOrDefault(),
+		subscriptionCfg.CheckpointFlushIntervalDuration(),
 	)
+
+	s.log.Info(fmt.Sprintf("starting subscription to all '%s'.", subscriptionOption.SubscriptionId))
+
+	checkpoint, err := s.checkpointer.Load(ctx)
 	if err != nil {
 		return err
 	}
 
+	s.updateStatus(func(status *es.SubscriptionStatus) {
+		status.SubscriptionId = subscriptionOption.SubscriptionId
+		status.State = es.SubscriptionCatchingUp
+		status.Position = checkpoint
+		status.CheckpointPosition = checkpoint
+	})
+
+	defer func() {
+		// the handled events are checkpointed after the stop of subscription, the context is done at this point
+		s.flushCheckpoint(context.Background())
+		s.updateStatus(func(status *es.SubscriptionStatus) {
+			status.State = es.SubscriptionStopped
+		})
+	}()
+
+	// https://developers.eventstore.com/clients/grpc/subscriptions.html#handling-subscription-drops
+	attempt := 0
+	for {
+		handledEvents, err := s.subscribe(ctx)
+		if ctx.Err() != nil {
+			// context canceled or deadlined
+			return ctx.Err()
+		}
+
+		// the backoff starts again when the previous subscription was working
+		if handledEvents {
+			attempt = 0
+		}
+		attempt++
+
+		s.flushCheckpoint(ctx)
+
+		delay := es.ResubscribeDelay(
+			attempt,
+			subscriptionCfg.MinResubscribeDelayDuration(),
+			subscriptionCfg.MaxResubscribeDelayDuration(),
+		)
+		s.log.Errorf(
+			"subscription to all '%s' dropped, resubscribing from position %d in %s: %v",
+			s.subscriptionId,
+			s.checkpointer.Position(),
+			delay,
+			err,
+		)
+		s.updateStatus(func(status *es.SubscriptionStatus) {
+			status.State = es.SubscriptionResubscribing
+			status.Resubscriptions++
+			if err != nil {
+				status.LastError = err.Error()
+			}
+		})
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (s *esdbSubscriptionAllWorker) SubscriptionStatus(ctx context.Context) (*es.SubscriptionStatus, error) {
+	status := s.currentStatus()
+	if status.State == es.SubscriptionStopped {
+		return &status, nil
+	}
+
+	headPosition, err := s.readHeadPosition(ctx)
+	if err != nil {
+		return nil, err
+	}
+	status.HeadPosition = headPosition
+
+	return &status, nil
+}
+
+// subscribe runs a subscription from the last handled event until it is dropped, it reports whether any event is handled
+func (s *esdbSubscriptionAllWorker) subscribe(ctx context.Context) (bool, error) {
+	headPosition, err := s.readHeadPosition(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	position := s.checkpointer.Position()
+
 	var from esdb.AllPosition
-	if checkpoint == 0 {
+	if position == 0 {
 		from = esdb.Start{}
 	} else {
 		from = esdb.Position{
-			Commit:  checkpoint,
-			Prepare: checkpoint,
+			Commit:  position,
+			Prepare: position,
 		}
 	}
 
+	s.updateStatus(func(status *es.SubscriptionStatus) {
+		status.HeadPosition = headPosition
+		status.State = es.SubscriptionCatchingUp
+	})
+	s.positionReached(position)
+
 	options := esdb.SubscribeToAllOptions{
-		ResolveLinkTos:     subscriptionOption.ResolveLinkTos,
-		Authenticated:      subscriptionOption.Credentials,
-		Filter:             subscriptionOption.FilterOptions,
+		ResolveLinkTos:     s.subscriptionOption.ResolveLinkTos,
+		Authenticated:      s.subscriptionOption.Credentials,
+		Filter:             s.subscriptionOption.FilterOptions,
 		From:               from,
-		CheckpointInterval: 1,
+		CheckpointInterval: s.subscriptionConfig().CheckpointIntervalOrDefault(),
 	}
+
 	// https://developers.eventstore.com/clients/grpc/subscriptions.html#subscribing-to-all-1
 	// https://github.com/EventStore/EventStore-Client-Go/blob/master/samples/subscribingToStream.go#L113
-	// https://developers.eventstore.com/clients/grpc/subscriptions.html#handling-subscription-drops
-	for {
-		stream, err := s.db.SubscribeToAll(ctx, options)
-		if err != nil {
-			time.Sleep(1 * time.Second)
-			continue
-		}
+	stream, err := s.db.SubscribeToAll(ctx, options)
+	if err != nil {
+		return false, errors.WrapIf(err, "failed to subscribe to all")
+	}
+	defer stream.Close()
 
-		s.log.Info(
-			fmt.Sprintf("subscription to all '%s' started.", subscriptionOption.SubscriptionId),
-		)
+	s.log.Info(
+		fmt.Sprintf("subscription to all '%s' started from position %d.", s.subscriptionId, position),
+	)
 
-		for true {
-			event := stream.Recv()
+	handledEvents := false
+	for {
+		event := stream.Recv()
+		if ctx.Err() != nil {
+			return handledEvents, ctx.Err()
+		}
 
-			if event.SubscriptionDropped != nil {
-				s.log.Errorf(
-					"subscription to all '%s' dropped: %s",
-					s.subscriptionId,
-					event.SubscriptionDropped.Error,
-				)
-				stream.Close()
-				break
+		if event.SubscriptionDropped != nil {
+			if event.SubscriptionDropped.Error != nil {
+				return handledEvents, event.SubscriptionDropped.Error
 			}
 
-			if event.EventAppeared != nil {
-				streamId := event.EventAppeared.OriginalEvent().StreamID
-				revision := event.EventAppeared.OriginalEvent().EventNumber
-				s.log.Info(
-					fmt.Sprintf(
-						"event appeared in subscription to all '%s'. streamId: %s, revision: %d",
-						s.subscriptionId,
-						streamId,
-						revision,
-					),
-				)
-
-				options.From = event.EventAppeared.OriginalEvent().Position
-
-				// handles the event...
-				err := s.handleEvent(ctx, event.EventAppeared)
-				if err != nil {
-					return err
-				}
+			return handledEvents, errors.New("subscription dropped")
+		}
+
+		// the server checkpoints of a filtered subscription move the position over the filtered events
+		if event.CheckPointReached != nil {
+			err := s.checkpointer.PositionReached(ctx, event.CheckPointReached.Commit)
+			if err != nil {
+				return handledEvents, err
 			}
+			s.positionReached(event.CheckPointReached.Commit)
 		}
 
-		select {
-		case <-ctx.Done():
-			time.Sleep(1 * time.Second)
-			// context canceled or deadlined
-			return ctx.Err()
+		if event.EventAppeared != nil {
+			streamId := event.EventAppeared.OriginalEvent().StreamID
+			revision := event.EventAppeared.OriginalEvent().EventNumber
+			s.log.Info(
+				fmt.Sprintf(
+					"event appeared in subscription to all '%s'. streamId: %s, revision: %d",
+					s.subscriptionId,
+					streamId,
+					revision,
+				),
+			)
+
+			// handles the event...
+			err := s.handleEvent(ctx, event.EventAppeared)
+			if err != nil {
+				return handledEvents, err
+			}
+			handledEvents = true
 		}
 	}
 }
@@ -197,15 +302,17 @@ func (s *esdbSubscriptionAllWorker) handleEvent(
 		return errors.WrapIf(err, "failed to publish stream event in the handle event")
 	}
 
-	err = s.subscriptionCheckpointRepository.Store(
-		s.subscriptionId,
-		resolvedEvent.Event.Position.Commit,
-		ctx,
-	)
+	position := resolvedEvent.Event.Position.Commit
+	err = s.checkpointer.EventHandled(ctx, position)
 	if err != nil {
-		return errors.WrapIf(err, "failed to store subscription checkpoint")
+		return err
 	}
 
+	s.positionReached(position)
+	s.updateStatus(func(status *es.SubscriptionStatus) {
+		status.LastEventAt = time.Now()
+	})
+
 	return nil
 }
 
@@ -228,16 +335,78 @@ func (s *esdbSubscriptionAllWorker) isCheckpointEvent(resolvedEvent *esdb.Resolv
 	return true
 }
 
-//https://developers.eventstore.com/clients/grpc/subscriptions.html#handling-subscription-drops
-//func (s *esdbSubscriptionAllWorker) resubscribe(ctx context.Context) {
-//	for true {
-//		err := s.SubscribeAll(ctx, s.subscriptionOption)
-//		if err != nil {
-//			s.log.Error(err)
-//			time.Sleep(time.Second * 1000)
-//			continue
-//		}
-//
-//		break
-//	}
-//}
+// positionReached updates the position of subscription and switches the catching up subscription to live when it reaches
+// the head position of its start
+func (s *esdbSubscriptionAllWorker) positionReached(position uint64) {
+	s.updateStatus(func(status *es.SubscriptionStatus) {
+		status.Position = position
+		status.CheckpointPosition = s.checkpointer.StoredPosition()
+
+		if status.State == es.SubscriptionCatchingUp && position >= status.HeadPosition {
+			status.State = es.SubscriptionLive
+			s.log.Info(fmt.Sprintf("subscription to all '%s' caught up and is live.", s.subscriptionId))
+		}
+	})
+}
+
+func (s *esdbSubscriptionAllWorker) flushCheckpoint(ctx context.Context) {
+	err := s.checkpointer.Flush(ctx)
+	if err != nil {
+		s.log.Errorf("subscription to all '%s' failed to store checkpoint: %v", s.subscriptionId, err)
+
+		return
+	}
+
+	s.updateStatus(func(status *es.SubscriptionStatus) {
+		status.CheckpointPosition = s.checkpointer.StoredPosition()
+	})
+}
+
+// readHeadPosition reads the commit position of the last event in $all
+func (s *esdbSubscriptionAllWorker) readHeadPosition(ctx context.Context) (uint64, error) {
+	stream, err := s.db.ReadAll(
+		ctx,
+		esdb.ReadAllOptions{
+			Direction:     esdb.Backwards,
+			From:          esdb.End{},
+			Authenticated: s.subscriptionOption.Credentials,
+		},
+		1,
+	)
+	if err != nil {
+		return 0, errors.WrapIf(err, "failed to read the head of $all")
+	}
+	defer stream.Close()
+
+	event, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.WrapIf(err, "failed to read the head of $all")
+	}
+
+	return event.OriginalEvent().Position.Commit, nil
+}
+
+func (s *esdbSubscriptionAllWorker) subscriptionConfig() *config.Subscription {
+	if s.cfg == nil || s.cfg.Subscription == nil {
+		return &config.Subscription{}
+	}
+
+	return s.cfg.Subscription
+}
+
+func (s *esdbSubscriptionAllWorker) currentStatus() es.SubscriptionStatus {
+	s.statusLock.RLock()
+	defer s.statusLock.RUnlock()
+
+	return s.status
+}
+
+func (s *esdbSubscriptionAllWorker) updateStatus(update func(status *es.SubscriptionStatus)) {
+	s.statusLock.Lock()
+	defer s.statusLock.Unlock()
+
+	update(&s.status)
+}
@@ -0,0 +1,21 @@
+package eventstroredb
+
+import (
+	"github.com/reoden/go-NFT/pkg/es"
+	"github.com/reoden/go-NFT/pkg/eventstroredb/config"
+	"github.com/reoden/go-NFT/pkg/health/contracts"
+)
+
+// NewEsdbSubscriptionLagHealthChecker creates the health check of the subscription to all, the lag is the distance
+// between the commit positions of the last handled event and the last event of $all
+func NewEsdbSubscriptionLagHealthChecker(
+	worker EsdbSubscriptionAllWorker,
+	cfg *config.EventStoreDbOptions,
+) contracts.Health {
+	var maxLag uint64
+	if cfg.Subscription != nil {
+		maxLag = cfg.Subscription.MaxLag
+	}
+
+	return es.NewSubscriptionLagHealthChecker("eventstoredb-subscription-lag", worker, maxLag)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/reoden/go-NFT/pkg/grpc/config"
 	"github.com/reoden/go-NFT/pkg/grpc/handlers/otel"
+	"github.com/reoden/go-NFT/pkg/grpc/interceptors"
 
 	"emperror.dev/errors"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
@@ -29,8 +30,25 @@ func NewGrpcClient(config *config.GrpcOptions) (GrpcClient, error) {
 	// Grpc Client to call Grpc Server
 	// https://sahansera.dev/building-grpc-client-go/
 	// https://github.com/open-telemetry/opentelemetry-go-contrib/blob/df16f32df86b40077c9c90d06f33c4cdb6dd5afa/instrumentation/google.golang.org/grpc/otelgrpc/example_interceptor_test.go
+	transportCredentials := insecure.NewCredentials()
+	if config.Tls.IsEnabled() {
+		var err error
+		transportCredentials, err = newClientCredentials(config.Tls)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	serviceCredential := config.Auth.IsEnabled() && config.Auth.ServiceCredential
+
 	conn, err := grpc.Dial(fmt.Sprintf("%s%s", config.Host, config.Port),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(transportCredentials),
+		grpc.WithChainUnaryInterceptor(
+			interceptors.TokenUnaryClientInterceptor(config.Name, serviceCredential),
+		),
+		grpc.WithChainStreamInterceptor(
+			interceptors.TokenStreamClientInterceptor(config.Name, serviceCredential),
+		),
 		// https://github.com/open-telemetry/opentelemetry-go-contrib/blob/main/instrumentation/google.golang.org/grpc/otelgrpc/example/client/main.go#L47C3-L47C52
 		// https://github.com/open-telemetry/opentelemetry-go-contrib/blob/main/instrumentation/google.golang.org/grpc/otelgrpc/doc.go
 		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"strings"
+
 	"github.com/reoden/go-NFT/pkg/config"
 	"github.com/reoden/go-NFT/pkg/config/environment"
 	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
@@ -15,6 +17,85 @@ type GrpcOptions struct {
 	Host        string `mapstructure:"host"        env:"Host"`
 	Development bool   `mapstructure:"development" env:"Development"`
 	Name        string `mapstructure:"name"        env:"ShortTypeName"`
+	// Auth the bearer token authentication and the per method authorization of the server, it is disabled when nil
+	Auth *AuthOptions `mapstructure:"auth"`
+	// Tls the mutual tls of the server and the client, the connections are insecure when nil
+	Tls *TlsOptions `mapstructure:"tls"`
+}
+
+type AuthOptions struct {
+	Enabled bool `mapstructure:"enabled"`
+	// PublicMethods the full method names called without a token, a name ending with `*` matches a prefix like
+	// `/products_service.ProductsService/*`, the health checks and the reflection are always public
+	PublicMethods []string `mapstructure:"publicMethods"`
+	// Rules the roles allowed to call the methods, a rule without roles allows every authenticated caller and the methods
+	// without a rule are denied
+	Rules []MethodRule `mapstructure:"rules"`
+	// ServiceCredential allows the client calls to ask for a service token with `interceptors.WithServiceCredential`
+	ServiceCredential bool `mapstructure:"serviceCredential"`
+}
+
+type MethodRule struct {
+	// Method the full method name of the rule, a name ending with `*` matches a prefix
+	Method string   `mapstructure:"method"`
+	Roles  []string `mapstructure:"roles"`
+}
+
+type TlsOptions struct {
+	Enabled  bool   `mapstructure:"enabled"`
+	CertFile string `mapstructure:"certFile"`
+	KeyFile  string `mapstructure:"keyFile"`
+	// CaFile the certificate authority of the peer certificates, the server requires and verifies the client certificates
+	CaFile string `mapstructure:"caFile"`
+	// ServerName overrides the name used by the client to verify the server certificate
+	ServerName string `mapstructure:"serverName"`
+}
+
+func (a *AuthOptions) IsEnabled() bool {
+	return a != nil && a.Enabled
+}
+
+func (t *TlsOptions) IsEnabled() bool {
+	return t != nil && t.Enabled
+}
+
+// RolesOf returns the roles allowed to call the method and whether the method has a rule, the exact rules are
+// matched before the prefix rules
+func (a *AuthOptions) RolesOf(fullMethod string) ([]string, bool) {
+	var prefixRule *MethodRule
+	for i := range a.Rules {
+		rule := &a.Rules[i]
+		if rule.Method == fullMethod {
+			return rule.Roles, true
+		}
+		if prefixRule == nil && matchMethod(rule.Method, fullMethod) {
+			prefixRule = rule
+		}
+	}
+
+	if prefixRule != nil {
+		return prefixRule.Roles, true
+	}
+
+	return nil, false
+}
+
+func (a *AuthOptions) IsPublic(fullMethod string) bool {
+	for _, method := range a.PublicMethods {
+		if matchMethod(method, fullMethod) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchMethod(pattern string, fullMethod string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(fullMethod, prefix)
+	}
+
+	return pattern == fullMethod
 }
 
 func ProvideConfig(environment environment.Environment) (*GrpcOptions, error) {
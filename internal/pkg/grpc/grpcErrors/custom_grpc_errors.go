@@ -30,6 +30,17 @@ func NewConflictGrpcError(detail string, stackTrace string) GrpcErr {
 	}
 }
 
+// NewConcurrencyGrpcError optimistic concurrency conflicts are `Aborted` - https://grpc.io/docs/guides/status-codes/
+func NewConcurrencyGrpcError(detail string, stackTrace string) GrpcErr {
+	return &grpcErr{
+		Title:      constants.ErrConcurrencyTitle,
+		Detail:     detail,
+		Status:     codes.Aborted,
+		Timestamp:  time.Now(),
+		StackTrace: stackTrace,
+	}
+}
+
 func NewBadRequestGrpcError(detail string, stackTrace string) GrpcErr {
 	return &grpcErr{
 		Title:      constants.ErrBadRequestTitle,
@@ -51,6 +51,8 @@ func ParseError(err error) GrpcErr {
 			return NewUnAuthorizedErrorGrpcError(customErr.Error(), stackTrace)
 		case customErrors.IsForbiddenError(err):
 			return NewForbiddenGrpcError(customErr.Error(), stackTrace)
+		case customErrors.IsConcurrencyError(err):
+			return NewConcurrencyGrpcError(customErr.Error(), stackTrace)
 		case customErrors.IsConflictError(err):
 			return NewConflictGrpcError(customErr.Error(), stackTrace)
 		case customErrors.IsInternalServerError(err):
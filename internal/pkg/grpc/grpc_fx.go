@@ -27,7 +27,8 @@ var (
 		// https://uber-go.github.io/fx/annotate.html
 		fx.Annotate(
 			NewGrpcServer,
-			fx.ParamTags(``, ``),
+			// the revoked tokens are rejected by the auth interceptors when a blacklist is provided
+			fx.ParamTags(``, ``, `optional:"true"`),
 		),
 		NewGrpcClient,
 	))
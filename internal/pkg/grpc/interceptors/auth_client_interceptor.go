@@ -0,0 +1,97 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/reoden/go-NFT/pkg/utils"
+
+	"emperror.dev/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type serviceCredentialKey struct{}
+
+// WithServiceCredential asks the client interceptors to call with the credential of the service instead of the token
+// of the caller, like the background jobs calling the other services, it is ignored when the service credential is not
+// enabled in the auth options
+func WithServiceCredential(ctx context.Context) context.Context {
+	return context.WithValue(ctx, serviceCredentialKey{}, true)
+}
+
+// TokenUnaryClientInterceptor forwards the token of the caller to the called service, the calls get the credential of
+// the service only when they ask for it with WithServiceCredential and serviceCredential is set
+func TokenUnaryClientInterceptor(serviceName string, serviceCredential bool) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		ctx, err := withOutgoingToken(ctx, serviceName, serviceCredential)
+		if err != nil {
+			return err
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// TokenStreamClientInterceptor forwards the token of the caller to the called service, the calls get the credential of
+// the service only when they ask for it with WithServiceCredential and serviceCredential is set
+func TokenStreamClientInterceptor(serviceName string, serviceCredential bool) grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		ctx, err := withOutgoingToken(ctx, serviceName, serviceCredential)
+		if err != nil {
+			return nil, err
+		}
+
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
+
+func withOutgoingToken(ctx context.Context, serviceName string, serviceCredential bool) (context.Context, error) {
+	// the token set explicitly by the caller is kept
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(authorizationHeader)) > 0 {
+		return ctx, nil
+	}
+
+	token := ""
+	if requested, _ := ctx.Value(serviceCredentialKey{}).(bool); requested {
+		if !serviceCredential {
+			return nil, errors.New("the service credential is not enabled for the client")
+		}
+
+		var err error
+		token, err = utils.GenServiceJWTToken(serviceName)
+		if err != nil {
+			return nil, errors.WrapIf(err, "error in generating the service credential")
+		}
+	} else if caller, ok := CallerFromContext(ctx); ok {
+		token = caller.Token
+	}
+	if token == "" {
+		token = tokenFromMetadata(ctx)
+	}
+
+	// the calls without a caller token are sent without a token, they are never upgraded to the service credential
+	if token == "" {
+		return ctx, nil
+	}
+
+	return metadata.AppendToOutgoingContext(
+		ctx,
+		authorizationHeader,
+		fmt.Sprintf("%s %s", bearerScheme, token),
+	), nil
+}
@@ -0,0 +1,195 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/reoden/go-NFT/pkg/grpc/config"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/utils"
+
+	grpcMiddleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"github.com/samber/lo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	authorizationHeader = "authorization"
+	bearerScheme        = "Bearer"
+)
+
+// alwaysPublicMethods the prefixes of the infrastructure methods called without a token
+var alwaysPublicMethods = []string{ //nolint:gochecknoglobals
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.",
+}
+
+// Caller the authenticated caller of a grpc call, a user or a service
+type Caller struct {
+	UserId      string
+	ServiceName string
+	Role        string
+	Token       string
+}
+
+type callerKey struct{}
+
+// TokenBlacklistChecker checks whether a token is revoked, like the tokens of the logged out users
+type TokenBlacklistChecker interface {
+	IsBlacklisted(ctx context.Context, token string) (bool, error)
+}
+
+// ContextWithToken keeps the token of the current caller in the context, the client interceptors forward it to the
+// called services, it is used when the caller is authenticated outside the grpc server like by the echo middlewares
+func ContextWithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, callerKey{}, &Caller{Token: token})
+}
+
+// CallerFromContext returns the caller authenticated by the auth server interceptors
+func CallerFromContext(ctx context.Context) (*Caller, bool) {
+	caller, ok := ctx.Value(callerKey{}).(*Caller)
+
+	return caller, ok && caller != nil
+}
+
+// AuthUnaryServerInterceptor validates the bearer jwt of the metadata, rejects the tokens revoked in the blacklist and
+// enforces the role rules of the methods
+func AuthUnaryServerInterceptor(
+	options *config.AuthOptions,
+	blacklist TokenBlacklistChecker,
+) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx, err := authorize(ctx, options, blacklist, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// AuthStreamServerInterceptor validates the bearer jwt of the metadata, rejects the tokens revoked in the blacklist and
+// enforces the role rules of the methods
+func AuthStreamServerInterceptor(
+	options *config.AuthOptions,
+	blacklist TokenBlacklistChecker,
+) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := authorize(ss.Context(), options, blacklist, info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		wrapped := grpcMiddleware.WrapServerStream(ss)
+		wrapped.WrappedContext = ctx
+
+		return handler(srv, wrapped)
+	}
+}
+
+func authorize(
+	ctx context.Context,
+	options *config.AuthOptions,
+	blacklist TokenBlacklistChecker,
+	fullMethod string,
+) (context.Context, error) {
+	if isAlwaysPublic(fullMethod) || options.IsPublic(fullMethod) {
+		return ctx, nil
+	}
+
+	token := tokenFromMetadata(ctx)
+	if token == "" {
+		return nil, customErrors.NewUnAuthorizedError(
+			fmt.Sprintf("the bearer token of `%s` is missing", fullMethod),
+		)
+	}
+
+	claims, err := utils.ParseJWTClaims(token)
+	if err != nil {
+		return nil, customErrors.NewUnAuthorizedErrorWrap(
+			err,
+			fmt.Sprintf("the bearer token of `%s` is invalid", fullMethod),
+		)
+	}
+
+	if blacklist != nil {
+		blacklisted, err := blacklist.IsBlacklisted(ctx, token)
+		if err != nil {
+			return nil, customErrors.NewInternalServerErrorWrap(
+				err,
+				fmt.Sprintf("error in checking the bearer token of `%s` in the blacklist", fullMethod),
+			)
+		}
+		if blacklisted {
+			return nil, customErrors.NewUnAuthorizedError(
+				fmt.Sprintf("the bearer token of `%s` is revoked", fullMethod),
+			)
+		}
+	}
+
+	caller := &Caller{
+		UserId:      stringClaim(claims, "userId"),
+		ServiceName: stringClaim(claims, "service"),
+		Role:        stringClaim(claims, "role"),
+		Token:       token,
+	}
+
+	// the methods without a rule are denied, so the new methods are never opened by a missing configuration
+	roles, ok := options.RolesOf(fullMethod)
+	if !ok {
+		return nil, customErrors.NewForbiddenError(
+			fmt.Sprintf("the method `%s` has no auth rule", fullMethod),
+		)
+	}
+	if len(roles) > 0 && !lo.Contains(roles, caller.Role) {
+		return nil, customErrors.NewForbiddenError(
+			fmt.Sprintf("the role `%s` is not allowed to call `%s`", caller.Role, fullMethod),
+		)
+	}
+
+	return context.WithValue(ctx, callerKey{}, caller), nil
+}
+
+func isAlwaysPublic(fullMethod string) bool {
+	for _, prefix := range alwaysPublicMethods {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func tokenFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	for _, value := range md.Get(authorizationHeader) {
+		scheme, token, found := strings.Cut(value, " ")
+		if found && strings.EqualFold(scheme, bearerScheme) && token != "" {
+			return strings.TrimSpace(token)
+		}
+	}
+
+	return ""
+}
+
+func stringClaim(claims map[string]interface{}, name string) string {
+	value, _ := claims[name].(string)
+
+	return value
+}
@@ -0,0 +1,195 @@
+//go:build unit
+// +build unit
+
+package interceptors
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/reoden/go-NFT/pkg/constants"
+	"github.com/reoden/go-NFT/pkg/grpc/config"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/utils"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const createProductMethod = "/products_service.ProductsService/CreateProduct"
+
+var authOptions = &config.AuthOptions{
+	Enabled:       true,
+	PublicMethods: []string{"/products_service.ProductsService/GetProductById"},
+	Rules: []config.MethodRule{
+		{Method: createProductMethod, Roles: []string{"艺术家", constants.ServiceRole}},
+	},
+}
+
+func TestMain(m *testing.M) {
+	os.Setenv("JWT_SECRET", "grpc-interceptors-test-secret")
+
+	os.Exit(m.Run())
+}
+
+func Test_Auth_Interceptor_Allows_Public_And_Health_Methods_Without_Token(t *testing.T) {
+	interceptor := AuthUnaryServerInterceptor(authOptions, nil)
+
+	for _, method := range []string{
+		"/products_service.ProductsService/GetProductById",
+		"/grpc.health.v1.Health/Check",
+	} {
+		_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method}, okHandler)
+		assert.NoError(t, err, method)
+	}
+}
+
+func Test_Auth_Interceptor_Rejects_Missing_And_Invalid_Tokens(t *testing.T) {
+	interceptor := AuthUnaryServerInterceptor(authOptions, nil)
+	info := &grpc.UnaryServerInfo{FullMethod: createProductMethod}
+
+	_, err := interceptor(context.Background(), nil, info, okHandler)
+	assert.True(t, customErrors.IsUnAuthorizedError(err))
+
+	_, err = interceptor(incomingContextWithToken("not-a-jwt"), nil, info, okHandler)
+	assert.True(t, customErrors.IsUnAuthorizedError(err))
+}
+
+func Test_Auth_Interceptor_Enforces_Method_Roles(t *testing.T) {
+	interceptor := AuthUnaryServerInterceptor(authOptions, nil)
+	info := &grpc.UnaryServerInfo{FullMethod: createProductMethod}
+	userId := uuid.NewV4()
+
+	customerToken, err := utils.GenJWTToken(userId, "普通用户")
+	require.NoError(t, err)
+	_, err = interceptor(incomingContextWithToken(customerToken), nil, info, okHandler)
+	assert.True(t, customErrors.IsForbiddenError(err))
+
+	artistToken, err := utils.GenJWTToken(userId, "艺术家")
+	require.NoError(t, err)
+	res, err := interceptor(incomingContextWithToken(artistToken), nil, info, callerHandler)
+	require.NoError(t, err)
+	caller := res.(*Caller)
+	assert.Equal(t, userId.String(), caller.UserId)
+	assert.Equal(t, "艺术家", caller.Role)
+
+	// the methods without a rule are denied even to the authenticated callers
+	_, err = interceptor(
+		incomingContextWithToken(artistToken),
+		nil,
+		&grpc.UnaryServerInfo{FullMethod: "/products_service.ProductsService/UpdateProduct"},
+		okHandler,
+	)
+	assert.True(t, customErrors.IsForbiddenError(err))
+}
+
+func Test_Auth_Interceptor_Rejects_Blacklisted_Tokens(t *testing.T) {
+	revokedToken, err := utils.GenJWTToken(uuid.NewV4(), "艺术家")
+	require.NoError(t, err)
+	activeToken, err := utils.GenJWTToken(uuid.NewV4(), "艺术家")
+	require.NoError(t, err)
+
+	interceptor := AuthUnaryServerInterceptor(authOptions, blacklist{revokedToken: true})
+	info := &grpc.UnaryServerInfo{FullMethod: createProductMethod}
+
+	_, err = interceptor(incomingContextWithToken(revokedToken), nil, info, okHandler)
+	assert.True(t, customErrors.IsUnAuthorizedError(err))
+
+	_, err = interceptor(incomingContextWithToken(activeToken), nil, info, okHandler)
+	assert.NoError(t, err)
+}
+
+func Test_Token_Client_Interceptor_Forwards_Caller_Token(t *testing.T) {
+	userToken, err := utils.GenJWTToken(uuid.NewV4(), "艺术家")
+	require.NoError(t, err)
+
+	var sent metadata.MD
+	interceptor := TokenUnaryClientInterceptor("catalogsservice", true)
+
+	err = interceptor(
+		ContextWithToken(context.Background(), userToken),
+		createProductMethod,
+		nil,
+		nil,
+		nil,
+		recordingInvoker(&sent),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Bearer " + userToken}, sent.Get(authorizationHeader))
+
+	// the calls without a caller token don't get the service credential implicitly
+	err = interceptor(context.Background(), createProductMethod, nil, nil, nil, recordingInvoker(&sent))
+	require.NoError(t, err)
+	assert.Empty(t, sent.Get(authorizationHeader))
+}
+
+func Test_Token_Client_Interceptor_Attaches_Requested_Service_Credential(t *testing.T) {
+	var sent metadata.MD
+	ctx := WithServiceCredential(context.Background())
+
+	err := TokenUnaryClientInterceptor("catalogsservice", false)(
+		ctx,
+		createProductMethod,
+		nil,
+		nil,
+		nil,
+		recordingInvoker(&sent),
+	)
+	assert.Error(t, err)
+
+	err = TokenUnaryClientInterceptor("catalogsservice", true)(
+		ctx,
+		createProductMethod,
+		nil,
+		nil,
+		nil,
+		recordingInvoker(&sent),
+	)
+	require.NoError(t, err)
+	require.Len(t, sent.Get(authorizationHeader), 1)
+
+	// the service credential passes the rules of the service role
+	res, err := AuthUnaryServerInterceptor(authOptions, nil)(
+		metadata.NewIncomingContext(context.Background(), sent),
+		nil,
+		&grpc.UnaryServerInfo{FullMethod: createProductMethod},
+		callerHandler,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "catalogsservice", res.(*Caller).ServiceName)
+	assert.Equal(t, constants.ServiceRole, res.(*Caller).Role)
+}
+
+func incomingContextWithToken(token string) context.Context {
+	return metadata.NewIncomingContext(
+		context.Background(),
+		metadata.Pairs(authorizationHeader, "Bearer "+token),
+	)
+}
+
+func okHandler(ctx context.Context, req interface{}) (interface{}, error) {
+	return "ok", nil
+}
+
+func callerHandler(ctx context.Context, req interface{}) (interface{}, error) {
+	caller, _ := CallerFromContext(ctx)
+
+	return caller, nil
+}
+
+func recordingInvoker(sent *metadata.MD) grpc.UnaryInvoker {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		*sent, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+}
+
+type blacklist map[string]bool
+
+func (b blacklist) IsBlacklisted(ctx context.Context, token string) (bool, error) {
+	return b[token], nil
+}
@@ -47,7 +47,8 @@ type grpcServer struct {
 func NewGrpcServer(
 	config *config.GrpcOptions,
 	logger logger.Logger,
-) GrpcServer {
+	blacklist interceptors.TokenBlacklistChecker,
+) (GrpcServer, error) {
 	unaryServerInterceptors := []googleGrpc.UnaryServerInterceptor{
 		interceptors.UnaryServerInterceptor(),
 		grpcCtxTags.UnaryServerInterceptor(),
@@ -57,7 +58,28 @@ func NewGrpcServer(
 		interceptors.StreamServerInterceptor(),
 	}
 
-	s := googleGrpc.NewServer(
+	// the auth errors are converted by the error interceptors at the head of the chains
+	if config.Auth.IsEnabled() {
+		unaryServerInterceptors = append(
+			unaryServerInterceptors,
+			interceptors.AuthUnaryServerInterceptor(config.Auth, blacklist),
+		)
+		streamServerInterceptors = append(
+			streamServerInterceptors,
+			interceptors.AuthStreamServerInterceptor(config.Auth, blacklist),
+		)
+	}
+
+	var serverOptions []googleGrpc.ServerOption
+	if config.Tls.IsEnabled() {
+		creds, err := newServerCredentials(config.Tls)
+		if err != nil {
+			return nil, err
+		}
+		serverOptions = append(serverOptions, googleGrpc.Creds(creds))
+	}
+
+	serverOptions = append(serverOptions,
 		// https://github.com/open-telemetry/opentelemetry-go-contrib/issues/2840
 		// https://github.com/open-telemetry/opentelemetry-go-contrib/pull/3002
 		// https://github.com/open-telemetry/opentelemetry-go-contrib/blob/main/instrumentation/google.golang.org/grpc/otelgrpc/doc.go
@@ -80,6 +102,8 @@ func NewGrpcServer(
 			unaryServerInterceptors...,
 		)),
 	)
+
+	s := googleGrpc.NewServer(serverOptions...)
 	healthServer := health.NewServer()
 	grpc_health_v1.RegisterHealthServer(s, healthServer)
 	healthServer.SetServingStatus(
@@ -93,7 +117,7 @@ func NewGrpcServer(
 		log:            logger,
 		serviceName:    config.Name,
 		serviceBuilder: NewGrpcServiceBuilder(s),
-	}
+	}, nil
 }
 
 func (s *grpcServer) RunGrpcServer(
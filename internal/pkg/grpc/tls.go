@@ -0,0 +1,68 @@
+package grpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+
+	"github.com/reoden/go-NFT/pkg/grpc/config"
+
+	"emperror.dev/errors"
+	"google.golang.org/grpc/credentials"
+)
+
+// newServerCredentials creates the mutual tls credentials of the server, the client certificates are required and
+// verified with the ca of the options
+func newServerCredentials(options *config.TlsOptions) (credentials.TransportCredentials, error) {
+	certificate, err := tls.LoadX509KeyPair(options.CertFile, options.KeyFile)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in loading the grpc server certificate")
+	}
+
+	caPool, err := loadCaPool(options.CaFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    caPool,
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
+
+// newClientCredentials creates the mutual tls credentials of the client, the server certificate is verified with the
+// ca of the options
+func newClientCredentials(options *config.TlsOptions) (credentials.TransportCredentials, error) {
+	certificate, err := tls.LoadX509KeyPair(options.CertFile, options.KeyFile)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in loading the grpc client certificate")
+	}
+
+	caPool, err := loadCaPool(options.CaFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		RootCAs:      caPool,
+		ServerName:   options.ServerName,
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
+
+func loadCaPool(caFile string) (*x509.CertPool, error) {
+	ca, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in reading the grpc ca certificate")
+	}
+
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(ca) {
+		return nil, errors.Errorf("no certificate found in the grpc ca file `%s`", caFile)
+	}
+
+	return caPool, nil
+}
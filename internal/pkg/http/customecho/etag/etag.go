@@ -0,0 +1,52 @@
+package etag
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderETag    = "ETag"
+	HeaderIfMatch = "If-Match"
+)
+
+// VersionETag the entity tag of a versioned resource, it is a strong tag because every change increments the version
+// https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/ETag
+func VersionETag(version int64) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// SetVersionETag sets the `ETag` response header for the version of the resource
+func SetVersionETag(c echo.Context, version int64) {
+	c.Response().Header().Set(HeaderETag, VersionETag(version))
+}
+
+// IfMatchVersion reads the version from the `If-Match` request header, ok is false when the header is missing or is
+// `*`. Weak tags are rejected because `If-Match` uses the strong comparison.
+// https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/If-Match
+func IfMatchVersion(c echo.Context) (version int64, ok bool, err error) {
+	header := strings.TrimSpace(c.Request().Header.Get(HeaderIfMatch))
+	if header == "" || header == "*" {
+		return 0, false, nil
+	}
+
+	if strings.Contains(header, ",") || strings.HasPrefix(header, "W/") {
+		return 0, false, customErrors.NewBadRequestError(
+			"`If-Match` header should contain a single strong entity tag",
+		)
+	}
+
+	version, err = strconv.ParseInt(strings.Trim(header, `"`), 10, 64)
+	if err != nil || version <= 0 {
+		return 0, false, customErrors.NewBadRequestError(
+			fmt.Sprintf("`If-Match` header `%s` is not a valid entity tag", header),
+		)
+	}
+
+	return version, true, nil
+}
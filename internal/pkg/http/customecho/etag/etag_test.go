@@ -0,0 +1,52 @@
+//go:build unit
+// +build unit
+
+package etag
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newContext(ifMatch string) echo.Context {
+	req := httptest.NewRequest(http.MethodPut, "/", nil)
+	if ifMatch != "" {
+		req.Header.Set(HeaderIfMatch, ifMatch)
+	}
+
+	return echo.New().NewContext(req, httptest.NewRecorder())
+}
+
+func Test_Set_Version_ETag(t *testing.T) {
+	c := newContext("")
+	SetVersionETag(c, 3)
+
+	assert.Equal(t, `"3"`, c.Response().Header().Get(HeaderETag))
+}
+
+func Test_If_Match_Version(t *testing.T) {
+	version, ok, err := IfMatchVersion(newContext(`"3"`))
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), version)
+
+	_, ok, err = IfMatchVersion(newContext(""))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, ok, err = IfMatchVersion(newContext("*"))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	for _, header := range []string{`W/"3"`, `"3", "4"`, `"abc"`, `"0"`} {
+		_, _, err = IfMatchVersion(newContext(header))
+		assert.True(t, customErrors.IsBadRequestError(err), header)
+	}
+}
@@ -17,7 +17,8 @@ func NewRedisTokenBlacklistChecker(client *redis.Client) TokenBlacklistChecker {
 }
 
 func (r *RedisTokenBlacklistChecker) IsBlacklisted(ctx context.Context, token string) (bool, error) {
-	key := fmt.Sprintf("%s:%s", constants.TokenBlackPrefixKey, token)
+	// the same key as the logout of the user service
+	key := fmt.Sprintf("%s%s", constants.TokenBlackPrefixKey, token)
 	exists, err := r.client.Exists(ctx, key).Result()
 	if err != nil {
 		return false, err
@@ -6,8 +6,11 @@ import (
 	"os"
 	"strings"
 
+	"github.com/reoden/go-NFT/pkg/utils"
+
 	echojwt "github.com/labstack/echo-jwt/v4"
 	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
 )
 
 // https://github.com/labstack/echo-jwt?tab=readme-ov-file#full-example
@@ -57,6 +60,23 @@ func JWTWithBlacklist(
 	}
 }
 
+// RequireRoles rejects the requests whose token, validated by the jwt middleware before it, doesn't have one of the roles
+func RequireRoles(roles ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			role, err := utils.ParseJWTRole(c)
+			if err != nil {
+				return echo.ErrUnauthorized
+			}
+			if !lo.Contains(roles, role) {
+				return echo.ErrForbidden
+			}
+
+			return next(c)
+		}
+	}
+}
+
 func extractRawToken(c echo.Context) string {
 	authHeader := c.Request().Header.Get("Authorization")
 	if authHeader == "" {
@@ -0,0 +1,68 @@
+package customErrors
+
+import (
+	"net/http"
+
+	"emperror.dev/errors"
+)
+
+// NewConcurrencyError creates an optimistic concurrency conflict error, the record was changed by someone else after
+// it was read. It is a `ConflictError` too, so it is mapped to `409 Conflict`.
+func NewConcurrencyError(message string) ConcurrencyError {
+	// `NewPlain` doesn't add stack-trace at all
+	concurrencyErrMessage := errors.NewPlain("concurrency error")
+	// `WrapIf` add stack-trace if not added before
+	stackErr := errors.WrapIf(concurrencyErrMessage, message)
+
+	concurrencyError := &concurrencyError{
+		CustomError: NewCustomError(stackErr, http.StatusConflict, message),
+	}
+
+	return concurrencyError
+}
+
+func NewConcurrencyErrorWrap(err error, message string) ConcurrencyError {
+	if err == nil {
+		return NewConcurrencyError(message)
+	}
+
+	// `WithMessage` doesn't add stack-trace at all
+	concurrencyErrMessage := errors.WithMessage(err, "concurrency error")
+	// `WrapIf` add stack-trace if not added before
+	stackErr := errors.WrapIf(concurrencyErrMessage, message)
+
+	concurrencyError := &concurrencyError{
+		CustomError: NewCustomError(stackErr, http.StatusConflict, message),
+	}
+
+	return concurrencyError
+}
+
+type concurrencyError struct {
+	CustomError
+}
+
+type ConcurrencyError interface {
+	ConflictError
+	isConcurrencyError()
+}
+
+func (c *concurrencyError) isConflictError() {
+}
+
+func (c *concurrencyError) isConcurrencyError() {
+}
+
+func IsConcurrencyError(err error) bool {
+	var concurrencyError ConcurrencyError
+
+	if _, ok := err.(ConcurrencyError); ok {
+		return true
+	}
+
+	if errors.As(err, &concurrencyError) {
+		return true
+	}
+
+	return false
+}
@@ -492,6 +492,28 @@ func Test_Conflict_Error(t *testing.T) {
 	}
 }
 
+func Test_Concurrency_Error(t *testing.T) {
+	rootErr := errors.NewPlain("version mismatch")
+	concurrencyErr := NewConcurrencyErrorWrap(rootErr, "product was modified by another request")
+	err := errors.WithMessage(concurrencyErr, "this is a top error message")
+
+	assert.True(t, IsCustomError(err))
+	assert.True(t, IsConcurrencyError(err))
+	assert.True(t, IsConflictError(err))
+	assert.False(t, IsConcurrencyError(NewConflictError("conflict error")))
+
+	var concurrencyError ConcurrencyError
+	errors.As(err, &concurrencyError)
+
+	assert.Equal(t, 409, concurrencyError.Status())
+	assert.Equal(t, "product was modified by another request", concurrencyError.Message())
+	assert.Equal(
+		t,
+		"product was modified by another request: concurrency error: version mismatch",
+		concurrencyError.Error(),
+	)
+}
+
 func myfoo(e error) error {
 	// https://itnext.io/golang-error-handling-best-practice-a36f47b0b94c
 	// Note: Do not repeat Wrap, it will record redundancy call stacks, we usually care about root stack trace
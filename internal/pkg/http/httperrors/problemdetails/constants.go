@@ -3,6 +3,7 @@ package problemDetails
 const (
 	ErrBadRequestTitle          = "Bad Request"
 	ErrConflictTitle            = "Conflict Error"
+	ErrConcurrencyTitle         = "Concurrency Conflict Error"
 	ErrNotFoundTitle            = "Not Found"
 	ErrUnauthorizedTitle        = "Unauthorized"
 	ErrForbiddenTitle           = "Forbidden"
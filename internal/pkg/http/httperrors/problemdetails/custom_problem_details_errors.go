@@ -31,6 +31,17 @@ func NewConflictProblemDetail(detail string, stackTrace string) ProblemDetailErr
 	}
 }
 
+func NewConcurrencyProblemDetail(detail string, stackTrace string) ProblemDetailErr {
+	return &problemDetail{
+		Title:      constants.ErrConcurrencyTitle,
+		Detail:     detail,
+		Status:     http.StatusConflict,
+		Type:       getDefaultType(http.StatusConflict),
+		Timestamp:  time.Now(),
+		StackTrace: stackTrace,
+	}
+}
+
 func NewBadRequestProblemDetail(detail string, stackTrace string) ProblemDetailErr {
 	return &problemDetail{
 		Title:      constants.ErrBadRequestTitle,
@@ -77,6 +77,8 @@ func ParseError(err error) ProblemDetailErr {
 			)
 		case customErrors.IsForbiddenError(err):
 			return NewForbiddenProblemDetail(customErr.Error(), stackTrace)
+		case customErrors.IsConcurrencyError(err):
+			return NewConcurrencyProblemDetail(customErr.Error(), stackTrace)
 		case customErrors.IsConflictError(err):
 			return NewConflictProblemDetail(customErr.Error(), stackTrace)
 		case customErrors.IsInternalServerError(err):
@@ -52,6 +52,12 @@ func Test_Parse_Error(t *testing.T) {
 	notfoundPrb := ParseError(notFoundError)
 	assert.NotNil(t, notFoundError)
 	assert.Equal(t, notfoundPrb.GetStatus(), 404)
+
+	// Concurrency ProblemDetail
+	concurrencyPrb := ParseError(customErrors.NewConcurrencyError("concurrency error"))
+	assert.NotNil(t, concurrencyPrb)
+	assert.Equal(t, concurrencyPrb.GetStatus(), 409)
+	assert.Equal(t, concurrencyPrb.GetTitle(), "Concurrency Conflict Error")
 }
 
 func TestMap(t *testing.T) {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/reoden/go-NFT/pkg/core/messaging/pipeline"
 	types2 "github.com/reoden/go-NFT/pkg/core/messaging/types"
+	"github.com/reoden/go-NFT/pkg/core/messaging/utils"
 	"github.com/reoden/go-NFT/pkg/otel/constants/telemetrytags"
 	"github.com/reoden/go-NFT/pkg/otel/metrics"
 	attribute2 "github.com/reoden/go-NFT/pkg/otel/tracing/attribute"
@@ -42,7 +43,7 @@ func (m *messagingMetricsPipeline) Handle(
 	next pipeline.ConsumerHandlerFunc,
 ) error {
 	message := consumerContext.Message()
-	messageTypeName := message.GetMessageTypeName()
+	messageTypeName := utils.GetMessageTypeName(message)
 	snakeTypeName := strcase.ToSnake(messageTypeName)
 
 	successRequestsCounter, err := m.meter.Int64Counter(
@@ -130,5 +131,6 @@ func (m *messagingMetricsPipeline) Handle(
 
 	durationValueRecorder.Record(ctx, duration, opt)
 
-	return nil
+	// the error of the handlers is returned to the consumer, so the failed message is retried
+	return err
 }
@@ -0,0 +1,20 @@
+package postgreseventstore
+
+import (
+	"github.com/reoden/go-NFT/pkg/postgresgorm/contracts"
+	"github.com/reoden/go-NFT/pkg/postgresgorm/gormdbcontext"
+
+	"gorm.io/gorm"
+)
+
+type PostgresEventStoreDBContext struct {
+	// our dbcontext base
+	contracts.GormDBContext
+}
+
+func NewPostgresEventStoreDBContext(db *gorm.DB) *PostgresEventStoreDBContext {
+	// initialize base GormContext
+	c := &PostgresEventStoreDBContext{GormDBContext: gormdbcontext.NewGormDBContext(db)}
+
+	return c
+}
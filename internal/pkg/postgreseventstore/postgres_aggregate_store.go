@@ -0,0 +1,366 @@
+package postgreseventstore
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/reoden/go-NFT/pkg/core/domain"
+	"github.com/reoden/go-NFT/pkg/core/metadata"
+	"github.com/reoden/go-NFT/pkg/core/serializer"
+	"github.com/reoden/go-NFT/pkg/es"
+	"github.com/reoden/go-NFT/pkg/es/contracts/store"
+	esErrors "github.com/reoden/go-NFT/pkg/es/errors"
+	"github.com/reoden/go-NFT/pkg/es/models"
+	appendResult "github.com/reoden/go-NFT/pkg/es/models/append_result"
+	streamName "github.com/reoden/go-NFT/pkg/es/models/stream_name"
+	readPosition "github.com/reoden/go-NFT/pkg/es/models/stream_position/read_position"
+	expectedStreamVersion "github.com/reoden/go-NFT/pkg/es/models/stream_version"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/otel/tracing/attribute"
+	"github.com/reoden/go-NFT/pkg/otel/tracing/utils"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+	attribute2 "go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const readPageSize = 500
+
+type postgresAggregateStore[T models.IHaveEventSourcedAggregate] struct {
+	log             logger.Logger
+	eventStore      store.EventStore
+	eventSerializer serializer.EventSerializer
+	tracer          trace.Tracer
+	snapshotStore   store.SnapshotStore
+	streamArchive   store.StreamArchive
+	config          *es.EventStoreConfig
+}
+
+// NewPostgresAggregateStore creates an aggregate store on the postgres event store, when a snapshot store is provided the
+// aggregates that implement models.IHaveSnapshot are snapshotted every `SnapshotFrequency` events and loaded from their latest snapshot.
+// When a stream archive is provided the archived streams are rehydrated on their load.
+func NewPostgresAggregateStore[T models.IHaveEventSourcedAggregate](
+	log logger.Logger,
+	eventStore store.EventStore,
+	eventSerializer serializer.EventSerializer,
+	tracer trace.Tracer,
+	snapshotStore store.SnapshotStore,
+	streamArchive store.StreamArchive,
+	config *es.EventStoreConfig,
+) store.AggregateStore[T] {
+	return &postgresAggregateStore[T]{
+		log:             log,
+		eventStore:      eventStore,
+		eventSerializer: eventSerializer,
+		tracer:          tracer,
+		snapshotStore:   snapshotStore,
+		streamArchive:   streamArchive,
+		config:          config,
+	}
+}
+
+func (a *postgresAggregateStore[T]) StoreWithVersion(
+	aggregate T,
+	metadata metadata.Metadata,
+	expectedVersion expectedStreamVersion.ExpectedStreamVersion,
+	ctx context.Context,
+) (*appendResult.AppendEventsResult, error) {
+	ctx, span := a.tracer.Start(ctx, "postgresAggregateStore.StoreWithVersion")
+	span.SetAttributes(attribute2.String("AggregateID", aggregate.Id().String()))
+	defer span.End()
+
+	if len(aggregate.UncommittedEvents()) == 0 {
+		a.log.Infow(
+			fmt.Sprintf(
+				"[postgresAggregateStore.StoreWithVersion] No events to store for aggregateId %s",
+				aggregate.Id(),
+			),
+			logger.Fields{"AggregateID": aggregate.Id()},
+		)
+
+		return appendResult.NoOp, nil
+	}
+
+	streamId := streamName.For[T](aggregate)
+	span.SetAttributes(attribute2.String("StreamId", streamId.String()))
+
+	previousVersion := aggregate.CurrentVersion() - int64(len(aggregate.UncommittedEvents()))
+
+	streamEvents := make([]*models.StreamEvent, 0, len(aggregate.UncommittedEvents()))
+	for i, domainEvent := range aggregate.UncommittedEvents() {
+		streamEvents = append(streamEvents, &models.StreamEvent{
+			EventID:  uuid.NewV4(),
+			Event:    domainEvent,
+			Metadata: metadata,
+			Version:  previousVersion + int64(i) + 1,
+		})
+	}
+
+	streamAppendResult, err := a.eventStore.AppendEvents(streamId, expectedVersion, streamEvents, ctx)
+	if err != nil {
+		return nil, utils.TraceErrStatusFromSpan(
+			span,
+			errors.WrapIff(
+				err,
+				"[postgresAggregateStore_StoreWithVersion:AppendEvents] error in storing aggregate with id {%s}",
+				aggregate.Id().String(),
+			),
+		)
+	}
+
+	aggregate.MarkUncommittedEventAsCommitted()
+
+	a.saveSnapshot(ctx, aggregate, streamId, previousVersion)
+
+	span.SetAttributes(attribute.Object("Aggregate", aggregate))
+
+	a.log.Infow(
+		fmt.Sprintf(
+			"[postgresAggregateStore.StoreWithVersion] aggregate with id %s stored successfully",
+			aggregate.Id().String(),
+		),
+		logger.Fields{"Aggregate": aggregate, "StreamId": streamId},
+	)
+
+	return streamAppendResult, nil
+}
+
+func (a *postgresAggregateStore[T]) Store(
+	aggregate T,
+	metadata metadata.Metadata,
+	ctx context.Context,
+) (*appendResult.AppendEventsResult, error) {
+	expectedVersion := expectedStreamVersion.FromInt64(aggregate.OriginalVersion())
+
+	return a.StoreWithVersion(aggregate, metadata, expectedVersion, ctx)
+}
+
+func (a *postgresAggregateStore[T]) Load(ctx context.Context, aggregateId uuid.UUID) (T, error) {
+	return a.LoadWithReadPosition(ctx, aggregateId, readPosition.Start)
+}
+
+func (a *postgresAggregateStore[T]) LoadWithReadPosition(
+	ctx context.Context,
+	aggregateId uuid.UUID,
+	position readPosition.StreamReadPosition,
+) (T, error) {
+	ctx, span := a.tracer.Start(ctx, "postgresAggregateStore.LoadWithReadPosition")
+	span.SetAttributes(attribute2.String("AggregateID", aggregateId.String()))
+	defer span.End()
+
+	aggregate, err := a.newEmptyAggregate()
+	if err != nil {
+		return *new(T), utils.TraceErrStatusFromSpan(span, err)
+	}
+
+	streamId := streamName.ForID[T](aggregateId)
+	span.SetAttributes(attribute2.String("StreamId", streamId.String()))
+
+	// snapshots only used when the whole state of aggregate is requested
+	restoredFromSnapshot := false
+	if position.IsStart() {
+		snapshotVersion, restored := a.restoreSnapshot(ctx, aggregate, streamId)
+		if restored {
+			restoredFromSnapshot = true
+			position = readPosition.FromInt64(snapshotVersion + 1)
+		} else if aggregate, err = a.newEmptyAggregate(); err != nil {
+			// the state of a failed restore is discarded
+			return *new(T), utils.TraceErrStatusFromSpan(span, err)
+		}
+	}
+
+	streamEvents, err := a.getStreamEvents(ctx, streamId, position)
+	if err == nil && a.isIncomplete(streamEvents, position) {
+		streamEvents, err = a.rehydrateStreamEvents(ctx, streamId, position, streamEvents)
+	}
+	if errors.Is(err, esErrors.ErrStreamNotFound) || (err == nil && len(streamEvents) == 0 && !restoredFromSnapshot) {
+		return *new(T), utils.TraceErrStatusFromSpan(
+			span,
+			customErrors.NewNotFoundErrorWrap(
+				esErrors.ErrStreamNotFound,
+				fmt.Sprintf("aggregate with id %s not found", aggregateId.String()),
+			),
+		)
+	}
+	if err != nil {
+		return *new(T), utils.TraceErrStatusFromSpan(
+			span,
+			errors.WrapIff(
+				err,
+				"[postgresAggregateStore.LoadWithReadPosition] error in loading aggregate {%s}",
+				aggregateId.String(),
+			),
+		)
+	}
+
+	var meta metadata.Metadata
+	domainEvents := make([]domain.IDomainEvent, 0, len(streamEvents))
+	for _, streamEvent := range streamEvents {
+		meta = streamEvent.Metadata
+		domainEvents = append(domainEvents, streamEvent.Event)
+	}
+
+	err = aggregate.LoadFromHistory(domainEvents, meta)
+	if err != nil {
+		return *new(T), utils.TraceStatusFromSpan(span, err)
+	}
+
+	span.SetAttributes(attribute.Object("Aggregate", aggregate))
+
+	return aggregate, nil
+}
+
+func (a *postgresAggregateStore[T]) Exists(ctx context.Context, aggregateId uuid.UUID) (bool, error) {
+	ctx, span := a.tracer.Start(ctx, "postgresAggregateStore.Exists")
+	span.SetAttributes(attribute2.String("AggregateID", aggregateId.String()))
+	defer span.End()
+
+	return a.eventStore.StreamExists(streamName.ForID[T](aggregateId), ctx)
+}
+
+func (a *postgresAggregateStore[T]) newEmptyAggregate() (T, error) {
+	var typeNameType T
+	aggregate, ok := typeMapper.InstancePointerByTypeName(typeMapper.GetFullTypeName(typeNameType)).(T)
+	if !ok {
+		return *new(T), errors.New(
+			fmt.Sprintf(
+				"[postgresAggregateStore_newEmptyAggregate] aggregate is not a %s",
+				typeMapper.GetFullTypeName(typeNameType),
+			),
+		)
+	}
+
+	method := reflect.ValueOf(aggregate).MethodByName("NewEmptyAggregate")
+	if !method.IsValid() {
+		return *new(T), errors.New(
+			"[postgresAggregateStore_newEmptyAggregate:MethodByName] aggregate does not have a `NewEmptyAggregate` method",
+		)
+	}
+	method.Call([]reflect.Value{})
+
+	return aggregate, nil
+}
+
+func (a *postgresAggregateStore[T]) saveSnapshot(
+	ctx context.Context,
+	aggregate T,
+	streamId streamName.StreamName,
+	previousVersion int64,
+) {
+	if a.snapshotStore == nil ||
+		!es.ShouldTakeSnapshot(a.config, previousVersion, aggregate.CurrentVersion()) {
+		return
+	}
+
+	snapshot, err := es.CreateSnapshot(aggregate, streamId.String(), aggregate.CurrentVersion(), a.eventSerializer)
+	if err == nil && snapshot != nil {
+		err = a.snapshotStore.Save(ctx, snapshot)
+	}
+
+	// the events are already stored, so a failed snapshot only makes the next loads slower
+	if err != nil {
+		a.log.Errorw(
+			fmt.Sprintf(
+				"[postgresAggregateStore.saveSnapshot] error in saving snapshot of stream %s: %v",
+				streamId.String(),
+				err,
+			),
+			logger.Fields{"StreamId": streamId.String(), "Version": aggregate.CurrentVersion()},
+		)
+	}
+}
+
+func (a *postgresAggregateStore[T]) restoreSnapshot(
+	ctx context.Context,
+	aggregate T,
+	streamId streamName.StreamName,
+) (int64, bool) {
+	if a.snapshotStore == nil {
+		return 0, false
+	}
+
+	snapshot, err := a.snapshotStore.Load(ctx, streamId.String())
+	if errors.Is(err, store.ErrSnapshotNotFound) {
+		return 0, false
+	}
+
+	restored := false
+	if err == nil {
+		restored, err = es.RestoreSnapshot(aggregate, snapshot, a.eventSerializer)
+	}
+	if err != nil {
+		a.log.Errorw(
+			fmt.Sprintf(
+				"[postgresAggregateStore.restoreSnapshot] error in restoring snapshot of stream %s, the whole stream will be replayed: %v",
+				streamId.String(),
+				err,
+			),
+			logger.Fields{"StreamId": streamId.String()},
+		)
+
+		return 0, false
+	}
+	if !restored {
+		return 0, false
+	}
+
+	return snapshot.Version, true
+}
+
+// isIncomplete reports whether the events don't start from the read position, it is the case of the archived and the
+// truncated streams
+func (a *postgresAggregateStore[T]) isIncomplete(
+	streamEvents []*models.StreamEvent,
+	position readPosition.StreamReadPosition,
+) bool {
+	if a.streamArchive == nil || position.IsEnd() {
+		return false
+	}
+
+	return len(streamEvents) == 0 || streamEvents[0].Version > position.Value()
+}
+
+// rehydrateStreamEvents restores the archived events of the stream and reads the stream again, the events of the streams
+// that are not archived are returned as they are
+func (a *postgresAggregateStore[T]) rehydrateStreamEvents(
+	ctx context.Context,
+	streamId streamName.StreamName,
+	position readPosition.StreamReadPosition,
+	streamEvents []*models.StreamEvent,
+) ([]*models.StreamEvent, error) {
+	rehydrated, err := a.streamArchive.Rehydrate(ctx, streamId)
+	if err != nil {
+		return nil, errors.WrapIf(err, "[postgresAggregateStore_rehydrateStreamEvents:Rehydrate] failed to rehydrate the stream")
+	}
+	if !rehydrated {
+		return streamEvents, nil
+	}
+
+	return a.getStreamEvents(ctx, streamId, position)
+}
+
+func (a *postgresAggregateStore[T]) getStreamEvents(
+	ctx context.Context,
+	streamId streamName.StreamName,
+	position readPosition.StreamReadPosition,
+) ([]*models.StreamEvent, error) {
+	var streamEvents []*models.StreamEvent
+
+	for {
+		events, err := a.eventStore.ReadEvents(streamId, position, readPageSize, ctx)
+		if err != nil {
+			return nil, errors.WrapIf(err, "[postgresAggregateStore_getStreamEvents:ReadEvents] failed to read events")
+		}
+		streamEvents = append(streamEvents, events...)
+		if len(events) < readPageSize {
+			break
+		}
+		position = readPosition.FromInt64(events[len(events)-1].Version + 1)
+	}
+
+	return streamEvents, nil
+}
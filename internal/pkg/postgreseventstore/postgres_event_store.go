@@ -0,0 +1,703 @@
+package postgreseventstore
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/core/serializer"
+	"github.com/reoden/go-NFT/pkg/es/contracts/store"
+	esErrors "github.com/reoden/go-NFT/pkg/es/errors"
+	"github.com/reoden/go-NFT/pkg/es/models"
+	appendResult "github.com/reoden/go-NFT/pkg/es/models/append_result"
+	streamName "github.com/reoden/go-NFT/pkg/es/models/stream_name"
+	readPosition "github.com/reoden/go-NFT/pkg/es/models/stream_position/read_position"
+	"github.com/reoden/go-NFT/pkg/es/models/stream_position/truncatePosition"
+	expectedStreamVersion "github.com/reoden/go-NFT/pkg/es/models/stream_version"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/otel/tracing/utils"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+
+	"emperror.dev/errors"
+	"github.com/ahmetb/go-linq/v3"
+	attribute2 "go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EventsNotificationChannel the postgres channel that is notified with the global position of the last appended event
+const EventsNotificationChannel = "es_events"
+
+// appendsAdvisoryLockKey the key of the postgres advisory lock of the appends
+const appendsAdvisoryLockKey int64 = 0x65735f617070656e
+
+// PostgresEventStore the event store on the postgres `events` table, in addition to the streams it reads the events of all
+// streams in the order of their global positions.
+type PostgresEventStore interface {
+	store.EventStore
+	store.AllEventsReader
+}
+
+type postgresEventStore struct {
+	log                logger.Logger
+	dbContext          *PostgresEventStoreDBContext
+	eventSerializer    serializer.EventSerializer
+	metadataSerializer serializer.MetadataSerializer
+	tracer             trace.Tracer
+}
+
+// NewPostgresEventStore creates an event store on the postgres database, the appends use the transaction of the context
+// when it exists.
+func NewPostgresEventStore(
+	log logger.Logger,
+	dbContext *PostgresEventStoreDBContext,
+	eventSerializer serializer.EventSerializer,
+	metadataSerializer serializer.MetadataSerializer,
+	tracer trace.Tracer,
+) PostgresEventStore {
+	return &postgresEventStore{
+		log:                log,
+		dbContext:          dbContext,
+		eventSerializer:    eventSerializer,
+		metadataSerializer: metadataSerializer,
+		tracer:             tracer,
+	}
+}
+
+func (p *postgresEventStore) StreamExists(
+	streamName streamName.StreamName,
+	ctx context.Context,
+) (bool, error) {
+	ctx, span := p.tracer.Start(ctx, "postgresEventStore.StreamExists")
+	span.SetAttributes(attribute2.String("StreamName", streamName.String()))
+	defer span.End()
+
+	var count int64
+	err := p.dbContext.WithTxIfExists(ctx).DB().
+		WithContext(ctx).
+		Model(&StoredStream{}).
+		Where("stream_id = ?", streamName.String()).
+		Count(&count).Error
+	if err != nil {
+		return false, utils.TraceErrStatusFromSpan(
+			span,
+			errors.WrapIf(err, "[postgresEventStore_StreamExists:Count] error in checking stream"),
+		)
+	}
+
+	return count > 0, nil
+}
+
+func (p *postgresEventStore) AppendEvents(
+	streamName streamName.StreamName,
+	expectedVersion expectedStreamVersion.ExpectedStreamVersion,
+	events []*models.StreamEvent,
+	ctx context.Context,
+) (*appendResult.AppendEventsResult, error) {
+	ctx, span := p.tracer.Start(ctx, "postgresEventStore.AppendEvents")
+	span.SetAttributes(attribute2.String("StreamName", streamName.String()))
+	defer span.End()
+
+	if len(events) == 0 {
+		return appendResult.NoOp, nil
+	}
+
+	// the events are serialized before locking the stream, the serializer reads the keys of the personal data
+	now := time.Now()
+	storedEvents := make([]*StoredEvent, 0, len(events))
+	for _, streamEvent := range events {
+		storedEvent, err := p.toStoredEvent(streamName, streamEvent, now)
+		if err != nil {
+			return nil, utils.TraceErrStatusFromSpan(
+				span,
+				errors.WrapIff(
+					err,
+					"[postgresEventStore_AppendEvents] error in appending to stream %s",
+					streamName.String(),
+				),
+			)
+		}
+		storedEvents = append(storedEvents, storedEvent)
+	}
+
+	var result *appendResult.AppendEventsResult
+
+	err := p.dbContext.WithTxIfExists(ctx).DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := lockAppends(tx)
+		if err != nil {
+			return err
+		}
+
+		stream, err := lockStream(tx, streamName)
+		if err != nil {
+			return err
+		}
+
+		err = checkExpectedVersion(streamName, stream, expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		currentVersion := expectedStreamVersion.NoStream.Value()
+		if stream != nil {
+			currentVersion = stream.Version
+		}
+
+		for i, storedEvent := range storedEvents {
+			storedEvent.Version = currentVersion + int64(i) + 1
+		}
+		newVersion := currentVersion + int64(len(events))
+
+		if stream == nil {
+			// a concurrent append creates the stream in the meantime
+			err = tx.Create(&StoredStream{StreamId: streamName.String(), Version: newVersion}).Error
+			if err != nil {
+				return newWrongExpectedVersionError(err, streamName, expectedVersion)
+			}
+		} else {
+			res := tx.Model(&StoredStream{}).
+				Where("stream_id = ? AND version = ?", streamName.String(), currentVersion).
+				Updates(map[string]interface{}{"version": newVersion, "updated_at": now})
+			if res.Error != nil {
+				return errors.WrapIf(res.Error, "error in updating the stream version")
+			}
+			if res.RowsAffected == 0 {
+				return newWrongExpectedVersionError(nil, streamName, expectedVersion)
+			}
+		}
+
+		// the unique index of the stream versions rejects the concurrent appends with the same versions
+		err = tx.Create(&storedEvents).Error
+		if err != nil {
+			return newWrongExpectedVersionError(err, streamName, expectedVersion)
+		}
+
+		lastPosition := storedEvents[len(storedEvents)-1].GlobalPosition
+		err = notifyEvents(tx, lastPosition)
+		if err != nil {
+			return err
+		}
+
+		result = appendResult.From(uint64(lastPosition), uint64(newVersion))
+
+		return nil
+	})
+	if err != nil {
+		return nil, utils.TraceErrStatusFromSpan(
+			span,
+			errors.WrapIff(
+				err,
+				"[postgresEventStore_AppendEvents] error in appending to stream %s",
+				streamName.String(),
+			),
+		)
+	}
+
+	p.log.Infow(
+		"events append to stream successfully",
+		logger.Fields{
+			"AppendEventsResult": result,
+			"StreamId":           streamName.String(),
+		},
+	)
+
+	return result, nil
+}
+
+func (p *postgresEventStore) AppendNewEvents(
+	streamName streamName.StreamName,
+	events []*models.StreamEvent,
+	ctx context.Context,
+) (*appendResult.AppendEventsResult, error) {
+	return p.AppendEvents(streamName, expectedStreamVersion.NoStream, events, ctx)
+}
+
+func (p *postgresEventStore) ReadEvents(
+	streamName streamName.StreamName,
+	readPosition readPosition.StreamReadPosition,
+	count uint64,
+	ctx context.Context,
+) ([]*models.StreamEvent, error) {
+	ctx, span := p.tracer.Start(ctx, "postgresEventStore.ReadEvents")
+	span.SetAttributes(attribute2.String("StreamName", streamName.String()))
+	defer span.End()
+
+	events, err := p.readStreamEvents(ctx, streamName, readPosition, count, false)
+	if err != nil {
+		return nil, utils.TraceErrStatusFromSpan(span, err)
+	}
+
+	return events, nil
+}
+
+func (p *postgresEventStore) ReadEventsWithMaxCount(
+	streamName streamName.StreamName,
+	readPosition readPosition.StreamReadPosition,
+	ctx context.Context,
+) ([]*models.StreamEvent, error) {
+	return p.ReadEvents(streamName, readPosition, uint64(math.MaxUint64), ctx)
+}
+
+func (p *postgresEventStore) ReadEventsFromStart(
+	streamName streamName.StreamName,
+	count uint64,
+	ctx context.Context,
+) ([]*models.StreamEvent, error) {
+	return p.ReadEvents(streamName, readPosition.Start, count, ctx)
+}
+
+func (p *postgresEventStore) ReadEventsBackwards(
+	streamName streamName.StreamName,
+	readPosition readPosition.StreamReadPosition,
+	count uint64,
+	ctx context.Context,
+) ([]*models.StreamEvent, error) {
+	ctx, span := p.tracer.Start(ctx, "postgresEventStore.ReadEventsBackwards")
+	span.SetAttributes(attribute2.String("StreamName", streamName.String()))
+	defer span.End()
+
+	events, err := p.readStreamEvents(ctx, streamName, readPosition, count, true)
+	if err != nil {
+		return nil, utils.TraceErrStatusFromSpan(span, err)
+	}
+
+	return events, nil
+}
+
+func (p *postgresEventStore) ReadEventsBackwardsWithMaxCount(
+	streamName streamName.StreamName,
+	readPosition readPosition.StreamReadPosition,
+	ctx context.Context,
+) ([]*models.StreamEvent, error) {
+	return p.ReadEventsBackwards(streamName, readPosition, uint64(math.MaxUint64), ctx)
+}
+
+func (p *postgresEventStore) ReadEventsBackwardsFromEnd(
+	streamName streamName.StreamName,
+	count uint64,
+	ctx context.Context,
+) ([]*models.StreamEvent, error) {
+	return p.ReadEventsBackwards(streamName, readPosition.End, count, ctx)
+}
+
+func (p *postgresEventStore) ReadAllEvents(
+	ctx context.Context,
+	fromGlobalPosition uint64,
+	count int,
+	prefixes []string,
+) ([]*models.StreamEvent, error) {
+	ctx, span := p.tracer.Start(ctx, "postgresEventStore.ReadAllEvents")
+	span.SetAttributes(attribute2.Int64("FromGlobalPosition", int64(fromGlobalPosition)))
+	defer span.End()
+
+	query := p.dbContext.WithTxIfExists(ctx).DB().
+		WithContext(ctx).
+		Where("global_position > ?", fromGlobalPosition)
+
+	if len(prefixes) > 0 {
+		prefixQuery := p.dbContext.DB().Where("stream_id LIKE ?", prefixes[0]+"%")
+		for _, prefix := range prefixes[1:] {
+			prefixQuery = prefixQuery.Or("stream_id LIKE ?", prefix+"%")
+		}
+		query = query.Where(prefixQuery)
+	}
+
+	var storedEvents []*StoredEvent
+	err := query.Order("global_position").Limit(count).Find(&storedEvents).Error
+	if err != nil {
+		return nil, utils.TraceErrStatusFromSpan(
+			span,
+			errors.WrapIf(err, "[postgresEventStore_ReadAllEvents:Find] error in reading events"),
+		)
+	}
+
+	events, err := p.toStreamEvents(storedEvents)
+	if err != nil {
+		return nil, utils.TraceErrStatusFromSpan(span, err)
+	}
+
+	return events, nil
+}
+
+func (p *postgresEventStore) ReadHeadPosition(ctx context.Context) (uint64, error) {
+	ctx, span := p.tracer.Start(ctx, "postgresEventStore.ReadHeadPosition")
+	defer span.End()
+
+	var headPosition uint64
+	err := p.dbContext.WithTxIfExists(ctx).DB().
+		WithContext(ctx).
+		Model(&StoredEvent{}).
+		Select("COALESCE(MAX(global_position), 0)").
+		Scan(&headPosition).
+		Error
+	if err != nil {
+		return 0, utils.TraceErrStatusFromSpan(
+			span,
+			errors.WrapIf(err, "[postgresEventStore_ReadHeadPosition:Scan] error in reading the head position"),
+		)
+	}
+
+	return headPosition, nil
+}
+
+func (p *postgresEventStore) TruncateStream(
+	streamName streamName.StreamName,
+	truncatePosition truncatePosition.StreamTruncatePosition,
+	expectedVersion expectedStreamVersion.ExpectedStreamVersion,
+	ctx context.Context,
+) (*appendResult.AppendEventsResult, error) {
+	ctx, span := p.tracer.Start(ctx, "postgresEventStore.TruncateStream")
+	span.SetAttributes(attribute2.String("StreamName", streamName.String()))
+	defer span.End()
+
+	var result *appendResult.AppendEventsResult
+
+	err := p.dbContext.WithTxIfExists(ctx).DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		stream, err := p.lockExistingStream(tx, streamName, expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		// the stream keeps its version, so the next appends continue after the truncated events
+		err = tx.Where("stream_id = ? AND version < ?", streamName.String(), truncatePosition.Value()).
+			Delete(&StoredEvent{}).Error
+		if err != nil {
+			return errors.WrapIf(err, "error in deleting the truncated events")
+		}
+
+		result = appendResult.From(0, uint64(stream.Version))
+
+		return nil
+	})
+	if err != nil {
+		return nil, utils.TraceErrStatusFromSpan(
+			span,
+			errors.WrapIff(
+				err,
+				"[postgresEventStore_TruncateStream] error in truncating stream %s",
+				streamName.String(),
+			),
+		)
+	}
+
+	p.log.Infow(
+		fmt.Sprintf(
+			"stream with id %s truncated successfully",
+			streamName.String(),
+		),
+		logger.Fields{
+			"TruncatePosition": truncatePosition.Value(),
+			"StreamId":         streamName.String(),
+		},
+	)
+
+	return result, nil
+}
+
+func (p *postgresEventStore) DeleteStream(
+	streamName streamName.StreamName,
+	expectedVersion expectedStreamVersion.ExpectedStreamVersion,
+	ctx context.Context,
+) error {
+	ctx, span := p.tracer.Start(ctx, "postgresEventStore.DeleteStream")
+	span.SetAttributes(attribute2.String("StreamName", streamName.String()))
+	defer span.End()
+
+	err := p.dbContext.WithTxIfExists(ctx).DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_, err := p.lockExistingStream(tx, streamName, expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Where("stream_id = ?", streamName.String()).Delete(&StoredEvent{}).Error
+		if err != nil {
+			return errors.WrapIf(err, "error in deleting the stream events")
+		}
+
+		// a snapshot of the deleted stream would restore its aggregate without any events
+		err = tx.Where("stream_id = ?", streamName.String()).Delete(&models.Snapshot{}).Error
+		if err != nil {
+			return errors.WrapIf(err, "error in deleting the stream snapshot")
+		}
+
+		err = tx.Where("stream_id = ?", streamName.String()).Delete(&StoredStream{}).Error
+		if err != nil {
+			return errors.WrapIf(err, "error in deleting the stream")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return utils.TraceErrStatusFromSpan(
+			span,
+			errors.WrapIff(
+				err,
+				"[postgresEventStore_DeleteStream] error in deleting stream %s",
+				streamName.String(),
+			),
+		)
+	}
+
+	p.log.Infow(
+		fmt.Sprintf(
+			"stream with id %s deleted successfully",
+			streamName.String(),
+		),
+		logger.Fields{"StreamId": streamName.String()},
+	)
+
+	return nil
+}
+
+func (p *postgresEventStore) readStreamEvents(
+	ctx context.Context,
+	streamName streamName.StreamName,
+	position readPosition.StreamReadPosition,
+	count uint64,
+	backwards bool,
+) ([]*models.StreamEvent, error) {
+	exists, err := p.StreamExists(streamName, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, newStreamNotFoundError(streamName)
+	}
+
+	query := p.dbContext.WithTxIfExists(ctx).DB().
+		WithContext(ctx).
+		Where("stream_id = ?", streamName.String())
+
+	if backwards {
+		if !position.IsEnd() {
+			query = query.Where("version <= ?", position.Value())
+		}
+		query = query.Order("version desc")
+	} else {
+		query = query.Where("version >= ?", position.Value()).Order("version")
+	}
+
+	if count < math.MaxInt32 {
+		query = query.Limit(int(count))
+	}
+
+	var storedEvents []*StoredEvent
+	err = query.Find(&storedEvents).Error
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in reading the stream events")
+	}
+
+	return p.toStreamEvents(storedEvents)
+}
+
+// lockAppends serializes the appends of all streams until the commit of the transaction, so the global positions are
+// committed in their order and the subscriptions reading after their checkpoint never skip an event of a slower
+// transaction. It is taken before the stream locks, so the transactions appending to several streams don't deadlock.
+func lockAppends(tx *gorm.DB) error {
+	// sqlite serializes the write transactions itself
+	if tx.Dialector.Name() != "postgres" {
+		return nil
+	}
+
+	err := tx.Exec("SELECT pg_advisory_xact_lock(?)", appendsAdvisoryLockKey).Error
+	if err != nil {
+		return errors.WrapIf(err, "error in locking the appends")
+	}
+
+	return nil
+}
+
+func lockStream(tx *gorm.DB, streamName streamName.StreamName) (*StoredStream, error) {
+	var streams []*StoredStream
+
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("stream_id = ?", streamName.String()).
+		Limit(1).
+		Find(&streams).Error
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in loading the stream")
+	}
+	if len(streams) == 0 {
+		return nil, nil
+	}
+
+	return streams[0], nil
+}
+
+func (p *postgresEventStore) lockExistingStream(
+	tx *gorm.DB,
+	streamName streamName.StreamName,
+	expectedVersion expectedStreamVersion.ExpectedStreamVersion,
+) (*StoredStream, error) {
+	stream, err := lockStream(tx, streamName)
+	if err != nil {
+		return nil, err
+	}
+	if stream == nil {
+		return nil, newStreamNotFoundError(streamName)
+	}
+
+	err = checkExpectedVersion(streamName, stream, expectedVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return stream, nil
+}
+
+func (p *postgresEventStore) toStoredEvent(
+	streamName streamName.StreamName,
+	streamEvent *models.StreamEvent,
+	createdAt time.Time,
+) (*StoredEvent, error) {
+	serializedEvent, err := p.eventSerializer.Serialize(streamEvent.Event)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in serializing the event")
+	}
+
+	serializedMetadata, err := p.metadataSerializer.Serialize(streamEvent.Metadata)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in serializing the event metadata")
+	}
+
+	return &StoredEvent{
+		EventId:     streamEvent.EventID,
+		StreamId:    streamName.String(),
+		EventType:   typeMapper.GetTypeName(streamEvent.Event),
+		ContentType: serializedEvent.ContentType,
+		Data:        serializedEvent.Data,
+		Metadata:    serializedMetadata,
+		CreatedAt:   createdAt,
+	}, nil
+}
+
+func (p *postgresEventStore) toStreamEvents(storedEvents []*StoredEvent) ([]*models.StreamEvent, error) {
+	var streamEvents []*models.StreamEvent
+	var err error
+
+	linq.From(storedEvents).
+		SelectT(func(storedEvent *StoredEvent) *models.StreamEvent {
+			if err != nil {
+				return nil
+			}
+
+			var streamEvent *models.StreamEvent
+			streamEvent, err = p.toStreamEvent(storedEvent)
+
+			return streamEvent
+		}).
+		ToSlice(&streamEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	return streamEvents, nil
+}
+
+func (p *postgresEventStore) toStreamEvent(storedEvent *StoredEvent) (*models.StreamEvent, error) {
+	return toStreamEvent(p.eventSerializer, p.metadataSerializer, storedEvent)
+}
+
+func toStreamEvent(
+	eventSerializer serializer.EventSerializer,
+	metadataSerializer serializer.MetadataSerializer,
+	storedEvent *StoredEvent,
+) (*models.StreamEvent, error) {
+	event, err := eventSerializer.Deserialize(
+		storedEvent.Data,
+		storedEvent.EventType,
+		storedEvent.ContentType,
+	)
+	if err != nil {
+		return nil, errors.WrapIff(err, "error in deserializing the event %s", storedEvent.EventId.String())
+	}
+
+	meta, err := metadataSerializer.Deserialize(storedEvent.Metadata)
+	if err != nil {
+		return nil, errors.WrapIff(err, "error in deserializing the metadata of event %s", storedEvent.EventId.String())
+	}
+
+	return &models.StreamEvent{
+		EventID:  storedEvent.EventId,
+		Version:  storedEvent.Version,
+		Position: storedEvent.GlobalPosition,
+		Event:    event,
+		Metadata: meta,
+	}, nil
+}
+
+func checkExpectedVersion(
+	streamName streamName.StreamName,
+	stream *StoredStream,
+	expectedVersion expectedStreamVersion.ExpectedStreamVersion,
+) error {
+	switch {
+	case expectedVersion.IsAny():
+		return nil
+	case expectedVersion.IsNoStream():
+		if stream == nil {
+			return nil
+		}
+	case expectedVersion.IsStreamExists():
+		if stream != nil {
+			return nil
+		}
+	default:
+		if stream != nil && stream.Version == expectedVersion.Value() {
+			return nil
+		}
+	}
+
+	return newWrongExpectedVersionError(nil, streamName, expectedVersion)
+}
+
+func notifyEvents(tx *gorm.DB, globalPosition int64) error {
+	// the notifications are only supported by postgres, the other databases rely on polling
+	if tx.Dialector.Name() != "postgres" {
+		return nil
+	}
+
+	// the notification is delivered to the listeners after the commit of transaction
+	err := tx.Exec(
+		"SELECT pg_notify(?, ?)",
+		EventsNotificationChannel,
+		strconv.FormatInt(globalPosition, 10),
+	).Error
+	if err != nil {
+		return errors.WrapIf(err, "error in notifying the appended events")
+	}
+
+	return nil
+}
+
+func newWrongExpectedVersionError(
+	err error,
+	streamName streamName.StreamName,
+	expectedVersion expectedStreamVersion.ExpectedStreamVersion,
+) error {
+	if err != nil {
+		err = errors.WithMessage(esErrors.ErrWrongExpectedVersion, err.Error())
+	} else {
+		err = esErrors.ErrWrongExpectedVersion
+	}
+
+	return customErrors.NewConflictErrorWrap(
+		err,
+		fmt.Sprintf(
+			"stream %s is not at the expected version %d",
+			streamName.String(),
+			expectedVersion.Value(),
+		),
+	)
+}
+
+func newStreamNotFoundError(streamName streamName.StreamName) error {
+	return customErrors.NewNotFoundErrorWrap(
+		esErrors.ErrStreamNotFound,
+		fmt.Sprintf("stream %s not found", streamName.String()),
+	)
+}
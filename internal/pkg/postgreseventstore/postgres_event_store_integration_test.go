@@ -0,0 +1,179 @@
+//go:build integration
+// +build integration
+
+package postgreseventstore
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/config"
+	"github.com/reoden/go-NFT/pkg/config/environment"
+	"github.com/reoden/go-NFT/pkg/core"
+	"github.com/reoden/go-NFT/pkg/core/domain"
+	"github.com/reoden/go-NFT/pkg/core/metadata"
+	"github.com/reoden/go-NFT/pkg/es"
+	"github.com/reoden/go-NFT/pkg/es/contracts"
+	"github.com/reoden/go-NFT/pkg/es/contracts/projection"
+	"github.com/reoden/go-NFT/pkg/es/models"
+	streamName "github.com/reoden/go-NFT/pkg/es/models/stream_name"
+	expectedStreamVersion "github.com/reoden/go-NFT/pkg/es/models/stream_version"
+	"github.com/reoden/go-NFT/pkg/logger"
+	defaultLogger "github.com/reoden/go-NFT/pkg/logger/defaultlogger"
+	"github.com/reoden/go-NFT/pkg/logger/external/fxlog"
+	"github.com/reoden/go-NFT/pkg/logger/zap"
+	"github.com/reoden/go-NFT/pkg/postgresgorm"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+	"github.com/reoden/go-NFT/pkg/test/containers/testcontainer/postgrespxg"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+type itemAdded struct {
+	*domain.DomainEvent
+	Item int
+}
+
+// positionsProjection records the global positions of the published events
+type positionsProjection struct {
+	mu        sync.Mutex
+	positions map[int64]int
+}
+
+func (p *positionsProjection) ProcessEvent(ctx context.Context, streamEvent *models.StreamEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.positions[streamEvent.Position]++
+
+	return nil
+}
+
+func (p *positionsProjection) count() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.positions)
+}
+
+func Test_SubscribeAll_Receives_Every_Event_Of_Concurrent_Appends_To_Different_Streams(t *testing.T) {
+	ctx := context.Background()
+
+	containerOptions, err := postgrespxg.NewPostgresPgxContainers(defaultLogger.GetLogger()).
+		PopulateContainerOptions(ctx, t)
+	require.NoError(t, err)
+
+	var (
+		eventStore           PostgresEventStore
+		dbContext            *PostgresEventStoreDBContext
+		checkpointRepository contracts.SubscriptionCheckpointRepository
+		options              *PostgresEventStoreOptions
+		log                  logger.Logger
+	)
+
+	app := fxtest.New(
+		t,
+		config.ModuleFunc(environment.Test),
+		zap.Module,
+		fxlog.FxLogger,
+		core.Module,
+		postgresgorm.Module,
+		fx.Decorate(
+			func(cfg *postgresgorm.GormOptions) (*postgresgorm.GormOptions, error) {
+				cfg.UseSQLLite = false
+				cfg.UseInMemory = false
+				cfg.Host = containerOptions.Host
+				cfg.Port = containerOptions.Port
+				cfg.User = containerOptions.User
+				cfg.Password = containerOptions.Password
+				cfg.DBName = containerOptions.DBName
+
+				return cfg, nil
+			},
+		),
+		fx.Provide(func() trace.Tracer {
+			return trace.NewNoopTracerProvider().Tracer("")
+		}),
+		Module,
+		fx.Populate(&eventStore),
+		fx.Populate(&dbContext),
+		fx.Populate(&checkpointRepository),
+		fx.Populate(&options),
+		fx.Populate(&log),
+	).RequireStart()
+	defer app.RequireStop()
+
+	positions := &positionsProjection{positions: map[int64]int{}}
+	worker := NewPostgresSubscriptionAllWorker(
+		log,
+		eventStore,
+		dbContext,
+		options,
+		checkpointRepository,
+		es.NewProjectionCheckpoints(checkpointRepository),
+		[]projection.IProjection{positions},
+	)
+
+	subscriptionCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	done := make(chan error)
+	go func() {
+		done <- worker.SubscribeAll(subscriptionCtx, &PostgresSubscriptionToAllOptions{
+			SubscriptionId: "concurrent-appends",
+		})
+	}()
+
+	// the appends of the two streams interleave, a transaction taking an earlier position can commit after a later one
+	const appendsPerStream = 200
+	var appends sync.WaitGroup
+	for _, stream := range []streamName.StreamName{"item-1", "item-2"} {
+		for writer := 0; writer < 4; writer++ {
+			appends.Add(1)
+			go func(stream streamName.StreamName, writer int) {
+				defer appends.Done()
+				for i := 0; i < appendsPerStream/4; i++ {
+					_, err := eventStore.AppendEvents(
+						streamName.StreamName(fmt.Sprintf("%s-%d", stream, writer)),
+						expectedStreamVersion.Any,
+						[]*models.StreamEvent{newItemAddedEvent(i)},
+						ctx,
+					)
+					assert.NoError(t, err)
+				}
+			}(stream, writer)
+		}
+	}
+	appends.Wait()
+
+	total := 2 * appendsPerStream
+	require.Eventually(t, func() bool {
+		return positions.count() == total
+	}, 30*time.Second, 50*time.Millisecond, "the subscription skipped events")
+
+	cancel()
+	<-done
+
+	for position := int64(1); position <= int64(total); position++ {
+		assert.Equal(t, 1, positions.positions[position], "position %d", position)
+	}
+}
+
+func newItemAddedEvent(item int) *models.StreamEvent {
+	return &models.StreamEvent{
+		EventID: uuid.NewV4(),
+		Event: &itemAdded{
+			DomainEvent: domain.NewDomainEvent(typeMapper.GetTypeName(&itemAdded{})),
+			Item:        item,
+		},
+		Metadata: metadata.Metadata{},
+	}
+}
@@ -0,0 +1,805 @@
+//go:build unit
+// +build unit
+
+package postgreseventstore
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/config"
+	"github.com/reoden/go-NFT/pkg/config/environment"
+	"github.com/reoden/go-NFT/pkg/core"
+	"github.com/reoden/go-NFT/pkg/core/domain"
+	"github.com/reoden/go-NFT/pkg/core/metadata"
+	"github.com/reoden/go-NFT/pkg/core/serializer"
+	"github.com/reoden/go-NFT/pkg/core/serializer/personaldata"
+	"github.com/reoden/go-NFT/pkg/es"
+	"github.com/reoden/go-NFT/pkg/es/contracts"
+	"github.com/reoden/go-NFT/pkg/es/contracts/projection"
+	"github.com/reoden/go-NFT/pkg/es/contracts/store"
+	esErrors "github.com/reoden/go-NFT/pkg/es/errors"
+	"github.com/reoden/go-NFT/pkg/es/models"
+	streamName "github.com/reoden/go-NFT/pkg/es/models/stream_name"
+	readPosition "github.com/reoden/go-NFT/pkg/es/models/stream_position/read_position"
+	"github.com/reoden/go-NFT/pkg/es/models/stream_position/truncatePosition"
+	expectedStreamVersion "github.com/reoden/go-NFT/pkg/es/models/stream_version"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/logger/external/fxlog"
+	"github.com/reoden/go-NFT/pkg/logger/zap"
+	"github.com/reoden/go-NFT/pkg/postgresgorm"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+	"github.com/reoden/go-NFT/pkg/storage"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+	"gorm.io/gorm"
+)
+
+type accountDeposited struct {
+	*domain.DomainEvent
+	Amount int
+}
+
+type accountHolderChanged struct {
+	*domain.DomainEvent
+	Holder string `json:"holder" personalData:"true"`
+}
+
+type testAccount struct {
+	*models.EventSourcedAggregateRoot
+	Balance int
+}
+
+func newTestAccount(id uuid.UUID) *testAccount {
+	account := &testAccount{}
+	account.EventSourcedAggregateRoot = models.NewEventSourcedAggregateRootWithId(
+		id,
+		typeMapper.GetTypeName(account),
+		account.When,
+	)
+
+	return account
+}
+
+func (a *testAccount) NewEmptyAggregate() {
+	a.EventSourcedAggregateRoot = models.NewEventSourcedAggregateRoot(typeMapper.GetTypeName(a), a.When)
+}
+
+func (a *testAccount) When(event domain.IDomainEvent) error {
+	// the loaded aggregates get their id from the events
+	if uuid.Equal(a.Id(), uuid.Nil) {
+		a.SetId(event.GetAggregateId())
+	}
+
+	if e, ok := event.(*accountDeposited); ok {
+		a.Balance += e.Amount
+	}
+
+	return nil
+}
+
+func (a *testAccount) Deposit(amount int) error {
+	return a.Apply(newAccountDeposited(amount), true)
+}
+
+func newAccountDeposited(amount int) *accountDeposited {
+	return &accountDeposited{
+		DomainEvent: domain.NewDomainEvent(typeMapper.GetTypeName(&accountDeposited{})),
+		Amount:      amount,
+	}
+}
+
+func depositedEvents(amounts ...int) []*models.StreamEvent {
+	var streamEvents []*models.StreamEvent
+	for _, amount := range amounts {
+		streamEvents = append(streamEvents, &models.StreamEvent{
+			EventID:  uuid.NewV4(),
+			Event:    newAccountDeposited(amount),
+			Metadata: metadata.Metadata{},
+		})
+	}
+
+	return streamEvents
+}
+
+// accountSnapshot the snapshot state of the snapshotAccount, the holder is forgotten with the account
+type accountSnapshot struct {
+	AccountId string `json:"accountId" personalData:"subject"`
+	Holder    string `json:"holder"    personalData:"true"`
+	Balance   int    `json:"balance"`
+}
+
+type snapshotAccount struct {
+	*testAccount
+	Holder string
+}
+
+func (a *snapshotAccount) SnapshotSchemaVersion() int {
+	return 1
+}
+
+func (a *snapshotAccount) CreateSnapshot() interface{} {
+	return &accountSnapshot{AccountId: a.Id().String(), Holder: a.Holder, Balance: a.Balance}
+}
+
+func (a *snapshotAccount) RestoreSnapshot(snapshot interface{}) error {
+	state := snapshot.(*accountSnapshot)
+	a.Holder = state.Holder
+	a.Balance = state.Balance
+
+	return nil
+}
+
+// balanceProjection sums the deposited amounts of all accounts
+type balanceProjection struct {
+	total int
+}
+
+func (b *balanceProjection) ProcessEvent(ctx context.Context, streamEvent *models.StreamEvent) error {
+	if e, ok := streamEvent.Event.(*accountDeposited); ok {
+		b.total += e.Amount
+	}
+
+	return nil
+}
+
+// rebuildableBalanceProjection sums the deposited amounts of all accounts into a live and a shadow total
+type rebuildableBalanceProjection struct {
+	balanceProjection
+	shadow *balanceProjection
+}
+
+func (b *rebuildableBalanceProjection) ProjectionName() string {
+	return "balance"
+}
+
+func (b *rebuildableBalanceProjection) PrepareRebuild(ctx context.Context) (projection.IProjection, error) {
+	b.shadow = &balanceProjection{}
+
+	return b.shadow, nil
+}
+
+func (b *rebuildableBalanceProjection) SwapRebuild(ctx context.Context) error {
+	b.total, b.shadow = b.shadow.total, nil
+
+	return nil
+}
+
+func (b *rebuildableBalanceProjection) CleanupRebuild(ctx context.Context) error {
+	b.shadow = nil
+
+	return nil
+}
+
+type postgresEventStoreTest struct {
+	suite.Suite
+	db                   *gorm.DB
+	dbContext            *PostgresEventStoreDBContext
+	eventStore           PostgresEventStore
+	checkpointRepository contracts.SubscriptionCheckpointRepository
+	keyStore             personaldata.KeyStore
+	eventSerializer      serializer.EventSerializer
+	metadataSerializer   serializer.MetadataSerializer
+	options              *PostgresEventStoreOptions
+	log                  logger.Logger
+	ctx                  context.Context
+	dbFilePath           string
+	app                  *fxtest.App
+}
+
+func TestPostgresEventStore(t *testing.T) {
+	suite.Run(t, &postgresEventStoreTest{})
+}
+
+func (c *postgresEventStoreTest) SetupTest() {
+	var gormOptions *postgresgorm.GormOptions
+
+	app := fxtest.New(
+		c.T(),
+		config.ModuleFunc(environment.Test),
+		zap.Module,
+		fxlog.FxLogger,
+		core.Module,
+		postgresgorm.Module,
+		fx.Decorate(
+			func(cfg *postgresgorm.GormOptions) (*postgresgorm.GormOptions, error) {
+				// using sql-lite with a database file
+				cfg.UseSQLLite = true
+
+				return cfg, nil
+			},
+		),
+		fx.Provide(func() trace.Tracer {
+			return trace.NewNoopTracerProvider().Tracer("")
+		}),
+		Module,
+		fx.Populate(&c.db),
+		fx.Populate(&c.dbContext),
+		fx.Populate(&c.eventStore),
+		fx.Populate(&c.checkpointRepository),
+		fx.Populate(&c.keyStore),
+		fx.Populate(&c.eventSerializer),
+		fx.Populate(&c.metadataSerializer),
+		fx.Populate(&c.options),
+		fx.Populate(&c.log),
+		fx.Populate(&gormOptions),
+	).RequireStart()
+
+	c.dbFilePath = gormOptions.Dns()
+	c.app = app
+	c.ctx = context.Background()
+}
+
+func (c *postgresEventStoreTest) TearDownTest() {
+	sqldb, _ := c.db.DB()
+	c.Require().NoError(sqldb.Close())
+
+	// removing sql-lite file
+	c.Require().NoError(os.Remove(c.dbFilePath))
+
+	c.app.RequireStop()
+}
+
+func (c *postgresEventStoreTest) Test_AppendEvents_Assigns_Stream_Versions_And_Global_Positions() {
+	stream := streamName.StreamName("account-1")
+
+	result, err := c.eventStore.AppendEvents(stream, expectedStreamVersion.NoStream, depositedEvents(1, 2), c.ctx)
+	c.Require().NoError(err)
+	c.Equal(uint64(1), result.NextExpectedVersion)
+
+	result, err = c.eventStore.AppendEvents(stream, expectedStreamVersion.FromInt64(1), depositedEvents(3), c.ctx)
+	c.Require().NoError(err)
+	c.Equal(uint64(2), result.NextExpectedVersion)
+	c.Equal(uint64(3), result.GlobalPosition)
+
+	events, err := c.eventStore.ReadEventsFromStart(stream, 10, c.ctx)
+	c.Require().NoError(err)
+	c.Require().Len(events, 3)
+	for i, event := range events {
+		c.Equal(int64(i), event.Version)
+		c.Equal(int64(i+1), event.Position)
+		c.Equal(i+1, event.Event.(*accountDeposited).Amount)
+	}
+
+	backwards, err := c.eventStore.ReadEventsBackwardsFromEnd(stream, 2, c.ctx)
+	c.Require().NoError(err)
+	c.Require().Len(backwards, 2)
+	c.Equal(int64(2), backwards[0].Version)
+	c.Equal(int64(1), backwards[1].Version)
+}
+
+func (c *postgresEventStoreTest) Test_AppendEvents_Rejects_Wrong_Expected_Version() {
+	stream := streamName.StreamName("account-1")
+
+	_, err := c.eventStore.AppendEvents(stream, expectedStreamVersion.NoStream, depositedEvents(1), c.ctx)
+	c.Require().NoError(err)
+
+	_, err = c.eventStore.AppendEvents(stream, expectedStreamVersion.NoStream, depositedEvents(2), c.ctx)
+	c.ErrorIs(err, esErrors.ErrWrongExpectedVersion)
+
+	_, err = c.eventStore.AppendEvents(stream, expectedStreamVersion.FromInt64(5), depositedEvents(2), c.ctx)
+	c.ErrorIs(err, esErrors.ErrWrongExpectedVersion)
+
+	_, err = c.eventStore.AppendEvents(
+		streamName.StreamName("account-2"),
+		expectedStreamVersion.StreamExists,
+		depositedEvents(2),
+		c.ctx,
+	)
+	c.ErrorIs(err, esErrors.ErrWrongExpectedVersion)
+
+	_, err = c.eventStore.AppendEvents(stream, expectedStreamVersion.Any, depositedEvents(2), c.ctx)
+	c.NoError(err)
+}
+
+func (c *postgresEventStoreTest) Test_TruncateStream_Removes_Events_Before_Position_And_Keeps_Version() {
+	stream := streamName.StreamName("account-1")
+
+	_, err := c.eventStore.AppendEvents(stream, expectedStreamVersion.NoStream, depositedEvents(1, 2, 3), c.ctx)
+	c.Require().NoError(err)
+
+	_, err = c.eventStore.TruncateStream(
+		stream,
+		truncatePosition.FromInt64(2),
+		expectedStreamVersion.FromInt64(2),
+		c.ctx,
+	)
+	c.Require().NoError(err)
+
+	events, err := c.eventStore.ReadEventsWithMaxCount(stream, readPosition.Start, c.ctx)
+	c.Require().NoError(err)
+	c.Require().Len(events, 1)
+	c.Equal(int64(2), events[0].Version)
+
+	_, err = c.eventStore.AppendEvents(stream, expectedStreamVersion.FromInt64(2), depositedEvents(4), c.ctx)
+	c.NoError(err)
+}
+
+func (c *postgresEventStoreTest) Test_DeleteStream_Removes_Stream() {
+	stream := streamName.StreamName("account-1")
+
+	_, err := c.eventStore.AppendEvents(stream, expectedStreamVersion.NoStream, depositedEvents(1), c.ctx)
+	c.Require().NoError(err)
+
+	snapshotStore := NewPostgresSnapshotStore(c.db)
+	for _, streamId := range []string{stream.String(), "account-2"} {
+		c.Require().NoError(snapshotStore.Save(c.ctx, &models.Snapshot{
+			StreamId:      streamId,
+			AggregateType: "account",
+			SchemaVersion: 1,
+			ContentType:   "application/json",
+			Data:          []byte(`{}`),
+		}))
+	}
+
+	err = c.eventStore.DeleteStream(stream, expectedStreamVersion.FromInt64(3), c.ctx)
+	c.ErrorIs(err, esErrors.ErrWrongExpectedVersion)
+
+	err = c.eventStore.DeleteStream(stream, expectedStreamVersion.FromInt64(0), c.ctx)
+	c.Require().NoError(err)
+
+	exists, err := c.eventStore.StreamExists(stream, c.ctx)
+	c.Require().NoError(err)
+	c.False(exists)
+
+	_, err = c.eventStore.ReadEventsFromStart(stream, 10, c.ctx)
+	c.ErrorIs(err, esErrors.ErrStreamNotFound)
+
+	// the snapshots of the deleted stream are deleted with it
+	_, err = snapshotStore.Load(c.ctx, stream.String())
+	c.ErrorIs(err, store.ErrSnapshotNotFound)
+
+	_, err = snapshotStore.Load(c.ctx, "account-2")
+	c.Require().NoError(err)
+}
+
+func (c *postgresEventStoreTest) Test_ReadAllEvents_Filters_Stream_Prefixes() {
+	_, err := c.eventStore.AppendEvents("account-1", expectedStreamVersion.NoStream, depositedEvents(1), c.ctx)
+	c.Require().NoError(err)
+	_, err = c.eventStore.AppendEvents("order-1", expectedStreamVersion.NoStream, depositedEvents(2), c.ctx)
+	c.Require().NoError(err)
+	_, err = c.eventStore.AppendEvents("account-2", expectedStreamVersion.NoStream, depositedEvents(3), c.ctx)
+	c.Require().NoError(err)
+
+	events, err := c.eventStore.ReadAllEvents(c.ctx, 0, 10, []string{"account-"})
+	c.Require().NoError(err)
+	c.Require().Len(events, 2)
+	c.Equal(int64(1), events[0].Position)
+	c.Equal(int64(3), events[1].Position)
+
+	events, err = c.eventStore.ReadAllEvents(c.ctx, 1, 10, nil)
+	c.Require().NoError(err)
+	c.Len(events, 2)
+}
+
+func (c *postgresEventStoreTest) Test_AggregateStore_Stores_And_Loads_Aggregate() {
+	aggregateStore := NewPostgresAggregateStore[*testAccount](
+		c.log,
+		c.eventStore,
+		c.eventSerializer,
+		trace.NewNoopTracerProvider().Tracer(""),
+		nil,
+		nil,
+		&es.EventStoreConfig{},
+	)
+
+	account := newTestAccount(uuid.NewV4())
+	c.Require().NoError(account.Deposit(10))
+	c.Require().NoError(account.Deposit(5))
+
+	_, err := aggregateStore.Store(account, metadata.Metadata{}, c.ctx)
+	c.Require().NoError(err)
+
+	loaded, err := aggregateStore.Load(c.ctx, account.Id())
+	c.Require().NoError(err)
+	c.Equal(15, loaded.Balance)
+	c.Equal(int64(1), loaded.OriginalVersion())
+
+	c.Require().NoError(loaded.Deposit(5))
+	_, err = aggregateStore.Store(loaded, metadata.Metadata{}, c.ctx)
+	c.Require().NoError(err)
+
+	// the first instance is stale after the second store
+	c.Require().NoError(account.Deposit(1))
+	_, err = aggregateStore.Store(account, metadata.Metadata{}, c.ctx)
+	c.ErrorIs(err, esErrors.ErrWrongExpectedVersion)
+
+	_, err = aggregateStore.Load(c.ctx, uuid.NewV4())
+	c.ErrorIs(err, esErrors.ErrStreamNotFound)
+}
+
+func (c *postgresEventStoreTest) Test_StreamArchiver_Archives_Cold_Streams_And_AggregateStore_Rehydrates_Them() {
+	objectStore, err := storage.NewLocalObjectStore(&storage.LocalStorageOptions{
+		BasePath:   c.T().TempDir(),
+		SigningKey: "test-signing-key",
+	})
+	c.Require().NoError(err)
+
+	options := &PostgresEventStoreOptions{Archive: &Archive{Prefix: []string{"testaccount-"}, OlderThan: 24}}
+	archiver := NewPostgresStreamArchiver(
+		c.log,
+		c.dbContext,
+		c.eventSerializer,
+		c.metadataSerializer,
+		objectStore,
+		options,
+		trace.NewNoopTracerProvider().Tracer(""),
+	)
+	aggregateStore := NewPostgresAggregateStore[*testAccount](
+		c.log,
+		c.eventStore,
+		c.eventSerializer,
+		trace.NewNoopTracerProvider().Tracer(""),
+		nil,
+		archiver,
+		&es.EventStoreConfig{},
+	)
+
+	cold := newTestAccount(uuid.NewV4())
+	c.Require().NoError(cold.Deposit(10))
+	c.Require().NoError(cold.Deposit(5))
+	_, err = aggregateStore.Store(cold, metadata.Metadata{}, c.ctx)
+	c.Require().NoError(err)
+
+	hot := newTestAccount(uuid.NewV4())
+	c.Require().NoError(hot.Deposit(1))
+	_, err = aggregateStore.Store(hot, metadata.Metadata{}, c.ctx)
+	c.Require().NoError(err)
+
+	_, err = c.eventStore.AppendEvents("order-1", expectedStreamVersion.NoStream, depositedEvents(7), c.ctx)
+	c.Require().NoError(err)
+
+	coldStream := streamName.For[*testAccount](cold)
+	c.Require().NoError(
+		c.db.Model(&StoredStream{}).
+			Where("stream_id IN ?", []string{coldStream.String(), "order-1"}).
+			Update("updated_at", time.Now().Add(-48*time.Hour)).Error,
+	)
+
+	// only the cold streams of the policy prefixes are archived
+	archived, err := archiver.ArchiveStreams(c.ctx)
+	c.Require().NoError(err)
+	c.Equal(1, archived)
+
+	events, err := c.eventStore.ReadEventsWithMaxCount(coldStream, readPosition.Start, c.ctx)
+	c.Require().NoError(err)
+	c.Empty(events)
+
+	var archive StoredStreamArchive
+	c.Require().NoError(c.db.Where("stream_id = ?", coldStream.String()).First(&archive).Error)
+	c.Equal(int64(1), archive.LastVersion)
+	c.Equal(2, archive.EventCount)
+
+	exists, err := storage.Exists(c.ctx, objectStore, archive.ArchiveKey)
+	c.Require().NoError(err)
+	c.True(exists)
+
+	// an archived stream is not archived again
+	archived, err = archiver.ArchiveStreams(c.ctx)
+	c.Require().NoError(err)
+	c.Equal(0, archived)
+
+	loaded, err := aggregateStore.Load(c.ctx, cold.Id())
+	c.Require().NoError(err)
+	c.Equal(15, loaded.Balance)
+	c.Equal(int64(1), loaded.OriginalVersion())
+
+	// the rehydrated events keep their versions and global positions
+	events, err = c.eventStore.ReadEventsWithMaxCount(coldStream, readPosition.Start, c.ctx)
+	c.Require().NoError(err)
+	c.Require().Len(events, 2)
+	c.Equal(int64(1), events[0].Position)
+	c.Equal(int64(2), events[1].Position)
+
+	exists, err = storage.Exists(c.ctx, objectStore, archive.ArchiveKey)
+	c.Require().NoError(err)
+	c.False(exists)
+
+	rehydrated, err := archiver.Rehydrate(c.ctx, coldStream)
+	c.Require().NoError(err)
+	c.False(rehydrated)
+
+	c.Require().NoError(loaded.Deposit(5))
+	_, err = aggregateStore.Store(loaded, metadata.Metadata{}, c.ctx)
+	c.Require().NoError(err)
+}
+
+func (c *postgresEventStoreTest) Test_StreamArchiver_Keeps_Stream_Version_For_Appends_After_Archival() {
+	objectStore, err := storage.NewLocalObjectStore(&storage.LocalStorageOptions{
+		BasePath:   c.T().TempDir(),
+		SigningKey: "test-signing-key",
+	})
+	c.Require().NoError(err)
+
+	archiver := NewPostgresStreamArchiver(
+		c.log,
+		c.dbContext,
+		c.eventSerializer,
+		c.metadataSerializer,
+		objectStore,
+		&PostgresEventStoreOptions{},
+		trace.NewNoopTracerProvider().Tracer(""),
+	)
+	aggregateStore := NewPostgresAggregateStore[*testAccount](
+		c.log,
+		c.eventStore,
+		c.eventSerializer,
+		trace.NewNoopTracerProvider().Tracer(""),
+		nil,
+		archiver,
+		&es.EventStoreConfig{},
+	)
+
+	account := newTestAccount(uuid.NewV4())
+	c.Require().NoError(account.Deposit(10))
+	_, err = aggregateStore.Store(account, metadata.Metadata{}, c.ctx)
+	c.Require().NoError(err)
+
+	stream := streamName.For[*testAccount](account)
+	archived, err := archiver.ArchiveStream(c.ctx, stream)
+	c.Require().NoError(err)
+	c.True(archived)
+
+	// the tombstone keeps the stream version, so the appends continue after the archived events
+	_, err = c.eventStore.AppendEvents(stream, expectedStreamVersion.NoStream, depositedEvents(1), c.ctx)
+	c.ErrorIs(err, esErrors.ErrWrongExpectedVersion)
+	_, err = c.eventStore.AppendEvents(stream, expectedStreamVersion.FromInt64(0), depositedEvents(3), c.ctx)
+	c.Require().NoError(err)
+
+	loaded, err := aggregateStore.Load(c.ctx, account.Id())
+	c.Require().NoError(err)
+	c.Equal(13, loaded.Balance)
+	c.Equal(int64(1), loaded.OriginalVersion())
+}
+
+// uploadHookObjectStore runs the hook after each put, like an append of a stream during the upload of its archive
+type uploadHookObjectStore struct {
+	storage.ObjectStore
+	afterPut func(key string)
+}
+
+func (u *uploadHookObjectStore) Put(
+	ctx context.Context,
+	key string,
+	reader io.Reader,
+	size int64,
+	contentType string,
+) (*storage.ObjectInfo, error) {
+	info, err := u.ObjectStore.Put(ctx, key, reader, size, contentType)
+	if err == nil {
+		u.afterPut(key)
+	}
+
+	return info, err
+}
+
+func (c *postgresEventStoreTest) Test_StreamArchiver_Skips_Streams_Appended_During_The_Upload() {
+	localStore, err := storage.NewLocalObjectStore(&storage.LocalStorageOptions{
+		BasePath:   c.T().TempDir(),
+		SigningKey: "test-signing-key",
+	})
+	c.Require().NoError(err)
+
+	var uploadedKey string
+	objectStore := &uploadHookObjectStore{
+		ObjectStore: localStore,
+		afterPut: func(key string) {
+			uploadedKey = key
+			_, err := c.eventStore.AppendEvents(
+				"account-1",
+				expectedStreamVersion.FromInt64(0),
+				depositedEvents(3),
+				c.ctx,
+			)
+			c.Require().NoError(err)
+		},
+	}
+
+	archiver := NewPostgresStreamArchiver(
+		c.log,
+		c.dbContext,
+		c.eventSerializer,
+		c.metadataSerializer,
+		objectStore,
+		&PostgresEventStoreOptions{},
+		trace.NewNoopTracerProvider().Tracer(""),
+	)
+
+	_, err = c.eventStore.AppendEvents("account-1", expectedStreamVersion.NoStream, depositedEvents(10), c.ctx)
+	c.Require().NoError(err)
+
+	archived, err := archiver.ArchiveStream(c.ctx, "account-1")
+	c.Require().NoError(err)
+	c.False(archived)
+
+	// the events appended during the upload are not in the archive, so the stream keeps its events
+	events, err := c.eventStore.ReadEventsWithMaxCount("account-1", readPosition.Start, c.ctx)
+	c.Require().NoError(err)
+	c.Len(events, 2)
+
+	var count int64
+	c.Require().NoError(c.db.Model(&StoredStreamArchive{}).Count(&count).Error)
+	c.Zero(count)
+
+	c.Require().NotEmpty(uploadedKey)
+	exists, err := storage.Exists(c.ctx, localStore, uploadedKey)
+	c.Require().NoError(err)
+	c.False(exists)
+}
+
+func (c *postgresEventStoreTest) Test_ProjectionRebuilder_Replays_Archived_Streams() {
+	objectStore, err := storage.NewLocalObjectStore(&storage.LocalStorageOptions{
+		BasePath:   c.T().TempDir(),
+		SigningKey: "test-signing-key",
+	})
+	c.Require().NoError(err)
+
+	archiver := NewPostgresStreamArchiver(
+		c.log,
+		c.dbContext,
+		c.eventSerializer,
+		c.metadataSerializer,
+		objectStore,
+		&PostgresEventStoreOptions{},
+		trace.NewNoopTracerProvider().Tracer(""),
+	)
+
+	_, err = c.eventStore.AppendEvents("account-1", expectedStreamVersion.NoStream, depositedEvents(10, 5), c.ctx)
+	c.Require().NoError(err)
+	_, err = c.eventStore.AppendEvents("account-2", expectedStreamVersion.NoStream, depositedEvents(1), c.ctx)
+	c.Require().NoError(err)
+
+	archived, err := archiver.ArchiveStream(c.ctx, "account-1")
+	c.Require().NoError(err)
+	c.Require().True(archived)
+
+	balance := &rebuildableBalanceProjection{}
+	rebuilder := NewPostgresProjectionRebuilder(
+		c.log,
+		c.eventStore,
+		archiver,
+		es.NewProjectionCheckpoints(c.checkpointRepository),
+		&PostgresEventStoreOptions{Subscription: &Subscription{Prefix: []string{"account-"}}},
+		[]projection.IProjection{balance},
+	)
+
+	progress, err := rebuilder.Rebuild(c.ctx, "balance", &es.ProjectionRebuildOptions{})
+	c.Require().NoError(err)
+	c.Equal(es.ProjectionRebuildCompleted, progress.State)
+	c.Equal(int64(3), progress.ProcessedEvents)
+	c.Equal(16, balance.total)
+
+	// the archived stream is replayed from the archive and stays archived
+	var count int64
+	c.Require().NoError(c.db.Model(&StoredStreamArchive{}).Count(&count).Error)
+	c.Equal(int64(1), count)
+
+	events, err := c.eventStore.ReadEventsWithMaxCount("account-1", readPosition.Start, c.ctx)
+	c.Require().NoError(err)
+	c.Empty(events)
+}
+
+func (c *postgresEventStoreTest) Test_Personal_Data_Is_Encrypted_And_Forgotten_Without_Rewriting_Stream() {
+	accountId := uuid.NewV4()
+	event := &accountHolderChanged{
+		DomainEvent: domain.NewDomainEvent(typeMapper.GetTypeName(&accountHolderChanged{})),
+		Holder:      "Jane Doe",
+	}
+	event.WithAggregate(accountId, 0)
+
+	stream := streamName.StreamName("account-" + accountId.String())
+	_, err := c.eventStore.AppendEvents(
+		stream,
+		expectedStreamVersion.NoStream,
+		[]*models.StreamEvent{{EventID: uuid.NewV4(), Event: event, Metadata: metadata.Metadata{}}},
+		c.ctx,
+	)
+	c.Require().NoError(err)
+
+	var stored StoredEvent
+	c.Require().NoError(c.db.Where("stream_id = ?", stream.String()).First(&stored).Error)
+	c.NotContains(string(stored.Data), "Jane Doe")
+
+	events, err := c.eventStore.ReadEventsFromStart(stream, 10, c.ctx)
+	c.Require().NoError(err)
+	c.Require().Len(events, 1)
+	c.Equal("Jane Doe", events[0].Event.(*accountHolderChanged).Holder)
+
+	c.Require().NoError(c.keyStore.DeleteKey(c.ctx, accountId.String()))
+
+	events, err = c.eventStore.ReadEventsFromStart(stream, 10, c.ctx)
+	c.Require().NoError(err)
+	c.Require().Len(events, 1)
+	c.Empty(events[0].Event.(*accountHolderChanged).Holder)
+	c.Equal(accountId, events[0].Event.GetAggregateId())
+
+	var forgotten StoredEvent
+	c.Require().NoError(c.db.Where("stream_id = ?", stream.String()).First(&forgotten).Error)
+	c.Equal(stored.Data, forgotten.Data)
+}
+
+func (c *postgresEventStoreTest) Test_Personal_Data_Of_Snapshots_Is_Encrypted_And_Forgotten() {
+	accountId := uuid.NewV4()
+	account := &snapshotAccount{testAccount: newTestAccount(accountId), Holder: "Jane Doe"}
+	account.Balance = 10
+	snapshotStore := NewPostgresSnapshotStore(c.db)
+
+	snapshot, err := es.CreateSnapshot(account, "account-"+accountId.String(), 4, c.eventSerializer)
+	c.Require().NoError(err)
+	c.Require().NoError(snapshotStore.Save(c.ctx, snapshot))
+
+	stored, err := snapshotStore.Load(c.ctx, "account-"+accountId.String())
+	c.Require().NoError(err)
+	c.NotContains(string(stored.Data), "Jane Doe")
+
+	restored := &snapshotAccount{testAccount: newTestAccount(accountId)}
+	ok, err := es.RestoreSnapshot(restored, stored, c.eventSerializer)
+	c.Require().NoError(err)
+	c.Require().True(ok)
+	c.Equal("Jane Doe", restored.Holder)
+	c.Equal(10, restored.Balance)
+
+	c.Require().NoError(c.keyStore.DeleteKey(c.ctx, accountId.String()))
+
+	forgotten := &snapshotAccount{testAccount: newTestAccount(accountId)}
+	ok, err = es.RestoreSnapshot(forgotten, stored, c.eventSerializer)
+	c.Require().NoError(err)
+	c.Require().True(ok)
+	c.Empty(forgotten.Holder)
+	c.Equal(10, forgotten.Balance)
+	c.Equal(int64(4), forgotten.OriginalVersion())
+}
+
+func (c *postgresEventStoreTest) Test_SubscribeAll_Publishes_Events_After_Checkpoint() {
+	_, err := c.eventStore.AppendEvents("account-1", expectedStreamVersion.NoStream, depositedEvents(1, 2), c.ctx)
+	c.Require().NoError(err)
+	c.Require().NoError(c.checkpointRepository.Store("balances", 1, c.ctx))
+
+	balances := &balanceProjection{}
+	worker := NewPostgresSubscriptionAllWorker(
+		c.log,
+		c.eventStore,
+		c.dbContext,
+		c.options,
+		c.checkpointRepository,
+		es.NewProjectionCheckpoints(c.checkpointRepository),
+		[]projection.IProjection{balances},
+	)
+
+	ctx, cancel := context.WithTimeout(c.ctx, 2*time.Second)
+	defer cancel()
+
+	done := make(chan error)
+	go func() {
+		done <- worker.SubscribeAll(ctx, &PostgresSubscriptionToAllOptions{
+			SubscriptionId: "balances",
+			Prefixes:       []string{"account-"},
+		})
+	}()
+
+	_, err = c.eventStore.AppendEvents("account-2", expectedStreamVersion.NoStream, depositedEvents(3), c.ctx)
+	c.Require().NoError(err)
+
+	c.Eventually(func() bool {
+		status, err := worker.SubscriptionStatus(c.ctx)
+		return err == nil && status.Position == 3 && status.State == es.SubscriptionLive
+	}, time.Second, 20*time.Millisecond)
+
+	cancel()
+	c.ErrorIs(<-done, context.Canceled)
+
+	// the checkpoints are batched, the last handled event is checkpointed with the stop of subscription
+	checkpoint, err := c.checkpointRepository.Load("balances", c.ctx)
+	c.Require().NoError(err)
+	c.Equal(uint64(3), checkpoint)
+
+	// the event before the checkpoint is not published again
+	c.Equal(5, balances.total)
+}
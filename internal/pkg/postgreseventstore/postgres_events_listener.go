@@ -0,0 +1,94 @@
+package postgreseventstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"emperror.dev/errors"
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/gorm"
+)
+
+// eventsListener waits for the notifications of the appended events on a dedicated connection of the pool
+type eventsListener struct {
+	db   *gorm.DB
+	conn *sql.Conn
+}
+
+func newEventsListener(db *gorm.DB) *eventsListener {
+	// the notifications are only supported by postgres, the other databases rely on polling
+	if db.Dialector.Name() != "postgres" {
+		return nil
+	}
+
+	return &eventsListener{db: db}
+}
+
+// Wait blocks until an event is appended or the context is done, it returns nil on the notification and on the deadline of context
+func (l *eventsListener) Wait(ctx context.Context) error {
+	err := l.listen(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = l.conn.Raw(func(driverConn interface{}) error {
+		conn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return errors.New("the postgres connection doesn't support notifications")
+		}
+
+		_, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil && conn.Conn().IsClosed() {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		// the closed connection is replaced on the next wait
+		_ = l.Close()
+
+		return errors.WrapIf(err, "error in waiting for the events notification")
+	}
+
+	return nil
+}
+
+func (l *eventsListener) Close() error {
+	if l.conn == nil {
+		return nil
+	}
+
+	err := l.conn.Close()
+	l.conn = nil
+
+	return err
+}
+
+func (l *eventsListener) listen(ctx context.Context) error {
+	if l.conn != nil {
+		return nil
+	}
+
+	sqlDB, err := l.db.DB()
+	if err != nil {
+		return errors.WrapIf(err, "error in getting the sql db")
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return errors.WrapIf(err, "error in getting a connection for the events notification")
+	}
+
+	_, err = conn.ExecContext(ctx, fmt.Sprintf("LISTEN %s", EventsNotificationChannel))
+	if err != nil {
+		_ = conn.Close()
+
+		return errors.WrapIf(err, "error in listening to the events notification")
+	}
+
+	l.conn = conn
+
+	return nil
+}
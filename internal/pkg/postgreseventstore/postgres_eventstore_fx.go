@@ -0,0 +1,161 @@
+package postgreseventstore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/reoden/go-NFT/pkg/core/serializer/personaldata"
+	"github.com/reoden/go-NFT/pkg/es"
+	"github.com/reoden/go-NFT/pkg/es/contracts/projection"
+	"github.com/reoden/go-NFT/pkg/es/contracts/store"
+	"github.com/reoden/go-NFT/pkg/es/models"
+	"github.com/reoden/go-NFT/pkg/health/contracts"
+	"github.com/reoden/go-NFT/pkg/logger"
+
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// Module stores the event sourcing data of the aggregates on the postgres database of the service and runs the
+// subscription to all of the projections when a subscription is configured and the archival of the cold streams when an
+// archive policy is configured
+var Module = fx.Options(
+	fx.Module(
+		"postgreseventstorefx",
+		fx.Provide(
+			es.ProvideConfig,
+			ProvideConfig,
+			NewPostgresEventStoreDBContext,
+			fx.Annotate(
+				NewPostgresEventStore,
+				fx.As(fx.Self()),
+				fx.As(new(store.EventStore)),
+			),
+			NewPostgresSnapshotStore,
+			NewPostgresPersonalDataKeyStore,
+			fx.Annotate(
+				NewPostgresStreamArchiver,
+				// the services without a storage can't archive the streams
+				fx.ParamTags(``, ``, ``, ``, `optional:"true"`, ``, ``),
+				fx.As(fx.Self()),
+				fx.As(new(store.StreamArchive)),
+			),
+			NewPostgresStreamArchiveWorker,
+			NewPostgresSubscriptionCheckpointRepository,
+			// the projections are locked on the database, so a rebuild on an instance holds the live events of all instances
+			NewPostgresProjectionLocker,
+			es.NewProjectionCheckpointsWithLocker,
+			fx.Annotate(
+				NewPostgresSubscriptionAllWorker,
+				fx.ParamTags(``, ``, ``, ``, ``, ``, `group:"projections"`),
+			),
+			fx.Annotate(
+				NewPostgresProjectionRebuilder,
+				fx.ParamTags(``, ``, ``, ``, ``, `group:"projections"`),
+			),
+			fx.Annotate(
+				NewPostgresSubscriptionLagHealthChecker,
+				fx.As(new(contracts.Health)),
+				fx.ResultTags(fmt.Sprintf(`group:"%s"`, "healths")),
+			),
+		),
+		fx.Invoke(migrateEventStore),
+		fx.Invoke(registerHooks),
+		fx.Invoke(runStreamArchiveWorker),
+	),
+	// the personal data of the stored events and snapshots is encrypted with the keys of its subjects, the decorator is
+	// applied out of the module scope so the aggregate stores of the services get the decorated serializer too
+	fx.Decorate(personaldata.NewPersonalDataEventSerializer),
+)
+
+// NewPostgresProjectionRebuilder creates the rebuilder of the projections, the events are replayed with the prefixes of
+// the configured subscription and the archived streams are replayed from their archives
+func NewPostgresProjectionRebuilder(
+	log logger.Logger,
+	eventStore PostgresEventStore,
+	archiver PostgresStreamArchiver,
+	checkpoints *es.ProjectionCheckpoints,
+	options *PostgresEventStoreOptions,
+	projections []projection.IProjection,
+) es.ProjectionRebuilder {
+	var prefixes []string
+	if options.Subscription != nil {
+		prefixes = options.Subscription.Prefix
+	}
+
+	return es.NewProjectionRebuilder(log, eventStore, archiver, checkpoints, projections, prefixes)
+}
+
+func migrateEventStore(db *gorm.DB) error {
+	err := db.Migrator().AutoMigrate(
+		&models.Snapshot{},
+		&StoredEvent{},
+		&StoredStream{},
+		&StoredCheckpoint{},
+		&StoredStreamArchive{},
+		&StoredPersonalDataKey{},
+	)
+
+	return err
+}
+
+func registerHooks(
+	lc fx.Lifecycle,
+	worker PostgresSubscriptionAllWorker,
+	logger logger.Logger,
+	options *PostgresEventStoreOptions,
+) {
+	if options.Subscription == nil {
+		return
+	}
+
+	lifetimeCtx, cancel := context.WithCancel(context.Background())
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			// the start context is canceled after the startup, so the worker runs on its own context
+			go func() {
+				option := &PostgresSubscriptionToAllOptions{
+					SubscriptionId: options.Subscription.SubscriptionId,
+					Prefixes:       options.Subscription.Prefix,
+				}
+				if err := worker.SubscribeAll(lifetimeCtx, option); err != nil && lifetimeCtx.Err() == nil {
+					logger.Errorf(
+						"(worker.SubscribeAll) error in running postgres subscription worker: {%v}",
+						err,
+					)
+				}
+			}()
+			logger.Info("postgres subscription worker is listening.")
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			cancel()
+
+			return nil
+		},
+	})
+}
+
+func runStreamArchiveWorker(
+	lc fx.Lifecycle,
+	worker PostgresStreamArchiveWorker,
+	options *PostgresEventStoreOptions,
+) {
+	if options.Archive == nil {
+		return
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			// the start context is canceled after the startup, so the worker runs on its own context
+			worker.Start(context.Background())
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return worker.Stop(ctx)
+		},
+	})
+}
@@ -0,0 +1,128 @@
+package postgreseventstore
+
+import (
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/config"
+	"github.com/reoden/go-NFT/pkg/config/environment"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[PostgresEventStoreOptions]())
+
+type PostgresEventStoreOptions struct {
+	// PollingInterval maximum wait for a notification of the new events in milliseconds before reading the events table again
+	PollingInterval int `mapstructure:"pollingInterval" default:"1000"`
+	// BatchSize maximum number of the events that are read in each subscription read
+	BatchSize    int           `mapstructure:"batchSize"       default:"100"`
+	Subscription *Subscription `mapstructure:"subscription"`
+	Archive      *Archive      `mapstructure:"archive"`
+}
+
+type Subscription struct {
+	Prefix         []string `mapstructure:"prefix"         validate:"required"`
+	SubscriptionId string   `mapstructure:"subscriptionId" validate:"required"`
+	// CheckpointInterval number of the handled events between the stored checkpoints
+	CheckpointInterval int `mapstructure:"checkpointInterval"`
+	// CheckpointFlushInterval maximum time in milliseconds that a handled event waits for its checkpoint
+	CheckpointFlushInterval int `mapstructure:"checkpointFlushInterval"`
+	// MinResubscribeDelay delay of the first retry after a failed read or handle in milliseconds, it doubles for the next attempts
+	MinResubscribeDelay int `mapstructure:"minResubscribeDelay"`
+	// MaxResubscribeDelay maximum delay of the retries in milliseconds
+	MaxResubscribeDelay int `mapstructure:"maxResubscribeDelay"`
+	// MaxLag maximum distance between the last handled event and the head of the events table in global positions for a
+	// healthy subscription
+	MaxLag uint64 `mapstructure:"maxLag"`
+}
+
+// Archive the policy of moving the events of the cold streams to the object store, the archived events are not read by
+// the subscriptions and the projection rebuilds, so the prefixes should only cover the streams with complete projections
+type Archive struct {
+	Prefix []string `mapstructure:"prefix" validate:"required"`
+	// OlderThan hours since the last append of a stream before its events are archived
+	OlderThan int `mapstructure:"olderThan"`
+	// Interval between the archival runs in seconds
+	Interval int `mapstructure:"interval"`
+	// BatchSize maximum number of the streams that are archived in each run
+	BatchSize int `mapstructure:"batchSize"`
+	// KeyPrefix prefix of the archive keys in the object store
+	KeyPrefix string `mapstructure:"keyPrefix"`
+}
+
+func (o *PostgresEventStoreOptions) PollingIntervalDuration() time.Duration {
+	if o.PollingInterval <= 0 {
+		return time.Second
+	}
+
+	return time.Duration(o.PollingInterval) * time.Millisecond
+}
+
+func (s *Subscription) CheckpointIntervalOrDefault() int {
+	if s.CheckpointInterval <= 0 {
+		return 100
+	}
+
+	return s.CheckpointInterval
+}
+
+func (s *Subscription) CheckpointFlushIntervalDuration() time.Duration {
+	if s.CheckpointFlushInterval <= 0 {
+		return 5 * time.Second
+	}
+
+	return time.Duration(s.CheckpointFlushInterval) * time.Millisecond
+}
+
+func (s *Subscription) MinResubscribeDelayDuration() time.Duration {
+	if s.MinResubscribeDelay <= 0 {
+		return 500 * time.Millisecond
+	}
+
+	return time.Duration(s.MinResubscribeDelay) * time.Millisecond
+}
+
+func (s *Subscription) MaxResubscribeDelayDuration() time.Duration {
+	if s.MaxResubscribeDelay <= 0 {
+		return 30 * time.Second
+	}
+
+	return time.Duration(s.MaxResubscribeDelay) * time.Millisecond
+}
+
+func (a *Archive) OlderThanDuration() time.Duration {
+	if a.OlderThan <= 0 {
+		return 90 * 24 * time.Hour
+	}
+
+	return time.Duration(a.OlderThan) * time.Hour
+}
+
+func (a *Archive) IntervalDuration() time.Duration {
+	if a.Interval <= 0 {
+		return time.Hour
+	}
+
+	return time.Duration(a.Interval) * time.Second
+}
+
+func (a *Archive) BatchSizeOrDefault() int {
+	if a.BatchSize <= 0 {
+		return 100
+	}
+
+	return a.BatchSize
+}
+
+func (a *Archive) KeyPrefixOrDefault() string {
+	if a.KeyPrefix == "" {
+		return "event-archives"
+	}
+
+	return a.KeyPrefix
+}
+
+func ProvideConfig(environment environment.Environment) (*PostgresEventStoreOptions, error) {
+	return config.BindConfigKey[*PostgresEventStoreOptions](optionName, environment)
+}
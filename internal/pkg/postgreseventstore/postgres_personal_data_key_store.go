@@ -0,0 +1,66 @@
+package postgreseventstore
+
+import (
+	"context"
+
+	"github.com/reoden/go-NFT/pkg/core/serializer/personaldata"
+
+	"emperror.dev/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type postgresPersonalDataKeyStore struct {
+	db *gorm.DB
+}
+
+// NewPostgresPersonalDataKeyStore creates a key store that keeps the personal data keys of the subjects in the
+// `personal_data_keys` table
+func NewPostgresPersonalDataKeyStore(db *gorm.DB) personaldata.KeyStore {
+	return &postgresPersonalDataKeyStore{db: db}
+}
+
+func (s *postgresPersonalDataKeyStore) GetOrCreateKey(ctx context.Context, subjectId string) ([]byte, error) {
+	key, err := s.GetKey(ctx, subjectId)
+	if !errors.Is(err, personaldata.ErrKeyNotFound) {
+		return key, err
+	}
+
+	newKey, err := personaldata.NewKey()
+	if err != nil {
+		return nil, err
+	}
+
+	// a concurrent write creates the key of the subject in the meantime, so the stored key is read again
+	result := s.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&StoredPersonalDataKey{SubjectId: subjectId, Key: newKey})
+	if result.Error != nil {
+		return nil, errors.WrapIf(result.Error, "error in saving the personal data key")
+	}
+
+	return s.GetKey(ctx, subjectId)
+}
+
+func (s *postgresPersonalDataKeyStore) GetKey(ctx context.Context, subjectId string) ([]byte, error) {
+	var keys []*StoredPersonalDataKey
+
+	result := s.db.WithContext(ctx).Where("subject_id = ?", subjectId).Limit(1).Find(&keys)
+	if result.Error != nil {
+		return nil, errors.WrapIf(result.Error, "error in fetching the personal data key")
+	}
+	if len(keys) == 0 {
+		return nil, errors.WithStack(personaldata.ErrKeyNotFound)
+	}
+
+	return keys[0].Key, nil
+}
+
+func (s *postgresPersonalDataKeyStore) DeleteKey(ctx context.Context, subjectId string) error {
+	result := s.db.WithContext(ctx).Where("subject_id = ?", subjectId).Delete(&StoredPersonalDataKey{})
+	if result.Error != nil {
+		return errors.WrapIf(result.Error, "error in deleting the personal data key")
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package postgreseventstore
+
+import (
+	"context"
+	"database/sql/driver"
+	"hash/fnv"
+
+	"github.com/reoden/go-NFT/pkg/es"
+	"github.com/reoden/go-NFT/pkg/logger"
+
+	"emperror.dev/errors"
+)
+
+type postgresProjectionLocker struct {
+	log       logger.Logger
+	dbContext *PostgresEventStoreDBContext
+	local     es.ProjectionLocker
+}
+
+// NewPostgresProjectionLocker creates a projection locker on the postgres session advisory locks, so the live events of
+// all the instances of the service wait for the final phase of a rebuild. The lock is held by a dedicated connection
+// until the unlock, it is released by the database when the connection is lost.
+func NewPostgresProjectionLocker(log logger.Logger, dbContext *PostgresEventStoreDBContext) es.ProjectionLocker {
+	return &postgresProjectionLocker{
+		log:       log,
+		dbContext: dbContext,
+		local:     es.NewInProcessProjectionLocker(),
+	}
+}
+
+func (p *postgresProjectionLocker) Lock(ctx context.Context, projectionName string) (func(), error) {
+	db := p.dbContext.DB()
+
+	// sqlite is used by a single process
+	if db.Dialector.Name() != "postgres" {
+		return p.local.Lock(ctx, projectionName)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in getting the database of the projection lock")
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in getting a connection for the projection lock")
+	}
+
+	key := projectionAdvisoryLockKey(projectionName)
+	_, err = conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", key)
+	if err != nil {
+		_ = conn.Close()
+
+		return nil, errors.WrapIf(err, "error in locking the projection")
+	}
+
+	return func() {
+		_, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", key)
+		if err != nil {
+			p.log.Errorf("error in unlocking the projection '%s', its connection is closed: %v", projectionName, err)
+
+			// a bad connection is closed instead of returning to the pool, so the session lock is released with it
+			_ = conn.Raw(func(interface{}) error {
+				return driver.ErrBadConn
+			})
+		}
+
+		_ = conn.Close()
+	}, nil
+}
+
+// projectionAdvisoryLockKey the key of the postgres advisory lock of a projection
+func projectionAdvisoryLockKey(projectionName string) int64 {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte("projection-" + projectionName))
+
+	return int64(hash.Sum64())
+}
@@ -0,0 +1,90 @@
+//go:build integration
+// +build integration
+
+package postgreseventstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/config"
+	"github.com/reoden/go-NFT/pkg/config/environment"
+	"github.com/reoden/go-NFT/pkg/core"
+	"github.com/reoden/go-NFT/pkg/logger"
+	defaultLogger "github.com/reoden/go-NFT/pkg/logger/defaultlogger"
+	"github.com/reoden/go-NFT/pkg/logger/external/fxlog"
+	"github.com/reoden/go-NFT/pkg/logger/zap"
+	"github.com/reoden/go-NFT/pkg/postgresgorm"
+	"github.com/reoden/go-NFT/pkg/test/containers/testcontainer/postgrespxg"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+func Test_Projection_Lock_Is_Shared_By_The_Lockers_Of_Different_Instances(t *testing.T) {
+	ctx := context.Background()
+
+	containerOptions, err := postgrespxg.NewPostgresPgxContainers(defaultLogger.GetLogger()).
+		PopulateContainerOptions(ctx, t)
+	require.NoError(t, err)
+
+	var (
+		dbContext *PostgresEventStoreDBContext
+		log       logger.Logger
+	)
+
+	app := fxtest.New(
+		t,
+		config.ModuleFunc(environment.Test),
+		zap.Module,
+		fxlog.FxLogger,
+		core.Module,
+		postgresgorm.Module,
+		fx.Decorate(
+			func(cfg *postgresgorm.GormOptions) (*postgresgorm.GormOptions, error) {
+				cfg.UseSQLLite = false
+				cfg.UseInMemory = false
+				cfg.Host = containerOptions.Host
+				cfg.Port = containerOptions.Port
+				cfg.User = containerOptions.User
+				cfg.Password = containerOptions.Password
+				cfg.DBName = containerOptions.DBName
+
+				return cfg, nil
+			},
+		),
+		fx.Provide(NewPostgresEventStoreDBContext),
+		fx.Populate(&dbContext),
+		fx.Populate(&log),
+	).RequireStart()
+	defer app.RequireStop()
+
+	// the lockers of two instances of the service on the same database
+	rebuildingInstance := NewPostgresProjectionLocker(log, dbContext)
+	liveInstance := NewPostgresProjectionLocker(log, dbContext)
+
+	unlock, err := rebuildingInstance.Lock(ctx, "positions")
+	require.NoError(t, err)
+
+	// the live events of the other instance wait for the rebuild
+	waitCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	_, err = liveInstance.Lock(waitCtx, "positions")
+	cancel()
+	assert.Error(t, err)
+
+	// the other projections are not locked
+	unlockOther, err := liveInstance.Lock(ctx, "others")
+	require.NoError(t, err)
+	unlockOther()
+
+	unlock()
+
+	waitCtx, cancel = context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	unlock, err = liveInstance.Lock(waitCtx, "positions")
+	require.NoError(t, err)
+	unlock()
+}
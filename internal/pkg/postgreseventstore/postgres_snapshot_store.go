@@ -0,0 +1,50 @@
+package postgreseventstore
+
+import (
+	"context"
+
+	"github.com/reoden/go-NFT/pkg/es/contracts/store"
+	"github.com/reoden/go-NFT/pkg/es/models"
+
+	"emperror.dev/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type postgresSnapshotStore struct {
+	db *gorm.DB
+}
+
+// NewPostgresSnapshotStore creates a snapshot store that keeps the latest snapshot of each aggregate stream in the `snapshots` table
+func NewPostgresSnapshotStore(db *gorm.DB) store.SnapshotStore {
+	return &postgresSnapshotStore{db: db}
+}
+
+func (s *postgresSnapshotStore) Load(ctx context.Context, streamId string) (*models.Snapshot, error) {
+	var snapshot *models.Snapshot
+
+	result := s.db.WithContext(ctx).Where("stream_id = ?", streamId).First(&snapshot)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, errors.WithStack(store.ErrSnapshotNotFound)
+	}
+	if result.Error != nil {
+		return nil, errors.WrapIf(result.Error, "error in fetching the snapshot")
+	}
+
+	return snapshot, nil
+}
+
+func (s *postgresSnapshotStore) Save(ctx context.Context, snapshot *models.Snapshot) error {
+	// https://gorm.io/docs/create.html#Upsert-On-Conflict
+	result := s.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "stream_id"}},
+			UpdateAll: true,
+		}).
+		Create(snapshot)
+	if result.Error != nil {
+		return errors.WrapIf(result.Error, "error in saving the snapshot")
+	}
+
+	return nil
+}
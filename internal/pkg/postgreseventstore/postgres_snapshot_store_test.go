@@ -0,0 +1,113 @@
+//go:build unit
+// +build unit
+
+package postgreseventstore
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/reoden/go-NFT/pkg/config"
+	"github.com/reoden/go-NFT/pkg/config/environment"
+	"github.com/reoden/go-NFT/pkg/core"
+	"github.com/reoden/go-NFT/pkg/es/contracts/store"
+	"github.com/reoden/go-NFT/pkg/es/models"
+	"github.com/reoden/go-NFT/pkg/logger/external/fxlog"
+	"github.com/reoden/go-NFT/pkg/logger/zap"
+	"github.com/reoden/go-NFT/pkg/postgresgorm"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+	"gorm.io/gorm"
+)
+
+type postgresSnapshotStoreTest struct {
+	suite.Suite
+	db         *gorm.DB
+	store      store.SnapshotStore
+	ctx        context.Context
+	dbFilePath string
+	app        *fxtest.App
+}
+
+func TestPostgresSnapshotStore(t *testing.T) {
+	suite.Run(t, &postgresSnapshotStoreTest{})
+}
+
+func (c *postgresSnapshotStoreTest) SetupTest() {
+	var db *gorm.DB
+	var gormOptions *postgresgorm.GormOptions
+
+	app := fxtest.New(
+		c.T(),
+		config.ModuleFunc(environment.Test),
+		zap.Module,
+		fxlog.FxLogger,
+		core.Module,
+		postgresgorm.Module,
+		fx.Decorate(
+			func(cfg *postgresgorm.GormOptions) (*postgresgorm.GormOptions, error) {
+				// using sql-lite with a database file
+				cfg.UseSQLLite = true
+
+				return cfg, nil
+			},
+		),
+		fx.Populate(&db),
+		fx.Populate(&gormOptions),
+	).RequireStart()
+
+	c.db = db
+	c.dbFilePath = gormOptions.Dns()
+	c.app = app
+	c.ctx = context.Background()
+	c.store = NewPostgresSnapshotStore(db)
+
+	c.Require().NoError(migrateEventStore(db))
+}
+
+func (c *postgresSnapshotStoreTest) TearDownTest() {
+	sqldb, _ := c.db.DB()
+	c.Require().NoError(sqldb.Close())
+
+	// removing sql-lite file
+	c.Require().NoError(os.Remove(c.dbFilePath))
+
+	c.app.RequireStop()
+}
+
+func (c *postgresSnapshotStoreTest) Test_Load_Returns_Not_Found_Without_Snapshot() {
+	_, err := c.store.Load(c.ctx, "counter-1")
+
+	c.ErrorIs(err, store.ErrSnapshotNotFound)
+}
+
+func (c *postgresSnapshotStoreTest) Test_Save_Replaces_Latest_Snapshot() {
+	c.Require().NoError(c.store.Save(c.ctx, &models.Snapshot{
+		StreamId:      "counter-1",
+		AggregateType: "counter",
+		Version:       9,
+		SchemaVersion: 1,
+		Data:          []byte(`{"Total":10}`),
+	}))
+	c.Require().NoError(c.store.Save(c.ctx, &models.Snapshot{
+		StreamId:      "counter-1",
+		AggregateType: "counter",
+		Version:       19,
+		SchemaVersion: 2,
+		Data:          []byte(`{"Total":20}`),
+	}))
+
+	snapshot, err := c.store.Load(c.ctx, "counter-1")
+	c.Require().NoError(err)
+
+	c.Equal(int64(19), snapshot.Version)
+	c.Equal(2, snapshot.SchemaVersion)
+	c.Equal(`{"Total":20}`, string(snapshot.Data))
+
+	var count int64
+	c.Require().NoError(c.db.Model(&models.Snapshot{}).Count(&count).Error)
+	c.Equal(int64(1), count)
+}
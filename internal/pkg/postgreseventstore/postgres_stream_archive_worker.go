@@ -0,0 +1,47 @@
+package postgreseventstore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/web"
+)
+
+type PostgresStreamArchiveWorker web.Worker
+
+// NewPostgresStreamArchiveWorker creates a background worker that archives the cold streams of the archive policy on
+// every archive interval
+func NewPostgresStreamArchiveWorker(
+	archiver PostgresStreamArchiver,
+	options *PostgresEventStoreOptions,
+	l logger.Logger,
+) PostgresStreamArchiveWorker {
+	return web.NewBackgroundWorker(
+		func(ctx context.Context) error {
+			if options.Archive == nil {
+				return nil
+			}
+
+			archiveTicker := time.NewTicker(options.Archive.IntervalDuration())
+			defer archiveTicker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-archiveTicker.C:
+					archived, err := archiver.ArchiveStreams(ctx)
+					if err != nil && ctx.Err() == nil {
+						l.Error(fmt.Sprintf("[PostgresStreamArchiveWorker.ArchiveStreams] error in archiving the streams: %v", err))
+					}
+					if archived > 0 {
+						l.Infof("%d cold streams archived", archived)
+					}
+				}
+			}
+		},
+		nil,
+	)
+}
@@ -0,0 +1,594 @@
+package postgreseventstore
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/core/serializer"
+	"github.com/reoden/go-NFT/pkg/es/contracts/store"
+	"github.com/reoden/go-NFT/pkg/es/models"
+	streamName "github.com/reoden/go-NFT/pkg/es/models/stream_name"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/otel/tracing/utils"
+	"github.com/reoden/go-NFT/pkg/storage"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+	attribute2 "go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const archiveContentType = "application/gzip"
+
+// PostgresStreamArchiver moves the events of the cold streams into gzip compressed json lines files of the object store,
+// the archived streams keep their versions and leave a tombstone with the archive key in the `stream_archives` table.
+type PostgresStreamArchiver interface {
+	store.StreamArchive
+
+	// ArchiveStreams archives a batch of the streams of the archive policy without any appends in the `OlderThan` period,
+	// it returns the number of the archived streams.
+	ArchiveStreams(ctx context.Context) (int, error)
+
+	// ArchiveStream archives the events of the stream regardless of the policy, it returns false for the missing, empty and
+	// already archived streams.
+	ArchiveStream(ctx context.Context, streamName streamName.StreamName) (bool, error)
+}
+
+// archivedEvent a line of the stream archives, the events are kept in their stored form, so they are restored without
+// any serialization
+type archivedEvent struct {
+	EventId        uuid.UUID `json:"eventId"`
+	Version        int64     `json:"version"`
+	GlobalPosition int64     `json:"globalPosition"`
+	EventType      string    `json:"eventType"`
+	ContentType    string    `json:"contentType"`
+	Data           []byte    `json:"data"`
+	Metadata       []byte    `json:"metadata"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+type postgresStreamArchiver struct {
+	log                logger.Logger
+	dbContext          *PostgresEventStoreDBContext
+	eventSerializer    serializer.EventSerializer
+	metadataSerializer serializer.MetadataSerializer
+	objectStore        storage.ObjectStore
+	options            *PostgresEventStoreOptions
+	tracer             trace.Tracer
+}
+
+// NewPostgresStreamArchiver creates the archiver of the postgres event store, the object store is optional for the services
+// without a storage, the archival and the reads of the archived streams fail without it.
+func NewPostgresStreamArchiver(
+	log logger.Logger,
+	dbContext *PostgresEventStoreDBContext,
+	eventSerializer serializer.EventSerializer,
+	metadataSerializer serializer.MetadataSerializer,
+	objectStore storage.ObjectStore,
+	options *PostgresEventStoreOptions,
+	tracer trace.Tracer,
+) PostgresStreamArchiver {
+	return &postgresStreamArchiver{
+		log:                log,
+		dbContext:          dbContext,
+		eventSerializer:    eventSerializer,
+		metadataSerializer: metadataSerializer,
+		objectStore:        objectStore,
+		options:            options,
+		tracer:             tracer,
+	}
+}
+
+func (p *postgresStreamArchiver) ArchiveStreams(ctx context.Context) (int, error) {
+	ctx, span := p.tracer.Start(ctx, "postgresStreamArchiver.ArchiveStreams")
+	defer span.End()
+
+	policy := p.options.Archive
+	if policy == nil {
+		return 0, nil
+	}
+
+	cutoff := time.Now().Add(-policy.OlderThanDuration())
+
+	query := p.dbContext.WithTxIfExists(ctx).DB().
+		WithContext(ctx).
+		Model(&StoredStream{}).
+		Where("updated_at < ?", cutoff).
+		Where("stream_id NOT IN (?)", p.dbContext.DB().Model(&StoredStreamArchive{}).Select("stream_id"))
+
+	if len(policy.Prefix) > 0 {
+		prefixQuery := p.dbContext.DB().Where("stream_id LIKE ?", policy.Prefix[0]+"%")
+		for _, prefix := range policy.Prefix[1:] {
+			prefixQuery = prefixQuery.Or("stream_id LIKE ?", prefix+"%")
+		}
+		query = query.Where(prefixQuery)
+	}
+
+	var streamIds []string
+	err := query.Order("updated_at").Limit(policy.BatchSizeOrDefault()).Pluck("stream_id", &streamIds).Error
+	if err != nil {
+		return 0, utils.TraceErrStatusFromSpan(
+			span,
+			errors.WrapIf(err, "[postgresStreamArchiver_ArchiveStreams:Pluck] error in reading the cold streams"),
+		)
+	}
+
+	// a failed stream doesn't stop the archival of the others, it is retried in the next run
+	archivedCount := 0
+	var archiveErr error
+	for _, streamId := range streamIds {
+		archived, err := p.archiveStream(ctx, streamName.StreamName(streamId), cutoff)
+		if err != nil {
+			archiveErr = errors.Append(archiveErr, err)
+			continue
+		}
+		if archived {
+			archivedCount++
+		}
+	}
+
+	if archiveErr != nil {
+		return archivedCount, utils.TraceErrStatusFromSpan(span, archiveErr)
+	}
+
+	return archivedCount, nil
+}
+
+func (p *postgresStreamArchiver) ArchiveStream(ctx context.Context, streamName streamName.StreamName) (bool, error) {
+	ctx, span := p.tracer.Start(ctx, "postgresStreamArchiver.ArchiveStream")
+	span.SetAttributes(attribute2.String("StreamName", streamName.String()))
+	defer span.End()
+
+	archived, err := p.archiveStream(ctx, streamName, time.Time{})
+	if err != nil {
+		return false, utils.TraceErrStatusFromSpan(span, err)
+	}
+
+	return archived, nil
+}
+
+func (p *postgresStreamArchiver) Rehydrate(ctx context.Context, streamName streamName.StreamName) (bool, error) {
+	ctx, span := p.tracer.Start(ctx, "postgresStreamArchiver.Rehydrate")
+	span.SetAttributes(attribute2.String("StreamName", streamName.String()))
+	defer span.End()
+
+	// the streams are rarely archived, so the tombstone is checked before locking the stream
+	var count int64
+	err := p.dbContext.WithTxIfExists(ctx).DB().
+		WithContext(ctx).
+		Model(&StoredStreamArchive{}).
+		Where("stream_id = ?", streamName.String()).
+		Count(&count).Error
+	if err != nil {
+		return false, utils.TraceErrStatusFromSpan(
+			span,
+			errors.WrapIf(err, "[postgresStreamArchiver_Rehydrate:Count] error in checking the stream archive"),
+		)
+	}
+	if count == 0 {
+		return false, nil
+	}
+
+	var archive *StoredStreamArchive
+
+	err = p.dbContext.WithTxIfExists(ctx).DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_, err := lockStream(tx, streamName)
+		if err != nil {
+			return err
+		}
+
+		// a concurrent load rehydrates the stream in the meantime
+		archive, err = findStreamArchive(tx, streamName)
+		if err != nil || archive == nil {
+			return err
+		}
+
+		storedEvents, err := p.readArchive(ctx, streamName, archive.ArchiveKey)
+		if err != nil {
+			return err
+		}
+
+		// the events are restored with their global positions, so the subscriptions don't publish them again
+		if len(storedEvents) > 0 {
+			err = tx.Create(&storedEvents).Error
+			if err != nil {
+				return errors.WrapIf(err, "error in restoring the archived events")
+			}
+		}
+
+		err = tx.Where("stream_id = ?", streamName.String()).Delete(&StoredStreamArchive{}).Error
+		if err != nil {
+			return errors.WrapIf(err, "error in deleting the stream archive")
+		}
+
+		// the rehydrated stream is hot again and is not archived before the next `OlderThan` period
+		err = tx.Model(&StoredStream{}).
+			Where("stream_id = ?", streamName.String()).
+			Update("updated_at", time.Now()).Error
+		if err != nil {
+			return errors.WrapIf(err, "error in updating the stream")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return false, utils.TraceErrStatusFromSpan(
+			span,
+			errors.WrapIff(
+				err,
+				"[postgresStreamArchiver_Rehydrate] error in rehydrating stream %s",
+				streamName.String(),
+			),
+		)
+	}
+	if archive == nil {
+		return false, nil
+	}
+
+	// the events are restored, so a failed delete only leaves an orphan archive
+	err = p.objectStore.Delete(ctx, archive.ArchiveKey)
+	if err != nil && !errors.Is(err, storage.ErrObjectNotFound) {
+		p.log.Errorw(
+			fmt.Sprintf(
+				"[postgresStreamArchiver.Rehydrate] error in deleting the archive %s of stream %s: %v",
+				archive.ArchiveKey,
+				streamName.String(),
+				err,
+			),
+			logger.Fields{"StreamId": streamName.String(), "ArchiveKey": archive.ArchiveKey},
+		)
+	}
+
+	p.log.Infow(
+		fmt.Sprintf("stream with id %s rehydrated successfully", streamName.String()),
+		logger.Fields{"StreamId": streamName.String(), "EventCount": archive.EventCount},
+	)
+
+	return true, nil
+}
+
+func (p *postgresStreamArchiver) ArchivedStreams(
+	ctx context.Context,
+	prefixes []string,
+) ([]streamName.StreamName, error) {
+	ctx, span := p.tracer.Start(ctx, "postgresStreamArchiver.ArchivedStreams")
+	defer span.End()
+
+	query := p.dbContext.WithTxIfExists(ctx).DB().WithContext(ctx).Model(&StoredStreamArchive{})
+	if len(prefixes) > 0 {
+		prefixQuery := p.dbContext.DB().Where("stream_id LIKE ?", prefixes[0]+"%")
+		for _, prefix := range prefixes[1:] {
+			prefixQuery = prefixQuery.Or("stream_id LIKE ?", prefix+"%")
+		}
+		query = query.Where(prefixQuery)
+	}
+
+	var streamIds []string
+	err := query.Order("stream_id").Pluck("stream_id", &streamIds).Error
+	if err != nil {
+		return nil, utils.TraceErrStatusFromSpan(
+			span,
+			errors.WrapIf(err, "[postgresStreamArchiver_ArchivedStreams:Pluck] error in reading the archived streams"),
+		)
+	}
+
+	streamNames := make([]streamName.StreamName, 0, len(streamIds))
+	for _, streamId := range streamIds {
+		streamNames = append(streamNames, streamName.StreamName(streamId))
+	}
+
+	return streamNames, nil
+}
+
+func (p *postgresStreamArchiver) ReadArchivedEvents(
+	ctx context.Context,
+	streamName streamName.StreamName,
+) ([]*models.StreamEvent, error) {
+	ctx, span := p.tracer.Start(ctx, "postgresStreamArchiver.ReadArchivedEvents")
+	span.SetAttributes(attribute2.String("StreamName", streamName.String()))
+	defer span.End()
+
+	var missingKey string
+	for {
+		archive, err := findStreamArchive(p.dbContext.WithTxIfExists(ctx).DB().WithContext(ctx), streamName)
+		if err != nil {
+			return nil, utils.TraceErrStatusFromSpan(
+				span,
+				errors.WrapIf(err, "[postgresStreamArchiver_ReadArchivedEvents:Find] error in reading the stream archive"),
+			)
+		}
+		if archive == nil {
+			return nil, nil
+		}
+
+		storedEvents, err := p.readArchive(ctx, streamName, archive.ArchiveKey)
+		if errors.Is(err, storage.ErrObjectNotFound) && archive.ArchiveKey != missingKey {
+			// the stream is rehydrated in the meantime and its archive is deleted, the tombstone is checked again
+			missingKey = archive.ArchiveKey
+			continue
+		}
+		if err != nil {
+			return nil, utils.TraceErrStatusFromSpan(
+				span,
+				errors.WrapIff(
+					err,
+					"[postgresStreamArchiver_ReadArchivedEvents] error in reading the archive of stream %s",
+					streamName.String(),
+				),
+			)
+		}
+
+		streamEvents := make([]*models.StreamEvent, 0, len(storedEvents))
+		for _, storedEvent := range storedEvents {
+			streamEvent, err := toStreamEvent(p.eventSerializer, p.metadataSerializer, storedEvent)
+			if err != nil {
+				return nil, utils.TraceErrStatusFromSpan(span, err)
+			}
+			streamEvents = append(streamEvents, streamEvent)
+		}
+
+		return streamEvents, nil
+	}
+}
+
+// archiveStream archives the events of the stream when it has no appends after the cutoff, the zero cutoff archives the
+// stream regardless of its last append. The archive is uploaded before the transaction that deletes the archived events
+// and stores the tombstone, so the stream is not locked during the upload, and the stream is only archived when it has
+// no appends during the upload.
+func (p *postgresStreamArchiver) archiveStream(
+	ctx context.Context,
+	streamName streamName.StreamName,
+	cutoff time.Time,
+) (bool, error) {
+	if p.objectStore == nil {
+		return false, errors.New("an object store is required for archiving the streams")
+	}
+
+	db := p.dbContext.WithTxIfExists(ctx).DB().WithContext(ctx)
+
+	stream, storedEvents, err := p.readColdStream(db, streamName, cutoff)
+	if err != nil || stream == nil {
+		return false, errors.WrapIff(
+			err,
+			"[postgresStreamArchiver_archiveStream] error in reading stream %s",
+			streamName.String(),
+		)
+	}
+
+	// each archival has its own key, so a failed archival doesn't remove the archive of a concurrent one
+	key := path.Join(p.keyPrefix(), fmt.Sprintf("%s-%s.jsonl.gz", streamName.String(), uuid.NewV4().String()))
+	err = p.writeArchive(ctx, key, storedEvents)
+	if err != nil {
+		return false, errors.WrapIff(
+			err,
+			"[postgresStreamArchiver_archiveStream] error in archiving stream %s",
+			streamName.String(),
+		)
+	}
+
+	var archive *StoredStreamArchive
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		locked, err := lockStream(tx, streamName)
+		if err != nil || locked == nil || locked.Version != stream.Version {
+			return err
+		}
+
+		existing, err := findStreamArchive(tx, streamName)
+		if err != nil || existing != nil {
+			return err
+		}
+
+		lastVersion := storedEvents[len(storedEvents)-1].Version
+		err = tx.Where("stream_id = ? AND version <= ?", streamName.String(), lastVersion).
+			Delete(&StoredEvent{}).Error
+		if err != nil {
+			return errors.WrapIf(err, "error in deleting the archived events")
+		}
+
+		archive = &StoredStreamArchive{
+			StreamId:    streamName.String(),
+			ArchiveKey:  key,
+			LastVersion: lastVersion,
+			EventCount:  len(storedEvents),
+			ArchivedAt:  time.Now(),
+		}
+		err = tx.Create(archive).Error
+		if err != nil {
+			return errors.WrapIf(err, "error in storing the stream archive")
+		}
+
+		return nil
+	})
+	if err != nil || archive == nil {
+		// the stream is changed during the upload or its events are not deleted, so the uploaded archive is not used
+		p.deleteArchive(ctx, streamName, key)
+	}
+	if err != nil {
+		return false, errors.WrapIff(
+			err,
+			"[postgresStreamArchiver_archiveStream] error in archiving stream %s",
+			streamName.String(),
+		)
+	}
+	if archive == nil {
+		return false, nil
+	}
+
+	p.log.Infow(
+		fmt.Sprintf("stream with id %s archived successfully", streamName.String()),
+		logger.Fields{
+			"StreamId":    streamName.String(),
+			"ArchiveKey":  archive.ArchiveKey,
+			"LastVersion": archive.LastVersion,
+			"EventCount":  archive.EventCount,
+		},
+	)
+
+	return true, nil
+}
+
+// readColdStream reads the stream and its events when it has no appends after the cutoff and is not archived, the
+// stream is nil for the missing, hot, empty and already archived streams
+func (p *postgresStreamArchiver) readColdStream(
+	db *gorm.DB,
+	streamName streamName.StreamName,
+	cutoff time.Time,
+) (*StoredStream, []*StoredEvent, error) {
+	var streams []*StoredStream
+	err := db.Where("stream_id = ?", streamName.String()).Limit(1).Find(&streams).Error
+	if err != nil {
+		return nil, nil, errors.WrapIf(err, "error in loading the stream")
+	}
+	if len(streams) == 0 {
+		return nil, nil, nil
+	}
+
+	stream := streams[0]
+	if !cutoff.IsZero() && !stream.UpdatedAt.Before(cutoff) {
+		return nil, nil, nil
+	}
+
+	existing, err := findStreamArchive(db, streamName)
+	if err != nil || existing != nil {
+		return nil, nil, err
+	}
+
+	var storedEvents []*StoredEvent
+	err = db.Where("stream_id = ? AND version <= ?", streamName.String(), stream.Version).
+		Order("version").
+		Find(&storedEvents).Error
+	if err != nil {
+		return nil, nil, errors.WrapIf(err, "error in reading the stream events")
+	}
+	if len(storedEvents) == 0 {
+		return nil, nil, nil
+	}
+
+	return stream, storedEvents, nil
+}
+
+func (p *postgresStreamArchiver) deleteArchive(ctx context.Context, streamName streamName.StreamName, key string) {
+	err := p.objectStore.Delete(ctx, key)
+	if err != nil && !errors.Is(err, storage.ErrObjectNotFound) {
+		p.log.Errorw(
+			fmt.Sprintf(
+				"[postgresStreamArchiver.archiveStream] error in deleting the unused archive %s of stream %s: %v",
+				key,
+				streamName.String(),
+				err,
+			),
+			logger.Fields{"StreamId": streamName.String(), "ArchiveKey": key},
+		)
+	}
+}
+
+func (p *postgresStreamArchiver) writeArchive(ctx context.Context, key string, storedEvents []*StoredEvent) error {
+	var buffer bytes.Buffer
+
+	writer := gzip.NewWriter(&buffer)
+	encoder := json.NewEncoder(writer)
+	for _, storedEvent := range storedEvents {
+		err := encoder.Encode(&archivedEvent{
+			EventId:        storedEvent.EventId,
+			Version:        storedEvent.Version,
+			GlobalPosition: storedEvent.GlobalPosition,
+			EventType:      storedEvent.EventType,
+			ContentType:    storedEvent.ContentType,
+			Data:           storedEvent.Data,
+			Metadata:       storedEvent.Metadata,
+			CreatedAt:      storedEvent.CreatedAt,
+		})
+		if err != nil {
+			return errors.WrapIf(err, "error in encoding the archived event")
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return errors.WrapIf(err, "error in compressing the archive")
+	}
+
+	_, err := p.objectStore.Put(ctx, key, bytes.NewReader(buffer.Bytes()), int64(buffer.Len()), archiveContentType)
+	if err != nil {
+		return errors.WrapIff(err, "error in storing the archive %s", key)
+	}
+
+	return nil
+}
+
+func (p *postgresStreamArchiver) readArchive(
+	ctx context.Context,
+	streamName streamName.StreamName,
+	key string,
+) ([]*StoredEvent, error) {
+	if p.objectStore == nil {
+		return nil, errors.New("an object store is required for reading the archived streams")
+	}
+
+	object, _, err := p.objectStore.Get(ctx, key)
+	if err != nil {
+		return nil, errors.WrapIff(err, "error in opening the archive %s", key)
+	}
+	defer object.Close()
+
+	reader, err := gzip.NewReader(object)
+	if err != nil {
+		return nil, errors.WrapIff(err, "error in decompressing the archive %s", key)
+	}
+	defer reader.Close()
+
+	var storedEvents []*StoredEvent
+	decoder := json.NewDecoder(reader)
+	for {
+		event := &archivedEvent{}
+		err = decoder.Decode(event)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errors.WrapIff(err, "error in decoding the archive %s", key)
+		}
+
+		storedEvents = append(storedEvents, &StoredEvent{
+			GlobalPosition: event.GlobalPosition,
+			EventId:        event.EventId,
+			StreamId:       streamName.String(),
+			Version:        event.Version,
+			EventType:      event.EventType,
+			ContentType:    event.ContentType,
+			Data:           event.Data,
+			Metadata:       event.Metadata,
+			CreatedAt:      event.CreatedAt,
+		})
+	}
+
+	return storedEvents, nil
+}
+
+func (p *postgresStreamArchiver) keyPrefix() string {
+	if p.options.Archive == nil {
+		return (&Archive{}).KeyPrefixOrDefault()
+	}
+
+	return p.options.Archive.KeyPrefixOrDefault()
+}
+
+func findStreamArchive(tx *gorm.DB, streamName streamName.StreamName) (*StoredStreamArchive, error) {
+	var archives []*StoredStreamArchive
+
+	err := tx.Where("stream_id = ?", streamName.String()).Limit(1).Find(&archives).Error
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in loading the stream archive")
+	}
+	if len(archives) == 0 {
+		return nil, nil
+	}
+
+	return archives[0], nil
+}
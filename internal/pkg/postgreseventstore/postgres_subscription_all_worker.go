@@ -0,0 +1,343 @@
+package postgreseventstore
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/es"
+	"github.com/reoden/go-NFT/pkg/es/contracts"
+	"github.com/reoden/go-NFT/pkg/es/contracts/projection"
+	"github.com/reoden/go-NFT/pkg/es/models"
+	"github.com/reoden/go-NFT/pkg/logger"
+
+	"emperror.dev/errors"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type PostgresSubscriptionAllWorker interface {
+	// SubscribeAll reads the events of all streams after the checkpoint of subscription and publishes them to the projections,
+	// the failed reads and handles are retried with a backoff from the last handled event, it blocks until the context is done.
+	SubscribeAll(ctx context.Context, subscriptionOption *PostgresSubscriptionToAllOptions) error
+
+	es.SubscriptionStatusProvider
+}
+
+type PostgresSubscriptionToAllOptions struct {
+	SubscriptionId string
+	// Prefixes the stream prefixes of the subscribed events, all events are subscribed without prefixes
+	Prefixes []string
+}
+
+type postgresSubscriptionAllWorker struct {
+	log                              logger.Logger
+	eventStore                       PostgresEventStore
+	dbContext                        *PostgresEventStoreDBContext
+	options                          *PostgresEventStoreOptions
+	subscriptionCheckpointRepository contracts.SubscriptionCheckpointRepository
+	projectionPublisher              projection.IProjectionPublisher
+	checkpointer                     *es.SubscriptionCheckpointer
+	statusLock                       sync.RWMutex
+	status                           es.SubscriptionStatus
+}
+
+// NewPostgresSubscriptionAllWorker creates the worker of the subscription to all, the new events are waited with the postgres
+// notifications and the events table is polled every `PollingInterval` for the missed notifications.
+func NewPostgresSubscriptionAllWorker(
+	log logger.Logger,
+	eventStore PostgresEventStore,
+	dbContext *PostgresEventStoreDBContext,
+	options *PostgresEventStoreOptions,
+	subscriptionCheckpointRepository contracts.SubscriptionCheckpointRepository,
+	projectionCheckpoints *es.ProjectionCheckpoints,
+	projections []projection.IProjection,
+) PostgresSubscriptionAllWorker {
+	return &postgresSubscriptionAllWorker{
+		log:                              log,
+		eventStore:                       eventStore,
+		dbContext:                        dbContext,
+		options:                          options,
+		subscriptionCheckpointRepository: subscriptionCheckpointRepository,
+		projectionPublisher:              es.NewProjectionPublisherWithCheckpoints(projections, projectionCheckpoints),
+		status:                           es.SubscriptionStatus{State: es.SubscriptionStopped},
+	}
+}
+
+func (s *postgresSubscriptionAllWorker) SubscribeAll(
+	ctx context.Context,
+	subscriptionOption *PostgresSubscriptionToAllOptions,
+) error {
+	if subscriptionOption.SubscriptionId == "" {
+		subscriptionOption.SubscriptionId = "defaultLogger"
+	}
+
+	subscriptionCfg := s.subscriptionConfig()
+	s.checkpointer = es.NewSubscriptionCheckpointer(
+		s.subscriptionCheckpointRepository,
+		subscriptionOption.SubscriptionId,
+		subscriptionCfg.CheckpointIntervalOrDefault(),
+		subscriptionCfg.CheckpointFlushIntervalDuration(),
+	)
+
+	s.log.Info(fmt.Sprintf("starting subscription to all '%s'.", subscriptionOption.SubscriptionId))
+
+	checkpoint, err := s.checkpointer.Load(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.updateStatus(func(status *es.SubscriptionStatus) {
+		status.SubscriptionId = subscriptionOption.SubscriptionId
+		status.State = es.SubscriptionCatchingUp
+		status.Position = checkpoint
+		status.CheckpointPosition = checkpoint
+	})
+
+	defer func() {
+		// the handled events are checkpointed after the stop of subscription, the context is done at this point
+		s.flushCheckpoint(context.Background(), subscriptionOption.SubscriptionId)
+		s.updateStatus(func(status *es.SubscriptionStatus) {
+			status.State = es.SubscriptionStopped
+		})
+	}()
+
+	listener := newEventsListener(s.dbContext.DB())
+	if listener != nil {
+		defer listener.Close()
+	}
+
+	batchSize := s.options.BatchSize
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	s.log.Info(fmt.Sprintf("subscription to all '%s' started.", subscriptionOption.SubscriptionId))
+
+	attempt := 0
+	for {
+		readEvents, err := s.readAndHandleEvents(ctx, subscriptionOption, batchSize)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if err != nil {
+			attempt++
+			s.flushCheckpoint(ctx, subscriptionOption.SubscriptionId)
+
+			delay := es.ResubscribeDelay(
+				attempt,
+				subscriptionCfg.MinResubscribeDelayDuration(),
+				subscriptionCfg.MaxResubscribeDelayDuration(),
+			)
+			s.log.Errorf(
+				"subscription to all '%s' failed, retrying from position %d in %s: %v",
+				subscriptionOption.SubscriptionId,
+				s.checkpointer.Position(),
+				delay,
+				err,
+			)
+			s.updateStatus(func(status *es.SubscriptionStatus) {
+				status.State = es.SubscriptionResubscribing
+				status.Resubscriptions++
+				status.LastError = err.Error()
+			})
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+
+			continue
+		}
+
+		if attempt > 0 {
+			attempt = 0
+			s.updateStatus(func(status *es.SubscriptionStatus) {
+				status.State = es.SubscriptionCatchingUp
+			})
+		}
+
+		if readEvents == batchSize {
+			continue
+		}
+
+		err = s.waitForEvents(ctx, listener)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *postgresSubscriptionAllWorker) SubscriptionStatus(ctx context.Context) (*es.SubscriptionStatus, error) {
+	status := s.currentStatus()
+	if status.State == es.SubscriptionStopped {
+		return &status, nil
+	}
+
+	headPosition, err := s.eventStore.ReadHeadPosition(ctx)
+	if err != nil {
+		return nil, err
+	}
+	status.HeadPosition = headPosition
+
+	return &status, nil
+}
+
+// readAndHandleEvents handles a batch of the events after the last handled event and returns the number of read events
+func (s *postgresSubscriptionAllWorker) readAndHandleEvents(
+	ctx context.Context,
+	subscriptionOption *PostgresSubscriptionToAllOptions,
+	batchSize int,
+) (int, error) {
+	// the head is read before the events, so a batch that is not full reaches at least this head
+	headPosition, err := s.eventStore.ReadHeadPosition(ctx)
+	if err != nil {
+		return 0, err
+	}
+	s.updateStatus(func(status *es.SubscriptionStatus) {
+		status.HeadPosition = headPosition
+	})
+
+	streamEvents, err := s.eventStore.ReadAllEvents(
+		ctx,
+		s.checkpointer.Position(),
+		batchSize,
+		subscriptionOption.Prefixes,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, streamEvent := range streamEvents {
+		err := s.handleEvent(ctx, subscriptionOption.SubscriptionId, streamEvent)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if len(streamEvents) < batchSize {
+		// the events of the other prefixes up to the head are skipped
+		err := s.checkpointer.PositionReached(ctx, headPosition)
+		if err != nil {
+			return 0, err
+		}
+		s.positionReached(subscriptionOption.SubscriptionId, s.checkpointer.Position())
+	}
+
+	return len(streamEvents), nil
+}
+
+func (s *postgresSubscriptionAllWorker) handleEvent(
+	ctx context.Context,
+	subscriptionId string,
+	streamEvent *models.StreamEvent,
+) error {
+	s.log.Info(
+		fmt.Sprintf(
+			"event appeared in subscription to all '%s'. position: %d, revision: %d",
+			subscriptionId,
+			streamEvent.Position,
+			streamEvent.Version,
+		),
+	)
+
+	// publish to internal event bus - for handling event and project it manually tp corresponding read model
+	err := mediatr.Publish(ctx, streamEvent)
+	if err != nil {
+		return errors.WrapIf(
+			err,
+			"failed to publish stream event for the mediatr (internal event bus for handling event)",
+		)
+	}
+
+	// publish to projection publisher
+	err = s.projectionPublisher.Publish(ctx, streamEvent)
+	if err != nil {
+		return errors.WrapIf(err, "failed to publish stream event in the handle event")
+	}
+
+	position := uint64(streamEvent.Position)
+	err = s.checkpointer.EventHandled(ctx, position)
+	if err != nil {
+		return err
+	}
+
+	s.positionReached(subscriptionId, position)
+	s.updateStatus(func(status *es.SubscriptionStatus) {
+		status.LastEventAt = time.Now()
+	})
+
+	return nil
+}
+
+// waitForEvents waits for a notification of the new events for at most the polling interval
+func (s *postgresSubscriptionAllWorker) waitForEvents(ctx context.Context, listener *eventsListener) error {
+	waitCtx, cancel := context.WithTimeout(ctx, s.options.PollingIntervalDuration())
+	defer cancel()
+
+	if listener == nil {
+		<-waitCtx.Done()
+
+		return ctx.Err()
+	}
+
+	err := listener.Wait(waitCtx)
+	if err != nil && ctx.Err() == nil {
+		s.log.Errorf("(listener.Wait) error in waiting for the events notification: %v", err)
+		// the polling interval is waited before listening again
+		<-waitCtx.Done()
+	}
+
+	return ctx.Err()
+}
+
+// positionReached updates the position of subscription and switches the catching up subscription to live when it reaches
+// the head position of the events table
+func (s *postgresSubscriptionAllWorker) positionReached(subscriptionId string, position uint64) {
+	s.updateStatus(func(status *es.SubscriptionStatus) {
+		status.Position = position
+		status.CheckpointPosition = s.checkpointer.StoredPosition()
+
+		if status.State == es.SubscriptionCatchingUp && position >= status.HeadPosition {
+			status.State = es.SubscriptionLive
+			s.log.Info(fmt.Sprintf("subscription to all '%s' caught up and is live.", subscriptionId))
+		}
+	})
+}
+
+func (s *postgresSubscriptionAllWorker) flushCheckpoint(ctx context.Context, subscriptionId string) {
+	err := s.checkpointer.Flush(ctx)
+	if err != nil {
+		s.log.Errorf("subscription to all '%s' failed to store checkpoint: %v", subscriptionId, err)
+
+		return
+	}
+
+	s.updateStatus(func(status *es.SubscriptionStatus) {
+		status.CheckpointPosition = s.checkpointer.StoredPosition()
+	})
+}
+
+func (s *postgresSubscriptionAllWorker) subscriptionConfig() *Subscription {
+	if s.options == nil || s.options.Subscription == nil {
+		return &Subscription{}
+	}
+
+	return s.options.Subscription
+}
+
+func (s *postgresSubscriptionAllWorker) currentStatus() es.SubscriptionStatus {
+	s.statusLock.RLock()
+	defer s.statusLock.RUnlock()
+
+	return s.status
+}
+
+func (s *postgresSubscriptionAllWorker) updateStatus(update func(status *es.SubscriptionStatus)) {
+	s.statusLock.Lock()
+	defer s.statusLock.Unlock()
+
+	update(&s.status)
+}
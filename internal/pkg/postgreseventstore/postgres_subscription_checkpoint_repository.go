@@ -0,0 +1,68 @@
+package postgreseventstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/es/contracts"
+
+	"emperror.dev/errors"
+	"gorm.io/gorm/clause"
+)
+
+type postgresSubscriptionCheckpointRepository struct {
+	dbContext *PostgresEventStoreDBContext
+}
+
+// NewPostgresSubscriptionCheckpointRepository creates a checkpoint repository on the `subscription_checkpoints` table, the
+// checkpoints are stored with the transaction of the context when it exists, so a projection can store its read model and
+// its checkpoint atomically.
+func NewPostgresSubscriptionCheckpointRepository(
+	dbContext *PostgresEventStoreDBContext,
+) contracts.SubscriptionCheckpointRepository {
+	return &postgresSubscriptionCheckpointRepository{dbContext: dbContext}
+}
+
+func (p *postgresSubscriptionCheckpointRepository) Load(subscriptionId string, ctx context.Context) (uint64, error) {
+	var checkpoints []*StoredCheckpoint
+
+	err := p.dbContext.WithTxIfExists(ctx).DB().
+		WithContext(ctx).
+		Where("subscription_id = ?", subscriptionId).
+		Limit(1).
+		Find(&checkpoints).Error
+	if err != nil {
+		return 0, errors.WrapIf(err, "error in loading the subscription checkpoint")
+	}
+	if len(checkpoints) == 0 {
+		return 0, nil
+	}
+
+	return checkpoints[0].Position, nil
+}
+
+func (p *postgresSubscriptionCheckpointRepository) Store(
+	subscriptionId string,
+	position uint64,
+	ctx context.Context,
+) error {
+	checkpoint := &StoredCheckpoint{
+		SubscriptionId: subscriptionId,
+		Position:       position,
+		CheckpointAt:   time.Now(),
+	}
+
+	// https://gorm.io/docs/create.html#Upsert-On-Conflict
+	err := p.dbContext.WithTxIfExists(ctx).DB().
+		WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "subscription_id"}},
+			UpdateAll: true,
+		}).
+		Create(checkpoint).Error
+	if err != nil {
+		return errors.WrapIf(err, "error in storing the subscription checkpoint")
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package postgreseventstore
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// StoredEvent an event of a stream, the global position is a sequence over the events of all streams
+type StoredEvent struct {
+	GlobalPosition int64     `gorm:"primaryKey;autoIncrement"`
+	EventId        uuid.UUID `gorm:"uniqueIndex"`
+	StreamId       string    `gorm:"uniqueIndex:idx_events_stream_id_version"`
+	Version        int64     `gorm:"uniqueIndex:idx_events_stream_id_version"`
+	EventType      string
+	ContentType    string
+	Data           []byte
+	Metadata       []byte
+	CreatedAt      time.Time
+}
+
+func (e *StoredEvent) TableName() string {
+	return "events"
+}
+
+// StoredStream the version of a stream, it is used for the optimistic concurrency of the appends and keeps the version
+// of the truncated streams
+type StoredStream struct {
+	StreamId string `gorm:"primaryKey"`
+	// Version the version of the last appended event of the stream
+	Version   int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (s *StoredStream) TableName() string {
+	return "streams"
+}
+
+// StoredCheckpoint the last handled global position of a subscription
+type StoredCheckpoint struct {
+	SubscriptionId string `gorm:"primaryKey"`
+	Position       uint64
+	CheckpointAt   time.Time
+}
+
+func (c *StoredCheckpoint) TableName() string {
+	return "subscription_checkpoints"
+}
+
+// StoredStreamArchive the tombstone of an archived stream, it points to the archive of the stream events in the object
+// store until the stream is rehydrated
+type StoredStreamArchive struct {
+	StreamId string `gorm:"primaryKey"`
+	// ArchiveKey the object store key of the compressed events
+	ArchiveKey string
+	// LastVersion the version of the last archived event
+	LastVersion int64
+	EventCount  int
+	ArchivedAt  time.Time
+}
+
+func (a *StoredStreamArchive) TableName() string {
+	return "stream_archives"
+}
+
+// StoredPersonalDataKey the encryption key of the personal data of a subject, the personal data of the subject is
+// forgotten by deleting its key
+type StoredPersonalDataKey struct {
+	SubjectId string `gorm:"primaryKey"`
+	Key       []byte
+	CreatedAt time.Time
+}
+
+func (k *StoredPersonalDataKey) TableName() string {
+	return "personal_data_keys"
+}
@@ -0,0 +1,20 @@
+package postgreseventstore
+
+import (
+	"github.com/reoden/go-NFT/pkg/es"
+	"github.com/reoden/go-NFT/pkg/health/contracts"
+)
+
+// NewPostgresSubscriptionLagHealthChecker creates the health check of the subscription to all, the lag is the distance
+// between the global positions of the last handled event and the last event of the events table
+func NewPostgresSubscriptionLagHealthChecker(
+	worker PostgresSubscriptionAllWorker,
+	options *PostgresEventStoreOptions,
+) contracts.Health {
+	var maxLag uint64
+	if options.Subscription != nil {
+		maxLag = options.Subscription.MaxLag
+	}
+
+	return es.NewSubscriptionLagHealthChecker("postgres-eventstore-subscription-lag", worker, maxLag)
+}
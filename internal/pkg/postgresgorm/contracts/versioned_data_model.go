@@ -0,0 +1,8 @@
+package contracts
+
+// VersionedDataModel is implemented by the data models that use optimistic concurrency, `UpdateModel` and
+// `UpdateDataModel` only update them when the stored version is still the version that was read and increment it
+type VersionedDataModel interface {
+	GetVersion() int64
+	SetVersion(version int64)
+}
@@ -15,6 +15,7 @@ import (
 	uuid "github.com/satori/go.uuid"
 )
 
+// Exists checks the existence of the data-model inner a tx if exists, so the changes of the tx are visible
 func Exists[TDataModel interface{}](
 	ctx context.Context,
 	dbContext contracts.GormDBContext,
@@ -24,11 +25,12 @@ func Exists[TDataModel interface{}](
 
 	dataModel := typeMapper.GenericInstanceByT[TDataModel]()
 
-	dbContext.DB().WithContext(ctx).Model(dataModel).Scopes(scopes.FilterByID(id)).Count(&count)
+	dbContext.WithTxIfExists(ctx).DB().WithContext(ctx).Model(dataModel).Scopes(scopes.FilterByID(id)).Count(&count)
 
 	return count > 0
 }
 
+// FindModelByID find the model inner a tx if exists
 func FindModelByID[TDataModel interface{}, TModel interface{}](
 	ctx context.Context,
 	dbContext contracts.GormDBContext,
@@ -47,7 +49,7 @@ func FindModelByID[TDataModel interface{}, TModel interface{}](
 	modelName := strcase.ToSnake(typeMapper.GetGenericNonePointerTypeNameByT[TModel]())
 	dataModelName := strcase.ToSnake(typeMapper.GetGenericNonePointerTypeNameByT[TDataModel]())
 
-	result := dbContext.DB().WithContext(ctx).First(&dataModel, id)
+	result := dbContext.WithTxIfExists(ctx).DB().WithContext(ctx).First(&dataModel, id)
 	if result.Error != nil {
 		return *new(TModel), customErrors.NewNotFoundErrorWrap(
 			result.Error,
@@ -72,6 +74,7 @@ func FindModelByID[TDataModel interface{}, TModel interface{}](
 	return resultModel, nil
 }
 
+// FindModelByCond find the model inner a tx if exists
 func FindModelByCond[TDataModel interface{}, TModel interface{}](
 	ctx context.Context,
 	dbContext contracts.GormDBContext,
@@ -90,7 +93,7 @@ func FindModelByCond[TDataModel interface{}, TModel interface{}](
 	modelName := strcase.ToSnake(typeMapper.GetGenericNonePointerTypeNameByT[TModel]())
 	dataModelName := strcase.ToSnake(typeMapper.GetGenericNonePointerTypeNameByT[TDataModel]())
 
-	query := dbContext.DB().WithContext(ctx)
+	query := dbContext.WithTxIfExists(ctx).DB().WithContext(ctx)
 	if len(conds) > 0 {
 		query = query.Where(conds)
 	}
@@ -119,6 +122,7 @@ func FindModelByCond[TDataModel interface{}, TModel interface{}](
 	return resultModel, nil
 }
 
+// FindDataModelByID find the data-model inner a tx if exists
 func FindDataModelByID[TDataModel interface{}](
 	ctx context.Context,
 	dbContext contracts.GormDBContext,
@@ -136,7 +140,7 @@ func FindDataModelByID[TDataModel interface{}](
 
 	dataModelName := strcase.ToSnake(typeMapper.GetGenericNonePointerTypeNameByT[TDataModel]())
 
-	result := dbContext.DB().WithContext(ctx).First(&dataModel, id)
+	result := dbContext.WithTxIfExists(ctx).DB().WithContext(ctx).First(&dataModel, id)
 	if result.Error != nil {
 		return *new(TDataModel), customErrors.NewNotFoundErrorWrap(
 			result.Error,
@@ -153,6 +157,7 @@ func FindDataModelByID[TDataModel interface{}](
 	return dataModel, nil
 }
 
+// FindDataModelByCond find the data-model inner a tx if exists
 func FindDataModelByCond[TDataModel interface{}](
 	ctx context.Context,
 	dbContext contracts.GormDBContext,
@@ -170,7 +175,7 @@ func FindDataModelByCond[TDataModel interface{}](
 
 	dataModelName := strcase.ToSnake(typeMapper.GetGenericNonePointerTypeNameByT[TDataModel]())
 
-	query := dbContext.DB().WithContext(ctx)
+	query := dbContext.WithTxIfExists(ctx).DB().WithContext(ctx)
 	if len(conds) > 0 {
 		query = query.Where(conds)
 	}
@@ -315,17 +320,11 @@ func UpdateModel[TDataModel interface{}, TModel interface{}](
 		)
 	}
 
-	// https://gorm.io/docs/update.html
-	result := txDBContext.DB().WithContext(ctx).Updates(dataModel)
-	if result.Error != nil {
-		return *new(TModel), customErrors.NewInternalServerErrorWrap(
-			result.Error,
-			fmt.Sprintf("error in updating the %s", modelName),
-		)
+	err = updates(ctx, txDBContext, dataModel, modelName)
+	if err != nil {
+		return *new(TModel), err
 	}
 
-	defaultlogger.GetLogger().Infof("Number of affected rows are: %d", result.RowsAffected)
-
 	modelResult, err := mapper.Map[TModel](dataModel)
 	if err != nil {
 		return *new(TModel), customErrors.NewInternalServerErrorWrap(
@@ -347,16 +346,63 @@ func UpdateDataModel[TDataModel interface{}](
 
 	dataModelName := strcase.ToSnake(typeMapper.GetGenericNonePointerTypeNameByT[TDataModel]())
 
-	// https://gorm.io/docs/update.html
-	result := txDBContext.DB().WithContext(ctx).Updates(dataModel)
+	err := updates(ctx, txDBContext, dataModel, dataModelName)
+	if err != nil {
+		return *new(TDataModel), err
+	}
+
+	return dataModel, nil
+}
+
+// updates saves the non-zero fields of the data-model, a `contracts.VersionedDataModel` is only updated when its stored
+// version is still the version it was read with, otherwise a `ConcurrencyError` is returned
+func updates(
+	ctx context.Context,
+	dbContext contracts.GormDBContext,
+	dataModel interface{},
+	name string,
+) error {
+	db := dbContext.DB().WithContext(ctx)
+
+	versioned, ok := dataModel.(contracts.VersionedDataModel)
+	if !ok {
+		// https://gorm.io/docs/update.html
+		result := db.Updates(dataModel)
+		if result.Error != nil {
+			return customErrors.NewInternalServerErrorWrap(
+				result.Error,
+				fmt.Sprintf("error in updating the %s", name),
+			)
+		}
+
+		defaultlogger.GetLogger().Infof("Number of affected rows are: %d", result.RowsAffected)
+
+		return nil
+	}
+
+	expectedVersion := versioned.GetVersion()
+	versioned.SetVersion(expectedVersion + 1)
+
+	// https://gorm.io/docs/update.html#Update-with-conditions
+	result := db.Model(dataModel).Where("version = ?", expectedVersion).Updates(dataModel)
 	if result.Error != nil {
-		return *new(TDataModel), customErrors.NewInternalServerErrorWrap(
+		versioned.SetVersion(expectedVersion)
+
+		return customErrors.NewInternalServerErrorWrap(
 			result.Error,
-			fmt.Sprintf("error in updating the %s", dataModelName),
+			fmt.Sprintf("error in updating the %s", name),
+		)
+	}
+
+	if result.RowsAffected == 0 {
+		versioned.SetVersion(expectedVersion)
+
+		return customErrors.NewConcurrencyError(
+			fmt.Sprintf("%s was changed or deleted after version %d was read", name, expectedVersion),
 		)
 	}
 
 	defaultlogger.GetLogger().Infof("Number of affected rows are: %d", result.RowsAffected)
 
-	return dataModel, nil
+	return nil
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/reoden/go-NFT/pkg/config"
 	"github.com/reoden/go-NFT/pkg/config/environment"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
 	"github.com/reoden/go-NFT/pkg/logger/external/fxlog"
 	"github.com/reoden/go-NFT/pkg/logger/zap"
 	"github.com/reoden/go-NFT/pkg/mapper"
@@ -34,6 +35,7 @@ type ProductDataModel struct {
 	Name        string
 	Description string
 	Price       float64
+	Version     int64     `gorm:"not null;default:1"`
 	CreatedAt   time.Time `gorm:"default:current_timestamp"`
 	UpdatedAt   time.Time
 	// for soft delete - https://gorm.io/docs/delete.html#Soft-Delete
@@ -45,6 +47,14 @@ func (p *ProductDataModel) TableName() string {
 	return "products"
 }
 
+func (p *ProductDataModel) GetVersion() int64 {
+	return p.Version
+}
+
+func (p *ProductDataModel) SetVersion(version int64) {
+	p.Version = version
+}
+
 func (p *ProductDataModel) String() string {
 	j, _ := json.Marshal(p)
 
@@ -57,6 +67,7 @@ type Product struct {
 	Name        string
 	Description string
 	Price       float64
+	Version     int64
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
@@ -209,6 +220,43 @@ func (s *GormDBContextTestSuite) Test_UpdateProduct() {
 
 	s.Assert().Equal(item.Name, p2.Name)
 	s.Assert().Equal(res.Name, p2.Name)
+	s.Assert().Equal(item.Version+1, p2.Version)
+	s.Assert().Equal(res.Version, p2.Version)
+}
+
+func (s *GormDBContextTestSuite) Test_UpdateProduct_With_Stale_Version() {
+	s.Require().NotNil(s.dbContext)
+
+	id := s.items[0].Id
+
+	p, err := FindModelByID[*ProductDataModel, *Product](
+		context.Background(),
+		s.dbContext,
+		id,
+	)
+	s.Require().NoError(err)
+
+	first := *p
+	first.Name = gofakeit.Name()
+	_, err = UpdateModel[*ProductDataModel, *Product](context.Background(), s.dbContext, &first)
+	s.Require().NoError(err)
+
+	// the second update is based on the version that was read before the first update
+	second := *p
+	second.Name = gofakeit.Name()
+	_, err = UpdateModel[*ProductDataModel, *Product](context.Background(), s.dbContext, &second)
+	s.Require().Error(err)
+	s.Assert().True(customErrors.IsConcurrencyError(err))
+
+	p2, err := FindModelByID[*ProductDataModel, *Product](
+		context.Background(),
+		s.dbContext,
+		id,
+	)
+	s.Require().NoError(err)
+
+	s.Assert().Equal(first.Name, p2.Name)
+	s.Assert().Equal(p.Version+1, p2.Version)
 }
 
 // TestSuite Hooks
@@ -48,6 +48,21 @@ func SetTxToContext(ctx context.Context, tx *gorm.DB) *contracts.GormContext {
 	return gormContext
 }
 
+// IsUniqueViolation reports whether the error of the db is a violation of a unique constraint, like the SQLSTATE 23505
+// of postgres. The driver error is translated by the dialector of the db.
+func IsUniqueViolation(db *gorm.DB, err error) bool {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
+	}
+
+	translator, ok := db.Dialector.(gorm.ErrorTranslator)
+	if !ok {
+		return false
+	}
+
+	return errors.Is(translator.Translate(err), gorm.ErrDuplicatedKey)
+}
+
 // Ref: https://dev.to/rafaelgfirmino/pagination-using-gorm-scopes-3k5f
 
 func Paginate[TDataModel any, TEntity any](
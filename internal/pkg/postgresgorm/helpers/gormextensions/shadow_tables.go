@@ -0,0 +1,78 @@
+package gormextensions
+
+import (
+	"context"
+	"fmt"
+
+	"emperror.dev/errors"
+	"gorm.io/gorm"
+)
+
+// ShadowTableName the name of the shadow table that a rebuild of the table is written to
+func ShadowTableName(table string) string {
+	return fmt.Sprintf("%s_shadow", table)
+}
+
+// CreateShadowTable creates an empty shadow with the columns of the table, a previous shadow is dropped. On postgres the
+// shadow has the constraints and the indexes of the table, so the table should not have serial columns that their sequences
+// are owned by the table.
+func CreateShadowTable(ctx context.Context, db *gorm.DB, table string, shadowTable string) error {
+	err := DropShadowTable(ctx, db, shadowTable)
+	if err != nil {
+		return err
+	}
+
+	quotedTable := db.Statement.Quote(table)
+	quotedShadow := db.Statement.Quote(shadowTable)
+
+	var statement string
+	if db.Dialector.Name() == "postgres" {
+		statement = fmt.Sprintf("CREATE TABLE %s (LIKE %s INCLUDING ALL)", quotedShadow, quotedTable)
+	} else {
+		statement = fmt.Sprintf("CREATE TABLE %s AS SELECT * FROM %s WHERE 1 = 0", quotedShadow, quotedTable)
+	}
+
+	err = db.WithContext(ctx).Exec(statement).Error
+	if err != nil {
+		return errors.WrapIf(err, fmt.Sprintf("error in creating the shadow table '%s'", shadowTable))
+	}
+
+	return nil
+}
+
+// SwapShadowTable replaces the table with its shadow in a transaction, the readers see the table or the shadow and never
+// a missing table
+func SwapShadowTable(ctx context.Context, db *gorm.DB, table string, shadowTable string) error {
+	oldTable := fmt.Sprintf("%s_old", table)
+
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Migrator().RenameTable(table, oldTable); err != nil {
+			return err
+		}
+		if err := tx.Migrator().RenameTable(shadowTable, table); err != nil {
+			return err
+		}
+
+		return tx.Migrator().DropTable(oldTable)
+	})
+	if err != nil {
+		return errors.WrapIf(err, fmt.Sprintf("error in swapping the table '%s' with its shadow", table))
+	}
+
+	return nil
+}
+
+// DropShadowTable drops the shadow table when it exists
+func DropShadowTable(ctx context.Context, db *gorm.DB, shadowTable string) error {
+	migrator := db.WithContext(ctx).Migrator()
+	if !migrator.HasTable(shadowTable) {
+		return nil
+	}
+
+	err := migrator.DropTable(shadowTable)
+	if err != nil {
+		return errors.WrapIf(err, fmt.Sprintf("error in dropping the shadow table '%s'", shadowTable))
+	}
+
+	return nil
+}
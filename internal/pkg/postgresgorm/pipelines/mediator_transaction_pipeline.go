@@ -34,8 +34,12 @@ func (m *mediatorTransactionPipeline) Handle(
 ) (interface{}, error) {
 	requestName := typeMapper.GetSnakeTypeName(request)
 
-	_, ok := request.(cqrs.TxRequest)
-	if !ok {
+	if !cqrs.IsTxRequest(request) {
+		return next(ctx)
+	}
+
+	// the requests that are sent by a transactional handler join the transaction of the handler
+	if gormextensions.GetTxFromContextIfExists(ctx) != nil {
 		return next(ctx)
 	}
 
@@ -83,7 +87,7 @@ func (m *mediatorTransactionPipeline) Handle(
 	m.logger.Infof("committing transaction for request `%s`", requestName)
 
 	if err = tx.WithContext(ctx).Commit().Error; err != nil {
-		m.logger.Errorf("transaction commit error: ", err)
+		m.logger.Errorf("transaction commit error: %+v", err)
 	}
 
 	if err != nil {
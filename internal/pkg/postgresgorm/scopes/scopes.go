@@ -62,27 +62,32 @@ func FilterPaginate[TDataModel any](
 			Limit(listQuery.GetLimit()).
 			Order(listQuery.GetOrderBy())
 
-		if listQuery.Filters != nil {
-			for _, filter := range listQuery.Filters {
-				column := filter.Field
-				action := filter.Comparison
-				value := filter.Value
-
-				switch action {
-				case "equals":
-					whereQuery := fmt.Sprintf("%s = ?", column)
-					query = query.Where(whereQuery, value)
-				case "contains":
-					whereQuery := fmt.Sprintf("%s LIKE ?", column)
-					query = query.Where(whereQuery, "%"+value+"%")
-				case "in":
-					whereQuery := fmt.Sprintf("%s IN (?)", column)
-					queryArray := strings.Split(value, ",")
-					query = query.Where(whereQuery, queryArray)
-				}
+		return Filter(listQuery.Filters)(query)
+	}
+}
+
+// Filter applies the `equals`, `contains` and `in` comparisons of the filters on their fields
+func Filter(filters []*utils.FilterModel) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, filter := range filters {
+			column := filter.Field
+			action := filter.Comparison
+			value := filter.Value
+
+			switch action {
+			case "equals":
+				whereQuery := fmt.Sprintf("%s = ?", column)
+				db = db.Where(whereQuery, value)
+			case "contains":
+				whereQuery := fmt.Sprintf("%s LIKE ?", column)
+				db = db.Where(whereQuery, "%"+value+"%")
+			case "in":
+				whereQuery := fmt.Sprintf("%s IN (?)", column)
+				queryArray := strings.Split(value, ",")
+				db = db.Where(whereQuery, queryArray)
 			}
 		}
 
-		return query
+		return db
 	}
 }
@@ -0,0 +1,40 @@
+package messagepersistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/core/messaging/persistmessage"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/web"
+)
+
+type InboxCleaner web.Worker
+
+// NewInboxCleaner creates a background worker that removes the inbox messages after their retention period
+func NewInboxCleaner(
+	messagePersistenceService persistmessage.MessagePersistenceService,
+	inboxOptions *InboxOptions,
+	l logger.Logger,
+) InboxCleaner {
+	return web.NewBackgroundWorker(
+		func(ctx context.Context) error {
+			cleanupTicker := time.NewTicker(inboxOptions.CleanupIntervalDuration())
+			defer cleanupTicker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-cleanupTicker.C:
+					err := messagePersistenceService.CleanupInboxMessages(ctx, inboxOptions.RetentionPeriodDuration())
+					if err != nil && ctx.Err() == nil {
+						l.Error(fmt.Sprintf("[InboxCleaner.CleanupInboxMessages] error in cleaning up the inbox messages: %v", err))
+					}
+				}
+			}
+		},
+		nil,
+	)
+}
@@ -0,0 +1,137 @@
+package messagepersistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/reoden/go-NFT/pkg/core/messaging/persistmessage"
+	"github.com/reoden/go-NFT/pkg/core/messaging/pipeline"
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	"github.com/reoden/go-NFT/pkg/core/serializer"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/postgresgorm/contracts"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// inboxNamespace namespace of the inbox ids, the inbox id of a message is unique per consumer
+var inboxNamespace = uuid.NewV5(uuid.NamespaceURL, "go-nft/inbox")
+
+// inboxConsumerPipeline records the received messages in the inbox in the same transaction of the handlers,
+// and skips the messages that are already handled by the consumer because of the broker redeliveries or the retries.
+type inboxConsumerPipeline struct {
+	consumerName              string
+	messagePersistenceService persistmessage.MessagePersistenceService
+	messagingDBContext        *PostgresMessagePersistenceDBContext
+	messageSerializer         serializer.MessageSerializer
+	logger                    logger.Logger
+}
+
+func NewInboxPipelineFactory(
+	messagingDBContext *PostgresMessagePersistenceDBContext,
+	messageSerializer serializer.MessageSerializer,
+	l logger.Logger,
+) pipeline.InboxPipelineFactory {
+	// the inbox doesn't publish messages, so it uses a message store without the bus
+	messagePersistenceService := NewPostgresMessageService(
+		messagingDBContext,
+		messageSerializer,
+		&OutboxOptions{},
+		l,
+		nil,
+	)
+
+	return func(consumerName string) pipeline.ConsumerPipeline {
+		return &inboxConsumerPipeline{
+			consumerName:              consumerName,
+			messagePersistenceService: messagePersistenceService,
+			messagingDBContext:        messagingDBContext,
+			messageSerializer:         messageSerializer,
+			logger:                    l,
+		}
+	}
+}
+
+func (p *inboxConsumerPipeline) Handle(
+	ctx context.Context,
+	consumerContext types.MessageConsumeContext,
+	next pipeline.ConsumerHandlerFunc,
+) error {
+	message := consumerContext.Message()
+	if message == nil {
+		return next(ctx)
+	}
+
+	messageId := message.GeMessageId()
+	if messageId == "" {
+		messageId = consumerContext.MessageId()
+	}
+	if messageId == "" {
+		return next(ctx)
+	}
+
+	inboxId := InboxMessageId(p.consumerName, messageId)
+	if p.isHandled(ctx, inboxId) {
+		p.skip(messageId)
+
+		return nil
+	}
+
+	err := p.messagingDBContext.RunInTx(ctx, func(ctx context.Context, _ contracts.GormDBContext) error {
+		storeMessage, err := NewStoreMessage(
+			p.messageSerializer,
+			message,
+			consumerContext.Metadata(),
+			p.consumerName,
+			persistmessage.Inbox,
+		)
+		if err != nil {
+			return err
+		}
+		storeMessage.ID = inboxId
+
+		// a concurrent delivery of the same message waits on this insert until the first delivery finishes
+		err = p.messagePersistenceService.Add(ctx, storeMessage)
+		if err != nil {
+			return err
+		}
+
+		err = next(ctx)
+		if err != nil {
+			return err
+		}
+
+		storeMessage.ChangeState(persistmessage.Processed)
+
+		return p.messagePersistenceService.Update(ctx, storeMessage)
+	})
+	if err != nil && p.isHandled(ctx, inboxId) {
+		p.skip(messageId)
+
+		return nil
+	}
+
+	return err
+}
+
+func (p *inboxConsumerPipeline) isHandled(ctx context.Context, inboxId uuid.UUID) bool {
+	storeMessage, err := p.messagePersistenceService.GetById(ctx, inboxId)
+
+	return err == nil && storeMessage.MessageStatus == persistmessage.Processed
+}
+
+func (p *inboxConsumerPipeline) skip(messageId string) {
+	p.logger.Infow(
+		fmt.Sprintf(
+			"message with id `%s` is already handled by the consumer `%s`, skipping the duplicate message",
+			messageId,
+			p.consumerName,
+		),
+		logger.Fields{"MessageId": messageId, "Consumer": p.consumerName},
+	)
+}
+
+// InboxMessageId returns the id of the inbox record of a message for a consumer
+func InboxMessageId(consumerName string, messageId string) uuid.UUID {
+	return uuid.NewV5(inboxNamespace, fmt.Sprintf("%s:%s", consumerName, messageId))
+}
@@ -0,0 +1,40 @@
+package messagepersistence
+
+import (
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/config"
+	"github.com/reoden/go-NFT/pkg/config/environment"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var inboxOptionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[InboxOptions]())
+
+type InboxOptions struct {
+	// RetentionPeriod hours that the received messages are kept for the deduplication
+	RetentionPeriod int `mapstructure:"retentionPeriod" default:"168"`
+	// CleanupInterval interval of removing the expired inbox messages in seconds
+	CleanupInterval int `mapstructure:"cleanupInterval" default:"3600"`
+}
+
+func (o *InboxOptions) RetentionPeriodDuration() time.Duration {
+	if o.RetentionPeriod <= 0 {
+		return 7 * 24 * time.Hour
+	}
+
+	return time.Duration(o.RetentionPeriod) * time.Hour
+}
+
+func (o *InboxOptions) CleanupIntervalDuration() time.Duration {
+	if o.CleanupInterval <= 0 {
+		return time.Hour
+	}
+
+	return time.Duration(o.CleanupInterval) * time.Second
+}
+
+func ProvideInboxConfig(environment environment.Environment) (*InboxOptions, error) {
+	return config.BindConfigKey[*InboxOptions](inboxOptionName, environment)
+}
@@ -0,0 +1,47 @@
+package messagepersistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/core/messaging/persistmessage"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/web"
+)
+
+type OutboxDispatcher web.Worker
+
+// NewOutboxDispatcher creates a background worker that polls the outbox and publishes the stored messages,
+// the processed messages are removed from the outbox on each cleanup interval.
+func NewOutboxDispatcher(
+	messagePersistenceService persistmessage.MessagePersistenceService,
+	outboxOptions *OutboxOptions,
+	l logger.Logger,
+) OutboxDispatcher {
+	return web.NewBackgroundWorker(
+		func(ctx context.Context) error {
+			pollingTicker := time.NewTicker(outboxOptions.PollingIntervalDuration())
+			defer pollingTicker.Stop()
+
+			cleanupTicker := time.NewTicker(outboxOptions.CleanupIntervalDuration())
+			defer cleanupTicker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-pollingTicker.C:
+					if err := messagePersistenceService.ProcessAll(ctx); err != nil && ctx.Err() == nil {
+						l.Error(fmt.Sprintf("[OutboxDispatcher.ProcessAll] error in dispatching the outbox messages: %v", err))
+					}
+				case <-cleanupTicker.C:
+					if err := messagePersistenceService.CleanupMessages(ctx); err != nil && ctx.Err() == nil {
+						l.Error(fmt.Sprintf("[OutboxDispatcher.CleanupMessages] error in cleaning up the outbox messages: %v", err))
+					}
+				}
+			}
+		},
+		nil,
+	)
+}
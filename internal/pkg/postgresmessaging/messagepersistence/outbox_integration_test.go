@@ -0,0 +1,176 @@
+//go:build integration
+// +build integration
+
+package messagepersistence
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reoden/go-NFT/pkg/config"
+	"github.com/reoden/go-NFT/pkg/config/environment"
+	"github.com/reoden/go-NFT/pkg/core"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	"github.com/reoden/go-NFT/pkg/core/messaging/mocks"
+	"github.com/reoden/go-NFT/pkg/core/messaging/persistmessage"
+	"github.com/reoden/go-NFT/pkg/core/messaging/producer"
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	"github.com/reoden/go-NFT/pkg/core/serializer"
+	"github.com/reoden/go-NFT/pkg/logger"
+	defaultLogger "github.com/reoden/go-NFT/pkg/logger/defaultlogger"
+	"github.com/reoden/go-NFT/pkg/logger/external/fxlog"
+	"github.com/reoden/go-NFT/pkg/logger/zap"
+	"github.com/reoden/go-NFT/pkg/postgresgorm"
+	"github.com/reoden/go-NFT/pkg/postgresgorm/pipelines"
+	"github.com/reoden/go-NFT/pkg/test/containers/testcontainer/postgrespxg"
+
+	"emperror.dev/errors"
+	"github.com/mehdihadeli/go-mediatr"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+	"gorm.io/gorm"
+)
+
+type orderPlaced struct {
+	*types.Message
+	OrderId string
+}
+
+type placeOrder struct {
+	cqrs.Command
+	OrderId string
+	Fail    bool
+}
+
+func (p *placeOrder) IsTxRequest() {
+}
+
+// placeOrderHandler publishes the order event and fails after it when the command asks for it
+type placeOrderHandler struct {
+	producer producer.Producer
+}
+
+func (h *placeOrderHandler) Handle(ctx context.Context, command *placeOrder) (string, error) {
+	event := &orderPlaced{Message: types.NewMessage(uuid.NewV4().String()), OrderId: command.OrderId}
+	if err := h.producer.PublishMessage(ctx, event, nil); err != nil {
+		return "", err
+	}
+
+	if command.Fail {
+		return "", errors.New("order can't be placed")
+	}
+
+	return event.MessageId, nil
+}
+
+func Test_Outbox_Publishes_Only_The_Messages_Of_The_Committed_Transactional_Requests(t *testing.T) {
+	ctx := context.Background()
+
+	containerOptions, err := postgrespxg.NewPostgresPgxContainers(defaultLogger.GetLogger()).
+		PopulateContainerOptions(ctx, t)
+	require.NoError(t, err)
+
+	var (
+		db                *gorm.DB
+		dbContext         *PostgresMessagePersistenceDBContext
+		messageSerializer serializer.MessageSerializer
+		log               logger.Logger
+	)
+
+	app := fxtest.New(
+		t,
+		config.ModuleFunc(environment.Test),
+		zap.Module,
+		fxlog.FxLogger,
+		core.Module,
+		postgresgorm.Module,
+		fx.Decorate(
+			func(cfg *postgresgorm.GormOptions) (*postgresgorm.GormOptions, error) {
+				cfg.UseSQLLite = false
+				cfg.UseInMemory = false
+				cfg.Host = containerOptions.Host
+				cfg.Port = containerOptions.Port
+				cfg.User = containerOptions.User
+				cfg.Password = containerOptions.Password
+				cfg.DBName = containerOptions.DBName
+
+				return cfg, nil
+			},
+		),
+		fx.Provide(NewPostgresMessagePersistenceDBContext),
+		fx.Populate(&db),
+		fx.Populate(&dbContext),
+		fx.Populate(&messageSerializer),
+		fx.Populate(&log),
+	).RequireStart()
+	defer app.RequireStop()
+
+	require.NoError(t, db.AutoMigrate(&persistmessage.StoreMessage{}))
+
+	bus := mocks.NewBus(t)
+	brokerProducer := mocks.NewProducer(t)
+	messageService := NewPostgresMessageService(
+		dbContext,
+		messageSerializer,
+		&OutboxOptions{BatchSize: 10, MaxRetryCount: 3},
+		log,
+		bus,
+	)
+	outbox := NewOutboxProducer(brokerProducer, messageService, messageSerializer, log)
+
+	defer mediatr.ClearRequestRegistrations()
+	defer mediatr.ClearPipelineBehaviors()
+	require.NoError(t, mediatr.RegisterRequestPipelineBehaviors(pipelines.NewMediatorTransactionPipeline(log, db)))
+	require.NoError(t, mediatr.RegisterRequestHandler[*placeOrder, string](&placeOrderHandler{producer: outbox}))
+
+	// the message of the rolled back request is not stored and not published
+	_, err = mediatr.Send[*placeOrder, string](
+		ctx,
+		&placeOrder{Command: cqrs.NewCommandByT[placeOrder](), OrderId: "rolled-back", Fail: true},
+	)
+	require.Error(t, err)
+
+	var count int64
+	require.NoError(t, db.Model(&persistmessage.StoreMessage{}).Count(&count).Error)
+	assert.Zero(t, count)
+
+	require.NoError(t, messageService.ProcessAll(ctx))
+	bus.AssertNotCalled(t, "PublishMessageWithTopicName", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// the message of the committed request is published by the outbox dispatcher
+	messageId, err := mediatr.Send[*placeOrder, string](
+		ctx,
+		&placeOrder{Command: cqrs.NewCommandByT[placeOrder](), OrderId: "committed"},
+	)
+	require.NoError(t, err)
+
+	bus.On(
+		"PublishMessageWithTopicName",
+		mock.Anything,
+		mock.MatchedBy(func(m *orderPlaced) bool {
+			return m.MessageId == messageId && m.OrderId == "committed"
+		}),
+		mock.Anything,
+		"",
+	).Return(nil).Once()
+
+	require.NoError(t, messageService.ProcessAll(ctx))
+
+	storeMessage, err := messageService.GetById(ctx, uuid.FromStringOrNil(messageId))
+	require.NoError(t, err)
+	assert.Equal(t, persistmessage.Processed, storeMessage.MessageStatus)
+
+	// the handler never published directly to the broker
+	brokerProducer.AssertNotCalled(
+		t,
+		"PublishMessageWithTopicName",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+	)
+}
@@ -0,0 +1,55 @@
+package messagepersistence
+
+import (
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/config"
+	"github.com/reoden/go-NFT/pkg/config/environment"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[OutboxOptions]())
+
+type OutboxOptions struct {
+	// PollingInterval interval of polling the outbox for the stored messages in milliseconds
+	PollingInterval int `mapstructure:"pollingInterval" default:"1000"`
+	// BatchSize maximum number of the messages that are dispatched in each polling
+	BatchSize int `mapstructure:"batchSize"       default:"100"`
+	// MaxRetryCount the messages that failed more than this count are not dispatched anymore
+	MaxRetryCount int `mapstructure:"maxRetryCount"   default:"10"`
+	// CleanupInterval interval of removing the processed messages in seconds
+	CleanupInterval int `mapstructure:"cleanupInterval" default:"3600"`
+	// ClaimTimeout lease of the claimed messages in milliseconds, the messages of a dispatcher that stopped before
+	// marking them are claimed again after the lease
+	ClaimTimeout int `mapstructure:"claimTimeout"    default:"30000"`
+}
+
+func (o *OutboxOptions) PollingIntervalDuration() time.Duration {
+	if o.PollingInterval <= 0 {
+		return time.Second
+	}
+
+	return time.Duration(o.PollingInterval) * time.Millisecond
+}
+
+func (o *OutboxOptions) ClaimTimeoutDuration() time.Duration {
+	if o.ClaimTimeout <= 0 {
+		return 30 * time.Second
+	}
+
+	return time.Duration(o.ClaimTimeout) * time.Millisecond
+}
+
+func (o *OutboxOptions) CleanupIntervalDuration() time.Duration {
+	if o.CleanupInterval <= 0 {
+		return time.Hour
+	}
+
+	return time.Duration(o.CleanupInterval) * time.Second
+}
+
+func ProvideOutboxConfig(environment environment.Environment) (*OutboxOptions, error) {
+	return config.BindConfigKey[*OutboxOptions](optionName, environment)
+}
@@ -0,0 +1,84 @@
+package messagepersistence
+
+import (
+	"context"
+
+	"github.com/reoden/go-NFT/pkg/core/messaging/persistmessage"
+	"github.com/reoden/go-NFT/pkg/core/messaging/producer"
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	"github.com/reoden/go-NFT/pkg/core/metadata"
+	"github.com/reoden/go-NFT/pkg/core/serializer"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/postgresgorm/helpers/gormextensions"
+)
+
+// outboxProducer stores the messages published inside a transaction in the outbox, so they are saved atomically
+// with the other changes of the transaction and published later by the outbox dispatcher.
+// Messages published outside a transaction are passed to the inner producer.
+type outboxProducer struct {
+	producer                  producer.Producer
+	messagePersistenceService persistmessage.MessagePersistenceService
+	messageSerializer         serializer.MessageSerializer
+	logger                    logger.Logger
+}
+
+func NewOutboxProducer(
+	producer producer.Producer,
+	messagePersistenceService persistmessage.MessagePersistenceService,
+	messageSerializer serializer.MessageSerializer,
+	l logger.Logger,
+) producer.Producer {
+	return &outboxProducer{
+		producer:                  producer,
+		messagePersistenceService: messagePersistenceService,
+		messageSerializer:         messageSerializer,
+		logger:                    l,
+	}
+}
+
+func (o *outboxProducer) PublishMessage(
+	ctx context.Context,
+	message types.IMessage,
+	meta metadata.Metadata,
+) error {
+	return o.PublishMessageWithTopicName(ctx, message, meta, "")
+}
+
+func (o *outboxProducer) PublishMessageWithTopicName(
+	ctx context.Context,
+	message types.IMessage,
+	meta metadata.Metadata,
+	topicOrExchangeName string,
+) error {
+	if gormextensions.GetTxFromContextIfExists(ctx) == nil {
+		return o.producer.PublishMessageWithTopicName(ctx, message, meta, topicOrExchangeName)
+	}
+
+	storeMessage, err := NewStoreMessage(
+		o.messageSerializer,
+		message,
+		meta,
+		topicOrExchangeName,
+		persistmessage.Outbox,
+	)
+	if err != nil {
+		return err
+	}
+
+	// `Add` uses the transaction of the context, so the message is committed or rolled back with the request changes
+	err = o.messagePersistenceService.Add(ctx, storeMessage)
+	if err != nil {
+		return err
+	}
+
+	o.logger.Infow(
+		"message stored in the outbox for publishing after the transaction commit",
+		logger.Fields{"MessageId": storeMessage.ID, "DataType": storeMessage.DataType},
+	)
+
+	return nil
+}
+
+func (o *outboxProducer) IsProduced(h func(message types.IMessage)) {
+	o.producer.IsProduced(h)
+}
@@ -0,0 +1,70 @@
+//go:build unit
+// +build unit
+
+package messagepersistence
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reoden/go-NFT/pkg/core/messaging/mocks"
+	"github.com/reoden/go-NFT/pkg/core/messaging/persistmessage"
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	"github.com/reoden/go-NFT/pkg/core/serializer/json"
+	defaultLogger "github.com/reoden/go-NFT/pkg/logger/defaultlogger"
+	"github.com/reoden/go-NFT/pkg/postgresgorm/helpers/gormextensions"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func Test_Outbox_Producer_Stores_Message_Inside_Transaction(t *testing.T) {
+	producer := mocks.NewProducer(t)
+	service := mocks.NewMessagePersistenceService(t)
+	outbox := NewOutboxProducer(
+		producer,
+		service,
+		json.NewDefaultMessageJsonSerializer(json.NewDefaultJsonSerializer()),
+		defaultLogger.GetLogger(),
+	)
+
+	message := &outboxMessage{Message: types.NewMessage(uuid.NewV4().String()), Data: "test data"}
+	service.On(
+		"Add",
+		mock.Anything,
+		mock.MatchedBy(func(sm *persistmessage.StoreMessage) bool {
+			return sm.ID.String() == message.MessageId &&
+				sm.DeliveryType == persistmessage.Outbox &&
+				sm.Destination == "products"
+		}),
+	).Return(nil).Once()
+
+	ctx := gormextensions.SetTxToContext(context.Background(), &gorm.DB{})
+	err := outbox.PublishMessageWithTopicName(ctx, message, nil, "products")
+
+	assert.NoError(t, err)
+	producer.AssertNotCalled(t, "PublishMessageWithTopicName", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_Outbox_Producer_Publishes_Message_Outside_Transaction(t *testing.T) {
+	producer := mocks.NewProducer(t)
+	service := mocks.NewMessagePersistenceService(t)
+	outbox := NewOutboxProducer(
+		producer,
+		service,
+		json.NewDefaultMessageJsonSerializer(json.NewDefaultJsonSerializer()),
+		defaultLogger.GetLogger(),
+	)
+
+	message := &outboxMessage{Message: types.NewMessage(uuid.NewV4().String()), Data: "test data"}
+	producer.On("PublishMessageWithTopicName", mock.Anything, message, mock.Anything, "").
+		Return(nil).
+		Once()
+
+	err := outbox.PublishMessage(context.Background(), message, nil)
+
+	assert.NoError(t, err)
+	service.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+}
@@ -2,48 +2,219 @@ package messagepersistence
 
 import (
 	"context"
-	"errors"
+	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/reoden/go-NFT/pkg/core/messaging/bus"
 	"github.com/reoden/go-NFT/pkg/core/messaging/persistmessage"
 	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	"github.com/reoden/go-NFT/pkg/core/metadata"
 	"github.com/reoden/go-NFT/pkg/core/serializer"
 	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
 	"github.com/reoden/go-NFT/pkg/logger"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
 
+	"emperror.dev/errors"
 	uuid "github.com/satori/go.uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type postgresMessagePersistenceService struct {
 	messagingDBContext *PostgresMessagePersistenceDBContext
 	messageSerializer  serializer.MessageSerializer
+	outboxOptions      *OutboxOptions
+	bus                bus.Bus
 	logger             logger.Logger
 }
 
+func NewPostgresMessageService(
+	postgresMessagePersistenceDBContext *PostgresMessagePersistenceDBContext,
+	messageSerializer serializer.MessageSerializer,
+	outboxOptions *OutboxOptions,
+	l logger.Logger,
+	bus bus.Bus,
+) persistmessage.MessagePersistenceService {
+	return &postgresMessagePersistenceService{
+		messagingDBContext: postgresMessagePersistenceDBContext,
+		messageSerializer:  messageSerializer,
+		outboxOptions:      outboxOptions,
+		bus:                bus,
+		logger:             l,
+	}
+}
+
+// Process publishes a stored outbox message through the bus and marks it as processed
 func (m *postgresMessagePersistenceService) Process(messageID string, ctx context.Context) error {
-	// TODO implement me
-	panic("implement me")
+	id, err := uuid.FromString(messageID)
+	if err != nil {
+		return customErrors.NewBadRequestErrorWrap(err, "invalid storeMessage id")
+	}
+
+	return m.messagingDBContext.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var storeMessage *persistmessage.StoreMessage
+
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", id).
+			First(&storeMessage)
+		if result.Error != nil {
+			return customErrors.NewNotFoundErrorWrap(
+				result.Error,
+				fmt.Sprintf("storeMessage with id `%s` not found in the database", messageID),
+			)
+		}
+
+		if storeMessage.DeliveryType != persistmessage.Outbox {
+			return customErrors.NewBadRequestError(
+				fmt.Sprintf("storeMessage with id `%s` is not an outbox message", messageID),
+			)
+		}
+
+		if storeMessage.MessageStatus == persistmessage.Processed {
+			return nil
+		}
+
+		return m.dispatch(ctx, tx, storeMessage)
+	})
 }
 
+// ProcessAll publishes a batch of the stored outbox messages. The batch is claimed with `FOR UPDATE SKIP LOCKED` in a
+// short transaction that leases the messages, so several dispatchers can poll the same table without publishing a
+// message twice and no row is locked while the broker is called. Each message is then published and marked on its own.
 func (m *postgresMessagePersistenceService) ProcessAll(ctx context.Context) error {
-	// TODO implement me
-	panic("implement me")
+	storeMessages, err := m.claim(ctx)
+	if err != nil {
+		return err
+	}
+
+	db := m.messagingDBContext.DB().WithContext(ctx)
+	for _, storeMessage := range storeMessages {
+		if err := m.dispatch(ctx, db, storeMessage); err != nil {
+			// failed message is retried in the next polling, the other messages of the batch are still dispatched
+			m.logger.Errorw(
+				fmt.Sprintf("error in dispatching the outbox message with id `%s`, err: %v", storeMessage.ID, err),
+				logger.Fields{"MessageId": storeMessage.ID, "RetryCount": storeMessage.RetryCount},
+			)
+		}
+	}
+
+	return nil
+}
+
+// claim leases a batch of the stored outbox messages that are not claimed by another dispatcher
+func (m *postgresMessagePersistenceService) claim(ctx context.Context) ([]*persistmessage.StoreMessage, error) {
+	var storeMessages []*persistmessage.StoreMessage
+
+	err := m.messagingDBContext.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where(
+				"message_status = ? AND delivery_type = ? AND retry_count < ? AND (claimed_until IS NULL OR claimed_until < ?)",
+				persistmessage.Stored,
+				persistmessage.Outbox,
+				m.outboxOptions.MaxRetryCount,
+				now,
+			).
+			Order("created_at").
+			Limit(m.outboxOptions.BatchSize).
+			Find(&storeMessages)
+		if result.Error != nil {
+			return errors.WrapIf(result.Error, "error in fetching the stored outbox messages")
+		}
+
+		if len(storeMessages) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, 0, len(storeMessages))
+		for _, storeMessage := range storeMessages {
+			ids = append(ids, storeMessage.ID)
+		}
+
+		claimedUntil := now.Add(m.outboxOptions.ClaimTimeoutDuration())
+		err := tx.Model(&persistmessage.StoreMessage{}).
+			Where("id IN ?", ids).
+			Update("claimed_until", claimedUntil).
+			Error
+		if err != nil {
+			return errors.WrapIf(err, "error in claiming the stored outbox messages")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return storeMessages, nil
+}
+
+// dispatch publishes the message and saves its new state on the given db, the claim of the message is released
+func (m *postgresMessagePersistenceService) dispatch(
+	ctx context.Context,
+	db *gorm.DB,
+	storeMessage *persistmessage.StoreMessage,
+) error {
+	publishErr := m.publish(ctx, storeMessage)
+	if publishErr != nil {
+		storeMessage.IncreaseRetry()
+	} else {
+		storeMessage.ChangeState(persistmessage.Processed)
+	}
+	storeMessage.ClaimedUntil = nil
+
+	err := db.Model(storeMessage).
+		Select("retry_count", "message_status", "processed_at", "claimed_until").
+		Updates(storeMessage).
+		Error
+	if err != nil {
+		return errors.WrapIf(err, "error in updating the storeMessage state")
+	}
+
+	return publishErr
+}
+
+func (m *postgresMessagePersistenceService) publish(
+	ctx context.Context,
+	storeMessage *persistmessage.StoreMessage,
+) error {
+	if m.bus == nil {
+		return errors.New("there is no bus for publishing the outbox messages")
+	}
+
+	message, err := m.messageSerializer.Deserialize(
+		[]byte(storeMessage.Data),
+		storeMessage.DataType,
+		m.messageSerializer.ContentType(),
+	)
+	if err != nil {
+		return err
+	}
+
+	meta := metadata.Metadata{}
+	if storeMessage.Metadata != "" {
+		if err := json.Unmarshal([]byte(storeMessage.Metadata), &meta); err != nil {
+			return errors.WrapIf(err, "error in deserializing the storeMessage metadata")
+		}
+	}
+
+	return m.bus.PublishMessageWithTopicName(ctx, message, meta, storeMessage.Destination)
 }
 
 func (m *postgresMessagePersistenceService) AddPublishMessage(
 	messageEnvelope types.MessageEnvelope,
 	ctx context.Context,
 ) error {
-	// TODO implement me
-	panic("implement me")
+	return m.AddMessageCore(ctx, messageEnvelope, persistmessage.Outbox)
 }
 
 func (m *postgresMessagePersistenceService) AddReceivedMessage(
 	messageEnvelope types.MessageEnvelope,
 	ctx context.Context,
 ) error {
-	// TODO implement me
-	panic("implement me")
+	return m.AddMessageCore(ctx, messageEnvelope, persistmessage.Inbox)
 }
 
 func (m *postgresMessagePersistenceService) AddMessageCore(
@@ -55,33 +226,17 @@ func (m *postgresMessagePersistenceService) AddMessageCore(
 		return errors.New("messageEnvelope.Message is nil")
 	}
 
-	var id string
-	switch message := messageEnvelope.Message.(type) {
-	case types.IMessage:
-		id = message.GeMessageId()
-	// case IInternalCommand:
-	//	id = message.InternalCommandId
-	default:
-		id = uuid.NewV4().String()
-	}
-
-	data, err := m.messageSerializer.SerializeEnvelop(messageEnvelope)
-	if err != nil {
-		return err
-	}
-
-	uuidId, err := uuid.FromString(id)
+	storeMessage, err := NewStoreMessage(
+		m.messageSerializer,
+		messageEnvelope.Message,
+		metadata.MapToMetadata(messageEnvelope.Headers),
+		"",
+		deliveryType,
+	)
 	if err != nil {
 		return err
 	}
 
-	storeMessage := persistmessage.NewStoreMessage(
-		uuidId,
-		messageEnvelope.Message.GetMessageFullTypeName(),
-		string(data.Data),
-		deliveryType,
-	)
-
 	err = m.Add(ctx, storeMessage)
 	if err != nil {
 		return err
@@ -89,21 +244,49 @@ func (m *postgresMessagePersistenceService) AddMessageCore(
 
 	m.logger.Infof(
 		"Message with id: %v and delivery type: %v saved in persistence message store",
-		id,
+		storeMessage.ID,
 		deliveryType,
 	)
 
 	return nil
 }
 
-func NewPostgresMessageService(
-	postgresMessagePersistenceDBContext *PostgresMessagePersistenceDBContext,
-	l logger.Logger,
-) persistmessage.MessagePersistenceService {
-	return &postgresMessagePersistenceService{
-		messagingDBContext: postgresMessagePersistenceDBContext,
-		logger:             l,
+// NewStoreMessage creates a StoreMessage with the serialized message and headers
+func NewStoreMessage(
+	messageSerializer serializer.MessageSerializer,
+	message types.IMessage,
+	meta metadata.Metadata,
+	destination string,
+	deliveryType persistmessage.MessageDeliveryType,
+) (*persistmessage.StoreMessage, error) {
+	id, err := uuid.FromString(message.GeMessageId())
+	if err != nil {
+		id = uuid.NewV4()
+	}
+
+	data, err := messageSerializer.Serialize(message)
+	if err != nil {
+		return nil, err
+	}
+
+	storeMessage := persistmessage.NewStoreMessage(
+		id,
+		// the embedded message type reports its own type name, so the full type name is taken from the message itself
+		typeMapper.GetFullTypeName(message),
+		string(data.Data),
+		deliveryType,
+	)
+	storeMessage.Destination = destination
+
+	if len(meta) > 0 {
+		metaJson, err := json.Marshal(meta)
+		if err != nil {
+			return nil, errors.WrapIf(err, "error in serializing the message metadata")
+		}
+		storeMessage.Metadata = string(metaJson)
 	}
+
+	return storeMessage, nil
 }
 
 func (m *postgresMessagePersistenceService) Add(
@@ -173,12 +356,11 @@ func (m *postgresMessagePersistenceService) GetAllActive(
 ) ([]*persistmessage.StoreMessage, error) {
 	var storeMessages []*persistmessage.StoreMessage
 
-	predicate := func(sm *persistmessage.StoreMessage) bool {
-		return sm.MessageStatus == persistmessage.Stored
-	}
-
 	dbContext := m.messagingDBContext.WithTxIfExists(ctx)
-	result := dbContext.DB().Where(predicate).Find(&storeMessages)
+	result := dbContext.DB().
+		Where("message_status = ?", persistmessage.Stored).
+		Order("created_at").
+		Find(&storeMessages)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -192,14 +374,22 @@ func (m *postgresMessagePersistenceService) GetByFilter(
 ) ([]*persistmessage.StoreMessage, error) {
 	var storeMessages []*persistmessage.StoreMessage
 
+	// the predicate can't be translated to sql, so it is applied on the loaded messages
 	dbContext := m.messagingDBContext.WithTxIfExists(ctx)
-	result := dbContext.DB().Where(predicate).Find(&storeMessages)
+	result := dbContext.DB().Order("created_at").Find(&storeMessages)
 
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
-	return storeMessages, nil
+	filtered := make([]*persistmessage.StoreMessage, 0, len(storeMessages))
+	for _, storeMessage := range storeMessages {
+		if predicate(storeMessage) {
+			filtered = append(filtered, storeMessage)
+		}
+	}
+
+	return filtered, nil
 }
 
 func (m *postgresMessagePersistenceService) GetById(
@@ -212,7 +402,8 @@ func (m *postgresMessagePersistenceService) GetById(
 	// https://gorm.io/docs/query.html#Struct-amp-Map-Conditions
 	// https://gorm.io/docs/query.html#Inline-Condition
 	// https://gorm.io/docs/advanced_query.html
-	result := m.messagingDBContext.DB().Find(&storeMessage, id)
+	dbContext := m.messagingDBContext.WithTxIfExists(ctx)
+	result := dbContext.DB().Where("id = ?", id).First(&storeMessage)
 	if result.Error != nil {
 		return nil, customErrors.NewNotFoundErrorWrap(
 			result.Error,
@@ -247,7 +438,7 @@ func (m *postgresMessagePersistenceService) Remove(
 
 	dbContext := m.messagingDBContext.WithTxIfExists(ctx)
 
-	result := dbContext.DB().Delete(storeMessage, id)
+	result := dbContext.DB().Where("id = ?", id).Delete(&persistmessage.StoreMessage{})
 	if result.Error != nil {
 		return false, customErrors.NewInternalServerErrorWrap(
 			result.Error,
@@ -266,14 +457,30 @@ func (m *postgresMessagePersistenceService) Remove(
 func (m *postgresMessagePersistenceService) CleanupMessages(
 	ctx context.Context,
 ) error {
-	predicate := func(sm *persistmessage.StoreMessage) bool {
-		return sm.MessageStatus == persistmessage.Processed
+	dbContext := m.messagingDBContext.WithTxIfExists(ctx)
+
+	// inbox messages are kept for the deduplication until the end of their retention period
+	result := dbContext.DB().
+		Where("message_status = ? AND delivery_type = ?", persistmessage.Processed, persistmessage.Outbox).
+		Delete(&persistmessage.StoreMessage{})
+
+	if result.Error != nil {
+		return result.Error
 	}
 
+	m.logger.Infof("Number of affected rows are: %d", result.RowsAffected)
+
+	return nil
+}
+
+func (m *postgresMessagePersistenceService) CleanupInboxMessages(
+	ctx context.Context,
+	retention time.Duration,
+) error {
 	dbContext := m.messagingDBContext.WithTxIfExists(ctx)
 
 	result := dbContext.DB().
-		Where(predicate).
+		Where("delivery_type = ? AND created_at < ?", persistmessage.Inbox, time.Now().Add(-retention)).
 		Delete(&persistmessage.StoreMessage{})
 
 	if result.Error != nil {
@@ -12,7 +12,10 @@ import (
 	"github.com/reoden/go-NFT/pkg/config"
 	"github.com/reoden/go-NFT/pkg/config/environment"
 	"github.com/reoden/go-NFT/pkg/core"
+	"github.com/reoden/go-NFT/pkg/core/messaging/mocks"
 	"github.com/reoden/go-NFT/pkg/core/messaging/persistmessage"
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	"github.com/reoden/go-NFT/pkg/core/serializer"
 	"github.com/reoden/go-NFT/pkg/logger"
 	defaultLogger "github.com/reoden/go-NFT/pkg/logger/defaultlogger"
 	"github.com/reoden/go-NFT/pkg/logger/external/fxlog"
@@ -23,6 +26,7 @@ import (
 
 	"emperror.dev/errors"
 	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/fx"
 	"go.uber.org/fx/fxtest"
@@ -39,6 +43,13 @@ type postgresMessageServiceTest struct {
 	ctx                 context.Context
 	dbFilePath          string
 	app                 *fxtest.App
+	bus                 *mocks.Bus
+	messageSerializer   serializer.MessageSerializer
+}
+
+type outboxMessage struct {
+	*types.Message
+	Data string
 }
 
 func TestPostgresMessageService(t *testing.T) {
@@ -70,6 +81,7 @@ func TestPostgresMessageService(t *testing.T) {
 func (c *postgresMessageServiceTest) SetupTest() {
 	var gormDBContext *PostgresMessagePersistenceDBContext
 	var gormOptions *postgresgorm.GormOptions
+	var messageSerializer serializer.MessageSerializer
 
 	app := fxtest.New(
 		c.T(),
@@ -89,11 +101,22 @@ func (c *postgresMessageServiceTest) SetupTest() {
 		fx.Provide(NewPostgresMessagePersistenceDBContext),
 		fx.Populate(&gormDBContext),
 		fx.Populate(&gormOptions),
+		fx.Populate(&messageSerializer),
 	).RequireStart()
 
 	c.dbContext = gormDBContext
 	c.dbFilePath = gormOptions.Dns()
 	c.app = app
+	c.ctx = context.Background()
+	c.bus = mocks.NewBus(c.T())
+	c.messageSerializer = messageSerializer
+	c.messagingRepository = NewPostgresMessageService(
+		gormDBContext,
+		messageSerializer,
+		&OutboxOptions{BatchSize: 10, MaxRetryCount: 2},
+		c.logger,
+		c.bus,
+	)
 
 	c.initDB()
 }
@@ -160,6 +183,229 @@ func (c *postgresMessageServiceTest) Test_Add() {
 	c.Assert().Equal(message.ID, m.ID)
 }
 
+func (c *postgresMessageServiceTest) Test_AddPublishMessage() {
+	message := &outboxMessage{Message: types.NewMessage(uuid.NewV4().String()), Data: "test data"}
+
+	err := c.messagingRepository.AddPublishMessage(
+		*types.NewMessageEnvelope(message, map[string]interface{}{"correlation-id": "123"}),
+		c.ctx,
+	)
+	c.Require().NoError(err)
+
+	m, err := c.messagingRepository.GetById(c.ctx, uuid.FromStringOrNil(message.MessageId))
+	c.Require().NoError(err)
+
+	c.Assert().Equal(persistmessage.Outbox, m.DeliveryType)
+	c.Assert().Equal(persistmessage.Stored, m.MessageStatus)
+	c.Assert().Equal("*messagepersistence.outboxMessage", m.DataType)
+	c.Assert().JSONEq(`{"correlation-id":"123"}`, m.Metadata)
+}
+
+func (c *postgresMessageServiceTest) Test_ProcessAll() {
+	message := &outboxMessage{Message: types.NewMessage(uuid.NewV4().String()), Data: "test data"}
+	err := c.messagingRepository.AddPublishMessage(*types.NewMessageEnvelope(message, nil), c.ctx)
+	c.Require().NoError(err)
+
+	c.bus.On(
+		"PublishMessageWithTopicName",
+		mock.Anything,
+		mock.MatchedBy(func(m *outboxMessage) bool {
+			return m.MessageId == message.MessageId && m.Data == message.Data
+		}),
+		mock.Anything,
+		"",
+	).Return(nil).Once()
+
+	err = c.messagingRepository.ProcessAll(c.ctx)
+	c.Require().NoError(err)
+
+	m, err := c.messagingRepository.GetById(c.ctx, uuid.FromStringOrNil(message.MessageId))
+	c.Require().NoError(err)
+	c.Assert().Equal(persistmessage.Processed, m.MessageStatus)
+	c.Assert().NotNil(m.ProcessedAt)
+
+	// processed messages are not dispatched again
+	err = c.messagingRepository.ProcessAll(c.ctx)
+	c.Require().NoError(err)
+	c.bus.AssertNumberOfCalls(c.T(), "PublishMessageWithTopicName", 1)
+}
+
+func (c *postgresMessageServiceTest) Test_ProcessAll_Increase_Retry_Count_On_Failure() {
+	message := &outboxMessage{Message: types.NewMessage(uuid.NewV4().String()), Data: "test data"}
+	err := c.messagingRepository.AddPublishMessage(*types.NewMessageEnvelope(message, nil), c.ctx)
+	c.Require().NoError(err)
+
+	c.bus.On("PublishMessageWithTopicName", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.New("broker is unreachable"))
+
+	// after reaching the max retry count the message is not dispatched anymore
+	for i := 0; i < 3; i++ {
+		err = c.messagingRepository.ProcessAll(c.ctx)
+		c.Require().NoError(err)
+	}
+
+	m, err := c.messagingRepository.GetById(c.ctx, uuid.FromStringOrNil(message.MessageId))
+	c.Require().NoError(err)
+	c.Assert().Equal(persistmessage.Stored, m.MessageStatus)
+	c.Assert().Equal(2, m.RetryCount)
+	c.bus.AssertNumberOfCalls(c.T(), "PublishMessageWithTopicName", 2)
+}
+
+func (c *postgresMessageServiceTest) Test_ProcessAll_Skips_Messages_Claimed_By_Another_Dispatcher() {
+	message := &outboxMessage{Message: types.NewMessage(uuid.NewV4().String()), Data: "test data"}
+	err := c.messagingRepository.AddPublishMessage(*types.NewMessageEnvelope(message, nil), c.ctx)
+	c.Require().NoError(err)
+
+	// the lease of another dispatcher is not ended yet
+	err = c.dbContext.DB().
+		Model(&persistmessage.StoreMessage{}).
+		Where("id = ?", uuid.FromStringOrNil(message.MessageId)).
+		Update("claimed_until", time.Now().Add(time.Minute)).
+		Error
+	c.Require().NoError(err)
+
+	err = c.messagingRepository.ProcessAll(c.ctx)
+	c.Require().NoError(err)
+	c.bus.AssertNotCalled(c.T(), "PublishMessageWithTopicName", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// the message of an ended lease is claimed again
+	err = c.dbContext.DB().
+		Model(&persistmessage.StoreMessage{}).
+		Where("id = ?", uuid.FromStringOrNil(message.MessageId)).
+		Update("claimed_until", time.Now().Add(-time.Minute)).
+		Error
+	c.Require().NoError(err)
+
+	c.bus.On("PublishMessageWithTopicName", mock.Anything, mock.Anything, mock.Anything, "").Return(nil).Once()
+
+	err = c.messagingRepository.ProcessAll(c.ctx)
+	c.Require().NoError(err)
+
+	m, err := c.messagingRepository.GetById(c.ctx, uuid.FromStringOrNil(message.MessageId))
+	c.Require().NoError(err)
+	c.Assert().Equal(persistmessage.Processed, m.MessageStatus)
+	c.Assert().Nil(m.ClaimedUntil)
+}
+
+func (c *postgresMessageServiceTest) Test_CleanupMessages() {
+	message := &outboxMessage{Message: types.NewMessage(uuid.NewV4().String()), Data: "test data"}
+	err := c.messagingRepository.AddPublishMessage(*types.NewMessageEnvelope(message, nil), c.ctx)
+	c.Require().NoError(err)
+
+	err = c.messagingRepository.CleanupMessages(c.ctx)
+	c.Require().NoError(err)
+
+	messages, err := c.messagingRepository.GetByFilter(
+		c.ctx,
+		func(sm *persistmessage.StoreMessage) bool { return true },
+	)
+	c.Require().NoError(err)
+
+	// seeded processed messages are removed and the stored message is kept
+	c.Assert().Len(messages, 1)
+	c.Assert().Equal(message.MessageId, messages[0].ID.String())
+}
+
+func (c *postgresMessageServiceTest) Test_Inbox_Pipeline_Skips_Duplicate_Messages() {
+	inbox := NewInboxPipelineFactory(c.dbContext, c.messageSerializer, c.logger)("products_consumer")
+
+	message := &outboxMessage{Message: types.NewMessage(uuid.NewV4().String()), Data: "test data"}
+	consumeContext := types.NewMessageConsumeContext(
+		message,
+		nil,
+		c.messageSerializer.ContentType(),
+		message.GetMessageTypeName(),
+		message.Created,
+		1,
+		message.MessageId,
+		"",
+	)
+
+	handledCount := 0
+	handler := func(ctx context.Context) error {
+		// handlers run in the transaction of the inbox record
+		c.Assert().NotNil(gormextensions.GetTxFromContextIfExists(ctx))
+		handledCount++
+
+		return nil
+	}
+
+	for i := 0; i < 2; i++ {
+		err := inbox.Handle(c.ctx, consumeContext, handler)
+		c.Require().NoError(err)
+	}
+
+	c.Assert().Equal(1, handledCount)
+
+	m, err := c.messagingRepository.GetById(c.ctx, InboxMessageId("products_consumer", message.MessageId))
+	c.Require().NoError(err)
+	c.Assert().Equal(persistmessage.Inbox, m.DeliveryType)
+	c.Assert().Equal(persistmessage.Processed, m.MessageStatus)
+
+	// other consumers handle the same message once for themselves
+	otherInbox := NewInboxPipelineFactory(c.dbContext, c.messageSerializer, c.logger)("search_consumer")
+	err = otherInbox.Handle(c.ctx, consumeContext, handler)
+	c.Require().NoError(err)
+	c.Assert().Equal(2, handledCount)
+}
+
+func (c *postgresMessageServiceTest) Test_Inbox_Pipeline_Handles_Message_Again_After_Failure() {
+	inbox := NewInboxPipelineFactory(c.dbContext, c.messageSerializer, c.logger)("products_consumer")
+
+	message := &outboxMessage{Message: types.NewMessage(uuid.NewV4().String()), Data: "test data"}
+	consumeContext := types.NewMessageConsumeContext(
+		message,
+		nil,
+		c.messageSerializer.ContentType(),
+		message.GetMessageTypeName(),
+		message.Created,
+		1,
+		message.MessageId,
+		"",
+	)
+
+	err := inbox.Handle(c.ctx, consumeContext, func(ctx context.Context) error {
+		return errors.New("handler failed")
+	})
+	c.Require().Error(err)
+
+	// the inbox record is rolled back with the failed handler
+	_, err = c.messagingRepository.GetById(c.ctx, InboxMessageId("products_consumer", message.MessageId))
+	c.Require().Error(err)
+
+	handled := false
+	err = inbox.Handle(c.ctx, consumeContext, func(ctx context.Context) error {
+		handled = true
+
+		return nil
+	})
+	c.Require().NoError(err)
+	c.Assert().True(handled)
+}
+
+func (c *postgresMessageServiceTest) Test_CleanupInboxMessages() {
+	expired := persistmessage.NewStoreMessage(uuid.NewV4(), "string", "expired", persistmessage.Inbox)
+	expired.CreatedAt = time.Now().Add(-48 * time.Hour)
+	expired.ChangeState(persistmessage.Processed)
+	recent := persistmessage.NewStoreMessage(uuid.NewV4(), "string", "recent", persistmessage.Inbox)
+	recent.ChangeState(persistmessage.Processed)
+
+	c.Require().NoError(c.messagingRepository.Add(c.ctx, expired))
+	c.Require().NoError(c.messagingRepository.Add(c.ctx, recent))
+
+	// outbox cleanup keeps the inbox messages
+	c.Require().NoError(c.messagingRepository.CleanupMessages(c.ctx))
+	_, err := c.messagingRepository.GetById(c.ctx, expired.ID)
+	c.Require().NoError(err)
+
+	c.Require().NoError(c.messagingRepository.CleanupInboxMessages(c.ctx, 24*time.Hour))
+
+	_, err = c.messagingRepository.GetById(c.ctx, expired.ID)
+	c.Assert().Error(err)
+	_, err = c.messagingRepository.GetById(c.ctx, recent.ID)
+	c.Assert().NoError(err)
+}
+
 func (c *postgresMessageServiceTest) initDB() {
 	err := migrateGorm(c.dbContext.DB())
 	c.Require().NoError(err)
@@ -0,0 +1,192 @@
+package messagepersistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/core/messaging/saga"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/postgresgorm/contracts"
+	"github.com/reoden/go-NFT/pkg/postgresgorm/helpers/gormextensions"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type postgresSagaStore struct {
+	messagingDBContext *PostgresMessagePersistenceDBContext
+}
+
+// NewPostgresSagaStore creates a saga store on the messaging database, the store uses the transaction of the context
+// when it exists, so the saga state is saved with the outbox messages of the saga.
+func NewPostgresSagaStore(postgresMessagePersistenceDBContext *PostgresMessagePersistenceDBContext) saga.SagaStore {
+	return &postgresSagaStore{messagingDBContext: postgresMessagePersistenceDBContext}
+}
+
+func (s *postgresSagaStore) Get(ctx context.Context, sagaType string, sagaId string) (*saga.StoredSaga, error) {
+	var storedSaga *saga.StoredSaga
+
+	dbContext := s.messagingDBContext.WithTxIfExists(ctx)
+	result := dbContext.DB().
+		WithContext(ctx).
+		Where("saga_type = ? AND saga_id = ?", sagaType, sagaId).
+		First(&storedSaga)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, errors.WithStack(saga.ErrSagaNotFound)
+	}
+	if result.Error != nil {
+		return nil, errors.WrapIf(result.Error, "error in fetching the saga")
+	}
+
+	return storedSaga, nil
+}
+
+func (s *postgresSagaStore) Save(ctx context.Context, storedSaga *saga.StoredSaga) error {
+	dbContext := s.messagingDBContext.WithTxIfExists(ctx)
+
+	now := time.Now()
+	version := storedSaga.Version
+
+	if version == 0 {
+		storedSaga.Version = 1
+		storedSaga.CreatedAt = now
+		storedSaga.UpdatedAt = now
+
+		// https://gorm.io/docs/create.html
+		result := dbContext.DB().WithContext(ctx).Create(storedSaga)
+		if result.Error != nil {
+			storedSaga.Version = version
+
+			// only an existing saga with the same id is a conflict, the other errors are returned as they are
+			if gormextensions.IsUniqueViolation(dbContext.DB(), result.Error) {
+				return errors.WithStack(saga.ErrConcurrencyConflict)
+			}
+
+			return customErrors.NewInternalServerErrorWrap(
+				result.Error,
+				fmt.Sprintf("error in inserting the saga with id `%s`", storedSaga.SagaId),
+			)
+		}
+
+		return nil
+	}
+
+	// the version condition makes the update fail when another message changed the saga after it was loaded
+	result := dbContext.DB().
+		WithContext(ctx).
+		Model(&saga.StoredSaga{}).
+		Where("saga_type = ? AND saga_id = ? AND version = ?", storedSaga.SagaType, storedSaga.SagaId, version).
+		Updates(map[string]interface{}{
+			"status":          storedSaga.Status,
+			"state":           storedSaga.State,
+			"completed_steps": storedSaga.CompletedSteps,
+			"failure_reason":  storedSaga.FailureReason,
+			"version":         version + 1,
+			"updated_at":      now,
+		})
+	if result.Error != nil {
+		return customErrors.NewInternalServerErrorWrap(
+			result.Error,
+			fmt.Sprintf("error in updating the saga with id `%s`", storedSaga.SagaId),
+		)
+	}
+	if result.RowsAffected == 0 {
+		return errors.WithStack(saga.ErrConcurrencyConflict)
+	}
+
+	storedSaga.Version = version + 1
+	storedSaga.UpdatedAt = now
+
+	return nil
+}
+
+func (s *postgresSagaStore) AddTimeout(ctx context.Context, timeout *saga.StoredTimeout) error {
+	dbContext := s.messagingDBContext.WithTxIfExists(ctx)
+
+	result := dbContext.DB().WithContext(ctx).Create(timeout)
+	if result.Error != nil {
+		return customErrors.NewConflictErrorWrap(
+			result.Error,
+			fmt.Sprintf("saga timeout with id `%s` already exists", timeout.ID),
+		)
+	}
+
+	return nil
+}
+
+// ClaimDueTimeouts claims the due timeouts with `FOR UPDATE SKIP LOCKED` in a short transaction, so the dispatchers of
+// several instances never publish the same timeout at the same time
+func (s *postgresSagaStore) ClaimDueTimeouts(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+	claimFor time.Duration,
+) ([]*saga.StoredTimeout, error) {
+	var timeouts []*saga.StoredTimeout
+
+	err := s.messagingDBContext.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("due_at <= ? AND (claimed_until IS NULL OR claimed_until < ?)", now, now).
+			Order("due_at").
+			Limit(limit).
+			Find(&timeouts)
+		if result.Error != nil {
+			return errors.WrapIf(result.Error, "error in fetching the due saga timeouts")
+		}
+
+		if len(timeouts) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, 0, len(timeouts))
+		for _, timeout := range timeouts {
+			ids = append(ids, timeout.ID)
+		}
+
+		claimedUntil := now.Add(claimFor)
+		err := tx.Model(&saga.StoredTimeout{}).Where("id IN ?", ids).Update("claimed_until", claimedUntil).Error
+		if err != nil {
+			return errors.WrapIf(err, "error in claiming the due saga timeouts")
+		}
+
+		for _, timeout := range timeouts {
+			timeout.ClaimedUntil = &claimedUntil
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return timeouts, nil
+}
+
+func (s *postgresSagaStore) RemoveTimeout(ctx context.Context, id uuid.UUID) error {
+	dbContext := s.messagingDBContext.WithTxIfExists(ctx)
+
+	result := dbContext.DB().WithContext(ctx).Where("id = ?", id).Delete(&saga.StoredTimeout{})
+	if result.Error != nil {
+		return customErrors.NewInternalServerErrorWrap(
+			result.Error,
+			fmt.Sprintf("error in removing the saga timeout with id `%s`", id),
+		)
+	}
+
+	return nil
+}
+
+// RunInTx runs the action in a transaction of the messaging database, the transaction of the context is used when it
+// exists
+func (s *postgresSagaStore) RunInTx(ctx context.Context, action func(ctx context.Context) error) error {
+	if gormextensions.GetTxFromContextIfExists(ctx) != nil {
+		return action(ctx)
+	}
+
+	return s.messagingDBContext.RunInTx(ctx, func(ctx context.Context, _ contracts.GormDBContext) error {
+		return action(ctx)
+	})
+}
@@ -0,0 +1,174 @@
+//go:build unit
+// +build unit
+
+package messagepersistence
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/config"
+	"github.com/reoden/go-NFT/pkg/config/environment"
+	"github.com/reoden/go-NFT/pkg/core"
+	"github.com/reoden/go-NFT/pkg/core/messaging/saga"
+	"github.com/reoden/go-NFT/pkg/logger/external/fxlog"
+	"github.com/reoden/go-NFT/pkg/logger/zap"
+	"github.com/reoden/go-NFT/pkg/postgresgorm"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+type postgresSagaStoreTest struct {
+	suite.Suite
+	dbContext  *PostgresMessagePersistenceDBContext
+	store      saga.SagaStore
+	ctx        context.Context
+	dbFilePath string
+	app        *fxtest.App
+}
+
+func TestPostgresSagaStore(t *testing.T) {
+	suite.Run(t, &postgresSagaStoreTest{})
+}
+
+func (c *postgresSagaStoreTest) SetupTest() {
+	var gormDBContext *PostgresMessagePersistenceDBContext
+	var gormOptions *postgresgorm.GormOptions
+
+	app := fxtest.New(
+		c.T(),
+		config.ModuleFunc(environment.Test),
+		zap.Module,
+		fxlog.FxLogger,
+		core.Module,
+		postgresgorm.Module,
+		fx.Decorate(
+			func(cfg *postgresgorm.GormOptions) (*postgresgorm.GormOptions, error) {
+				// using sql-lite with a database file
+				cfg.UseSQLLite = true
+
+				return cfg, nil
+			},
+		),
+		fx.Provide(NewPostgresMessagePersistenceDBContext),
+		fx.Populate(&gormDBContext),
+		fx.Populate(&gormOptions),
+	).RequireStart()
+
+	c.dbContext = gormDBContext
+	c.dbFilePath = gormOptions.Dns()
+	c.app = app
+	c.ctx = context.Background()
+	c.store = NewPostgresSagaStore(gormDBContext)
+
+	err := gormDBContext.DB().AutoMigrate(&saga.StoredSaga{}, &saga.StoredTimeout{})
+	c.Require().NoError(err)
+}
+
+func (c *postgresSagaStoreTest) TearDownTest() {
+	sqldb, _ := c.dbContext.DB().DB()
+	c.Require().NoError(sqldb.Close())
+
+	// removing sql-lite file
+	c.Require().NoError(os.Remove(c.dbFilePath))
+
+	c.app.RequireStop()
+}
+
+func (c *postgresSagaStoreTest) Test_Save_And_Get() {
+	stored := &saga.StoredSaga{SagaId: "1", SagaType: "purchase", Status: saga.Running, State: `{"Amount":20}`}
+	c.Require().NoError(c.store.Save(c.ctx, stored))
+	c.Equal(1, stored.Version)
+
+	stored.Status = saga.Completed
+	c.Require().NoError(c.store.Save(c.ctx, stored))
+	c.Equal(2, stored.Version)
+
+	loaded, err := c.store.Get(c.ctx, "purchase", "1")
+	c.Require().NoError(err)
+	c.Equal(saga.Completed, loaded.Status)
+	c.Equal(`{"Amount":20}`, loaded.State)
+	c.Equal(2, loaded.Version)
+}
+
+func (c *postgresSagaStoreTest) Test_Get_Not_Found() {
+	_, err := c.store.Get(c.ctx, "purchase", "unknown")
+
+	c.ErrorIs(err, saga.ErrSagaNotFound)
+}
+
+func (c *postgresSagaStoreTest) Test_Save_Stale_Version() {
+	stored := &saga.StoredSaga{SagaId: "1", SagaType: "purchase", Status: saga.Running}
+	c.Require().NoError(c.store.Save(c.ctx, stored))
+
+	stale := &saga.StoredSaga{SagaId: "1", SagaType: "purchase", Status: saga.Running}
+	c.ErrorIs(c.store.Save(c.ctx, stale), saga.ErrConcurrencyConflict)
+
+	stale.Version = 1
+	c.Require().NoError(c.store.Save(c.ctx, stale))
+	c.ErrorIs(c.store.Save(c.ctx, stored), saga.ErrConcurrencyConflict)
+}
+
+func (c *postgresSagaStoreTest) Test_Due_Timeouts() {
+	due := &saga.StoredTimeout{ID: uuid.NewV4(), SagaId: "1", SagaType: "purchase", DueAt: time.Now().Add(-time.Minute)}
+	later := &saga.StoredTimeout{ID: uuid.NewV4(), SagaId: "2", SagaType: "purchase", DueAt: time.Now().Add(time.Hour)}
+	c.Require().NoError(c.store.AddTimeout(c.ctx, due))
+	c.Require().NoError(c.store.AddTimeout(c.ctx, later))
+
+	timeouts, err := c.store.ClaimDueTimeouts(c.ctx, time.Now(), 10, time.Minute)
+	c.Require().NoError(err)
+	c.Require().Len(timeouts, 1)
+	c.Equal(due.ID, timeouts[0].ID)
+
+	// a claimed timeout is not returned to the other dispatchers until its claim is passed
+	timeouts, err = c.store.ClaimDueTimeouts(c.ctx, time.Now(), 10, time.Minute)
+	c.Require().NoError(err)
+	c.Empty(timeouts)
+
+	timeouts, err = c.store.ClaimDueTimeouts(c.ctx, time.Now().Add(2*time.Minute), 10, time.Minute)
+	c.Require().NoError(err)
+	c.Require().Len(timeouts, 1)
+
+	c.Require().NoError(c.store.RemoveTimeout(c.ctx, due.ID))
+
+	timeouts, err = c.store.ClaimDueTimeouts(c.ctx, time.Now().Add(time.Hour), 10, time.Minute)
+	c.Require().NoError(err)
+	c.Require().Len(timeouts, 1)
+	c.Equal(later.ID, timeouts[0].ID)
+}
+
+func (c *postgresSagaStoreTest) Test_Save_Returns_Database_Errors_As_They_Are() {
+	ctx, cancel := context.WithCancel(c.ctx)
+	cancel()
+
+	err := c.store.Save(ctx, &saga.StoredSaga{SagaId: "1", SagaType: "purchase", Status: saga.Running})
+
+	c.Require().Error(err)
+	c.NotErrorIs(err, saga.ErrConcurrencyConflict)
+}
+
+func (c *postgresSagaStoreTest) Test_RunInTx_Rolls_Back_The_Saga_And_Its_Timeouts() {
+	err := c.store.RunInTx(c.ctx, func(ctx context.Context) error {
+		stored := &saga.StoredSaga{SagaId: "1", SagaType: "purchase", Status: saga.Running}
+		c.Require().NoError(c.store.Save(ctx, stored))
+
+		timeout := &saga.StoredTimeout{ID: uuid.NewV4(), SagaId: "1", SagaType: "purchase", DueAt: time.Now()}
+		c.Require().NoError(c.store.AddTimeout(ctx, timeout))
+
+		return errors.New("publish failed")
+	})
+	c.Require().Error(err)
+
+	_, err = c.store.Get(c.ctx, "purchase", "1")
+	c.ErrorIs(err, saga.ErrSagaNotFound)
+
+	timeouts, err := c.store.ClaimDueTimeouts(c.ctx, time.Now().Add(time.Hour), 10, time.Minute)
+	c.Require().NoError(err)
+	c.Empty(timeouts)
+}
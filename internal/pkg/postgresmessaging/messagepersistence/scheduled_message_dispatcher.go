@@ -0,0 +1,205 @@
+package messagepersistence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	messageHeader "github.com/reoden/go-NFT/pkg/core/messaging/messageheader"
+	"github.com/reoden/go-NFT/pkg/core/messaging/producer"
+	"github.com/reoden/go-NFT/pkg/core/messaging/scheduling"
+	"github.com/reoden/go-NFT/pkg/core/metadata"
+	"github.com/reoden/go-NFT/pkg/core/serializer"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/postgresgorm/helpers/gormextensions"
+	"github.com/reoden/go-NFT/pkg/web"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ScheduledMessageDispatcher a background worker that publishes the due scheduled messages
+type ScheduledMessageDispatcher interface {
+	web.Worker
+	// DispatchDueMessages publishes the messages that are due now, the one time messages are removed and the
+	// recurring messages are moved to their next due time
+	DispatchDueMessages(ctx context.Context) error
+}
+
+type scheduledMessageDispatcher struct {
+	web.Worker
+	messagingDBContext *PostgresMessagePersistenceDBContext
+	producer           producer.Producer
+	messageSerializer  serializer.MessageSerializer
+	schedulerOptions   *SchedulerOptions
+	logger             logger.Logger
+}
+
+func NewScheduledMessageDispatcher(
+	postgresMessagePersistenceDBContext *PostgresMessagePersistenceDBContext,
+	producer producer.Producer,
+	messageSerializer serializer.MessageSerializer,
+	schedulerOptions *SchedulerOptions,
+	l logger.Logger,
+) ScheduledMessageDispatcher {
+	d := &scheduledMessageDispatcher{
+		messagingDBContext: postgresMessagePersistenceDBContext,
+		producer:           producer,
+		messageSerializer:  messageSerializer,
+		schedulerOptions:   schedulerOptions,
+		logger:             l,
+	}
+
+	d.Worker = web.NewBackgroundWorker(
+		func(ctx context.Context) error {
+			pollingTicker := time.NewTicker(schedulerOptions.PollingIntervalDuration())
+			defer pollingTicker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-pollingTicker.C:
+					if err := d.DispatchDueMessages(ctx); err != nil && ctx.Err() == nil {
+						l.Error(fmt.Sprintf("[ScheduledMessageDispatcher.DispatchDueMessages] error in dispatching the scheduled messages: %v", err))
+					}
+				}
+			}
+		},
+		nil,
+	)
+
+	return d
+}
+
+// scheduledMessageSavePoint the savepoint of each dispatched message, the changes of a failed message are rolled back
+// without the changes of the other messages of the batch
+const scheduledMessageSavePoint = "scheduled_message"
+
+// DispatchDueMessages locks the due rows with `FOR UPDATE SKIP LOCKED`, so each due message is published by only one
+// of the instances of the service, also for the recurring messages. A failed message records its attempt and error
+// and is moved back by the retry delay, so it doesn't block the next batches.
+func (d *scheduledMessageDispatcher) DispatchDueMessages(ctx context.Context) error {
+	return d.messagingDBContext.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var scheduledMessages []*scheduling.ScheduledMessage
+
+		now := time.Now()
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("due_at <= ?", now).
+			Order("due_at").
+			Limit(d.schedulerOptions.BatchSize).
+			Find(&scheduledMessages)
+		if result.Error != nil {
+			return errors.WrapIf(result.Error, "error in fetching the due scheduled messages")
+		}
+
+		// an outbox producer stores the messages in the same transaction with the new state of the schedules
+		txCtx := gormextensions.SetTxToContext(ctx, tx)
+
+		for _, scheduledMessage := range scheduledMessages {
+			if err := tx.SavePoint(scheduledMessageSavePoint).Error; err != nil {
+				return errors.WrapIf(err, "error in creating the savepoint of the scheduled message")
+			}
+
+			err := d.dispatch(txCtx, tx, scheduledMessage, now)
+			if err == nil {
+				continue
+			}
+
+			d.logger.Errorw(
+				fmt.Sprintf("error in dispatching the scheduled message with id `%s`, err: %v", scheduledMessage.ScheduleId, err),
+				logger.Fields{"ScheduleId": scheduledMessage.ScheduleId, "MessageType": scheduledMessage.MessageType},
+			)
+
+			if err := tx.RollbackTo(scheduledMessageSavePoint).Error; err != nil {
+				return errors.WrapIf(err, "error in rolling back the savepoint of the scheduled message")
+			}
+			// the failed message is retried after its retry delay, the other messages of the batch are still dispatched
+			if err := d.recordFailure(tx, scheduledMessage, err, now); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (d *scheduledMessageDispatcher) recordFailure(
+	tx *gorm.DB,
+	scheduledMessage *scheduling.ScheduledMessage,
+	dispatchErr error,
+	now time.Time,
+) error {
+	attempts := scheduledMessage.Attempts + 1
+
+	err := tx.Model(scheduledMessage).
+		Updates(map[string]interface{}{
+			"attempts":   attempts,
+			"last_error": dispatchErr.Error(),
+			"due_at":     now.Add(d.schedulerOptions.RetryDelayDuration(attempts)),
+			"updated_at": now,
+		}).Error
+	if err != nil {
+		return errors.WrapIf(err, "error in recording the failure of the scheduled message")
+	}
+
+	return nil
+}
+
+func (d *scheduledMessageDispatcher) dispatch(
+	ctx context.Context,
+	tx *gorm.DB,
+	scheduledMessage *scheduling.ScheduledMessage,
+	now time.Time,
+) error {
+	message, err := d.messageSerializer.Deserialize(
+		[]byte(scheduledMessage.Data),
+		scheduledMessage.MessageType,
+		scheduledMessage.ContentType,
+	)
+	if err != nil {
+		return err
+	}
+
+	meta := metadata.Metadata{}
+	if scheduledMessage.Metadata != "" {
+		if err := json.Unmarshal([]byte(scheduledMessage.Metadata), &meta); err != nil {
+			return errors.WrapIf(err, "error in deserializing the scheduled message metadata")
+		}
+	}
+
+	if !scheduledMessage.IsRecurring() {
+		if err := d.producer.PublishMessage(ctx, message, meta); err != nil {
+			return err
+		}
+
+		return tx.Delete(scheduledMessage).Error
+	}
+
+	// each publish of a recurring message is a new message for the consumers and their inbox
+	messageHeader.SetMessageId(meta, uuid.NewV4().String())
+	messageHeader.SetMessageCreated(meta, now)
+
+	if err := d.producer.PublishMessage(ctx, message, meta); err != nil {
+		return err
+	}
+
+	// the missed times of a stopped service are skipped, the next due time is always in the future
+	dueAt, err := scheduling.NextDueAt(scheduledMessage.CronExpression, now)
+	if err != nil {
+		return err
+	}
+
+	return tx.Model(scheduledMessage).
+		Updates(map[string]interface{}{
+			"due_at":            dueAt,
+			"last_published_at": now,
+			"attempts":          0,
+			"last_error":        "",
+			"updated_at":        now,
+		}).
+		Error
+}
@@ -0,0 +1,180 @@
+package messagepersistence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/core/messaging/producer"
+	"github.com/reoden/go-NFT/pkg/core/messaging/scheduling"
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	"github.com/reoden/go-NFT/pkg/core/messaging/utils"
+	"github.com/reoden/go-NFT/pkg/core/metadata"
+	"github.com/reoden/go-NFT/pkg/core/serializer"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/logger"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+	"gorm.io/gorm/clause"
+)
+
+// scheduledProducer stores the scheduled messages in the messaging database, the scheduled message dispatcher
+// publishes them through the inner producer on their due time.
+// The schedules are stored with the transaction of the context, so they are rolled back with the request changes.
+type scheduledProducer struct {
+	producer.Producer
+	messagingDBContext *PostgresMessagePersistenceDBContext
+	messageSerializer  serializer.MessageSerializer
+	logger             logger.Logger
+}
+
+func NewScheduledProducer(
+	producer producer.Producer,
+	postgresMessagePersistenceDBContext *PostgresMessagePersistenceDBContext,
+	messageSerializer serializer.MessageSerializer,
+	l logger.Logger,
+) producer.ScheduledProducer {
+	return &scheduledProducer{
+		Producer:           producer,
+		messagingDBContext: postgresMessagePersistenceDBContext,
+		messageSerializer:  messageSerializer,
+		logger:             l,
+	}
+}
+
+func (s *scheduledProducer) PublishAt(
+	ctx context.Context,
+	message types.IMessage,
+	meta metadata.Metadata,
+	at time.Time,
+) (string, error) {
+	scheduledMessage, err := s.newScheduledMessage(uuid.NewV4().String(), message, meta)
+	if err != nil {
+		return "", err
+	}
+	scheduledMessage.DueAt = at
+
+	dbContext := s.messagingDBContext.WithTxIfExists(ctx)
+
+	result := dbContext.DB().WithContext(ctx).Create(scheduledMessage)
+	if result.Error != nil {
+		return "", errors.WrapIf(result.Error, "error in storing the scheduled message")
+	}
+
+	s.logger.Infow(
+		fmt.Sprintf("message scheduled for publishing at %s", at.Format(time.RFC3339)),
+		logger.Fields{"ScheduleId": scheduledMessage.ScheduleId, "MessageType": scheduledMessage.MessageType},
+	)
+
+	return scheduledMessage.ScheduleId, nil
+}
+
+func (s *scheduledProducer) PublishAfter(
+	ctx context.Context,
+	message types.IMessage,
+	meta metadata.Metadata,
+	delay time.Duration,
+) (string, error) {
+	return s.PublishAt(ctx, message, meta, time.Now().Add(delay))
+}
+
+func (s *scheduledProducer) PublishRecurring(
+	ctx context.Context,
+	scheduleId string,
+	cronExpression string,
+	message types.IMessage,
+	meta metadata.Metadata,
+) error {
+	if scheduleId == "" {
+		return customErrors.NewBadRequestError("schedule id of the recurring message is required")
+	}
+
+	dueAt, err := scheduling.NextDueAt(cronExpression, time.Now())
+	if err != nil {
+		return customErrors.NewBadRequestErrorWrap(err, "invalid recurring message schedule")
+	}
+
+	scheduledMessage, err := s.newScheduledMessage(scheduleId, message, meta)
+	if err != nil {
+		return err
+	}
+	scheduledMessage.CronExpression = cronExpression
+	scheduledMessage.DueAt = dueAt
+
+	dbContext := s.messagingDBContext.WithTxIfExists(ctx)
+
+	// the instances of a service register the same schedule on their startup, so the existing schedule is replaced
+	result := dbContext.DB().WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "schedule_id"}},
+		DoUpdates: clause.AssignmentColumns(
+			[]string{
+				"message_type",
+				"content_type",
+				"data",
+				"metadata",
+				"cron_expression",
+				"due_at",
+				"attempts",
+				"last_error",
+				"updated_at",
+			},
+		),
+	}).Create(scheduledMessage)
+	if result.Error != nil {
+		return errors.WrapIf(result.Error, "error in storing the recurring message")
+	}
+
+	return nil
+}
+
+func (s *scheduledProducer) CancelScheduled(ctx context.Context, scheduleId string) error {
+	dbContext := s.messagingDBContext.WithTxIfExists(ctx)
+
+	result := dbContext.DB().
+		WithContext(ctx).
+		Where("schedule_id = ?", scheduleId).
+		Delete(&scheduling.ScheduledMessage{})
+	if result.Error != nil {
+		return errors.WrapIf(result.Error, "error in removing the scheduled message")
+	}
+	if result.RowsAffected == 0 {
+		return customErrors.NewNotFoundErrorWrap(
+			scheduling.ErrScheduleNotFound,
+			fmt.Sprintf("scheduled message with id `%s` not found", scheduleId),
+		)
+	}
+
+	return nil
+}
+
+func (s *scheduledProducer) newScheduledMessage(
+	scheduleId string,
+	message types.IMessage,
+	meta metadata.Metadata,
+) (*scheduling.ScheduledMessage, error) {
+	data, err := s.messageSerializer.Serialize(message)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in serializing the scheduled message")
+	}
+
+	scheduledMessage := &scheduling.ScheduledMessage{
+		ScheduleId:  scheduleId,
+		MessageType: utils.GetMessageTypeName(message),
+		ContentType: data.ContentType,
+		Data:        string(data.Data),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if len(meta) > 0 {
+		metaJson, err := json.Marshal(meta)
+		if err != nil {
+			return nil, errors.WrapIf(err, "error in serializing the scheduled message metadata")
+		}
+		scheduledMessage.Metadata = string(metaJson)
+	}
+
+	return scheduledMessage, nil
+}
@@ -0,0 +1,234 @@
+//go:build unit
+// +build unit
+
+package messagepersistence
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/config"
+	"github.com/reoden/go-NFT/pkg/config/environment"
+	"github.com/reoden/go-NFT/pkg/core"
+	messageHeader "github.com/reoden/go-NFT/pkg/core/messaging/messageheader"
+	"github.com/reoden/go-NFT/pkg/core/messaging/mocks"
+	"github.com/reoden/go-NFT/pkg/core/messaging/producer"
+	"github.com/reoden/go-NFT/pkg/core/messaging/scheduling"
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	"github.com/reoden/go-NFT/pkg/core/metadata"
+	"github.com/reoden/go-NFT/pkg/core/serializer"
+	defaultLogger "github.com/reoden/go-NFT/pkg/logger/defaultlogger"
+	"github.com/reoden/go-NFT/pkg/logger/external/fxlog"
+	"github.com/reoden/go-NFT/pkg/logger/zap"
+	"github.com/reoden/go-NFT/pkg/postgresgorm"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+type scheduledProducerTest struct {
+	suite.Suite
+	dbContext         *PostgresMessagePersistenceDBContext
+	producer          *mocks.Producer
+	scheduledProducer producer.ScheduledProducer
+	dispatcher        ScheduledMessageDispatcher
+	ctx               context.Context
+	dbFilePath        string
+	app               *fxtest.App
+}
+
+func TestScheduledProducer(t *testing.T) {
+	suite.Run(t, &scheduledProducerTest{})
+}
+
+func (c *scheduledProducerTest) SetupTest() {
+	var gormDBContext *PostgresMessagePersistenceDBContext
+	var gormOptions *postgresgorm.GormOptions
+	var messageSerializer serializer.MessageSerializer
+
+	app := fxtest.New(
+		c.T(),
+		config.ModuleFunc(environment.Test),
+		zap.Module,
+		fxlog.FxLogger,
+		core.Module,
+		postgresgorm.Module,
+		fx.Decorate(
+			func(cfg *postgresgorm.GormOptions) (*postgresgorm.GormOptions, error) {
+				// using sql-lite with a database file
+				cfg.UseSQLLite = true
+
+				return cfg, nil
+			},
+		),
+		fx.Provide(NewPostgresMessagePersistenceDBContext),
+		fx.Populate(&gormDBContext),
+		fx.Populate(&gormOptions),
+		fx.Populate(&messageSerializer),
+	).RequireStart()
+
+	c.dbContext = gormDBContext
+	c.dbFilePath = gormOptions.Dns()
+	c.app = app
+	c.ctx = context.Background()
+	c.producer = mocks.NewProducer(c.T())
+	c.scheduledProducer = NewScheduledProducer(c.producer, gormDBContext, messageSerializer, defaultLogger.GetLogger())
+	c.dispatcher = NewScheduledMessageDispatcher(
+		gormDBContext,
+		c.producer,
+		messageSerializer,
+		&SchedulerOptions{BatchSize: 10},
+		defaultLogger.GetLogger(),
+	)
+
+	err := gormDBContext.DB().AutoMigrate(&scheduling.ScheduledMessage{})
+	c.Require().NoError(err)
+}
+
+func (c *scheduledProducerTest) TearDownTest() {
+	sqldb, _ := c.dbContext.DB().DB()
+	c.Require().NoError(sqldb.Close())
+
+	// removing sql-lite file
+	c.Require().NoError(os.Remove(c.dbFilePath))
+
+	c.app.RequireStop()
+}
+
+func (c *scheduledProducerTest) Test_Publish_After_Delay() {
+	message := &outboxMessage{Message: types.NewMessage(uuid.NewV4().String()), Data: "order timeout"}
+
+	scheduleId, err := c.scheduledProducer.PublishAfter(c.ctx, message, nil, -time.Second)
+	c.Require().NoError(err)
+	c.NotEmpty(scheduleId)
+
+	c.producer.On(
+		"PublishMessage",
+		mock.Anything,
+		mock.MatchedBy(func(m *outboxMessage) bool {
+			return m.MessageId == message.MessageId && m.Data == "order timeout"
+		}),
+		mock.Anything,
+	).Return(nil).Once()
+
+	c.Require().NoError(c.dispatcher.DispatchDueMessages(c.ctx))
+	c.Equal(int64(0), c.countScheduledMessages())
+}
+
+func (c *scheduledProducerTest) Test_Publish_At_Future_Time_Is_Not_Dispatched() {
+	message := &outboxMessage{Message: types.NewMessage(uuid.NewV4().String()), Data: "sale start"}
+
+	_, err := c.scheduledProducer.PublishAt(c.ctx, message, nil, time.Now().Add(time.Hour))
+	c.Require().NoError(err)
+
+	c.Require().NoError(c.dispatcher.DispatchDueMessages(c.ctx))
+
+	c.producer.AssertNotCalled(c.T(), "PublishMessage", mock.Anything, mock.Anything, mock.Anything)
+	c.Equal(int64(1), c.countScheduledMessages())
+}
+
+func (c *scheduledProducerTest) Test_Cancel_Scheduled() {
+	message := &outboxMessage{Message: types.NewMessage(uuid.NewV4().String()), Data: "order timeout"}
+
+	scheduleId, err := c.scheduledProducer.PublishAfter(c.ctx, message, nil, -time.Second)
+	c.Require().NoError(err)
+
+	c.Require().NoError(c.scheduledProducer.CancelScheduled(c.ctx, scheduleId))
+	c.Require().NoError(c.dispatcher.DispatchDueMessages(c.ctx))
+
+	c.producer.AssertNotCalled(c.T(), "PublishMessage", mock.Anything, mock.Anything, mock.Anything)
+	c.ErrorIs(c.scheduledProducer.CancelScheduled(c.ctx, scheduleId), scheduling.ErrScheduleNotFound)
+}
+
+func (c *scheduledProducerTest) Test_Publish_Recurring() {
+	message := &outboxMessage{Message: types.NewMessage(uuid.NewV4().String()), Data: "daily report"}
+
+	c.Require().NoError(c.scheduledProducer.PublishRecurring(c.ctx, "daily-report", "0 8 * * *", message, nil))
+	// registering the same schedule again replaces it
+	c.Require().NoError(c.scheduledProducer.PublishRecurring(c.ctx, "daily-report", "0 9 * * *", message, nil))
+	c.Equal(int64(1), c.countScheduledMessages())
+
+	// moving the schedule to its due time
+	c.Require().NoError(
+		c.dbContext.DB().
+			Model(&scheduling.ScheduledMessage{}).
+			Where("schedule_id = ?", "daily-report").
+			Update("due_at", time.Now().Add(-time.Second)).
+			Error,
+	)
+
+	c.producer.On(
+		"PublishMessage",
+		mock.Anything,
+		mock.Anything,
+		mock.MatchedBy(func(meta metadata.Metadata) bool {
+			return messageHeader.GetMessageId(meta) != message.MessageId
+		}),
+	).Return(nil).Once()
+
+	c.Require().NoError(c.dispatcher.DispatchDueMessages(c.ctx))
+
+	var scheduledMessage *scheduling.ScheduledMessage
+	c.Require().NoError(c.dbContext.DB().Where("schedule_id = ?", "daily-report").First(&scheduledMessage).Error)
+	c.Equal("0 9 * * *", scheduledMessage.CronExpression)
+	c.True(scheduledMessage.DueAt.After(time.Now()))
+	c.NotNil(scheduledMessage.LastPublishedAt)
+}
+
+func (c *scheduledProducerTest) Test_Failed_Message_Is_Retried_Later_Without_Blocking_The_Batch() {
+	failed := &scheduling.ScheduledMessage{
+		ScheduleId:  uuid.NewV4().String(),
+		MessageType: "*unknownMessage",
+		ContentType: "application/json",
+		Data:        "{}",
+		DueAt:       time.Now().Add(-time.Minute),
+	}
+	c.Require().NoError(c.dbContext.DB().Create(failed).Error)
+
+	message := &outboxMessage{Message: types.NewMessage(uuid.NewV4().String()), Data: "order timeout"}
+	_, err := c.scheduledProducer.PublishAfter(c.ctx, message, nil, -time.Second)
+	c.Require().NoError(err)
+
+	c.producer.On("PublishMessage", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	c.Require().NoError(c.dispatcher.DispatchDueMessages(c.ctx))
+	c.Equal(int64(1), c.countScheduledMessages())
+
+	var scheduledMessage *scheduling.ScheduledMessage
+	c.Require().NoError(c.dbContext.DB().Where("schedule_id = ?", failed.ScheduleId).First(&scheduledMessage).Error)
+	c.Equal(1, scheduledMessage.Attempts)
+	c.NotEmpty(scheduledMessage.LastError)
+	c.True(scheduledMessage.DueAt.After(time.Now()))
+
+	// the failed message is not due until its retry delay
+	c.Require().NoError(c.dispatcher.DispatchDueMessages(c.ctx))
+	c.producer.AssertNumberOfCalls(c.T(), "PublishMessage", 1)
+}
+
+func (c *scheduledProducerTest) Test_Retry_Delay_Is_Doubled_Up_To_Max() {
+	options := &SchedulerOptions{RetryDelay: 1000, MaxRetryDelay: 5000}
+
+	c.Equal(time.Second, options.RetryDelayDuration(1))
+	c.Equal(4*time.Second, options.RetryDelayDuration(3))
+	c.Equal(5*time.Second, options.RetryDelayDuration(10))
+}
+
+func (c *scheduledProducerTest) Test_Publish_Recurring_Invalid_Cron() {
+	message := &outboxMessage{Message: types.NewMessage(uuid.NewV4().String()), Data: "daily report"}
+
+	err := c.scheduledProducer.PublishRecurring(c.ctx, "daily-report", "every day", message, nil)
+
+	c.Error(err)
+}
+
+func (c *scheduledProducerTest) countScheduledMessages() int64 {
+	var count int64
+	c.Require().NoError(c.dbContext.DB().Model(&scheduling.ScheduledMessage{}).Count(&count).Error)
+
+	return count
+}
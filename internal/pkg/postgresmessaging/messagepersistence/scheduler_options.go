@@ -0,0 +1,57 @@
+package messagepersistence
+
+import (
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/config"
+	"github.com/reoden/go-NFT/pkg/config/environment"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var schedulerOptionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[SchedulerOptions]())
+
+type SchedulerOptions struct {
+	// PollingInterval interval of polling the scheduled messages for the due messages in milliseconds
+	PollingInterval int `mapstructure:"pollingInterval" default:"1000"`
+	// BatchSize maximum number of the due messages that are published in each polling
+	BatchSize int `mapstructure:"batchSize"       default:"100"`
+	// RetryDelay delay of the first retry of a failed message in milliseconds, it is doubled on each failed attempt
+	RetryDelay int `mapstructure:"retryDelay"      default:"5000"`
+	// MaxRetryDelay maximum delay of the retries of a failed message in milliseconds
+	MaxRetryDelay int `mapstructure:"maxRetryDelay"   default:"3600000"`
+}
+
+func (o *SchedulerOptions) PollingIntervalDuration() time.Duration {
+	if o.PollingInterval <= 0 {
+		return time.Second
+	}
+
+	return time.Duration(o.PollingInterval) * time.Millisecond
+}
+
+// RetryDelayDuration returns the delay of the retry after the failed attempts of a message
+func (o *SchedulerOptions) RetryDelayDuration(attempts int) time.Duration {
+	delay := time.Duration(o.RetryDelay) * time.Millisecond
+	if delay <= 0 {
+		delay = 5 * time.Second
+	}
+	maxDelay := time.Duration(o.MaxRetryDelay) * time.Millisecond
+	if maxDelay <= 0 {
+		maxDelay = time.Hour
+	}
+
+	for i := 1; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		return maxDelay
+	}
+
+	return delay
+}
+
+func ProvideSchedulerConfig(environment environment.Environment) (*SchedulerOptions, error) {
+	return config.BindConfigKey[*SchedulerOptions](schedulerOptionName, environment)
+}
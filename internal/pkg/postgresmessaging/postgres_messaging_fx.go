@@ -1,8 +1,13 @@
 package postgresmessaging
 
 import (
+	"context"
+
 	"github.com/reoden/go-NFT/pkg/core/messaging/persistmessage"
+	"github.com/reoden/go-NFT/pkg/core/messaging/saga"
+	"github.com/reoden/go-NFT/pkg/core/messaging/scheduling"
 	"github.com/reoden/go-NFT/pkg/postgresmessaging/messagepersistence"
+	"github.com/reoden/go-NFT/pkg/web"
 
 	"go.uber.org/fx"
 	"gorm.io/gorm"
@@ -11,14 +16,102 @@ import (
 var Module = fx.Module(
 	"postgresmessagingfx",
 	fx.Provide(
+		messagepersistence.ProvideOutboxConfig,
+		messagepersistence.ProvideInboxConfig,
 		messagepersistence.NewPostgresMessagePersistenceDBContext,
-		messagepersistence.NewPostgresMessageService,
+		fx.Annotate(
+			messagepersistence.NewPostgresMessageService,
+			// services without a message bus only use the message store
+			fx.ParamTags(``, ``, ``, ``, `optional:"true"`),
+		),
+		messagepersistence.NewInboxPipelineFactory,
 	),
 	fx.Invoke(migrateMessaging),
 )
 
+// OutboxModule runs the dispatcher that publishes the stored outbox messages through the bus
+var OutboxModule = fx.Module(
+	"postgresmessagingoutboxfx",
+	fx.Provide(messagepersistence.NewOutboxDispatcher),
+	fx.Invoke(runOutboxDispatcher),
+)
+
+// InboxModule runs the cleaner that removes the inbox messages after their retention period
+var InboxModule = fx.Module(
+	"postgresmessaginginboxfx",
+	fx.Provide(messagepersistence.NewInboxCleaner),
+	fx.Invoke(runInboxCleaner),
+)
+
+// SagaModule stores the sagas on the messaging database and runs the dispatcher of their scheduled timeouts
+var SagaModule = fx.Module(
+	"postgresmessagingsagafx",
+	fx.Provide(
+		saga.ProvideSagaConfig,
+		messagepersistence.NewPostgresSagaStore,
+		saga.NewTimeoutDispatcher,
+	),
+	fx.Invoke(migrateSagas),
+	fx.Invoke(runSagaTimeoutDispatcher),
+)
+
+// SchedulerModule stores the scheduled and the recurring messages of the scheduled producer on the messaging database
+// and runs the dispatcher that publishes them on their due time
+var SchedulerModule = fx.Module(
+	"postgresmessagingschedulerfx",
+	fx.Provide(
+		messagepersistence.ProvideSchedulerConfig,
+		messagepersistence.NewScheduledProducer,
+		messagepersistence.NewScheduledMessageDispatcher,
+	),
+	fx.Invoke(migrateScheduledMessages),
+	fx.Invoke(runScheduledMessageDispatcher),
+)
+
 func migrateMessaging(db *gorm.DB) error {
 	err := db.Migrator().AutoMigrate(&persistmessage.StoreMessage{})
 
 	return err
 }
+
+func migrateSagas(db *gorm.DB) error {
+	err := db.Migrator().AutoMigrate(&saga.StoredSaga{}, &saga.StoredTimeout{})
+
+	return err
+}
+
+func migrateScheduledMessages(db *gorm.DB) error {
+	err := db.Migrator().AutoMigrate(&scheduling.ScheduledMessage{})
+
+	return err
+}
+
+func runOutboxDispatcher(lc fx.Lifecycle, dispatcher messagepersistence.OutboxDispatcher) {
+	runWorker(lc, dispatcher)
+}
+
+func runInboxCleaner(lc fx.Lifecycle, cleaner messagepersistence.InboxCleaner) {
+	runWorker(lc, cleaner)
+}
+
+func runSagaTimeoutDispatcher(lc fx.Lifecycle, dispatcher saga.TimeoutDispatcher) {
+	runWorker(lc, dispatcher)
+}
+
+func runScheduledMessageDispatcher(lc fx.Lifecycle, dispatcher messagepersistence.ScheduledMessageDispatcher) {
+	runWorker(lc, dispatcher)
+}
+
+func runWorker(lc fx.Lifecycle, worker web.Worker) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			// the start context is canceled after the startup, so the worker runs on its own context
+			worker.Start(context.Background())
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return worker.Stop(ctx)
+		},
+	})
+}
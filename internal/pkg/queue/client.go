@@ -11,7 +11,7 @@ import (
 )
 
 func NewClient(config *redis2.RedisOptions) *asynq.Client {
-	return asynq.NewClient(asynq.RedisClientOpt{Addr: fmt.Sprintf("%s:%d", config.Host, config.Port)})
+	return asynq.NewClient(redisClientOpt(config))
 }
 
 func HookClient(lifecycle fx.Lifecycle, client *asynq.Client) {
@@ -21,3 +21,11 @@ func HookClient(lifecycle fx.Lifecycle, client *asynq.Client) {
 		},
 	})
 }
+
+func redisClientOpt(config *redis2.RedisOptions) asynq.RedisClientOpt {
+	return asynq.RedisClientOpt{
+		Addr:     fmt.Sprintf("%s:%d", config.Host, config.Port),
+		Password: config.Password,
+		DB:       config.Database,
+	}
+}
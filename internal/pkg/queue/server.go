@@ -2,7 +2,6 @@ package queue
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/reoden/go-NFT/pkg/logger"
 	redis2 "github.com/reoden/go-NFT/pkg/redis"
@@ -17,7 +16,7 @@ func NewServeMux() *asynq.ServeMux {
 
 func NewServer(config *redis2.RedisOptions, logger logger.Logger) *asynq.Server {
 	return asynq.NewServer(
-		asynq.RedisClientOpt{Addr: fmt.Sprintf("%s:%d", config.Host, config.Port)},
+		redisClientOpt(config),
 		asynq.Config{Concurrency: 10},
 	)
 }
@@ -25,12 +24,8 @@ func NewServer(config *redis2.RedisOptions, logger logger.Logger) *asynq.Server
 func HookServer(lifecycle fx.Lifecycle, server *asynq.Server, mux *asynq.ServeMux) {
 	lifecycle.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
-			go func() {
-				if err := server.Run(mux); err != nil {
-					panic(err)
-				}
-			}()
-			return nil
+			// `Start` runs the workers in the background and returns the error of connecting to redis
+			return server.Start(mux)
 		},
 		OnStop: func(ctx context.Context) error {
 			server.Shutdown()
@@ -28,10 +28,13 @@ import (
 type RabbitmqBus interface {
 	bus.Bus
 	consumerConfigurations.RabbitMQConsumerConnector
+	// ConsumersConfigurations returns the configurations of all the consumers of the bus
+	ConsumersConfigurations() []*consumerConfigurations.RabbitMQConsumerConfiguration
 }
 
 type rabbitmqBus struct {
 	messageTypeConsumers    map[reflect.Type][]consumer2.Consumer
+	consumersConfigurations []*consumerConfigurations.RabbitMQConsumerConfiguration
 	producer                producer.Producer
 	rabbitmqConfiguration   *configurations.RabbitMQConfiguration
 	rabbitmqConfigBuilder   configurations.RabbitMQConfigurationBuilder
@@ -104,6 +107,7 @@ func NewRabbitmqBus(
 			rabbitBus.messageTypeConsumers[consumerConfiguration.ConsumerMessageType],
 			mqConsumer,
 		)
+		rabbitBus.consumersConfigurations = append(rabbitBus.consumersConfigurations, consumerConfiguration)
 	}
 
 	mqProducer, err := producerFactory.CreateProducer(
@@ -183,6 +187,7 @@ func (r *rabbitmqBus) ConnectRabbitMQConsumer(
 		r.messageTypeConsumers[typeName],
 		mqConsumer,
 	)
+	r.consumersConfigurations = append(r.consumersConfigurations, consumerConfig)
 
 	return nil
 }
@@ -225,10 +230,15 @@ func (r *rabbitmqBus) ConnectConsumerHandler(
 		}
 
 		r.messageTypeConsumers[typeName] = append(r.messageTypeConsumers[typeName], mqConsumer)
+		r.consumersConfigurations = append(r.consumersConfigurations, consumerConfig)
 	}
 	return nil
 }
 
+func (r *rabbitmqBus) ConsumersConfigurations() []*consumerConfigurations.RabbitMQConsumerConfiguration {
+	return r.consumersConfigurations
+}
+
 func (r *rabbitmqBus) Start(ctx context.Context) error {
 	r.logger.Infof(
 		"rabbitmq is running on host: %s",
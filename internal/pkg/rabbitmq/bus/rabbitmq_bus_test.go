@@ -61,12 +61,15 @@ func Test_AddRabbitMQ(t *testing.T) {
 		options,
 		conn,
 		serializer,
+		nil,
+		nil,
 		defaultlogger.GetLogger(),
 	)
 	producerFactory := rabbitmqproducer.NewProducerFactory(
 		options,
 		conn,
 		serializer,
+		nil,
 		defaultlogger.GetLogger(),
 	)
 
@@ -18,6 +18,30 @@ type RabbitmqOptions struct {
 	AppId               string
 	AutoStart           bool `mapstructure:"autoStart"           default:"true"`
 	Reconnecting        bool `mapstructure:"reconnecting"        default:"true"`
+	// RequestTimeout timeout of the requests that wait for a reply in seconds, it applies when the context of the request has no deadline
+	RequestTimeout int `mapstructure:"requestTimeout"      default:"30"`
+	// DirectReplyTo receives the replies through the direct reply-to of the broker instead of a temporary reply queue
+	DirectReplyTo bool `mapstructure:"directReplyTo"`
+	// QueueDepthCollectionInterval interval of collecting the depth of the consumer queues in seconds
+	QueueDepthCollectionInterval int `mapstructure:"queueDepthCollectionInterval" default:"15"`
+	// MaxQueueDepth the health check is down when a consumer queue has more ready messages, zero disables the check
+	MaxQueueDepth int `mapstructure:"maxQueueDepth"`
+}
+
+func (o *RabbitmqOptions) RequestTimeoutDuration() time.Duration {
+	if o.RequestTimeout <= 0 {
+		return 30 * time.Second
+	}
+
+	return time.Duration(o.RequestTimeout) * time.Second
+}
+
+func (o *RabbitmqOptions) QueueDepthCollectionIntervalDuration() time.Duration {
+	if o.QueueDepthCollectionInterval <= 0 {
+		return 15 * time.Second
+	}
+
+	return time.Duration(o.QueueDepthCollectionInterval) * time.Second
 }
 
 type RabbitmqHostOptions struct {
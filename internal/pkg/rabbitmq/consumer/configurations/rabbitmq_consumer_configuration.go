@@ -27,6 +27,7 @@ type RabbitMQConsumerConfiguration struct {
 	BindingOptions  *options.RabbitMQBindingOptions
 	QueueOptions    *options.RabbitMQQueueOptions
 	ExchangeOptions *options.RabbitMQExchangeOptions
+	RetryOptions    *options.RabbitMQRetryOptions
 }
 
 func NewDefaultRabbitMQConsumerConfiguration(
@@ -52,7 +53,17 @@ func NewDefaultRabbitMQConsumerConfiguration(
 			Durable: true,
 			Name:    utils.GetQueueName(messageType),
 		},
+		RetryOptions:        options.NewDefaultRabbitMQRetryOptions(),
 		ConsumerMessageType: utils.GetMessageBaseReflectType(messageType),
 		Name:                name,
 	}
 }
+
+// QueueName returns the name of the consumer queue
+func (c *RabbitMQConsumerConfiguration) QueueName() string {
+	if c.QueueOptions.Name != "" {
+		return c.QueueOptions.Name
+	}
+
+	return utils.GetQueueNameFromType(c.ConsumerMessageType)
+}
@@ -1,6 +1,8 @@
 package configurations
 
 import (
+	"time"
+
 	messageConsumer "github.com/reoden/go-NFT/pkg/core/messaging/consumer"
 	"github.com/reoden/go-NFT/pkg/core/messaging/pipeline"
 	types2 "github.com/reoden/go-NFT/pkg/core/messaging/types"
@@ -14,6 +16,8 @@ type RabbitMQConsumerConfigurationBuilder interface {
 	WIthPipelines(
 		pipelineBuilderFunc pipeline.ConsumerPipelineConfigurationBuilderFunc,
 	) RabbitMQConsumerConfigurationBuilder
+	// WithInbox skips the messages that are already handled by the consumer, the inbox pipeline runs before the other pipelines
+	WithInbox(inboxPipelineFactory pipeline.InboxPipelineFactory) RabbitMQConsumerConfigurationBuilder
 	WithExitOnError(exitOnError bool) RabbitMQConsumerConfigurationBuilder
 	WithAutoAck(ack bool) RabbitMQConsumerConfigurationBuilder
 	WithNoLocal(noLocal bool) RabbitMQConsumerConfigurationBuilder
@@ -33,6 +37,12 @@ type RabbitMQConsumerConfigurationBuilder interface {
 	WithRoutingKey(routingKey string) RabbitMQConsumerConfigurationBuilder
 	WithBindingArgs(args map[string]any) RabbitMQConsumerConfigurationBuilder
 	WithName(name string) RabbitMQConsumerConfigurationBuilder
+	// WithImmediateRetries sets the in-process attempts of the handlers for each delivery of the message
+	WithImmediateRetries(count int, delay time.Duration) RabbitMQConsumerConfigurationBuilder
+	// WithDelayedRetries sets the delays of the redeliveries through the retry queues, no delay disables the delayed retries
+	WithDelayedRetries(delays ...time.Duration) RabbitMQConsumerConfigurationBuilder
+	// WithDeadLetter moves the messages to the dead-letter queue after the last retry when it is enabled
+	WithDeadLetter(enabled bool) RabbitMQConsumerConfigurationBuilder
 	Build() *RabbitMQConsumerConfiguration
 }
 
@@ -40,6 +50,7 @@ type rabbitMQConsumerConfigurationBuilder struct {
 	rabbitmqConsumerConfigurations *RabbitMQConsumerConfiguration
 	pipelinesBuilder               pipeline.ConsumerPipelineConfigurationBuilder
 	handlersBuilder                messageConsumer.ConsumerHandlerConfigurationBuilder
+	inboxPipelineFactory           pipeline.InboxPipelineFactory
 }
 
 func NewRabbitMQConsumerConfigurationBuilder(
@@ -74,6 +85,13 @@ func (b *rabbitMQConsumerConfigurationBuilder) WithHandlers(
 	return b
 }
 
+func (b *rabbitMQConsumerConfigurationBuilder) WithInbox(
+	inboxPipelineFactory pipeline.InboxPipelineFactory,
+) RabbitMQConsumerConfigurationBuilder {
+	b.inboxPipelineFactory = inboxPipelineFactory
+	return b
+}
+
 func (b *rabbitMQConsumerConfigurationBuilder) WithExitOnError(
 	exitOnError bool,
 ) RabbitMQConsumerConfigurationBuilder {
@@ -208,6 +226,29 @@ func (b *rabbitMQConsumerConfigurationBuilder) WithBindingArgs(
 	return b
 }
 
+func (b *rabbitMQConsumerConfigurationBuilder) WithImmediateRetries(
+	count int,
+	delay time.Duration,
+) RabbitMQConsumerConfigurationBuilder {
+	b.rabbitmqConsumerConfigurations.RetryOptions.ImmediateRetryCount = count
+	b.rabbitmqConsumerConfigurations.RetryOptions.ImmediateRetryDelay = delay
+	return b
+}
+
+func (b *rabbitMQConsumerConfigurationBuilder) WithDelayedRetries(
+	delays ...time.Duration,
+) RabbitMQConsumerConfigurationBuilder {
+	b.rabbitmqConsumerConfigurations.RetryOptions.DelayedRetryDelays = delays
+	return b
+}
+
+func (b *rabbitMQConsumerConfigurationBuilder) WithDeadLetter(
+	enabled bool,
+) RabbitMQConsumerConfigurationBuilder {
+	b.rabbitmqConsumerConfigurations.RetryOptions.DeadLetterEnabled = enabled
+	return b
+}
+
 func (b *rabbitMQConsumerConfigurationBuilder) Build() *RabbitMQConsumerConfiguration {
 	if b.pipelinesBuilder != nil {
 		b.rabbitmqConsumerConfigurations.Pipelines = b.pipelinesBuilder.Build().Pipelines
@@ -215,6 +256,12 @@ func (b *rabbitMQConsumerConfigurationBuilder) Build() *RabbitMQConsumerConfigur
 	if b.handlersBuilder != nil {
 		b.rabbitmqConsumerConfigurations.Handlers = b.handlersBuilder.Build().Handlers
 	}
+	if b.inboxPipelineFactory != nil {
+		b.rabbitmqConsumerConfigurations.Pipelines = append(
+			[]pipeline.ConsumerPipeline{b.inboxPipelineFactory(b.rabbitmqConsumerConfigurations.Name)},
+			b.rabbitmqConsumerConfigurations.Pipelines...,
+		)
+	}
 
 	return b.rabbitmqConsumerConfigurations
 }
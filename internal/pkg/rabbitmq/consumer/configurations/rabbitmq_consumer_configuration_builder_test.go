@@ -0,0 +1,68 @@
+//go:build unit
+// +build unit
+
+package configurations
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	"github.com/reoden/go-NFT/pkg/rabbitmq/consumer/options"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type orderPlaced struct {
+	*types.Message
+}
+
+func Test_Build_Default_Retry_Options(t *testing.T) {
+	configuration := NewRabbitMQConsumerConfigurationBuilder(
+		&orderPlaced{Message: types.NewMessage(uuid.NewV4().String())},
+	).Build()
+
+	assert.Equal(t, options.NewDefaultRabbitMQRetryOptions(), configuration.RetryOptions)
+}
+
+func Test_Build_Custom_Retry_Options(t *testing.T) {
+	configuration := NewRabbitMQConsumerConfigurationBuilder(
+		&orderPlaced{Message: types.NewMessage(uuid.NewV4().String())},
+	).
+		WithImmediateRetries(1, time.Second).
+		WithDelayedRetries(time.Second, 30*time.Second).
+		WithDeadLetter(false).
+		Build()
+
+	assert.Equal(t, 1, configuration.RetryOptions.ImmediateRetryCount)
+	assert.Equal(t, time.Second, configuration.RetryOptions.ImmediateRetryDelay)
+	assert.Equal(t, []time.Duration{time.Second, 30 * time.Second}, configuration.RetryOptions.DelayedRetryDelays)
+	assert.False(t, configuration.RetryOptions.DeadLetterEnabled)
+}
+
+func Test_Build_Retry_Options_Are_Not_Shared(t *testing.T) {
+	first := NewRabbitMQConsumerConfigurationBuilder(
+		&orderPlaced{Message: types.NewMessage(uuid.NewV4().String())},
+	).WithDelayedRetries().Build()
+	second := NewRabbitMQConsumerConfigurationBuilder(
+		&orderPlaced{Message: types.NewMessage(uuid.NewV4().String())},
+	).Build()
+
+	assert.Empty(t, first.RetryOptions.DelayedRetryDelays)
+	assert.Len(t, second.RetryOptions.DelayedRetryDelays, 3)
+}
+
+func Test_Failure_Queue_Names(t *testing.T) {
+	configuration := NewRabbitMQConsumerConfigurationBuilder(
+		&orderPlaced{Message: types.NewMessage(uuid.NewV4().String())},
+	).WithQueueName("orders").Build()
+
+	queue := configuration.QueueName()
+
+	assert.Equal(t, "orders", queue)
+	assert.Equal(t, "orders.retry", options.RetryExchangeName(queue))
+	assert.Equal(t, "orders.retry.10000ms", options.RetryQueueName(queue, 10*time.Second))
+	assert.Equal(t, "orders.dlx", options.DeadLetterExchangeName(queue))
+	assert.Equal(t, "orders.dlq", options.DeadLetterQueueName(queue))
+}
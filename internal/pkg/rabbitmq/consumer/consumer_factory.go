@@ -3,6 +3,7 @@ package consumer
 import (
 	"github.com/reoden/go-NFT/pkg/core/messaging/consumer"
 	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	"github.com/reoden/go-NFT/pkg/core/messaging/versioning"
 	serializer "github.com/reoden/go-NFT/pkg/core/serializer"
 	"github.com/reoden/go-NFT/pkg/logger"
 	"github.com/reoden/go-NFT/pkg/rabbitmq/config"
@@ -12,23 +13,29 @@ import (
 )
 
 type consumerFactory struct {
-	connection      types2.IConnection
-	eventSerializer serializer.MessageSerializer
-	logger          logger.Logger
-	rabbitmqOptions *config.RabbitmqOptions
+	connection         types2.IConnection
+	eventSerializer    serializer.MessageSerializer
+	serializerRegistry serializer.MessageSerializerRegistry
+	upcasterRegistry   versioning.UpcasterRegistry
+	logger             logger.Logger
+	rabbitmqOptions    *config.RabbitmqOptions
 }
 
 func NewConsumerFactory(
 	rabbitmqOptions *config.RabbitmqOptions,
 	connection types2.IConnection,
 	eventSerializer serializer.MessageSerializer,
+	serializerRegistry serializer.MessageSerializerRegistry,
+	upcasterRegistry versioning.UpcasterRegistry,
 	l logger.Logger,
 ) consumercontracts.ConsumerFactory {
 	return &consumerFactory{
-		rabbitmqOptions: rabbitmqOptions,
-		logger:          l,
-		eventSerializer: eventSerializer,
-		connection:      connection,
+		serializerRegistry: serializerRegistry,
+		upcasterRegistry:   upcasterRegistry,
+		rabbitmqOptions:    rabbitmqOptions,
+		logger:             l,
+		eventSerializer:    eventSerializer,
+		connection:         connection,
 	}
 }
 
@@ -41,6 +48,8 @@ func (c *consumerFactory) CreateConsumer(
 		c.connection,
 		consumerConfiguration,
 		c.eventSerializer,
+		c.serializerRegistry,
+		c.upcasterRegistry,
 		c.logger,
 		isConsumedNotifications...)
 }
@@ -0,0 +1,52 @@
+package options
+
+import (
+	"fmt"
+	"time"
+)
+
+type RabbitMQRetryOptions struct {
+	// ImmediateRetryCount number of the in-process attempts of the handlers for each delivery of the message
+	ImmediateRetryCount int
+	// ImmediateRetryDelay base delay of the in-process attempts, it grows with a backoff between the attempts
+	ImmediateRetryDelay time.Duration
+	// DelayedRetryDelays delays of the redeliveries through the retry queues, after a failed delivery the message
+	// waits in the retry queue of the next delay until its ttl expires and then returns to the consumer queue
+	DelayedRetryDelays []time.Duration
+	// DeadLetterEnabled moves the message to the dead-letter queue after the last retry, otherwise it is dropped
+	DeadLetterEnabled bool
+}
+
+func NewDefaultRabbitMQRetryOptions() *RabbitMQRetryOptions {
+	return &RabbitMQRetryOptions{
+		ImmediateRetryCount: 3,
+		ImmediateRetryDelay: 300 * time.Millisecond,
+		DelayedRetryDelays:  []time.Duration{10 * time.Second, time.Minute, 5 * time.Minute},
+		DeadLetterEnabled:   true,
+	}
+}
+
+// RetryExchangeName name of the exchange that routes the failed messages of a queue to its retry queues
+func RetryExchangeName(queueName string) string {
+	return fmt.Sprintf("%s.retry", queueName)
+}
+
+// RetryQueueName name of the retry queue of a queue for a delay
+func RetryQueueName(queueName string, delay time.Duration) string {
+	return fmt.Sprintf("%s.retry.%s", queueName, RetryRoutingKey(delay))
+}
+
+// RetryRoutingKey routing key of the retry queue of a delay
+func RetryRoutingKey(delay time.Duration) string {
+	return fmt.Sprintf("%dms", delay.Milliseconds())
+}
+
+// DeadLetterExchangeName name of the exchange that routes the dead-lettered messages of a queue
+func DeadLetterExchangeName(queueName string) string {
+	return fmt.Sprintf("%s.dlx", queueName)
+}
+
+// DeadLetterQueueName name of the parking lot queue of the dead-lettered messages of a queue
+func DeadLetterQueueName(queueName string) string {
+	return fmt.Sprintf("%s.dlq", queueName)
+}
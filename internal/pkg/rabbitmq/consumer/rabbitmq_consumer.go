@@ -7,10 +7,13 @@ import (
 	"time"
 
 	"github.com/reoden/go-NFT/pkg/core/messaging/consumer"
+	messageHeader "github.com/reoden/go-NFT/pkg/core/messaging/messageheader"
+	messagingMetrics "github.com/reoden/go-NFT/pkg/core/messaging/otel/metrics"
 	consumertracing "github.com/reoden/go-NFT/pkg/core/messaging/otel/tracing/consumer"
 	"github.com/reoden/go-NFT/pkg/core/messaging/pipeline"
 	messagingTypes "github.com/reoden/go-NFT/pkg/core/messaging/types"
 	"github.com/reoden/go-NFT/pkg/core/messaging/utils"
+	"github.com/reoden/go-NFT/pkg/core/messaging/versioning"
 	"github.com/reoden/go-NFT/pkg/core/metadata"
 	"github.com/reoden/go-NFT/pkg/core/serializer"
 	"github.com/reoden/go-NFT/pkg/logger"
@@ -28,17 +31,6 @@ import (
 	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 )
 
-const (
-	retryAttempts = 3
-	retryDelay    = 300 * time.Millisecond
-)
-
-var retryOptions = []retry.Option{
-	retry.Attempts(retryAttempts),
-	retry.Delay(retryDelay),
-	retry.DelayType(retry.BackOffDelay),
-}
-
 type rabbitMQConsumer struct {
 	rabbitmqConsumerOptions *configurations.RabbitMQConsumerConfiguration
 	connection              types.IConnection
@@ -46,6 +38,8 @@ type rabbitMQConsumer struct {
 	channel                 *amqp091.Channel
 	deliveryRoutines        chan struct{} // chan should init before using channel
 	messageSerializer       serializer.MessageSerializer
+	serializerRegistry      serializer.MessageSerializerRegistry
+	upcasterRegistry        versioning.UpcasterRegistry
 	logger                  logger.Logger
 	rabbitmqOptions         *config.RabbitmqOptions
 	ErrChan                 chan error
@@ -60,6 +54,8 @@ func NewRabbitMQConsumer(
 	connection types.IConnection,
 	consumerConfiguration *configurations.RabbitMQConsumerConfiguration,
 	messageSerializer serializer.MessageSerializer,
+	serializerRegistry serializer.MessageSerializerRegistry,
+	upcasterRegistry versioning.UpcasterRegistry,
 	logger logger.Logger,
 	isConsumedNotifications ...func(message messagingTypes.IMessage),
 ) (consumer.Consumer, error) {
@@ -79,6 +75,8 @@ func NewRabbitMQConsumer(
 	)
 	cons := &rabbitMQConsumer{
 		messageSerializer:       messageSerializer,
+		serializerRegistry:      serializerRegistry,
+		upcasterRegistry:        upcasterRegistry,
 		rabbitmqOptions:         rabbitmqOptions,
 		logger:                  logger,
 		rabbitmqConsumerOptions: consumerConfiguration,
@@ -105,7 +103,6 @@ func (r *rabbitMQConsumer) Start(ctx context.Context) error {
 	}
 
 	var exchange string
-	var queue string
 	var routingKey string
 
 	if r.rabbitmqConsumerOptions.ExchangeOptions.Name != "" {
@@ -120,11 +117,7 @@ func (r *rabbitMQConsumer) Start(ctx context.Context) error {
 		routingKey = utils.GetRoutingKeyFromType(r.rabbitmqConsumerOptions.ConsumerMessageType)
 	}
 
-	if r.rabbitmqConsumerOptions.QueueOptions.Name != "" {
-		queue = r.rabbitmqConsumerOptions.QueueOptions.Name
-	} else {
-		queue = utils.GetQueueNameFromType(r.rabbitmqConsumerOptions.ConsumerMessageType)
-	}
+	queue := r.rabbitmqConsumerOptions.QueueName()
 
 	r.reConsumeOnDropConnection(ctx)
 
@@ -141,6 +134,11 @@ func (r *rabbitMQConsumer) Start(ctx context.Context) error {
 		return err
 	}
 
+	// the failed messages are acknowledged only after the broker confirms their publish to the retry or dead-letter queue
+	if err := r.channel.Confirm(false); err != nil {
+		return err
+	}
+
 	err = r.channel.ExchangeDeclare(
 		exchange,
 		string(r.rabbitmqConsumerOptions.ExchangeOptions.Type),
@@ -174,6 +172,11 @@ func (r *rabbitMQConsumer) Start(ctx context.Context) error {
 		return err
 	}
 
+	err = r.declareFailureTopology(queue)
+	if err != nil {
+		return err
+	}
+
 	msgs, err := r.channel.Consume(
 		queue,
 		r.rabbitmqConsumerOptions.ConsumerId,
@@ -301,10 +304,16 @@ func (r *rabbitMQConsumer) handleReceived(
 		meta = metadata.MapToMetadata(delivery.Headers)
 	}
 
+	// the broker rewrites the reply address of the direct reply-to requests, so the property is the real reply address
+	if delivery.ReplyTo != "" {
+		meta = metadata.FromMetadata(meta)
+		messageHeader.SetReplyTo(meta, delivery.ReplyTo)
+	}
+
 	consumerTraceOption := &consumertracing.ConsumerTracingOptions{
 		MessagingSystem: "rabbitmq",
 		DestinationKind: "queue",
-		Destination:     r.rabbitmqConsumerOptions.QueueOptions.Name,
+		Destination:     r.rabbitmqConsumerOptions.QueueName(),
 		OtherAttributes: []attribute.KeyValue{
 			semconv.MessagingRabbitmqDestinationRoutingKey(delivery.RoutingKey),
 		},
@@ -316,17 +325,40 @@ func (r *rabbitMQConsumer) handleReceived(
 		consumerTraceOption,
 	)
 
-	consumeContext, err := r.createConsumeContext(delivery)
+	if delivery.Redelivered {
+		messagingMetrics.RecordRedelivered(ctx, r.metricsOptions(delivery.Type))
+	}
+
+	var ack func()
+
+	fail := func(handleErr error) {
+		if err := r.handleFailure(ctx, delivery, handleErr); err != nil {
+			r.logger.Errorf(
+				"error in handling the failed message of RabbitMQ consumer: %v",
+				consumertracing.FinishConsumerSpan(beforeConsumeSpan, err),
+			)
+			return
+		}
+		_ = consumertracing.FinishConsumerSpan(beforeConsumeSpan, handleErr)
+	}
+
+	// a message that can't be upcasted or deserialized never succeeds, so it is moved to the dead-letter queue without
+	// retrying, leaving it unacknowledged would hold a prefetch slot and redeliver it forever
+	consumeContext, err := r.createConsumeContext(delivery, meta)
 	if err != nil {
-		r.logger.Error(
-			consumertracing.FinishConsumerSpan(beforeConsumeSpan, err),
+		r.deadLetterPoisonMessage(ctx, delivery, err, fail)
+		return
+	}
+	if consumeContext.Message() == nil {
+		r.deadLetterPoisonMessage(
+			ctx,
+			delivery,
+			errors.Errorf("message of type `%s` can't be deserialized", delivery.Type),
+			fail,
 		)
 		return
 	}
 
-	var ack func()
-	var nack func()
-
 	// if auto-ack is enabled we should not call Ack method manually it could create some unexpected errors
 	if r.rabbitmqConsumerOptions.AutoAck == false {
 		ack = func() {
@@ -346,62 +378,52 @@ func (r *rabbitMQConsumer) handleReceived(
 				}
 			}
 		}
-
-		nack = func() {
-			if err := delivery.Nack(false, true); err != nil {
-				r.logger.Error(
-					"error in sending Nack to RabbitMQ consumer: %v",
-					consumertracing.FinishConsumerSpan(beforeConsumeSpan, err),
-				)
-				return
-			}
-			_ = consumertracing.FinishConsumerSpan(beforeConsumeSpan, nil)
-		}
 	}
 
-	r.handle(ctx, ack, nack, consumeContext)
+	r.handle(ctx, ack, fail, consumeContext)
 }
 
 func (r *rabbitMQConsumer) handle(
 	ctx context.Context,
 	ack func(),
-	nack func(),
+	fail func(err error),
 	messageConsumeContext messagingTypes.MessageConsumeContext,
 ) {
-	var err error
-	for _, handler := range r.handlers {
-		err = r.runHandlersWithRetry(ctx, handler, messageConsumeContext)
-		if err != nil {
-			break
-		}
-	}
+	finishConsume := messagingMetrics.StartConsume(ctx, r.metricsOptions(messageConsumeContext.MessageType()))
+
+	err := r.runHandlersWithRetry(ctx, messageConsumeContext)
+
+	finishConsume(err)
 
 	if err != nil {
 		r.logger.Error(
-			"[rabbitMQConsumer.Handle] error in handling consume message of RabbitmqMQ, prepare for nacking message",
+			"[rabbitMQConsumer.Handle] error in handling consume message of RabbitmqMQ, prepare for retrying message",
 		)
-		if nack != nil && r.rabbitmqConsumerOptions.AutoAck == false {
-			nack()
-		}
+		fail(err)
 	} else if err == nil && ack != nil && r.rabbitmqConsumerOptions.AutoAck == false {
 		ack()
 	}
 }
 
+// runHandlersWithRetry runs the handlers of the message inside the pipelines, pipelines wrap all the handlers once,
+// so a pipeline like the inbox sees a single handling of the message
 func (r *rabbitMQConsumer) runHandlersWithRetry(
 	ctx context.Context,
-	handler consumer.ConsumerHandler,
 	messageConsumeContext messagingTypes.MessageConsumeContext,
 ) error {
 	err := retry.Do(func() error {
-		var lastHandler pipeline.ConsumerHandlerFunc
+		var lastHandler pipeline.ConsumerHandlerFunc = func(ctx context.Context) error {
+			for _, handler := range r.handlers {
+				if err := handler.Handle(ctx, messageConsumeContext); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
 
 		if r.pipelines != nil && len(r.pipelines) > 0 {
 			reversPipes := r.reversOrder(r.pipelines)
-			lastHandler = func(ctx context.Context) error {
-				handler := handler.(consumer.ConsumerHandler)
-				return handler.Handle(ctx, messageConsumeContext)
-			}
 
 			aggregateResult := linq.From(reversPipes).
 				AggregateWithSeedT(lastHandler, func(next pipeline.ConsumerHandlerFunc, pipe pipeline.ConsumerPipeline) pipeline.ConsumerHandlerFunc {
@@ -436,31 +458,67 @@ func (r *rabbitMQConsumer) runHandlersWithRetry(
 			}
 			return nil
 		} else {
-			err := handler.Handle(ctx, messageConsumeContext.(messagingTypes.MessageConsumeContext))
+			err := lastHandler(ctx)
 			if err != nil {
 				return err
 			}
 		}
 		return nil
-	}, append(retryOptions, retry.Context(ctx))...)
+	}, r.immediateRetryOptions(ctx)...)
 
 	return err
 }
 
+func (r *rabbitMQConsumer) immediateRetryOptions(ctx context.Context) []retry.Option {
+	retryOptions := r.rabbitmqConsumerOptions.RetryOptions
+
+	attempts := 1
+	delay := time.Duration(0)
+	if retryOptions != nil && retryOptions.ImmediateRetryCount > 0 {
+		attempts = retryOptions.ImmediateRetryCount
+		delay = retryOptions.ImmediateRetryDelay
+	}
+
+	return []retry.Option{
+		retry.Attempts(uint(attempts)),
+		retry.Delay(delay),
+		retry.DelayType(retry.BackOffDelay),
+		retry.LastErrorOnly(true),
+		retry.Context(ctx),
+	}
+}
+
+// createConsumeContext creates the consume context of the delivery with the metadata that is read from the delivery
 func (r *rabbitMQConsumer) createConsumeContext(
 	delivery amqp091.Delivery,
+	meta metadata.Metadata,
 ) (messagingTypes.MessageConsumeContext, error) {
+	body := delivery.Body
+
+	// the json payloads of the older schema versions are transformed to the current version of the message type, the
+	// binary payloads keep the compatibility rules of their formats
+	if r.upcasterRegistry != nil && (delivery.ContentType == "" || delivery.ContentType == "application/json") {
+		upcasted, err := r.upcasterRegistry.Upcast(
+			body,
+			delivery.Type,
+			messageHeader.GetSchemaVersion(meta),
+		)
+		if err != nil {
+			return nil, errors.WrapIff(
+				err,
+				"error in upcasting the message with id `%s`",
+				delivery.MessageId,
+			)
+		}
+		body = upcasted
+	}
+
 	message := r.deserializeData(
 		delivery.ContentType,
 		delivery.Type,
-		delivery.Body,
+		body,
 	)
 
-	var meta metadata.Metadata
-	if delivery.Headers != nil {
-		meta = metadata.MapToMetadata(delivery.Headers)
-	}
-
 	consumeContext := messagingTypes.NewMessageConsumeContext(
 		message,
 		meta,
@@ -488,28 +546,48 @@ func (r *rabbitMQConsumer) deserializeData(
 		return nil
 	}
 
-	if contentType == "application/json" {
-		// r.rabbitmqConsumerOptions.ConsumerMessageType --> actual type
-		// deserialize, err := r.messageSerializer.DeserializeType(body, r.rabbitmqConsumerOptions.ConsumerMessageType, contentType)
-		deserialize, err := r.messageSerializer.Deserialize(
-			body,
-			eventType,
-			contentType,
-		) // or this to explicit type deserialization
+	// the deserializer is picked by the content type of the message, so the producers can choose the serializer per message type
+	messageSerializer := r.messageSerializer
+	if contentType != r.messageSerializer.ContentType() {
+		if r.serializerRegistry == nil {
+			r.logger.Errorf("there is no message serializer for the content type '%s' in the consumer", contentType)
+			return nil
+		}
+
+		contentTypeSerializer, err := r.serializerRegistry.Get(contentType)
 		if err != nil {
-			r.logger.Errorf(
-				fmt.Sprintf(
-					"error in deserilizng of type '%s' in the consumer",
-					eventType,
-				),
-			)
+			r.logger.Errorf("error in getting the message serializer in the consumer: %v", err)
 			return nil
 		}
+		messageSerializer = contentTypeSerializer
+	}
 
-		return deserialize
+	// r.rabbitmqConsumerOptions.ConsumerMessageType --> actual type
+	// deserialize, err := r.messageSerializer.DeserializeType(body, r.rabbitmqConsumerOptions.ConsumerMessageType, contentType)
+	deserialize, err := messageSerializer.Deserialize(
+		body,
+		eventType,
+		contentType,
+	) // or this to explicit type deserialization
+	if err != nil {
+		r.logger.Errorf(
+			fmt.Sprintf(
+				"error in deserilizng of type '%s' in the consumer",
+				eventType,
+			),
+		)
+		return nil
 	}
 
-	return nil
+	return deserialize
+}
+
+func (r *rabbitMQConsumer) metricsOptions(messageType string) *messagingMetrics.MessagingMetricsOptions {
+	return &messagingMetrics.MessagingMetricsOptions{
+		MessagingSystem: "rabbitmq",
+		Destination:     r.rabbitmqConsumerOptions.QueueName(),
+		MessageType:     messageType,
+	}
 }
 
 func (r *rabbitMQConsumer) reversOrder(
@@ -0,0 +1,270 @@
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	messagingMetrics "github.com/reoden/go-NFT/pkg/core/messaging/otel/metrics"
+	"github.com/reoden/go-NFT/pkg/rabbitmq/consumer/options"
+	"github.com/reoden/go-NFT/pkg/rabbitmq/types"
+
+	"emperror.dev/errors"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// declareFailureTopology declares the retry queues and the dead-letter queue of the consumer queue.
+// A retry queue has a ttl of its delay and dead-letters the expired messages back to the consumer queue
+// through the default exchange, the dead-letter queue keeps the messages that failed on all the retries.
+func (r *rabbitMQConsumer) declareFailureTopology(queue string) error {
+	retryOptions := r.rabbitmqConsumerOptions.RetryOptions
+	if retryOptions == nil {
+		return nil
+	}
+
+	durable := r.rabbitmqConsumerOptions.QueueOptions.Durable
+	noWait := r.rabbitmqConsumerOptions.NoWait
+
+	if len(retryOptions.DelayedRetryDelays) > 0 {
+		retryExchange := options.RetryExchangeName(queue)
+
+		err := r.channel.ExchangeDeclare(retryExchange, types.ExchangeDirect, durable, false, false, noWait, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, delay := range retryOptions.DelayedRetryDelays {
+			retryQueue := options.RetryQueueName(queue, delay)
+
+			_, err = r.channel.QueueDeclare(
+				retryQueue,
+				durable,
+				false,
+				false,
+				noWait,
+				amqp091.Table{
+					"x-message-ttl":             delay.Milliseconds(),
+					"x-dead-letter-exchange":    "",
+					"x-dead-letter-routing-key": queue,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			err = r.channel.QueueBind(retryQueue, options.RetryRoutingKey(delay), retryExchange, noWait, nil)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if retryOptions.DeadLetterEnabled {
+		deadLetterExchange := options.DeadLetterExchangeName(queue)
+		deadLetterQueue := options.DeadLetterQueueName(queue)
+
+		err := r.channel.ExchangeDeclare(deadLetterExchange, types.ExchangeDirect, durable, false, false, noWait, nil)
+		if err != nil {
+			return err
+		}
+
+		_, err = r.channel.QueueDeclare(deadLetterQueue, durable, false, false, noWait, nil)
+		if err != nil {
+			return err
+		}
+
+		err = r.channel.QueueBind(deadLetterQueue, queue, deadLetterExchange, noWait, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// handleFailure sends the failed message to the retry queue of its next delay, or to the dead-letter queue
+// after the last retry, and then acknowledges the original delivery once the broker confirms the publish.
+func (r *rabbitMQConsumer) handleFailure(
+	ctx context.Context,
+	delivery amqp091.Delivery,
+	handleErr error,
+) error {
+	queue := r.rabbitmqConsumerOptions.QueueName()
+	retryOptions := r.rabbitmqConsumerOptions.RetryOptions
+	if retryOptions == nil {
+		retryOptions = &options.RabbitMQRetryOptions{}
+	}
+
+	attemptCount := GetAttemptCount(delivery.Headers) + 1
+	publishing := r.failedPublishing(delivery, queue, attemptCount, handleErr)
+
+	var exchange, routingKey string
+	var recordFailure func(ctx context.Context, options *messagingMetrics.MessagingMetricsOptions)
+
+	switch {
+	case attemptCount <= len(retryOptions.DelayedRetryDelays):
+		delay := retryOptions.DelayedRetryDelays[attemptCount-1]
+		exchange = options.RetryExchangeName(queue)
+		routingKey = options.RetryRoutingKey(delay)
+		recordFailure = messagingMetrics.RecordRetried
+
+		r.logger.Infof(
+			"message with id `%s` failed on attempt %d, redelivering it to the queue `%s` after %s",
+			delivery.MessageId,
+			attemptCount,
+			queue,
+			delay,
+		)
+	case retryOptions.DeadLetterEnabled:
+		exchange = options.DeadLetterExchangeName(queue)
+		routingKey = queue
+		recordFailure = messagingMetrics.RecordDeadLettered
+
+		r.logger.Errorf(
+			"message with id `%s` failed on attempt %d, moving it to the dead-letter queue `%s`",
+			delivery.MessageId,
+			attemptCount,
+			options.DeadLetterQueueName(queue),
+		)
+	default:
+		r.logger.Errorf(
+			"message with id `%s` failed on attempt %d, dropping it because the dead-letter queue is disabled",
+			delivery.MessageId,
+			attemptCount,
+		)
+
+		return r.nack(delivery, false)
+	}
+
+	err := r.publishFailedMessage(ctx, exchange, routingKey, publishing)
+	if err != nil {
+		// the message goes back to the queue, so it is not lost when the retry or dead-letter queue is unreachable
+		return errors.Append(err, r.nack(delivery, true))
+	}
+
+	recordFailure(ctx, r.metricsOptions(delivery.Type))
+
+	if r.rabbitmqConsumerOptions.AutoAck {
+		return nil
+	}
+
+	return delivery.Ack(false)
+}
+
+// publishFailedMessage publishes the failed message on the consumer channel in the confirm mode and waits for the
+// confirm of the broker
+func (r *rabbitMQConsumer) publishFailedMessage(
+	ctx context.Context,
+	exchange string,
+	routingKey string,
+	publishing amqp091.Publishing,
+) error {
+	confirmation, err := r.channel.PublishWithDeferredConfirmWithContext(
+		ctx,
+		exchange,
+		routingKey,
+		false,
+		false,
+		publishing,
+	)
+	if err != nil {
+		return errors.WrapIf(err, "error in publishing the failed message")
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return errors.WrapIf(err, "error in waiting for the confirm of the failed message")
+	}
+	if !acked {
+		return errors.Errorf(
+			"publishing the failed message with id `%s` is not confirmed by the broker",
+			publishing.MessageId,
+		)
+	}
+
+	return nil
+}
+
+// deadLetterPoisonMessage moves a message that can't be handled on any retry directly to the dead-letter queue, or
+// drops it when the dead-letter queue is disabled
+func (r *rabbitMQConsumer) deadLetterPoisonMessage(
+	ctx context.Context,
+	delivery amqp091.Delivery,
+	poisonErr error,
+	fail func(err error),
+) {
+	if delivery.Headers == nil {
+		delivery.Headers = amqp091.Table{}
+	}
+	if retryOptions := r.rabbitmqConsumerOptions.RetryOptions; retryOptions != nil {
+		delivery.Headers[types.AttemptCountHeader] = int64(len(retryOptions.DelayedRetryDelays))
+	}
+
+	fail(poisonErr)
+}
+
+func (r *rabbitMQConsumer) failedPublishing(
+	delivery amqp091.Delivery,
+	queue string,
+	attemptCount int,
+	handleErr error,
+) amqp091.Publishing {
+	headers := amqp091.Table{}
+	for key, value := range delivery.Headers {
+		headers[key] = value
+	}
+
+	headers[types.ExceptionHeader] = handleErr.Error()
+	headers[types.AttemptCountHeader] = int64(attemptCount)
+	headers[types.FailedAtHeader] = time.Now().UTC().Format(time.RFC3339)
+	headers[types.OriginalQueueHeader] = queue
+
+	// redelivered messages come back from the retry queues through the default exchange, so only the first delivery has the original route
+	if _, exists := headers[types.OriginalExchangeHeader]; !exists {
+		headers[types.OriginalExchangeHeader] = delivery.Exchange
+		headers[types.OriginalRoutingKeyHeader] = delivery.RoutingKey
+	}
+
+	return amqp091.Publishing{
+		Headers:         headers,
+		ContentType:     delivery.ContentType,
+		ContentEncoding: delivery.ContentEncoding,
+		DeliveryMode:    delivery.DeliveryMode,
+		Priority:        delivery.Priority,
+		CorrelationId:   delivery.CorrelationId,
+		MessageId:       delivery.MessageId,
+		Timestamp:       delivery.Timestamp,
+		Type:            delivery.Type,
+		AppId:           delivery.AppId,
+		Body:            delivery.Body,
+	}
+}
+
+func (r *rabbitMQConsumer) nack(delivery amqp091.Delivery, requeue bool) error {
+	if r.rabbitmqConsumerOptions.AutoAck {
+		return nil
+	}
+
+	return delivery.Nack(false, requeue)
+}
+
+// GetAttemptCount returns the number of the failed deliveries of a message from its headers
+func GetAttemptCount(headers amqp091.Table) int {
+	switch value := headers[types.AttemptCountHeader].(type) {
+	case int:
+		return value
+	case int32:
+		return int(value)
+	case int64:
+		return int(value)
+	case string:
+		count, _ := strconv.Atoi(value)
+		return count
+	case nil:
+		return 0
+	default:
+		count, _ := strconv.Atoi(fmt.Sprint(value))
+		return count
+	}
+}
@@ -57,12 +57,15 @@ func Test_Consumer_With_Fake_Message(t *testing.T) {
 		options,
 		conn,
 		eventSerializer,
+		nil,
+		nil,
 		defaultLogger2.GetLogger(),
 	)
 	producerFactory := producer.NewProducerFactory(
 		options,
 		conn,
 		eventSerializer,
+		nil,
 		defaultLogger2.GetLogger(),
 	)
 
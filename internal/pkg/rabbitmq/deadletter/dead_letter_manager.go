@@ -0,0 +1,371 @@
+package deadletter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/rabbitmq/bus"
+	"github.com/reoden/go-NFT/pkg/rabbitmq/consumer"
+	consumerConfigurations "github.com/reoden/go-NFT/pkg/rabbitmq/consumer/configurations"
+	"github.com/reoden/go-NFT/pkg/rabbitmq/consumer/options"
+	"github.com/reoden/go-NFT/pkg/rabbitmq/types"
+
+	"emperror.dev/errors"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// DeadLetterManager manages the messages that are moved to the dead-letter queues of the consumers
+type DeadLetterManager interface {
+	// GetQueues returns the consumer queues that have a dead-letter queue with the count of their dead-lettered messages
+	GetQueues(ctx context.Context) ([]*DeadLetterQueue, error)
+	// GetMessages returns the first dead-lettered messages of a consumer queue without removing them
+	GetMessages(ctx context.Context, queue string, limit int) ([]*DeadLetterMessage, error)
+	// GetMessage returns a dead-lettered message of a consumer queue without removing it
+	GetMessage(ctx context.Context, queue string, messageId string) (*DeadLetterMessage, error)
+	// Replay sends a dead-lettered message back to its consumer queue
+	Replay(ctx context.Context, queue string, messageId string) error
+	// ReplayAll sends all the dead-lettered messages of a consumer queue back to the queue
+	ReplayAll(ctx context.Context, queue string) (int, error)
+	// Purge removes all the dead-lettered messages of a consumer queue
+	Purge(ctx context.Context, queue string) (int, error)
+}
+
+type DeadLetterQueue struct {
+	Queue           string
+	DeadLetterQueue string
+	MessageCount    int
+}
+
+type DeadLetterMessage struct {
+	MessageId     string
+	CorrelationId string
+	MessageType   string
+	ContentType   string
+	Body          string
+	Headers       map[string]interface{}
+	Exception     string
+	AttemptCount  int
+	FailedAt      time.Time
+	Queue         string
+}
+
+type deadLetterManager struct {
+	connection types.IConnection
+	bus        bus.RabbitmqBus
+	logger     logger.Logger
+}
+
+func NewDeadLetterManager(
+	connection types.IConnection,
+	bus bus.RabbitmqBus,
+	l logger.Logger,
+) DeadLetterManager {
+	return &deadLetterManager{connection: connection, bus: bus, logger: l}
+}
+
+func (m *deadLetterManager) GetQueues(ctx context.Context) ([]*DeadLetterQueue, error) {
+	var queues []*DeadLetterQueue
+
+	for _, consumerConfiguration := range m.deadLetterConsumers() {
+		queue := consumerConfiguration.QueueName()
+
+		count, err := m.messageCount(consumerConfiguration)
+		if err != nil {
+			return nil, err
+		}
+
+		queues = append(queues, &DeadLetterQueue{
+			Queue:           queue,
+			DeadLetterQueue: options.DeadLetterQueueName(queue),
+			MessageCount:    count,
+		})
+	}
+
+	return queues, nil
+}
+
+func (m *deadLetterManager) GetMessages(
+	ctx context.Context,
+	queue string,
+	limit int,
+) ([]*DeadLetterMessage, error) {
+	var messages []*DeadLetterMessage
+
+	err := m.browse(queue, func(_ *amqp091.Channel, delivery amqp091.Delivery) (bool, error) {
+		messages = append(messages, toDeadLetterMessage(queue, delivery))
+
+		return limit > 0 && len(messages) >= limit, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (m *deadLetterManager) GetMessage(
+	ctx context.Context,
+	queue string,
+	messageId string,
+) (*DeadLetterMessage, error) {
+	var message *DeadLetterMessage
+
+	err := m.browse(queue, func(_ *amqp091.Channel, delivery amqp091.Delivery) (bool, error) {
+		if delivery.MessageId != messageId {
+			return false, nil
+		}
+		message = toDeadLetterMessage(queue, delivery)
+
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if message == nil {
+		return nil, messageNotFoundError(queue, messageId)
+	}
+
+	return message, nil
+}
+
+func (m *deadLetterManager) Replay(ctx context.Context, queue string, messageId string) error {
+	replayed := false
+
+	err := m.browse(queue, func(ch *amqp091.Channel, delivery amqp091.Delivery) (bool, error) {
+		if delivery.MessageId != messageId {
+			return false, nil
+		}
+
+		if err := m.replay(ctx, ch, queue, delivery); err != nil {
+			return true, err
+		}
+		replayed = true
+
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !replayed {
+		return messageNotFoundError(queue, messageId)
+	}
+
+	return nil
+}
+
+func (m *deadLetterManager) ReplayAll(ctx context.Context, queue string) (int, error) {
+	consumerConfiguration, err := m.deadLetterConsumer(queue)
+	if err != nil {
+		return 0, err
+	}
+
+	// only the current messages are replayed, the messages that fail again during the replay stay in the dead-letter queue
+	count, err := m.messageCount(consumerConfiguration)
+	if err != nil {
+		return 0, err
+	}
+
+	replayed := 0
+	err = m.browse(queue, func(ch *amqp091.Channel, delivery amqp091.Delivery) (bool, error) {
+		if err := m.replay(ctx, ch, queue, delivery); err != nil {
+			return true, err
+		}
+		replayed++
+
+		return replayed >= count, nil
+	})
+
+	return replayed, err
+}
+
+func (m *deadLetterManager) Purge(ctx context.Context, queue string) (int, error) {
+	if _, err := m.deadLetterConsumer(queue); err != nil {
+		return 0, err
+	}
+
+	ch, err := m.connection.Channel()
+	if err != nil {
+		return 0, err
+	}
+	defer ch.Close()
+
+	count, err := ch.QueuePurge(options.DeadLetterQueueName(queue), false)
+	if err != nil {
+		return 0, errors.WrapIf(err, "error in purging the dead-letter queue")
+	}
+
+	m.logger.Infof("%d dead-lettered messages of the queue `%s` purged", count, queue)
+
+	return count, nil
+}
+
+// browse visits the dead-lettered messages of a queue in order until the visitor stops. The visited messages that
+// are not acknowledged by the visitor return to the dead-letter queue when the browsing channel is closed.
+func (m *deadLetterManager) browse(
+	queue string,
+	visit func(ch *amqp091.Channel, delivery amqp091.Delivery) (bool, error),
+) error {
+	if _, err := m.deadLetterConsumer(queue); err != nil {
+		return err
+	}
+
+	ch, err := m.connection.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+
+	if err := ch.Confirm(false); err != nil {
+		return err
+	}
+
+	for {
+		delivery, ok, err := ch.Get(options.DeadLetterQueueName(queue), false)
+		if err != nil {
+			return errors.WrapIf(err, "error in reading the dead-letter queue")
+		}
+		if !ok {
+			return nil
+		}
+
+		stop, err := visit(ch, delivery)
+		if err != nil {
+			return err
+		}
+		if stop {
+			return nil
+		}
+	}
+}
+
+// replay publishes the message directly to the consumer queue, so the other consumers of the original exchange don't receive it again
+func (m *deadLetterManager) replay(
+	ctx context.Context,
+	ch *amqp091.Channel,
+	queue string,
+	delivery amqp091.Delivery,
+) error {
+	headers := amqp091.Table{}
+	for key, value := range delivery.Headers {
+		headers[key] = value
+	}
+	for _, header := range types.FailureHeaders {
+		delete(headers, header)
+	}
+	delete(headers, "x-death")
+
+	confirmation, err := ch.PublishWithDeferredConfirmWithContext(ctx, "", queue, false, false, amqp091.Publishing{
+		Headers:         headers,
+		ContentType:     delivery.ContentType,
+		ContentEncoding: delivery.ContentEncoding,
+		DeliveryMode:    delivery.DeliveryMode,
+		Priority:        delivery.Priority,
+		CorrelationId:   delivery.CorrelationId,
+		MessageId:       delivery.MessageId,
+		Timestamp:       delivery.Timestamp,
+		Type:            delivery.Type,
+		AppId:           delivery.AppId,
+		Body:            delivery.Body,
+	})
+	if err != nil {
+		return errors.WrapIf(err, "error in replaying the dead-lettered message")
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return errors.WrapIf(err, "error in replaying the dead-lettered message")
+	}
+	if !acked {
+		return errors.Errorf("replaying the dead-lettered message with id `%s` is not confirmed by the broker", delivery.MessageId)
+	}
+
+	m.logger.Infof("dead-lettered message with id `%s` replayed to the queue `%s`", delivery.MessageId, queue)
+
+	return delivery.Ack(false)
+}
+
+func (m *deadLetterManager) messageCount(
+	consumerConfiguration *consumerConfigurations.RabbitMQConsumerConfiguration,
+) (int, error) {
+	// a passive declare of a missing queue closes the channel, so each queue is inspected on its own channel
+	ch, err := m.connection.Channel()
+	if err != nil {
+		return 0, err
+	}
+	defer ch.Close()
+
+	deadLetterQueue, err := ch.QueueDeclarePassive(
+		options.DeadLetterQueueName(consumerConfiguration.QueueName()),
+		consumerConfiguration.QueueOptions.Durable,
+		false,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		return 0, errors.WrapIf(err, "error in inspecting the dead-letter queue")
+	}
+
+	return deadLetterQueue.Messages, nil
+}
+
+func (m *deadLetterManager) deadLetterConsumers() []*consumerConfigurations.RabbitMQConsumerConfiguration {
+	var consumers []*consumerConfigurations.RabbitMQConsumerConfiguration
+
+	for _, consumerConfiguration := range m.bus.ConsumersConfigurations() {
+		if consumerConfiguration.RetryOptions != nil && consumerConfiguration.RetryOptions.DeadLetterEnabled {
+			consumers = append(consumers, consumerConfiguration)
+		}
+	}
+
+	return consumers
+}
+
+func (m *deadLetterManager) deadLetterConsumer(
+	queue string,
+) (*consumerConfigurations.RabbitMQConsumerConfiguration, error) {
+	for _, consumerConfiguration := range m.deadLetterConsumers() {
+		if consumerConfiguration.QueueName() == queue {
+			return consumerConfiguration, nil
+		}
+	}
+
+	return nil, customErrors.NewNotFoundError(
+		fmt.Sprintf("there is no dead-letter queue for the queue `%s`", queue),
+	)
+}
+
+func toDeadLetterMessage(queue string, delivery amqp091.Delivery) *DeadLetterMessage {
+	headers := map[string]interface{}{}
+	for key, value := range delivery.Headers {
+		headers[key] = value
+	}
+
+	exception, _ := delivery.Headers[types.ExceptionHeader].(string)
+	failedAtValue, _ := delivery.Headers[types.FailedAtHeader].(string)
+	failedAt, _ := time.Parse(time.RFC3339, failedAtValue)
+
+	return &DeadLetterMessage{
+		MessageId:     delivery.MessageId,
+		CorrelationId: delivery.CorrelationId,
+		MessageType:   delivery.Type,
+		ContentType:   delivery.ContentType,
+		Body:          string(delivery.Body),
+		Headers:       headers,
+		Exception:     exception,
+		AttemptCount:  consumer.GetAttemptCount(delivery.Headers),
+		FailedAt:      failedAt,
+		Queue:         queue,
+	}
+}
+
+func messageNotFoundError(queue string, messageId string) error {
+	return customErrors.NewNotFoundError(
+		fmt.Sprintf("dead-lettered message with id `%s` not found for the queue `%s`", messageId, queue),
+	)
+}
@@ -0,0 +1,199 @@
+package monitoring
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	messagingMetrics "github.com/reoden/go-NFT/pkg/core/messaging/otel/metrics"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/rabbitmq/bus"
+	"github.com/reoden/go-NFT/pkg/rabbitmq/config"
+	consumerConfigurations "github.com/reoden/go-NFT/pkg/rabbitmq/consumer/configurations"
+	"github.com/reoden/go-NFT/pkg/rabbitmq/types"
+	"github.com/reoden/go-NFT/pkg/web"
+
+	"emperror.dev/errors"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// QueueDepth the last collected state of a consumer queue
+type QueueDepth struct {
+	Queue string
+	// Messages number of the ready messages of the queue that are not delivered to the consumers yet
+	Messages    int
+	Consumers   int
+	CollectedAt time.Time
+}
+
+// QueueDepthCollector a background worker that collects the depth of the consumer queues and reports them as the
+// `messaging.queue.depth` and `messaging.queue.consumers` gauges
+type QueueDepthCollector interface {
+	web.Worker
+	// Collect inspects the consumer queues with passive declares and keeps their depth
+	Collect(ctx context.Context) error
+	// QueueDepths returns the last collected depth of the consumer queues
+	QueueDepths() []*QueueDepth
+}
+
+type queueDepthCollector struct {
+	web.Worker
+	connection   types.IConnection
+	bus          bus.RabbitmqBus
+	logger       logger.Logger
+	registration metric.Registration
+	mu           sync.RWMutex
+	depths       map[string]*QueueDepth
+}
+
+func NewQueueDepthCollector(
+	connection types.IConnection,
+	bus bus.RabbitmqBus,
+	rabbitmqOptions *config.RabbitmqOptions,
+	l logger.Logger,
+) (QueueDepthCollector, error) {
+	c := &queueDepthCollector{
+		connection: connection,
+		bus:        bus,
+		logger:     l,
+		depths:     map[string]*QueueDepth{},
+	}
+
+	registration, err := c.registerGauges()
+	if err != nil {
+		return nil, err
+	}
+	c.registration = registration
+
+	c.Worker = web.NewBackgroundWorker(
+		func(ctx context.Context) error {
+			ticker := time.NewTicker(rabbitmqOptions.QueueDepthCollectionIntervalDuration())
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-ticker.C:
+					if err := c.Collect(ctx); err != nil && ctx.Err() == nil {
+						l.Error(fmt.Sprintf("[QueueDepthCollector.Collect] error in collecting the queue depths: %v", err))
+					}
+				}
+			}
+		},
+		nil,
+	)
+
+	return c, nil
+}
+
+func (c *queueDepthCollector) Stop(ctx context.Context) error {
+	err := c.Worker.Stop(ctx)
+
+	return errors.Append(err, c.registration.Unregister())
+}
+
+func (c *queueDepthCollector) Collect(ctx context.Context) error {
+	var collectErr error
+
+	for _, consumerConfiguration := range c.bus.ConsumersConfigurations() {
+		depth, err := c.inspect(consumerConfiguration)
+		if err != nil {
+			collectErr = errors.Append(collectErr, err)
+			continue
+		}
+
+		c.mu.Lock()
+		c.depths[depth.Queue] = depth
+		c.mu.Unlock()
+	}
+
+	return collectErr
+}
+
+func (c *queueDepthCollector) QueueDepths() []*QueueDepth {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	depths := make([]*QueueDepth, 0, len(c.depths))
+	for _, depth := range c.depths {
+		depths = append(depths, depth)
+	}
+
+	sort.Slice(depths, func(i, j int) bool {
+		return depths[i].Queue < depths[j].Queue
+	})
+
+	return depths
+}
+
+func (c *queueDepthCollector) inspect(
+	consumerConfiguration *consumerConfigurations.RabbitMQConsumerConfiguration,
+) (*QueueDepth, error) {
+	// a passive declare of a missing queue closes the channel, so each queue is inspected on its own channel
+	ch, err := c.connection.Channel()
+	if err != nil {
+		return nil, err
+	}
+	defer ch.Close()
+
+	queue, err := ch.QueueDeclarePassive(
+		consumerConfiguration.QueueName(),
+		consumerConfiguration.QueueOptions.Durable,
+		consumerConfiguration.QueueOptions.AutoDelete,
+		consumerConfiguration.QueueOptions.Exclusive,
+		false,
+		nil,
+	)
+	if err != nil {
+		return nil, errors.WrapIff(err, "error in inspecting the queue `%s`", consumerConfiguration.QueueName())
+	}
+
+	return &QueueDepth{
+		Queue:       queue.Name,
+		Messages:    queue.Messages,
+		Consumers:   queue.Consumers,
+		CollectedAt: time.Now(),
+	}, nil
+}
+
+func (c *queueDepthCollector) registerGauges() (metric.Registration, error) {
+	depthGauge, err := messagingMetrics.MessagingMeter.Int64ObservableGauge(
+		"messaging.queue.depth",
+		metric.WithUnit("count"),
+		metric.WithDescription("Measures the number of the ready messages of the consumer queues"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	consumersGauge, err := messagingMetrics.MessagingMeter.Int64ObservableGauge(
+		"messaging.queue.consumers",
+		metric.WithUnit("count"),
+		metric.WithDescription("Measures the number of the consumers of the consumer queues"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return messagingMetrics.MessagingMeter.RegisterCallback(
+		func(ctx context.Context, observer metric.Observer) error {
+			for _, depth := range c.QueueDepths() {
+				attributes := metric.WithAttributes(
+					semconv.MessagingSystemKey.String("rabbitmq"),
+					semconv.MessagingDestinationName(depth.Queue),
+				)
+
+				observer.ObserveInt64(depthGauge, int64(depth.Messages), attributes)
+				observer.ObserveInt64(consumersGauge, int64(depth.Consumers), attributes)
+			}
+
+			return nil
+		},
+		depthGauge,
+		consumersGauge,
+	)
+}
@@ -0,0 +1,53 @@
+package monitoring
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/reoden/go-NFT/pkg/health/contracts"
+	"github.com/reoden/go-NFT/pkg/rabbitmq/config"
+
+	"emperror.dev/errors"
+)
+
+type queueLagHealthChecker struct {
+	collector       QueueDepthCollector
+	rabbitmqOptions *config.RabbitmqOptions
+}
+
+// NewQueueLagHealthChecker creates a health check that is down when a consumer queue has more ready messages than the
+// `MaxQueueDepth` option, the check uses the last collected depths of the queues
+func NewQueueLagHealthChecker(
+	collector QueueDepthCollector,
+	rabbitmqOptions *config.RabbitmqOptions,
+) contracts.Health {
+	return &queueLagHealthChecker{collector: collector, rabbitmqOptions: rabbitmqOptions}
+}
+
+func (q *queueLagHealthChecker) CheckHealth(ctx context.Context) error {
+	if q.rabbitmqOptions.MaxQueueDepth <= 0 {
+		return nil
+	}
+
+	var laggingQueues []string
+	for _, depth := range q.collector.QueueDepths() {
+		if depth.Messages > q.rabbitmqOptions.MaxQueueDepth {
+			laggingQueues = append(laggingQueues, fmt.Sprintf("%s (%d)", depth.Queue, depth.Messages))
+		}
+	}
+
+	if len(laggingQueues) > 0 {
+		return errors.Errorf(
+			"rabbitmq consumer queues have more than %d ready messages: %s",
+			q.rabbitmqOptions.MaxQueueDepth,
+			strings.Join(laggingQueues, ", "),
+		)
+	}
+
+	return nil
+}
+
+func (q *queueLagHealthChecker) GetHealthName() string {
+	return "rabbitmq-queue-lag"
+}
@@ -0,0 +1,54 @@
+//go:build unit
+// +build unit
+
+package monitoring
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reoden/go-NFT/pkg/rabbitmq/config"
+	"github.com/reoden/go-NFT/pkg/web"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeQueueDepthCollector struct {
+	web.Worker
+	depths []*QueueDepth
+}
+
+func (f *fakeQueueDepthCollector) Collect(ctx context.Context) error {
+	return nil
+}
+
+func (f *fakeQueueDepthCollector) QueueDepths() []*QueueDepth {
+	return f.depths
+}
+
+func Test_Queue_Lag_Health_Is_Down_When_Queue_Exceeds_Max_Depth(t *testing.T) {
+	collector := &fakeQueueDepthCollector{depths: []*QueueDepth{
+		{Queue: "product_created", Messages: 10},
+		{Queue: "product_updated", Messages: 250},
+	}}
+	checker := NewQueueLagHealthChecker(collector, &config.RabbitmqOptions{MaxQueueDepth: 100})
+
+	err := checker.CheckHealth(context.Background())
+
+	assert.ErrorContains(t, err, "product_updated (250)")
+	assert.NotContains(t, err.Error(), "product_created")
+}
+
+func Test_Queue_Lag_Health_Is_Up_Below_Max_Depth(t *testing.T) {
+	collector := &fakeQueueDepthCollector{depths: []*QueueDepth{{Queue: "product_created", Messages: 10}}}
+	checker := NewQueueLagHealthChecker(collector, &config.RabbitmqOptions{MaxQueueDepth: 100})
+
+	assert.NoError(t, checker.CheckHealth(context.Background()))
+}
+
+func Test_Queue_Lag_Health_Is_Disabled_Without_Max_Depth(t *testing.T) {
+	collector := &fakeQueueDepthCollector{depths: []*QueueDepth{{Queue: "product_created", Messages: 5000}}}
+	checker := NewQueueLagHealthChecker(collector, &config.RabbitmqOptions{})
+
+	assert.NoError(t, checker.CheckHealth(context.Background()))
+}
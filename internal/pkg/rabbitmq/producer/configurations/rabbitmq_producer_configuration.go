@@ -19,6 +19,8 @@ type RabbitMQProducerConfiguration struct {
 	Expiration          string
 	ReplyTo             string
 	ContentEncoding     string
+	// ContentType content type of the serializer of the message, the default message serializer is used when it is empty
+	ContentType string
 }
 
 func NewDefaultRabbitMQProducerConfiguration(
@@ -18,6 +18,7 @@ type RabbitMQProducerConfigurationBuilder interface {
 	WithExpiration(expiration string) RabbitMQProducerConfigurationBuilder
 	WithReplyTo(replyTo string) RabbitMQProducerConfigurationBuilder
 	WithContentEncoding(contentEncoding string) RabbitMQProducerConfigurationBuilder
+	WithContentType(contentType string) RabbitMQProducerConfigurationBuilder
 	Build() *RabbitMQProducerConfiguration
 }
 
@@ -117,6 +118,14 @@ func (b *rabbitMQProducerConfigurationBuilder) WithContentEncoding(
 	return b
 }
 
+// WithContentType sets the content type of the serializer of the message, like `protobuf.ContentType` or `msgpack.ContentType`
+func (b *rabbitMQProducerConfigurationBuilder) WithContentType(
+	contentType string,
+) RabbitMQProducerConfigurationBuilder {
+	b.rabbitmqProducerOptions.ContentType = contentType
+	return b
+}
+
 func (b *rabbitMQProducerConfigurationBuilder) Build() *RabbitMQProducerConfiguration {
 	return b.rabbitmqProducerOptions
 }
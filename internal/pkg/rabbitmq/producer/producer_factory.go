@@ -12,23 +12,26 @@ import (
 )
 
 type producerFactory struct {
-	connection      types2.IConnection
-	logger          logger.Logger
-	eventSerializer serializer.MessageSerializer
-	rabbitmqOptions *config.RabbitmqOptions
+	connection         types2.IConnection
+	logger             logger.Logger
+	eventSerializer    serializer.MessageSerializer
+	serializerRegistry serializer.MessageSerializerRegistry
+	rabbitmqOptions    *config.RabbitmqOptions
 }
 
 func NewProducerFactory(
 	rabbitmqOptions *config.RabbitmqOptions,
 	connection types2.IConnection,
 	eventSerializer serializer.MessageSerializer,
+	serializerRegistry serializer.MessageSerializerRegistry,
 	l logger.Logger,
 ) producercontracts.ProducerFactory {
 	return &producerFactory{
-		rabbitmqOptions: rabbitmqOptions,
-		logger:          l,
-		connection:      connection,
-		eventSerializer: eventSerializer,
+		serializerRegistry: serializerRegistry,
+		rabbitmqOptions:    rabbitmqOptions,
+		logger:             l,
+		connection:         connection,
+		eventSerializer:    eventSerializer,
 	}
 }
 
@@ -42,5 +45,6 @@ func (p *producerFactory) CreateProducer(
 		rabbitmqProducersConfiguration,
 		p.logger,
 		p.eventSerializer,
+		p.serializerRegistry,
 		isProducedNotifications...)
 }
@@ -5,10 +5,12 @@ import (
 	"time"
 
 	messageHeader "github.com/reoden/go-NFT/pkg/core/messaging/messageheader"
+	messagingMetrics "github.com/reoden/go-NFT/pkg/core/messaging/otel/metrics"
 	producer3 "github.com/reoden/go-NFT/pkg/core/messaging/otel/tracing/producer"
 	"github.com/reoden/go-NFT/pkg/core/messaging/producer"
 	types2 "github.com/reoden/go-NFT/pkg/core/messaging/types"
 	"github.com/reoden/go-NFT/pkg/core/messaging/utils"
+	"github.com/reoden/go-NFT/pkg/core/messaging/versioning"
 	"github.com/reoden/go-NFT/pkg/core/metadata"
 	"github.com/reoden/go-NFT/pkg/core/serializer"
 	"github.com/reoden/go-NFT/pkg/logger"
@@ -28,6 +30,7 @@ type rabbitMQProducer struct {
 	rabbitmqOptions         *config.RabbitmqOptions
 	connection              types.IConnection
 	messageSerializer       serializer.MessageSerializer
+	serializerRegistry      serializer.MessageSerializerRegistry
 	producersConfigurations map[string]*configurations.RabbitMQProducerConfiguration
 	isProducedNotifications []func(message types2.IMessage)
 }
@@ -38,6 +41,7 @@ func NewRabbitMQProducer(
 	rabbitmqProducersConfiguration map[string]*configurations.RabbitMQProducerConfiguration,
 	logger logger.Logger,
 	eventSerializer serializer.MessageSerializer,
+	serializerRegistry serializer.MessageSerializerRegistry,
 	isProducedNotifications ...func(message types2.IMessage),
 ) (producer.Producer, error) {
 	p := &rabbitMQProducer{
@@ -45,6 +49,7 @@ func NewRabbitMQProducer(
 		rabbitmqOptions:         cfg,
 		connection:              connection,
 		messageSerializer:       eventSerializer,
+		serializerRegistry:      serializerRegistry,
 		producersConfigurations: rabbitmqProducersConfiguration,
 	}
 
@@ -77,7 +82,7 @@ func (r *rabbitMQProducer) PublishMessageWithTopicName(
 	message types2.IMessage,
 	meta metadata.Metadata,
 	topicOrExchangeName string,
-) error {
+) (err error) {
 	producerConfiguration := r.getProducerConfigurationByMessage(message)
 
 	if producerConfiguration == nil {
@@ -103,7 +108,21 @@ func (r *rabbitMQProducer) PublishMessageWithTopicName(
 		routingKey = utils.GetRoutingKey(message)
 	}
 
-	meta = r.getMetadata(message, meta)
+	metricsOptions := &messagingMetrics.MessagingMetricsOptions{
+		MessagingSystem: "rabbitmq",
+		Destination:     exchange,
+		MessageType:     utils.GetMessageTypeName(message),
+	}
+	defer func() {
+		messagingMetrics.RecordPublished(ctx, metricsOptions, err)
+	}()
+
+	messageSerializer, err := r.getMessageSerializer(producerConfiguration)
+	if err != nil {
+		return err
+	}
+
+	meta = r.getMetadata(message, meta, messageSerializer.ContentType())
 
 	producerOptions := &producer3.ProducerTracingOptions{
 		MessagingSystem: "rabbitmq",
@@ -114,7 +133,7 @@ func (r *rabbitMQProducer) PublishMessageWithTopicName(
 		},
 	}
 
-	serializedObj, err := r.messageSerializer.Serialize(message)
+	serializedObj, err := messageSerializer.Serialize(message)
 	if err != nil {
 		return err
 	}
@@ -166,7 +185,7 @@ func (r *rabbitMQProducer) PublishMessageWithTopicName(
 		MessageId:       message.GeMessageId(),
 		Timestamp:       time.Now(),
 		Headers:         metadata.MetadataToMap(meta),
-		Type:            message.GetMessageTypeName(), // typeMapper.GetTypeName(message) - just message type name not full type name because in other side package name for type could be different
+		Type:            utils.GetMessageTypeName(message), // just message type name not full type name because in other side package name for type could be different
 		ContentType:     serializedObj.ContentType,
 		Body:            serializedObj.Data,
 		DeliveryMode:    producerConfiguration.DeliveryMode,
@@ -210,12 +229,14 @@ func (r *rabbitMQProducer) PublishMessageWithTopicName(
 func (r *rabbitMQProducer) getMetadata(
 	message types2.IMessage,
 	meta metadata.Metadata,
+	contentType string,
 ) metadata.Metadata {
 	meta = metadata.FromMetadata(meta)
 
 	// just message type name not full type name because in other side package name for type could be different
-	messageHeader.SetMessageType(meta, message.GetMessageTypeName())
-	messageHeader.SetMessageContentType(meta, r.messageSerializer.ContentType())
+	messageHeader.SetMessageType(meta, utils.GetMessageTypeName(message))
+	messageHeader.SetMessageContentType(meta, contentType)
+	messageHeader.SetSchemaVersion(meta, versioning.GetSchemaVersion(message))
 
 	if messageHeader.GetMessageId(meta) == "" {
 		messageHeader.SetMessageId(meta, message.GeMessageId())
@@ -234,6 +255,25 @@ func (r *rabbitMQProducer) getMetadata(
 	return meta
 }
 
+// getMessageSerializer returns the serializer of the content type of the producer configuration
+func (r *rabbitMQProducer) getMessageSerializer(
+	producerConfiguration *configurations.RabbitMQProducerConfiguration,
+) (serializer.MessageSerializer, error) {
+	contentType := producerConfiguration.ContentType
+	if contentType == "" || contentType == r.messageSerializer.ContentType() {
+		return r.messageSerializer, nil
+	}
+
+	if r.serializerRegistry == nil {
+		return nil, errors.Errorf(
+			"there is no message serializer registry for the content type `%s` of the message",
+			contentType,
+		)
+	}
+
+	return r.serializerRegistry.Get(contentType)
+}
+
 func (r *rabbitMQProducer) ensureExchange(
 	producersConfigurations *configurations.RabbitMQProducerConfiguration,
 	channel *amqp091.Channel,
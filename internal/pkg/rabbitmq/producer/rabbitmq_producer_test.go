@@ -66,6 +66,7 @@ func Test_Publish_Message(t *testing.T) {
 		options,
 		conn,
 		eventSerializer,
+		nil,
 		defaultLogger.GetLogger(),
 	)
 
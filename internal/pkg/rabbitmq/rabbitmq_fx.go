@@ -12,7 +12,10 @@ import (
 	"github.com/reoden/go-NFT/pkg/rabbitmq/bus"
 	"github.com/reoden/go-NFT/pkg/rabbitmq/config"
 	rabbitmqconsumer "github.com/reoden/go-NFT/pkg/rabbitmq/consumer"
+	"github.com/reoden/go-NFT/pkg/rabbitmq/deadletter"
+	"github.com/reoden/go-NFT/pkg/rabbitmq/monitoring"
 	rabbitmqproducer "github.com/reoden/go-NFT/pkg/rabbitmq/producer"
+	"github.com/reoden/go-NFT/pkg/rabbitmq/requestreply"
 	"github.com/reoden/go-NFT/pkg/rabbitmq/types"
 
 	"go.uber.org/fx"
@@ -43,12 +46,27 @@ var (
 			fx.As(new(bus2.Bus)),
 			fx.As(new(bus.RabbitmqBus)),
 		)),
-		fx.Provide(rabbitmqconsumer.NewConsumerFactory),
-		fx.Provide(rabbitmqproducer.NewProducerFactory),
+		fx.Provide(fx.Annotate(
+			rabbitmqconsumer.NewConsumerFactory,
+			fx.ParamTags(``, ``, ``, `optional:"true"`, `optional:"true"`),
+		)),
+		fx.Provide(fx.Annotate(
+			rabbitmqproducer.NewProducerFactory,
+			fx.ParamTags(``, ``, ``, `optional:"true"`),
+		)),
+		fx.Provide(deadletter.NewDeadLetterManager),
+		fx.Provide(requestreply.NewRabbitMQRequester),
+		fx.Provide(requestreply.NewRabbitMQResponder),
+		fx.Provide(monitoring.NewQueueDepthCollector),
 		fx.Provide(fx.Annotate(
 			NewRabbitMQHealthChecker,
 			fx.As(new(contracts.Health)),
 			fx.ResultTags(fmt.Sprintf(`group:"%s"`, "healths")),
+		)),
+		fx.Provide(fx.Annotate(
+			monitoring.NewQueueLagHealthChecker,
+			fx.As(new(contracts.Health)),
+			fx.ResultTags(fmt.Sprintf(`group:"%s"`, "healths")),
 		)))
 
 	// - execute after registering all of our provided
@@ -57,6 +75,7 @@ var (
 	// - return value will be discarded and can not be provided
 	rabbitmqInvokes = fx.Options(
 		fx.Invoke(registerHooks),
+		fx.Invoke(registerQueueDepthCollectorHooks),
 	) //nolint:gochecknoglobals
 )
 
@@ -109,3 +128,25 @@ func registerHooks(
 		},
 	})
 }
+
+func registerQueueDepthCollectorHooks(
+	lc fx.Lifecycle,
+	collector monitoring.QueueDepthCollector,
+	rabbitmqOptions *config.RabbitmqOptions,
+) {
+	if rabbitmqOptions.AutoStart == false {
+		return
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			// the start context is canceled after the startup, so the collector runs on its own context
+			collector.Start(context.Background())
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return collector.Stop(ctx)
+		},
+	})
+}
@@ -0,0 +1,271 @@
+package requestreply
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/core/messaging/bus"
+	messageHeader "github.com/reoden/go-NFT/pkg/core/messaging/messageheader"
+	messageRequestReply "github.com/reoden/go-NFT/pkg/core/messaging/requestreply"
+	messagingTypes "github.com/reoden/go-NFT/pkg/core/messaging/types"
+	"github.com/reoden/go-NFT/pkg/core/messaging/utils"
+	"github.com/reoden/go-NFT/pkg/core/metadata"
+	"github.com/reoden/go-NFT/pkg/core/serializer"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/rabbitmq/config"
+	producerConfigurations "github.com/reoden/go-NFT/pkg/rabbitmq/producer/configurations"
+	"github.com/reoden/go-NFT/pkg/rabbitmq/types"
+
+	"emperror.dev/errors"
+	"github.com/rabbitmq/amqp091-go"
+	uuid "github.com/satori/go.uuid"
+)
+
+// directReplyToQueue pseudo queue of the direct reply-to of rabbitmq, https://www.rabbitmq.com/docs/direct-reply-to
+const directReplyToQueue = "amq.rabbitmq.reply-to"
+
+// rabbitMQRequester receives the replies of its requests on a reply queue that is created on the first request. The
+// reply queue is a temporary exclusive queue of the requester, or the direct reply-to of the broker when it is enabled.
+type rabbitMQRequester struct {
+	mu                sync.Mutex
+	connection        types.IConnection
+	bus               bus.Bus
+	messageSerializer serializer.MessageSerializer
+	rabbitmqOptions   *config.RabbitmqOptions
+	logger            logger.Logger
+	channel           *amqp091.Channel
+	replyQueue        string
+	pending           map[string]chan messagingTypes.MessageConsumeContext
+}
+
+func NewRabbitMQRequester(
+	connection types.IConnection,
+	bus bus.Bus,
+	messageSerializer serializer.MessageSerializer,
+	rabbitmqOptions *config.RabbitmqOptions,
+	l logger.Logger,
+) messageRequestReply.Requester {
+	return &rabbitMQRequester{
+		connection:        connection,
+		bus:               bus,
+		messageSerializer: messageSerializer,
+		rabbitmqOptions:   rabbitmqOptions,
+		logger:            l,
+		pending:           map[string]chan messagingTypes.MessageConsumeContext{},
+	}
+}
+
+func (r *rabbitMQRequester) Request(
+	ctx context.Context,
+	request messagingTypes.IMessage,
+	meta metadata.Metadata,
+) (messagingTypes.MessageConsumeContext, error) {
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.rabbitmqOptions.RequestTimeoutDuration())
+		defer cancel()
+	}
+
+	channel, replyQueue, err := r.ensureReplyConsumer()
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in creating the reply consumer")
+	}
+
+	requestMeta := metadata.Metadata{}
+	for key, value := range meta {
+		requestMeta[key] = value
+	}
+
+	correlationId := uuid.NewV4().String()
+	messageHeader.SetCorrelationId(requestMeta, correlationId)
+	messageHeader.SetReplyTo(requestMeta, replyQueue)
+
+	replies := make(chan messagingTypes.MessageConsumeContext, 1)
+	r.register(correlationId, replies)
+	defer r.unregister(correlationId)
+
+	if replyQueue == directReplyToQueue {
+		err = r.publishOnReplyChannel(ctx, channel, request, requestMeta)
+	} else {
+		err = r.bus.PublishMessage(ctx, request, requestMeta)
+	}
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in publishing the request")
+	}
+
+	select {
+	case reply := <-replies:
+		return reply, nil
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, errors.WithMessagef(
+				messageRequestReply.ErrRequestTimeout,
+				"request `%s` with correlation id `%s`",
+				utils.GetMessageTypeName(request),
+				correlationId,
+			)
+		}
+
+		return nil, ctx.Err()
+	}
+}
+
+// ensureReplyConsumer starts consuming the reply queue if it is not consumed, the replies are dispatched to the waiting requests
+func (r *rabbitMQRequester) ensureReplyConsumer() (*amqp091.Channel, string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.channel != nil && !r.channel.IsClosed() {
+		return r.channel, r.replyQueue, nil
+	}
+
+	channel, err := r.connection.Channel()
+	if err != nil {
+		return nil, "", err
+	}
+
+	replyQueue := directReplyToQueue
+	if !r.rabbitmqOptions.DirectReplyTo {
+		queue, err := channel.QueueDeclare("", false, true, true, false, nil)
+		if err != nil {
+			_ = channel.Close()
+			return nil, "", err
+		}
+		replyQueue = queue.Name
+	}
+
+	// the direct reply-to is only consumed in the no-ack mode
+	deliveries, err := channel.Consume(replyQueue, "", true, true, false, false, nil)
+	if err != nil {
+		_ = channel.Close()
+		return nil, "", err
+	}
+
+	r.channel = channel
+	r.replyQueue = replyQueue
+
+	go func() {
+		for delivery := range deliveries {
+			r.dispatch(delivery)
+		}
+	}()
+
+	return channel, replyQueue, nil
+}
+
+// publishOnReplyChannel publishes the request on the channel of the direct reply-to, the broker only accepts the direct
+// reply-to requests that are published on the channel that consumes the replies
+func (r *rabbitMQRequester) publishOnReplyChannel(
+	ctx context.Context,
+	channel *amqp091.Channel,
+	request messagingTypes.IMessage,
+	meta metadata.Metadata,
+) error {
+	producerConfiguration := producerConfigurations.NewDefaultRabbitMQProducerConfiguration(request)
+
+	serializedObj, err := r.messageSerializer.Serialize(request)
+	if err != nil {
+		return err
+	}
+
+	messageHeader.SetMessageId(meta, request.GeMessageId())
+	messageHeader.SetMessageCreated(meta, request.GetCreated())
+	messageHeader.SetMessageName(meta, utils.GetMessageName(request))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err = channel.ExchangeDeclare(
+		producerConfiguration.ExchangeOptions.Name,
+		string(producerConfiguration.ExchangeOptions.Type),
+		producerConfiguration.ExchangeOptions.Durable,
+		producerConfiguration.ExchangeOptions.AutoDelete,
+		false,
+		false,
+		producerConfiguration.ExchangeOptions.Args,
+	)
+	if err != nil {
+		return err
+	}
+
+	return channel.PublishWithContext(
+		ctx,
+		producerConfiguration.ExchangeOptions.Name,
+		producerConfiguration.RoutingKey,
+		false,
+		false,
+		amqp091.Publishing{
+			CorrelationId: messageHeader.GetCorrelationId(meta),
+			MessageId:     request.GeMessageId(),
+			Timestamp:     time.Now(),
+			Headers:       metadata.MetadataToMap(meta),
+			Type:          utils.GetMessageTypeName(request),
+			ContentType:   serializedObj.ContentType,
+			Body:          serializedObj.Data,
+			ReplyTo:       directReplyToQueue,
+		},
+	)
+}
+
+func (r *rabbitMQRequester) dispatch(delivery amqp091.Delivery) {
+	r.mu.Lock()
+	replies, exists := r.pending[delivery.CorrelationId]
+	r.mu.Unlock()
+
+	if !exists {
+		r.logger.Infof("reply with correlation id `%s` has no waiting request, it is dropped", delivery.CorrelationId)
+		return
+	}
+
+	meta := metadata.FromMetadata(metadata.MapToMetadata(delivery.Headers))
+
+	var message messagingTypes.IMessage
+	if messageHeader.GetReplyError(meta) == "" && len(delivery.Body) > 0 {
+		deserialized, err := r.messageSerializer.Deserialize(delivery.Body, delivery.Type, delivery.ContentType)
+		if err != nil {
+			// the request fails with the error reply instead of a successful reply without a message
+			replyErr := fmt.Sprintf("error in deserializing the reply of type `%s`: %v", delivery.Type, err)
+			r.logger.Error(replyErr)
+			messageHeader.SetReplyError(meta, replyErr)
+		} else {
+			message = deserialized
+		}
+	}
+
+	reply := messagingTypes.NewMessageConsumeContext(
+		message,
+		meta,
+		delivery.ContentType,
+		delivery.Type,
+		delivery.Timestamp,
+		delivery.DeliveryTag,
+		delivery.MessageId,
+		delivery.CorrelationId,
+	)
+
+	// the replies channel keeps one reply, a duplicated reply never blocks the dispatch of the other replies
+	select {
+	case replies <- reply:
+	default:
+		r.logger.Infof(
+			"reply with correlation id `%s` is already received, the duplicated reply is dropped",
+			delivery.CorrelationId,
+		)
+	}
+}
+
+func (r *rabbitMQRequester) register(correlationId string, replies chan messagingTypes.MessageConsumeContext) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pending[correlationId] = replies
+}
+
+func (r *rabbitMQRequester) unregister(correlationId string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.pending, correlationId)
+}
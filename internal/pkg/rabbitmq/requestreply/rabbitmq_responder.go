@@ -0,0 +1,93 @@
+package requestreply
+
+import (
+	"context"
+	"time"
+
+	messageHeader "github.com/reoden/go-NFT/pkg/core/messaging/messageheader"
+	messageRequestReply "github.com/reoden/go-NFT/pkg/core/messaging/requestreply"
+	messagingTypes "github.com/reoden/go-NFT/pkg/core/messaging/types"
+	"github.com/reoden/go-NFT/pkg/core/messaging/utils"
+	"github.com/reoden/go-NFT/pkg/core/metadata"
+	"github.com/reoden/go-NFT/pkg/core/serializer"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/rabbitmq/types"
+
+	"emperror.dev/errors"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// rabbitMQResponder publishes the replies to the reply queue of the requester through the default exchange
+type rabbitMQResponder struct {
+	connection        types.IConnection
+	messageSerializer serializer.MessageSerializer
+	logger            logger.Logger
+}
+
+func NewRabbitMQResponder(
+	connection types.IConnection,
+	messageSerializer serializer.MessageSerializer,
+	l logger.Logger,
+) messageRequestReply.Responder {
+	return &rabbitMQResponder{connection: connection, messageSerializer: messageSerializer, logger: l}
+}
+
+func (r *rabbitMQResponder) Reply(
+	ctx context.Context,
+	requestContext messagingTypes.MessageConsumeContext,
+	response messagingTypes.IMessage,
+) error {
+	meta, err := messageRequestReply.ReplyMetadata(requestContext, response)
+	if err != nil {
+		return err
+	}
+
+	serializedObj, err := r.messageSerializer.Serialize(response)
+	if err != nil {
+		return err
+	}
+
+	return r.publish(ctx, meta, amqp091.Publishing{
+		MessageId:   response.GeMessageId(),
+		Type:        utils.GetMessageTypeName(response),
+		ContentType: serializedObj.ContentType,
+		Body:        serializedObj.Data,
+	})
+}
+
+func (r *rabbitMQResponder) ReplyError(
+	ctx context.Context,
+	requestContext messagingTypes.MessageConsumeContext,
+	replyErr error,
+) error {
+	meta, err := messageRequestReply.ReplyMetadata(requestContext, nil)
+	if err != nil {
+		return err
+	}
+	messageHeader.SetReplyError(meta, replyErr.Error())
+
+	return r.publish(ctx, meta, amqp091.Publishing{ContentType: r.messageSerializer.ContentType()})
+}
+
+func (r *rabbitMQResponder) publish(ctx context.Context, meta metadata.Metadata, publishing amqp091.Publishing) error {
+	channel, err := r.connection.Channel()
+	if err != nil {
+		return err
+	}
+	defer channel.Close()
+
+	replyTo := messageHeader.GetReplyTo(meta)
+
+	publishing.CorrelationId = messageHeader.GetCorrelationId(meta)
+	publishing.Timestamp = time.Now()
+	publishing.Headers = metadata.MetadataToMap(meta)
+
+	err = channel.PublishWithContext(ctx, "", replyTo, false, false, publishing)
+	if err != nil {
+		return errors.WrapIf(err, "error in publishing the reply")
+	}
+
+	r.logger.Infof("reply with correlation id `%s` sent to `%s`", publishing.CorrelationId, replyTo)
+
+	return nil
+}
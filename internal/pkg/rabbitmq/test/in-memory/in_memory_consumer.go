@@ -0,0 +1,242 @@
+package in_memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	consumer2 "github.com/reoden/go-NFT/pkg/core/messaging/consumer"
+	"github.com/reoden/go-NFT/pkg/core/messaging/pipeline"
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	consumerConfigurations "github.com/reoden/go-NFT/pkg/rabbitmq/consumer/configurations"
+
+	"emperror.dev/errors"
+	"github.com/avast/retry-go"
+)
+
+// inMemoryConsumer handles the messages of a consumer configuration, in the async mode the messages wait in the queue of
+// the consumer until one of its workers takes them
+type inMemoryConsumer struct {
+	mu                      sync.Mutex
+	harnesses               *RabbitmqInMemoryHarnesses
+	configuration           *consumerConfigurations.RabbitMQConsumerConfiguration
+	handlers                []consumer2.ConsumerHandler
+	queue                   []types.MessageConsumeContext
+	signal                  chan struct{}
+	cancel                  context.CancelFunc
+	workers                 sync.WaitGroup
+	isConsumedNotifications []func(message types.IMessage)
+}
+
+func newInMemoryConsumer(
+	harnesses *RabbitmqInMemoryHarnesses,
+	configuration *consumerConfigurations.RabbitMQConsumerConfiguration,
+) *inMemoryConsumer {
+	return &inMemoryConsumer{
+		harnesses:     harnesses,
+		configuration: configuration,
+		handlers:      configuration.Handlers,
+		signal:        make(chan struct{}, 1),
+	}
+}
+
+func (c *inMemoryConsumer) Start(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cancel != nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	c.cancel = cancel
+
+	workers := c.configuration.ConcurrencyLimit
+	if workers < 1 {
+		workers = 1
+	}
+
+	for i := 0; i < workers; i++ {
+		c.workers.Add(1)
+		go c.work(ctx)
+	}
+
+	return nil
+}
+
+func (c *inMemoryConsumer) Stop() error {
+	c.mu.Lock()
+	cancel := c.cancel
+	c.cancel = nil
+	c.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+
+	cancel()
+	c.workers.Wait()
+
+	return nil
+}
+
+func (c *inMemoryConsumer) ConnectHandler(handler consumer2.ConsumerHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.handlers = append(c.handlers, handler)
+}
+
+func (c *inMemoryConsumer) IsConsumed(h func(message types.IMessage)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.isConsumedNotifications = append(c.isConsumedNotifications, h)
+}
+
+func (c *inMemoryConsumer) GetName() string {
+	return c.configuration.Name
+}
+
+func (c *inMemoryConsumer) enqueue(consumeContext types.MessageConsumeContext) {
+	c.harnesses.addPending(1)
+
+	c.mu.Lock()
+	c.queue = append(c.queue, consumeContext)
+	c.mu.Unlock()
+
+	c.wakeUp()
+}
+
+func (c *inMemoryConsumer) dequeue() (types.MessageConsumeContext, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.queue) == 0 {
+		return nil, false
+	}
+
+	consumeContext := c.queue[0]
+	c.queue = c.queue[1:]
+
+	// the other workers may have messages to take
+	if len(c.queue) > 0 {
+		c.wakeUp()
+	}
+
+	return consumeContext, true
+}
+
+func (c *inMemoryConsumer) wakeUp() {
+	select {
+	case c.signal <- struct{}{}:
+	default:
+	}
+}
+
+func (c *inMemoryConsumer) work(ctx context.Context) {
+	defer c.workers.Done()
+
+	for {
+		consumeContext, ok := c.dequeue()
+		if !ok {
+			select {
+			case <-ctx.Done():
+				return
+			case <-c.signal:
+				continue
+			}
+		}
+
+		c.deliver(ctx, consumeContext)
+		c.harnesses.addPending(-1)
+	}
+}
+
+// deliver runs the handlers of the message inside the pipelines with the immediate retries of the consumer
+func (c *inMemoryConsumer) deliver(ctx context.Context, consumeContext types.MessageConsumeContext) {
+	attempts := 1
+	retryOptions := c.configuration.RetryOptions
+	if retryOptions != nil && retryOptions.ImmediateRetryCount > 0 {
+		attempts = retryOptions.ImmediateRetryCount
+	}
+
+	var delay time.Duration
+	if retryOptions != nil {
+		delay = retryOptions.ImmediateRetryDelay
+	}
+
+	err := retry.Do(
+		func() error {
+			return c.handle(ctx, consumeContext)
+		},
+		retry.Attempts(uint(attempts)),
+		retry.Delay(delay),
+		retry.DelayType(retry.BackOffDelay),
+		retry.LastErrorOnly(true),
+		retry.Context(ctx),
+	)
+	if err != nil {
+		c.harnesses.logger.Errorf(
+			"message with id `%s` failed on the consumer `%s`: %v",
+			consumeContext.MessageId(),
+			c.GetName(),
+			err,
+		)
+
+		c.harnesses.failed(&FailedMessage{
+			Message:        consumeContext.Message(),
+			ConsumeContext: consumeContext,
+			Consumer:       c.GetName(),
+			Queue:          c.configuration.QueueName(),
+			Err:            err,
+			AttemptCount:   attempts,
+			FailedAt:       time.Now(),
+		})
+
+		return
+	}
+
+	c.mu.Lock()
+	notifications := c.isConsumedNotifications
+	c.mu.Unlock()
+
+	for _, notification := range notifications {
+		if notification != nil {
+			notification(consumeContext.Message())
+		}
+	}
+
+	c.harnesses.consumed(consumeContext.Message())
+}
+
+func (c *inMemoryConsumer) handle(ctx context.Context, consumeContext types.MessageConsumeContext) error {
+	c.mu.Lock()
+	handlers := c.handlers
+	c.mu.Unlock()
+
+	var next pipeline.ConsumerHandlerFunc = func(ctx context.Context) error {
+		for _, handler := range handlers {
+			if err := handler.Handle(ctx, consumeContext); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	// the first pipeline is the outermost one, like the rabbitmq consumer
+	for i := len(c.configuration.Pipelines) - 1; i >= 0; i-- {
+		pipe := c.configuration.Pipelines[i]
+		inner := next
+		next = func(ctx context.Context) error {
+			return pipe.Handle(ctx, consumeContext, inner)
+		}
+	}
+
+	if err := next(ctx); err != nil {
+		return errors.WrapIf(err, "error in handling the message")
+	}
+
+	return nil
+}
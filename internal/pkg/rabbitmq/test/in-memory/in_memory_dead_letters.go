@@ -0,0 +1,203 @@
+package in_memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/rabbitmq/consumer/options"
+	"github.com/reoden/go-NFT/pkg/rabbitmq/deadletter"
+)
+
+// inMemoryDeadLetterManager manages the failed messages of the in-memory consumers like the dead-lettered messages of the broker
+type inMemoryDeadLetterManager struct {
+	harnesses *RabbitmqInMemoryHarnesses
+}
+
+func NewInMemoryDeadLetterManager(harnesses *RabbitmqInMemoryHarnesses) deadletter.DeadLetterManager {
+	return &inMemoryDeadLetterManager{harnesses: harnesses}
+}
+
+func (m *inMemoryDeadLetterManager) GetQueues(ctx context.Context) ([]*deadletter.DeadLetterQueue, error) {
+	var queues []*deadletter.DeadLetterQueue
+
+	for _, c := range m.deadLetterConsumers() {
+		queue := c.configuration.QueueName()
+		queues = append(queues, &deadletter.DeadLetterQueue{
+			Queue:           queue,
+			DeadLetterQueue: options.DeadLetterQueueName(queue),
+			MessageCount:    len(m.failedMessages(queue)),
+		})
+	}
+
+	return queues, nil
+}
+
+func (m *inMemoryDeadLetterManager) GetMessages(
+	ctx context.Context,
+	queue string,
+	limit int,
+) ([]*deadletter.DeadLetterMessage, error) {
+	if _, err := m.deadLetterConsumer(queue); err != nil {
+		return nil, err
+	}
+
+	var messages []*deadletter.DeadLetterMessage
+	for _, failedMessage := range m.failedMessages(queue) {
+		if limit > 0 && len(messages) >= limit {
+			break
+		}
+		messages = append(messages, toDeadLetterMessage(failedMessage))
+	}
+
+	return messages, nil
+}
+
+func (m *inMemoryDeadLetterManager) GetMessage(
+	ctx context.Context,
+	queue string,
+	messageId string,
+) (*deadletter.DeadLetterMessage, error) {
+	if _, err := m.deadLetterConsumer(queue); err != nil {
+		return nil, err
+	}
+
+	for _, failedMessage := range m.failedMessages(queue) {
+		if failedMessage.ConsumeContext.MessageId() == messageId {
+			return toDeadLetterMessage(failedMessage), nil
+		}
+	}
+
+	return nil, messageNotFoundError(queue, messageId)
+}
+
+func (m *inMemoryDeadLetterManager) Replay(ctx context.Context, queue string, messageId string) error {
+	replayed, err := m.replay(ctx, queue, func(failedMessage *FailedMessage) bool {
+		return failedMessage.ConsumeContext.MessageId() == messageId
+	})
+	if err != nil {
+		return err
+	}
+
+	if replayed == 0 {
+		return messageNotFoundError(queue, messageId)
+	}
+
+	return nil
+}
+
+func (m *inMemoryDeadLetterManager) ReplayAll(ctx context.Context, queue string) (int, error) {
+	return m.replay(ctx, queue, func(*FailedMessage) bool {
+		return true
+	})
+}
+
+func (m *inMemoryDeadLetterManager) Purge(ctx context.Context, queue string) (int, error) {
+	if _, err := m.deadLetterConsumer(queue); err != nil {
+		return 0, err
+	}
+
+	purged := m.harnesses.removeFailed(func(failedMessage *FailedMessage) bool {
+		return failedMessage.Queue == queue
+	})
+
+	return len(purged), nil
+}
+
+func (m *inMemoryDeadLetterManager) replay(
+	ctx context.Context,
+	queue string,
+	replay func(failedMessage *FailedMessage) bool,
+) (int, error) {
+	c, err := m.deadLetterConsumer(queue)
+	if err != nil {
+		return 0, err
+	}
+
+	replayed := m.harnesses.removeFailed(func(failedMessage *FailedMessage) bool {
+		return failedMessage.Queue == queue && replay(failedMessage)
+	})
+
+	m.harnesses.mu.RLock()
+	deliveryMode := m.harnesses.deliveryMode
+	m.harnesses.mu.RUnlock()
+
+	// the messages are sent only to the consumer of the queue, like the replay of the broker dead-letter queues
+	for _, failedMessage := range replayed {
+		if deliveryMode == AsyncDelivery {
+			c.enqueue(failedMessage.ConsumeContext)
+		} else {
+			c.deliver(ctx, failedMessage.ConsumeContext)
+		}
+	}
+
+	return len(replayed), nil
+}
+
+func (m *inMemoryDeadLetterManager) failedMessages(queue string) []*FailedMessage {
+	var failedMessages []*FailedMessage
+	for _, failedMessage := range m.harnesses.FailedMessages() {
+		if failedMessage.Queue == queue {
+			failedMessages = append(failedMessages, failedMessage)
+		}
+	}
+
+	return failedMessages
+}
+
+func (m *inMemoryDeadLetterManager) deadLetterConsumers() []*inMemoryConsumer {
+	m.harnesses.mu.RLock()
+	defer m.harnesses.mu.RUnlock()
+
+	var consumers []*inMemoryConsumer
+	for _, c := range m.harnesses.consumers {
+		if c.configuration.RetryOptions != nil && c.configuration.RetryOptions.DeadLetterEnabled {
+			consumers = append(consumers, c)
+		}
+	}
+
+	return consumers
+}
+
+func (m *inMemoryDeadLetterManager) deadLetterConsumer(queue string) (*inMemoryConsumer, error) {
+	for _, c := range m.deadLetterConsumers() {
+		if c.configuration.QueueName() == queue {
+			return c, nil
+		}
+	}
+
+	return nil, customErrors.NewNotFoundError(
+		fmt.Sprintf("there is no dead-letter queue for the queue `%s`", queue),
+	)
+}
+
+func toDeadLetterMessage(failedMessage *FailedMessage) *deadletter.DeadLetterMessage {
+	consumeContext := failedMessage.ConsumeContext
+
+	body, _ := json.Marshal(failedMessage.Message)
+
+	headers := map[string]interface{}{}
+	for key, value := range consumeContext.Metadata() {
+		headers[key] = value
+	}
+
+	return &deadletter.DeadLetterMessage{
+		MessageId:     consumeContext.MessageId(),
+		CorrelationId: consumeContext.CorrelationId(),
+		MessageType:   consumeContext.MessageType(),
+		ContentType:   consumeContext.ContentType(),
+		Body:          string(body),
+		Headers:       headers,
+		Exception:     failedMessage.Err.Error(),
+		AttemptCount:  failedMessage.AttemptCount,
+		FailedAt:      failedMessage.FailedAt,
+		Queue:         failedMessage.Queue,
+	}
+}
+
+func messageNotFoundError(queue string, messageId string) error {
+	return customErrors.NewNotFoundError(
+		fmt.Sprintf("dead-lettered message with id `%s` not found for the queue `%s`", messageId, queue),
+	)
+}
@@ -0,0 +1,55 @@
+package in_memory
+
+import (
+	"context"
+
+	bus2 "github.com/reoden/go-NFT/pkg/core/messaging/bus"
+	"github.com/reoden/go-NFT/pkg/core/messaging/producer"
+	"github.com/reoden/go-NFT/pkg/core/messaging/requestreply"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/rabbitmq/bus"
+	"github.com/reoden/go-NFT/pkg/rabbitmq/configurations"
+
+	"go.uber.org/fx"
+)
+
+// ModuleFunc provides the in-memory bus in place of the rabbitmq module, with the same rabbitmq configuration constructor
+// https://uber-go.github.io/fx/modules.html
+var ModuleFunc = func(rabbitMQConfigurationConstructor interface{}, deliveryMode DeliveryMode) fx.Option { //nolint:gochecknoglobals
+	return fx.Module(
+		"rabbitmqinmemoryfx",
+		fx.Provide(rabbitMQConfigurationConstructor),
+		fx.Provide(fx.Annotate(
+			func(l logger.Logger, rabbitmqBuilderFunc configurations.RabbitMQConfigurationBuilderFuc) *RabbitmqInMemoryHarnesses {
+				return NewRabbitmqInMemoryHarnesses(l, rabbitmqBuilderFunc).WithDeliveryMode(deliveryMode)
+			},
+			fx.ParamTags(``, `optional:"true"`),
+		)),
+		fx.Provide(
+			func(harnesses *RabbitmqInMemoryHarnesses) bus.RabbitmqBus { return harnesses },
+			func(harnesses *RabbitmqInMemoryHarnesses) bus2.Bus { return harnesses },
+			func(harnesses *RabbitmqInMemoryHarnesses) producer.Producer { return harnesses },
+			func(harnesses *RabbitmqInMemoryHarnesses) requestreply.Requester { return harnesses },
+			func(harnesses *RabbitmqInMemoryHarnesses) requestreply.Responder { return harnesses },
+			NewInMemoryDeadLetterManager,
+		),
+		fx.Invoke(registerHooks),
+	)
+}
+
+func registerHooks(lc fx.Lifecycle, harnesses *RabbitmqInMemoryHarnesses, l logger.Logger) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			// the startup context is short-lived, the consumer workers live until the stop of the app
+			if err := harnesses.Start(context.Background()); err != nil {
+				return err
+			}
+			l.Info("in-memory bus is listening.")
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return harnesses.Stop()
+		},
+	})
+}
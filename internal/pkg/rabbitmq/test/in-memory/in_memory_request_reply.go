@@ -0,0 +1,155 @@
+package in_memory
+
+import (
+	"context"
+	"time"
+
+	messageHeader "github.com/reoden/go-NFT/pkg/core/messaging/messageheader"
+	"github.com/reoden/go-NFT/pkg/core/messaging/requestreply"
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	"github.com/reoden/go-NFT/pkg/core/messaging/utils"
+	"github.com/reoden/go-NFT/pkg/core/metadata"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// replyAddress reply address of the in-memory requests, the replies are matched to their requests by the correlation id
+const replyAddress = "in-memory"
+
+var (
+	_ requestreply.Requester = (*RabbitmqInMemoryHarnesses)(nil)
+	_ requestreply.Responder = (*RabbitmqInMemoryHarnesses)(nil)
+)
+
+// WithRequestTimeout sets the timeout of the requests that have no deadline in their context, the default timeout is 30 seconds
+func (r *RabbitmqInMemoryHarnesses) WithRequestTimeout(timeout time.Duration) *RabbitmqInMemoryHarnesses {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.requestTimeout = timeout
+
+	return r
+}
+
+func (r *RabbitmqInMemoryHarnesses) Request(
+	ctx context.Context,
+	request types.IMessage,
+	meta metadata.Metadata,
+) (types.MessageConsumeContext, error) {
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		r.mu.RLock()
+		timeout := r.requestTimeout
+		r.mu.RUnlock()
+
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	requestMeta := metadata.Metadata{}
+	for key, value := range meta {
+		requestMeta[key] = value
+	}
+
+	correlationId := uuid.NewV4().String()
+	messageHeader.SetCorrelationId(requestMeta, correlationId)
+	messageHeader.SetReplyTo(requestMeta, replyAddress)
+
+	replies := make(chan types.MessageConsumeContext, 1)
+
+	r.mu.Lock()
+	r.pendingRequests[correlationId] = replies
+	r.mu.Unlock()
+
+	defer func() {
+		r.mu.Lock()
+		delete(r.pendingRequests, correlationId)
+		r.mu.Unlock()
+	}()
+
+	if err := r.PublishMessage(ctx, request, requestMeta); err != nil {
+		return nil, err
+	}
+
+	select {
+	case reply := <-replies:
+		return reply, nil
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, errors.WithMessagef(
+				requestreply.ErrRequestTimeout,
+				"request `%s` with correlation id `%s`",
+				utils.GetMessageTypeName(request),
+				correlationId,
+			)
+		}
+
+		return nil, ctx.Err()
+	}
+}
+
+func (r *RabbitmqInMemoryHarnesses) Reply(
+	ctx context.Context,
+	requestContext types.MessageConsumeContext,
+	response types.IMessage,
+) error {
+	meta, err := requestreply.ReplyMetadata(requestContext, response)
+	if err != nil {
+		return err
+	}
+
+	r.reply(types.NewMessageConsumeContext(
+		response,
+		meta,
+		contentType,
+		utils.GetMessageTypeName(response),
+		response.GetCreated(),
+		0,
+		response.GeMessageId(),
+		requestContext.CorrelationId(),
+	))
+
+	return nil
+}
+
+func (r *RabbitmqInMemoryHarnesses) ReplyError(
+	ctx context.Context,
+	requestContext types.MessageConsumeContext,
+	replyErr error,
+) error {
+	meta, err := requestreply.ReplyMetadata(requestContext, nil)
+	if err != nil {
+		return err
+	}
+	messageHeader.SetReplyError(meta, replyErr.Error())
+
+	r.reply(types.NewMessageConsumeContext(
+		nil,
+		meta,
+		contentType,
+		"",
+		time.Now(),
+		0,
+		"",
+		requestContext.CorrelationId(),
+	))
+
+	return nil
+}
+
+func (r *RabbitmqInMemoryHarnesses) reply(replyContext types.MessageConsumeContext) {
+	r.mu.RLock()
+	replies, exists := r.pendingRequests[replyContext.CorrelationId()]
+	r.mu.RUnlock()
+
+	if !exists {
+		r.logger.Infof("reply with correlation id `%s` has no waiting request, it is dropped", replyContext.CorrelationId())
+		return
+	}
+
+	select {
+	case replies <- replyContext:
+	default:
+	}
+}
@@ -0,0 +1,99 @@
+//go:build unit
+// +build unit
+
+package in_memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/core/messaging/requestreply"
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	defaultLogger "github.com/reoden/go-NFT/pkg/logger/defaultlogger"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type getOrder struct {
+	*types.Message
+	OrderId string
+}
+
+type orderReply struct {
+	*types.Message
+	OrderId string
+	Status  string
+}
+
+func newGetOrder(orderId string) *getOrder {
+	return &getOrder{Message: types.NewMessage(uuid.NewV4().String()), OrderId: orderId}
+}
+
+func newRequestHarnesses(mode DeliveryMode) *RabbitmqInMemoryHarnesses {
+	harnesses := NewRabbitmqInMemoryHarnesses(defaultLogger.GetLogger(), nil).WithDeliveryMode(mode)
+
+	handler := requestreply.NewRequestHandler(
+		harnesses,
+		func(ctx context.Context, request *getOrder) (*orderReply, error) {
+			if request.OrderId == "" {
+				return nil, errors.New("order id is required")
+			}
+
+			return &orderReply{
+				Message: types.NewMessage(uuid.NewV4().String()),
+				OrderId: request.OrderId,
+				Status:  "placed",
+			}, nil
+		},
+	)
+	_ = harnesses.ConnectConsumerHandler(&getOrder{}, handler)
+
+	return harnesses
+}
+
+func Test_Request_Receives_Reply(t *testing.T) {
+	for _, mode := range []DeliveryMode{SyncDelivery, AsyncDelivery} {
+		harnesses := newRequestHarnesses(mode)
+		require.NoError(t, harnesses.Start(context.Background()))
+
+		reply, err := requestreply.Request[*getOrder, *orderReply](context.Background(), harnesses, newGetOrder("1"), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "1", reply.OrderId)
+		assert.Equal(t, "placed", reply.Status)
+
+		require.NoError(t, harnesses.Stop())
+	}
+}
+
+func Test_Request_Receives_Reply_Error(t *testing.T) {
+	harnesses := newRequestHarnesses(SyncDelivery)
+
+	_, err := requestreply.Request[*getOrder, *orderReply](context.Background(), harnesses, newGetOrder(""), nil)
+
+	var replyErr *requestreply.ReplyError
+	require.ErrorAs(t, err, &replyErr)
+	assert.Equal(t, "order id is required", replyErr.Message)
+}
+
+func Test_Request_Times_Out_Without_Responder(t *testing.T) {
+	harnesses := NewRabbitmqInMemoryHarnesses(defaultLogger.GetLogger(), nil).WithRequestTimeout(50 * time.Millisecond)
+
+	_, err := requestreply.Request[*getOrder, *orderReply](context.Background(), harnesses, newGetOrder("1"), nil)
+
+	assert.ErrorIs(t, err, requestreply.ErrRequestTimeout)
+}
+
+func Test_Request_Is_Cancelled_With_Context(t *testing.T) {
+	harnesses := NewRabbitmqInMemoryHarnesses(defaultLogger.GetLogger(), nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := harnesses.Request(ctx, newGetOrder("1"), nil)
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -2,20 +2,117 @@ package in_memory
 
 import (
 	"context"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	consumer2 "github.com/reoden/go-NFT/pkg/core/messaging/consumer"
+	messageHeader "github.com/reoden/go-NFT/pkg/core/messaging/messageheader"
 	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	"github.com/reoden/go-NFT/pkg/core/messaging/utils"
+	"github.com/reoden/go-NFT/pkg/core/messaging/versioning"
 	"github.com/reoden/go-NFT/pkg/core/metadata"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/rabbitmq/bus"
+	"github.com/reoden/go-NFT/pkg/rabbitmq/configurations"
+	consumerConfigurations "github.com/reoden/go-NFT/pkg/rabbitmq/consumer/configurations"
+	producerConfigurations "github.com/reoden/go-NFT/pkg/rabbitmq/producer/configurations"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// DeliveryMode how the published messages are delivered to the consumers
+type DeliveryMode int
+
+const (
+	// SyncDelivery runs the consumers of a message inside the publish call, so the message is handled when the publish returns
+	SyncDelivery DeliveryMode = iota
+	// AsyncDelivery queues the message for the consumers and handles it on the consumer workers like a broker,
+	// the messages that are published before the start of the bus wait in the queues
+	AsyncDelivery
 )
 
+const contentType = "application/json"
+
+// RabbitmqInMemoryHarnesses an in-memory bus with the rabbitmq configurations for the tests and the local runs without a broker.
+// The published messages are routed to the consumers of their type that are bound to their exchange, and the consumers
+// run their pipelines and handlers with the immediate retries of the consumer. The messages that fail on all the retries
+// are kept as the dead-lettered messages of the consumer queue. The requests get their replies by their correlation id.
 type RabbitmqInMemoryHarnesses struct {
-	publishedMessage []types.IMessage
-	consumedMessage  []types.IMessage
-	consumerHandlers map[types.IMessage][]consumer2.ConsumerHandler
+	mu                      sync.RWMutex
+	logger                  logger.Logger
+	deliveryMode            DeliveryMode
+	consumers               []*inMemoryConsumer
+	externalConsumers       map[reflect.Type][]consumer2.Consumer
+	consumersConfigurations []*consumerConfigurations.RabbitMQConsumerConfiguration
+	producersConfigurations map[string]*producerConfigurations.RabbitMQProducerConfiguration
+	publishedMessages       []types.IMessage
+	consumedMessages        []types.IMessage
+	failedMessages          []*FailedMessage
+	isConsumedNotifications []func(message types.IMessage)
+	isProducedNotifications []func(message types.IMessage)
+	pendingRequests         map[string]chan types.MessageConsumeContext
+	requestTimeout          time.Duration
+	deliveryTag             uint64
+	pendingDeliveries       int64
+	// changed is closed and replaced on every change of the recorded messages, so the waiters can wait for the next change
+	changed chan struct{}
+	started bool
+}
+
+// FailedMessage a message that a consumer failed to handle on all the retries
+type FailedMessage struct {
+	Message        types.IMessage
+	ConsumeContext types.MessageConsumeContext
+	Consumer       string
+	Queue          string
+	Err            error
+	AttemptCount   int
+	FailedAt       time.Time
+}
+
+var _ bus.RabbitmqBus = (*RabbitmqInMemoryHarnesses)(nil)
+
+func NewRabbitmqInMemoryHarnesses(
+	l logger.Logger,
+	rabbitmqBuilderFunc configurations.RabbitMQConfigurationBuilderFuc,
+) *RabbitmqInMemoryHarnesses {
+	builder := configurations.NewRabbitMQConfigurationBuilder()
+	if rabbitmqBuilderFunc != nil {
+		rabbitmqBuilderFunc(builder)
+	}
+	rabbitmqConfiguration := builder.Build()
+
+	r := &RabbitmqInMemoryHarnesses{
+		logger:                  l,
+		deliveryMode:            SyncDelivery,
+		externalConsumers:       map[reflect.Type][]consumer2.Consumer{},
+		producersConfigurations: map[string]*producerConfigurations.RabbitMQProducerConfiguration{},
+		pendingRequests:         map[string]chan types.MessageConsumeContext{},
+		requestTimeout:          30 * time.Second,
+		changed:                 make(chan struct{}),
+	}
+
+	for _, producerConfiguration := range rabbitmqConfiguration.ProducersConfigurations {
+		r.producersConfigurations[producerConfiguration.ProducerMessageType.String()] = producerConfiguration
+	}
+
+	for _, consumerConfiguration := range rabbitmqConfiguration.ConsumersConfigurations {
+		r.addConsumer(consumerConfiguration)
+	}
+
+	return r
 }
 
-func NewRabbitmqInMemoryHarnesses() *RabbitmqInMemoryHarnesses {
-	return &RabbitmqInMemoryHarnesses{}
+// WithDeliveryMode sets the delivery mode of the bus, the default mode is SyncDelivery
+func (r *RabbitmqInMemoryHarnesses) WithDeliveryMode(mode DeliveryMode) *RabbitmqInMemoryHarnesses {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deliveryMode = mode
+
+	return r
 }
 
 func (r *RabbitmqInMemoryHarnesses) PublishMessage(
@@ -23,8 +120,7 @@ func (r *RabbitmqInMemoryHarnesses) PublishMessage(
 	message types.IMessage,
 	meta metadata.Metadata,
 ) error {
-	r.publishedMessage = append(r.publishedMessage, message)
-	return nil
+	return r.PublishMessageWithTopicName(ctx, message, meta, "")
 }
 
 func (r *RabbitmqInMemoryHarnesses) PublishMessageWithTopicName(
@@ -33,43 +129,369 @@ func (r *RabbitmqInMemoryHarnesses) PublishMessageWithTopicName(
 	meta metadata.Metadata,
 	topicOrExchangeName string,
 ) error {
-	r.publishedMessage = append(r.publishedMessage, message)
+	exchange := r.exchangeName(message, topicOrExchangeName)
+	meta = r.getMetadata(message, meta)
+
+	consumeContext := types.NewMessageConsumeContext(
+		message,
+		meta,
+		contentType,
+		utils.GetMessageTypeName(message),
+		messageHeader.GetMessageCreated(meta),
+		atomic.AddUint64(&r.deliveryTag, 1),
+		messageHeader.GetMessageId(meta),
+		messageHeader.GetCorrelationId(meta),
+	)
+
+	r.mu.Lock()
+	r.publishedMessages = append(r.publishedMessages, message)
+	consumers := r.routedConsumers(message, exchange)
+	externalConsumers := r.externalConsumers[utils.GetMessageBaseReflectType(message)]
+	deliveryMode := r.deliveryMode
+	notifications := r.isProducedNotifications
+	r.notifyChanged()
+	r.mu.Unlock()
+
+	for _, notification := range notifications {
+		if notification != nil {
+			notification(message)
+		}
+	}
+
+	for _, c := range consumers {
+		if deliveryMode == AsyncDelivery {
+			c.enqueue(consumeContext)
+		} else {
+			c.deliver(ctx, consumeContext)
+		}
+	}
+
+	// the consumers that are connected from outside receive the message when they can handle it directly
+	for _, c := range externalConsumers {
+		handler, ok := c.(consumer2.ConsumerHandler)
+		if !ok {
+			continue
+		}
+		if err := handler.Handle(ctx, consumeContext); err != nil {
+			r.logger.Errorf("error in handling the message with id `%s` by the consumer `%s`: %v", consumeContext.MessageId(), c.GetName(), err)
+		}
+	}
+
 	return nil
 }
 
-func (r *RabbitmqInMemoryHarnesses) IsProduced(f func(message types.IMessage)) {
+func (r *RabbitmqInMemoryHarnesses) IsProduced(h func(message types.IMessage)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.isProducedNotifications = append(r.isProducedNotifications, h)
 }
 
-func (r *RabbitmqInMemoryHarnesses) AddMessageConsumedHandler(f func(message types.IMessage)) {
+func (r *RabbitmqInMemoryHarnesses) IsConsumed(h func(message types.IMessage)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.isConsumedNotifications = append(r.isConsumedNotifications, h)
 }
 
+// AddMessageConsumedHandler Deprecated: use IsConsumed
+func (r *RabbitmqInMemoryHarnesses) AddMessageConsumedHandler(h func(message types.IMessage)) {
+	r.IsConsumed(h)
+}
+
+// Start starts the workers of the consumers, in the async mode the queued messages are handled after the start
 func (r *RabbitmqInMemoryHarnesses) Start(ctx context.Context) error {
+	r.mu.Lock()
+	if r.started {
+		r.mu.Unlock()
+		return nil
+	}
+	r.started = true
+	consumers := r.allConsumers()
+	r.mu.Unlock()
+
+	for _, c := range consumers {
+		if err := c.Start(ctx); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func (r *RabbitmqInMemoryHarnesses) Stop(ctx context.Context) error {
+// Stop stops the workers of the consumers, the queued messages stay in the queues until the next start
+func (r *RabbitmqInMemoryHarnesses) Stop() error {
+	r.mu.Lock()
+	r.started = false
+	consumers := r.allConsumers()
+	r.mu.Unlock()
+
+	for _, c := range consumers {
+		if err := c.Stop(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func (r *RabbitmqInMemoryHarnesses) ConnectConsumerHandler(
+// ConnectConsumer adds a consumer for the message type, the consumer receives the messages of the type when it
+// implements consumer.ConsumerHandler
+func (r *RabbitmqInMemoryHarnesses) ConnectConsumer(
 	messageType types.IMessage,
-	consumerHandler consumer2.ConsumerHandler,
+	consumer consumer2.Consumer,
 ) error {
-	r.consumerHandlers[messageType] = append(r.consumerHandlers[messageType], consumerHandler)
+	typeName := utils.GetMessageBaseReflectType(messageType)
+
+	r.mu.Lock()
+	r.externalConsumers[typeName] = append(r.externalConsumers[typeName], consumer)
+	started := r.started
+	r.mu.Unlock()
+
+	if started {
+		return consumer.Start(context.Background())
+	}
+
 	return nil
 }
 
-func (r *RabbitmqInMemoryHarnesses) ConnectConsumer(
+// ConnectRabbitMQConsumer adds a new consumer with its rabbitmq configuration for the message type
+func (r *RabbitmqInMemoryHarnesses) ConnectRabbitMQConsumer(
 	messageType types.IMessage,
-	consumer consumer2.Consumer,
+	consumerBuilderFunc consumerConfigurations.RabbitMQConsumerConfigurationBuilderFuc,
 ) error {
-	return nil
+	builder := consumerConfigurations.NewRabbitMQConsumerConfigurationBuilder(messageType)
+	if consumerBuilderFunc != nil {
+		consumerBuilderFunc(builder)
+	}
+
+	return r.startConsumer(r.addConsumer(builder.Build()))
+}
+
+// ConnectConsumerHandler adds the handler to the existing consumers of the message type, or creates a new consumer for it
+func (r *RabbitmqInMemoryHarnesses) ConnectConsumerHandler(
+	messageType types.IMessage,
+	consumerHandler consumer2.ConsumerHandler,
+) error {
+	typeName := utils.GetMessageBaseReflectType(messageType)
+
+	r.mu.RLock()
+	var consumersForType []*inMemoryConsumer
+	for _, c := range r.consumers {
+		if c.configuration.ConsumerMessageType == typeName {
+			consumersForType = append(consumersForType, c)
+		}
+	}
+	r.mu.RUnlock()
+
+	if len(consumersForType) > 0 {
+		for _, c := range consumersForType {
+			c.ConnectHandler(consumerHandler)
+		}
+
+		return nil
+	}
+
+	builder := consumerConfigurations.NewRabbitMQConsumerConfigurationBuilder(messageType)
+	builder.WithHandlers(func(builder consumer2.ConsumerHandlerConfigurationBuilder) {
+		builder.AddHandler(consumerHandler)
+	})
+
+	return r.startConsumer(r.addConsumer(builder.Build()))
+}
+
+func (r *RabbitmqInMemoryHarnesses) ConsumersConfigurations() []*consumerConfigurations.RabbitMQConsumerConfiguration {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]*consumerConfigurations.RabbitMQConsumerConfiguration{}, r.consumersConfigurations...)
 }
 
+// PublishedMessages returns the published messages in the publish order
 func (r *RabbitmqInMemoryHarnesses) PublishedMessages() []types.IMessage {
-	return r.publishedMessage
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]types.IMessage{}, r.publishedMessages...)
 }
 
+// ConsumedMessages returns the messages that are handled successfully by a consumer, a message is recorded once for each of its consumers
 func (r *RabbitmqInMemoryHarnesses) ConsumedMessages() []types.IMessage {
-	return r.consumedMessage
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]types.IMessage{}, r.consumedMessages...)
+}
+
+// FailedMessages returns the messages that failed on all the retries of a consumer
+func (r *RabbitmqInMemoryHarnesses) FailedMessages() []*FailedMessage {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]*FailedMessage{}, r.failedMessages...)
+}
+
+// Reset clears the recorded messages, the consumers and their queued messages are kept
+func (r *RabbitmqInMemoryHarnesses) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.publishedMessages = nil
+	r.consumedMessages = nil
+	r.failedMessages = nil
+	r.notifyChanged()
+}
+
+func (r *RabbitmqInMemoryHarnesses) addConsumer(
+	consumerConfiguration *consumerConfigurations.RabbitMQConsumerConfiguration,
+) *inMemoryConsumer {
+	c := newInMemoryConsumer(r, consumerConfiguration)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.consumers = append(r.consumers, c)
+	r.consumersConfigurations = append(r.consumersConfigurations, consumerConfiguration)
+
+	return c
+}
+
+func (r *RabbitmqInMemoryHarnesses) startConsumer(c *inMemoryConsumer) error {
+	r.mu.RLock()
+	started := r.started
+	r.mu.RUnlock()
+
+	if started {
+		return c.Start(context.Background())
+	}
+
+	return nil
+}
+
+func (r *RabbitmqInMemoryHarnesses) allConsumers() []consumer2.Consumer {
+	var consumers []consumer2.Consumer
+	for _, c := range r.consumers {
+		consumers = append(consumers, c)
+	}
+	for _, externalConsumers := range r.externalConsumers {
+		consumers = append(consumers, externalConsumers...)
+	}
+
+	return consumers
+}
+
+// routedConsumers returns the consumers of the message type that are bound to the exchange of the message
+func (r *RabbitmqInMemoryHarnesses) routedConsumers(message types.IMessage, exchange string) []*inMemoryConsumer {
+	messageType := utils.GetMessageBaseReflectType(message)
+
+	var consumers []*inMemoryConsumer
+	for _, c := range r.consumers {
+		if c.configuration.ConsumerMessageType != messageType {
+			continue
+		}
+		if c.configuration.ExchangeOptions != nil && c.configuration.ExchangeOptions.Name != exchange {
+			continue
+		}
+		consumers = append(consumers, c)
+	}
+
+	return consumers
+}
+
+func (r *RabbitmqInMemoryHarnesses) exchangeName(message types.IMessage, topicOrExchangeName string) string {
+	if topicOrExchangeName != "" {
+		return topicOrExchangeName
+	}
+
+	r.mu.RLock()
+	producerConfiguration := r.producersConfigurations[utils.GetMessageBaseReflectType(message).String()]
+	r.mu.RUnlock()
+
+	if producerConfiguration != nil && producerConfiguration.ExchangeOptions != nil &&
+		producerConfiguration.ExchangeOptions.Name != "" {
+		return producerConfiguration.ExchangeOptions.Name
+	}
+
+	return utils.GetTopicOrExchangeName(message)
+}
+
+func (r *RabbitmqInMemoryHarnesses) getMetadata(
+	message types.IMessage,
+	meta metadata.Metadata,
+) metadata.Metadata {
+	meta = metadata.FromMetadata(meta)
+
+	messageHeader.SetMessageType(meta, utils.GetMessageTypeName(message))
+	messageHeader.SetSchemaVersion(meta, versioning.GetSchemaVersion(message))
+	messageHeader.SetMessageContentType(meta, contentType)
+
+	if messageHeader.GetMessageId(meta) == "" {
+		messageHeader.SetMessageId(meta, message.GeMessageId())
+	}
+
+	if messageHeader.GetMessageCreated(meta) == *new(time.Time) {
+		messageHeader.SetMessageCreated(meta, message.GetCreated())
+	}
+
+	if messageHeader.GetCorrelationId(meta) == "" {
+		messageHeader.SetCorrelationId(meta, uuid.NewV4().String())
+	}
+	messageHeader.SetMessageName(meta, utils.GetMessageName(message))
+
+	return meta
+}
+
+func (r *RabbitmqInMemoryHarnesses) consumed(message types.IMessage) {
+	r.mu.Lock()
+	r.consumedMessages = append(r.consumedMessages, message)
+	notifications := r.isConsumedNotifications
+	r.notifyChanged()
+	r.mu.Unlock()
+
+	for _, notification := range notifications {
+		if notification != nil {
+			notification(message)
+		}
+	}
+}
+
+func (r *RabbitmqInMemoryHarnesses) failed(failedMessage *FailedMessage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.failedMessages = append(r.failedMessages, failedMessage)
+	r.notifyChanged()
+}
+
+func (r *RabbitmqInMemoryHarnesses) removeFailed(remove func(failedMessage *FailedMessage) bool) []*FailedMessage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var removed, kept []*FailedMessage
+	for _, failedMessage := range r.failedMessages {
+		if remove(failedMessage) {
+			removed = append(removed, failedMessage)
+		} else {
+			kept = append(kept, failedMessage)
+		}
+	}
+	r.failedMessages = kept
+	r.notifyChanged()
+
+	return removed
+}
+
+func (r *RabbitmqInMemoryHarnesses) addPending(delta int64) {
+	atomic.AddInt64(&r.pendingDeliveries, delta)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.notifyChanged()
+}
+
+// notifyChanged wakes up the waiters, it should be called with the lock
+func (r *RabbitmqInMemoryHarnesses) notifyChanged() {
+	close(r.changed)
+	r.changed = make(chan struct{})
 }
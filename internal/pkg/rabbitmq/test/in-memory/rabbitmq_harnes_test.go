@@ -0,0 +1,212 @@
+//go:build unit
+// +build unit
+
+package in_memory
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	consumer2 "github.com/reoden/go-NFT/pkg/core/messaging/consumer"
+	"github.com/reoden/go-NFT/pkg/core/messaging/pipeline"
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	defaultLogger "github.com/reoden/go-NFT/pkg/logger/defaultlogger"
+	"github.com/reoden/go-NFT/pkg/rabbitmq/configurations"
+	consumerConfigurations "github.com/reoden/go-NFT/pkg/rabbitmq/consumer/configurations"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type orderPlaced struct {
+	*types.Message
+	OrderId string
+}
+
+func newOrderPlaced(orderId string) *orderPlaced {
+	return &orderPlaced{Message: types.NewMessage(uuid.NewV4().String()), OrderId: orderId}
+}
+
+type recordingHandler struct {
+	mu       sync.Mutex
+	handled  []string
+	failures int
+}
+
+func (h *recordingHandler) Handle(ctx context.Context, consumeContext types.MessageConsumeContext) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.failures > 0 {
+		h.failures--
+		return errors.New("handler failed")
+	}
+	h.handled = append(h.handled, consumeContext.Message().(*orderPlaced).OrderId)
+
+	return nil
+}
+
+func (h *recordingHandler) Handled() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return append([]string{}, h.handled...)
+}
+
+type recordingPipeline struct {
+	name  string
+	calls *[]string
+}
+
+func (p *recordingPipeline) Handle(
+	ctx context.Context,
+	consumerContext types.MessageConsumeContext,
+	next pipeline.ConsumerHandlerFunc,
+) error {
+	*p.calls = append(*p.calls, p.name)
+
+	return next(ctx)
+}
+
+func newHarnesses(handler consumer2.ConsumerHandler, builderFunc consumerConfigurations.RabbitMQConsumerConfigurationBuilderFuc) *RabbitmqInMemoryHarnesses {
+	return NewRabbitmqInMemoryHarnesses(
+		defaultLogger.GetLogger(),
+		func(builder configurations.RabbitMQConfigurationBuilder) {
+			builder.AddConsumer(&orderPlaced{}, func(consumerBuilder consumerConfigurations.RabbitMQConsumerConfigurationBuilder) {
+				consumerBuilder.
+					WithImmediateRetries(2, 0).
+					WithHandlers(func(handlersBuilder consumer2.ConsumerHandlerConfigurationBuilder) {
+						handlersBuilder.AddHandler(handler)
+					})
+				if builderFunc != nil {
+					builderFunc(consumerBuilder)
+				}
+			})
+		},
+	)
+}
+
+func Test_Sync_Delivery_Runs_Pipelines_And_Handlers(t *testing.T) {
+	var calls []string
+	handler := &recordingHandler{}
+	harnesses := newHarnesses(handler, func(builder consumerConfigurations.RabbitMQConsumerConfigurationBuilder) {
+		builder.WIthPipelines(func(pipelinesBuilder pipeline.ConsumerPipelineConfigurationBuilder) {
+			pipelinesBuilder.
+				AddPipeline(&recordingPipeline{name: "first", calls: &calls}).
+				AddPipeline(&recordingPipeline{name: "second", calls: &calls})
+		})
+	})
+
+	err := harnesses.PublishMessage(context.Background(), newOrderPlaced("1"), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"1"}, handler.Handled())
+	assert.Equal(t, []string{"first", "second"}, calls)
+	assert.Len(t, harnesses.PublishedMessages(), 1)
+	assert.Len(t, harnesses.ConsumedMessages(), 1)
+}
+
+func Test_Async_Delivery_Waits_For_Start(t *testing.T) {
+	handler := &recordingHandler{}
+	harnesses := newHarnesses(handler, nil).WithDeliveryMode(AsyncDelivery)
+
+	err := harnesses.PublishMessage(context.Background(), newOrderPlaced("1"), nil)
+	require.NoError(t, err)
+	assert.Empty(t, handler.Handled())
+
+	require.NoError(t, harnesses.Start(context.Background()))
+	defer harnesses.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	message, err := WaitUntilConsumed[*orderPlaced](ctx, harnesses, func(m *orderPlaced) bool {
+		return m.OrderId == "1"
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "1", message.OrderId)
+
+	require.NoError(t, harnesses.WaitUntilIdle(ctx))
+	assert.Equal(t, []string{"1"}, handler.Handled())
+}
+
+func Test_Wait_Until_Consumed_Times_Out(t *testing.T) {
+	harnesses := newHarnesses(&recordingHandler{}, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := WaitUntilConsumed[*orderPlaced](ctx, harnesses, nil)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func Test_Immediate_Retries(t *testing.T) {
+	handler := &recordingHandler{failures: 1}
+	harnesses := newHarnesses(handler, nil)
+
+	err := harnesses.PublishMessage(context.Background(), newOrderPlaced("1"), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"1"}, handler.Handled())
+	assert.Empty(t, harnesses.FailedMessages())
+}
+
+func Test_Failed_Message_Is_Dead_Lettered_And_Replayed(t *testing.T) {
+	handler := &recordingHandler{failures: 2}
+	harnesses := newHarnesses(handler, nil)
+	deadLetterManager := NewInMemoryDeadLetterManager(harnesses)
+
+	message := newOrderPlaced("1")
+	err := harnesses.PublishMessage(context.Background(), message, nil)
+	require.NoError(t, err)
+
+	failedMessage, err := WaitUntilFailed[*orderPlaced](context.Background(), harnesses, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, failedMessage.AttemptCount)
+
+	queue := harnesses.ConsumersConfigurations()[0].QueueName()
+
+	queues, err := deadLetterManager.GetQueues(context.Background())
+	require.NoError(t, err)
+	require.Len(t, queues, 1)
+	assert.Equal(t, 1, queues[0].MessageCount)
+
+	deadLetter, err := deadLetterManager.GetMessage(context.Background(), queue, message.MessageId)
+	require.NoError(t, err)
+	assert.Contains(t, deadLetter.Exception, "handler failed")
+
+	err = deadLetterManager.Replay(context.Background(), queue, message.MessageId)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"1"}, handler.Handled())
+	assert.Empty(t, harnesses.FailedMessages())
+}
+
+func Test_Connect_Consumer_Handler_Creates_Consumer(t *testing.T) {
+	harnesses := NewRabbitmqInMemoryHarnesses(defaultLogger.GetLogger(), nil)
+	handler := &recordingHandler{}
+
+	require.NoError(t, harnesses.ConnectConsumerHandler(&orderPlaced{}, handler))
+
+	err := harnesses.PublishMessage(context.Background(), newOrderPlaced("1"), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"1"}, handler.Handled())
+	assert.Len(t, harnesses.ConsumersConfigurations(), 1)
+}
+
+func Test_Message_Is_Routed_By_Exchange(t *testing.T) {
+	handler := &recordingHandler{}
+	harnesses := newHarnesses(handler, nil)
+
+	err := harnesses.PublishMessageWithTopicName(context.Background(), newOrderPlaced("1"), nil, "other-exchange")
+	require.NoError(t, err)
+
+	assert.Empty(t, handler.Handled())
+	assert.Len(t, harnesses.PublishedMessages(), 1)
+}
@@ -0,0 +1,108 @@
+package in_memory
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+
+	"emperror.dev/errors"
+)
+
+// WaitUntilPublished waits until a message of type T that meets the condition is published, a nil condition accepts
+// any message of the type. The messages that are published before the call are checked too.
+func WaitUntilPublished[T types.IMessage](
+	ctx context.Context,
+	harnesses *RabbitmqInMemoryHarnesses,
+	condition func(T) bool,
+) (T, error) {
+	return waitUntilMessage(ctx, harnesses, harnesses.PublishedMessages, condition, "published")
+}
+
+// WaitUntilConsumed waits until a message of type T that meets the condition is handled successfully by a consumer,
+// a nil condition accepts any message of the type. The messages that are consumed before the call are checked too.
+func WaitUntilConsumed[T types.IMessage](
+	ctx context.Context,
+	harnesses *RabbitmqInMemoryHarnesses,
+	condition func(T) bool,
+) (T, error) {
+	return waitUntilMessage(ctx, harnesses, harnesses.ConsumedMessages, condition, "consumed")
+}
+
+// WaitUntilFailed waits until a message of type T that meets the condition fails on all the retries of a consumer
+func WaitUntilFailed[T types.IMessage](
+	ctx context.Context,
+	harnesses *RabbitmqInMemoryHarnesses,
+	condition func(T) bool,
+) (*FailedMessage, error) {
+	var result *FailedMessage
+
+	err := harnesses.waitUntil(ctx, func() bool {
+		for _, failedMessage := range harnesses.FailedMessages() {
+			if message, ok := failedMessage.Message.(T); ok && (condition == nil || condition(message)) {
+				result = failedMessage
+				return true
+			}
+		}
+
+		return false
+	})
+	if err != nil {
+		return nil, errors.WrapIff(err, "no message of type `%s` failed", typeMapper.GetGenericTypeNameByT[T]())
+	}
+
+	return result, nil
+}
+
+// WaitUntilIdle waits until the consumers handle all the queued messages of the async mode
+func (r *RabbitmqInMemoryHarnesses) WaitUntilIdle(ctx context.Context) error {
+	return r.waitUntil(ctx, func() bool {
+		return atomic.LoadInt64(&r.pendingDeliveries) == 0
+	})
+}
+
+func waitUntilMessage[T types.IMessage](
+	ctx context.Context,
+	harnesses *RabbitmqInMemoryHarnesses,
+	messages func() []types.IMessage,
+	condition func(T) bool,
+	state string,
+) (T, error) {
+	var result T
+
+	err := harnesses.waitUntil(ctx, func() bool {
+		for _, message := range messages() {
+			if typed, ok := message.(T); ok && (condition == nil || condition(typed)) {
+				result = typed
+				return true
+			}
+		}
+
+		return false
+	})
+	if err != nil {
+		return result, errors.WrapIff(err, "no message of type `%s` %s", typeMapper.GetGenericTypeNameByT[T](), state)
+	}
+
+	return result, nil
+}
+
+// waitUntil checks the condition on every change of the bus until it is met or the context is done
+func (r *RabbitmqInMemoryHarnesses) waitUntil(ctx context.Context, condition func() bool) error {
+	for {
+		r.mu.RLock()
+		changed := r.changed
+		r.mu.RUnlock()
+
+		if condition() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package types
+
+// headers of the failed messages that are sent to the retry and dead-letter queues
+const (
+	// ExceptionHeader error of the last failed delivery
+	ExceptionHeader = "x-exception"
+	// AttemptCountHeader number of the failed deliveries of the message
+	AttemptCountHeader = "x-attempt-count"
+	// FailedAtHeader time of the last failed delivery in RFC3339
+	FailedAtHeader = "x-failed-at"
+	// OriginalQueueHeader queue of the consumer that failed to handle the message
+	OriginalQueueHeader = "x-original-queue"
+	// OriginalExchangeHeader exchange that the message was originally published to
+	OriginalExchangeHeader = "x-original-exchange"
+	// OriginalRoutingKeyHeader routing key that the message was originally published with
+	OriginalRoutingKeyHeader = "x-original-routing-key"
+)
+
+// FailureHeaders all the headers that are added to the failed messages
+var FailureHeaders = []string{
+	ExceptionHeader,
+	AttemptCountHeader,
+	FailedAtHeader,
+	OriginalQueueHeader,
+	OriginalExchangeHeader,
+	OriginalRoutingKeyHeader,
+}
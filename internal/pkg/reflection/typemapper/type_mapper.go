@@ -378,6 +378,10 @@ func InstanceByPackageName(pkgPath string, name string) interface{} {
 }
 
 func getInstanceFromType(typ reflect.Type) interface{} {
+	if typ == nil {
+		return nil
+	}
+
 	if typ.Kind() == reflect.Ptr {
 		res := reflect.New(typ.Elem()).Interface()
 		return res
@@ -0,0 +1,23 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/reoden/go-NFT/pkg/health/contracts"
+)
+
+type StorageHealthChecker struct {
+	store ObjectStore
+}
+
+func NewStorageHealthChecker(store ObjectStore) contracts.Health {
+	return &StorageHealthChecker{store}
+}
+
+func (healthChecker *StorageHealthChecker) CheckHealth(ctx context.Context) error {
+	return healthChecker.store.Ping(ctx)
+}
+
+func (healthChecker *StorageHealthChecker) GetHealthName() string {
+	return "storage"
+}
@@ -0,0 +1,201 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+)
+
+// contentTypeSuffix suffix of the sidecar file that keeps the content type of a local object
+const contentTypeSuffix = ".content-type"
+
+var (
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrSignatureExpired = errors.New("signature expired")
+	ErrInvalidKey       = errors.New("invalid object key")
+)
+
+// LocalObjectStore stores the objects on the local file system, signed urls point to `BaseUrl` and are signed with
+// HMAC-SHA256, the application should serve them and check them with `VerifySignature`
+type LocalObjectStore struct {
+	options *LocalStorageOptions
+}
+
+func NewLocalObjectStore(options *LocalStorageOptions) (*LocalObjectStore, error) {
+	if options.BasePath == "" {
+		return nil, errors.New("local storage base path is required")
+	}
+	if options.SigningKey == "" {
+		return nil, errors.New("local storage signing key is required")
+	}
+	if err := os.MkdirAll(options.BasePath, 0o755); err != nil {
+		return nil, errors.WrapIf(err, "error in creating local storage directory")
+	}
+
+	return &LocalObjectStore{options: options}, nil
+}
+
+func (l *LocalObjectStore) Put(
+	ctx context.Context,
+	key string,
+	reader io.Reader,
+	size int64,
+	contentType string,
+) (*ObjectInfo, error) {
+	filePath, err := l.filePath(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return nil, errors.WrapIf(err, "error in creating object directory")
+	}
+
+	// write to a temp file and rename it, so readers never see a partially written object
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), ".upload-*")
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in creating temp file")
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, reader)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in writing object")
+	}
+	if size >= 0 && written != size {
+		return nil, errors.Errorf("object size mismatch, expected %d bytes but got %d", size, written)
+	}
+
+	if err := os.WriteFile(filePath+contentTypeSuffix, []byte(contentType), 0o644); err != nil {
+		return nil, errors.WrapIf(err, "error in writing object content type")
+	}
+	if err := os.Rename(tmp.Name(), filePath); err != nil {
+		return nil, errors.WrapIf(err, "error in moving object")
+	}
+
+	return l.Stat(ctx, key)
+}
+
+func (l *LocalObjectStore) Get(ctx context.Context, key string) (io.ReadCloser, *ObjectInfo, error) {
+	info, err := l.Stat(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filePath, _ := l.filePath(key)
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, nil, errors.WrapIf(err, "error in opening object")
+	}
+
+	return file, info, nil
+}
+
+func (l *LocalObjectStore) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	filePath, err := l.filePath(key)
+	if err != nil {
+		return nil, err
+	}
+
+	fileInfo, err := os.Stat(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errors.WithStack(ErrObjectNotFound)
+	}
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in reading object info")
+	}
+
+	contentType := "application/octet-stream"
+	if b, err := os.ReadFile(filePath + contentTypeSuffix); err == nil && len(b) > 0 {
+		contentType = string(b)
+	}
+
+	return &ObjectInfo{
+		Key:          key,
+		Size:         fileInfo.Size(),
+		ContentType:  contentType,
+		LastModified: fileInfo.ModTime(),
+	}, nil
+}
+
+func (l *LocalObjectStore) Delete(ctx context.Context, key string) error {
+	filePath, err := l.filePath(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.WrapIf(err, "error in deleting object")
+	}
+	_ = os.Remove(filePath + contentTypeSuffix)
+
+	return nil
+}
+
+func (l *LocalObjectStore) SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	if _, err := l.filePath(key); err != nil {
+		return "", err
+	}
+
+	expires := time.Now().Add(expiry).Unix()
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", l.sign(key, expires))
+
+	return fmt.Sprintf("%s/%s?%s", strings.TrimSuffix(l.options.BaseUrl, "/"), key, query.Encode()), nil
+}
+
+// VerifySignature checks a signature created by `SignedURL` for the key and its expiry
+func (l *LocalObjectStore) VerifySignature(key string, expires int64, signature string) error {
+	if !hmac.Equal([]byte(l.sign(key, expires)), []byte(signature)) {
+		return errors.WithStack(ErrInvalidSignature)
+	}
+	if time.Now().Unix() > expires {
+		return errors.WithStack(ErrSignatureExpired)
+	}
+
+	return nil
+}
+
+func (l *LocalObjectStore) Ping(ctx context.Context) error {
+	info, err := os.Stat(l.options.BasePath)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return errors.Errorf("local storage base path `%s` is not a directory", l.options.BasePath)
+	}
+
+	return nil
+}
+
+func (l *LocalObjectStore) sign(key string, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(l.options.SigningKey))
+	mac.Write([]byte(fmt.Sprintf("%s:%d", key, expires)))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// filePath maps the key to a path inside the base path and rejects the keys that escape it
+func (l *LocalObjectStore) filePath(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if key == "" || cleaned == "/" || cleaned != "/"+key || strings.HasSuffix(key, contentTypeSuffix) {
+		return "", errors.WithStack(ErrInvalidKey)
+	}
+
+	return filepath.Join(l.options.BasePath, filepath.FromSlash(cleaned)), nil
+}
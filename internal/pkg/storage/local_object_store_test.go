@@ -0,0 +1,130 @@
+//go:build unit
+// +build unit
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLocalStore(t *testing.T) *LocalObjectStore {
+	store, err := NewLocalObjectStore(&LocalStorageOptions{
+		BasePath:   t.TempDir(),
+		BaseUrl:    "http://localhost:7001/api/v1/media/",
+		SigningKey: "secret",
+	})
+	require.NoError(t, err)
+
+	return store
+}
+
+func Test_Local_Put_Get_Delete(t *testing.T) {
+	ctx := context.Background()
+	store := newTestLocalStore(t)
+
+	content := []byte("blue ape")
+	info, err := store.Put(ctx, "media/ape.txt", bytes.NewReader(content), int64(len(content)), "text/plain")
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), info.Size)
+	assert.Equal(t, "text/plain", info.ContentType)
+
+	reader, info, err := store.Get(ctx, "media/ape.txt")
+	require.NoError(t, err)
+	defer reader.Close()
+	b, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, content, b)
+	assert.Equal(t, "text/plain", info.ContentType)
+
+	exists, err := Exists(ctx, store, "media/ape.txt")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	require.NoError(t, store.Delete(ctx, "media/ape.txt"))
+
+	_, err = store.Stat(ctx, "media/ape.txt")
+	assert.True(t, errors.Is(err, ErrObjectNotFound))
+}
+
+func Test_Local_Put_Size_Mismatch(t *testing.T) {
+	store := newTestLocalStore(t)
+
+	_, err := store.Put(context.Background(), "media/ape.txt", strings.NewReader("ape"), 10, "text/plain")
+	assert.Error(t, err)
+
+	exists, err := Exists(context.Background(), store, "media/ape.txt")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func Test_Local_Rejects_Invalid_Keys(t *testing.T) {
+	store := newTestLocalStore(t)
+
+	for _, key := range []string{"", "../ape.txt", "media/../../ape.txt", "/ape.txt", "media//ape.txt"} {
+		_, err := store.Put(context.Background(), key, strings.NewReader("ape"), 3, "text/plain")
+		assert.True(t, errors.Is(err, ErrInvalidKey), key)
+	}
+}
+
+func Test_Local_Signed_Url(t *testing.T) {
+	ctx := context.Background()
+	store := newTestLocalStore(t)
+
+	signedUrl, err := store.SignedURL(ctx, "media/ape.txt", time.Minute)
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(signedUrl)
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/media/media/ape.txt", parsed.Path)
+
+	expires, err := strconv.ParseInt(parsed.Query().Get("expires"), 10, 64)
+	require.NoError(t, err)
+	signature := parsed.Query().Get("signature")
+
+	assert.NoError(t, store.VerifySignature("media/ape.txt", expires, signature))
+	assert.True(t, errors.Is(store.VerifySignature("media/punk.txt", expires, signature), ErrInvalidSignature))
+	assert.True(t, errors.Is(store.VerifySignature("media/ape.txt", expires+1, signature), ErrInvalidSignature))
+
+	expired := time.Now().Add(-time.Minute).Unix()
+	assert.True(
+		t,
+		errors.Is(store.VerifySignature("media/ape.txt", expired, store.sign("media/ape.txt", expired)), ErrSignatureExpired),
+	)
+}
+
+func Test_Generate_Thumbnail(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 800, 400))
+	for x := 0; x < 800; x++ {
+		for y := 0; y < 400; y++ {
+			src.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, src))
+
+	thumbnail, err := GenerateThumbnail(&buf, 320)
+	require.NoError(t, err)
+
+	img, err := jpeg.Decode(bytes.NewReader(thumbnail))
+	require.NoError(t, err)
+	assert.Equal(t, 320, img.Bounds().Dx())
+	assert.Equal(t, 160, img.Bounds().Dy())
+
+	_, err = GenerateThumbnail(strings.NewReader("not an image"), 320)
+	assert.Error(t, err)
+}
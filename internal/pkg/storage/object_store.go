@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"emperror.dev/errors"
+)
+
+// ErrObjectNotFound returned when an object with the given key doesn't exist in the store
+var ErrObjectNotFound = errors.New("object not found")
+
+// ObjectInfo metadata of a stored object
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	LastModified time.Time
+}
+
+// ObjectStore stores binary objects by key, keys are slash separated paths like `media/<hash>.png`
+type ObjectStore interface {
+	// Put stores the content of the reader with the given key, an existing object with the same key will be replaced
+	Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) (*ObjectInfo, error)
+	// Get opens the object for reading, the caller should close the returned reader
+	Get(ctx context.Context, key string) (io.ReadCloser, *ObjectInfo, error)
+	// Stat returns the object metadata or ErrObjectNotFound
+	Stat(ctx context.Context, key string) (*ObjectInfo, error)
+	Delete(ctx context.Context, key string) error
+	// SignedURL returns a time limited url for downloading the object without any other credential
+	SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error)
+	// Ping checks the availability of the store, used by the health checks
+	Ping(ctx context.Context) error
+}
+
+// SignedURLVerifier implemented by the stores that serve the signed urls themselves (like the local store)
+type SignedURLVerifier interface {
+	VerifySignature(key string, expires int64, signature string) error
+}
+
+// Exists reports whether an object with the given key exists in the store
+func Exists(ctx context.Context, store ObjectStore, key string) (bool, error) {
+	_, err := store.Stat(ctx, key)
+	if errors.Is(err, ErrObjectNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
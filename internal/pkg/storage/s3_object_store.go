@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3ObjectStore stores the objects in a S3 compatible bucket (AWS S3, MinIO, ...)
+type S3ObjectStore struct {
+	client  *minio.Client
+	options *S3StorageOptions
+}
+
+func NewS3ObjectStore(ctx context.Context, options *S3StorageOptions) (*S3ObjectStore, error) {
+	if options.Bucket == "" {
+		return nil, errors.New("s3 storage bucket is required")
+	}
+
+	// https://min.io/docs/minio/linux/developers/go/API.html
+	client, err := minio.New(options.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(options.AccessKey, options.SecretKey, ""),
+		Secure: options.UseSSL,
+		Region: options.Region,
+	})
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in creating s3 client")
+	}
+
+	store := &S3ObjectStore{client: client, options: options}
+
+	if options.CreateBucket {
+		if err := store.ensureBucket(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return store, nil
+}
+
+func (s *S3ObjectStore) Put(
+	ctx context.Context,
+	key string,
+	reader io.Reader,
+	size int64,
+	contentType string,
+) (*ObjectInfo, error) {
+	info, err := s.client.PutObject(ctx, s.options.Bucket, key, reader, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in putting object")
+	}
+
+	return &ObjectInfo{
+		Key:          key,
+		Size:         info.Size,
+		ContentType:  contentType,
+		LastModified: info.LastModified,
+	}, nil
+}
+
+func (s *S3ObjectStore) Get(ctx context.Context, key string) (io.ReadCloser, *ObjectInfo, error) {
+	info, err := s.Stat(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	object, err := s.client.GetObject(ctx, s.options.Bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, nil, errors.WrapIf(err, "error in getting object")
+	}
+
+	return object, info, nil
+}
+
+func (s *S3ObjectStore) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	info, err := s.client.StatObject(ctx, s.options.Bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, errors.WithStack(ErrObjectNotFound)
+		}
+
+		return nil, errors.WrapIf(err, "error in reading object info")
+	}
+
+	return &ObjectInfo{
+		Key:          info.Key,
+		Size:         info.Size,
+		ContentType:  info.ContentType,
+		LastModified: info.LastModified,
+	}, nil
+}
+
+func (s *S3ObjectStore) Delete(ctx context.Context, key string) error {
+	err := s.client.RemoveObject(ctx, s.options.Bucket, key, minio.RemoveObjectOptions{})
+	if err != nil {
+		return errors.WrapIf(err, "error in deleting object")
+	}
+
+	return nil
+}
+
+func (s *S3ObjectStore) SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	signedUrl, err := s.client.PresignedGetObject(ctx, s.options.Bucket, key, expiry, url.Values{})
+	if err != nil {
+		return "", errors.WrapIf(err, "error in presigning object url")
+	}
+
+	return signedUrl.String(), nil
+}
+
+func (s *S3ObjectStore) Ping(ctx context.Context) error {
+	exists, err := s.client.BucketExists(ctx, s.options.Bucket)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.Errorf("s3 bucket `%s` doesn't exist", s.options.Bucket)
+	}
+
+	return nil
+}
+
+func (s *S3ObjectStore) ensureBucket(ctx context.Context) error {
+	exists, err := s.client.BucketExists(ctx, s.options.Bucket)
+	if err != nil {
+		return errors.WrapIf(err, "error in checking s3 bucket")
+	}
+	if exists {
+		return nil
+	}
+
+	err = s.client.MakeBucket(ctx, s.options.Bucket, minio.MakeBucketOptions{Region: s.options.Region})
+	if err != nil {
+		return errors.WrapIf(err, "error in creating s3 bucket")
+	}
+
+	return nil
+}
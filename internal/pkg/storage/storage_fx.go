@@ -0,0 +1,35 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/reoden/go-NFT/pkg/health/contracts"
+
+	"emperror.dev/errors"
+	"go.uber.org/fx"
+)
+
+// Module provided to fxlog
+// https://uber-go.github.io/fx/modules.html
+var Module = fx.Module("storagefx",
+	fx.Provide(provideConfig),
+	fx.Provide(NewObjectStore),
+	fx.Provide(fx.Annotate(
+		NewStorageHealthChecker,
+		fx.As(new(contracts.Health)),
+		fx.ResultTags(fmt.Sprintf(`group:"%s"`, "healths")),
+	)),
+)
+
+// NewObjectStore creates the object store of the configured provider
+func NewObjectStore(options *StorageOptions) (ObjectStore, error) {
+	switch options.Provider {
+	case "", LocalProvider:
+		return NewLocalObjectStore(&options.Local)
+	case S3Provider:
+		return NewS3ObjectStore(context.Background(), &options.S3)
+	default:
+		return nil, errors.Errorf("storage provider `%s` is not supported", options.Provider)
+	}
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/config"
+	"github.com/reoden/go-NFT/pkg/config/environment"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+
+	"github.com/iancoleman/strcase"
+)
+
+var optionName = strcase.ToLowerCamel(typeMapper.GetGenericTypeNameByT[StorageOptions]())
+
+const (
+	LocalProvider = "local"
+	S3Provider    = "s3"
+)
+
+type StorageOptions struct {
+	// Provider of the object store, `local` or `s3`
+	Provider string `mapstructure:"provider"               default:"local"`
+	// SignedUrlExpiry expiry of the signed download urls in seconds
+	SignedUrlExpiry int `mapstructure:"signedUrlExpiry"        default:"900"`
+	// MaxUploadSize maximum size of an uploaded object in bytes
+	MaxUploadSize int64               `mapstructure:"maxUploadSize"          default:"20971520"`
+	Local         LocalStorageOptions `mapstructure:"local"`
+	S3            S3StorageOptions    `mapstructure:"s3"`
+}
+
+type LocalStorageOptions struct {
+	// BasePath directory that keeps the objects
+	BasePath string `mapstructure:"basePath"   default:"storage"`
+	// BaseUrl public url of the download endpoint, signed urls are built as `<BaseUrl>/<key>?expires=..&signature=..`
+	BaseUrl string `mapstructure:"baseUrl"`
+	// SigningKey secret used for signing the download urls
+	SigningKey string `mapstructure:"signingKey"`
+}
+
+type S3StorageOptions struct {
+	Endpoint     string `mapstructure:"endpoint"`
+	Region       string `mapstructure:"region"`
+	AccessKey    string `mapstructure:"accessKey"`
+	SecretKey    string `mapstructure:"secretKey"`
+	Bucket       string `mapstructure:"bucket"`
+	UseSSL       bool   `mapstructure:"useSSL"`
+	CreateBucket bool   `mapstructure:"createBucket"`
+}
+
+func (o *StorageOptions) SignedUrlExpiryDuration() time.Duration {
+	if o.SignedUrlExpiry <= 0 {
+		return 15 * time.Minute
+	}
+
+	return time.Duration(o.SignedUrlExpiry) * time.Second
+}
+
+func provideConfig(environment environment.Environment) (*StorageOptions, error) {
+	return config.BindConfigKey[*StorageOptions](optionName, environment)
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"io"
+
+	// registering the decoders of the supported image formats
+	_ "image/gif"
+	_ "image/png"
+
+	"emperror.dev/errors"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const ThumbnailContentType = "image/jpeg"
+
+// GenerateThumbnail decodes the image and scales it down to fit in a `maxSize` x `maxSize` box keeping its aspect
+// ratio, the result is encoded as jpeg. Images smaller than the box are only re-encoded.
+func GenerateThumbnail(reader io.Reader, maxSize int) ([]byte, error) {
+	src, _, err := image.Decode(reader)
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in decoding image")
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width == 0 || height == 0 {
+		return nil, errors.New("image is empty")
+	}
+
+	if width > maxSize || height > maxSize {
+		if width >= height {
+			height = max(1, height*maxSize/width)
+			width = maxSize
+		} else {
+			width = max(1, width*maxSize/height)
+			height = maxSize
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	// jpeg has no alpha channel, transparent areas are rendered on a white background
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	// https://pkg.go.dev/golang.org/x/image/draw#pkg-variables
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85}); err != nil {
+		return nil, errors.WrapIf(err, "error in encoding thumbnail")
+	}
+
+	return buf.Bytes(), nil
+}
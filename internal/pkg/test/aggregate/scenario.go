@@ -0,0 +1,190 @@
+package aggregate
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/reoden/go-NFT/pkg/core/domain"
+	"github.com/reoden/go-NFT/pkg/es/models"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// the fields of the domain events that are set by the aggregate and are not compared by `Then`
+var domainEventEnvelopeFields = []string{"event_id", "event_type", "occurred_on", "aggregate_id", "aggregate_sequence_number"}
+
+// Scenario a Given/When/Then test of an event sourced aggregate:
+//
+//	aggregate.For[*models.Edition](t).
+//		Given(&models.EditionMinted{...}).
+//		When(func(edition *models.Edition) error { return edition.Freeze("dispute") }).
+//		Then(&models.EditionFrozen{Reason: "dispute"})
+type Scenario[T models.IHaveEventSourcedAggregate] struct {
+	t           testing.TB
+	aggregateId uuid.UUID
+	aggregate   T
+	whenCalled  bool
+	err         error
+}
+
+// For starts a scenario of the aggregate type, the events of `Given` belong to a new aggregate id
+func For[T models.IHaveEventSourcedAggregate](t testing.TB) *Scenario[T] {
+	t.Helper()
+
+	return &Scenario[T]{t: t, aggregateId: uuid.NewV4()}
+}
+
+// Given restores the aggregate from its past events, the events without a domain event envelope or an aggregate id get
+// the id of the scenario
+func (s *Scenario[T]) Given(events ...domain.IDomainEvent) *Scenario[T] {
+	s.t.Helper()
+
+	if reflect.ValueOf(s.aggregate).IsNil() {
+		s.aggregate = newEmptyAggregate[T]()
+	}
+
+	version := s.aggregate.CurrentVersion()
+	for _, event := range events {
+		version++
+		withDomainEvent(event)
+		if event.GetAggregateId() == uuid.Nil {
+			event.WithAggregate(s.aggregateId, version)
+		}
+	}
+
+	err := s.aggregate.LoadFromHistory(events, nil)
+	require.NoError(s.t, err, "the given events can't be applied to the aggregate")
+
+	return s
+}
+
+// GivenAggregate starts the scenario from the aggregate, its uncommitted events are treated as its past events
+func (s *Scenario[T]) GivenAggregate(aggregate T) *Scenario[T] {
+	s.t.Helper()
+
+	aggregate.MarkUncommittedEventAsCommitted()
+	aggregate.SetOriginalVersion(aggregate.CurrentVersion())
+	s.aggregate = aggregate
+	s.aggregateId = aggregate.Id()
+
+	return s
+}
+
+// When runs the command on the aggregate, the command error is checked by `Then` or `ThenError`
+func (s *Scenario[T]) When(command func(aggregate T) error) *Scenario[T] {
+	s.t.Helper()
+	require.False(s.t, reflect.ValueOf(s.aggregate).IsNil(), "the aggregate has no history, use `WhenCreated` for a new aggregate")
+
+	s.whenCalled = true
+	s.err = command(s.aggregate)
+
+	return s
+}
+
+// WhenCreated creates a new aggregate with its constructor
+func (s *Scenario[T]) WhenCreated(create func() (T, error)) *Scenario[T] {
+	s.t.Helper()
+
+	s.whenCalled = true
+	aggregate, err := create()
+	s.err = err
+	if err == nil {
+		s.aggregate = aggregate
+	}
+
+	return s
+}
+
+// Then checks the command succeeded and raised the events in order, the events are compared by their type and data
+// without their ids, times and aggregate versions
+func (s *Scenario[T]) Then(expected ...domain.IDomainEvent) *Scenario[T] {
+	s.t.Helper()
+	require.True(s.t, s.whenCalled, "`Then` should be called after `When`")
+	require.NoError(s.t, s.err, "the command failed")
+
+	actual := s.aggregate.UncommittedEvents()
+	require.Len(s.t, actual, len(expected), "unexpected number of the raised events")
+
+	for i := range expected {
+		require.IsType(s.t, expected[i], actual[i], "unexpected type of the raised event %d", i)
+		require.Equal(
+			s.t,
+			eventData(s.t, expected[i]),
+			eventData(s.t, actual[i]),
+			"unexpected data of the raised event %d",
+			i,
+		)
+	}
+
+	return s
+}
+
+// ThenError checks the command failed with the target error, it is compared with `errors.Is`
+func (s *Scenario[T]) ThenError(target error) *Scenario[T] {
+	s.t.Helper()
+	require.True(s.t, s.whenCalled, "`ThenError` should be called after `When`")
+	require.Error(s.t, s.err, "the command succeeded")
+	require.True(s.t, errors.Is(s.err, target), "the command failed with %v instead of %v", s.err, target)
+
+	if !reflect.ValueOf(s.aggregate).IsNil() {
+		require.Empty(s.t, s.aggregate.UncommittedEvents(), "a failed command should not raise events")
+	}
+
+	return s
+}
+
+// ThenState checks the state of aggregate after the command
+func (s *Scenario[T]) ThenState(check func(aggregate T)) *Scenario[T] {
+	s.t.Helper()
+	require.False(s.t, reflect.ValueOf(s.aggregate).IsNil(), "there is no aggregate")
+
+	check(s.aggregate)
+
+	return s
+}
+
+// Aggregate returns the aggregate of the scenario
+func (s *Scenario[T]) Aggregate() T {
+	return s.aggregate
+}
+
+// eventData the json fields of the event without the fields of its domain event envelope
+func eventData(t testing.TB, event domain.IDomainEvent) map[string]interface{} {
+	t.Helper()
+
+	data, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	fields := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(data, &fields))
+
+	for _, field := range domainEventEnvelopeFields {
+		delete(fields, field)
+	}
+
+	return fields
+}
+
+// withDomainEvent sets the nil domain event envelope of the event
+func withDomainEvent(event domain.IDomainEvent) {
+	value := reflect.ValueOf(event)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return
+	}
+
+	envelope := value.Elem().FieldByName("DomainEvent")
+	if envelope.IsValid() && envelope.Kind() == reflect.Ptr && envelope.IsNil() && envelope.CanSet() {
+		envelope.Set(reflect.ValueOf(domain.NewDomainEvent(typeMapper.GetTypeName(event))))
+	}
+}
+
+func newEmptyAggregate[T models.IHaveEventSourcedAggregate]() T {
+	aggregate := reflect.New(reflect.TypeOf(*new(T)).Elem()).Interface().(T)
+	aggregate.NewEmptyAggregate()
+
+	return aggregate
+}
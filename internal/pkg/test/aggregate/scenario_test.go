@@ -0,0 +1,113 @@
+//go:build unit
+// +build unit
+
+package aggregate
+
+import (
+	"testing"
+
+	"github.com/reoden/go-NFT/pkg/core/domain"
+	"github.com/reoden/go-NFT/pkg/es/models"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var errLampBroken = errors.New("lamp is broken")
+
+type lampSwitched struct {
+	*domain.DomainEvent
+	On bool `json:"on"`
+}
+
+type lampBroken struct {
+	*domain.DomainEvent
+}
+
+type testLamp struct {
+	*models.EventSourcedAggregateRoot
+	On     bool
+	Broken bool
+}
+
+func newTestLamp(id uuid.UUID) (*testLamp, error) {
+	lamp := &testLamp{}
+	lamp.EventSourcedAggregateRoot = models.NewEventSourcedAggregateRootWithId(
+		id,
+		typeMapper.GetTypeName(lamp),
+		lamp.When,
+	)
+
+	return lamp, lamp.Switch(false)
+}
+
+func (l *testLamp) NewEmptyAggregate() {
+	l.EventSourcedAggregateRoot = models.NewEventSourcedAggregateRoot(typeMapper.GetTypeName(l), l.When)
+}
+
+func (l *testLamp) Switch(on bool) error {
+	if l.Broken {
+		return errLampBroken
+	}
+
+	return l.Apply(&lampSwitched{
+		DomainEvent: domain.NewDomainEvent(typeMapper.GetTypeName(&lampSwitched{})),
+		On:          on,
+	}, true)
+}
+
+func (l *testLamp) When(event domain.IDomainEvent) error {
+	if uuid.Equal(l.Id(), uuid.Nil) {
+		l.SetId(event.GetAggregateId())
+	}
+
+	switch e := event.(type) {
+	case *lampSwitched:
+		l.On = e.On
+	case *lampBroken:
+		l.Broken = true
+	}
+
+	return nil
+}
+
+func Test_Scenario_Given_When_Then(t *testing.T) {
+	scenario := For[*testLamp](t).
+		Given(&lampSwitched{On: true}).
+		When(func(lamp *testLamp) error { return lamp.Switch(false) }).
+		Then(&lampSwitched{On: false}).
+		ThenState(func(lamp *testLamp) {
+			assert.False(t, lamp.On)
+			assert.Equal(t, int64(0), lamp.OriginalVersion())
+			assert.Equal(t, int64(1), lamp.CurrentVersion())
+		})
+
+	assert.NotEqual(t, uuid.Nil, scenario.Aggregate().Id())
+	assert.Equal(t, scenario.Aggregate().Id(), scenario.Aggregate().UncommittedEvents()[0].GetAggregateId())
+}
+
+func Test_Scenario_Then_Error(t *testing.T) {
+	For[*testLamp](t).
+		Given(&lampSwitched{On: true}, &lampBroken{}).
+		When(func(lamp *testLamp) error { return lamp.Switch(false) }).
+		ThenError(errLampBroken)
+}
+
+func Test_Scenario_When_Created(t *testing.T) {
+	For[*testLamp](t).
+		WhenCreated(func() (*testLamp, error) { return newTestLamp(uuid.NewV4()) }).
+		Then(&lampSwitched{On: false})
+
+	lamp, err := newTestLamp(uuid.NewV4())
+	assert.NoError(t, err)
+
+	For[*testLamp](t).
+		GivenAggregate(lamp).
+		When(func(lamp *testLamp) error { return lamp.Switch(true) }).
+		Then(&lampSwitched{On: true}).
+		ThenState(func(lamp *testLamp) {
+			assert.Equal(t, int64(0), lamp.OriginalVersion())
+		})
+}
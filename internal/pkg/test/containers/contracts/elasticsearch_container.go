@@ -0,0 +1,26 @@
+package contracts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reoden/go-NFT/pkg/elasticsearch"
+)
+
+type ElasticsearchContainerOptions struct {
+	Host      string
+	Port      string
+	HostPort  int
+	ImageName string
+	Name      string
+	Tag       string
+}
+
+type ElasticsearchContainer interface {
+	PopulateContainerOptions(
+		ctx context.Context,
+		t *testing.T,
+		options ...*ElasticsearchContainerOptions,
+	) (*elasticsearch.ElasticOptions, error)
+	Cleanup(ctx context.Context) error
+}
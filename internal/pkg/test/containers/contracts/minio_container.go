@@ -0,0 +1,29 @@
+package contracts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reoden/go-NFT/pkg/storage"
+)
+
+type MinioContainerOptions struct {
+	Host      string
+	Port      string
+	HostPort  int
+	UserName  string
+	Password  string
+	Bucket    string
+	ImageName string
+	Name      string
+	Tag       string
+}
+
+type MinioContainer interface {
+	PopulateContainerOptions(
+		ctx context.Context,
+		t *testing.T,
+		options ...*MinioContainerOptions,
+	) (*storage.StorageOptions, error)
+	Cleanup(ctx context.Context) error
+}
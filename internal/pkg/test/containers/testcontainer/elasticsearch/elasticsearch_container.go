@@ -0,0 +1,168 @@
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	elasticsearch2 "github.com/reoden/go-NFT/pkg/elasticsearch"
+	"github.com/reoden/go-NFT/pkg/health/contracts"
+	"github.com/reoden/go-NFT/pkg/logger"
+	containercontracts "github.com/reoden/go-NFT/pkg/test/containers/contracts"
+
+	"emperror.dev/errors"
+	"github.com/docker/go-connections/nat"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+type elasticsearchTestContainers struct {
+	container      testcontainers.Container
+	defaultOptions *containercontracts.ElasticsearchContainerOptions
+	logger         logger.Logger
+}
+
+func NewElasticsearchTestContainers(l logger.Logger) containercontracts.ElasticsearchContainer {
+	return &elasticsearchTestContainers{
+		defaultOptions: &containercontracts.ElasticsearchContainerOptions{
+			Port:      "9200/tcp",
+			Host:      "localhost",
+			Tag:       "8.15.0",
+			ImageName: "docker.elastic.co/elasticsearch/elasticsearch",
+			Name:      "elasticsearch-testcontainers",
+		},
+		logger: l,
+	}
+}
+
+func (g *elasticsearchTestContainers) PopulateContainerOptions(
+	ctx context.Context,
+	t *testing.T,
+	options ...*containercontracts.ElasticsearchContainerOptions,
+) (*elasticsearch2.ElasticOptions, error) {
+	// https://github.com/testcontainers/testcontainers-go
+	containerReq := g.getRunOptions(options...)
+
+	dbContainer, err := testcontainers.GenericContainer(
+		ctx,
+		testcontainers.GenericContainerRequest{
+			ContainerRequest: containerReq,
+			Started:          true,
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := dbContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// get a free random host hostPort
+	hostPort, err := dbContainer.MappedPort(
+		ctx,
+		nat.Port(g.defaultOptions.Port),
+	)
+	if err != nil {
+		return nil, err
+	}
+	g.defaultOptions.HostPort = hostPort.Int()
+
+	host, err := dbContainer.Host(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	g.container = dbContainer
+
+	elasticOptions := &elasticsearch2.ElasticOptions{
+		URL: fmt.Sprintf("http://%s:%d", host, g.defaultOptions.HostPort),
+	}
+
+	isConnectable := isConnectable(ctx, g.logger, elasticOptions)
+	if !isConnectable {
+		return g.PopulateContainerOptions(context.Background(), t, options...)
+	}
+
+	return elasticOptions, nil
+}
+
+func (g *elasticsearchTestContainers) Cleanup(ctx context.Context) error {
+	if err := g.container.Terminate(ctx); err != nil {
+		return errors.WrapIf(err, "failed to terminate container: %s")
+	}
+
+	return nil
+}
+
+func (g *elasticsearchTestContainers) getRunOptions(
+	opts ...*containercontracts.ElasticsearchContainerOptions,
+) testcontainers.ContainerRequest {
+	if len(opts) > 0 && opts[0] != nil {
+		option := opts[0]
+		if option.ImageName != "" {
+			g.defaultOptions.ImageName = option.ImageName
+		}
+		if option.Host != "" {
+			g.defaultOptions.Host = option.Host
+		}
+		if option.Port != "" {
+			g.defaultOptions.Port = option.Port
+		}
+		if option.Tag != "" {
+			g.defaultOptions.Tag = option.Tag
+		}
+	}
+
+	containerReq := testcontainers.ContainerRequest{
+		Image: fmt.Sprintf(
+			"%s:%s",
+			g.defaultOptions.ImageName,
+			g.defaultOptions.Tag,
+		),
+		ExposedPorts: []string{g.defaultOptions.Port},
+		WaitingFor: wait.ForHTTP("/").
+			WithPort(nat.Port(g.defaultOptions.Port)).
+			WithStartupTimeout(2 * time.Minute).
+			WithPollInterval(2 * time.Second),
+		Hostname: g.defaultOptions.Host,
+		Env: map[string]string{
+			"discovery.type":         "single-node",
+			"xpack.security.enabled": "false",
+			"ES_JAVA_OPTS":           "-Xms512m -Xmx512m",
+		},
+	}
+
+	return containerReq
+}
+
+func isConnectable(
+	ctx context.Context,
+	logger logger.Logger,
+	options *elasticsearch2.ElasticOptions,
+) bool {
+	client, err := elasticsearch2.NewElasticClient(options)
+	if err != nil {
+		logger.Errorf("Error in creating elasticsearch client with %s", options.URL)
+
+		return false
+	}
+
+	var healthChecker contracts.Health = elasticsearch2.NewElasticHealthChecker(client)
+	if err := healthChecker.CheckHealth(ctx); err != nil {
+		// we should not use `t.Error` or `t.Errorf` for logging errors because it will `fail` our test at the end and, we just should use logs without error like log.Error (not log.Fatal)
+		logger.Errorf(
+			"Error in creating elasticsearch connection with %s",
+			options.URL,
+		)
+
+		return false
+	}
+
+	logger.Infof("Opened elasticsearch connection on %s", options.URL)
+
+	return true
+}
@@ -0,0 +1,63 @@
+//go:build integration
+// +build integration
+
+package elasticsearch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reoden/go-NFT/pkg/config"
+	"github.com/reoden/go-NFT/pkg/config/environment"
+	"github.com/reoden/go-NFT/pkg/core"
+	elasticsearch2 "github.com/reoden/go-NFT/pkg/elasticsearch"
+	"github.com/reoden/go-NFT/pkg/logger/external/fxlog"
+	"github.com/reoden/go-NFT/pkg/logger/zap"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+func Test_Custom_Elasticsearch_Container(t *testing.T) {
+	ctx := context.Background()
+	var client *elasticsearch.Client
+
+	fxtest.New(t,
+		config.ModuleFunc(environment.Test),
+		zap.Module,
+		fxlog.FxLogger,
+		core.Module,
+		elasticsearch2.Module,
+		fx.Decorate(ElasticsearchContainerOptionsDecorator(t, ctx)),
+		fx.Populate(&client),
+	).RequireStart()
+
+	assert.NotNil(t, client)
+
+	type document struct {
+		Name string `json:"name"`
+	}
+
+	err := elasticsearch2.CreateIndex(ctx, client, "documents-v1", nil)
+	require.NoError(t, err)
+
+	_, err = elasticsearch2.SwapAlias(ctx, client, "documents", "documents-v1")
+	require.NoError(t, err)
+
+	err = elasticsearch2.BulkIndex(ctx, client, "documents", []elasticsearch2.BulkDocument{
+		{Id: "1", Document: &document{Name: "blue ape"}},
+		{Id: "2", Document: &document{Name: "red punk"}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, elasticsearch2.RefreshIndex(ctx, client, "documents"))
+
+	res, err := elasticsearch2.Search(ctx, client, "documents", map[string]interface{}{
+		"query": map[string]interface{}{"match": map[string]interface{}{"name": "ape"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), res.Hits.Total.Value)
+	assert.Equal(t, "1", res.Hits.Hits[0].Id)
+}
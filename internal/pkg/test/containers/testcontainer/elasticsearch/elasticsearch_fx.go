@@ -0,0 +1,15 @@
+package elasticsearch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reoden/go-NFT/pkg/elasticsearch"
+	"github.com/reoden/go-NFT/pkg/logger"
+)
+
+var ElasticsearchContainerOptionsDecorator = func(t *testing.T, ctx context.Context) interface{} {
+	return func(c *elasticsearch.ElasticOptions, logger logger.Logger) (*elasticsearch.ElasticOptions, error) {
+		return NewElasticsearchTestContainers(logger).PopulateContainerOptions(ctx, t)
+	}
+}
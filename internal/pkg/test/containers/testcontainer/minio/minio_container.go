@@ -0,0 +1,185 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/storage"
+	containercontracts "github.com/reoden/go-NFT/pkg/test/containers/contracts"
+
+	"emperror.dev/errors"
+	"github.com/docker/go-connections/nat"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+type minioTestContainers struct {
+	container      testcontainers.Container
+	defaultOptions *containercontracts.MinioContainerOptions
+	logger         logger.Logger
+}
+
+func NewMinioTestContainers(l logger.Logger) containercontracts.MinioContainer {
+	return &minioTestContainers{
+		defaultOptions: &containercontracts.MinioContainerOptions{
+			Port:      "9000/tcp",
+			Host:      "localhost",
+			UserName:  "minioadmin",
+			Password:  "minioadmin",
+			Bucket:    "test",
+			Tag:       "RELEASE.2024-08-17T01-24-54Z",
+			ImageName: "minio/minio",
+			Name:      "minio-testcontainers",
+		},
+		logger: l,
+	}
+}
+
+func (g *minioTestContainers) PopulateContainerOptions(
+	ctx context.Context,
+	t *testing.T,
+	options ...*containercontracts.MinioContainerOptions,
+) (*storage.StorageOptions, error) {
+	// https://github.com/testcontainers/testcontainers-go
+	containerReq := g.getRunOptions(options...)
+
+	dbContainer, err := testcontainers.GenericContainer(
+		ctx,
+		testcontainers.GenericContainerRequest{
+			ContainerRequest: containerReq,
+			Started:          true,
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := dbContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// get a free random host hostPort
+	hostPort, err := dbContainer.MappedPort(
+		ctx,
+		nat.Port(g.defaultOptions.Port),
+	)
+	if err != nil {
+		return nil, err
+	}
+	g.defaultOptions.HostPort = hostPort.Int()
+
+	host, err := dbContainer.Host(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	g.container = dbContainer
+
+	storageOptions := &storage.StorageOptions{
+		Provider:        storage.S3Provider,
+		SignedUrlExpiry: 900,
+		MaxUploadSize:   20 * 1024 * 1024,
+		S3: storage.S3StorageOptions{
+			Endpoint:     fmt.Sprintf("%s:%d", host, g.defaultOptions.HostPort),
+			Region:       "us-east-1",
+			AccessKey:    g.defaultOptions.UserName,
+			SecretKey:    g.defaultOptions.Password,
+			Bucket:       g.defaultOptions.Bucket,
+			CreateBucket: true,
+		},
+	}
+
+	isConnectable := isConnectable(ctx, g.logger, storageOptions)
+	if !isConnectable {
+		return g.PopulateContainerOptions(context.Background(), t, options...)
+	}
+
+	return storageOptions, nil
+}
+
+func (g *minioTestContainers) Cleanup(ctx context.Context) error {
+	if err := g.container.Terminate(ctx); err != nil {
+		return errors.WrapIf(err, "failed to terminate container: %s")
+	}
+
+	return nil
+}
+
+func (g *minioTestContainers) getRunOptions(
+	opts ...*containercontracts.MinioContainerOptions,
+) testcontainers.ContainerRequest {
+	if len(opts) > 0 && opts[0] != nil {
+		option := opts[0]
+		if option.ImageName != "" {
+			g.defaultOptions.ImageName = option.ImageName
+		}
+		if option.Host != "" {
+			g.defaultOptions.Host = option.Host
+		}
+		if option.Port != "" {
+			g.defaultOptions.Port = option.Port
+		}
+		if option.UserName != "" {
+			g.defaultOptions.UserName = option.UserName
+		}
+		if option.Password != "" {
+			g.defaultOptions.Password = option.Password
+		}
+		if option.Bucket != "" {
+			g.defaultOptions.Bucket = option.Bucket
+		}
+		if option.Tag != "" {
+			g.defaultOptions.Tag = option.Tag
+		}
+	}
+
+	containerReq := testcontainers.ContainerRequest{
+		Image: fmt.Sprintf(
+			"%s:%s",
+			g.defaultOptions.ImageName,
+			g.defaultOptions.Tag,
+		),
+		ExposedPorts: []string{g.defaultOptions.Port},
+		Cmd:          []string{"server", "/data"},
+		WaitingFor: wait.ForHTTP("/minio/health/live").
+			WithPort(nat.Port(g.defaultOptions.Port)).
+			WithStartupTimeout(2 * time.Minute).
+			WithPollInterval(2 * time.Second),
+		Hostname: g.defaultOptions.Host,
+		Env: map[string]string{
+			"MINIO_ROOT_USER":     g.defaultOptions.UserName,
+			"MINIO_ROOT_PASSWORD": g.defaultOptions.Password,
+		},
+	}
+
+	return containerReq
+}
+
+func isConnectable(
+	ctx context.Context,
+	logger logger.Logger,
+	options *storage.StorageOptions,
+) bool {
+	store, err := storage.NewS3ObjectStore(ctx, &options.S3)
+	if err != nil {
+		// we should not use `t.Error` or `t.Errorf` for logging errors because it will `fail` our test at the end and, we just should use logs without error like log.Error (not log.Fatal)
+		logger.Errorf("Error in creating minio connection with %s", options.S3.Endpoint)
+
+		return false
+	}
+
+	if err := storage.NewStorageHealthChecker(store).CheckHealth(ctx); err != nil {
+		logger.Errorf("Error in checking minio bucket on %s", options.S3.Endpoint)
+
+		return false
+	}
+
+	logger.Infof("Opened minio connection on %s", options.S3.Endpoint)
+
+	return true
+}
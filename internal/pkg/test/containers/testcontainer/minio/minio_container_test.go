@@ -0,0 +1,65 @@
+//go:build integration
+// +build integration
+
+package minio
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/config"
+	"github.com/reoden/go-NFT/pkg/config/environment"
+	"github.com/reoden/go-NFT/pkg/core"
+	"github.com/reoden/go-NFT/pkg/logger/external/fxlog"
+	"github.com/reoden/go-NFT/pkg/logger/zap"
+	"github.com/reoden/go-NFT/pkg/storage"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+func Test_Custom_Minio_Container(t *testing.T) {
+	ctx := context.Background()
+	var store storage.ObjectStore
+
+	fxtest.New(t,
+		config.ModuleFunc(environment.Test),
+		zap.Module,
+		fxlog.FxLogger,
+		core.Module,
+		storage.Module,
+		fx.Decorate(MinioContainerOptionsDecorator(t, ctx)),
+		fx.Populate(&store),
+	).RequireStart()
+
+	require.NotNil(t, store)
+
+	info, err := store.Put(ctx, "media/ape.txt", strings.NewReader("blue ape"), 8, "text/plain")
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), info.Size)
+
+	info, err = store.Stat(ctx, "media/ape.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "text/plain", info.ContentType)
+
+	signedUrl, err := store.SignedURL(ctx, "media/ape.txt", time.Minute)
+	require.NoError(t, err)
+
+	res, err := http.Get(signedUrl)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	b, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "blue ape", string(b))
+
+	require.NoError(t, store.Delete(ctx, "media/ape.txt"))
+	_, err = store.Stat(ctx, "media/ape.txt")
+	assert.True(t, errors.Is(err, storage.ErrObjectNotFound))
+}
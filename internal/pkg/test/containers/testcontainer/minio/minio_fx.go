@@ -0,0 +1,15 @@
+package minio
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/storage"
+)
+
+var MinioContainerOptionsDecorator = func(t *testing.T, ctx context.Context) interface{} {
+	return func(c *storage.StorageOptions, logger logger.Logger) (*storage.StorageOptions, error) {
+		return NewMinioTestContainers(logger).PopulateContainerOptions(ctx, t)
+	}
+}
@@ -0,0 +1,94 @@
+package contracts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	"github.com/reoden/go-NFT/pkg/core/messaging/versioning"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+)
+
+// UpdateContractsEnv the environment variable that records the compatible changes of the contracts in their files
+const UpdateContractsEnv = "UPDATE_CONTRACTS"
+
+// AssertContractCompatible fails the test when the contract of the message T is changed incompatibly against its
+// published contract in the contracts directory. The contract of a new schema version is recorded on its first run, the
+// file of the contract should be committed with the message. The compatible additions are recorded when the
+// UPDATE_CONTRACTS environment variable is true.
+func AssertContractCompatible[T types.IMessage](t *testing.T, contractsDir string) {
+	t.Helper()
+
+	current := versioning.DescribeContract(typeMapper.GenericInstanceByT[T]())
+	contractFile := filepath.Join(contractsDir, contractFileName(current))
+
+	data, err := os.ReadFile(contractFile)
+	if os.IsNotExist(err) {
+		writeContract(t, contractFile, current)
+		t.Logf("contract of the message `%s` version `%d` is recorded in `%s`", current.MessageType, current.SchemaVersion, contractFile)
+
+		return
+	}
+	if err != nil {
+		t.Fatalf("error in reading the contract file `%s`: %v", contractFile, err)
+	}
+
+	published := &versioning.MessageContract{}
+	if err := json.Unmarshal(data, published); err != nil {
+		t.Fatalf("error in unmarshaling the contract file `%s`: %v", contractFile, err)
+	}
+
+	if breakingChanges := versioning.CheckCompatibility(published, current); len(breakingChanges) > 0 {
+		t.Fatalf(
+			"contract of the message `%s` version `%d` is changed incompatibly:\n- %s\nincrease the schema version of the message and register an upcaster from the version `%d`",
+			current.MessageType,
+			current.SchemaVersion,
+			strings.Join(breakingChanges, "\n- "),
+			current.SchemaVersion,
+		)
+	}
+
+	if added := versioning.AddedFields(published, current); len(added) > 0 {
+		if os.Getenv(UpdateContractsEnv) == "true" {
+			writeContract(t, contractFile, current)
+			return
+		}
+
+		t.Logf(
+			"contract of the message `%s` has the new compatible fields %v, run the test with %s=true to record them",
+			current.MessageType,
+			added,
+			UpdateContractsEnv,
+		)
+	}
+}
+
+func contractFileName(contract *versioning.MessageContract) string {
+	return fmt.Sprintf("%s.v%d.json", strings.TrimPrefix(contract.MessageType, "*"), contract.SchemaVersion)
+}
+
+func writeContract(t *testing.T, contractFile string, contract *versioning.MessageContract) {
+	t.Helper()
+
+	// the kinds of the fields like `array<object>` are kept readable in the contract files
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(contract); err != nil {
+		t.Fatalf("error in marshaling the contract of the message `%s`: %v", contract.MessageType, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(contractFile), os.ModePerm); err != nil {
+		t.Fatalf("error in creating the contracts directory: %v", err)
+	}
+
+	if err := os.WriteFile(contractFile, buffer.Bytes(), 0o644); err != nil {
+		t.Fatalf("error in writing the contract file `%s`: %v", contractFile, err)
+	}
+}
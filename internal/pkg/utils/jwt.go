@@ -11,13 +11,26 @@ import (
 	uuid "github.com/satori/go.uuid"
 )
 
-func GenJWTToken(userId uuid.UUID) (string, error) {
-	claims := jwt.MapClaims{
+func GenJWTToken(userId uuid.UUID, role string) (string, error) {
+	return signJWTToken(jwt.MapClaims{
 		"userId": userId.String(),
+		"role":   role,
 		"exp":    time.Now().Add(constants.TokenExpireDuration).Unix(),
 		"iat":    time.Now().Unix(),
-	}
+	})
+}
+
+// GenServiceJWTToken generates the credential of a service for the service to service calls, it has the service role
+func GenServiceJWTToken(serviceName string) (string, error) {
+	return signJWTToken(jwt.MapClaims{
+		"service": serviceName,
+		"role":    constants.ServiceRole,
+		"exp":     time.Now().Add(constants.TokenExpireDuration).Unix(),
+		"iat":     time.Now().Unix(),
+	})
+}
 
+func signJWTToken(claims jwt.MapClaims) (string, error) {
 	rawToken := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	secret := []byte(os.Getenv("JWT_SECRET"))
 	token, err := rawToken.SignedString(secret)
@@ -28,16 +41,81 @@ func GenJWTToken(userId uuid.UUID) (string, error) {
 }
 
 func ParseJWTToken(c echo.Context) (string, uuid.UUID, error) {
-	token, ok := c.Get("user").(*jwt.Token)
+	token, claims, err := jwtClaimsOf(c)
+	if err != nil {
+		return "", uuid.Nil, err
+	}
+
+	// the service credentials have no user id
+	uuidString, ok := claims["userId"].(string)
 	if !ok {
 		return "", uuid.Nil, errors.New(constants.ErrJWTTokenInvalid)
 	}
+	userId, err := uuid.FromString(uuidString)
+	return token.Raw, userId, err
+}
+
+// ParseJWTRole returns the role of the token validated by the jwt middleware
+func ParseJWTRole(c echo.Context) (string, error) {
+	_, claims, err := jwtClaimsOf(c)
+	if err != nil {
+		return "", err
+	}
+
+	role, ok := claims["role"].(string)
+	if !ok {
+		return "", errors.New(constants.ErrJWTTokenInvalid)
+	}
+
+	return role, nil
+}
+
+func jwtClaimsOf(c echo.Context) (*jwt.Token, jwt.MapClaims, error) {
+	token, ok := c.Get("user").(*jwt.Token)
+	if !ok {
+		return nil, nil, errors.New(constants.ErrJWTTokenInvalid)
+	}
 
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok {
-		return "", uuid.Nil, errors.New(constants.ErrJWTTokenFailedCastClaim)
+		return nil, nil, errors.New(constants.ErrJWTTokenFailedCastClaim)
 	}
-	uuidString := claims["userId"].(string)
-	userId, err := uuid.FromString(uuidString)
-	return token.Raw, userId, err
+
+	return token, claims, nil
+}
+
+// ParseJWTClaims validates the signature and the expiration of the raw token and returns its claims
+func ParseJWTClaims(rawToken string) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(
+		rawToken,
+		func(token *jwt.Token) (interface{}, error) {
+			return []byte(os.Getenv("JWT_SECRET")), nil
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+	)
+	if err != nil || !token.Valid {
+		return nil, errors.New(constants.ErrJWTTokenInvalid)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.New(constants.ErrJWTTokenFailedCastClaim)
+	}
+
+	return claims, nil
+}
+
+// ValidateJWTToken validates the signature and the expiration of the raw token and returns its userId
+func ValidateJWTToken(rawToken string) (uuid.UUID, error) {
+	claims, err := ParseJWTClaims(rawToken)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	uuidString, ok := claims["userId"].(string)
+	if !ok {
+		return uuid.Nil, errors.New(constants.ErrJWTTokenInvalid)
+	}
+
+	return uuid.FromString(uuidString)
 }
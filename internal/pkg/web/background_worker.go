@@ -26,7 +26,7 @@ func NewBackgroundWorker(executionFunc ExecutionFunc, stopFunc StopFunc) Worker
 	return &BackgroundWorker{executionFunc: executionFunc, stopFunc: stopFunc, errChan: make(chan error)}
 }
 
-func (b BackgroundWorker) Start(ctx context.Context) chan error {
+func (b *BackgroundWorker) Start(ctx context.Context) chan error {
 	b.ctx, b.cancelFunc = context.WithCancel(ctx)
 	go func() {
 		if b.executionFunc == nil {
@@ -42,7 +42,7 @@ func (b BackgroundWorker) Start(ctx context.Context) chan error {
 	return b.errChan
 }
 
-func (b BackgroundWorker) Stop(ctx context.Context) error {
+func (b *BackgroundWorker) Stop(ctx context.Context) error {
 	if b.executionFunc == nil {
 		return nil
 	}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	appconfig "github.com/reoden/go-NFT/catalogs/config"
+	"github.com/reoden/go-NFT/catalogs/internal/products/projections"
+	"github.com/reoden/go-NFT/pkg/config"
+	"github.com/reoden/go-NFT/pkg/config/environment"
+	"github.com/reoden/go-NFT/pkg/core"
+	"github.com/reoden/go-NFT/pkg/es"
+	"github.com/reoden/go-NFT/pkg/logger"
+	defaultLogger "github.com/reoden/go-NFT/pkg/logger/defaultlogger"
+	"github.com/reoden/go-NFT/pkg/logger/external/fxlog"
+	"github.com/reoden/go-NFT/pkg/logger/zap"
+	"github.com/reoden/go-NFT/pkg/otel/tracing"
+	"github.com/reoden/go-NFT/pkg/postgreseventstore"
+	gormPostgres "github.com/reoden/go-NFT/pkg/postgresgorm"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/fx"
+)
+
+func init() {
+	rootCmd.Flags().String("projection", "", "Name of the rebuilt projection")
+	rootCmd.Flags().Int("batch-size", 500, "Number of events read from the event store in each read")
+	rootCmd.Flags().Int("max-events-per-second", 0, "Maximum replayed events per second, 0 for no throttling")
+	_ = rootCmd.MarkFlagRequired("projection")
+}
+
+var rootCmd = &cobra.Command{ //nolint:gochecknoglobals
+	Use:   "rebuildprojection",
+	Short: "A tool for rebuilding a projection read model by replaying the events from the start of the event store",
+	Long: `A tool for rebuilding a projection read model by replaying the events from the start of the event store.
+The events are replayed into a shadow of the read model that replaces the read model at the end of the replay.
+Use it while the catalogs service is stopped, the running service rebuilds its projections with its admin endpoint.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		executeRebuild(cmd)
+	},
+}
+
+func executeRebuild(cmd *cobra.Command) {
+	projectionName, err := cmd.Flags().GetString("projection")
+	if err != nil {
+		defaultLogger.GetLogger().Fatal(err)
+	}
+
+	batchSize, err := cmd.Flags().GetInt("batch-size")
+	if err != nil {
+		defaultLogger.GetLogger().Fatal(err)
+	}
+
+	maxEventsPerSecond, err := cmd.Flags().GetInt("max-events-per-second")
+	if err != nil {
+		defaultLogger.GetLogger().Fatal(err)
+	}
+
+	app := fx.New(
+		config.ModuleFunc(environment.Development),
+		zap.Module,
+		fxlog.FxLogger,
+		core.Module,
+		gormPostgres.Module,
+		tracing.Module,
+		appconfig.Module,
+		postgreseventstore.Module,
+		fx.Provide(es.AsProjection(projections.NewEditionProvenanceProjection)),
+		fx.Invoke(
+			func(rebuilder es.ProjectionRebuilder, logger logger.Logger) {
+				logger.Infof(
+					"Rebuild of projection '%s' started, rebuildable projections: %v",
+					projectionName,
+					rebuilder.ProjectionNames(),
+				)
+				progress, err := rebuilder.Rebuild(
+					context.Background(),
+					projectionName,
+					&es.ProjectionRebuildOptions{
+						BatchSize:          batchSize,
+						MaxEventsPerSecond: maxEventsPerSecond,
+					},
+				)
+				if err != nil {
+					logger.Fatalf("rebuild failed, err: %s", err)
+				}
+				logger.Infof(
+					"Rebuild completed, %d events replayed into '%s' up to position %d...",
+					progress.ProcessedEvents,
+					progress.ProjectionName,
+					progress.Position,
+				)
+			},
+		),
+	)
+
+	err = app.Start(context.Background())
+	if err != nil {
+		defaultLogger.GetLogger().Fatal(err)
+	}
+
+	err = app.Stop(context.Background())
+	if err != nil {
+		defaultLogger.GetLogger().Fatal(err)
+	}
+}
+
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		defaultLogger.GetLogger().Error(err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	appconfig "github.com/reoden/go-NFT/catalogs/config"
+	"github.com/reoden/go-NFT/catalogs/internal/products/configurations/mappings"
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/repositories"
+	reindexingproductsv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/reindexingproducts/v1"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/reindexingproducts/v1/dtos"
+	"github.com/reoden/go-NFT/catalogs/internal/shared/data"
+	"github.com/reoden/go-NFT/pkg/config"
+	"github.com/reoden/go-NFT/pkg/config/environment"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	"github.com/reoden/go-NFT/pkg/elasticsearch"
+	"github.com/reoden/go-NFT/pkg/logger"
+	defaultLogger "github.com/reoden/go-NFT/pkg/logger/defaultlogger"
+	"github.com/reoden/go-NFT/pkg/logger/external/fxlog"
+	"github.com/reoden/go-NFT/pkg/logger/zap"
+	"github.com/reoden/go-NFT/pkg/otel/tracing"
+	gormPostgres "github.com/reoden/go-NFT/pkg/postgresgorm"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/fx"
+)
+
+func init() {
+	rootCmd.Flags().Int("batch-size", 500, "Number of products indexed in each bulk request")
+}
+
+var rootCmd = &cobra.Command{ //nolint:gochecknoglobals
+	Use:   "reindex",
+	Short: "A tool for rebuilding the products search index from the database",
+	Run: func(cmd *cobra.Command, args []string) {
+		executeReindex(cmd)
+	},
+}
+
+func executeReindex(cmd *cobra.Command) {
+	batchSize, err := cmd.Flags().GetInt("batch-size")
+	if err != nil {
+		defaultLogger.GetLogger().Fatal(err)
+	}
+
+	command, err := reindexingproductsv1.NewReindexProductsWithValidation(batchSize)
+	if err != nil {
+		defaultLogger.GetLogger().Fatal(err)
+	}
+
+	if err = mappings.ConfigureProductsMappings(); err != nil {
+		defaultLogger.GetLogger().Fatal(err)
+	}
+
+	app := fx.New(
+		config.ModuleFunc(environment.Development),
+		zap.Module,
+		fxlog.FxLogger,
+		gormPostgres.Module,
+		elasticsearch.Module,
+		tracing.Module,
+		appconfig.Module,
+		data.Module,
+		fx.Provide(
+			repositories.NewElasticProductSearchRepository,
+			reindexingproductsv1.NewReindexProductsHandler,
+		),
+		fx.Invoke(
+			func(
+				handler cqrs.RequestHandlerWithRegisterer[*reindexingproductsv1.ReindexProducts, *dtos.ReindexProductsResponseDto],
+				logger logger.Logger,
+			) {
+				logger.Info("Reindex process started...")
+				result, err := handler.Handle(context.Background(), command)
+				if err != nil {
+					logger.Fatalf("reindex failed, err: %s", err)
+				}
+				logger.Infof(
+					"Reindex completed, %d products indexed into '%s'...",
+					result.IndexedCount,
+					result.IndexName,
+				)
+			},
+		),
+	)
+
+	err = app.Start(context.Background())
+	if err != nil {
+		defaultLogger.GetLogger().Fatal(err)
+	}
+
+	err = app.Stop(context.Background())
+	if err != nil {
+		defaultLogger.GetLogger().Fatal(err)
+	}
+}
+
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		defaultLogger.GetLogger().Error(err)
+		os.Exit(1)
+	}
+}
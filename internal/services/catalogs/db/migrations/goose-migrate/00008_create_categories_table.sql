@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS categories
+(
+    id          uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name        text NOT NULL,
+    slug        text NOT NULL UNIQUE,
+    description text,
+    parent_id   uuid REFERENCES categories (id) ON DELETE RESTRICT,
+    path        text NOT NULL,
+    created_at  timestamp with time zone,
+    updated_at  timestamp with time zone
+);
+
+CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON categories (parent_id);
+CREATE INDEX IF NOT EXISTS idx_categories_path ON categories (path text_pattern_ops);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE categories;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS tags
+(
+    id         uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name       text NOT NULL UNIQUE,
+    created_at timestamp with time zone
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE tags;
+-- +goose StatementEnd
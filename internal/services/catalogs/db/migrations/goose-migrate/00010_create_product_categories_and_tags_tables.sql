@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS product_categories
+(
+    product_id  uuid NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    category_id uuid NOT NULL REFERENCES categories (id) ON DELETE CASCADE,
+    PRIMARY KEY (product_id, category_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_categories_category_id ON product_categories (category_id);
+
+CREATE TABLE IF NOT EXISTS product_tags
+(
+    product_id uuid NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    tag_id     uuid NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (product_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_tags_tag_id ON product_tags (tag_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE product_tags;
+DROP TABLE product_categories;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS media_assets
+(
+    id            uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id    uuid   NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    kind          text   NOT NULL,
+    file_name     text   NOT NULL,
+    content_type  text   NOT NULL,
+    size          bigint NOT NULL,
+    hash          text   NOT NULL,
+    storage_key   text   NOT NULL,
+    thumbnail_key text,
+    created_at    timestamp with time zone,
+    UNIQUE (product_id, hash)
+);
+
+CREATE INDEX IF NOT EXISTS idx_media_assets_hash ON media_assets (hash);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE media_assets;
+-- +goose StatementEnd
@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE products ALTER COLUMN price TYPE numeric(38, 18) USING price::numeric(38, 18);
+ALTER TABLE products ADD COLUMN IF NOT EXISTS currency text NOT NULL DEFAULT 'USD';
+
+CREATE TABLE IF NOT EXISTS product_prices
+(
+    id           uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id   uuid                     NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    price        numeric(38, 18)          NOT NULL,
+    currency     text                     NOT NULL,
+    reason       text                     NOT NULL,
+    effective_at timestamp with time zone NOT NULL,
+    created_at   timestamp with time zone
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_prices_product_id_effective_at ON product_prices (product_id, effective_at);
+
+-- the current prices are the first entries of the history
+INSERT INTO product_prices (product_id, price, currency, reason, effective_at, created_at)
+SELECT id, price, currency, 'created', COALESCE(created_at, now()), now()
+FROM products
+WHERE deleted_at IS NULL
+  AND price IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS product_price_schedules
+(
+    id         uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id uuid                     NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    price      numeric(38, 18)          NOT NULL,
+    currency   text                     NOT NULL,
+    apply_at   timestamp with time zone NOT NULL,
+    status     text                     NOT NULL,
+    applied_at timestamp with time zone,
+    created_at timestamp with time zone
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_price_schedules_product_id_status ON product_price_schedules (product_id, status);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE product_price_schedules;
+DROP TABLE product_prices;
+ALTER TABLE products DROP COLUMN IF EXISTS currency;
+ALTER TABLE products ALTER COLUMN price TYPE numeric;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS edition_provenance
+(
+    id             uuid PRIMARY KEY,
+    edition_id     uuid                     NOT NULL,
+    product_id     uuid                     NOT NULL,
+    token_id       text                     NOT NULL,
+    edition_number integer                  NOT NULL,
+    version        bigint                   NOT NULL,
+    event_type     text                     NOT NULL,
+    from_owner     text,
+    to_owner       text,
+    owner          text                     NOT NULL,
+    status         text                     NOT NULL,
+    frozen         boolean                  NOT NULL DEFAULT false,
+    price          numeric(38, 18),
+    currency       text,
+    reason         text,
+    position       bigint                   NOT NULL,
+    occurred_at    timestamp with time zone NOT NULL,
+    UNIQUE (edition_id, version)
+);
+
+CREATE INDEX IF NOT EXISTS idx_edition_provenance_product_id ON edition_provenance (product_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE edition_provenance;
+-- +goose StatementEnd
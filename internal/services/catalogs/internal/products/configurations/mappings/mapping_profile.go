@@ -5,7 +5,9 @@ import (
 	dtoV1 "github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1"
 	"github.com/reoden/go-NFT/catalogs/internal/products/models"
 	productsService "github.com/reoden/go-NFT/catalogs/internal/shared/grpc/genproto"
+	"github.com/reoden/go-NFT/pkg/es"
 	"github.com/reoden/go-NFT/pkg/mapper"
+	"github.com/reoden/go-NFT/pkg/rabbitmq/deadletter"
 
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -31,6 +33,216 @@ func ConfigureProductsMappings() error {
 		return err
 	}
 
+	err = mapper.CreateMap[*models.Category, *dtoV1.CategoryDto]()
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateMap[*dtoV1.CategoryDto, *models.Category]()
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateMap[*datamodel.CategoryDataModel, *models.Category]()
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateMap[*models.Category, *datamodel.CategoryDataModel]()
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateMap[*models.Tag, *dtoV1.TagDto]()
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateMap[*dtoV1.TagDto, *models.Tag]()
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateMap[*datamodel.TagDataModel, *models.Tag]()
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateMap[*models.Tag, *datamodel.TagDataModel]()
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateMap[*models.MediaAsset, *dtoV1.MediaAssetDto]()
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateMap[*dtoV1.MediaAssetDto, *models.MediaAsset]()
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateMap[*datamodel.MediaAssetDataModel, *models.MediaAsset]()
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateMap[*models.MediaAsset, *datamodel.MediaAssetDataModel]()
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateMap[*datamodel.ProductPriceDataModel, *models.ProductPrice]()
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateMap[*models.ProductPrice, *datamodel.ProductPriceDataModel]()
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateMap[*models.ProductPrice, *dtoV1.ProductPriceDto]()
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateMap[*datamodel.ProductPriceScheduleDataModel, *models.ProductPriceSchedule]()
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateMap[*models.ProductPriceSchedule, *datamodel.ProductPriceScheduleDataModel]()
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateMap[*models.ProductPriceSchedule, *dtoV1.ProductPriceScheduleDto]()
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateMap[*deadletter.DeadLetterQueue, *dtoV1.DeadLetterQueueDto]()
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateMap[*deadletter.DeadLetterMessage, *dtoV1.DeadLetterMessageDto]()
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateCustomMap(
+		func(progress *es.ProjectionRebuildProgress) *dtoV1.ProjectionRebuildProgressDto {
+			if progress == nil {
+				return nil
+			}
+
+			return &dtoV1.ProjectionRebuildProgressDto{
+				ProjectionName:  progress.ProjectionName,
+				State:           string(progress.State),
+				Position:        progress.Position,
+				HeadPosition:    progress.HeadPosition,
+				ProcessedEvents: progress.ProcessedEvents,
+				StartedAt:       progress.StartedAt,
+				CompletedAt:     progress.CompletedAt,
+				Error:           progress.Error,
+			}
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateCustomMap(
+		func(edition *models.Edition) *dtoV1.EditionDto {
+			if edition == nil {
+				return nil
+			}
+
+			editionDto := &dtoV1.EditionDto{
+				Id:            edition.Id(),
+				ProductId:     edition.ProductId,
+				TokenId:       edition.TokenId,
+				EditionNumber: edition.EditionNumber,
+				Owner:         edition.Owner,
+				Status:        string(edition.Status),
+				Frozen:        edition.Frozen,
+				Version:       edition.CurrentVersion(),
+			}
+			if edition.Status == models.EditionStatusListed {
+				price := edition.Price
+				editionDto.Price = &price
+				editionDto.Currency = edition.Currency
+			}
+
+			return editionDto
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateCustomMap(
+		func(entry *datamodel.EditionProvenanceDataModel) *dtoV1.EditionProvenanceEntryDto {
+			if entry == nil {
+				return nil
+			}
+
+			return &dtoV1.EditionProvenanceEntryDto{
+				EventId:    entry.Id,
+				Version:    entry.Version,
+				EventType:  entry.EventType,
+				FromOwner:  entry.FromOwner,
+				ToOwner:    entry.ToOwner,
+				Owner:      entry.Owner,
+				Status:     entry.Status,
+				Frozen:     entry.Frozen,
+				Price:      entry.Price,
+				Currency:   entry.Currency,
+				Reason:     entry.Reason,
+				OccurredAt: entry.OccurredAt,
+			}
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateCustomMap(
+		func(hit *models.ProductSearchHit) *dtoV1.ProductSearchItemDto {
+			if hit == nil {
+				return nil
+			}
+			productDto, _ := mapper.Map[*dtoV1.ProductDto](hit.Product)
+
+			return &dtoV1.ProductSearchItemDto{
+				ProductDto: productDto,
+				Score:      hit.Score,
+				Highlights: hit.Highlights,
+			}
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateMap[*models.FacetBucket, *dtoV1.FacetBucketDto]()
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateMap[*models.PriceRangeBucket, *dtoV1.PriceRangeBucketDto]()
+	if err != nil {
+		return err
+	}
+
+	err = mapper.CreateMap[*models.ProductSearchFacets, *dtoV1.ProductSearchFacetsDto]()
+	if err != nil {
+		return err
+	}
+
 	err = mapper.CreateCustomMap[*dtoV1.ProductDto, *productsService.Product](
 		func(product *dtoV1.ProductDto) *productsService.Product {
 			if product == nil {
@@ -40,7 +252,9 @@ func ConfigureProductsMappings() error {
 				ProductId:   product.Id.String(),
 				Name:        product.Name,
 				Description: product.Description,
-				Price:       product.Price,
+				Artist:      product.Artist,
+				Price:       &productsService.Money{Amount: product.Price.String(), Currency: product.Currency},
+				Version:     product.Version,
 				CreatedAt:   timestamppb.New(product.CreatedAt),
 				UpdatedAt:   timestamppb.New(product.UpdatedAt),
 			}
@@ -56,7 +270,9 @@ func ConfigureProductsMappings() error {
 				ProductId:   product.Id.String(),
 				Name:        product.Name,
 				Description: product.Description,
-				Price:       product.Price,
+				Artist:      product.Artist,
+				Price:       &productsService.Money{Amount: product.Price.String(), Currency: product.Currency},
+				Version:     product.Version,
 				CreatedAt:   timestamppb.New(product.CreatedAt),
 				UpdatedAt:   timestamppb.New(product.UpdatedAt),
 			}
@@ -1,17 +1,71 @@
 package rabbitmq
 
 import (
+	"github.com/reoden/go-NFT/catalogs/internal/products/contracts"
 	"github.com/reoden/go-NFT/catalogs/internal/products/features/creatingproduct/v1/events/integrationevents"
+	deletingProductIntegrationEvents "github.com/reoden/go-NFT/catalogs/internal/products/features/deletingproduct/v1/events/integrationevents"
+	updatingProductIntegrationEvents "github.com/reoden/go-NFT/catalogs/internal/products/features/updatingproduct/v1/events/integrationevents"
+	"github.com/reoden/go-NFT/pkg/core/messaging/consumer"
+	"github.com/reoden/go-NFT/pkg/core/messaging/pipeline"
+	"github.com/reoden/go-NFT/pkg/logger"
 	"github.com/reoden/go-NFT/pkg/rabbitmq/configurations"
+	consumerConfigurations "github.com/reoden/go-NFT/pkg/rabbitmq/consumer/configurations"
 	producerConfigurations "github.com/reoden/go-NFT/pkg/rabbitmq/producer/configurations"
 )
 
 func ConfigProductsRabbitMQ(
 	builder configurations.RabbitMQConfigurationBuilder,
+	log logger.Logger,
+	searchRepository contracts.ProductSearchRepository,
+	inboxPipelineFactory pipeline.InboxPipelineFactory,
 ) {
 	builder.AddProducer(
 		integrationevents.ProductCreatedV1{},
 		func(builder producerConfigurations.RabbitMQProducerConfigurationBuilder) {
 		},
+	).AddProducer(
+		updatingProductIntegrationEvents.ProductUpdatedV1{},
+		func(builder producerConfigurations.RabbitMQProducerConfigurationBuilder) {
+		},
+	).AddProducer(
+		deletingProductIntegrationEvents.ProductDeletedV1{},
+		func(builder producerConfigurations.RabbitMQProducerConfigurationBuilder) {
+		},
+	)
+
+	// products search index projection, the inbox skips the redelivered messages
+	builder.AddConsumer(
+		integrationevents.ProductCreatedV1{},
+		func(builder consumerConfigurations.RabbitMQConsumerConfigurationBuilder) {
+			builder.WithInbox(inboxPipelineFactory).WithHandlers(
+				func(handlersBuilder consumer.ConsumerHandlerConfigurationBuilder) {
+					handlersBuilder.AddHandler(
+						integrationevents.NewProductCreatedConsumer(log, searchRepository),
+					)
+				},
+			)
+		},
+	).AddConsumer(
+		updatingProductIntegrationEvents.ProductUpdatedV1{},
+		func(builder consumerConfigurations.RabbitMQConsumerConfigurationBuilder) {
+			builder.WithInbox(inboxPipelineFactory).WithHandlers(
+				func(handlersBuilder consumer.ConsumerHandlerConfigurationBuilder) {
+					handlersBuilder.AddHandler(
+						updatingProductIntegrationEvents.NewProductUpdatedConsumer(log, searchRepository),
+					)
+				},
+			)
+		},
+	).AddConsumer(
+		deletingProductIntegrationEvents.ProductDeletedV1{},
+		func(builder consumerConfigurations.RabbitMQConsumerConfigurationBuilder) {
+			builder.WithInbox(inboxPipelineFactory).WithHandlers(
+				func(handlersBuilder consumer.ConsumerHandlerConfigurationBuilder) {
+					handlersBuilder.AddHandler(
+						deletingProductIntegrationEvents.NewProductDeletedConsumer(log, searchRepository),
+					)
+				},
+			)
+		},
 	)
 }
@@ -0,0 +1,29 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type ProductSearchRepository interface {
+	// IndexProduct adds or replaces the product in the search index, it is skipped when a newer version of the product
+	// is already indexed
+	IndexProduct(ctx context.Context, product *models.Product) error
+	DeleteProduct(ctx context.Context, id uuid.UUID) error
+	SearchProducts(
+		ctx context.Context,
+		criteria *models.ProductSearchCriteria,
+	) (*models.ProductSearchResult, error)
+	// CreateIndex creates a new physical index and returns its name, the index isn't searchable until SwitchIndex
+	CreateIndex(ctx context.Context) (string, error)
+	// BulkIndexProducts adds or replaces the products in the index, the products whose newer version is already indexed
+	// are skipped
+	BulkIndexProducts(ctx context.Context, indexName string, products []*models.Product) error
+	// DeleteIndexedProducts removes the products from the physical index, the missing products are ignored
+	DeleteIndexedProducts(ctx context.Context, indexName string, ids []uuid.UUID) error
+	// SwitchIndex points the search alias to the index and removes the previous indices
+	SwitchIndex(ctx context.Context, indexName string) error
+}
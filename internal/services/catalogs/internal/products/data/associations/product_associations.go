@@ -0,0 +1,209 @@
+package associations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/datamodels"
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	"github.com/samber/lo"
+	uuid "github.com/satori/go.uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReplaceProductCategories replaces the product categories with the categories with the given ids, all categories should exist
+func ReplaceProductCategories(
+	ctx context.Context,
+	db *gorm.DB,
+	productID uuid.UUID,
+	categoryIds []uuid.UUID,
+) error {
+	categoryIds = lo.Uniq(categoryIds)
+
+	var categories []*datamodels.CategoryDataModel
+	if len(categoryIds) > 0 {
+		if err := db.WithContext(ctx).Where("id IN ?", categoryIds).Find(&categories).Error; err != nil {
+			return errors.WrapIf(err, "error in finding categories")
+		}
+	}
+
+	if len(categories) != len(categoryIds) {
+		missing, _ := lo.Difference(
+			categoryIds,
+			lo.Map(categories, func(c *datamodels.CategoryDataModel, _ int) uuid.UUID { return c.Id }),
+		)
+
+		return customErrors.NewNotFoundError(
+			fmt.Sprintf("categories with ids `%v` not found", missing),
+		)
+	}
+
+	// https://gorm.io/docs/associations.html#Replace-Associations
+	err := db.WithContext(ctx).
+		Model(&datamodels.ProductDataModel{Id: productID}).
+		Association("Categories").
+		Replace(categories)
+	if err != nil {
+		return errors.WrapIf(err, "error in replacing product categories")
+	}
+
+	return nil
+}
+
+// ReplaceProductTags replaces the product tags with the tags with the given names, missing tags will be created
+func ReplaceProductTags(
+	ctx context.Context,
+	db *gorm.DB,
+	productID uuid.UUID,
+	tagNames []string,
+) error {
+	tags, err := EnsureTags(ctx, db, tagNames)
+	if err != nil {
+		return err
+	}
+
+	err = db.WithContext(ctx).
+		Model(&datamodels.ProductDataModel{Id: productID}).
+		Association("Tags").
+		Replace(tags)
+	if err != nil {
+		return errors.WrapIf(err, "error in replacing product tags")
+	}
+
+	return nil
+}
+
+// EnsureTags returns the tags with the given names and creates the missing ones
+func EnsureTags(
+	ctx context.Context,
+	db *gorm.DB,
+	tagNames []string,
+) ([]*datamodels.TagDataModel, error) {
+	names := lo.Uniq(lo.FilterMap(tagNames, func(name string, _ int) (string, bool) {
+		normalized := models.NormalizeTagName(name)
+
+		return normalized, normalized != ""
+	}))
+	if len(names) == 0 {
+		return []*datamodels.TagDataModel{}, nil
+	}
+
+	newTags := lo.Map(names, func(name string, _ int) *datamodels.TagDataModel {
+		return &datamodels.TagDataModel{Id: uuid.NewV4(), Name: name}
+	})
+
+	// https://gorm.io/docs/create.html#Upsert-x2F-On-Conflict
+	err := db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).
+		Create(&newTags).Error
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in creating tags")
+	}
+
+	var tags []*datamodels.TagDataModel
+	if err := db.WithContext(ctx).Where("name IN ?", names).Find(&tags).Error; err != nil {
+		return nil, errors.WrapIf(err, "error in finding tags")
+	}
+
+	return tags, nil
+}
+
+// FindProductsWithAssociations returns the products with the given ids with their categories and tags
+func FindProductsWithAssociations(
+	ctx context.Context,
+	db *gorm.DB,
+	productIds []uuid.UUID,
+) ([]*datamodels.ProductDataModel, error) {
+	var products []*datamodels.ProductDataModel
+	if len(productIds) == 0 {
+		return products, nil
+	}
+
+	err := PreloadAssociations(db.WithContext(ctx)).
+		Where("id IN ?", productIds).
+		Find(&products).Error
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in finding products")
+	}
+
+	return products, nil
+}
+
+// FindProductIdsInCategoryTree returns the ids of the products that belong to the category with the given path or to its descendants
+func FindProductIdsInCategoryTree(
+	ctx context.Context,
+	db *gorm.DB,
+	categoryPath string,
+) ([]uuid.UUID, error) {
+	var productIds []uuid.UUID
+
+	err := db.WithContext(ctx).
+		Table("product_categories pc").
+		Joins("JOIN categories c ON c.id = pc.category_id").
+		Where("c.path = ? OR c.path LIKE ?", categoryPath, categoryPath+models.CategoryPathSeparator+"%").
+		Distinct("pc.product_id").
+		Pluck("pc.product_id", &productIds).Error
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in finding category products")
+	}
+
+	return productIds, nil
+}
+
+// FindProductIdsWithTag returns the ids of the products that have the tag with the given id
+func FindProductIdsWithTag(
+	ctx context.Context,
+	db *gorm.DB,
+	tagID uuid.UUID,
+) ([]uuid.UUID, error) {
+	var productIds []uuid.UUID
+
+	err := db.WithContext(ctx).
+		Table("product_tags").
+		Where("tag_id = ?", tagID).
+		Pluck("product_id", &productIds).Error
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in finding tag products")
+	}
+
+	return productIds, nil
+}
+
+// IncrementProductVersions increments the versions of the products whose representation is changed by a shared category
+// or tag, so their new representation replaces the older versions in the search index and is copied by the delta passes
+// of a running reindex
+func IncrementProductVersions(
+	ctx context.Context,
+	db *gorm.DB,
+	productIds []uuid.UUID,
+) error {
+	if len(productIds) == 0 {
+		return nil
+	}
+
+	err := db.WithContext(ctx).
+		Model(&datamodels.ProductDataModel{}).
+		Where("id IN ?", productIds).
+		UpdateColumns(map[string]interface{}{
+			"version":    gorm.Expr("version + 1"),
+			"updated_at": time.Now(),
+		}).Error
+	if err != nil {
+		return errors.WrapIf(err, "error in incrementing the product versions")
+	}
+
+	return nil
+}
+
+// PreloadAssociations loads the categories, tags and media of the queried products - https://gorm.io/docs/preload.html
+func PreloadAssociations(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Categories", func(db *gorm.DB) *gorm.DB { return db.Order("categories.path") }).
+		Preload("Tags", func(db *gorm.DB) *gorm.DB { return db.Order("tags.name") }).
+		Preload("Media", func(db *gorm.DB) *gorm.DB { return db.Order("media_assets.created_at") })
+}
@@ -0,0 +1,31 @@
+package datamodels
+
+import (
+	"time"
+
+	"github.com/goccy/go-json"
+	uuid "github.com/satori/go.uuid"
+)
+
+// CategoryDataModel data model
+type CategoryDataModel struct {
+	Id          uuid.UUID `gorm:"primaryKey"`
+	Name        string
+	Slug        string
+	Description string
+	ParentId    *uuid.UUID
+	Path        string
+	CreatedAt   time.Time `gorm:"default:current_timestamp"`
+	UpdatedAt   time.Time
+}
+
+// TableName overrides the table name used by CategoryDataModel to `categories` - https://gorm.io/docs/conventions.html#TableName
+func (c *CategoryDataModel) TableName() string {
+	return "categories"
+}
+
+func (c *CategoryDataModel) String() string {
+	j, _ := json.Marshal(c)
+
+	return string(j)
+}
@@ -0,0 +1,45 @@
+package datamodels
+
+import (
+	"time"
+
+	"github.com/goccy/go-json"
+	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
+)
+
+// EditionProvenanceDataModel read model of the provenance of editions, each row is an event of an edition with the state
+// of edition after the event
+type EditionProvenanceDataModel struct {
+	// Id the id of the event
+	Id            uuid.UUID `gorm:"primaryKey"`
+	EditionId     uuid.UUID
+	ProductId     uuid.UUID
+	TokenId       string
+	EditionNumber int
+	// Version the version of edition after the event, it orders the provenance of an edition
+	Version   int64
+	EventType string
+	FromOwner string
+	ToOwner   string
+	Owner     string
+	Status    string
+	Frozen    bool
+	Price     *decimal.Decimal `gorm:"type:numeric(38,18)"`
+	Currency  string
+	Reason    string
+	// Position the global position of the event in the event store
+	Position   int64
+	OccurredAt time.Time
+}
+
+// TableName overrides the table name used by EditionProvenanceDataModel to `edition_provenance` - https://gorm.io/docs/conventions.html#TableName
+func (e *EditionProvenanceDataModel) TableName() string {
+	return "edition_provenance"
+}
+
+func (e *EditionProvenanceDataModel) String() string {
+	j, _ := json.Marshal(e)
+
+	return string(j)
+}
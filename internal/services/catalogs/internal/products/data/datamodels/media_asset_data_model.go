@@ -0,0 +1,33 @@
+package datamodels
+
+import (
+	"time"
+
+	"github.com/goccy/go-json"
+	uuid "github.com/satori/go.uuid"
+)
+
+// MediaAssetDataModel data model
+type MediaAssetDataModel struct {
+	Id           uuid.UUID `gorm:"primaryKey"`
+	ProductId    uuid.UUID
+	Kind         string
+	FileName     string
+	ContentType  string
+	Size         int64
+	Hash         string
+	StorageKey   string
+	ThumbnailKey string
+	CreatedAt    time.Time `gorm:"default:current_timestamp"`
+}
+
+// TableName overrides the table name used by MediaAssetDataModel to `media_assets` - https://gorm.io/docs/conventions.html#TableName
+func (m *MediaAssetDataModel) TableName() string {
+	return "media_assets"
+}
+
+func (m *MediaAssetDataModel) String() string {
+	j, _ := json.Marshal(m)
+
+	return string(j)
+}
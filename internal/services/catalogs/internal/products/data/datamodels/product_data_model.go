@@ -5,6 +5,7 @@ import (
 
 	"github.com/goccy/go-json"
 	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 )
 
@@ -16,9 +17,19 @@ type ProductDataModel struct {
 	Id          uuid.UUID `gorm:"primaryKey"`
 	Name        string
 	Description string
-	Price       float64
-	CreatedAt   time.Time `gorm:"default:current_timestamp"`
-	UpdatedAt   time.Time
+	Artist      string
+	// Price exact amount of the price, it is kept as `numeric` in the database to avoid floating point rounding
+	Price    decimal.Decimal `gorm:"type:numeric(38,18)"`
+	Currency string          `gorm:"not null;default:USD"`
+	// Version is incremented on every update, updates are only applied to the version they were read with
+	Version int64 `gorm:"not null;default:1"`
+	// https://gorm.io/docs/many_to_many.html#Override-Foreign-Key
+	Categories []*CategoryDataModel `gorm:"many2many:product_categories;joinForeignKey:ProductId;joinReferences:CategoryId"`
+	Tags       []*TagDataModel      `gorm:"many2many:product_tags;joinForeignKey:ProductId;joinReferences:TagId"`
+	// https://gorm.io/docs/has_many.html
+	Media     []*MediaAssetDataModel `gorm:"foreignKey:ProductId"`
+	CreatedAt time.Time              `gorm:"default:current_timestamp"`
+	UpdatedAt time.Time
 	// for soft delete - https://gorm.io/docs/delete.html#Soft-Delete
 	gorm.DeletedAt
 }
@@ -28,6 +39,14 @@ func (p *ProductDataModel) TableName() string {
 	return "products"
 }
 
+func (p *ProductDataModel) GetVersion() int64 {
+	return p.Version
+}
+
+func (p *ProductDataModel) SetVersion(version int64) {
+	p.Version = version
+}
+
 func (p *ProductDataModel) String() string {
 	j, _ := json.Marshal(p)
 
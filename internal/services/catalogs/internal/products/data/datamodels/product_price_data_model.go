@@ -0,0 +1,31 @@
+package datamodels
+
+import (
+	"time"
+
+	"github.com/goccy/go-json"
+	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ProductPriceDataModel data model
+type ProductPriceDataModel struct {
+	Id          uuid.UUID `gorm:"primaryKey"`
+	ProductId   uuid.UUID
+	Price       decimal.Decimal `gorm:"type:numeric(38,18)"`
+	Currency    string
+	Reason      string
+	EffectiveAt time.Time
+	CreatedAt   time.Time `gorm:"default:current_timestamp"`
+}
+
+// TableName overrides the table name used by ProductPriceDataModel to `product_prices` - https://gorm.io/docs/conventions.html#TableName
+func (p *ProductPriceDataModel) TableName() string {
+	return "product_prices"
+}
+
+func (p *ProductPriceDataModel) String() string {
+	j, _ := json.Marshal(p)
+
+	return string(j)
+}
@@ -0,0 +1,32 @@
+package datamodels
+
+import (
+	"time"
+
+	"github.com/goccy/go-json"
+	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ProductPriceScheduleDataModel data model
+type ProductPriceScheduleDataModel struct {
+	Id        uuid.UUID `gorm:"primaryKey"`
+	ProductId uuid.UUID
+	Price     decimal.Decimal `gorm:"type:numeric(38,18)"`
+	Currency  string
+	ApplyAt   time.Time
+	Status    string
+	AppliedAt *time.Time
+	CreatedAt time.Time `gorm:"default:current_timestamp"`
+}
+
+// TableName overrides the table name used by ProductPriceScheduleDataModel to `product_price_schedules` - https://gorm.io/docs/conventions.html#TableName
+func (p *ProductPriceScheduleDataModel) TableName() string {
+	return "product_price_schedules"
+}
+
+func (p *ProductPriceScheduleDataModel) String() string {
+	j, _ := json.Marshal(p)
+
+	return string(j)
+}
@@ -0,0 +1,26 @@
+package datamodels
+
+import (
+	"time"
+
+	"github.com/goccy/go-json"
+	uuid "github.com/satori/go.uuid"
+)
+
+// TagDataModel data model
+type TagDataModel struct {
+	Id        uuid.UUID `gorm:"primaryKey"`
+	Name      string
+	CreatedAt time.Time `gorm:"default:current_timestamp"`
+}
+
+// TableName overrides the table name used by TagDataModel to `tags` - https://gorm.io/docs/conventions.html#TableName
+func (t *TagDataModel) TableName() string {
+	return "tags"
+}
+
+func (t *TagDataModel) String() string {
+	j, _ := json.Marshal(t)
+
+	return string(j)
+}
@@ -0,0 +1,67 @@
+package documents
+
+import (
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+// ProductsIndexAlias is the alias that always points to the live products search index
+const ProductsIndexAlias = "catalogs-products"
+
+// ProductDocument search document of a product in the products search index, `Categories` keeps the slugs of the
+// product categories and their ancestors, so filtering by a category also matches its sub-categories
+type ProductDocument struct {
+	Id          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Artist      string   `json:"artist"`
+	Categories  []string `json:"categories"`
+	Tags        []string `json:"tags"`
+	// Price is only used for the price range filters and facets, the exact price is kept in `PriceAmount`
+	Price       float64   `json:"price"`
+	PriceAmount string    `json:"priceAmount"`
+	Currency    string    `json:"currency"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+func (p *ProductDocument) String() string {
+	j, _ := json.Marshal(p)
+
+	return string(j)
+}
+
+// ProductsIndexDefinition settings and mappings of the products search index - https://www.elastic.co/guide/en/elasticsearch/reference/current/mapping.html
+func ProductsIndexDefinition() map[string]interface{} {
+	textWithKeyword := map[string]interface{}{
+		"type":     "text",
+		"analyzer": "english",
+		"fields": map[string]interface{}{
+			"keyword": map[string]interface{}{"type": "keyword", "ignore_above": 256},
+		},
+	}
+
+	return map[string]interface{}{
+		"settings": map[string]interface{}{
+			"number_of_shards":   1,
+			"number_of_replicas": 0,
+		},
+		"mappings": map[string]interface{}{
+			"dynamic": "strict",
+			"properties": map[string]interface{}{
+				"id":          map[string]interface{}{"type": "keyword"},
+				"name":        textWithKeyword,
+				"description": map[string]interface{}{"type": "text", "analyzer": "english"},
+				"artist":      textWithKeyword,
+				"categories":  map[string]interface{}{"type": "keyword"},
+				"tags":        map[string]interface{}{"type": "keyword"},
+				"price":       map[string]interface{}{"type": "double"},
+				"priceAmount": map[string]interface{}{"type": "keyword", "index": false},
+				"currency":    map[string]interface{}{"type": "keyword"},
+				"createdAt":   map[string]interface{}{"type": "date"},
+				"updatedAt":   map[string]interface{}{"type": "date"},
+			},
+		},
+	}
+}
@@ -0,0 +1,41 @@
+package pricehistory
+
+import (
+	"context"
+	"time"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/datamodels"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// RecordPriceChange adds an entry to the product price history, it should be called in the same transaction that changes
+// the product price so the history never misses a change
+func RecordPriceChange(
+	ctx context.Context,
+	db *gorm.DB,
+	productID uuid.UUID,
+	price decimal.Decimal,
+	currency string,
+	reason string,
+	effectiveAt time.Time,
+) error {
+	entry := &datamodels.ProductPriceDataModel{
+		Id:          uuid.NewV4(),
+		ProductId:   productID,
+		Price:       price,
+		Currency:    currency,
+		Reason:      reason,
+		EffectiveAt: effectiveAt,
+		CreatedAt:   time.Now(),
+	}
+
+	if err := db.WithContext(ctx).Create(entry).Error; err != nil {
+		return errors.WrapIf(err, "error in recording the product price change")
+	}
+
+	return nil
+}
@@ -0,0 +1,570 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/contracts"
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/documents"
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
+	elasticsearch2 "github.com/reoden/go-NFT/pkg/elasticsearch"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/otel/tracing"
+	utils2 "github.com/reoden/go-NFT/pkg/otel/tracing/utils"
+
+	"emperror.dev/errors"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/goccy/go-json"
+	"github.com/samber/lo"
+	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
+	attribute2 "go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	artistsFacetSize    = 20
+	categoriesFacetSize = 50
+)
+
+type priceRange struct {
+	Key  string
+	From *float64
+	To   *float64
+}
+
+// priceRanges buckets of the price facet, `To` is exclusive
+var priceRanges = []priceRange{ //nolint:gochecknoglobals
+	{Key: "0-100", To: lo.ToPtr(100.0)},
+	{Key: "100-500", From: lo.ToPtr(100.0), To: lo.ToPtr(500.0)},
+	{Key: "500-1000", From: lo.ToPtr(500.0), To: lo.ToPtr(1000.0)},
+	{Key: "1000-5000", From: lo.ToPtr(1000.0), To: lo.ToPtr(5000.0)},
+	{Key: "5000+", From: lo.ToPtr(5000.0)},
+}
+
+type elasticProductSearchRepository struct {
+	log         logger.Logger
+	client      *elasticsearch.Client
+	tracer      tracing.AppTracer
+	mu          sync.Mutex
+	initialized bool
+}
+
+func NewElasticProductSearchRepository(
+	log logger.Logger,
+	client *elasticsearch.Client,
+	tracer tracing.AppTracer,
+) contracts.ProductSearchRepository {
+	return &elasticProductSearchRepository{
+		log:    log,
+		client: client,
+		tracer: tracer,
+	}
+}
+
+func (e *elasticProductSearchRepository) IndexProduct(
+	ctx context.Context,
+	product *models.Product,
+) error {
+	ctx, span := e.tracer.Start(ctx, "elasticProductSearchRepository.IndexProduct")
+	span.SetAttributes(attribute2.String("Id", product.Id.String()))
+	defer span.End()
+
+	if err := e.ensureIndex(ctx); err != nil {
+		return utils2.TraceStatusFromSpan(span, err)
+	}
+
+	// the messages of a product can be consumed out of order, so an older version never replaces a newer one
+	indexed, err := elasticsearch2.IndexVersionedDocument(
+		ctx,
+		e.client,
+		documents.ProductsIndexAlias,
+		product.Id.String(),
+		product.Version,
+		toProductDocument(product),
+	)
+	err = utils2.TraceStatusFromSpan(
+		span,
+		errors.WrapIf(
+			err,
+			fmt.Sprintf(
+				"error in indexing product with id %s",
+				product.Id,
+			),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	if !indexed {
+		e.log.Infow(
+			fmt.Sprintf(
+				"product with id '%s' is not indexed, a version newer than %d is already indexed",
+				product.Id,
+				product.Version,
+			),
+			logger.Fields{"Id": product.Id, "Version": product.Version},
+		)
+
+		return nil
+	}
+
+	e.log.Infow(
+		fmt.Sprintf("product with id '%s' indexed", product.Id),
+		logger.Fields{"Id": product.Id, "Version": product.Version},
+	)
+
+	return nil
+}
+
+func (e *elasticProductSearchRepository) DeleteProduct(
+	ctx context.Context,
+	id uuid.UUID,
+) error {
+	ctx, span := e.tracer.Start(ctx, "elasticProductSearchRepository.DeleteProduct")
+	span.SetAttributes(attribute2.String("Id", id.String()))
+	defer span.End()
+
+	if err := e.ensureIndex(ctx); err != nil {
+		return utils2.TraceStatusFromSpan(span, err)
+	}
+
+	err := elasticsearch2.DeleteDocument(
+		ctx,
+		e.client,
+		documents.ProductsIndexAlias,
+		id.String(),
+	)
+	err = utils2.TraceStatusFromSpan(
+		span,
+		errors.WrapIf(
+			err,
+			fmt.Sprintf(
+				"error in removing product with id %s from the index",
+				id,
+			),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	e.log.Infow(
+		fmt.Sprintf("product with id '%s' removed from the index", id),
+		logger.Fields{"Id": id},
+	)
+
+	return nil
+}
+
+func (e *elasticProductSearchRepository) SearchProducts(
+	ctx context.Context,
+	criteria *models.ProductSearchCriteria,
+) (*models.ProductSearchResult, error) {
+	ctx, span := e.tracer.Start(ctx, "elasticProductSearchRepository.SearchProducts")
+	span.SetAttributes(attribute2.String("SearchText", criteria.SearchText))
+	defer span.End()
+
+	if err := e.ensureIndex(ctx); err != nil {
+		return nil, utils2.TraceStatusFromSpan(span, err)
+	}
+
+	res, err := elasticsearch2.Search(
+		ctx,
+		e.client,
+		documents.ProductsIndexAlias,
+		buildSearchBody(criteria),
+	)
+	err = utils2.TraceStatusFromSpan(
+		span,
+		errors.WrapIf(
+			err,
+			"error in searching products in the index",
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := toProductSearchResult(res, criteria)
+	if err != nil {
+		return nil, utils2.TraceStatusFromSpan(span, err)
+	}
+
+	e.log.Infow(
+		fmt.Sprintf(
+			"%d products found for search term '%s'",
+			result.TotalItems,
+			criteria.SearchText,
+		),
+		logger.Fields{"SearchText": criteria.SearchText, "TotalItems": result.TotalItems},
+	)
+
+	return result, nil
+}
+
+func (e *elasticProductSearchRepository) CreateIndex(ctx context.Context) (string, error) {
+	indexName := fmt.Sprintf(
+		"%s-%d",
+		documents.ProductsIndexAlias,
+		time.Now().UTC().UnixNano(),
+	)
+
+	err := elasticsearch2.CreateIndex(
+		ctx,
+		e.client,
+		indexName,
+		documents.ProductsIndexDefinition(),
+	)
+	if err != nil {
+		return "", errors.WrapIf(err, "error in creating products index")
+	}
+
+	return indexName, nil
+}
+
+func (e *elasticProductSearchRepository) BulkIndexProducts(
+	ctx context.Context,
+	indexName string,
+	products []*models.Product,
+) error {
+	bulkDocuments := lo.Map(
+		products,
+		func(product *models.Product, _ int) elasticsearch2.BulkDocument {
+			return elasticsearch2.BulkDocument{
+				Id:       product.Id.String(),
+				Document: toProductDocument(product),
+				Version:  lo.ToPtr(product.Version),
+			}
+		},
+	)
+
+	return elasticsearch2.BulkIndex(ctx, e.client, indexName, bulkDocuments)
+}
+
+func (e *elasticProductSearchRepository) DeleteIndexedProducts(
+	ctx context.Context,
+	indexName string,
+	ids []uuid.UUID,
+) error {
+	for _, id := range ids {
+		err := elasticsearch2.DeleteDocument(ctx, e.client, indexName, id.String())
+		if err != nil {
+			return errors.WrapIf(err, fmt.Sprintf("error in removing product with id %s from index %s", id, indexName))
+		}
+	}
+
+	return nil
+}
+
+func (e *elasticProductSearchRepository) SwitchIndex(
+	ctx context.Context,
+	indexName string,
+) error {
+	if err := elasticsearch2.RefreshIndex(ctx, e.client, indexName); err != nil {
+		return err
+	}
+
+	oldIndices, err := elasticsearch2.SwapAlias(
+		ctx,
+		e.client,
+		documents.ProductsIndexAlias,
+		indexName,
+	)
+	if err != nil {
+		return errors.WrapIf(err, "error in switching products index alias")
+	}
+
+	oldIndices = lo.Without(oldIndices, indexName)
+	if err := elasticsearch2.DeleteIndices(ctx, e.client, oldIndices...); err != nil {
+		return errors.WrapIf(err, "error in deleting old products indices")
+	}
+
+	e.mu.Lock()
+	e.initialized = true
+	e.mu.Unlock()
+
+	e.log.Infow(
+		fmt.Sprintf("products index alias switched to '%s'", indexName),
+		logger.Fields{"Index": indexName, "RemovedIndices": oldIndices},
+	)
+
+	return nil
+}
+
+// ensureIndex creates the first physical index behind the alias, otherwise writing to the alias would create an index with dynamic mappings
+func (e *elasticProductSearchRepository) ensureIndex(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.initialized {
+		return nil
+	}
+
+	exists, err := elasticsearch2.IndexExists(ctx, e.client, documents.ProductsIndexAlias)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		indexName, err := e.CreateIndex(ctx)
+		if err != nil {
+			return err
+		}
+
+		if _, err := elasticsearch2.SwapAlias(ctx, e.client, documents.ProductsIndexAlias, indexName); err != nil {
+			return errors.WrapIf(err, "error in creating products index alias")
+		}
+	}
+
+	e.initialized = true
+
+	return nil
+}
+
+func toProductDocument(product *models.Product) *documents.ProductDocument {
+	return &documents.ProductDocument{
+		Id:          product.Id.String(),
+		Name:        product.Name,
+		Description: product.Description,
+		Artist:      product.Artist,
+		Categories: lo.Uniq(lo.FlatMap(product.Categories, func(category *models.Category, _ int) []string {
+			return category.PathSlugs()
+		})),
+		Tags: lo.Map(product.Tags, func(tag *models.Tag, _ int) string {
+			return tag.Name
+		}),
+		Price:       product.Price.InexactFloat64(),
+		PriceAmount: product.Price.String(),
+		Currency:    product.Currency,
+		CreatedAt:   product.CreatedAt,
+		UpdatedAt:   product.UpdatedAt,
+	}
+}
+
+// buildSearchBody facet filters go to the `post_filter` so each facet is counted with all filters except its own - https://www.elastic.co/guide/en/elasticsearch/reference/current/filter-search-results.html#post-filter
+func buildSearchBody(criteria *models.ProductSearchCriteria) map[string]interface{} {
+	var query map[string]interface{}
+	if criteria.SearchText == "" {
+		query = map[string]interface{}{"match_all": map[string]interface{}{}}
+	} else {
+		query = map[string]interface{}{
+			"multi_match": map[string]interface{}{
+				"query":     criteria.SearchText,
+				"fields":    []string{"name^3", "artist^2", "description"},
+				"type":      "best_fields",
+				"fuzziness": "AUTO",
+			},
+		}
+	}
+
+	artistFilter := termFilter("artist.keyword", criteria.Artist)
+	categoryFilter := termFilter("categories", criteria.Category)
+	priceFilter := priceRangeFilter(criteria.MinPrice, criteria.MaxPrice)
+
+	ranges := lo.Map(
+		priceRanges,
+		func(r priceRange, _ int) map[string]interface{} {
+			bucket := map[string]interface{}{"key": r.Key}
+			if r.From != nil {
+				bucket["from"] = *r.From
+			}
+			if r.To != nil {
+				bucket["to"] = *r.To
+			}
+
+			return bucket
+		},
+	)
+
+	page := lo.Max([]int{criteria.Page, 1})
+
+	return map[string]interface{}{
+		"from":        (page - 1) * criteria.Size,
+		"size":        criteria.Size,
+		"query":       query,
+		"post_filter": boolFilter(artistFilter, categoryFilter, priceFilter),
+		"aggs": map[string]interface{}{
+			"artists": facetAggregation(
+				boolFilter(categoryFilter, priceFilter),
+				map[string]interface{}{
+					"terms": map[string]interface{}{"field": "artist.keyword", "size": artistsFacetSize},
+				},
+			),
+			"categories": facetAggregation(
+				boolFilter(artistFilter, priceFilter),
+				map[string]interface{}{
+					"terms": map[string]interface{}{"field": "categories", "size": categoriesFacetSize},
+				},
+			),
+			"price_ranges": facetAggregation(
+				boolFilter(artistFilter, categoryFilter),
+				map[string]interface{}{
+					"range": map[string]interface{}{"field": "price", "ranges": ranges},
+				},
+			),
+		},
+		"highlight": map[string]interface{}{
+			"pre_tags":  []string{"<em>"},
+			"post_tags": []string{"</em>"},
+			"fields": map[string]interface{}{
+				"name":        map[string]interface{}{"number_of_fragments": 0},
+				"artist":      map[string]interface{}{"number_of_fragments": 0},
+				"description": map[string]interface{}{"fragment_size": 150, "number_of_fragments": 3},
+			},
+		},
+	}
+}
+
+func termFilter(field string, value string) map[string]interface{} {
+	if value == "" {
+		return nil
+	}
+
+	return map[string]interface{}{"term": map[string]interface{}{field: value}}
+}
+
+func priceRangeFilter(minPrice *float64, maxPrice *float64) map[string]interface{} {
+	if minPrice == nil && maxPrice == nil {
+		return nil
+	}
+
+	priceRange := map[string]interface{}{}
+	if minPrice != nil {
+		priceRange["gte"] = *minPrice
+	}
+	if maxPrice != nil {
+		priceRange["lte"] = *maxPrice
+	}
+
+	return map[string]interface{}{"range": map[string]interface{}{"price": priceRange}}
+}
+
+func boolFilter(filters ...map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"filter": lo.Filter(filters, func(filter map[string]interface{}, _ int) bool {
+				return filter != nil
+			}),
+		},
+	}
+}
+
+func facetAggregation(
+	filter map[string]interface{},
+	aggregation map[string]interface{},
+) map[string]interface{} {
+	return map[string]interface{}{
+		"filter": filter,
+		"aggs":   map[string]interface{}{"values": aggregation},
+	}
+}
+
+type facetAggregationResult struct {
+	Values struct {
+		Buckets []struct {
+			Key      interface{} `json:"key"`
+			From     *float64    `json:"from"`
+			To       *float64    `json:"to"`
+			DocCount int64       `json:"doc_count"`
+		} `json:"buckets"`
+	} `json:"values"`
+}
+
+func toProductSearchResult(
+	res *elasticsearch2.SearchResponse,
+	criteria *models.ProductSearchCriteria,
+) (*models.ProductSearchResult, error) {
+	hits := make([]*models.ProductSearchHit, 0, len(res.Hits.Hits))
+	for _, hit := range res.Hits.Hits {
+		document := &documents.ProductDocument{}
+		if err := json.Unmarshal(hit.Source, document); err != nil {
+			return nil, errors.WrapIf(err, "error in unmarshaling product document")
+		}
+
+		id, err := uuid.FromString(document.Id)
+		if err != nil {
+			return nil, errors.WrapIf(err, "error in parsing product document id")
+		}
+
+		price, err := decimal.NewFromString(document.PriceAmount)
+		if err != nil {
+			price = decimal.NewFromFloat(document.Price)
+		}
+
+		hits = append(hits, &models.ProductSearchHit{
+			Product: &models.Product{
+				Id:          id,
+				Name:        document.Name,
+				Description: document.Description,
+				Artist:      document.Artist,
+				Price:       price,
+				Currency:    document.Currency,
+				CreatedAt:   document.CreatedAt,
+				UpdatedAt:   document.UpdatedAt,
+			},
+			Score:      hit.Score,
+			Highlights: hit.Highlight,
+		})
+	}
+
+	facets := &models.ProductSearchFacets{}
+
+	artists, err := parseFacet(res.Aggregations["artists"])
+	if err != nil {
+		return nil, err
+	}
+	for _, bucket := range artists.Values.Buckets {
+		facets.Artists = append(facets.Artists, &models.FacetBucket{
+			Key:   fmt.Sprint(bucket.Key),
+			Count: bucket.DocCount,
+		})
+	}
+
+	categories, err := parseFacet(res.Aggregations["categories"])
+	if err != nil {
+		return nil, err
+	}
+	for _, bucket := range categories.Values.Buckets {
+		facets.Categories = append(facets.Categories, &models.FacetBucket{
+			Key:   fmt.Sprint(bucket.Key),
+			Count: bucket.DocCount,
+		})
+	}
+
+	prices, err := parseFacet(res.Aggregations["price_ranges"])
+	if err != nil {
+		return nil, err
+	}
+	for _, bucket := range prices.Values.Buckets {
+		facets.PriceRanges = append(facets.PriceRanges, &models.PriceRangeBucket{
+			Key:   fmt.Sprint(bucket.Key),
+			From:  bucket.From,
+			To:    bucket.To,
+			Count: bucket.DocCount,
+		})
+	}
+
+	return &models.ProductSearchResult{
+		Hits:       hits,
+		Facets:     facets,
+		TotalItems: res.Hits.Total.Value,
+		Page:       criteria.Page,
+		Size:       criteria.Size,
+	}, nil
+}
+
+func parseFacet(raw json.RawMessage) (*facetAggregationResult, error) {
+	result := &facetAggregationResult{}
+	if len(raw) == 0 {
+		return result, nil
+	}
+
+	if err := json.Unmarshal(raw, result); err != nil {
+		return nil, errors.WrapIf(err, "error in unmarshaling search facets")
+	}
+
+	return result, nil
+}
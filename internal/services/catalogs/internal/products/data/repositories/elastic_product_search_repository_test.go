@@ -0,0 +1,96 @@
+//go:build unit
+// +build unit
+
+package repositories
+
+import (
+	"testing"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
+	"github.com/reoden/go-NFT/pkg/elasticsearch"
+
+	"github.com/goccy/go-json"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type ElasticProductSearchRepositoryTestSuite struct {
+	suite.Suite
+}
+
+func TestElasticProductSearchRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(ElasticProductSearchRepositoryTestSuite))
+}
+
+func (s *ElasticProductSearchRepositoryTestSuite) Test_BuildSearchBody_Without_Text_Matches_All() {
+	body := buildSearchBody(&models.ProductSearchCriteria{Page: 1, Size: 10})
+
+	s.Assert().Contains(body["query"], "match_all")
+	s.Assert().Equal(0, body["from"])
+	s.Assert().Equal(10, body["size"])
+}
+
+func (s *ElasticProductSearchRepositoryTestSuite) Test_BuildSearchBody_Facet_Excludes_Its_Own_Filter() {
+	minPrice := 100.0
+	body := buildSearchBody(&models.ProductSearchCriteria{
+		SearchText: "sunset",
+		Artist:     "banksy",
+		Category:   "paintings",
+		MinPrice:   &minPrice,
+		Page:       2,
+		Size:       10,
+	})
+
+	s.Assert().Equal(10, body["from"])
+	s.Assert().Contains(body["query"], "multi_match")
+	s.Assert().Len(filtersOf(body["post_filter"]), 3)
+
+	aggs := body["aggs"].(map[string]interface{})
+	for _, name := range []string{"artists", "categories", "price_ranges"} {
+		facet := aggs[name].(map[string]interface{})
+		s.Assert().Len(filtersOf(facet["filter"]), 2, name)
+	}
+
+	artistsFilters, err := json.Marshal(aggs["artists"].(map[string]interface{})["filter"])
+	s.Require().NoError(err)
+	s.Assert().NotContains(string(artistsFilters), "artist.keyword")
+}
+
+func (s *ElasticProductSearchRepositoryTestSuite) Test_ToProductSearchResult() {
+	id := uuid.NewV4()
+	response := &elasticsearch.SearchResponse{}
+	err := json.Unmarshal([]byte(`{
+		"hits": {
+			"total": {"value": 1, "relation": "eq"},
+			"hits": [{
+				"_id": "`+id.String()+`",
+				"_score": 1.5,
+				"_source": {"id": "`+id.String()+`", "name": "Sunset", "artist": "Banksy", "categories": [], "price": 120},
+				"highlight": {"name": ["<em>Sunset</em>"]}
+			}]
+		},
+		"aggregations": {
+			"artists": {"doc_count": 1, "values": {"buckets": [{"key": "Banksy", "doc_count": 1}]}},
+			"price_ranges": {"doc_count": 1, "values": {"buckets": [{"key": "100-500", "from": 100, "to": 500, "doc_count": 1}]}}
+		}
+	}`), response)
+	s.Require().NoError(err)
+
+	result, err := toProductSearchResult(response, &models.ProductSearchCriteria{Page: 1, Size: 10})
+	s.Require().NoError(err)
+
+	s.Assert().Equal(int64(1), result.TotalItems)
+	s.Require().Len(result.Hits, 1)
+	s.Assert().Equal(id, result.Hits[0].Product.Id)
+	s.Assert().Equal(1.5, result.Hits[0].Score)
+	s.Assert().Equal([]string{"<em>Sunset</em>"}, result.Hits[0].Highlights["name"])
+	s.Require().Len(result.Facets.Artists, 1)
+	s.Assert().Equal("Banksy", result.Facets.Artists[0].Key)
+	s.Assert().Empty(result.Facets.Categories)
+	s.Require().Len(result.Facets.PriceRanges, 1)
+	s.Assert().Equal(500.0, *result.Facets.PriceRanges[0].To)
+}
+
+func filtersOf(filter interface{}) []map[string]interface{} {
+	return filter.(map[string]interface{})["bool"].(map[string]interface{})["filter"].([]map[string]interface{})
+}
@@ -0,0 +1,23 @@
+package v1
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type CategoryDto struct {
+	Id          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Slug        string     `json:"slug"`
+	Description string     `json:"description"`
+	ParentId    *uuid.UUID `json:"parentId,omitempty"`
+	Path        string     `json:"path"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+type CategoryTreeDto struct {
+	*CategoryDto
+	Children []*CategoryTreeDto `json:"children"`
+}
@@ -0,0 +1,22 @@
+package v1
+
+import "time"
+
+type DeadLetterQueueDto struct {
+	Queue           string `json:"queue"`
+	DeadLetterQueue string `json:"deadLetterQueue"`
+	MessageCount    int    `json:"messageCount"`
+}
+
+type DeadLetterMessageDto struct {
+	MessageId     string                 `json:"messageId"`
+	CorrelationId string                 `json:"correlationId"`
+	MessageType   string                 `json:"messageType"`
+	ContentType   string                 `json:"contentType"`
+	Body          string                 `json:"body"`
+	Headers       map[string]interface{} `json:"headers"`
+	Exception     string                 `json:"exception"`
+	AttemptCount  int                    `json:"attemptCount"`
+	FailedAt      time.Time              `json:"failedAt"`
+	Queue         string                 `json:"queue"`
+}
@@ -0,0 +1,38 @@
+package v1
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
+)
+
+// EditionDto the current state of an edition, the price is the listing price of a listed edition
+type EditionDto struct {
+	Id            uuid.UUID        `json:"id"`
+	ProductId     uuid.UUID        `json:"productId"`
+	TokenId       string           `json:"tokenId"`
+	EditionNumber int              `json:"editionNumber"`
+	Owner         string           `json:"owner"`
+	Status        string           `json:"status"`
+	Frozen        bool             `json:"frozen"`
+	Price         *decimal.Decimal `json:"price,omitempty"`
+	Currency      string           `json:"currency,omitempty"`
+	Version       int64            `json:"version"`
+}
+
+// EditionProvenanceEntryDto an event of the edition provenance with the owner, the status and the freeze of edition after the event
+type EditionProvenanceEntryDto struct {
+	EventId    uuid.UUID        `json:"eventId"`
+	Version    int64            `json:"version"`
+	EventType  string           `json:"eventType"`
+	FromOwner  string           `json:"fromOwner,omitempty"`
+	ToOwner    string           `json:"toOwner,omitempty"`
+	Owner      string           `json:"owner"`
+	Status     string           `json:"status"`
+	Frozen     bool             `json:"frozen"`
+	Price      *decimal.Decimal `json:"price,omitempty"`
+	Currency   string           `json:"currency,omitempty"`
+	Reason     string           `json:"reason,omitempty"`
+	OccurredAt time.Time        `json:"occurredAt"`
+}
@@ -1,19 +1,31 @@
 package fxparams
 
 import (
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
 	"github.com/reoden/go-NFT/catalogs/internal/shared/data/dbcontext"
 	"github.com/reoden/go-NFT/pkg/core/messaging/producer"
+	"github.com/reoden/go-NFT/pkg/es"
+	"github.com/reoden/go-NFT/pkg/es/contracts/store"
 	"github.com/reoden/go-NFT/pkg/logger"
 	"github.com/reoden/go-NFT/pkg/otel/tracing"
+	"github.com/reoden/go-NFT/pkg/rabbitmq/deadletter"
+	"github.com/reoden/go-NFT/pkg/storage"
 
+	"github.com/hibiken/asynq"
 	"go.uber.org/fx"
 )
 
 type ProductHandlerParams struct {
 	fx.In
 
-	Log               logger.Logger
-	CatalogsDBContext *dbcontext.CatalogsGormDBContext
-	RabbitmqProducer  producer.Producer
-	Tracer            tracing.AppTracer
+	Log                   logger.Logger
+	CatalogsDBContext     *dbcontext.CatalogsGormDBContext
+	RabbitmqProducer      producer.Producer
+	Tracer                tracing.AppTracer
+	ObjectStore           storage.ObjectStore
+	StorageOptions        *storage.StorageOptions
+	QueueClient           *asynq.Client
+	DeadLetterManager     deadletter.DeadLetterManager
+	ProjectionRebuilder   es.ProjectionRebuilder
+	EditionAggregateStore store.AggregateStore[*models.Edition]
 }
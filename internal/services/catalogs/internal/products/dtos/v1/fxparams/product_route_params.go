@@ -12,8 +12,14 @@ import (
 type ProductRouteParams struct {
 	fx.In
 
-	CatalogsMetrics *contracts.CatalogsMetrics
-	Logger          logger.Logger
-	ProductsGroup   *echo.Group `name:"product-echo-group"`
-	Validator       *validator.Validate
+	CatalogsMetrics  *contracts.CatalogsMetrics
+	Logger           logger.Logger
+	ProductsGroup    *echo.Group `name:"product-echo-group"`
+	CategoriesGroup  *echo.Group `name:"category-echo-group"`
+	TagsGroup        *echo.Group `name:"tag-echo-group"`
+	MediaGroup       *echo.Group `name:"media-echo-group"`
+	DeadLettersGroup *echo.Group `name:"dead-letter-echo-group"`
+	ProjectionsGroup *echo.Group `name:"projection-echo-group"`
+	EditionsGroup    *echo.Group `name:"edition-echo-group"`
+	Validator        *validator.Validate
 }
@@ -0,0 +1,24 @@
+package v1
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// MediaAssetDto media file of a product, `Url` and `ThumbnailUrl` are signed download urls that are only filled in the
+// query responses because they expire
+type MediaAssetDto struct {
+	Id           uuid.UUID `json:"id"`
+	ProductId    uuid.UUID `json:"productId"`
+	Kind         string    `json:"kind"`
+	FileName     string    `json:"fileName"`
+	ContentType  string    `json:"contentType"`
+	Size         int64     `json:"size"`
+	Hash         string    `json:"hash"`
+	StorageKey   string    `json:"storageKey"`
+	ThumbnailKey string    `json:"thumbnailKey,omitempty"`
+	Url          string    `json:"url,omitempty"`
+	ThumbnailUrl string    `json:"thumbnailUrl,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
@@ -4,13 +4,20 @@ import (
 	"time"
 
 	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
 )
 
 type ProductDto struct {
-	Id          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	Id          uuid.UUID        `json:"id"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Artist      string           `json:"artist"`
+	Price       decimal.Decimal  `json:"price"`
+	Currency    string           `json:"currency"`
+	Version     int64            `json:"version"`
+	Categories  []*CategoryDto   `json:"categories"`
+	Tags        []*TagDto        `json:"tags"`
+	Media       []*MediaAssetDto `json:"media"`
+	CreatedAt   time.Time        `json:"createdAt"`
+	UpdatedAt   time.Time        `json:"updatedAt"`
 }
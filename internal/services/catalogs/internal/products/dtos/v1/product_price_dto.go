@@ -0,0 +1,31 @@
+package v1
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ProductPriceDto an entry of the product price history, the price is effective from `EffectiveAt` until the next entry
+type ProductPriceDto struct {
+	Id          uuid.UUID       `json:"id"`
+	ProductId   uuid.UUID       `json:"productId"`
+	Price       decimal.Decimal `json:"price"`
+	Currency    string          `json:"currency"`
+	Reason      string          `json:"reason"`
+	EffectiveAt time.Time       `json:"effectiveAt"`
+	CreatedAt   time.Time       `json:"createdAt"`
+}
+
+// ProductPriceScheduleDto a price change of the product that is applied at `ApplyAt`
+type ProductPriceScheduleDto struct {
+	Id        uuid.UUID       `json:"id"`
+	ProductId uuid.UUID       `json:"productId"`
+	Price     decimal.Decimal `json:"price"`
+	Currency  string          `json:"currency"`
+	ApplyAt   time.Time       `json:"applyAt"`
+	Status    string          `json:"status"`
+	AppliedAt *time.Time      `json:"appliedAt,omitempty"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
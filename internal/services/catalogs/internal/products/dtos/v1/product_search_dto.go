@@ -0,0 +1,26 @@
+package v1
+
+// ProductSearchItemDto a matched product with its relevance score and highlighted fragments
+type ProductSearchItemDto struct {
+	*ProductDto
+	Score      float64             `json:"score"`
+	Highlights map[string][]string `json:"highlights,omitempty"`
+}
+
+type FacetBucketDto struct {
+	Key   string `json:"key"`
+	Count int64  `json:"count"`
+}
+
+type PriceRangeBucketDto struct {
+	Key   string   `json:"key"`
+	From  *float64 `json:"from,omitempty"`
+	To    *float64 `json:"to,omitempty"`
+	Count int64    `json:"count"`
+}
+
+type ProductSearchFacetsDto struct {
+	Artists     []*FacetBucketDto      `json:"artists"`
+	Categories  []*FacetBucketDto      `json:"categories"`
+	PriceRanges []*PriceRangeBucketDto `json:"priceRanges"`
+}
@@ -0,0 +1,14 @@
+package v1
+
+import "time"
+
+type ProjectionRebuildProgressDto struct {
+	ProjectionName  string    `json:"projectionName"`
+	State           string    `json:"state"`
+	Position        uint64    `json:"position"`
+	HeadPosition    uint64    `json:"headPosition"`
+	ProcessedEvents int64     `json:"processedEvents"`
+	StartedAt       time.Time `json:"startedAt"`
+	CompletedAt     time.Time `json:"completedAt"`
+	Error           string    `json:"error,omitempty"`
+}
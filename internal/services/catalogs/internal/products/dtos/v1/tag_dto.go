@@ -0,0 +1,13 @@
+package v1
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type TagDto struct {
+	Id        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+}
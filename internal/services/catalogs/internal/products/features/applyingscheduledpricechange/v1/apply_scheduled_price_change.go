@@ -0,0 +1,39 @@
+package v1
+
+import (
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+// ApplyScheduledPriceChange applies a pending scheduled price change to its product, it is sent by the scheduled
+// price change task when the apply time is reached
+type ApplyScheduledPriceChange struct {
+	cqrs.Command
+	ScheduleID uuid.UUID
+}
+
+func NewApplyScheduledPriceChange(scheduleID uuid.UUID) *ApplyScheduledPriceChange {
+	return &ApplyScheduledPriceChange{
+		Command:    cqrs.NewCommandByT[ApplyScheduledPriceChange](),
+		ScheduleID: scheduleID,
+	}
+}
+
+// IsTxRequest for enabling transactions on the mediatr pipeline
+func (c *ApplyScheduledPriceChange) IsTxRequest() {
+}
+
+func (c *ApplyScheduledPriceChange) Validate() error {
+	err := validation.ValidateStruct(
+		c,
+		validation.Field(&c.ScheduleID, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
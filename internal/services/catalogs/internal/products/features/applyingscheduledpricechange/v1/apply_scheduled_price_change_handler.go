@@ -0,0 +1,168 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/datamodels"
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/pricehistory"
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/updatingproduct/v1/events/integrationevents"
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/postgresgorm/gormdbcontext"
+
+	"emperror.dev/errors"
+	"github.com/mehdihadeli/go-mediatr"
+	uuid "github.com/satori/go.uuid"
+	"gorm.io/gorm"
+)
+
+type applyScheduledPriceChangeHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewApplyScheduledPriceChangeHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*ApplyScheduledPriceChange, *mediatr.Unit] {
+	return &applyScheduledPriceChangeHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *applyScheduledPriceChangeHandler) RegisterHandler() error {
+	return mediatr.RegisterRequestHandler[*ApplyScheduledPriceChange, *mediatr.Unit](
+		c,
+	)
+}
+
+// Handle runs in the transaction of the mediatr transaction pipeline, so the price, its history, the schedule status and
+// the `ProductUpdated` message in the outbox are committed together. The schedule is moved out of the pending status with a
+// conditional update, a task that is delivered more than once applies the change only once and the later deliveries are
+// skipped. A missing schedule is returned as an error, the task is enqueued before the commit of the schedule, so it is
+// retried by asynq until the schedule is committed.
+func (c *applyScheduledPriceChangeHandler) Handle(
+	ctx context.Context,
+	command *ApplyScheduledPriceChange,
+) (*mediatr.Unit, error) {
+	db := c.CatalogsDBContext.WithTxIfExists(ctx).DB().WithContext(ctx)
+
+	schedule := &datamodels.ProductPriceScheduleDataModel{}
+
+	err := db.First(schedule, "id = ?", command.ScheduleID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, customErrors.NewNotFoundErrorWrap(
+			err,
+			fmt.Sprintf("scheduled price change `%s` not found, it may not be committed yet", command.ScheduleID),
+		)
+	}
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in fetching the scheduled price change")
+	}
+
+	if schedule.Status != models.PendingPriceScheduleStatus {
+		c.skip(command.ScheduleID, schedule.Status)
+
+		return &mediatr.Unit{}, nil
+	}
+
+	product, err := gormdbcontext.FindModelByID[*datamodels.ProductDataModel, *models.Product](
+		ctx,
+		c.CatalogsDBContext,
+		schedule.ProductId,
+	)
+	if customErrors.IsNotFoundError(err) {
+		// the product is deleted after scheduling the change
+		_, err = c.completeSchedule(db, schedule, models.CanceledPriceScheduleStatus, nil)
+
+		return &mediatr.Unit{}, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	appliedAt := time.Now()
+
+	completed, err := c.completeSchedule(db, schedule, models.AppliedPriceScheduleStatus, &appliedAt)
+	if err != nil {
+		return nil, err
+	}
+	if !completed {
+		// another delivery of the task completed the schedule after it was read
+		c.skip(command.ScheduleID, "completed")
+
+		return &mediatr.Unit{}, nil
+	}
+
+	product.Price = schedule.Price
+	product.Currency = schedule.Currency
+	product.UpdatedAt = appliedAt
+
+	_, err = gormdbcontext.UpdateModel[*datamodels.ProductDataModel, *models.Product](
+		ctx,
+		c.CatalogsDBContext,
+		product,
+	)
+	if err != nil {
+		// a concurrency error is returned as it is, the schedule status is rolled back and the task is retried on the
+		// new version of the product
+		return nil, err
+	}
+
+	err = pricehistory.RecordPriceChange(
+		ctx,
+		db,
+		schedule.ProductId,
+		schedule.Price,
+		schedule.Currency,
+		models.ScheduledPriceChangeReason,
+		appliedAt,
+	)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in saving product price history")
+	}
+
+	err = integrationevents.PublishProductsUpdated(ctx, db, c.RabbitmqProducer, []uuid.UUID{schedule.ProductId})
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in publishing 'ProductUpdated' message")
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf(
+			"scheduled price change `%s` applied to the product with id '%s'",
+			command.ScheduleID,
+			schedule.ProductId,
+		),
+		logger.Fields{"Id": schedule.ProductId, "ScheduleId": command.ScheduleID},
+	)
+
+	return &mediatr.Unit{}, nil
+}
+
+// completeSchedule moves the schedule from the pending status to the given status, false is returned when the schedule
+// is not pending anymore. The conditional update waits for the transaction of a concurrent delivery of the same task.
+func (c *applyScheduledPriceChangeHandler) completeSchedule(
+	db *gorm.DB,
+	schedule *datamodels.ProductPriceScheduleDataModel,
+	status string,
+	appliedAt *time.Time,
+) (bool, error) {
+	result := db.Model(&datamodels.ProductPriceScheduleDataModel{}).
+		Where("id = ? AND status = ?", schedule.Id, models.PendingPriceScheduleStatus).
+		Updates(map[string]interface{}{"status": status, "applied_at": appliedAt})
+	if result.Error != nil {
+		return false, customErrors.NewApplicationErrorWrap(result.Error, "error in updating the scheduled price change")
+	}
+
+	schedule.Status = status
+	schedule.AppliedAt = appliedAt
+
+	return result.RowsAffected == 1, nil
+}
+
+func (c *applyScheduledPriceChangeHandler) skip(scheduleID uuid.UUID, status string) {
+	c.Log.Infof("scheduled price change `%s` is already %s, it is skipped", scheduleID, status)
+}
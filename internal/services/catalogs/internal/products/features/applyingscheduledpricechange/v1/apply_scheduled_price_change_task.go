@@ -0,0 +1,74 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/logger"
+
+	"emperror.dev/errors"
+	"github.com/goccy/go-json"
+	"github.com/hibiken/asynq"
+	"github.com/mehdihadeli/go-mediatr"
+	uuid "github.com/satori/go.uuid"
+)
+
+// ApplyScheduledPriceChangeTaskType type of the asynq task that applies a scheduled price change
+const ApplyScheduledPriceChangeTaskType = "catalogs:products:apply-scheduled-price-change"
+
+// maxMissingScheduleRetries retries of a task that its schedule is not found, the schedule of a task that is still missing
+// after these retries is rolled back after enqueuing the task
+const maxMissingScheduleRetries = 5
+
+type applyScheduledPriceChangePayload struct {
+	ScheduleID uuid.UUID `json:"scheduleId"`
+}
+
+// NewApplyScheduledPriceChangeTask creates the task of a scheduled price change, it should be enqueued with
+// `asynq.ProcessAt` of the schedule apply time
+func NewApplyScheduledPriceChangeTask(scheduleID uuid.UUID) (*asynq.Task, error) {
+	payload, err := json.Marshal(&applyScheduledPriceChangePayload{ScheduleID: scheduleID})
+	if err != nil {
+		return nil, errors.WrapIf(err, "error in marshaling the scheduled price change task payload")
+	}
+
+	return asynq.NewTask(ApplyScheduledPriceChangeTaskType, payload), nil
+}
+
+// RegisterApplyScheduledPriceChangeTaskHandler registers the handler of the scheduled price change tasks on the asynq
+// worker, failed tasks are retried by asynq so a change that conflicts with a concurrent update or runs before the
+// commit of its schedule is applied later. The task of a rolled back schedule is archived after its retries.
+func RegisterApplyScheduledPriceChangeTaskHandler(mux *asynq.ServeMux, log logger.Logger) {
+	mux.HandleFunc(ApplyScheduledPriceChangeTaskType, func(ctx context.Context, task *asynq.Task) error {
+		payload := &applyScheduledPriceChangePayload{}
+		if err := json.Unmarshal(task.Payload(), payload); err != nil {
+			return fmt.Errorf("error in unmarshaling the scheduled price change task payload: %v: %w", err, asynq.SkipRetry)
+		}
+
+		_, err := mediatr.Send[*ApplyScheduledPriceChange, *mediatr.Unit](
+			ctx,
+			NewApplyScheduledPriceChange(payload.ScheduleID),
+		)
+		retried, _ := asynq.GetRetryCount(ctx)
+		if customErrors.IsNotFoundError(err) && retried >= maxMissingScheduleRetries {
+			log.Warnf(
+				"scheduled price change `%s` is not found after %d retries, its task is archived",
+				payload.ScheduleID,
+				retried,
+			)
+
+			return fmt.Errorf("scheduled price change is rolled back: %v: %w", err, asynq.SkipRetry)
+		}
+		if err != nil {
+			log.Errorw(
+				fmt.Sprintf("error in applying the scheduled price change `%s`", payload.ScheduleID),
+				logger.Fields{"ScheduleId": payload.ScheduleID, "Error": err.Error()},
+			)
+
+			return err
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,53 @@
+package v1
+
+import (
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+// BurnEdition burns the edition by its owner
+type BurnEdition struct {
+	cqrs.Command
+	EditionID uuid.UUID
+	Owner     string
+}
+
+func NewBurnEdition(
+	editionID uuid.UUID,
+	owner string,
+) *BurnEdition {
+	command := &BurnEdition{
+		Command:   cqrs.NewCommandByT[BurnEdition](),
+		EditionID: editionID,
+		Owner:     owner,
+	}
+
+	return command
+}
+
+// NewBurnEditionWithValidation burns the edition by its owner with inline validation - for defensive programming and ensuring validation even without using middleware
+func NewBurnEditionWithValidation(
+	editionID uuid.UUID,
+	owner string,
+) (*BurnEdition, error) {
+	command := NewBurnEdition(editionID, owner)
+	err := command.Validate()
+
+	return command, err
+}
+
+func (c *BurnEdition) Validate() error {
+	err := validation.ValidateStruct(
+		c,
+		validation.Field(&c.EditionID, validation.Required),
+		validation.Field(&c.Owner, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
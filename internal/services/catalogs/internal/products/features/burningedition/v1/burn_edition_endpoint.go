@@ -0,0 +1,86 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/burningedition/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/web/route"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/utils"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type burnEditionEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewBurnEditionEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &burnEditionEndpoint{ProductRouteParams: params}
+}
+
+func (ep *burnEditionEndpoint) MapEndpoint() {
+	ep.EditionsGroup.POST("/:id/burn", ep.handler())
+}
+
+// BurnEdition
+// @Tags Editions
+// @Summary Burn edition
+// @Description Burn the edition by its owner, a burned edition has no further changes
+// @Accept json
+// @Produce json
+// @Param BurnEditionRequestDto body dtos.BurnEditionRequestDto true "Burn data"
+// @Param id path string true "Edition ID"
+// @Success 200 {object} dtos.BurnEditionResponseDto
+// @Security BearerAuth
+// @Router /api/v1/editions/{id}/burn [post]
+func (ep *burnEditionEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.BurnEditionRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		// the owner is the authenticated user, not a value of the request
+		_, userId, err := utils.ParseJWTToken(c)
+		if err != nil {
+			return customErrors.NewUnAuthorizedErrorWrap(
+				err,
+				"error in reading the user of the token",
+			)
+		}
+
+		command, err := NewBurnEditionWithValidation(
+			request.EditionID,
+			userId.String(),
+		)
+		if err != nil {
+			return err
+		}
+
+		result, err := mediatr.Send[*BurnEdition, *dtos.BurnEditionResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending BurnEdition",
+			)
+		}
+
+		return c.JSON(http.StatusOK, result)
+	}
+}
@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	dtoV1 "github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1"
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/burningedition/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/mapper"
+
+	"emperror.dev/errors"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type burnEditionHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewBurnEditionHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*BurnEdition, *dtos.BurnEditionResponseDto] {
+	return &burnEditionHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *burnEditionHandler) RegisterHandler() error {
+	return mediatr.RegisterRequestHandler[*BurnEdition, *dtos.BurnEditionResponseDto](
+		c,
+	)
+}
+
+func (c *burnEditionHandler) Handle(
+	ctx context.Context,
+	command *BurnEdition,
+) (*dtos.BurnEditionResponseDto, error) {
+	edition, err := c.EditionAggregateStore.Load(ctx, command.EditionID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = edition.Burn(command.Owner)
+	if err != nil {
+		return nil, err
+	}
+
+	// the edition is stored with its loaded version, so a concurrent change of the edition is a conflict
+	_, err = c.EditionAggregateStore.Store(edition, nil, ctx)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error in storing the edition")
+	}
+
+	editionDto, err := mapper.Map[*dtoV1.EditionDto](edition)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in the mapping EditionDto",
+		)
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf("edition with id '%s' burned", command.EditionID),
+		logger.Fields{"Id": command.EditionID},
+	)
+
+	return &dtos.BurnEditionResponseDto{Edition: editionDto}, nil
+}
@@ -0,0 +1,10 @@
+package dtos
+
+import (
+	uuid "github.com/satori/go.uuid"
+)
+
+// BurnEditionRequestDto validation will handle in command level
+type BurnEditionRequestDto struct {
+	EditionID uuid.UUID `json:"-" param:"id"`
+}
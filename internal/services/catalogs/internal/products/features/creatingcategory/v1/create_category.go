@@ -0,0 +1,84 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+type CreateCategory struct {
+	cqrs.Command
+	CategoryID  uuid.UUID
+	Name        string
+	Slug        string
+	Description string
+	ParentID    *uuid.UUID
+	CreatedAt   time.Time
+}
+
+// NewCreateCategory Create a new category, the slug is generated from the name when it is empty
+func NewCreateCategory(
+	name string,
+	slug string,
+	description string,
+	parentID *uuid.UUID,
+) *CreateCategory {
+	if slug == "" {
+		slug = models.NewCategorySlug(name)
+	}
+
+	command := &CreateCategory{
+		Command:     cqrs.NewCommandByT[CreateCategory](),
+		CategoryID:  uuid.NewV4(),
+		Name:        name,
+		Slug:        slug,
+		Description: description,
+		ParentID:    parentID,
+		CreatedAt:   time.Now(),
+	}
+
+	return command
+}
+
+// NewCreateCategoryWithValidation Create a new category with inline validation - for defensive programming and ensuring validation even without using middleware
+func NewCreateCategoryWithValidation(
+	name string,
+	slug string,
+	description string,
+	parentID *uuid.UUID,
+) (*CreateCategory, error) {
+	command := NewCreateCategory(name, slug, description, parentID)
+	err := command.Validate()
+
+	return command, err
+}
+
+// IsTxRequest for enabling transactions on the mediatr pipeline
+func (c *CreateCategory) IsTxRequest() {
+}
+
+func (c *CreateCategory) Validate() error {
+	err := validation.ValidateStruct(
+		c,
+		validation.Field(&c.CategoryID, validation.Required),
+		validation.Field(&c.Name, validation.Required, validation.Length(0, 100)),
+		validation.Field(
+			&c.Slug,
+			validation.Required,
+			validation.Length(0, 100),
+			validation.Match(models.CategorySlugRegex),
+		),
+		validation.Field(&c.Description, validation.Length(0, 1000)),
+		validation.Field(&c.CreatedAt, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/creatingcategory/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/web/route"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type createCategoryEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewCreateCategoryEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &createCategoryEndpoint{ProductRouteParams: params}
+}
+
+func (ep *createCategoryEndpoint) MapEndpoint() {
+	ep.CategoriesGroup.POST("", ep.handler())
+}
+
+// CreateCategory
+// @Tags Categories
+// @Summary Create category
+// @Description Create new category, a category with a parent becomes its sub-category
+// @Accept json
+// @Produce json
+// @Param CreateCategoryRequestDto body dtos.CreateCategoryRequestDto true "Category data"
+// @Success 201 {object} dtos.CreateCategoryResponseDto
+// @Router /api/v1/categories [post]
+func (ep *createCategoryEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.CreateCategoryRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		command, err := NewCreateCategoryWithValidation(
+			request.Name,
+			request.Slug,
+			request.Description,
+			request.ParentID,
+		)
+		if err != nil {
+			return err
+		}
+
+		result, err := mediatr.Send[*CreateCategory, *dtos.CreateCategoryResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending CreateCategory",
+			)
+		}
+
+		return c.JSON(http.StatusCreated, result)
+	}
+}
@@ -0,0 +1,84 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/datamodels"
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/creatingcategory/v1/dtos"
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/postgresgorm/gormdbcontext"
+
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type createCategoryHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewCreateCategoryHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*CreateCategory, *dtos.CreateCategoryResponseDto] {
+	return &createCategoryHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *createCategoryHandler) RegisterHandler() error {
+	return mediatr.RegisterRequestHandler[*CreateCategory, *dtos.CreateCategoryResponseDto](
+		c,
+	)
+}
+
+func (c *createCategoryHandler) Handle(
+	ctx context.Context,
+	command *CreateCategory,
+) (*dtos.CreateCategoryResponseDto, error) {
+	var parentPath string
+	if command.ParentID != nil {
+		parent, err := gormdbcontext.FindModelByID[*datamodels.CategoryDataModel, *models.Category](
+			ctx,
+			c.CatalogsDBContext,
+			*command.ParentID,
+		)
+		if err != nil {
+			return nil, customErrors.NewApplicationErrorWrapWithCode(
+				err,
+				http.StatusNotFound,
+				fmt.Sprintf("parent category with id `%s` not found", command.ParentID),
+			)
+		}
+		parentPath = parent.Path
+	}
+
+	category := &models.Category{
+		Id:          command.CategoryID,
+		Name:        command.Name,
+		Slug:        command.Slug,
+		Description: command.Description,
+		ParentId:    command.ParentID,
+		Path:        models.NewCategoryPath(parentPath, command.Slug),
+		CreatedAt:   command.CreatedAt,
+	}
+
+	_, err := gormdbcontext.AddModel[*datamodels.CategoryDataModel, *models.Category](
+		ctx,
+		c.CatalogsDBContext,
+		category,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf("category with id '%s' created", command.CategoryID),
+		logger.Fields{"Id": command.CategoryID, "Path": category.Path},
+	)
+
+	return &dtos.CreateCategoryResponseDto{CategoryID: command.CategoryID}, nil
+}
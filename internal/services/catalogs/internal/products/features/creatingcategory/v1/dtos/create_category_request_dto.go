@@ -0,0 +1,11 @@
+package dtos
+
+import uuid "github.com/satori/go.uuid"
+
+// CreateCategoryRequestDto validation will handle in command level
+type CreateCategoryRequestDto struct {
+	Name        string     `json:"name"`
+	Slug        string     `json:"slug"`
+	Description string     `json:"description"`
+	ParentID    *uuid.UUID `json:"parentId"`
+}
@@ -0,0 +1,15 @@
+package dtos
+
+import (
+	"github.com/reoden/go-NFT/pkg/core/serializer/json"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type CreateCategoryResponseDto struct {
+	CategoryID uuid.UUID `json:"categoryId"`
+}
+
+func (c *CreateCategoryResponseDto) String() string {
+	return json.PrettyPrint(c)
+}
@@ -3,22 +3,33 @@ package v1
 import (
 	"time"
 
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
 	"github.com/reoden/go-NFT/pkg/core/cqrs"
 	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
 
 	validation "github.com/go-ozzo/ozzo-validation"
 	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
 )
 
 // https://echo.labstack.com/guide/request/
 // https://github.com/go-playground/validator
 
+const (
+	maxProductCategories = 10
+	maxProductTags       = 20
+)
+
 type CreateProduct struct {
 	cqrs.Command
 	ProductID   uuid.UUID
 	Name        string
 	Description string
-	Price       float64
+	Artist      string
+	Price       decimal.Decimal
+	Currency    string
+	CategoryIds []uuid.UUID
+	Tags        []string
 	CreatedAt   time.Time
 }
 
@@ -26,14 +37,22 @@ type CreateProduct struct {
 func NewCreateProduct(
 	name string,
 	description string,
-	price float64,
+	artist string,
+	price decimal.Decimal,
+	currency string,
+	categoryIds []uuid.UUID,
+	tags []string,
 ) *CreateProduct {
 	command := &CreateProduct{
 		Command:     cqrs.NewCommandByT[CreateProduct](),
 		ProductID:   uuid.NewV4(),
 		Name:        name,
 		Description: description,
+		Artist:      artist,
 		Price:       price,
+		Currency:    models.NormalizeCurrency(currency),
+		CategoryIds: categoryIds,
+		Tags:        tags,
 		CreatedAt:   time.Now(),
 	}
 
@@ -44,15 +63,27 @@ func NewCreateProduct(
 func NewCreateProductWithValidation(
 	name string,
 	description string,
-	price float64,
+	artist string,
+	price decimal.Decimal,
+	currency string,
+	categoryIds []uuid.UUID,
+	tags []string,
 ) (*CreateProduct, error) {
-	command := NewCreateProduct(name, description, price)
+	command := NewCreateProduct(
+		name,
+		description,
+		artist,
+		price,
+		currency,
+		categoryIds,
+		tags,
+	)
 	err := command.Validate()
 
 	return command, err
 }
 
-func (c *CreateProduct) isTxRequest() {
+func (c *CreateProduct) IsTxRequest() {
 }
 
 func (c *CreateProduct) Validate() error {
@@ -69,10 +100,14 @@ func (c *CreateProduct) Validate() error {
 			validation.Required,
 			validation.Length(0, 5000),
 		),
+		validation.Field(&c.Artist, validation.Length(0, 255)),
+		validation.Field(&c.Price, models.PositiveAmount),
+		validation.Field(&c.Currency, validation.Required, validation.Match(models.CurrencyRegex)),
+		validation.Field(&c.CategoryIds, validation.Length(0, maxProductCategories)),
 		validation.Field(
-			&c.Price,
-			validation.Required,
-			validation.Min(0.0).Exclusive(),
+			&c.Tags,
+			validation.Length(0, maxProductTags),
+			validation.Each(validation.Required, validation.Length(1, 50)),
 		),
 		validation.Field(&c.CreatedAt, validation.Required),
 	)
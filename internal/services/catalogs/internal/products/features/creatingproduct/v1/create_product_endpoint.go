@@ -53,7 +53,11 @@ func (ep *createProductEndpoint) handler() echo.HandlerFunc {
 		command, err := NewCreateProductWithValidation(
 			request.Name,
 			request.Description,
+			request.Artist,
 			request.Price,
+			request.Currency,
+			request.CategoryIds,
+			request.Tags,
 		)
 		if err != nil {
 			return err
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/associations"
 	datamodel "github.com/reoden/go-NFT/catalogs/internal/products/data/datamodels"
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/pricehistory"
 	dtosv1 "github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1"
 	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
 	"github.com/reoden/go-NFT/catalogs/internal/products/features/creatingproduct/v1/dtos"
@@ -17,6 +19,7 @@ import (
 	"github.com/reoden/go-NFT/pkg/postgresgorm/gormdbcontext"
 
 	"github.com/mehdihadeli/go-mediatr"
+	uuid "github.com/satori/go.uuid"
 )
 
 type createProductHandler struct {
@@ -45,13 +48,16 @@ func (c *createProductHandler) Handle(
 		Id:          command.ProductID,
 		Name:        command.Name,
 		Description: command.Description,
+		Artist:      command.Artist,
 		Price:       command.Price,
+		Currency:    command.Currency,
+		Version:     1,
 		CreatedAt:   command.CreatedAt,
 	}
 
 	var createProductResult *dtos.CreateProductResponseDto
 
-	result, err := gormdbcontext.AddModel[*datamodel.ProductDataModel, *models.Product](
+	_, err := gormdbcontext.AddModel[*datamodel.ProductDataModel, *models.Product](
 		ctx,
 		c.CatalogsDBContext,
 		product,
@@ -60,6 +66,24 @@ func (c *createProductHandler) Handle(
 		return nil, err
 	}
 
+	err = pricehistory.RecordPriceChange(
+		ctx,
+		c.CatalogsDBContext.WithTxIfExists(ctx).DB(),
+		product.Id,
+		product.Price,
+		product.Currency,
+		models.CreatedPriceChangeReason,
+		command.CreatedAt,
+	)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in saving product price history")
+	}
+
+	result, err := c.saveAssociations(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
 	productDto, err := mapper.Map[*dtosv1.ProductDto](result)
 	if err != nil {
 		return nil, customErrors.NewApplicationErrorWrap(
@@ -105,3 +129,41 @@ func (c *createProductHandler) Handle(
 
 	return createProductResult, err
 }
+
+func (c *createProductHandler) saveAssociations(
+	ctx context.Context,
+	command *CreateProduct,
+) (*models.Product, error) {
+	db := c.CatalogsDBContext.WithTxIfExists(ctx).DB()
+
+	if len(command.CategoryIds) > 0 {
+		err := associations.ReplaceProductCategories(ctx, db, command.ProductID, command.CategoryIds)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(command.Tags) > 0 {
+		err := associations.ReplaceProductTags(ctx, db, command.ProductID, command.Tags)
+		if err != nil {
+			return nil, customErrors.NewApplicationErrorWrap(err, "error in saving product tags")
+		}
+	}
+
+	products, err := associations.FindProductsWithAssociations(ctx, db, []uuid.UUID{command.ProductID})
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in fetching the created product")
+	}
+	if len(products) == 0 {
+		return nil, customErrors.NewNotFoundError(
+			fmt.Sprintf("product with id `%s` not found", command.ProductID),
+		)
+	}
+
+	product, err := mapper.Map[*models.Product](products[0])
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in the mapping Product")
+	}
+
+	return product, nil
+}
@@ -1,12 +1,23 @@
 package dtos
 
+import (
+	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
+)
+
 // https://echo.labstack.com/guide/binding/
 // https://echo.labstack.com/guide/request/
 // https://github.com/go-playground/validator
 
 // CreateProductRequestDto validation will handle in command level
 type CreateProductRequestDto struct {
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Artist      string `json:"artist"`
+	// Price exact amount of the price, it can be sent as a number or a string like `"12.50"`
+	Price decimal.Decimal `json:"price"`
+	// Currency code of the price like `USD` or `ETH`, the default is `USD`
+	Currency    string      `json:"currency"`
+	CategoryIds []uuid.UUID `json:"categoryIds"`
+	Tags        []string    `json:"tags"`
 }
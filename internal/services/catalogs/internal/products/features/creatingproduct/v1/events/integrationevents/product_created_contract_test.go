@@ -0,0 +1,14 @@
+//go:build unit
+// +build unit
+
+package integrationevents
+
+import (
+	"testing"
+
+	"github.com/reoden/go-NFT/pkg/test/messaging/contracts"
+)
+
+func Test_ProductCreatedV1_Contract_Is_Compatible(t *testing.T) {
+	contracts.AssertContractCompatible[*ProductCreatedV1](t, "testdata/contracts")
+}
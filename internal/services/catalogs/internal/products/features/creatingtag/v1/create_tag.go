@@ -0,0 +1,53 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+type CreateTag struct {
+	cqrs.Command
+	TagID     uuid.UUID
+	Name      string
+	CreatedAt time.Time
+}
+
+// NewCreateTag Create a new tag, the name is normalized to lower case
+func NewCreateTag(name string) *CreateTag {
+	command := &CreateTag{
+		Command:   cqrs.NewCommandByT[CreateTag](),
+		TagID:     uuid.NewV4(),
+		Name:      models.NormalizeTagName(name),
+		CreatedAt: time.Now(),
+	}
+
+	return command
+}
+
+// NewCreateTagWithValidation Create a new tag with inline validation - for defensive programming and ensuring validation even without using middleware
+func NewCreateTagWithValidation(name string) (*CreateTag, error) {
+	command := NewCreateTag(name)
+	err := command.Validate()
+
+	return command, err
+}
+
+func (c *CreateTag) Validate() error {
+	err := validation.ValidateStruct(
+		c,
+		validation.Field(&c.TagID, validation.Required),
+		validation.Field(&c.Name, validation.Required, validation.Length(1, 50)),
+		validation.Field(&c.CreatedAt, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
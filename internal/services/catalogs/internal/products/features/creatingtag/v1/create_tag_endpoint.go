@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/creatingtag/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/web/route"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type createTagEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewCreateTagEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &createTagEndpoint{ProductRouteParams: params}
+}
+
+func (ep *createTagEndpoint) MapEndpoint() {
+	ep.TagsGroup.POST("", ep.handler())
+}
+
+// CreateTag
+// @Tags Tags
+// @Summary Create tag
+// @Description Create new tag, tags are also created on the fly when they are attached to a product
+// @Accept json
+// @Produce json
+// @Param CreateTagRequestDto body dtos.CreateTagRequestDto true "Tag data"
+// @Success 201 {object} dtos.CreateTagResponseDto
+// @Router /api/v1/tags [post]
+func (ep *createTagEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.CreateTagRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		command, err := NewCreateTagWithValidation(request.Name)
+		if err != nil {
+			return err
+		}
+
+		result, err := mediatr.Send[*CreateTag, *dtos.CreateTagResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending CreateTag",
+			)
+		}
+
+		return c.JSON(http.StatusCreated, result)
+	}
+}
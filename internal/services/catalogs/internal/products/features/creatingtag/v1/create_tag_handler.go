@@ -0,0 +1,61 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/datamodels"
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/creatingtag/v1/dtos"
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/postgresgorm/gormdbcontext"
+
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type createTagHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewCreateTagHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*CreateTag, *dtos.CreateTagResponseDto] {
+	return &createTagHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *createTagHandler) RegisterHandler() error {
+	return mediatr.RegisterRequestHandler[*CreateTag, *dtos.CreateTagResponseDto](
+		c,
+	)
+}
+
+func (c *createTagHandler) Handle(
+	ctx context.Context,
+	command *CreateTag,
+) (*dtos.CreateTagResponseDto, error) {
+	tag := &models.Tag{
+		Id:        command.TagID,
+		Name:      command.Name,
+		CreatedAt: command.CreatedAt,
+	}
+
+	_, err := gormdbcontext.AddModel[*datamodels.TagDataModel, *models.Tag](
+		ctx,
+		c.CatalogsDBContext,
+		tag,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf("tag with id '%s' created", command.TagID),
+		logger.Fields{"Id": command.TagID, "Name": command.Name},
+	)
+
+	return &dtos.CreateTagResponseDto{TagID: command.TagID}, nil
+}
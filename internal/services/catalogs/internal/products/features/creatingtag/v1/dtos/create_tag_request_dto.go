@@ -0,0 +1,6 @@
+package dtos
+
+// CreateTagRequestDto validation will handle in command level
+type CreateTagRequestDto struct {
+	Name string `json:"name"`
+}
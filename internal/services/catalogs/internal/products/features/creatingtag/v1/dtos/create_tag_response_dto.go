@@ -0,0 +1,15 @@
+package dtos
+
+import (
+	"github.com/reoden/go-NFT/pkg/core/serializer/json"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type CreateTagResponseDto struct {
+	TagID uuid.UUID `json:"tagId"`
+}
+
+func (c *CreateTagResponseDto) String() string {
+	return json.PrettyPrint(c)
+}
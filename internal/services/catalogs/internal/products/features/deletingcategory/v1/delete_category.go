@@ -0,0 +1,43 @@
+package v1
+
+import (
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+type DeleteCategory struct {
+	CategoryID uuid.UUID
+}
+
+// NewDeleteCategory delete a category
+func NewDeleteCategory(categoryID uuid.UUID) *DeleteCategory {
+	command := &DeleteCategory{CategoryID: categoryID}
+
+	return command
+}
+
+// NewDeleteCategoryWithValidation delete a category with inline validation - for defensive programming and ensuring validation even without using middleware
+func NewDeleteCategoryWithValidation(categoryID uuid.UUID) (*DeleteCategory, error) {
+	command := NewDeleteCategory(categoryID)
+	err := command.Validate()
+
+	return command, err
+}
+
+// IsTxRequest for enabling transactions on the mediatr pipeline
+func (c *DeleteCategory) IsTxRequest() {
+}
+
+func (c *DeleteCategory) Validate() error {
+	err := validation.ValidateStruct(
+		c,
+		validation.Field(&c.CategoryID, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/deletingcategory/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/web/route"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type deleteCategoryEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewDeleteCategoryEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &deleteCategoryEndpoint{ProductRouteParams: params}
+}
+
+func (ep *deleteCategoryEndpoint) MapEndpoint() {
+	ep.CategoriesGroup.DELETE("/:id", ep.handler())
+}
+
+// DeleteCategory
+// @Tags Categories
+// @Summary Delete category
+// @Description Delete existing category without sub-categories
+// @Accept json
+// @Produce json
+// @Success 204
+// @Param id path string true "Category ID"
+// @Router /api/v1/categories/{id} [delete]
+func (ep *deleteCategoryEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.DeleteCategoryRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		command, err := NewDeleteCategoryWithValidation(request.CategoryID)
+		if err != nil {
+			return err
+		}
+
+		_, err = mediatr.Send[*DeleteCategory, *mediatr.Unit](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending DeleteCategory",
+			)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,103 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/associations"
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/datamodels"
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/updatingproduct/v1/events/integrationevents"
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/postgresgorm/gormdbcontext"
+
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type deleteCategoryHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewDeleteCategoryHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*DeleteCategory, *mediatr.Unit] {
+	return &deleteCategoryHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *deleteCategoryHandler) RegisterHandler() error {
+	return mediatr.RegisterRequestHandler[*DeleteCategory, *mediatr.Unit](
+		c,
+	)
+}
+
+// Handle deletes a category without sub-categories, the products of the category are detached from it and republished
+func (c *deleteCategoryHandler) Handle(
+	ctx context.Context,
+	command *DeleteCategory,
+) (*mediatr.Unit, error) {
+	category, err := gormdbcontext.FindModelByID[*datamodels.CategoryDataModel, *models.Category](
+		ctx,
+		c.CatalogsDBContext,
+		command.CategoryID,
+	)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrapWithCode(
+			err,
+			http.StatusNotFound,
+			fmt.Sprintf("category with id `%s` not found", command.CategoryID),
+		)
+	}
+
+	db := c.CatalogsDBContext.WithTxIfExists(ctx).DB().WithContext(ctx)
+
+	var childrenCount int64
+	err = db.Model(&datamodels.CategoryDataModel{}).
+		Where("parent_id = ?", command.CategoryID).
+		Count(&childrenCount).Error
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in counting sub-categories")
+	}
+
+	if childrenCount > 0 {
+		return nil, customErrors.NewConflictError(
+			fmt.Sprintf("category with id `%s` has sub-categories and can't be deleted", command.CategoryID),
+		)
+	}
+
+	productIds, err := associations.FindProductIdsInCategoryTree(ctx, db, category.Path)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in finding category products")
+	}
+
+	// product associations are removed by the `ON DELETE CASCADE` of the join table
+	err = db.Delete(&datamodels.CategoryDataModel{Id: command.CategoryID}).Error
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in deleting the category")
+	}
+
+	err = associations.IncrementProductVersions(ctx, db, productIds)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in updating category products")
+	}
+
+	err = integrationevents.PublishProductsUpdated(ctx, db, c.RabbitmqProducer, productIds)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in publishing 'ProductUpdated' messages for the category products",
+		)
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf("category with id '%s' deleted", command.CategoryID),
+		logger.Fields{"Id": command.CategoryID, "AffectedProducts": len(productIds)},
+	)
+
+	return &mediatr.Unit{}, nil
+}
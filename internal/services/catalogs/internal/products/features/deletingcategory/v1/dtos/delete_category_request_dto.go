@@ -0,0 +1,7 @@
+package dtos
+
+import uuid "github.com/satori/go.uuid"
+
+type DeleteCategoryRequestDto struct {
+	CategoryID uuid.UUID `param:"id" json:"-"`
+}
@@ -28,7 +28,7 @@ func NewDeleteProductWithValidation(productID uuid.UUID) (*DeleteProduct, error)
 }
 
 // IsTxRequest for enabling transactions on the mediatr pipeline
-func (c *DeleteProduct) isTxRequest() {
+func (c *DeleteProduct) IsTxRequest() {
 }
 
 func (c *DeleteProduct) Validate() error {
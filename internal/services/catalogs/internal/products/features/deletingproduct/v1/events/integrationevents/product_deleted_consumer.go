@@ -0,0 +1,60 @@
+package integrationEvents
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/contracts"
+	"github.com/reoden/go-NFT/pkg/core/messaging/consumer"
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	"github.com/reoden/go-NFT/pkg/logger"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// productDeletedConsumer removes the deleted product from the products search index
+type productDeletedConsumer struct {
+	log              logger.Logger
+	searchRepository contracts.ProductSearchRepository
+}
+
+func NewProductDeletedConsumer(
+	log logger.Logger,
+	searchRepository contracts.ProductSearchRepository,
+) consumer.ConsumerHandler {
+	return &productDeletedConsumer{
+		log:              log,
+		searchRepository: searchRepository,
+	}
+}
+
+func (c *productDeletedConsumer) Handle(
+	ctx context.Context,
+	consumeContext types.MessageConsumeContext,
+) error {
+	message, ok := consumeContext.Message().(*ProductDeletedV1)
+	if !ok {
+		return errors.New("error in casting message to ProductDeletedV1")
+	}
+
+	productId, err := uuid.FromString(message.ProductId)
+	if err != nil {
+		return errors.WrapIf(err, "error in parsing deleted product id")
+	}
+
+	err = c.searchRepository.DeleteProduct(ctx, productId)
+	if err != nil {
+		return errors.WrapIf(err, "error in removing deleted product from the search index")
+	}
+
+	c.log.Infow(
+		fmt.Sprintf(
+			"ProductDeleted message with messageId `%s` projected to the search index",
+			message.MessageId,
+		),
+		logger.Fields{"MessageId": message.MessageId, "Id": productId},
+	)
+
+	return nil
+}
@@ -0,0 +1,14 @@
+//go:build unit
+// +build unit
+
+package integrationEvents
+
+import (
+	"testing"
+
+	"github.com/reoden/go-NFT/pkg/test/messaging/contracts"
+)
+
+func Test_ProductDeletedV1_Contract_Is_Compatible(t *testing.T) {
+	contracts.AssertContractCompatible[*ProductDeletedV1](t, "testdata/contracts")
+}
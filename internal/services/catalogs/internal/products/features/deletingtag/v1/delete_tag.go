@@ -0,0 +1,43 @@
+package v1
+
+import (
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+type DeleteTag struct {
+	TagID uuid.UUID
+}
+
+// NewDeleteTag delete a tag
+func NewDeleteTag(tagID uuid.UUID) *DeleteTag {
+	command := &DeleteTag{TagID: tagID}
+
+	return command
+}
+
+// NewDeleteTagWithValidation delete a tag with inline validation - for defensive programming and ensuring validation even without using middleware
+func NewDeleteTagWithValidation(tagID uuid.UUID) (*DeleteTag, error) {
+	command := NewDeleteTag(tagID)
+	err := command.Validate()
+
+	return command, err
+}
+
+// IsTxRequest for enabling transactions on the mediatr pipeline
+func (c *DeleteTag) IsTxRequest() {
+}
+
+func (c *DeleteTag) Validate() error {
+	err := validation.ValidateStruct(
+		c,
+		validation.Field(&c.TagID, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
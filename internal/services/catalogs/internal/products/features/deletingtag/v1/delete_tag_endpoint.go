@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/deletingtag/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/web/route"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type deleteTagEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewDeleteTagEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &deleteTagEndpoint{ProductRouteParams: params}
+}
+
+func (ep *deleteTagEndpoint) MapEndpoint() {
+	ep.TagsGroup.DELETE("/:id", ep.handler())
+}
+
+// DeleteTag
+// @Tags Tags
+// @Summary Delete tag
+// @Description Delete existing tag, the tag is removed from its products
+// @Accept json
+// @Produce json
+// @Success 204
+// @Param id path string true "Tag ID"
+// @Router /api/v1/tags/{id} [delete]
+func (ep *deleteTagEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.DeleteTagRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		command, err := NewDeleteTagWithValidation(request.TagID)
+		if err != nil {
+			return err
+		}
+
+		_, err = mediatr.Send[*DeleteTag, *mediatr.Unit](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending DeleteTag",
+			)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/associations"
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/datamodels"
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/updatingproduct/v1/events/integrationevents"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/postgresgorm/gormdbcontext"
+
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type deleteTagHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewDeleteTagHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*DeleteTag, *mediatr.Unit] {
+	return &deleteTagHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *deleteTagHandler) RegisterHandler() error {
+	return mediatr.RegisterRequestHandler[*DeleteTag, *mediatr.Unit](
+		c,
+	)
+}
+
+// Handle deletes a tag, the products of the tag are detached from it and republished
+func (c *deleteTagHandler) Handle(
+	ctx context.Context,
+	command *DeleteTag,
+) (*mediatr.Unit, error) {
+	if !gormdbcontext.Exists[*datamodels.TagDataModel](ctx, c.CatalogsDBContext, command.TagID) {
+		return nil, customErrors.NewApplicationErrorWithCode(
+			fmt.Sprintf("tag with id `%s` not found", command.TagID),
+			http.StatusNotFound,
+		)
+	}
+
+	db := c.CatalogsDBContext.WithTxIfExists(ctx).DB().WithContext(ctx)
+
+	productIds, err := associations.FindProductIdsWithTag(ctx, db, command.TagID)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in finding tag products")
+	}
+
+	// product associations are removed by the `ON DELETE CASCADE` of the join table
+	err = db.Delete(&datamodels.TagDataModel{Id: command.TagID}).Error
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in deleting the tag")
+	}
+
+	err = associations.IncrementProductVersions(ctx, db, productIds)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in updating tag products")
+	}
+
+	err = integrationevents.PublishProductsUpdated(ctx, db, c.RabbitmqProducer, productIds)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in publishing 'ProductUpdated' messages for the tag products",
+		)
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf("tag with id '%s' deleted", command.TagID),
+		logger.Fields{"Id": command.TagID, "AffectedProducts": len(productIds)},
+	)
+
+	return &mediatr.Unit{}, nil
+}
@@ -0,0 +1,7 @@
+package dtos
+
+import uuid "github.com/satori/go.uuid"
+
+type DeleteTagRequestDto struct {
+	TagID uuid.UUID `param:"id" json:"-"`
+}
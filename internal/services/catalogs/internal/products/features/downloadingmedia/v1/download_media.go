@@ -0,0 +1,45 @@
+package v1
+
+import (
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// DownloadMedia downloads a media object with a signed url of the local object store
+type DownloadMedia struct {
+	Key       string
+	Expires   int64
+	Signature string
+}
+
+func NewDownloadMedia(key string, expires int64, signature string) *DownloadMedia {
+	query := &DownloadMedia{
+		Key:       key,
+		Expires:   expires,
+		Signature: signature,
+	}
+
+	return query
+}
+
+func NewDownloadMediaWithValidation(key string, expires int64, signature string) (*DownloadMedia, error) {
+	query := NewDownloadMedia(key, expires, signature)
+	err := query.Validate()
+
+	return query, err
+}
+
+func (q *DownloadMedia) Validate() error {
+	err := validation.ValidateStruct(
+		q,
+		validation.Field(&q.Key, validation.Required),
+		validation.Field(&q.Expires, validation.Required),
+		validation.Field(&q.Signature, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
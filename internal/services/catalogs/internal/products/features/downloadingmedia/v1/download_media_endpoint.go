@@ -0,0 +1,76 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/downloadingmedia/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/web/route"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type downloadMediaEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewDownloadMediaEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &downloadMediaEndpoint{ProductRouteParams: params}
+}
+
+func (ep *downloadMediaEndpoint) MapEndpoint() {
+	ep.MediaGroup.GET("/*", ep.handler())
+}
+
+// DownloadMedia
+// @Tags Media
+// @Summary Download media
+// @Description Download a product media file with the signed url returned in the product media
+// @Produce octet-stream
+// @Param key path string true "Media object key"
+// @Param expires query int true "Signature expiry as unix time"
+// @Param signature query string true "Url signature"
+// @Success 200 {file} file
+// @Router /api/v1/media/{key} [get]
+func (ep *downloadMediaEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.DownloadMediaRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		query, err := NewDownloadMediaWithValidation(c.Param("*"), request.Expires, request.Signature)
+		if err != nil {
+			return err
+		}
+
+		result, err := mediatr.Send[*DownloadMedia, *dtos.DownloadMediaResponseDto](
+			ctx,
+			query,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending DownloadMedia",
+			)
+		}
+		defer result.Content.Close()
+
+		c.Response().Header().Set(echo.HeaderContentLength, strconv.FormatInt(result.Size, 10))
+
+		return c.Stream(http.StatusOK, result.ContentType, result.Content)
+	}
+}
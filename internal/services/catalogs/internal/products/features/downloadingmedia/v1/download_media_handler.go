@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/downloadingmedia/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/storage"
+
+	"emperror.dev/errors"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type downloadMediaHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewDownloadMediaHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*DownloadMedia, *dtos.DownloadMediaResponseDto] {
+	return &downloadMediaHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *downloadMediaHandler) RegisterHandler() error {
+	return mediatr.RegisterRequestHandler[*DownloadMedia, *dtos.DownloadMediaResponseDto](
+		c,
+	)
+}
+
+// Handle checks the signature of the url and opens the media object, only the stores that don't serve their signed
+// urls themselves (the local store) are served by the service, S3 signed urls point to the bucket directly
+func (c *downloadMediaHandler) Handle(
+	ctx context.Context,
+	query *DownloadMedia,
+) (*dtos.DownloadMediaResponseDto, error) {
+	verifier, ok := c.ObjectStore.(storage.SignedURLVerifier)
+	if !ok {
+		return nil, customErrors.NewNotFoundError("media downloads are served by the object store")
+	}
+
+	err := verifier.VerifySignature(query.Key, query.Expires, query.Signature)
+	if err != nil {
+		return nil, customErrors.NewForbiddenErrorWrap(err, "media url signature is invalid or expired")
+	}
+
+	content, info, err := c.ObjectStore.Get(ctx, query.Key)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		return nil, customErrors.NewApplicationErrorWithCode(
+			fmt.Sprintf("media `%s` not found", query.Key),
+			http.StatusNotFound,
+		)
+	}
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in reading the media object")
+	}
+
+	return &dtos.DownloadMediaResponseDto{
+		Content:     content,
+		ContentType: info.ContentType,
+		Size:        info.Size,
+	}, nil
+}
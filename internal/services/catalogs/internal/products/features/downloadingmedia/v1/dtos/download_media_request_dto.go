@@ -0,0 +1,6 @@
+package dtos
+
+type DownloadMediaRequestDto struct {
+	Expires   int64  `query:"expires"   json:"-"`
+	Signature string `query:"signature" json:"-"`
+}
@@ -0,0 +1,10 @@
+package dtos
+
+import "io"
+
+// DownloadMediaResponseDto the endpoint streams `Content` to the response and closes it
+type DownloadMediaResponseDto struct {
+	Content     io.ReadCloser
+	ContentType string
+	Size        int64
+}
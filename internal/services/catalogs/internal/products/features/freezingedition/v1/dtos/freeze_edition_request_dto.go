@@ -0,0 +1,12 @@
+package dtos
+
+import (
+	uuid "github.com/satori/go.uuid"
+)
+
+// FreezeEditionRequestDto validation will handle in command level
+type FreezeEditionRequestDto struct {
+	EditionID uuid.UUID `json:"-" param:"id"`
+	// Reason the reason of the freeze
+	Reason string `json:"reason"`
+}
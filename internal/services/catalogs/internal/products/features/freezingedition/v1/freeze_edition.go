@@ -0,0 +1,53 @@
+package v1
+
+import (
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+// FreezeEdition freezes the edition, for example during a dispute of its ownership
+type FreezeEdition struct {
+	cqrs.Command
+	EditionID uuid.UUID
+	Reason    string
+}
+
+func NewFreezeEdition(
+	editionID uuid.UUID,
+	reason string,
+) *FreezeEdition {
+	command := &FreezeEdition{
+		Command:   cqrs.NewCommandByT[FreezeEdition](),
+		EditionID: editionID,
+		Reason:    reason,
+	}
+
+	return command
+}
+
+// NewFreezeEditionWithValidation freezes the edition, for example during a dispute of its ownership with inline validation - for defensive programming and ensuring validation even without using middleware
+func NewFreezeEditionWithValidation(
+	editionID uuid.UUID,
+	reason string,
+) (*FreezeEdition, error) {
+	command := NewFreezeEdition(editionID, reason)
+	err := command.Validate()
+
+	return command, err
+}
+
+func (c *FreezeEdition) Validate() error {
+	err := validation.ValidateStruct(
+		c,
+		validation.Field(&c.EditionID, validation.Required),
+		validation.Field(&c.Reason, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/freezingedition/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/constants"
+	"github.com/reoden/go-NFT/pkg/core/web/route"
+	"github.com/reoden/go-NFT/pkg/http/customecho/middlewares/auth"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type freezeEditionEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewFreezeEditionEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &freezeEditionEndpoint{ProductRouteParams: params}
+}
+
+func (ep *freezeEditionEndpoint) MapEndpoint() {
+	ep.EditionsGroup.POST("/:id/freeze", ep.handler(), auth.RequireRoles(constants.AdminRole))
+}
+
+// FreezeEdition
+// @Tags Editions
+// @Summary Freeze edition
+// @Description Freeze the edition, a frozen edition cannot be listed, sold, transferred or burned until it is unfrozen
+// @Accept json
+// @Produce json
+// @Param FreezeEditionRequestDto body dtos.FreezeEditionRequestDto true "Freeze data"
+// @Param id path string true "Edition ID"
+// @Success 200 {object} dtos.FreezeEditionResponseDto
+// @Security BearerAuth
+// @Router /api/v1/editions/{id}/freeze [post]
+func (ep *freezeEditionEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.FreezeEditionRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		command, err := NewFreezeEditionWithValidation(
+			request.EditionID,
+			request.Reason,
+		)
+		if err != nil {
+			return err
+		}
+
+		result, err := mediatr.Send[*FreezeEdition, *dtos.FreezeEditionResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending FreezeEdition",
+			)
+		}
+
+		return c.JSON(http.StatusOK, result)
+	}
+}
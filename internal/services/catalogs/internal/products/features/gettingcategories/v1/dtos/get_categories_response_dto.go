@@ -0,0 +1,10 @@
+package dtos
+
+import (
+	dtoV1 "github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1"
+)
+
+// GetCategoriesResponseDto root categories with their sub-categories
+type GetCategoriesResponseDto struct {
+	Categories []*dtoV1.CategoryTreeDto `json:"categories"`
+}
@@ -0,0 +1,13 @@
+package v1
+
+import (
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+)
+
+type GetCategories struct {
+	cqrs.Query
+}
+
+func NewGetCategories() *GetCategories {
+	return &GetCategories{Query: cqrs.NewQueryByT[GetCategories]()}
+}
@@ -0,0 +1,54 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/gettingcategories/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/web/route"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type getCategoriesEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewGetCategoriesEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &getCategoriesEndpoint{ProductRouteParams: params}
+}
+
+func (ep *getCategoriesEndpoint) MapEndpoint() {
+	ep.CategoriesGroup.GET("", ep.handler())
+}
+
+// GetCategories
+// @Tags Categories
+// @Summary Get categories tree
+// @Description Get all categories as a tree
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.GetCategoriesResponseDto
+// @Router /api/v1/categories [get]
+func (ep *getCategoriesEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		queryResult, err := mediatr.Send[*GetCategories, *dtos.GetCategoriesResponseDto](
+			ctx,
+			NewGetCategories(),
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending GetCategories",
+			)
+		}
+
+		return c.JSON(http.StatusOK, queryResult)
+	}
+}
@@ -0,0 +1,85 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/datamodels"
+	dtoV1 "github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1"
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/gettingcategories/v1/dtos"
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/mapper"
+
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type getCategoriesHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewGetCategoriesHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*GetCategories, *dtos.GetCategoriesResponseDto] {
+	return &getCategoriesHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *getCategoriesHandler) RegisterHandler() error {
+	return mediatr.RegisterRequestHandler[*GetCategories, *dtos.GetCategoriesResponseDto](
+		c,
+	)
+}
+
+func (c *getCategoriesHandler) Handle(
+	ctx context.Context,
+	query *GetCategories,
+) (*dtos.GetCategoriesResponseDto, error) {
+	var dataModels []*datamodels.CategoryDataModel
+
+	err := c.CatalogsDBContext.DB().WithContext(ctx).Order("path").Find(&dataModels).Error
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in the fetching categories",
+		)
+	}
+
+	categories, err := mapper.Map[[]*models.Category](dataModels)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in the mapping Category")
+	}
+
+	categoryDtos, err := mapper.Map[[]*dtoV1.CategoryDto](categories)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in the mapping CategoryDto")
+	}
+
+	c.Log.Info("categories fetched")
+
+	return &dtos.GetCategoriesResponseDto{Categories: buildCategoryTree(categoryDtos)}, nil
+}
+
+// buildCategoryTree expects the categories ordered by path, so parents always come before their children
+func buildCategoryTree(categories []*dtoV1.CategoryDto) []*dtoV1.CategoryTreeDto {
+	roots := make([]*dtoV1.CategoryTreeDto, 0)
+	nodes := make(map[string]*dtoV1.CategoryTreeDto, len(categories))
+
+	for _, category := range categories {
+		node := &dtoV1.CategoryTreeDto{CategoryDto: category, Children: make([]*dtoV1.CategoryTreeDto, 0)}
+		nodes[category.Id.String()] = node
+
+		if category.ParentId == nil {
+			roots = append(roots, node)
+			continue
+		}
+
+		if parent, ok := nodes[category.ParentId.String()]; ok {
+			parent.Children = append(parent.Children, node)
+		}
+	}
+
+	return roots
+}
@@ -0,0 +1,6 @@
+package dtos
+
+type GetDeadLetterByIdRequestDto struct {
+	Queue     string `param:"queue"     json:"-"`
+	MessageId string `param:"messageId" json:"-"`
+}
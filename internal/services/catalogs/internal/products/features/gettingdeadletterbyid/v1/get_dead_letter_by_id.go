@@ -0,0 +1,44 @@
+package v1
+
+import (
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type GetDeadLetterById struct {
+	cqrs.Query
+	Queue     string
+	MessageId string
+}
+
+func NewGetDeadLetterById(queue string, messageId string) *GetDeadLetterById {
+	query := &GetDeadLetterById{
+		Query:     cqrs.NewQueryByT[GetDeadLetterById](),
+		Queue:     queue,
+		MessageId: messageId,
+	}
+
+	return query
+}
+
+func NewGetDeadLetterByIdWithValidation(queue string, messageId string) (*GetDeadLetterById, error) {
+	query := NewGetDeadLetterById(queue, messageId)
+	err := query.Validate()
+
+	return query, err
+}
+
+func (q *GetDeadLetterById) Validate() error {
+	err := validation.ValidateStruct(
+		q,
+		validation.Field(&q.Queue, validation.Required),
+		validation.Field(&q.MessageId, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/gettingdeadletterbyid/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/web/route"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type getDeadLetterByIdEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewGetDeadLetterByIdEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &getDeadLetterByIdEndpoint{ProductRouteParams: params}
+}
+
+func (ep *getDeadLetterByIdEndpoint) MapEndpoint() {
+	ep.DeadLettersGroup.GET("/:queue/:messageId", ep.handler())
+}
+
+// GetDeadLetterById
+// @Tags DeadLetters
+// @Summary Get dead-lettered message
+// @Description Inspect a dead-lettered message of a consumer queue with its failure headers
+// @Accept json
+// @Produce json
+// @Param queue path string true "Consumer queue"
+// @Param messageId path string true "Message ID"
+// @Success 200 {object} dtos.GetDeadLetterByIdResponseDto
+// @Security BearerAuth
+// @Router /api/v1/dead-letters/{queue}/{messageId} [get]
+func (ep *getDeadLetterByIdEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.GetDeadLetterByIdRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		query, err := NewGetDeadLetterByIdWithValidation(request.Queue, request.MessageId)
+		if err != nil {
+			return err
+		}
+
+		queryResult, err := mediatr.Send[*GetDeadLetterById, *dtos.GetDeadLetterByIdResponseDto](
+			ctx,
+			query,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending GetDeadLetterById",
+			)
+		}
+
+		return c.JSON(http.StatusOK, queryResult)
+	}
+}
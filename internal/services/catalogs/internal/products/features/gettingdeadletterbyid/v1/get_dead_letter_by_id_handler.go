@@ -0,0 +1,56 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	dtoV1 "github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1"
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/gettingdeadletterbyid/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/mapper"
+
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type getDeadLetterByIdHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewGetDeadLetterByIdHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*GetDeadLetterById, *dtos.GetDeadLetterByIdResponseDto] {
+	return &getDeadLetterByIdHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *getDeadLetterByIdHandler) RegisterHandler() error {
+	return mediatr.RegisterRequestHandler[*GetDeadLetterById, *dtos.GetDeadLetterByIdResponseDto](
+		c,
+	)
+}
+
+func (c *getDeadLetterByIdHandler) Handle(
+	ctx context.Context,
+	query *GetDeadLetterById,
+) (*dtos.GetDeadLetterByIdResponseDto, error) {
+	message, err := c.DeadLetterManager.GetMessage(ctx, query.Queue, query.MessageId)
+	if err != nil {
+		return nil, err
+	}
+
+	messageDto, err := mapper.Map[*dtoV1.DeadLetterMessageDto](message)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in the mapping DeadLetterMessageDto")
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf("dead-lettered message with id '%s' of the queue '%s' fetched", query.MessageId, query.Queue),
+		logger.Fields{"Queue": query.Queue, "MessageId": query.MessageId},
+	)
+
+	return &dtos.GetDeadLetterByIdResponseDto{Message: messageDto}, nil
+}
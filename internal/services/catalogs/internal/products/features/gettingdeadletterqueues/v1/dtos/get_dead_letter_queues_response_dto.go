@@ -0,0 +1,7 @@
+package dtos
+
+import dtoV1 "github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1"
+
+type GetDeadLetterQueuesResponseDto struct {
+	Queues []*dtoV1.DeadLetterQueueDto `json:"queues"`
+}
@@ -0,0 +1,13 @@
+package v1
+
+import (
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+)
+
+type GetDeadLetterQueues struct {
+	cqrs.Query
+}
+
+func NewGetDeadLetterQueues() *GetDeadLetterQueues {
+	return &GetDeadLetterQueues{Query: cqrs.NewQueryByT[GetDeadLetterQueues]()}
+}
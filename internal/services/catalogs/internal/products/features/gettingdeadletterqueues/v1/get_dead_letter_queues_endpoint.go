@@ -0,0 +1,55 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/gettingdeadletterqueues/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/web/route"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type getDeadLetterQueuesEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewGetDeadLetterQueuesEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &getDeadLetterQueuesEndpoint{ProductRouteParams: params}
+}
+
+func (ep *getDeadLetterQueuesEndpoint) MapEndpoint() {
+	ep.DeadLettersGroup.GET("", ep.handler())
+}
+
+// GetDeadLetterQueues
+// @Tags DeadLetters
+// @Summary Get dead-letter queues
+// @Description Get the consumer queues that have a dead-letter queue with the count of their dead-lettered messages
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.GetDeadLetterQueuesResponseDto
+// @Security BearerAuth
+// @Router /api/v1/dead-letters [get]
+func (ep *getDeadLetterQueuesEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		queryResult, err := mediatr.Send[*GetDeadLetterQueues, *dtos.GetDeadLetterQueuesResponseDto](
+			ctx,
+			NewGetDeadLetterQueues(),
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending GetDeadLetterQueues",
+			)
+		}
+
+		return c.JSON(http.StatusOK, queryResult)
+	}
+}
@@ -0,0 +1,54 @@
+package v1
+
+import (
+	"context"
+
+	dtoV1 "github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1"
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/gettingdeadletterqueues/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/mapper"
+
+	"github.com/mehdihadeli/go-mediatr"
+	"github.com/samber/lo"
+)
+
+type getDeadLetterQueuesHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewGetDeadLetterQueuesHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*GetDeadLetterQueues, *dtos.GetDeadLetterQueuesResponseDto] {
+	return &getDeadLetterQueuesHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *getDeadLetterQueuesHandler) RegisterHandler() error {
+	return mediatr.RegisterRequestHandler[*GetDeadLetterQueues, *dtos.GetDeadLetterQueuesResponseDto](
+		c,
+	)
+}
+
+func (c *getDeadLetterQueuesHandler) Handle(
+	ctx context.Context,
+	query *GetDeadLetterQueues,
+) (*dtos.GetDeadLetterQueuesResponseDto, error) {
+	queues, err := c.DeadLetterManager.GetQueues(ctx)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in fetching the dead-letter queues")
+	}
+
+	queueDtos, err := mapper.Map[[]*dtoV1.DeadLetterQueueDto](queues)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in the mapping DeadLetterQueueDto")
+	}
+
+	c.Log.Info("dead-letter queues fetched")
+
+	return &dtos.GetDeadLetterQueuesResponseDto{
+		Queues: lo.Ternary(queueDtos == nil, []*dtoV1.DeadLetterQueueDto{}, queueDtos),
+	}, nil
+}
@@ -0,0 +1,8 @@
+package dtos
+
+// GetDeadLettersRequestDto validation will handle in query level, `limit` is the number of the returned messages
+// from the head of the dead-letter queue
+type GetDeadLettersRequestDto struct {
+	Queue string `param:"queue" json:"-"`
+	Limit int    `query:"limit" json:"limit"`
+}
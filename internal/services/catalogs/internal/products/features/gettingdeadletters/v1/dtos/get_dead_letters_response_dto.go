@@ -0,0 +1,8 @@
+package dtos
+
+import dtoV1 "github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1"
+
+type GetDeadLettersResponseDto struct {
+	Queue    string                        `json:"queue"`
+	Messages []*dtoV1.DeadLetterMessageDto `json:"messages"`
+}
@@ -0,0 +1,51 @@
+package v1
+
+import (
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// defaultLimit number of the returned dead-lettered messages when the limit is not set
+const defaultLimit = 20
+
+type GetDeadLetters struct {
+	cqrs.Query
+	Queue string
+	Limit int
+}
+
+func NewGetDeadLetters(queue string, limit int) *GetDeadLetters {
+	if limit == 0 {
+		limit = defaultLimit
+	}
+
+	query := &GetDeadLetters{
+		Query: cqrs.NewQueryByT[GetDeadLetters](),
+		Queue: queue,
+		Limit: limit,
+	}
+
+	return query
+}
+
+func NewGetDeadLettersWithValidation(queue string, limit int) (*GetDeadLetters, error) {
+	query := NewGetDeadLetters(queue, limit)
+	err := query.Validate()
+
+	return query, err
+}
+
+func (q *GetDeadLetters) Validate() error {
+	err := validation.ValidateStruct(
+		q,
+		validation.Field(&q.Queue, validation.Required),
+		validation.Field(&q.Limit, validation.Min(1), validation.Max(1000)),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
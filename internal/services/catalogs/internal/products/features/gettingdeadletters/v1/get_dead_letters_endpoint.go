@@ -0,0 +1,73 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/gettingdeadletters/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/web/route"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type getDeadLettersEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewGetDeadLettersEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &getDeadLettersEndpoint{ProductRouteParams: params}
+}
+
+func (ep *getDeadLettersEndpoint) MapEndpoint() {
+	ep.DeadLettersGroup.GET("/:queue", ep.handler())
+}
+
+// GetDeadLetters
+// @Tags DeadLetters
+// @Summary Get dead-lettered messages
+// @Description Get the dead-lettered messages of a consumer queue without removing them
+// @Accept json
+// @Produce json
+// @Param queue path string true "Consumer queue"
+// @Param limit query int false "Number of the returned messages"
+// @Success 200 {object} dtos.GetDeadLettersResponseDto
+// @Security BearerAuth
+// @Router /api/v1/dead-letters/{queue} [get]
+func (ep *getDeadLettersEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.GetDeadLettersRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		query, err := NewGetDeadLettersWithValidation(request.Queue, request.Limit)
+		if err != nil {
+			return err
+		}
+
+		queryResult, err := mediatr.Send[*GetDeadLetters, *dtos.GetDeadLettersResponseDto](
+			ctx,
+			query,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending GetDeadLetters",
+			)
+		}
+
+		return c.JSON(http.StatusOK, queryResult)
+	}
+}
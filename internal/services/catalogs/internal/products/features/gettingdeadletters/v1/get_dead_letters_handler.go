@@ -0,0 +1,60 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	dtoV1 "github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1"
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/gettingdeadletters/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/mapper"
+
+	"github.com/mehdihadeli/go-mediatr"
+	"github.com/samber/lo"
+)
+
+type getDeadLettersHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewGetDeadLettersHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*GetDeadLetters, *dtos.GetDeadLettersResponseDto] {
+	return &getDeadLettersHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *getDeadLettersHandler) RegisterHandler() error {
+	return mediatr.RegisterRequestHandler[*GetDeadLetters, *dtos.GetDeadLettersResponseDto](
+		c,
+	)
+}
+
+func (c *getDeadLettersHandler) Handle(
+	ctx context.Context,
+	query *GetDeadLetters,
+) (*dtos.GetDeadLettersResponseDto, error) {
+	messages, err := c.DeadLetterManager.GetMessages(ctx, query.Queue, query.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	messageDtos, err := mapper.Map[[]*dtoV1.DeadLetterMessageDto](messages)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in the mapping DeadLetterMessageDto")
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf("dead-lettered messages of the queue '%s' fetched", query.Queue),
+		logger.Fields{"Queue": query.Queue, "Count": len(messageDtos)},
+	)
+
+	return &dtos.GetDeadLettersResponseDto{
+		Queue:    query.Queue,
+		Messages: lo.Ternary(messageDtos == nil, []*dtoV1.DeadLetterMessageDto{}, messageDtos),
+	}, nil
+}
@@ -0,0 +1,9 @@
+package dtos
+
+import (
+	uuid "github.com/satori/go.uuid"
+)
+
+type GetEditionProvenanceRequestDto struct {
+	EditionID uuid.UUID `param:"id" json:"-"`
+}
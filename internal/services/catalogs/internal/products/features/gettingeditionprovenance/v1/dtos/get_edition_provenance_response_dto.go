@@ -0,0 +1,12 @@
+package dtos
+
+import (
+	dtoV1 "github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1"
+)
+
+// GetEditionProvenanceResponseDto `history` is the provenance of the edition ordered by its version from the mint, the
+// history is projected from the events of the edition so it can miss the latest changes of `edition` for a moment
+type GetEditionProvenanceResponseDto struct {
+	Edition *dtoV1.EditionDto                  `json:"edition"`
+	History []*dtoV1.EditionProvenanceEntryDto `json:"history"`
+}
@@ -0,0 +1,42 @@
+package v1
+
+import (
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+type GetEditionProvenance struct {
+	cqrs.Query
+	EditionID uuid.UUID
+}
+
+func NewGetEditionProvenance(editionID uuid.UUID) *GetEditionProvenance {
+	query := &GetEditionProvenance{
+		Query:     cqrs.NewQueryByT[GetEditionProvenance](),
+		EditionID: editionID,
+	}
+
+	return query
+}
+
+func NewGetEditionProvenanceWithValidation(editionID uuid.UUID) (*GetEditionProvenance, error) {
+	query := NewGetEditionProvenance(editionID)
+	err := query.Validate()
+
+	return query, err
+}
+
+func (q *GetEditionProvenance) Validate() error {
+	err := validation.ValidateStruct(
+		q,
+		validation.Field(&q.EditionID, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/gettingeditionprovenance/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/web/route"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type getEditionProvenanceEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewGetEditionProvenanceEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &getEditionProvenanceEndpoint{ProductRouteParams: params}
+}
+
+func (ep *getEditionProvenanceEndpoint) MapEndpoint() {
+	ep.EditionsGroup.GET("/:id/provenance", ep.handler())
+}
+
+// GetEditionProvenance
+// @Tags Editions
+// @Summary Get edition provenance
+// @Description Get the current state of the edition and its full provenance from the mint, ordered by the version of the edition
+// @Accept json
+// @Produce json
+// @Param id path string true "Edition ID"
+// @Success 200 {object} dtos.GetEditionProvenanceResponseDto
+// @Router /api/v1/editions/{id}/provenance [get]
+func (ep *getEditionProvenanceEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.GetEditionProvenanceRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		query, err := NewGetEditionProvenanceWithValidation(request.EditionID)
+		if err != nil {
+			return err
+		}
+
+		queryResult, err := mediatr.Send[*GetEditionProvenance, *dtos.GetEditionProvenanceResponseDto](
+			ctx,
+			query,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending GetEditionProvenance",
+			)
+		}
+
+		return c.JSON(http.StatusOK, queryResult)
+	}
+}
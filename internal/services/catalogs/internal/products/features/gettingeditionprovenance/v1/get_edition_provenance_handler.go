@@ -0,0 +1,78 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/datamodels"
+	dtoV1 "github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1"
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/gettingeditionprovenance/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/mapper"
+
+	"github.com/mehdihadeli/go-mediatr"
+	"github.com/samber/lo"
+)
+
+type getEditionProvenanceHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewGetEditionProvenanceHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*GetEditionProvenance, *dtos.GetEditionProvenanceResponseDto] {
+	return &getEditionProvenanceHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *getEditionProvenanceHandler) RegisterHandler() error {
+	return mediatr.RegisterRequestHandler[*GetEditionProvenance, *dtos.GetEditionProvenanceResponseDto](
+		c,
+	)
+}
+
+// Handle returns the current state of the edition from its events and its provenance from the provenance read model
+func (c *getEditionProvenanceHandler) Handle(
+	ctx context.Context,
+	query *GetEditionProvenance,
+) (*dtos.GetEditionProvenanceResponseDto, error) {
+	edition, err := c.EditionAggregateStore.Load(ctx, query.EditionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var history []*datamodels.EditionProvenanceDataModel
+	err = c.CatalogsDBContext.DB().
+		WithContext(ctx).
+		Where("edition_id = ?", query.EditionID).
+		Order("version").
+		Find(&history).
+		Error
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in fetching the edition provenance")
+	}
+
+	editionDto, err := mapper.Map[*dtoV1.EditionDto](edition)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in the mapping EditionDto")
+	}
+
+	historyDtos, err := mapper.Map[[]*dtoV1.EditionProvenanceEntryDto](history)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in the mapping edition provenance")
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf("provenance of the edition with id '%s' fetched", query.EditionID),
+		logger.Fields{"Id": query.EditionID.String()},
+	)
+
+	return &dtos.GetEditionProvenanceResponseDto{
+		Edition: editionDto,
+		History: lo.Ternary(historyDtos == nil, []*dtoV1.EditionProvenanceEntryDto{}, historyDtos),
+	}, nil
+}
@@ -0,0 +1,15 @@
+package dtos
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// GetPriceTimelineRequestDto validation will handle in query level, `from` and `to` are optional RFC3339 times that
+// limit the price history
+type GetPriceTimelineRequestDto struct {
+	ProductId uuid.UUID  `param:"id"   json:"-"`
+	From      *time.Time `query:"from" json:"from"`
+	To        *time.Time `query:"to"   json:"to"`
+}
@@ -0,0 +1,18 @@
+package dtos
+
+import (
+	dtoV1 "github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
+)
+
+// GetPriceTimelineResponseDto `history` is ordered by the effective time and `scheduled` keeps the pending price
+// changes ordered by their apply time
+type GetPriceTimelineResponseDto struct {
+	ProductId uuid.UUID                        `json:"productId"`
+	Price     decimal.Decimal                  `json:"price"`
+	Currency  string                           `json:"currency"`
+	History   []*dtoV1.ProductPriceDto         `json:"history"`
+	Scheduled []*dtoV1.ProductPriceScheduleDto `json:"scheduled"`
+}
@@ -0,0 +1,56 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+type GetPriceTimeline struct {
+	cqrs.Query
+	ProductID uuid.UUID
+	From      *time.Time
+	To        *time.Time
+}
+
+func NewGetPriceTimeline(productId uuid.UUID, from *time.Time, to *time.Time) *GetPriceTimeline {
+	query := &GetPriceTimeline{
+		Query:     cqrs.NewQueryByT[GetPriceTimeline](),
+		ProductID: productId,
+		From:      from,
+		To:        to,
+	}
+
+	return query
+}
+
+func NewGetPriceTimelineWithValidation(
+	productId uuid.UUID,
+	from *time.Time,
+	to *time.Time,
+) (*GetPriceTimeline, error) {
+	query := NewGetPriceTimeline(productId, from, to)
+	err := query.Validate()
+
+	return query, err
+}
+
+func (p *GetPriceTimeline) Validate() error {
+	err := validation.ValidateStruct(
+		p,
+		validation.Field(&p.ProductID, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	if p.From != nil && p.To != nil && p.From.After(*p.To) {
+		return customErrors.NewValidationError("from can't be after to")
+	}
+
+	return nil
+}
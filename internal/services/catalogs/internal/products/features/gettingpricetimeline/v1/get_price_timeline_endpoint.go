@@ -0,0 +1,73 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/gettingpricetimeline/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/web/route"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type getPriceTimelineEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewGetPriceTimelineEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &getPriceTimelineEndpoint{ProductRouteParams: params}
+}
+
+func (ep *getPriceTimelineEndpoint) MapEndpoint() {
+	ep.ProductsGroup.GET("/:id/prices", ep.handler())
+}
+
+// GetPriceTimeline
+// @Tags Products
+// @Summary Get product price timeline
+// @Description Get the current price, the price history and the pending scheduled price changes of the product
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param from query string false "Start of the price history in RFC3339"
+// @Param to query string false "End of the price history in RFC3339"
+// @Success 200 {object} dtos.GetPriceTimelineResponseDto
+// @Router /api/v1/products/{id}/prices [get]
+func (ep *getPriceTimelineEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.GetPriceTimelineRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		query, err := NewGetPriceTimelineWithValidation(request.ProductId, request.From, request.To)
+		if err != nil {
+			return err
+		}
+
+		queryResult, err := mediatr.Send[*GetPriceTimeline, *dtos.GetPriceTimelineResponseDto](
+			ctx,
+			query,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending GetPriceTimeline",
+			)
+		}
+
+		return c.JSON(http.StatusOK, queryResult)
+	}
+}
@@ -0,0 +1,108 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/datamodels"
+	dtoV1 "github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1"
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/gettingpricetimeline/v1/dtos"
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/mapper"
+	"github.com/reoden/go-NFT/pkg/postgresgorm/gormdbcontext"
+
+	"github.com/mehdihadeli/go-mediatr"
+	"github.com/samber/lo"
+)
+
+type getPriceTimelineHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewGetPriceTimelineHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*GetPriceTimeline, *dtos.GetPriceTimelineResponseDto] {
+	return &getPriceTimelineHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *getPriceTimelineHandler) RegisterHandler() error {
+	return mediatr.RegisterRequestHandler[*GetPriceTimeline, *dtos.GetPriceTimelineResponseDto](
+		c,
+	)
+}
+
+func (c *getPriceTimelineHandler) Handle(
+	ctx context.Context,
+	query *GetPriceTimeline,
+) (*dtos.GetPriceTimelineResponseDto, error) {
+	product, err := gormdbcontext.FindModelByID[*datamodels.ProductDataModel, *models.Product](
+		ctx,
+		c.CatalogsDBContext,
+		query.ProductID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	db := c.CatalogsDBContext.DB().WithContext(ctx)
+
+	historyQuery := db.Where("product_id = ?", query.ProductID)
+	if query.From != nil {
+		historyQuery = historyQuery.Where("effective_at >= ?", *query.From)
+	}
+	if query.To != nil {
+		historyQuery = historyQuery.Where("effective_at <= ?", *query.To)
+	}
+
+	var history []*datamodels.ProductPriceDataModel
+	if err := historyQuery.Order("effective_at, created_at").Find(&history).Error; err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in fetching the product price history")
+	}
+
+	var scheduled []*datamodels.ProductPriceScheduleDataModel
+	err = db.Where("product_id = ? AND status = ?", query.ProductID, models.PendingPriceScheduleStatus).
+		Order("apply_at").
+		Find(&scheduled).Error
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in fetching the scheduled price changes")
+	}
+
+	historyDtos, err := mapPrices[*models.ProductPrice, *dtoV1.ProductPriceDto](history)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in the mapping product prices")
+	}
+
+	scheduledDtos, err := mapPrices[*models.ProductPriceSchedule, *dtoV1.ProductPriceScheduleDto](scheduled)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in the mapping scheduled price changes")
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf("price timeline of the product with id '%s' fetched", query.ProductID),
+		logger.Fields{"Id": query.ProductID.String()},
+	)
+
+	return &dtos.GetPriceTimelineResponseDto{
+		ProductId: product.Id,
+		Price:     product.Price,
+		Currency:  product.Currency,
+		History:   lo.Ternary(historyDtos == nil, []*dtoV1.ProductPriceDto{}, historyDtos),
+		Scheduled: lo.Ternary(scheduledDtos == nil, []*dtoV1.ProductPriceScheduleDto{}, scheduledDtos),
+	}, nil
+}
+
+// mapPrices maps the data models to their models and then to their dtos
+func mapPrices[TModel any, TDto any, TDataModel any](dataModels []TDataModel) ([]TDto, error) {
+	items, err := mapper.Map[[]TModel](dataModels)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.Map[[]TDto](items)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
 	"github.com/reoden/go-NFT/catalogs/internal/products/features/gettingproductbyid/v1/dtos"
 	"github.com/reoden/go-NFT/pkg/core/web/route"
+	"github.com/reoden/go-NFT/pkg/http/customecho/etag"
 	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
 
 	"emperror.dev/errors"
@@ -35,6 +36,7 @@ func (ep *getProductByIdEndpoint) MapEndpoint() {
 // @Produce json
 // @Param id path string true "Product ID"
 // @Success 200 {object} dtos.GetProductByIdResponseDto
+// @Header 200 {string} ETag "ETag of the product version, it is sent back in the `If-Match` header of the updates"
 // @Security BearerAuth
 // @Router /api/v1/products/{id} [get]
 func (ep *getProductByIdEndpoint) handler() echo.HandlerFunc {
@@ -66,6 +68,9 @@ func (ep *getProductByIdEndpoint) handler() echo.HandlerFunc {
 				"error in sending GetProductById",
 			)
 		}
+
+		etag.SetVersionETag(c, queryResult.Product.Version)
+
 		return c.JSON(http.StatusOK, queryResult)
 	}
 }
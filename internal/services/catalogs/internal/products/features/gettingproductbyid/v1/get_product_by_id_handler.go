@@ -4,18 +4,19 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/reoden/go-NFT/catalogs/internal/products/data/datamodels"
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/associations"
 	dtoV1 "github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1"
 	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
 	"github.com/reoden/go-NFT/catalogs/internal/products/features/gettingproductbyid/v1/dtos"
+	"github.com/reoden/go-NFT/catalogs/internal/products/media"
 	"github.com/reoden/go-NFT/catalogs/internal/products/models"
 	"github.com/reoden/go-NFT/pkg/core/cqrs"
 	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
 	"github.com/reoden/go-NFT/pkg/logger"
 	"github.com/reoden/go-NFT/pkg/mapper"
-	"github.com/reoden/go-NFT/pkg/postgresgorm/gormdbcontext"
 
 	"github.com/mehdihadeli/go-mediatr"
+	uuid "github.com/satori/go.uuid"
 )
 
 type GetProductByIDHandler struct {
@@ -40,13 +41,26 @@ func (c *GetProductByIDHandler) Handle(
 	ctx context.Context,
 	query *GetProductById,
 ) (*dtos.GetProductByIdResponseDto, error) {
-	product, err := gormdbcontext.FindModelByID[*datamodels.ProductDataModel, *models.Product](
+	products, err := associations.FindProductsWithAssociations(
 		ctx,
-		c.CatalogsDBContext,
-		query.ProductID,
+		c.CatalogsDBContext.DB(),
+		[]uuid.UUID{query.ProductID},
 	)
 	if err != nil {
-		return nil, err
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in fetching the product")
+	}
+	if len(products) == 0 {
+		return nil, customErrors.NewNotFoundError(
+			fmt.Sprintf("product with id `%s` not found in the database", query.ProductID),
+		)
+	}
+
+	product, err := mapper.Map[*models.Product](products[0])
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in the mapping product",
+		)
 	}
 
 	productDto, err := mapper.Map[*dtoV1.ProductDto](product)
@@ -57,6 +71,14 @@ func (c *GetProductByIDHandler) Handle(
 		)
 	}
 
+	err = media.SignProductsMediaUrls(ctx, c.ObjectStore, c.StorageOptions, productDto)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in signing product media urls",
+		)
+	}
+
 	c.Log.Infow(
 		fmt.Sprintf(
 			"product with id: {%s} fetched",
@@ -6,7 +6,8 @@ import "github.com/reoden/go-NFT/pkg/utils"
 // https://echo.labstack.com/guide/request/
 // https://github.com/go-playground/validator
 
-// GetProductsRequestDto validation will handle in command level
+// GetProductsRequestDto validation will handle in command level, besides the product columns, filters support the
+// `category` field with a category slug, which also matches its sub-categories, and the `tag` field with a tag name
 type GetProductsRequestDto struct {
 	*utils.ListQuery
 }
@@ -8,4 +8,11 @@ import (
 // https://echo.labstack.com/guide/response/
 type GetProductsResponseDto struct {
 	Products *utils.ListResult[*dtoV1.ProductDto]
+	Facets   *ProductsFacetsDto `json:"facets"`
+}
+
+// ProductsFacetsDto number of the filtered products in each category, including its sub-categories, and in each tag
+type ProductsFacetsDto struct {
+	Categories []*dtoV1.FacetBucketDto `json:"categories"`
+	Tags       []*dtoV1.FacetBucketDto `json:"tags"`
 }
@@ -6,6 +6,13 @@ import (
 
 // Ref: https://golangbot.com/inheritance/
 
+const (
+	// CategoryFilterField filters the products by a category slug, or comma separated slugs with the `in` comparison
+	CategoryFilterField = "category"
+	// TagFilterField filters the products by a tag name, or comma separated names with the `in` comparison
+	TagFilterField = "tag"
+)
+
 type GetProducts struct {
 	*utils.ListQuery
 }
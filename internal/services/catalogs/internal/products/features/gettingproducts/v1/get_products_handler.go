@@ -2,18 +2,24 @@ package v1
 
 import (
 	"context"
+	"strings"
 
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/associations"
 	datamodel "github.com/reoden/go-NFT/catalogs/internal/products/data/datamodels"
 	dtosv1 "github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1"
 	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
 	"github.com/reoden/go-NFT/catalogs/internal/products/features/gettingproducts/v1/dtos"
+	"github.com/reoden/go-NFT/catalogs/internal/products/media"
 	"github.com/reoden/go-NFT/catalogs/internal/products/models"
 	"github.com/reoden/go-NFT/pkg/core/cqrs"
 	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
-	"github.com/reoden/go-NFT/pkg/postgresgorm/helpers/gormextensions"
+	"github.com/reoden/go-NFT/pkg/mapper"
+	"github.com/reoden/go-NFT/pkg/postgresgorm/scopes"
 	"github.com/reoden/go-NFT/pkg/utils"
 
 	"github.com/mehdihadeli/go-mediatr"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
 )
 
 type getProductsHandler struct {
@@ -38,11 +44,26 @@ func (c *getProductsHandler) Handle(
 	ctx context.Context,
 	query *GetProducts,
 ) (*dtos.GetProductsResponseDto, error) {
-	products, err := gormextensions.Paginate[*datamodel.ProductDataModel, *models.Product](
-		ctx,
-		query.ListQuery,
-		c.CatalogsDBContext.DB(),
-	)
+	db := c.CatalogsDBContext.DB().WithContext(ctx)
+
+	filteredProducts := func() *gorm.DB {
+		return db.Model(&datamodel.ProductDataModel{}).Scopes(filterProducts(query.Filters))
+	}
+
+	var totalItems int64
+	if err := filteredProducts().Count(&totalItems).Error; err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in counting products",
+		)
+	}
+
+	var dataModels []*datamodel.ProductDataModel
+	err := associations.PreloadAssociations(filteredProducts()).
+		Offset(query.GetOffset()).
+		Limit(query.GetLimit()).
+		Order(query.GetOrderBy()).
+		Find(&dataModels).Error
 	if err != nil {
 		return nil, customErrors.NewApplicationErrorWrap(
 			err,
@@ -50,8 +71,16 @@ func (c *getProductsHandler) Handle(
 		)
 	}
 
+	products, err := mapper.Map[[]*models.Product](dataModels)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in the mapping",
+		)
+	}
+
 	listResultDto, err := utils.ListResultToListResultDto[*dtosv1.ProductDto](
-		products,
+		utils.NewListResult(products, query.GetSize(), query.GetPage(), totalItems),
 	)
 	if err != nil {
 		return nil, customErrors.NewApplicationErrorWrap(
@@ -60,7 +89,110 @@ func (c *getProductsHandler) Handle(
 		)
 	}
 
+	err = media.SignProductsMediaUrls(ctx, c.ObjectStore, c.StorageOptions, listResultDto.Items...)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in signing products media urls",
+		)
+	}
+
+	facets, err := c.getFacets(db, filteredProducts().Select("products.id"))
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in the fetching products facets",
+		)
+	}
+
 	c.Log.Info("products fetched")
 
-	return &dtos.GetProductsResponseDto{Products: listResultDto}, nil
+	return &dtos.GetProductsResponseDto{Products: listResultDto, Facets: facets}, nil
+}
+
+// getFacets counts the filtered products of each category, a product in a sub-category is also counted for its ancestors
+func (c *getProductsHandler) getFacets(
+	db *gorm.DB,
+	productIds *gorm.DB,
+) (*dtos.ProductsFacetsDto, error) {
+	var categories []*models.FacetBucket
+
+	err := db.Table("categories c").
+		Select(`c.slug AS "key", COUNT(DISTINCT pc.product_id) AS "count"`).
+		Joins("JOIN categories d ON d.path = c.path OR d.path LIKE c.path || ?", models.CategoryPathSeparator+"%").
+		Joins("JOIN product_categories pc ON pc.category_id = d.id").
+		Where("pc.product_id IN (?)", productIds).
+		Group("c.slug").
+		Order(`"count" DESC, c.slug`).
+		Scan(&categories).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []*models.FacetBucket
+
+	err = db.Table("tags t").
+		Select(`t.name AS "key", COUNT(DISTINCT pt.product_id) AS "count"`).
+		Joins("JOIN product_tags pt ON pt.tag_id = t.id").
+		Where("pt.product_id IN (?)", productIds).
+		Group("t.name").
+		Order(`"count" DESC, t.name`).
+		Scan(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+
+	categoryDtos, err := mapper.Map[[]*dtosv1.FacetBucketDto](categories)
+	if err != nil {
+		return nil, err
+	}
+
+	tagDtos, err := mapper.Map[[]*dtosv1.FacetBucketDto](tags)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dtos.ProductsFacetsDto{
+		Categories: lo.Ternary(categoryDtos == nil, []*dtosv1.FacetBucketDto{}, categoryDtos),
+		Tags:       lo.Ternary(tagDtos == nil, []*dtosv1.FacetBucketDto{}, tagDtos),
+	}, nil
+}
+
+// filterProducts applies the `category` and `tag` filters through the product associations and the other filters on the product columns
+func filterProducts(filters []*utils.FilterModel) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		var columnFilters []*utils.FilterModel
+
+		for _, filter := range filters {
+			values := lo.Compact(lo.Map(strings.Split(filter.Value, ","), func(v string, _ int) string {
+				return strings.TrimSpace(v)
+			}))
+			if filter.Comparison != "in" {
+				values = []string{strings.TrimSpace(filter.Value)}
+			}
+
+			switch filter.Field {
+			case CategoryFilterField:
+				db = db.Where(
+					`products.id IN (SELECT pc.product_id FROM product_categories pc
+						JOIN categories c ON c.id = pc.category_id
+						JOIN categories f ON c.path = f.path OR c.path LIKE f.path || ?
+						WHERE f.slug IN ?)`,
+					models.CategoryPathSeparator+"%",
+					values,
+				)
+			case TagFilterField:
+				db = db.Where(
+					`products.id IN (SELECT pt.product_id FROM product_tags pt
+						JOIN tags t ON t.id = pt.tag_id
+						WHERE t.name IN ?)`,
+					lo.Map(values, func(v string, _ int) string { return models.NormalizeTagName(v) }),
+				)
+			default:
+				columnFilters = append(columnFilters, filter)
+			}
+		}
+
+		return scopes.Filter(columnFilters)(db)
+	}
 }
@@ -0,0 +1,5 @@
+package dtos
+
+type GetProjectionRebuildProgressRequestDto struct {
+	ProjectionName string `param:"projectionName" json:"-"`
+}
@@ -0,0 +1,41 @@
+package v1
+
+import (
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type GetProjectionRebuildProgress struct {
+	cqrs.Query
+	ProjectionName string
+}
+
+func NewGetProjectionRebuildProgress(projectionName string) *GetProjectionRebuildProgress {
+	query := &GetProjectionRebuildProgress{
+		Query:          cqrs.NewQueryByT[GetProjectionRebuildProgress](),
+		ProjectionName: projectionName,
+	}
+
+	return query
+}
+
+func NewGetProjectionRebuildProgressWithValidation(projectionName string) (*GetProjectionRebuildProgress, error) {
+	query := NewGetProjectionRebuildProgress(projectionName)
+	err := query.Validate()
+
+	return query, err
+}
+
+func (q *GetProjectionRebuildProgress) Validate() error {
+	err := validation.ValidateStruct(
+		q,
+		validation.Field(&q.ProjectionName, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
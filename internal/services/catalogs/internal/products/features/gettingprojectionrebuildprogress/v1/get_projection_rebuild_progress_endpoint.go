@@ -0,0 +1,72 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/gettingprojectionrebuildprogress/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/web/route"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type getProjectionRebuildProgressEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewGetProjectionRebuildProgressEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &getProjectionRebuildProgressEndpoint{ProductRouteParams: params}
+}
+
+func (ep *getProjectionRebuildProgressEndpoint) MapEndpoint() {
+	ep.ProjectionsGroup.GET("/:projectionName/rebuild", ep.handler())
+}
+
+// GetProjectionRebuildProgress
+// @Tags Projections
+// @Summary Get projection rebuild progress
+// @Description Get the progress of the last rebuild of a projection
+// @Accept json
+// @Produce json
+// @Param projectionName path string true "Projection name"
+// @Success 200 {object} dtos.GetProjectionRebuildProgressResponseDto
+// @Security BearerAuth
+// @Router /api/v1/projections/{projectionName}/rebuild [get]
+func (ep *getProjectionRebuildProgressEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.GetProjectionRebuildProgressRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		query, err := NewGetProjectionRebuildProgressWithValidation(request.ProjectionName)
+		if err != nil {
+			return err
+		}
+
+		queryResult, err := mediatr.Send[*GetProjectionRebuildProgress, *dtos.GetProjectionRebuildProgressResponseDto](
+			ctx,
+			query,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending GetProjectionRebuildProgress",
+			)
+		}
+
+		return c.JSON(http.StatusOK, queryResult)
+	}
+}
@@ -0,0 +1,52 @@
+package v1
+
+import (
+	"context"
+
+	dtoV1 "github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1"
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/gettingprojectionrebuildprogress/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/mapper"
+
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type getProjectionRebuildProgressHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewGetProjectionRebuildProgressHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*GetProjectionRebuildProgress, *dtos.GetProjectionRebuildProgressResponseDto] {
+	return &getProjectionRebuildProgressHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *getProjectionRebuildProgressHandler) RegisterHandler() error {
+	return mediatr.RegisterRequestHandler[*GetProjectionRebuildProgress, *dtos.GetProjectionRebuildProgressResponseDto](
+		c,
+	)
+}
+
+func (c *getProjectionRebuildProgressHandler) Handle(
+	ctx context.Context,
+	query *GetProjectionRebuildProgress,
+) (*dtos.GetProjectionRebuildProgressResponseDto, error) {
+	progress, err := c.ProjectionRebuilder.Progress(query.ProjectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	progressDto, err := mapper.Map[*dtoV1.ProjectionRebuildProgressDto](progress)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in the mapping ProjectionRebuildProgressDto",
+		)
+	}
+
+	return &dtos.GetProjectionRebuildProgressResponseDto{Progress: progressDto}, nil
+}
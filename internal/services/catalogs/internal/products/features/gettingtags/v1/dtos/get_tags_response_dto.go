@@ -0,0 +1,9 @@
+package dtos
+
+import (
+	dtoV1 "github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1"
+)
+
+type GetTagsResponseDto struct {
+	Tags []*dtoV1.TagDto `json:"tags"`
+}
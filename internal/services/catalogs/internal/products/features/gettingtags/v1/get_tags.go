@@ -0,0 +1,13 @@
+package v1
+
+import (
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+)
+
+type GetTags struct {
+	cqrs.Query
+}
+
+func NewGetTags() *GetTags {
+	return &GetTags{Query: cqrs.NewQueryByT[GetTags]()}
+}
@@ -0,0 +1,54 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/gettingtags/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/web/route"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type getTagsEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewGetTagsEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &getTagsEndpoint{ProductRouteParams: params}
+}
+
+func (ep *getTagsEndpoint) MapEndpoint() {
+	ep.TagsGroup.GET("", ep.handler())
+}
+
+// GetTags
+// @Tags Tags
+// @Summary Get tags
+// @Description Get all tags
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.GetTagsResponseDto
+// @Router /api/v1/tags [get]
+func (ep *getTagsEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		queryResult, err := mediatr.Send[*GetTags, *dtos.GetTagsResponseDto](
+			ctx,
+			NewGetTags(),
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending GetTags",
+			)
+		}
+
+		return c.JSON(http.StatusOK, queryResult)
+	}
+}
@@ -0,0 +1,63 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/datamodels"
+	dtoV1 "github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1"
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/gettingtags/v1/dtos"
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/mapper"
+
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type getTagsHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewGetTagsHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*GetTags, *dtos.GetTagsResponseDto] {
+	return &getTagsHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *getTagsHandler) RegisterHandler() error {
+	return mediatr.RegisterRequestHandler[*GetTags, *dtos.GetTagsResponseDto](
+		c,
+	)
+}
+
+func (c *getTagsHandler) Handle(
+	ctx context.Context,
+	query *GetTags,
+) (*dtos.GetTagsResponseDto, error) {
+	var dataModels []*datamodels.TagDataModel
+
+	err := c.CatalogsDBContext.DB().WithContext(ctx).Order("name").Find(&dataModels).Error
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in the fetching tags",
+		)
+	}
+
+	tags, err := mapper.Map[[]*models.Tag](dataModels)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in the mapping Tag")
+	}
+
+	tagDtos, err := mapper.Map[[]*dtoV1.TagDto](tags)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in the mapping TagDto")
+	}
+
+	c.Log.Info("tags fetched")
+
+	return &dtos.GetTagsResponseDto{Tags: tagDtos}, nil
+}
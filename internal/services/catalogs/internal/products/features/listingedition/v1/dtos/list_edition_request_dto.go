@@ -0,0 +1,15 @@
+package dtos
+
+import (
+	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ListEditionRequestDto validation will handle in command level
+type ListEditionRequestDto struct {
+	EditionID uuid.UUID `json:"-" param:"id"`
+	// Price exact amount of the listing price, it can be sent as a number or a string like `"0.75"`
+	Price decimal.Decimal `json:"price"`
+	// Currency currency code of the listing price like `ETH`
+	Currency string `json:"currency"`
+}
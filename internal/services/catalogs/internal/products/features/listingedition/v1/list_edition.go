@@ -0,0 +1,65 @@
+package v1
+
+import (
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ListEdition lists the edition for sale with the price by its owner
+type ListEdition struct {
+	cqrs.Command
+	EditionID uuid.UUID
+	Seller    string
+	Price     decimal.Decimal
+	Currency  string
+}
+
+func NewListEdition(
+	editionID uuid.UUID,
+	seller string,
+	price decimal.Decimal,
+	currency string,
+) *ListEdition {
+	command := &ListEdition{
+		Command:   cqrs.NewCommandByT[ListEdition](),
+		EditionID: editionID,
+		Seller:    seller,
+		Price:     price,
+		Currency:  models.NormalizeCurrency(currency),
+	}
+
+	return command
+}
+
+// NewListEditionWithValidation lists the edition for sale with the price by its owner with inline validation - for defensive programming and ensuring validation even without using middleware
+func NewListEditionWithValidation(
+	editionID uuid.UUID,
+	seller string,
+	price decimal.Decimal,
+	currency string,
+) (*ListEdition, error) {
+	command := NewListEdition(editionID, seller, price, currency)
+	err := command.Validate()
+
+	return command, err
+}
+
+func (c *ListEdition) Validate() error {
+	err := validation.ValidateStruct(
+		c,
+		validation.Field(&c.EditionID, validation.Required),
+		validation.Field(&c.Seller, validation.Required),
+		validation.Field(&c.Price, models.PositiveAmount),
+		validation.Field(&c.Currency, validation.Required, validation.Match(models.CurrencyRegex)),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
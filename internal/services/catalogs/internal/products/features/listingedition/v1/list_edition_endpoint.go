@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/listingedition/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/web/route"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/utils"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type listEditionEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewListEditionEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &listEditionEndpoint{ProductRouteParams: params}
+}
+
+func (ep *listEditionEndpoint) MapEndpoint() {
+	ep.EditionsGroup.POST("/:id/list", ep.handler())
+}
+
+// ListEdition
+// @Tags Editions
+// @Summary List edition for sale
+// @Description List the edition for sale with the price by its owner, a frozen or burned edition cannot be listed
+// @Accept json
+// @Produce json
+// @Param ListEditionRequestDto body dtos.ListEditionRequestDto true "List data"
+// @Param id path string true "Edition ID"
+// @Success 200 {object} dtos.ListEditionResponseDto
+// @Security BearerAuth
+// @Router /api/v1/editions/{id}/list [post]
+func (ep *listEditionEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.ListEditionRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		// the owner is the authenticated user, not a value of the request
+		_, userId, err := utils.ParseJWTToken(c)
+		if err != nil {
+			return customErrors.NewUnAuthorizedErrorWrap(
+				err,
+				"error in reading the user of the token",
+			)
+		}
+
+		command, err := NewListEditionWithValidation(
+			request.EditionID,
+			userId.String(),
+			request.Price,
+			request.Currency,
+		)
+		if err != nil {
+			return err
+		}
+
+		result, err := mediatr.Send[*ListEdition, *dtos.ListEditionResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending ListEdition",
+			)
+		}
+
+		return c.JSON(http.StatusOK, result)
+	}
+}
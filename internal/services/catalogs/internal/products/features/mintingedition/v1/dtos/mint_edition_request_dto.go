@@ -0,0 +1,16 @@
+package dtos
+
+import (
+	uuid "github.com/satori/go.uuid"
+)
+
+// MintEditionRequestDto validation will handle in command level
+type MintEditionRequestDto struct {
+	ProductID uuid.UUID `json:"productId"`
+	// TokenID the id of the token of edition on the chain
+	TokenID string `json:"tokenId"`
+	// EditionNumber the number of edition in the editions of the product, it starts from 1
+	EditionNumber int `json:"editionNumber"`
+	// Owner the user id of the first owner of edition
+	Owner string `json:"owner"`
+}
@@ -0,0 +1,15 @@
+package dtos
+
+import (
+	"github.com/reoden/go-NFT/pkg/core/serializer/json"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type MintEditionResponseDto struct {
+	EditionID uuid.UUID `json:"editionId"`
+}
+
+func (c *MintEditionResponseDto) String() string {
+	return json.PrettyPrint(c)
+}
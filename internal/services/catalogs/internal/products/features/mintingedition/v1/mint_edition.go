@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+// MintEdition mints a new edition of the product to its first owner
+type MintEdition struct {
+	cqrs.Command
+	EditionID     uuid.UUID
+	ProductID     uuid.UUID
+	TokenID       string
+	EditionNumber int
+	Owner         string
+}
+
+func NewMintEdition(
+	productID uuid.UUID,
+	tokenID string,
+	editionNumber int,
+	owner string,
+) *MintEdition {
+	command := &MintEdition{
+		Command:       cqrs.NewCommandByT[MintEdition](),
+		EditionID:     uuid.NewV4(),
+		ProductID:     productID,
+		TokenID:       tokenID,
+		EditionNumber: editionNumber,
+		Owner:         owner,
+	}
+
+	return command
+}
+
+// NewMintEditionWithValidation mints a new edition with inline validation - for defensive programming and ensuring validation even without using middleware
+func NewMintEditionWithValidation(
+	productID uuid.UUID,
+	tokenID string,
+	editionNumber int,
+	owner string,
+) (*MintEdition, error) {
+	command := NewMintEdition(productID, tokenID, editionNumber, owner)
+	err := command.Validate()
+
+	return command, err
+}
+
+func (c *MintEdition) Validate() error {
+	err := validation.ValidateStruct(
+		c,
+		validation.Field(&c.EditionID, validation.Required),
+		validation.Field(&c.ProductID, validation.Required),
+		validation.Field(&c.TokenID, validation.Required),
+		validation.Field(&c.EditionNumber, validation.Required, validation.Min(1)),
+		validation.Field(&c.Owner, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/mintingedition/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/constants"
+	"github.com/reoden/go-NFT/pkg/core/web/route"
+	"github.com/reoden/go-NFT/pkg/http/customecho/middlewares/auth"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type mintEditionEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewMintEditionEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &mintEditionEndpoint{ProductRouteParams: params}
+}
+
+func (ep *mintEditionEndpoint) MapEndpoint() {
+	ep.EditionsGroup.POST("", ep.handler(), auth.RequireRoles(constants.AdminRole))
+}
+
+// MintEdition
+// @Tags Editions
+// @Summary Mint edition
+// @Description Mint a new edition of the product to its first owner, the mint is the first event of the edition provenance
+// @Accept json
+// @Produce json
+// @Param MintEditionRequestDto body dtos.MintEditionRequestDto true "Edition data"
+// @Success 201 {object} dtos.MintEditionResponseDto
+// @Security BearerAuth
+// @Router /api/v1/editions [post]
+func (ep *mintEditionEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.MintEditionRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		command, err := NewMintEditionWithValidation(
+			request.ProductID,
+			request.TokenID,
+			request.EditionNumber,
+			request.Owner,
+		)
+		if err != nil {
+			return err
+		}
+
+		result, err := mediatr.Send[*MintEdition, *dtos.MintEditionResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending MintEdition",
+			)
+		}
+
+		return c.JSON(http.StatusCreated, result)
+	}
+}
@@ -0,0 +1,77 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/datamodels"
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/mintingedition/v1/dtos"
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/postgresgorm/gormdbcontext"
+
+	"emperror.dev/errors"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type mintEditionHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewMintEditionHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*MintEdition, *dtos.MintEditionResponseDto] {
+	return &mintEditionHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *mintEditionHandler) RegisterHandler() error {
+	return mediatr.RegisterRequestHandler[*MintEdition, *dtos.MintEditionResponseDto](
+		c,
+	)
+}
+
+func (c *mintEditionHandler) Handle(
+	ctx context.Context,
+	command *MintEdition,
+) (*dtos.MintEditionResponseDto, error) {
+	if !gormdbcontext.Exists[*datamodels.ProductDataModel](ctx, c.CatalogsDBContext, command.ProductID) {
+		return nil, customErrors.NewApplicationErrorWithCode(
+			fmt.Sprintf("product with id `%s` not found", command.ProductID),
+			http.StatusNotFound,
+		)
+	}
+
+	edition, err := models.NewEdition(
+		command.EditionID,
+		command.ProductID,
+		command.TokenID,
+		command.EditionNumber,
+		command.Owner,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.EditionAggregateStore.Store(edition, nil, ctx)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error in storing the edition")
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf(
+			"edition %d of the product with id '%s' minted with id '%s'",
+			command.EditionNumber,
+			command.ProductID,
+			command.EditionID,
+		),
+		logger.Fields{"Id": command.EditionID, "ProductId": command.ProductID},
+	)
+
+	return &dtos.MintEditionResponseDto{EditionID: command.EditionID}, nil
+}
@@ -0,0 +1,5 @@
+package dtos
+
+type PurgeDeadLettersRequestDto struct {
+	Queue string `param:"queue" json:"-"`
+}
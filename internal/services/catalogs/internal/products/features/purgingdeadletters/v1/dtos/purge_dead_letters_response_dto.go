@@ -0,0 +1,6 @@
+package dtos
+
+type PurgeDeadLettersResponseDto struct {
+	Queue  string `json:"queue"`
+	Purged int    `json:"purged"`
+}
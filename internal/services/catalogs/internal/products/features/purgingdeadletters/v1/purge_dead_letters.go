@@ -0,0 +1,38 @@
+package v1
+
+import (
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type PurgeDeadLetters struct {
+	Queue string
+}
+
+// NewPurgeDeadLetters purge the dead-lettered messages of a queue
+func NewPurgeDeadLetters(queue string) *PurgeDeadLetters {
+	command := &PurgeDeadLetters{Queue: queue}
+
+	return command
+}
+
+// NewPurgeDeadLettersWithValidation purge the dead-lettered messages of a queue with inline validation - for defensive programming and ensuring validation even without using middleware
+func NewPurgeDeadLettersWithValidation(queue string) (*PurgeDeadLetters, error) {
+	command := NewPurgeDeadLetters(queue)
+	err := command.Validate()
+
+	return command, err
+}
+
+func (c *PurgeDeadLetters) Validate() error {
+	err := validation.ValidateStruct(
+		c,
+		validation.Field(&c.Queue, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/purgingdeadletters/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/web/route"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type purgeDeadLettersEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewPurgeDeadLettersEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &purgeDeadLettersEndpoint{ProductRouteParams: params}
+}
+
+func (ep *purgeDeadLettersEndpoint) MapEndpoint() {
+	ep.DeadLettersGroup.DELETE("/:queue", ep.handler())
+}
+
+// PurgeDeadLetters
+// @Tags DeadLetters
+// @Summary Purge dead-lettered messages
+// @Description Remove all the dead-lettered messages of a consumer queue
+// @Accept json
+// @Produce json
+// @Param queue path string true "Consumer queue"
+// @Success 200 {object} dtos.PurgeDeadLettersResponseDto
+// @Security BearerAuth
+// @Router /api/v1/dead-letters/{queue} [delete]
+func (ep *purgeDeadLettersEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.PurgeDeadLettersRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		command, err := NewPurgeDeadLettersWithValidation(request.Queue)
+		if err != nil {
+			return err
+		}
+
+		result, err := mediatr.Send[*PurgeDeadLetters, *dtos.PurgeDeadLettersResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending PurgeDeadLetters",
+			)
+		}
+
+		return c.JSON(http.StatusOK, result)
+	}
+}
@@ -0,0 +1,48 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/purgingdeadletters/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	"github.com/reoden/go-NFT/pkg/logger"
+
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type purgeDeadLettersHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewPurgeDeadLettersHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*PurgeDeadLetters, *dtos.PurgeDeadLettersResponseDto] {
+	return &purgeDeadLettersHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *purgeDeadLettersHandler) RegisterHandler() error {
+	return mediatr.RegisterRequestHandler[*PurgeDeadLetters, *dtos.PurgeDeadLettersResponseDto](
+		c,
+	)
+}
+
+func (c *purgeDeadLettersHandler) Handle(
+	ctx context.Context,
+	command *PurgeDeadLetters,
+) (*dtos.PurgeDeadLettersResponseDto, error) {
+	purged, err := c.DeadLetterManager.Purge(ctx, command.Queue)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf("%d dead-lettered messages of the queue '%s' purged", purged, command.Queue),
+		logger.Fields{"Queue": command.Queue, "Purged": purged},
+	)
+
+	return &dtos.PurgeDeadLettersResponseDto{Queue: command.Queue, Purged: purged}, nil
+}
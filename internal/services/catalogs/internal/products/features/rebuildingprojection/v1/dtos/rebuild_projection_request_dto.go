@@ -0,0 +1,7 @@
+package dtos
+
+type RebuildProjectionRequestDto struct {
+	ProjectionName     string `param:"projectionName"     json:"-"`
+	BatchSize          int    `query:"batchSize"          json:"-"`
+	MaxEventsPerSecond int    `query:"maxEventsPerSecond" json:"-"`
+}
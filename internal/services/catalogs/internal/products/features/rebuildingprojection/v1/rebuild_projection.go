@@ -0,0 +1,63 @@
+package v1
+
+import (
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const defaultRebuildBatchSize = 500
+
+// RebuildProjection replays the events of the store from the start into a shadow of the projection read model and swaps
+// it with the read model, the command waits for the end of rebuild only with `Wait`
+type RebuildProjection struct {
+	ProjectionName     string
+	BatchSize          int
+	MaxEventsPerSecond int
+	Wait               bool
+}
+
+func NewRebuildProjection(
+	projectionName string,
+	batchSize int,
+	maxEventsPerSecond int,
+	wait bool,
+) *RebuildProjection {
+	if batchSize == 0 {
+		batchSize = defaultRebuildBatchSize
+	}
+
+	return &RebuildProjection{
+		ProjectionName:     projectionName,
+		BatchSize:          batchSize,
+		MaxEventsPerSecond: maxEventsPerSecond,
+		Wait:               wait,
+	}
+}
+
+// NewRebuildProjectionWithValidation rebuild a projection with inline validation - for defensive programming and ensuring validation even without using middleware
+func NewRebuildProjectionWithValidation(
+	projectionName string,
+	batchSize int,
+	maxEventsPerSecond int,
+	wait bool,
+) (*RebuildProjection, error) {
+	command := NewRebuildProjection(projectionName, batchSize, maxEventsPerSecond, wait)
+	err := command.Validate()
+
+	return command, err
+}
+
+func (c *RebuildProjection) Validate() error {
+	err := validation.ValidateStruct(
+		c,
+		validation.Field(&c.ProjectionName, validation.Required),
+		validation.Field(&c.BatchSize, validation.Required, validation.Min(1), validation.Max(5000)),
+		validation.Field(&c.MaxEventsPerSecond, validation.Min(0)),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/rebuildingprojection/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/web/route"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type rebuildProjectionEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewRebuildProjectionEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &rebuildProjectionEndpoint{ProductRouteParams: params}
+}
+
+func (ep *rebuildProjectionEndpoint) MapEndpoint() {
+	ep.ProjectionsGroup.POST("/:projectionName/rebuild", ep.handler())
+}
+
+// RebuildProjection
+// @Tags Projections
+// @Summary Rebuild a projection
+// @Description Start replaying the events from the start of the store into a shadow of the projection read model, the shadow replaces the read model at the end of the replay
+// @Accept json
+// @Produce json
+// @Param projectionName path string true "Projection name"
+// @Param batchSize query int false "Batch size"
+// @Param maxEventsPerSecond query int false "Maximum replayed events per second"
+// @Success 202 {object} dtos.RebuildProjectionResponseDto
+// @Security BearerAuth
+// @Router /api/v1/projections/{projectionName}/rebuild [post]
+func (ep *rebuildProjectionEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.RebuildProjectionRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		command, err := NewRebuildProjectionWithValidation(
+			request.ProjectionName,
+			request.BatchSize,
+			request.MaxEventsPerSecond,
+			false,
+		)
+		if err != nil {
+			return err
+		}
+
+		result, err := mediatr.Send[*RebuildProjection, *dtos.RebuildProjectionResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending RebuildProjection",
+			)
+		}
+
+		return c.JSON(http.StatusAccepted, result)
+	}
+}
@@ -0,0 +1,74 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	dtoV1 "github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1"
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/rebuildingprojection/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	"github.com/reoden/go-NFT/pkg/es"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/mapper"
+
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type rebuildProjectionHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewRebuildProjectionHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*RebuildProjection, *dtos.RebuildProjectionResponseDto] {
+	return &rebuildProjectionHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *rebuildProjectionHandler) RegisterHandler() error {
+	return mediatr.RegisterRequestHandler[*RebuildProjection, *dtos.RebuildProjectionResponseDto](
+		c,
+	)
+}
+
+// Handle rebuilds the projection, the rebuild runs in the background without `Wait` and its progress is returned
+func (c *rebuildProjectionHandler) Handle(
+	ctx context.Context,
+	command *RebuildProjection,
+) (*dtos.RebuildProjectionResponseDto, error) {
+	options := &es.ProjectionRebuildOptions{
+		BatchSize:          command.BatchSize,
+		MaxEventsPerSecond: command.MaxEventsPerSecond,
+	}
+
+	var (
+		progress *es.ProjectionRebuildProgress
+		err      error
+	)
+	if command.Wait {
+		progress, err = c.ProjectionRebuilder.Rebuild(ctx, command.ProjectionName, options)
+	} else {
+		progress, err = c.ProjectionRebuilder.StartRebuild(command.ProjectionName, options)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	progressDto, err := mapper.Map[*dtoV1.ProjectionRebuildProgressDto](progress)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in the mapping ProjectionRebuildProgressDto",
+		)
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf("rebuild of projection '%s' is %s", command.ProjectionName, progress.State),
+		logger.Fields{"ProjectionName": command.ProjectionName, "State": progress.State},
+	)
+
+	return &dtos.RebuildProjectionResponseDto{Progress: progressDto}, nil
+}
@@ -0,0 +1,5 @@
+package dtos
+
+type ReindexProductsRequestDto struct {
+	BatchSize int `query:"batchSize" json:"-"`
+}
@@ -0,0 +1,6 @@
+package dtos
+
+type ReindexProductsResponseDto struct {
+	IndexName    string `json:"indexName"`
+	IndexedCount int64  `json:"indexedCount"`
+}
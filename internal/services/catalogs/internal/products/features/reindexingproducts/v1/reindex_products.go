@@ -0,0 +1,42 @@
+package v1
+
+import (
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const defaultReindexBatchSize = 500
+
+type ReindexProducts struct {
+	BatchSize int
+}
+
+// NewReindexProducts rebuild the products search index from the database
+func NewReindexProducts(batchSize int) *ReindexProducts {
+	if batchSize == 0 {
+		batchSize = defaultReindexBatchSize
+	}
+
+	return &ReindexProducts{BatchSize: batchSize}
+}
+
+// NewReindexProductsWithValidation rebuild the products search index with inline validation - for defensive programming and ensuring validation even without using middleware
+func NewReindexProductsWithValidation(batchSize int) (*ReindexProducts, error) {
+	command := NewReindexProducts(batchSize)
+	err := command.Validate()
+
+	return command, err
+}
+
+func (c *ReindexProducts) Validate() error {
+	err := validation.ValidateStruct(
+		c,
+		validation.Field(&c.BatchSize, validation.Required, validation.Min(1), validation.Max(5000)),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
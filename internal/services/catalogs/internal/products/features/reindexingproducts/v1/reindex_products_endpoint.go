@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/reindexingproducts/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/web/route"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type reindexProductsEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewReindexProductsEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &reindexProductsEndpoint{ProductRouteParams: params}
+}
+
+func (ep *reindexProductsEndpoint) MapEndpoint() {
+	ep.ProductsGroup.POST("/search/reindex", ep.handler())
+}
+
+// ReindexProducts
+// @Tags Products
+// @Summary Reindex products
+// @Description Rebuild the products search index from the database
+// @Accept json
+// @Produce json
+// @Param batchSize query int false "Batch size"
+// @Success 200 {object} dtos.ReindexProductsResponseDto
+// @Router /api/v1/products/search/reindex [post]
+func (ep *reindexProductsEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.ReindexProductsRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		command, err := NewReindexProductsWithValidation(request.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		result, err := mediatr.Send[*ReindexProducts, *dtos.ReindexProductsResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending ReindexProducts",
+			)
+		}
+
+		return c.JSON(http.StatusOK, result)
+	}
+}
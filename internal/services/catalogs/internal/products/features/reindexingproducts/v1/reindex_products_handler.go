@@ -0,0 +1,242 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/contracts"
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/associations"
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/datamodels"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/reindexingproducts/v1/dtos"
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
+	"github.com/reoden/go-NFT/catalogs/internal/shared/data/dbcontext"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/mapper"
+
+	"github.com/mehdihadeli/go-mediatr"
+	uuid "github.com/satori/go.uuid"
+	"gorm.io/gorm"
+)
+
+type reindexProductsHandler struct {
+	log               logger.Logger
+	catalogsDBContext *dbcontext.CatalogsGormDBContext
+	searchRepository  contracts.ProductSearchRepository
+}
+
+// NewReindexProductsHandler doesn't depend on the rabbitmq producer, so it can also be used by the reindex command line tool
+func NewReindexProductsHandler(
+	log logger.Logger,
+	catalogsDBContext *dbcontext.CatalogsGormDBContext,
+	searchRepository contracts.ProductSearchRepository,
+) cqrs.RequestHandlerWithRegisterer[*ReindexProducts, *dtos.ReindexProductsResponseDto] {
+	return &reindexProductsHandler{
+		log:               log,
+		catalogsDBContext: catalogsDBContext,
+		searchRepository:  searchRepository,
+	}
+}
+
+func (c *reindexProductsHandler) RegisterHandler() error {
+	return mediatr.RegisterRequestHandler[*ReindexProducts, *dtos.ReindexProductsResponseDto](
+		c,
+	)
+}
+
+// deltaSafetyMargin covers the clock skew of the instances that write the `updated_at` and `deleted_at` of the products
+const deltaSafetyMargin = time.Minute
+
+// Handle builds a new index from all products and switches the search alias to it when the whole index is populated,
+// so searches keep hitting the old index during the rebuild. The consumers write to the old index until the switch, so
+// the products changed or deleted after the start of the rebuild are copied by a delta pass before the switch and by a
+// last delta pass for the changes between the first delta pass and the switch.
+func (c *reindexProductsHandler) Handle(
+	ctx context.Context,
+	command *ReindexProducts,
+) (*dtos.ReindexProductsResponseDto, error) {
+	startedAt := time.Now()
+
+	indexName, err := c.searchRepository.CreateIndex(ctx)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in creating the products search index",
+		)
+	}
+
+	var indexedCount int64
+
+	err = c.forEachBatch(
+		ctx,
+		c.catalogsDBContext.DB().WithContext(ctx),
+		command.BatchSize,
+		func(batch []*datamodels.ProductDataModel) error {
+			if err := c.indexBatch(ctx, indexName, batch); err != nil {
+				return err
+			}
+
+			indexedCount += int64(len(batch))
+
+			c.log.Infow(
+				"products batch indexed",
+				logger.Fields{"IndexName": indexName, "IndexedCount": indexedCount},
+			)
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	deltaStartedAt := time.Now()
+	if err := c.indexChangedProducts(ctx, indexName, command.BatchSize, startedAt); err != nil {
+		return nil, err
+	}
+
+	if err := c.searchRepository.SwitchIndex(ctx, indexName); err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in switching the products search index",
+		)
+	}
+
+	// the consumers write to the new index after the switch, so this pass only copies the few changes between the first
+	// delta pass and the switch
+	if err := c.indexChangedProducts(ctx, indexName, command.BatchSize, deltaStartedAt); err != nil {
+		return nil, err
+	}
+
+	c.log.Infow(
+		"products search index rebuilt",
+		logger.Fields{"IndexName": indexName, "IndexedCount": indexedCount},
+	)
+
+	return &dtos.ReindexProductsResponseDto{
+		IndexName:    indexName,
+		IndexedCount: indexedCount,
+	}, nil
+}
+
+// indexChangedProducts indexes the products updated since the time and removes the products deleted since the time
+// from the index
+func (c *reindexProductsHandler) indexChangedProducts(
+	ctx context.Context,
+	indexName string,
+	batchSize int,
+	since time.Time,
+) error {
+	since = since.Add(-deltaSafetyMargin)
+
+	var indexedCount int
+
+	query := c.catalogsDBContext.DB().WithContext(ctx).Where("updated_at >= ?", since)
+
+	err := c.forEachBatch(ctx, query, batchSize, func(batch []*datamodels.ProductDataModel) error {
+		indexedCount += len(batch)
+
+		return c.indexBatch(ctx, indexName, batch)
+	})
+	if err != nil {
+		return err
+	}
+
+	// the products are soft deleted, so the deleted products are found by their deletion time
+	var deletedIds []uuid.UUID
+
+	err = c.catalogsDBContext.DB().
+		WithContext(ctx).
+		Unscoped().
+		Model(&datamodels.ProductDataModel{}).
+		Where("deleted_at >= ?", since).
+		Pluck("id", &deletedIds).Error
+	if err != nil {
+		return customErrors.NewApplicationErrorWrap(
+			err,
+			"error in fetching deleted products for reindex",
+		)
+	}
+
+	if err := c.searchRepository.DeleteIndexedProducts(ctx, indexName, deletedIds); err != nil {
+		return customErrors.NewApplicationErrorWrap(
+			err,
+			"error in removing deleted products from the index",
+		)
+	}
+
+	c.log.Infow(
+		"products changed during the reindex are copied to the index",
+		logger.Fields{"IndexName": indexName, "IndexedCount": indexedCount, "DeletedCount": len(deletedIds)},
+	)
+
+	return nil
+}
+
+// forEachBatch reads the products of the query in the id order with their associations in batches
+func (c *reindexProductsHandler) forEachBatch(
+	ctx context.Context,
+	query *gorm.DB,
+	batchSize int,
+	handle func(batch []*datamodels.ProductDataModel) error,
+) error {
+	lastID := uuid.Nil
+
+	for {
+		var batch []*datamodels.ProductDataModel
+
+		err := associations.PreloadAssociations(query.Session(&gorm.Session{})).
+			Where("id > ?", lastID).
+			Order("id").
+			Limit(batchSize).
+			Find(&batch).Error
+		if err != nil {
+			return customErrors.NewApplicationErrorWrap(
+				err,
+				"error in fetching products for reindex",
+			)
+		}
+
+		if len(batch) == 0 {
+			return nil
+		}
+
+		if err := handle(batch); err != nil {
+			return err
+		}
+
+		lastID = batch[len(batch)-1].Id
+
+		if len(batch) < batchSize {
+			return nil
+		}
+	}
+}
+
+func (c *reindexProductsHandler) indexBatch(
+	ctx context.Context,
+	indexName string,
+	batch []*datamodels.ProductDataModel,
+) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	products, err := mapper.Map[[]*models.Product](batch)
+	if err != nil {
+		return customErrors.NewApplicationErrorWrap(
+			err,
+			"error in the mapping products",
+		)
+	}
+
+	if err := c.searchRepository.BulkIndexProducts(ctx, indexName, products); err != nil {
+		return customErrors.NewApplicationErrorWrap(
+			err,
+			"error in bulk indexing products",
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,225 @@
+//go:build integration
+// +build integration
+
+package v1
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/configurations/mappings"
+	"github.com/reoden/go-NFT/catalogs/internal/products/contracts"
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/datamodels"
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/documents"
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/repositories"
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
+	"github.com/reoden/go-NFT/catalogs/internal/shared/data/dbcontext"
+	"github.com/reoden/go-NFT/pkg/config"
+	"github.com/reoden/go-NFT/pkg/config/environment"
+	"github.com/reoden/go-NFT/pkg/core"
+	elasticsearch2 "github.com/reoden/go-NFT/pkg/elasticsearch"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/logger/external/fxlog"
+	"github.com/reoden/go-NFT/pkg/logger/zap"
+	"github.com/reoden/go-NFT/pkg/mapper"
+	"github.com/reoden/go-NFT/pkg/otel/tracing"
+	gormPostgres "github.com/reoden/go-NFT/pkg/postgresgorm"
+	elasticsearchcontainer "github.com/reoden/go-NFT/pkg/test/containers/testcontainer/elasticsearch"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+	"gorm.io/gorm"
+)
+
+type ReindexProductsHandlerTestSuite struct {
+	suite.Suite
+	ctx              context.Context
+	app              *fxtest.App
+	log              logger.Logger
+	dbContext        *dbcontext.CatalogsGormDBContext
+	client           *elasticsearch.Client
+	searchRepository contracts.ProductSearchRepository
+	dbFilePath       string
+}
+
+func TestReindexProductsHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ReindexProductsHandlerTestSuite))
+}
+
+func (s *ReindexProductsHandlerTestSuite) Test_Reindex_Copies_The_Products_To_A_New_Index_And_Switches_The_Alias() {
+	products := s.seedProducts(3, 2)
+
+	deleted := s.seedProducts(1, 1)[0]
+	s.Require().NoError(s.dbContext.DB().Delete(&datamodels.ProductDataModel{Id: deleted.Id}).Error)
+
+	// the old index has a stale version of a product and a product deleted in the database
+	stale := s.toProduct(products[0])
+	stale.Name = "stale"
+	stale.Version = 1
+	s.Require().NoError(s.searchRepository.IndexProduct(s.ctx, stale))
+	s.Require().NoError(s.searchRepository.IndexProduct(s.ctx, s.toProduct(deleted)))
+
+	oldIndices, err := elasticsearch2.GetAliasIndices(s.ctx, s.client, documents.ProductsIndexAlias)
+	s.Require().NoError(err)
+	s.Require().Len(oldIndices, 1)
+
+	handler := NewReindexProductsHandler(s.log, s.dbContext, s.searchRepository)
+	result, err := handler.Handle(s.ctx, NewReindexProducts(2))
+	s.Require().NoError(err)
+	s.Assert().Equal(int64(3), result.IndexedCount)
+
+	aliasIndices, err := elasticsearch2.GetAliasIndices(s.ctx, s.client, documents.ProductsIndexAlias)
+	s.Require().NoError(err)
+	s.Assert().Equal([]string{result.IndexName}, aliasIndices)
+
+	exists, err := elasticsearch2.IndexExists(s.ctx, s.client, oldIndices[0])
+	s.Require().NoError(err)
+	s.Assert().False(exists)
+
+	s.Assert().ElementsMatch(
+		[]string{products[0].Name, products[1].Name, products[2].Name},
+		s.searchNames(),
+	)
+}
+
+func (s *ReindexProductsHandlerTestSuite) Test_Reindex_Keeps_The_Newer_Versions_Indexed_During_The_Rebuild() {
+	products := s.seedProducts(2, 2)
+
+	indexName, err := s.searchRepository.CreateIndex(s.ctx)
+	s.Require().NoError(err)
+
+	// a product updated after it is read by the rebuild is indexed with its newer version by the consumer and the delta
+	// pass, the older version of the first pass doesn't replace it
+	updated := s.toProduct(products[0])
+	updated.Name = "updated"
+	updated.Version = 3
+	s.Require().NoError(s.searchRepository.BulkIndexProducts(s.ctx, indexName, []*models.Product{updated}))
+	s.Require().NoError(
+		s.searchRepository.BulkIndexProducts(s.ctx, indexName, []*models.Product{s.toProduct(products[0])}),
+	)
+
+	s.Require().NoError(s.searchRepository.SwitchIndex(s.ctx, indexName))
+
+	// the consumer writes to the new index after the switch, an out of order older message is skipped
+	s.Require().NoError(s.searchRepository.IndexProduct(s.ctx, s.toProduct(products[1])))
+	older := s.toProduct(products[1])
+	older.Name = "older"
+	older.Version = 1
+	s.Require().NoError(s.searchRepository.IndexProduct(s.ctx, older))
+
+	s.Assert().ElementsMatch([]string{"updated", products[1].Name}, s.searchNames())
+}
+
+func (s *ReindexProductsHandlerTestSuite) seedProducts(count int, version int64) []*datamodels.ProductDataModel {
+	products := make([]*datamodels.ProductDataModel, 0, count)
+	for i := 0; i < count; i++ {
+		products = append(products, &datamodels.ProductDataModel{
+			Id:        uuid.NewV4(),
+			Name:      uuid.NewV4().String(),
+			Artist:    "banksy",
+			Price:     decimal.NewFromInt(100),
+			Currency:  models.DefaultCurrency,
+			Version:   version,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		})
+	}
+
+	s.Require().NoError(s.dbContext.DB().Create(&products).Error)
+
+	return products
+}
+
+func (s *ReindexProductsHandlerTestSuite) toProduct(dataModel *datamodels.ProductDataModel) *models.Product {
+	product, err := mapper.Map[*models.Product](dataModel)
+	s.Require().NoError(err)
+
+	return product
+}
+
+func (s *ReindexProductsHandlerTestSuite) searchNames() []string {
+	s.Require().NoError(elasticsearch2.RefreshIndex(s.ctx, s.client, documents.ProductsIndexAlias))
+
+	result, err := s.searchRepository.SearchProducts(s.ctx, &models.ProductSearchCriteria{Page: 1, Size: 10})
+	s.Require().NoError(err)
+
+	names := make([]string, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		names = append(names, hit.Product.Name)
+	}
+
+	return names
+}
+
+// TestSuite Hooks
+
+func (s *ReindexProductsHandlerTestSuite) SetupTest() {
+	s.ctx = context.Background()
+
+	err := mappings.ConfigureProductsMappings()
+	s.Require().NoError(err)
+
+	var gormOptions *gormPostgres.GormOptions
+
+	s.app = fxtest.New(
+		s.T(),
+		config.ModuleFunc(environment.Test),
+		zap.Module,
+		fxlog.FxLogger,
+		core.Module,
+		gormPostgres.Module,
+		fx.Decorate(
+			func(cfg *gormPostgres.GormOptions) (*gormPostgres.GormOptions, error) {
+				// using sql-lite with a database file
+				cfg.UseSQLLite = true
+
+				return cfg, nil
+			},
+		),
+		elasticsearch2.Module,
+		fx.Decorate(elasticsearchcontainer.ElasticsearchContainerOptionsDecorator(s.T(), s.ctx)),
+		fx.Provide(dbcontext.NewCatalogsDBContext),
+		fx.Populate(&s.log),
+		fx.Populate(&s.dbContext),
+		fx.Populate(&s.client),
+		fx.Populate(&gormOptions),
+	).RequireStart()
+
+	s.dbFilePath = gormOptions.Dns()
+
+	err = migrateProducts(s.dbContext.DB())
+	s.Require().NoError(err)
+
+	s.searchRepository = repositories.NewElasticProductSearchRepository(
+		s.log,
+		s.client,
+		tracing.NewAppTracer("reindex-products-test"),
+	)
+}
+
+func (s *ReindexProductsHandlerTestSuite) TearDownTest() {
+	sqldb, _ := s.dbContext.DB().DB()
+	s.Require().NoError(sqldb.Close())
+
+	// removing sql-lite file
+	s.Require().NoError(os.Remove(s.dbFilePath))
+
+	mapper.ClearMappings()
+
+	s.app.RequireStop()
+}
+
+func migrateProducts(db *gorm.DB) error {
+	return db.AutoMigrate(
+		&datamodels.CategoryDataModel{},
+		&datamodels.TagDataModel{},
+		&datamodels.ProductDataModel{},
+		&datamodels.MediaAssetDataModel{},
+	)
+}
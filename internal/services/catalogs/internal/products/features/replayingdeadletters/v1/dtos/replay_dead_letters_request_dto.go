@@ -0,0 +1,7 @@
+package dtos
+
+// ReplayDeadLettersRequestDto `messageId` is empty on the route that replays all the messages of the queue
+type ReplayDeadLettersRequestDto struct {
+	Queue     string `param:"queue"     json:"-"`
+	MessageId string `param:"messageId" json:"-"`
+}
@@ -0,0 +1,6 @@
+package dtos
+
+type ReplayDeadLettersResponseDto struct {
+	Queue    string `json:"queue"`
+	Replayed int    `json:"replayed"`
+}
@@ -0,0 +1,39 @@
+package v1
+
+import (
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// ReplayDeadLetters replays a dead-lettered message of the queue, or all of them when the message id is empty
+type ReplayDeadLetters struct {
+	Queue     string
+	MessageId string
+}
+
+func NewReplayDeadLetters(queue string, messageId string) *ReplayDeadLetters {
+	command := &ReplayDeadLetters{Queue: queue, MessageId: messageId}
+
+	return command
+}
+
+// NewReplayDeadLettersWithValidation replay dead-lettered messages with inline validation - for defensive programming and ensuring validation even without using middleware
+func NewReplayDeadLettersWithValidation(queue string, messageId string) (*ReplayDeadLetters, error) {
+	command := NewReplayDeadLetters(queue, messageId)
+	err := command.Validate()
+
+	return command, err
+}
+
+func (c *ReplayDeadLetters) Validate() error {
+	err := validation.ValidateStruct(
+		c,
+		validation.Field(&c.Queue, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
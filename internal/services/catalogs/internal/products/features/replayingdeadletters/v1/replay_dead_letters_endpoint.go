@@ -0,0 +1,75 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/replayingdeadletters/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/web/route"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type replayDeadLettersEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewReplayDeadLettersEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &replayDeadLettersEndpoint{ProductRouteParams: params}
+}
+
+func (ep *replayDeadLettersEndpoint) MapEndpoint() {
+	ep.DeadLettersGroup.POST("/:queue/replay", ep.handler())
+	ep.DeadLettersGroup.POST("/:queue/:messageId/replay", ep.handler())
+}
+
+// ReplayDeadLetters
+// @Tags DeadLetters
+// @Summary Replay dead-lettered messages
+// @Description Send a dead-lettered message, or all the dead-lettered messages of a consumer queue, back to the queue
+// @Accept json
+// @Produce json
+// @Param queue path string true "Consumer queue"
+// @Param messageId path string false "Message ID"
+// @Success 200 {object} dtos.ReplayDeadLettersResponseDto
+// @Security BearerAuth
+// @Router /api/v1/dead-letters/{queue}/replay [post]
+// @Router /api/v1/dead-letters/{queue}/{messageId}/replay [post]
+func (ep *replayDeadLettersEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.ReplayDeadLettersRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		command, err := NewReplayDeadLettersWithValidation(request.Queue, request.MessageId)
+		if err != nil {
+			return err
+		}
+
+		result, err := mediatr.Send[*ReplayDeadLetters, *dtos.ReplayDeadLettersResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending ReplayDeadLetters",
+			)
+		}
+
+		return c.JSON(http.StatusOK, result)
+	}
+}
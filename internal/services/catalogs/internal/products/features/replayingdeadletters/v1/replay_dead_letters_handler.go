@@ -0,0 +1,59 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/replayingdeadletters/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	"github.com/reoden/go-NFT/pkg/logger"
+
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type replayDeadLettersHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewReplayDeadLettersHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*ReplayDeadLetters, *dtos.ReplayDeadLettersResponseDto] {
+	return &replayDeadLettersHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *replayDeadLettersHandler) RegisterHandler() error {
+	return mediatr.RegisterRequestHandler[*ReplayDeadLetters, *dtos.ReplayDeadLettersResponseDto](
+		c,
+	)
+}
+
+// Handle replays the dead-lettered messages, the messages that fail again go through the retries of the consumer
+// and return to the dead-letter queue
+func (c *replayDeadLettersHandler) Handle(
+	ctx context.Context,
+	command *ReplayDeadLetters,
+) (*dtos.ReplayDeadLettersResponseDto, error) {
+	replayed := 1
+
+	if command.MessageId != "" {
+		if err := c.DeadLetterManager.Replay(ctx, command.Queue, command.MessageId); err != nil {
+			return nil, err
+		}
+	} else {
+		count, err := c.DeadLetterManager.ReplayAll(ctx, command.Queue)
+		if err != nil {
+			return nil, err
+		}
+		replayed = count
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf("%d dead-lettered messages of the queue '%s' replayed", replayed, command.Queue),
+		logger.Fields{"Queue": command.Queue, "MessageId": command.MessageId, "Replayed": replayed},
+	)
+
+	return &dtos.ReplayDeadLettersResponseDto{Queue: command.Queue, Replayed: replayed}, nil
+}
@@ -0,0 +1,19 @@
+package dtos
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
+)
+
+// SchedulePriceChangeRequestDto validation will handle in command level
+type SchedulePriceChangeRequestDto struct {
+	ProductID uuid.UUID `json:"-" param:"id"`
+	// Price exact amount of the new price, it can be sent as a number or a string like `"12.50"`
+	Price decimal.Decimal `json:"price"`
+	// Currency code of the new price like `USD` or `ETH`, the default is `USD`
+	Currency string `json:"currency"`
+	// ApplyAt time that the new price is applied at, it should be in the future
+	ApplyAt time.Time `json:"applyAt"`
+}
@@ -0,0 +1,15 @@
+package dtos
+
+import (
+	"github.com/reoden/go-NFT/pkg/core/serializer/json"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type SchedulePriceChangeResponseDto struct {
+	ScheduleID uuid.UUID `json:"scheduleId"`
+}
+
+func (c *SchedulePriceChangeResponseDto) String() string {
+	return json.PrettyPrint(c)
+}
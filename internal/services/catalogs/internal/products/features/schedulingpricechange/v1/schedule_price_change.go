@@ -0,0 +1,81 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
+)
+
+type SchedulePriceChange struct {
+	cqrs.Command
+	ScheduleID uuid.UUID
+	ProductID  uuid.UUID
+	Price      decimal.Decimal
+	Currency   string
+	ApplyAt    time.Time
+	CreatedAt  time.Time
+}
+
+// NewSchedulePriceChange Schedule a price change of the product that is applied at `applyAt`
+func NewSchedulePriceChange(
+	productID uuid.UUID,
+	price decimal.Decimal,
+	currency string,
+	applyAt time.Time,
+) *SchedulePriceChange {
+	command := &SchedulePriceChange{
+		Command:    cqrs.NewCommandByT[SchedulePriceChange](),
+		ScheduleID: uuid.NewV4(),
+		ProductID:  productID,
+		Price:      price,
+		Currency:   models.NormalizeCurrency(currency),
+		ApplyAt:    applyAt,
+		CreatedAt:  time.Now(),
+	}
+
+	return command
+}
+
+// NewSchedulePriceChangeWithValidation Schedule a price change with inline validation - for defensive programming and ensuring validation even without using middleware
+func NewSchedulePriceChangeWithValidation(
+	productID uuid.UUID,
+	price decimal.Decimal,
+	currency string,
+	applyAt time.Time,
+) (*SchedulePriceChange, error) {
+	command := NewSchedulePriceChange(productID, price, currency, applyAt)
+	err := command.Validate()
+
+	return command, err
+}
+
+// IsTxRequest for enabling transactions on the mediatr pipeline
+func (c *SchedulePriceChange) IsTxRequest() {
+}
+
+func (c *SchedulePriceChange) Validate() error {
+	err := validation.ValidateStruct(
+		c,
+		validation.Field(&c.ScheduleID, validation.Required),
+		validation.Field(&c.ProductID, validation.Required),
+		validation.Field(&c.Price, models.PositiveAmount),
+		validation.Field(&c.Currency, validation.Required, validation.Match(models.CurrencyRegex)),
+		validation.Field(
+			&c.ApplyAt,
+			validation.Required,
+			validation.Min(c.CreatedAt).Exclusive().Error("must be in the future"),
+		),
+		validation.Field(&c.CreatedAt, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/schedulingpricechange/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/web/route"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type schedulePriceChangeEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewSchedulePriceChangeEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &schedulePriceChangeEndpoint{ProductRouteParams: params}
+}
+
+func (ep *schedulePriceChangeEndpoint) MapEndpoint() {
+	ep.ProductsGroup.POST("/:id/price-schedules", ep.handler())
+}
+
+// SchedulePriceChange
+// @Tags Products
+// @Summary Schedule product price change
+// @Description Schedule a price change of the product, the new price is applied at `applyAt` and is added to the product price history
+// @Accept json
+// @Produce json
+// @Param SchedulePriceChangeRequestDto body dtos.SchedulePriceChangeRequestDto true "Price change data"
+// @Param id path string true "Product ID"
+// @Success 201 {object} dtos.SchedulePriceChangeResponseDto
+// @Router /api/v1/products/{id}/price-schedules [post]
+func (ep *schedulePriceChangeEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.SchedulePriceChangeRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		command, err := NewSchedulePriceChangeWithValidation(
+			request.ProductID,
+			request.Price,
+			request.Currency,
+			request.ApplyAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		result, err := mediatr.Send[*SchedulePriceChange, *dtos.SchedulePriceChangeResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending SchedulePriceChange",
+			)
+		}
+
+		return c.JSON(http.StatusCreated, result)
+	}
+}
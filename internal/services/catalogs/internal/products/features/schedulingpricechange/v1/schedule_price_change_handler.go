@@ -0,0 +1,101 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/datamodels"
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	applyingscheduledpricechangev1 "github.com/reoden/go-NFT/catalogs/internal/products/features/applyingscheduledpricechange/v1"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/schedulingpricechange/v1/dtos"
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/postgresgorm/gormdbcontext"
+
+	"github.com/hibiken/asynq"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type schedulePriceChangeHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewSchedulePriceChangeHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*SchedulePriceChange, *dtos.SchedulePriceChangeResponseDto] {
+	return &schedulePriceChangeHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *schedulePriceChangeHandler) RegisterHandler() error {
+	return mediatr.RegisterRequestHandler[*SchedulePriceChange, *dtos.SchedulePriceChangeResponseDto](
+		c,
+	)
+}
+
+// Handle saves the pending schedule and enqueues its task in the transaction of the mediatr transaction pipeline. The task
+// is enqueued last, so a failure in enqueuing rolls back the schedule. A task that runs before the commit of its schedule
+// is retried by the apply handler, and the task of a schedule that is rolled back after enqueuing is archived after
+// its retries.
+func (c *schedulePriceChangeHandler) Handle(
+	ctx context.Context,
+	command *SchedulePriceChange,
+) (*dtos.SchedulePriceChangeResponseDto, error) {
+	if !gormdbcontext.Exists[*datamodels.ProductDataModel](ctx, c.CatalogsDBContext, command.ProductID) {
+		return nil, customErrors.NewApplicationErrorWithCode(
+			fmt.Sprintf("product with id `%s` not found", command.ProductID),
+			http.StatusNotFound,
+		)
+	}
+
+	schedule := &datamodels.ProductPriceScheduleDataModel{
+		Id:        command.ScheduleID,
+		ProductId: command.ProductID,
+		Price:     command.Price,
+		Currency:  command.Currency,
+		ApplyAt:   command.ApplyAt,
+		Status:    models.PendingPriceScheduleStatus,
+		CreatedAt: command.CreatedAt,
+	}
+
+	_, err := gormdbcontext.AddDataModel[*datamodels.ProductPriceScheduleDataModel](
+		ctx,
+		c.CatalogsDBContext,
+		schedule,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := applyingscheduledpricechangev1.NewApplyScheduledPriceChangeTask(command.ScheduleID)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in creating the scheduled price change task")
+	}
+
+	// https://github.com/hibiken/asynq/wiki/Unique-Tasks#task-id
+	_, err = c.QueueClient.EnqueueContext(
+		ctx,
+		task,
+		asynq.ProcessAt(command.ApplyAt),
+		asynq.TaskID(command.ScheduleID.String()),
+	)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in enqueuing the scheduled price change task")
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf(
+			"price change `%s` of the product with id '%s' scheduled at %s",
+			command.ScheduleID,
+			command.ProductID,
+			command.ApplyAt,
+		),
+		logger.Fields{"Id": command.ProductID, "ScheduleId": command.ScheduleID},
+	)
+
+	return &dtos.SchedulePriceChangeResponseDto{ScheduleID: command.ScheduleID}, nil
+}
@@ -5,6 +5,10 @@ import (
 )
 
 type SearchProductsRequestDto struct {
-	SearchText       string `query:"search" json:"search"`
-	*utils.ListQuery `                      json:"listQuery"`
+	SearchText       string   `query:"search"   json:"search"`
+	Artist           string   `query:"artist"   json:"artist"`
+	Category         string   `query:"category" json:"category"`
+	MinPrice         *float64 `query:"minPrice" json:"minPrice"`
+	MaxPrice         *float64 `query:"maxPrice" json:"maxPrice"`
+	*utils.ListQuery `                          json:"listQuery"`
 }
@@ -6,5 +6,6 @@ import (
 )
 
 type SearchProductsResponseDto struct {
-	Products *utils.ListResult[*dtoV1.ProductDto]
+	Products *utils.ListResult[*dtoV1.ProductSearchItemDto]
+	Facets   *dtoV1.ProductSearchFacetsDto
 }
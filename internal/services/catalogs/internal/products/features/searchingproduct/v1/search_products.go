@@ -9,20 +9,49 @@ import (
 
 type SearchProducts struct {
 	SearchText string
+	Artist     string
+	Category   string
+	MinPrice   *float64
+	MaxPrice   *float64
 	*utils.ListQuery
 }
 
-func NewSearchProducts(searchText string, query *utils.ListQuery) *SearchProducts {
+func NewSearchProducts(
+	searchText string,
+	artist string,
+	category string,
+	minPrice *float64,
+	maxPrice *float64,
+	query *utils.ListQuery,
+) *SearchProducts {
 	searchProductQuery := &SearchProducts{
 		SearchText: searchText,
+		Artist:     artist,
+		Category:   category,
+		MinPrice:   minPrice,
+		MaxPrice:   maxPrice,
 		ListQuery:  query,
 	}
 
 	return searchProductQuery
 }
 
-func NewSearchProductsWithValidation(searchText string, query *utils.ListQuery) (*SearchProducts, error) {
-	searchProductQuery := NewSearchProducts(searchText, query)
+func NewSearchProductsWithValidation(
+	searchText string,
+	artist string,
+	category string,
+	minPrice *float64,
+	maxPrice *float64,
+	query *utils.ListQuery,
+) (*SearchProducts, error) {
+	searchProductQuery := NewSearchProducts(
+		searchText,
+		artist,
+		category,
+		minPrice,
+		maxPrice,
+		query,
+	)
 
 	err := searchProductQuery.Validate()
 
@@ -30,10 +59,21 @@ func NewSearchProductsWithValidation(searchText string, query *utils.ListQuery)
 }
 
 func (p *SearchProducts) Validate() error {
-	err := validation.ValidateStruct(p, validation.Field(&p.SearchText, validation.Required))
+	err := validation.ValidateStruct(
+		p,
+		validation.Field(&p.SearchText, validation.Length(0, 255)),
+		validation.Field(&p.Artist, validation.Length(0, 255)),
+		validation.Field(&p.Category, validation.Length(0, 255)),
+		validation.Field(&p.MinPrice, validation.Min(0.0)),
+		validation.Field(&p.MaxPrice, validation.Min(0.0)),
+	)
 	if err != nil {
 		return customErrors.NewValidationErrorWrap(err, "validation error")
 	}
 
+	if p.MinPrice != nil && p.MaxPrice != nil && *p.MinPrice > *p.MaxPrice {
+		return customErrors.NewValidationError("minPrice can't be greater than maxPrice")
+	}
+
 	return nil
 }
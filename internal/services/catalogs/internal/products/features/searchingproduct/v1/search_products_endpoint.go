@@ -31,7 +31,7 @@ func (ep *searchProductsEndpoint) MapEndpoint() {
 // SearchProducts
 // @Tags Products
 // @Summary Search products
-// @Description Search products
+// @Description Full text search products with relevance ranking, facets and highlighting
 // @Accept json
 // @Produce json
 // @Param searchProductsRequestDto query dtos.SearchProductsRequestDto false "SearchProductsRequestDto"
@@ -63,6 +63,10 @@ func (ep *searchProductsEndpoint) handler() echo.HandlerFunc {
 
 		query, err := NewSearchProductsWithValidation(
 			request.SearchText,
+			request.Artist,
+			request.Category,
+			request.MinPrice,
+			request.MaxPrice,
 			request.ListQuery,
 		)
 		if err != nil {
@@ -2,36 +2,33 @@ package v1
 
 import (
 	"context"
-	"fmt"
-	"reflect"
-	"strings"
 
-	datamodel "github.com/reoden/go-NFT/catalogs/internal/products/data/datamodels"
+	"github.com/reoden/go-NFT/catalogs/internal/products/contracts"
 	dto "github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1"
 	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
 	"github.com/reoden/go-NFT/catalogs/internal/products/features/searchingproduct/v1/dtos"
 	"github.com/reoden/go-NFT/catalogs/internal/products/models"
 	"github.com/reoden/go-NFT/pkg/core/cqrs"
 	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
-	gormPostgres "github.com/reoden/go-NFT/pkg/postgresgorm/helpers/gormextensions"
-	reflectionHelper "github.com/reoden/go-NFT/pkg/reflection/reflectionhelper"
-	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/mapper"
 	"github.com/reoden/go-NFT/pkg/utils"
 
-	"github.com/iancoleman/strcase"
 	"github.com/mehdihadeli/go-mediatr"
-	"gorm.io/gorm"
 )
 
 type searchProductsHandler struct {
 	fxparams.ProductHandlerParams
+	searchRepository contracts.ProductSearchRepository
 }
 
 func NewSearchProductsHandler(
 	params fxparams.ProductHandlerParams,
+	searchRepository contracts.ProductSearchRepository,
 ) cqrs.RequestHandlerWithRegisterer[*SearchProducts, *dtos.SearchProductsResponseDto] {
 	return &searchProductsHandler{
 		ProductHandlerParams: params,
+		searchRepository:     searchRepository,
 	}
 }
 
@@ -45,54 +42,52 @@ func (c *searchProductsHandler) Handle(
 	ctx context.Context,
 	query *SearchProducts,
 ) (*dtos.SearchProductsResponseDto, error) {
-	dbQuery := c.prepareSearchDBQuery(query)
+	criteria := &models.ProductSearchCriteria{
+		SearchText: query.SearchText,
+		Artist:     query.Artist,
+		Category:   query.Category,
+		MinPrice:   query.MinPrice,
+		MaxPrice:   query.MaxPrice,
+		Page:       query.GetPage(),
+		Size:       query.GetSize(),
+	}
 
-	products, err := gormPostgres.Paginate[*datamodel.ProductDataModel, *models.Product](
-		ctx,
-		query.ListQuery,
-		dbQuery,
-	)
+	result, err := c.searchRepository.SearchProducts(ctx, criteria)
 	if err != nil {
 		return nil, customErrors.NewApplicationErrorWrap(
 			err,
-			"error in searching products in the repository",
+			"error in searching products in the search index",
 		)
 	}
 
-	listResultDto, err := utils.ListResultToListResultDto[*dto.ProductDto](
-		products,
-	)
+	items, err := mapper.Map[[]*dto.ProductSearchItemDto](result.Hits)
 	if err != nil {
 		return nil, customErrors.NewApplicationErrorWrap(
 			err,
-			"error in the mapping ListResultToListResultDto",
+			"error in the mapping ProductSearchItemDto",
 		)
 	}
 
-	c.Log.Info("products fetched")
-
-	return &dtos.SearchProductsResponseDto{Products: listResultDto}, nil
-}
-
-func (c *searchProductsHandler) prepareSearchDBQuery(
-	query *SearchProducts,
-) *gorm.DB {
-	fields := reflectionHelper.GetAllFields(
-		typeMapper.GetGenericTypeByT[*datamodel.ProductDataModel](),
-	)
-
-	dbQuery := c.CatalogsDBContext.DB()
-
-	for _, field := range fields {
-		if field.Type.Kind() != reflect.String {
-			continue
-		}
-
-		dbQuery = dbQuery.Or(
-			fmt.Sprintf("%s LIKE ?", strcase.ToSnake(field.Name)),
-			"%"+strings.ToLower(query.SearchText)+"%",
+	facets, err := mapper.Map[*dto.ProductSearchFacetsDto](result.Facets)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in the mapping ProductSearchFacetsDto",
 		)
 	}
 
-	return dbQuery
+	c.Log.Infow(
+		"products searched",
+		logger.Fields{"SearchText": query.SearchText, "TotalItems": result.TotalItems},
+	)
+
+	return &dtos.SearchProductsResponseDto{
+		Products: utils.NewListResult(
+			items,
+			result.Size,
+			result.Page,
+			result.TotalItems,
+		),
+		Facets: facets,
+	}, nil
 }
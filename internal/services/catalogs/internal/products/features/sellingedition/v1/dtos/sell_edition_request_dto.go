@@ -0,0 +1,10 @@
+package dtos
+
+import (
+	uuid "github.com/satori/go.uuid"
+)
+
+// SellEditionRequestDto validation will handle in command level
+type SellEditionRequestDto struct {
+	EditionID uuid.UUID `json:"-" param:"id"`
+}
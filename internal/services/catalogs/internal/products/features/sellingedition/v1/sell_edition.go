@@ -0,0 +1,53 @@
+package v1
+
+import (
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+// SellEdition sells the listed edition to the buyer with its listing price
+type SellEdition struct {
+	cqrs.Command
+	EditionID uuid.UUID
+	Buyer     string
+}
+
+func NewSellEdition(
+	editionID uuid.UUID,
+	buyer string,
+) *SellEdition {
+	command := &SellEdition{
+		Command:   cqrs.NewCommandByT[SellEdition](),
+		EditionID: editionID,
+		Buyer:     buyer,
+	}
+
+	return command
+}
+
+// NewSellEditionWithValidation sells the listed edition to the buyer with its listing price with inline validation - for defensive programming and ensuring validation even without using middleware
+func NewSellEditionWithValidation(
+	editionID uuid.UUID,
+	buyer string,
+) (*SellEdition, error) {
+	command := NewSellEdition(editionID, buyer)
+	err := command.Validate()
+
+	return command, err
+}
+
+func (c *SellEdition) Validate() error {
+	err := validation.ValidateStruct(
+		c,
+		validation.Field(&c.EditionID, validation.Required),
+		validation.Field(&c.Buyer, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
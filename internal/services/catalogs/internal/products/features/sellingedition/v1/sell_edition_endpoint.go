@@ -0,0 +1,86 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/sellingedition/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/web/route"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/utils"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type sellEditionEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewSellEditionEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &sellEditionEndpoint{ProductRouteParams: params}
+}
+
+func (ep *sellEditionEndpoint) MapEndpoint() {
+	ep.EditionsGroup.POST("/:id/sell", ep.handler())
+}
+
+// SellEdition
+// @Tags Editions
+// @Summary Sell edition
+// @Description Sell the listed edition to the buyer with its listing price, the buyer becomes the owner of edition
+// @Accept json
+// @Produce json
+// @Param SellEditionRequestDto body dtos.SellEditionRequestDto true "Sell data"
+// @Param id path string true "Edition ID"
+// @Success 200 {object} dtos.SellEditionResponseDto
+// @Security BearerAuth
+// @Router /api/v1/editions/{id}/sell [post]
+func (ep *sellEditionEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.SellEditionRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		// the buyer is the authenticated user, not a value of the request
+		_, userId, err := utils.ParseJWTToken(c)
+		if err != nil {
+			return customErrors.NewUnAuthorizedErrorWrap(
+				err,
+				"error in reading the user of the token",
+			)
+		}
+
+		command, err := NewSellEditionWithValidation(
+			request.EditionID,
+			userId.String(),
+		)
+		if err != nil {
+			return err
+		}
+
+		result, err := mediatr.Send[*SellEdition, *dtos.SellEditionResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending SellEdition",
+			)
+		}
+
+		return c.JSON(http.StatusOK, result)
+	}
+}
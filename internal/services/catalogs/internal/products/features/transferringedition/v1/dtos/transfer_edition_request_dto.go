@@ -0,0 +1,12 @@
+package dtos
+
+import (
+	uuid "github.com/satori/go.uuid"
+)
+
+// TransferEditionRequestDto validation will handle in command level
+type TransferEditionRequestDto struct {
+	EditionID uuid.UUID `json:"-" param:"id"`
+	// To the user id of the new owner
+	To string `json:"to"`
+}
@@ -0,0 +1,15 @@
+package dtos
+
+import (
+	dtoV1 "github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1"
+	"github.com/reoden/go-NFT/pkg/core/serializer/json"
+)
+
+// TransferEditionResponseDto the state of edition after the change
+type TransferEditionResponseDto struct {
+	Edition *dtoV1.EditionDto `json:"edition"`
+}
+
+func (c *TransferEditionResponseDto) String() string {
+	return json.PrettyPrint(c)
+}
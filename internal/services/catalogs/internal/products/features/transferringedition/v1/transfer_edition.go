@@ -0,0 +1,58 @@
+package v1
+
+import (
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+// TransferEdition transfers the edition to a new owner without a sale
+type TransferEdition struct {
+	cqrs.Command
+	EditionID uuid.UUID
+	From      string
+	To        string
+}
+
+func NewTransferEdition(
+	editionID uuid.UUID,
+	from string,
+	to string,
+) *TransferEdition {
+	command := &TransferEdition{
+		Command:   cqrs.NewCommandByT[TransferEdition](),
+		EditionID: editionID,
+		From:      from,
+		To:        to,
+	}
+
+	return command
+}
+
+// NewTransferEditionWithValidation transfers the edition to a new owner without a sale with inline validation - for defensive programming and ensuring validation even without using middleware
+func NewTransferEditionWithValidation(
+	editionID uuid.UUID,
+	from string,
+	to string,
+) (*TransferEdition, error) {
+	command := NewTransferEdition(editionID, from, to)
+	err := command.Validate()
+
+	return command, err
+}
+
+func (c *TransferEdition) Validate() error {
+	err := validation.ValidateStruct(
+		c,
+		validation.Field(&c.EditionID, validation.Required),
+		validation.Field(&c.From, validation.Required),
+		validation.Field(&c.To, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/transferringedition/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/web/route"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/utils"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type transferEditionEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewTransferEditionEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &transferEditionEndpoint{ProductRouteParams: params}
+}
+
+func (ep *transferEditionEndpoint) MapEndpoint() {
+	ep.EditionsGroup.POST("/:id/transfer", ep.handler())
+}
+
+// TransferEdition
+// @Tags Editions
+// @Summary Transfer edition
+// @Description Transfer the edition to a new owner without a sale, a frozen, listed or burned edition cannot be transferred
+// @Accept json
+// @Produce json
+// @Param TransferEditionRequestDto body dtos.TransferEditionRequestDto true "Transfer data"
+// @Param id path string true "Edition ID"
+// @Success 200 {object} dtos.TransferEditionResponseDto
+// @Security BearerAuth
+// @Router /api/v1/editions/{id}/transfer [post]
+func (ep *transferEditionEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.TransferEditionRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		// the owner is the authenticated user, not a value of the request
+		_, userId, err := utils.ParseJWTToken(c)
+		if err != nil {
+			return customErrors.NewUnAuthorizedErrorWrap(
+				err,
+				"error in reading the user of the token",
+			)
+		}
+
+		command, err := NewTransferEditionWithValidation(
+			request.EditionID,
+			userId.String(),
+			request.To,
+		)
+		if err != nil {
+			return err
+		}
+
+		result, err := mediatr.Send[*TransferEdition, *dtos.TransferEditionResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending TransferEdition",
+			)
+		}
+
+		return c.JSON(http.StatusOK, result)
+	}
+}
@@ -0,0 +1,10 @@
+package dtos
+
+import (
+	uuid "github.com/satori/go.uuid"
+)
+
+// UnfreezeEditionRequestDto validation will handle in command level
+type UnfreezeEditionRequestDto struct {
+	EditionID uuid.UUID `json:"-" param:"id"`
+}
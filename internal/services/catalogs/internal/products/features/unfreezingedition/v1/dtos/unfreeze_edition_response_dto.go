@@ -0,0 +1,15 @@
+package dtos
+
+import (
+	dtoV1 "github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1"
+	"github.com/reoden/go-NFT/pkg/core/serializer/json"
+)
+
+// UnfreezeEditionResponseDto the state of edition after the change
+type UnfreezeEditionResponseDto struct {
+	Edition *dtoV1.EditionDto `json:"edition"`
+}
+
+func (c *UnfreezeEditionResponseDto) String() string {
+	return json.PrettyPrint(c)
+}
@@ -0,0 +1,48 @@
+package v1
+
+import (
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+// UnfreezeEdition lifts the freeze of edition
+type UnfreezeEdition struct {
+	cqrs.Command
+	EditionID uuid.UUID
+}
+
+func NewUnfreezeEdition(
+	editionID uuid.UUID,
+) *UnfreezeEdition {
+	command := &UnfreezeEdition{
+		Command:   cqrs.NewCommandByT[UnfreezeEdition](),
+		EditionID: editionID,
+	}
+
+	return command
+}
+
+// NewUnfreezeEditionWithValidation lifts the freeze of edition with inline validation - for defensive programming and ensuring validation even without using middleware
+func NewUnfreezeEditionWithValidation(
+	editionID uuid.UUID,
+) (*UnfreezeEdition, error) {
+	command := NewUnfreezeEdition(editionID)
+	err := command.Validate()
+
+	return command, err
+}
+
+func (c *UnfreezeEdition) Validate() error {
+	err := validation.ValidateStruct(
+		c,
+		validation.Field(&c.EditionID, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
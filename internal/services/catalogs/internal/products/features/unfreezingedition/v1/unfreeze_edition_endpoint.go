@@ -0,0 +1,76 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/unfreezingedition/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/constants"
+	"github.com/reoden/go-NFT/pkg/core/web/route"
+	"github.com/reoden/go-NFT/pkg/http/customecho/middlewares/auth"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type unfreezeEditionEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewUnfreezeEditionEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &unfreezeEditionEndpoint{ProductRouteParams: params}
+}
+
+func (ep *unfreezeEditionEndpoint) MapEndpoint() {
+	ep.EditionsGroup.POST("/:id/unfreeze", ep.handler(), auth.RequireRoles(constants.AdminRole))
+}
+
+// UnfreezeEdition
+// @Tags Editions
+// @Summary Unfreeze edition
+// @Description Lift the freeze of edition
+// @Accept json
+// @Produce json
+// @Param id path string true "Edition ID"
+// @Success 200 {object} dtos.UnfreezeEditionResponseDto
+// @Security BearerAuth
+// @Router /api/v1/editions/{id}/unfreeze [post]
+func (ep *unfreezeEditionEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.UnfreezeEditionRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		command, err := NewUnfreezeEditionWithValidation(
+			request.EditionID,
+		)
+		if err != nil {
+			return err
+		}
+
+		result, err := mediatr.Send[*UnfreezeEdition, *dtos.UnfreezeEditionResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending UnfreezeEdition",
+			)
+		}
+
+		return c.JSON(http.StatusOK, result)
+	}
+}
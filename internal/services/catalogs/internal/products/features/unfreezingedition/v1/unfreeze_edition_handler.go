@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	dtoV1 "github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1"
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/unfreezingedition/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/mapper"
+
+	"emperror.dev/errors"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type unfreezeEditionHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewUnfreezeEditionHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*UnfreezeEdition, *dtos.UnfreezeEditionResponseDto] {
+	return &unfreezeEditionHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *unfreezeEditionHandler) RegisterHandler() error {
+	return mediatr.RegisterRequestHandler[*UnfreezeEdition, *dtos.UnfreezeEditionResponseDto](
+		c,
+	)
+}
+
+func (c *unfreezeEditionHandler) Handle(
+	ctx context.Context,
+	command *UnfreezeEdition,
+) (*dtos.UnfreezeEditionResponseDto, error) {
+	edition, err := c.EditionAggregateStore.Load(ctx, command.EditionID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = edition.Unfreeze()
+	if err != nil {
+		return nil, err
+	}
+
+	// the edition is stored with its loaded version, so a concurrent change of the edition is a conflict
+	_, err = c.EditionAggregateStore.Store(edition, nil, ctx)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error in storing the edition")
+	}
+
+	editionDto, err := mapper.Map[*dtoV1.EditionDto](edition)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in the mapping EditionDto",
+		)
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf("edition with id '%s' unfrozen", command.EditionID),
+		logger.Fields{"Id": command.EditionID},
+	)
+
+	return &dtos.UnfreezeEditionResponseDto{Edition: editionDto}, nil
+}
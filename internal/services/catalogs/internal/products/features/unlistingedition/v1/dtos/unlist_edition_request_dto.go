@@ -0,0 +1,10 @@
+package dtos
+
+import (
+	uuid "github.com/satori/go.uuid"
+)
+
+// UnlistEditionRequestDto validation will handle in command level
+type UnlistEditionRequestDto struct {
+	EditionID uuid.UUID `json:"-" param:"id"`
+}
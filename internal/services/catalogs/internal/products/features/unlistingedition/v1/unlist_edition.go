@@ -0,0 +1,53 @@
+package v1
+
+import (
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+// UnlistEdition withdraws the listed edition from sale by its owner
+type UnlistEdition struct {
+	cqrs.Command
+	EditionID uuid.UUID
+	Seller    string
+}
+
+func NewUnlistEdition(
+	editionID uuid.UUID,
+	seller string,
+) *UnlistEdition {
+	command := &UnlistEdition{
+		Command:   cqrs.NewCommandByT[UnlistEdition](),
+		EditionID: editionID,
+		Seller:    seller,
+	}
+
+	return command
+}
+
+// NewUnlistEditionWithValidation withdraws the listed edition from sale by its owner with inline validation - for defensive programming and ensuring validation even without using middleware
+func NewUnlistEditionWithValidation(
+	editionID uuid.UUID,
+	seller string,
+) (*UnlistEdition, error) {
+	command := NewUnlistEdition(editionID, seller)
+	err := command.Validate()
+
+	return command, err
+}
+
+func (c *UnlistEdition) Validate() error {
+	err := validation.ValidateStruct(
+		c,
+		validation.Field(&c.EditionID, validation.Required),
+		validation.Field(&c.Seller, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/unlistingedition/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/web/route"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/utils"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type unlistEditionEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewUnlistEditionEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &unlistEditionEndpoint{ProductRouteParams: params}
+}
+
+func (ep *unlistEditionEndpoint) MapEndpoint() {
+	ep.EditionsGroup.POST("/:id/unlist", ep.handler())
+}
+
+// UnlistEdition
+// @Tags Editions
+// @Summary Unlist edition
+// @Description Withdraw the listed edition from sale by its owner, a frozen edition can be unlisted
+// @Accept json
+// @Produce json
+// @Param UnlistEditionRequestDto body dtos.UnlistEditionRequestDto true "Unlist data"
+// @Param id path string true "Edition ID"
+// @Success 200 {object} dtos.UnlistEditionResponseDto
+// @Security BearerAuth
+// @Router /api/v1/editions/{id}/unlist [post]
+func (ep *unlistEditionEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.UnlistEditionRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		// the owner is the authenticated user, not a value of the request
+		_, userId, err := utils.ParseJWTToken(c)
+		if err != nil {
+			return customErrors.NewUnAuthorizedErrorWrap(
+				err,
+				"error in reading the user of the token",
+			)
+		}
+
+		command, err := NewUnlistEditionWithValidation(
+			request.EditionID,
+			userId.String(),
+		)
+		if err != nil {
+			return err
+		}
+
+		result, err := mediatr.Send[*UnlistEdition, *dtos.UnlistEditionResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending UnlistEdition",
+			)
+		}
+
+		return c.JSON(http.StatusOK, result)
+	}
+}
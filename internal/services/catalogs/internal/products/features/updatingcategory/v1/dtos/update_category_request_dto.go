@@ -0,0 +1,11 @@
+package dtos
+
+import uuid "github.com/satori/go.uuid"
+
+type UpdateCategoryRequestDto struct {
+	CategoryID  uuid.UUID  `json:"-"           param:"id"`
+	Name        string     `json:"name"`
+	Slug        string     `json:"slug"`
+	Description string     `json:"description"`
+	ParentID    *uuid.UUID `json:"parentId"`
+}
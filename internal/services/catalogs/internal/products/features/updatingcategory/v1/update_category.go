@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+type UpdateCategory struct {
+	CategoryID  uuid.UUID
+	Name        string
+	Slug        string
+	Description string
+	// ParentID nil moves the category to the root of the categories tree
+	ParentID  *uuid.UUID
+	UpdatedAt time.Time
+}
+
+func NewUpdateCategory(
+	categoryID uuid.UUID,
+	name string,
+	slug string,
+	description string,
+	parentID *uuid.UUID,
+) *UpdateCategory {
+	if slug == "" {
+		slug = models.NewCategorySlug(name)
+	}
+
+	command := &UpdateCategory{
+		CategoryID:  categoryID,
+		Name:        name,
+		Slug:        slug,
+		Description: description,
+		ParentID:    parentID,
+		UpdatedAt:   time.Now(),
+	}
+
+	return command
+}
+
+func NewUpdateCategoryWithValidation(
+	categoryID uuid.UUID,
+	name string,
+	slug string,
+	description string,
+	parentID *uuid.UUID,
+) (*UpdateCategory, error) {
+	command := NewUpdateCategory(categoryID, name, slug, description, parentID)
+	err := command.Validate()
+
+	return command, err
+}
+
+// IsTxRequest for enabling transactions on the mediatr pipeline
+func (c *UpdateCategory) IsTxRequest() {
+}
+
+func (c *UpdateCategory) Validate() error {
+	err := validation.ValidateStruct(
+		c,
+		validation.Field(&c.CategoryID, validation.Required),
+		validation.Field(&c.Name, validation.Required, validation.Length(0, 100)),
+		validation.Field(
+			&c.Slug,
+			validation.Required,
+			validation.Length(0, 100),
+			validation.Match(models.CategorySlugRegex),
+		),
+		validation.Field(&c.Description, validation.Length(0, 1000)),
+		validation.Field(&c.UpdatedAt, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
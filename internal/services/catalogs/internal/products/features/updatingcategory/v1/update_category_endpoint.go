@@ -0,0 +1,78 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/updatingcategory/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/web/route"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type updateCategoryEndpoint struct {
+	fxparams.ProductRouteParams
+}
+
+func NewUpdateCategoryEndpoint(
+	params fxparams.ProductRouteParams,
+) route.Endpoint {
+	return &updateCategoryEndpoint{ProductRouteParams: params}
+}
+
+func (ep *updateCategoryEndpoint) MapEndpoint() {
+	ep.CategoriesGroup.PUT("/:id", ep.handler())
+}
+
+// UpdateCategory
+// @Tags Categories
+// @Summary Update category
+// @Description Update existing category, changing the parent moves the category with its sub-categories
+// @Accept json
+// @Produce json
+// @Param UpdateCategoryRequestDto body dtos.UpdateCategoryRequestDto true "Category data"
+// @Param id path string true "Category ID"
+// @Success 204
+// @Router /api/v1/categories/{id} [put]
+func (ep *updateCategoryEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		request := &dtos.UpdateCategoryRequestDto{}
+		if err := c.Bind(request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		command, err := NewUpdateCategoryWithValidation(
+			request.CategoryID,
+			request.Name,
+			request.Slug,
+			request.Description,
+			request.ParentID,
+		)
+		if err != nil {
+			return err
+		}
+
+		_, err = mediatr.Send[*UpdateCategory, *mediatr.Unit](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending UpdateCategory",
+			)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,140 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/associations"
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/datamodels"
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/updatingproduct/v1/events/integrationevents"
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/postgresgorm/gormdbcontext"
+
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type updateCategoryHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewUpdateCategoryHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*UpdateCategory, *mediatr.Unit] {
+	return &updateCategoryHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *updateCategoryHandler) RegisterHandler() error {
+	return mediatr.RegisterRequestHandler[*UpdateCategory, *mediatr.Unit](
+		c,
+	)
+}
+
+func (c *updateCategoryHandler) Handle(
+	ctx context.Context,
+	command *UpdateCategory,
+) (*mediatr.Unit, error) {
+	category, err := gormdbcontext.FindModelByID[*datamodels.CategoryDataModel, *models.Category](
+		ctx,
+		c.CatalogsDBContext,
+		command.CategoryID,
+	)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrapWithCode(
+			err,
+			http.StatusNotFound,
+			fmt.Sprintf("category with id `%s` not found", command.CategoryID),
+		)
+	}
+
+	var parentPath string
+	if command.ParentID != nil {
+		parent, err := gormdbcontext.FindModelByID[*datamodels.CategoryDataModel, *models.Category](
+			ctx,
+			c.CatalogsDBContext,
+			*command.ParentID,
+		)
+		if err != nil {
+			return nil, customErrors.NewApplicationErrorWrapWithCode(
+				err,
+				http.StatusNotFound,
+				fmt.Sprintf("parent category with id `%s` not found", command.ParentID),
+			)
+		}
+
+		if category.IsSelfOrAncestorOf(parent.Path) {
+			return nil, customErrors.NewBadRequestError(
+				"category can't be moved under itself or one of its sub-categories",
+			)
+		}
+		parentPath = parent.Path
+	}
+
+	oldPath := category.Path
+	newPath := models.NewCategoryPath(parentPath, command.Slug)
+
+	db := c.CatalogsDBContext.WithTxIfExists(ctx).DB().WithContext(ctx)
+
+	// map updates, so clearing the parent and the description is also persisted - https://gorm.io/docs/update.html#Updates-multiple-columns
+	err = db.Model(&datamodels.CategoryDataModel{Id: command.CategoryID}).
+		Updates(map[string]interface{}{
+			"name":        command.Name,
+			"slug":        command.Slug,
+			"description": command.Description,
+			"parent_id":   command.ParentID,
+			"path":        newPath,
+			"updated_at":  command.UpdatedAt,
+		}).Error
+	if err != nil {
+		return nil, customErrors.NewConflictErrorWrap(
+			err,
+			fmt.Sprintf("error in updating category with id `%s`", command.CategoryID),
+		)
+	}
+
+	if newPath != oldPath {
+		err = db.Exec(
+			"UPDATE categories SET path = ? || substr(path, ?) WHERE path LIKE ?",
+			newPath,
+			len(oldPath)+1,
+			oldPath+models.CategoryPathSeparator+"%",
+		).Error
+		if err != nil {
+			return nil, customErrors.NewApplicationErrorWrap(
+				err,
+				"error in updating sub-categories paths",
+			)
+		}
+	}
+
+	productIds, err := associations.FindProductIdsInCategoryTree(ctx, db, newPath)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in finding category products")
+	}
+
+	err = associations.IncrementProductVersions(ctx, db, productIds)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in updating category products")
+	}
+
+	err = integrationevents.PublishProductsUpdated(ctx, db, c.RabbitmqProducer, productIds)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in publishing 'ProductUpdated' messages for the category products",
+		)
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf("category with id '%s' updated", command.CategoryID),
+		logger.Fields{"Id": command.CategoryID, "Path": newPath, "AffectedProducts": len(productIds)},
+	)
+
+	return &mediatr.Unit{}, nil
+}
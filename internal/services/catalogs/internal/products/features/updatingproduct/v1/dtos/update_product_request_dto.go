@@ -1,12 +1,24 @@
 package dtos
 
-import uuid "github.com/satori/go.uuid"
+import (
+	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
+)
 
 // https://echo.labstack.com/guide/binding/
 
+// UpdateProductRequestDto `categoryIds` and `tags` are optional, when they are omitted the current values are kept.
+// `version` is the version of the product that the update is based on, it can be sent in the `If-Match` header instead.
 type UpdateProductRequestDto struct {
 	ProductID   uuid.UUID `json:"-"           param:"id"`
 	Name        string    `json:"name"`
 	Description string    `json:"description"`
-	Price       float64   `json:"price"`
+	Artist      string    `json:"artist"`
+	// Price exact amount of the price, it can be sent as a number or a string like `"12.50"`
+	Price decimal.Decimal `json:"price"`
+	// Currency code of the price like `USD` or `ETH`, the default is `USD`
+	Currency    string      `json:"currency"`
+	Version     int64       `json:"version"`
+	CategoryIds []uuid.UUID `json:"categoryIds"`
+	Tags        []string    `json:"tags"`
 }
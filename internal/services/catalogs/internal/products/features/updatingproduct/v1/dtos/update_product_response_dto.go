@@ -0,0 +1,12 @@
+package dtos
+
+import "github.com/reoden/go-NFT/pkg/core/serializer/json"
+
+type UpdateProductResponseDto struct {
+	// Version of the product after the update, it is returned as the `ETag` of the product
+	Version int64 `json:"version"`
+}
+
+func (c *UpdateProductResponseDto) String() string {
+	return json.PrettyPrint(c)
+}
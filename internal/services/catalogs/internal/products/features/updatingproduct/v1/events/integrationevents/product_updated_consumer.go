@@ -0,0 +1,61 @@
+package integrationevents
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/contracts"
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
+	"github.com/reoden/go-NFT/pkg/core/messaging/consumer"
+	"github.com/reoden/go-NFT/pkg/core/messaging/types"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/mapper"
+
+	"emperror.dev/errors"
+)
+
+// productUpdatedConsumer replaces the product document in the products search index
+type productUpdatedConsumer struct {
+	log              logger.Logger
+	searchRepository contracts.ProductSearchRepository
+}
+
+func NewProductUpdatedConsumer(
+	log logger.Logger,
+	searchRepository contracts.ProductSearchRepository,
+) consumer.ConsumerHandler {
+	return &productUpdatedConsumer{
+		log:              log,
+		searchRepository: searchRepository,
+	}
+}
+
+func (c *productUpdatedConsumer) Handle(
+	ctx context.Context,
+	consumeContext types.MessageConsumeContext,
+) error {
+	message, ok := consumeContext.Message().(*ProductUpdatedV1)
+	if !ok || message.ProductDto == nil {
+		return errors.New("error in casting message to ProductUpdatedV1")
+	}
+
+	product, err := mapper.Map[*models.Product](message.ProductDto)
+	if err != nil {
+		return errors.WrapIf(err, "error in the mapping Product")
+	}
+
+	err = c.searchRepository.IndexProduct(ctx, product)
+	if err != nil {
+		return errors.WrapIf(err, "error in indexing updated product")
+	}
+
+	c.log.Infow(
+		fmt.Sprintf(
+			"ProductUpdated message with messageId `%s` projected to the search index",
+			message.MessageId,
+		),
+		logger.Fields{"MessageId": message.MessageId, "Id": product.Id},
+	)
+
+	return nil
+}
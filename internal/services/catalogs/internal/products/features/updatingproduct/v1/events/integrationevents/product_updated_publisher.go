@@ -0,0 +1,46 @@
+package integrationevents
+
+import (
+	"context"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/associations"
+	dto "github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1"
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
+	"github.com/reoden/go-NFT/pkg/core/messaging/producer"
+	"github.com/reoden/go-NFT/pkg/mapper"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+	"gorm.io/gorm"
+)
+
+// PublishProductsUpdated publishes a `ProductUpdatedV1` for each of the products, it is used when a change on a shared category or tag changes the products representation
+func PublishProductsUpdated(
+	ctx context.Context,
+	db *gorm.DB,
+	rabbitmqProducer producer.Producer,
+	productIds []uuid.UUID,
+) error {
+	products, err := associations.FindProductsWithAssociations(ctx, db, productIds)
+	if err != nil {
+		return err
+	}
+
+	productModels, err := mapper.Map[[]*models.Product](products)
+	if err != nil {
+		return errors.WrapIf(err, "error in the mapping Product")
+	}
+
+	productDtos, err := mapper.Map[[]*dto.ProductDto](productModels)
+	if err != nil {
+		return errors.WrapIf(err, "error in the mapping ProductDto")
+	}
+
+	for _, productDto := range productDtos {
+		if err := rabbitmqProducer.PublishMessage(ctx, NewProductUpdatedV1(productDto), nil); err != nil {
+			return errors.WrapIf(err, "error in publishing 'ProductUpdated' message")
+		}
+	}
+
+	return nil
+}
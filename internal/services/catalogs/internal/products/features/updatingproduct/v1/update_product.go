@@ -3,31 +3,57 @@ package v1
 import (
 	"time"
 
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
 	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
 
 	validation "github.com/go-ozzo/ozzo-validation"
 	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	maxProductCategories = 10
+	maxProductTags       = 20
 )
 
 type UpdateProduct struct {
 	ProductID   uuid.UUID
 	Name        string
 	Description string
-	Price       float64
-	UpdatedAt   time.Time
+	Artist      string
+	Price       decimal.Decimal
+	Currency    string
+	// Version of the product that the update is based on, the update fails with a concurrency error when the
+	// product was changed after this version
+	Version int64
+	// CategoryIds replaces the product categories, nil keeps the current categories
+	CategoryIds []uuid.UUID
+	// Tags replaces the product tags, nil keeps the current tags
+	Tags      []string
+	UpdatedAt time.Time
 }
 
 func NewUpdateProduct(
 	productID uuid.UUID,
 	name string,
 	description string,
-	price float64,
+	artist string,
+	price decimal.Decimal,
+	currency string,
+	version int64,
+	categoryIds []uuid.UUID,
+	tags []string,
 ) *UpdateProduct {
 	command := &UpdateProduct{
 		ProductID:   productID,
 		Name:        name,
 		Description: description,
+		Artist:      artist,
 		Price:       price,
+		Currency:    models.NormalizeCurrency(currency),
+		Version:     version,
+		CategoryIds: categoryIds,
+		Tags:        tags,
 		UpdatedAt:   time.Now(),
 	}
 
@@ -38,16 +64,31 @@ func NewUpdateProductWithValidation(
 	productID uuid.UUID,
 	name string,
 	description string,
-	price float64,
+	artist string,
+	price decimal.Decimal,
+	currency string,
+	version int64,
+	categoryIds []uuid.UUID,
+	tags []string,
 ) (*UpdateProduct, error) {
-	command := NewUpdateProduct(productID, name, description, price)
+	command := NewUpdateProduct(
+		productID,
+		name,
+		description,
+		artist,
+		price,
+		currency,
+		version,
+		categoryIds,
+		tags,
+	)
 	err := command.Validate()
 
 	return command, err
 }
 
 // IsTxRequest for enabling transactions on the mediatr pipeline
-func (c *UpdateProduct) isTxRequest() {
+func (c *UpdateProduct) IsTxRequest() {
 }
 
 func (c *UpdateProduct) Validate() error {
@@ -64,7 +105,16 @@ func (c *UpdateProduct) Validate() error {
 			validation.Required,
 			validation.Length(0, 5000),
 		),
-		validation.Field(&c.Price, validation.Required, validation.Min(0.0)),
+		validation.Field(&c.Artist, validation.Length(0, 255)),
+		validation.Field(&c.Price, models.PositiveAmount),
+		validation.Field(&c.Currency, validation.Required, validation.Match(models.CurrencyRegex)),
+		validation.Field(&c.Version, validation.Required, validation.Min(int64(1))),
+		validation.Field(&c.CategoryIds, validation.Length(0, maxProductCategories)),
+		validation.Field(
+			&c.Tags,
+			validation.Length(0, maxProductTags),
+			validation.Each(validation.Required, validation.Length(1, 50)),
+		),
 		validation.Field(&c.UpdatedAt, validation.Required),
 	)
 	if err != nil {
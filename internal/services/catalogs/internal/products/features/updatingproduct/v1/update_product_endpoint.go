@@ -6,6 +6,7 @@ import (
 	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
 	"github.com/reoden/go-NFT/catalogs/internal/products/features/updatingproduct/v1/dtos"
 	"github.com/reoden/go-NFT/pkg/core/web/route"
+	"github.com/reoden/go-NFT/pkg/http/customecho/etag"
 	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
 
 	"emperror.dev/errors"
@@ -30,12 +31,14 @@ func (ep *updateProductEndpoint) MapEndpoint() {
 // UpdateProduct
 // @Tags Products
 // @Summary Update product
-// @Description Update existing product
+// @Description Update existing product, the update is only applied when the product is still on the version that is sent in the `If-Match` header or the `version` field, otherwise it fails with 409
 // @Accept json
 // @Produce json
 // @Param UpdateProductRequestDto body dtos.UpdateProductRequestDto true "Product data"
 // @Param id path string true "Product ID"
+// @Param If-Match header string false "ETag of the product version the update is based on"
 // @Success 204
+// @Header 204 {string} ETag "ETag of the updated product version"
 // @Router /api/v1/products/{id} [put]
 func (ep *updateProductEndpoint) handler() echo.HandlerFunc {
 	return func(c echo.Context) error {
@@ -51,17 +54,37 @@ func (ep *updateProductEndpoint) handler() echo.HandlerFunc {
 			return badRequestErr
 		}
 
+		// the `If-Match` header takes precedence over the version of the body
+		version, ok, err := etag.IfMatchVersion(c)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			version = request.Version
+		}
+		if version == 0 {
+			return customErrors.NewApplicationErrorWithCode(
+				"the product version is required in the `If-Match` header or the `version` field",
+				http.StatusPreconditionRequired,
+			)
+		}
+
 		command, err := NewUpdateProductWithValidation(
 			request.ProductID,
 			request.Name,
 			request.Description,
+			request.Artist,
 			request.Price,
+			request.Currency,
+			version,
+			request.CategoryIds,
+			request.Tags,
 		)
 		if err != nil {
 			return err
 		}
 
-		_, err = mediatr.Send[*UpdateProduct, *mediatr.Unit](
+		result, err := mediatr.Send[*UpdateProduct, *dtos.UpdateProductResponseDto](
 			ctx,
 			command,
 		)
@@ -72,6 +95,8 @@ func (ep *updateProductEndpoint) handler() echo.HandlerFunc {
 			)
 		}
 
+		etag.SetVersionETag(c, result.Version)
+
 		return c.NoContent(http.StatusNoContent)
 	}
 }
@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/associations"
 	"github.com/reoden/go-NFT/catalogs/internal/products/data/datamodels"
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/pricehistory"
 	dto "github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1"
 	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/updatingproduct/v1/dtos"
 	"github.com/reoden/go-NFT/catalogs/internal/products/features/updatingproduct/v1/events/integrationevents"
 	"github.com/reoden/go-NFT/catalogs/internal/products/models"
 	"github.com/reoden/go-NFT/pkg/core/cqrs"
@@ -17,6 +20,7 @@ import (
 	"github.com/reoden/go-NFT/pkg/postgresgorm/gormdbcontext"
 
 	"github.com/mehdihadeli/go-mediatr"
+	uuid "github.com/satori/go.uuid"
 )
 
 type updateProductHandler struct {
@@ -26,26 +30,22 @@ type updateProductHandler struct {
 
 func NewUpdateProductHandler(
 	params fxparams.ProductHandlerParams,
-) cqrs.RequestHandlerWithRegisterer[*UpdateProduct, *mediatr.Unit] {
+) cqrs.RequestHandlerWithRegisterer[*UpdateProduct, *dtos.UpdateProductResponseDto] {
 	return &updateProductHandler{
 		ProductHandlerParams: params,
 	}
 }
 
 func (c *updateProductHandler) RegisterHandler() error {
-	return mediatr.RegisterRequestHandler[*UpdateProduct, *mediatr.Unit](
+	return mediatr.RegisterRequestHandler[*UpdateProduct, *dtos.UpdateProductResponseDto](
 		c,
 	)
 }
 
-// IsTxRequest for enabling transactions on the mediatr pipeline
-func (c *updateProductHandler) isTxRequest() {
-}
-
 func (c *updateProductHandler) Handle(
 	ctx context.Context,
 	command *UpdateProduct,
-) (*mediatr.Unit, error) {
+) (*dtos.UpdateProductResponseDto, error) {
 	product, err := gormdbcontext.FindModelByID[*datamodels.ProductDataModel, *models.Product](
 		ctx,
 		c.CatalogsDBContext,
@@ -62,16 +62,32 @@ func (c *updateProductHandler) Handle(
 		)
 	}
 
+	priceChanged := !product.Price.Equal(command.Price) || product.Currency != command.Currency
+
 	product.Name = command.Name
 	product.Price = command.Price
+	product.Currency = command.Currency
 	product.Description = command.Description
+	product.Artist = command.Artist
 	product.UpdatedAt = command.UpdatedAt
+	// the update is only applied when the product is still on the version the client has read
+	product.Version = command.Version
 
-	updatedProduct, err := gormdbcontext.UpdateModel[*datamodels.ProductDataModel, *models.Product](
+	_, err = gormdbcontext.UpdateModel[*datamodels.ProductDataModel, *models.Product](
 		ctx,
 		c.CatalogsDBContext,
 		product,
 	)
+	if customErrors.IsConcurrencyError(err) {
+		return nil, customErrors.NewConcurrencyErrorWrap(
+			err,
+			fmt.Sprintf(
+				"product with id `%s` was changed after version %d, reload it and retry the update",
+				command.ProductID,
+				command.Version,
+			),
+		)
+	}
 	if err != nil {
 		return nil, customErrors.NewApplicationErrorWrap(
 			err,
@@ -79,6 +95,26 @@ func (c *updateProductHandler) Handle(
 		)
 	}
 
+	if priceChanged {
+		err = pricehistory.RecordPriceChange(
+			ctx,
+			c.CatalogsDBContext.WithTxIfExists(ctx).DB(),
+			command.ProductID,
+			command.Price,
+			command.Currency,
+			models.UpdatedPriceChangeReason,
+			command.UpdatedAt,
+		)
+		if err != nil {
+			return nil, customErrors.NewApplicationErrorWrap(err, "error in saving product price history")
+		}
+	}
+
+	updatedProduct, err := c.saveAssociations(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
 	productDto, err := mapper.Map[*dto.ProductDto](updatedProduct)
 	if err != nil {
 		return nil, customErrors.NewApplicationErrorWrap(
@@ -113,5 +149,43 @@ func (c *updateProductHandler) Handle(
 		logger.Fields{"MessageId": productUpdated.MessageId},
 	)
 
-	return &mediatr.Unit{}, err
+	return &dtos.UpdateProductResponseDto{Version: updatedProduct.Version}, nil
+}
+
+func (c *updateProductHandler) saveAssociations(
+	ctx context.Context,
+	command *UpdateProduct,
+) (*models.Product, error) {
+	db := c.CatalogsDBContext.WithTxIfExists(ctx).DB()
+
+	if command.CategoryIds != nil {
+		err := associations.ReplaceProductCategories(ctx, db, command.ProductID, command.CategoryIds)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if command.Tags != nil {
+		err := associations.ReplaceProductTags(ctx, db, command.ProductID, command.Tags)
+		if err != nil {
+			return nil, customErrors.NewApplicationErrorWrap(err, "error in saving product tags")
+		}
+	}
+
+	products, err := associations.FindProductsWithAssociations(ctx, db, []uuid.UUID{command.ProductID})
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in fetching the updated product")
+	}
+	if len(products) == 0 {
+		return nil, customErrors.NewNotFoundError(
+			fmt.Sprintf("product with id `%s` not found", command.ProductID),
+		)
+	}
+
+	product, err := mapper.Map[*models.Product](products[0])
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in the mapping Product")
+	}
+
+	return product, nil
 }
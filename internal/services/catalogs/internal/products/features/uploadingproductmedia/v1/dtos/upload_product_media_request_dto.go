@@ -0,0 +1,8 @@
+package dtos
+
+import uuid "github.com/satori/go.uuid"
+
+// UploadProductMediaRequestDto the file is sent as the `file` field of a multipart form
+type UploadProductMediaRequestDto struct {
+	ProductID uuid.UUID `param:"id" json:"-"`
+}
@@ -0,0 +1,16 @@
+package dtos
+
+import (
+	dtoV1 "github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1"
+	"github.com/reoden/go-NFT/pkg/core/serializer/json"
+)
+
+type UploadProductMediaResponseDto struct {
+	Media *dtoV1.MediaAssetDto `json:"media"`
+	// Duplicate is true when the product already had the same file and the existing media is returned
+	Duplicate bool `json:"duplicate"`
+}
+
+func (c *UploadProductMediaResponseDto) String() string {
+	return json.PrettyPrint(c)
+}
@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"io"
+
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+type UploadProductMedia struct {
+	ProductID uuid.UUID
+	FileName  string
+	Size      int64
+	// File content of the uploaded file, it should be seekable because it is read once for hashing and sniffing and
+	// once for storing
+	File io.ReadSeeker
+}
+
+func NewUploadProductMedia(
+	productID uuid.UUID,
+	fileName string,
+	size int64,
+	file io.ReadSeeker,
+) *UploadProductMedia {
+	command := &UploadProductMedia{
+		ProductID: productID,
+		FileName:  fileName,
+		Size:      size,
+		File:      file,
+	}
+
+	return command
+}
+
+func NewUploadProductMediaWithValidation(
+	productID uuid.UUID,
+	fileName string,
+	size int64,
+	file io.ReadSeeker,
+) (*UploadProductMedia, error) {
+	command := NewUploadProductMedia(productID, fileName, size, file)
+	err := command.Validate()
+
+	return command, err
+}
+
+// IsTxRequest for enabling transactions on the mediatr pipeline
+func (c *UploadProductMedia) IsTxRequest() {
+}
+
+func (c *UploadProductMedia) Validate() error {
+	err := validation.ValidateStruct(
+		c,
+		validation.Field(&c.ProductID, validation.Required),
+		validation.Field(&c.FileName, validation.Required, validation.Length(1, 255)),
+		validation.Field(&c.Size, validation.Required, validation.Min(int64(1))),
+		validation.Field(&c.File, validation.NotNil),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/uploadingproductmedia/v1/dtos"
+	"github.com/reoden/go-NFT/pkg/core/web/route"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/storage"
+
+	"emperror.dev/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+// multipartOverhead room for the multipart boundaries and headers on top of the maximum file size
+const multipartOverhead = 1 << 20
+
+type uploadProductMediaEndpoint struct {
+	fxparams.ProductRouteParams
+	storageOptions *storage.StorageOptions
+}
+
+func NewUploadProductMediaEndpoint(
+	params fxparams.ProductRouteParams,
+	storageOptions *storage.StorageOptions,
+) route.Endpoint {
+	return &uploadProductMediaEndpoint{ProductRouteParams: params, storageOptions: storageOptions}
+}
+
+func (ep *uploadProductMediaEndpoint) MapEndpoint() {
+	ep.ProductsGroup.POST("/:id/media", ep.handler())
+}
+
+// UploadProductMedia
+// @Tags Products
+// @Summary Upload product media
+// @Description Upload an image, video or 3D model (glb/gltf) of the product, images get a thumbnail and uploading the same file again returns its existing media
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param file formData file true "Media file"
+// @Success 201 {object} dtos.UploadProductMediaResponseDto
+// @Success 200 {object} dtos.UploadProductMediaResponseDto
+// @Router /api/v1/products/{id}/media [post]
+func (ep *uploadProductMediaEndpoint) handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		// bodies bigger than the maximum upload size fail while parsing the form instead of being buffered to disk
+		c.Request().Body = http.MaxBytesReader(
+			c.Response(),
+			c.Request().Body,
+			ep.storageOptions.MaxUploadSize+multipartOverhead,
+		)
+
+		// only the path params are bound, the multipart body is read by `FormFile`
+		request := &dtos.UploadProductMediaRequestDto{}
+		if err := (&echo.DefaultBinder{}).BindPathParams(c, request); err != nil {
+			badRequestErr := customErrors.NewBadRequestErrorWrap(
+				err,
+				"error in the binding request",
+			)
+
+			return badRequestErr
+		}
+
+		fileHeader, err := c.FormFile("file")
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return customErrors.NewApplicationErrorWithCode(
+				"request body is too large",
+				http.StatusRequestEntityTooLarge,
+			)
+		}
+		if err != nil {
+			return customErrors.NewBadRequestErrorWrap(
+				err,
+				"the `file` field of the multipart form is required",
+			)
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			return customErrors.NewBadRequestErrorWrap(err, "error in reading the uploaded file")
+		}
+		defer file.Close()
+
+		command, err := NewUploadProductMediaWithValidation(
+			request.ProductID,
+			fileHeader.Filename,
+			fileHeader.Size,
+			file,
+		)
+		if err != nil {
+			return err
+		}
+
+		result, err := mediatr.Send[*UploadProductMedia, *dtos.UploadProductMediaResponseDto](
+			ctx,
+			command,
+		)
+		if err != nil {
+			return errors.WithMessage(
+				err,
+				"error in sending UploadProductMedia",
+			)
+		}
+
+		if result.Duplicate {
+			return c.JSON(http.StatusOK, result)
+		}
+
+		return c.JSON(http.StatusCreated, result)
+	}
+}
@@ -0,0 +1,280 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/datamodels"
+	dtoV1 "github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1"
+	"github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/updatingproduct/v1/events/integrationevents"
+	"github.com/reoden/go-NFT/catalogs/internal/products/features/uploadingproductmedia/v1/dtos"
+	"github.com/reoden/go-NFT/catalogs/internal/products/media"
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/mapper"
+	"github.com/reoden/go-NFT/pkg/postgresgorm/gormdbcontext"
+	"github.com/reoden/go-NFT/pkg/storage"
+
+	"emperror.dev/errors"
+	"github.com/mehdihadeli/go-mediatr"
+	uuid "github.com/satori/go.uuid"
+)
+
+// thumbnailSize maximum width and height of the image thumbnails
+const thumbnailSize = 320
+
+type uploadProductMediaHandler struct {
+	fxparams.ProductHandlerParams
+}
+
+func NewUploadProductMediaHandler(
+	params fxparams.ProductHandlerParams,
+) cqrs.RequestHandlerWithRegisterer[*UploadProductMedia, *dtos.UploadProductMediaResponseDto] {
+	return &uploadProductMediaHandler{
+		ProductHandlerParams: params,
+	}
+}
+
+func (c *uploadProductMediaHandler) RegisterHandler() error {
+	return mediatr.RegisterRequestHandler[*UploadProductMedia, *dtos.UploadProductMediaResponseDto](
+		c,
+	)
+}
+
+// Handle stores the uploaded file in the object store and attaches it to the product, files are content addressed by
+// their sha256 hash, so a file that is already stored is not uploaded again and a file that the product already has
+// returns its existing media
+func (c *uploadProductMediaHandler) Handle(
+	ctx context.Context,
+	command *UploadProductMedia,
+) (*dtos.UploadProductMediaResponseDto, error) {
+	if command.Size > c.StorageOptions.MaxUploadSize {
+		return nil, customErrors.NewApplicationErrorWithCode(
+			fmt.Sprintf("file size should not be more than %d bytes", c.StorageOptions.MaxUploadSize),
+			http.StatusRequestEntityTooLarge,
+		)
+	}
+
+	if !gormdbcontext.Exists[*datamodels.ProductDataModel](ctx, c.CatalogsDBContext, command.ProductID) {
+		return nil, customErrors.NewApplicationErrorWithCode(
+			fmt.Sprintf("product with id `%s` not found", command.ProductID),
+			http.StatusNotFound,
+		)
+	}
+
+	hash, contentType, kind, err := c.inspectFile(command)
+	if err != nil {
+		return nil, err
+	}
+
+	db := c.CatalogsDBContext.WithTxIfExists(ctx).DB().WithContext(ctx)
+
+	var existing []*datamodels.MediaAssetDataModel
+	err = db.Where("product_id = ? AND hash = ?", command.ProductID, hash).Limit(1).Find(&existing).Error
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in finding product media")
+	}
+	if len(existing) > 0 {
+		mediaDto, err := c.toDto(ctx, existing[0])
+		if err != nil {
+			return nil, err
+		}
+
+		return &dtos.UploadProductMediaResponseDto{Media: mediaDto, Duplicate: true}, nil
+	}
+
+	storageKey := models.MediaStorageKey(hash, contentType)
+	if err := c.storeObject(ctx, storageKey, command.File, command.Size, contentType); err != nil {
+		return nil, err
+	}
+
+	var thumbnailKey string
+	if kind == models.ImageMediaKind {
+		thumbnailKey, err = c.storeThumbnail(ctx, hash, command.File)
+		if err != nil {
+			// a missing thumbnail should not fail the upload, clients fall back to the original file
+			c.Log.WarnMsg(fmt.Sprintf("error in generating thumbnail of media `%s`", storageKey), err)
+		}
+	}
+
+	asset := &models.MediaAsset{
+		Id:           uuid.NewV4(),
+		ProductId:    command.ProductID,
+		Kind:         kind,
+		FileName:     command.FileName,
+		ContentType:  contentType,
+		Size:         command.Size,
+		Hash:         hash,
+		StorageKey:   storageKey,
+		ThumbnailKey: thumbnailKey,
+		CreatedAt:    time.Now(),
+	}
+
+	_, err = gormdbcontext.AddModel[*datamodels.MediaAssetDataModel, *models.MediaAsset](
+		ctx,
+		c.CatalogsDBContext,
+		asset,
+	)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in saving product media")
+	}
+
+	err = integrationevents.PublishProductsUpdated(ctx, db, c.RabbitmqProducer, []uuid.UUID{command.ProductID})
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			"error in publishing 'ProductUpdated' message",
+		)
+	}
+
+	mediaDto, err := mapper.Map[*dtoV1.MediaAssetDto](asset)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in the mapping MediaAssetDto")
+	}
+	if err := media.SignMediaUrls(ctx, c.ObjectStore, c.StorageOptions, mediaDto); err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in signing media urls")
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf("media `%s` uploaded for product with id '%s'", asset.Id, command.ProductID),
+		logger.Fields{"Id": asset.Id, "ProductId": command.ProductID, "Hash": hash},
+	)
+
+	return &dtos.UploadProductMediaResponseDto{Media: mediaDto}, nil
+}
+
+// inspectFile sniffs the content type of the file and computes its sha256 hash
+func (c *uploadProductMediaHandler) inspectFile(
+	command *UploadProductMedia,
+) (hash string, contentType string, kind string, err error) {
+	if _, err := command.File.Seek(0, io.SeekStart); err != nil {
+		return "", "", "", customErrors.NewApplicationErrorWrap(err, "error in reading the uploaded file")
+	}
+
+	// https://pkg.go.dev/net/http#DetectContentType considers at most the first 512 bytes
+	head := make([]byte, 512)
+	n, err := io.ReadFull(command.File, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", "", "", customErrors.NewApplicationErrorWrap(err, "error in reading the uploaded file")
+	}
+
+	contentType, kind, ok := models.ResolveMediaContentType(http.DetectContentType(head[:n]), command.FileName)
+	if !ok {
+		return "", "", "", customErrors.NewApplicationErrorWithCode(
+			fmt.Sprintf("file type of `%s` is not supported", command.FileName),
+			http.StatusUnsupportedMediaType,
+		)
+	}
+
+	if _, err := command.File.Seek(0, io.SeekStart); err != nil {
+		return "", "", "", customErrors.NewApplicationErrorWrap(err, "error in reading the uploaded file")
+	}
+
+	hasher := sha256.New()
+	written, err := io.Copy(hasher, command.File)
+	if err != nil {
+		return "", "", "", customErrors.NewApplicationErrorWrap(err, "error in hashing the uploaded file")
+	}
+	if written != command.Size {
+		return "", "", "", customErrors.NewBadRequestError(
+			fmt.Sprintf("file size is %d bytes but %d bytes are declared", written, command.Size),
+		)
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), contentType, kind, nil
+}
+
+// storeObject uploads the file unless an object with the same content address already exists
+func (c *uploadProductMediaHandler) storeObject(
+	ctx context.Context,
+	key string,
+	file io.ReadSeeker,
+	size int64,
+	contentType string,
+) error {
+	exists, err := storage.Exists(ctx, c.ObjectStore, key)
+	if err != nil {
+		return customErrors.NewApplicationErrorWrap(err, "error in checking the media object")
+	}
+	if exists {
+		return nil
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return customErrors.NewApplicationErrorWrap(err, "error in reading the uploaded file")
+	}
+
+	if _, err := c.ObjectStore.Put(ctx, key, file, size, contentType); err != nil {
+		return customErrors.NewApplicationErrorWrap(err, "error in storing the media object")
+	}
+
+	return nil
+}
+
+func (c *uploadProductMediaHandler) storeThumbnail(
+	ctx context.Context,
+	hash string,
+	file io.ReadSeeker,
+) (string, error) {
+	key := models.ThumbnailStorageKey(hash)
+
+	exists, err := storage.Exists(ctx, c.ObjectStore, key)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return key, nil
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	thumbnail, err := storage.GenerateThumbnail(file, thumbnailSize)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = c.ObjectStore.Put(
+		ctx,
+		key,
+		bytes.NewReader(thumbnail),
+		int64(len(thumbnail)),
+		storage.ThumbnailContentType,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
+
+func (c *uploadProductMediaHandler) toDto(
+	ctx context.Context,
+	dataModel *datamodels.MediaAssetDataModel,
+) (*dtoV1.MediaAssetDto, error) {
+	asset, err := mapper.Map[*models.MediaAsset](dataModel)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in the mapping MediaAsset")
+	}
+
+	mediaDto, err := mapper.Map[*dtoV1.MediaAssetDto](asset)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in the mapping MediaAssetDto")
+	}
+
+	if err := media.SignMediaUrls(ctx, c.ObjectStore, c.StorageOptions, mediaDto); err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(err, "error in signing media urls")
+	}
+
+	return mediaDto, nil
+}
@@ -0,0 +1,62 @@
+package media
+
+import (
+	"context"
+
+	dtoV1 "github.com/reoden/go-NFT/catalogs/internal/products/dtos/v1"
+	"github.com/reoden/go-NFT/pkg/storage"
+
+	"emperror.dev/errors"
+)
+
+// SignProductsMediaUrls fills the signed download urls of the products media
+func SignProductsMediaUrls(
+	ctx context.Context,
+	store storage.ObjectStore,
+	options *storage.StorageOptions,
+	products ...*dtoV1.ProductDto,
+) error {
+	for _, product := range products {
+		if product == nil {
+			continue
+		}
+
+		if err := SignMediaUrls(ctx, store, options, product.Media...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SignMediaUrls fills the signed download urls of the media assets
+func SignMediaUrls(
+	ctx context.Context,
+	store storage.ObjectStore,
+	options *storage.StorageOptions,
+	assets ...*dtoV1.MediaAssetDto,
+) error {
+	expiry := options.SignedUrlExpiryDuration()
+
+	for _, asset := range assets {
+		if asset == nil {
+			continue
+		}
+
+		url, err := store.SignedURL(ctx, asset.StorageKey, expiry)
+		if err != nil {
+			return errors.WrapIf(err, "error in signing media url")
+		}
+		asset.Url = url
+
+		if asset.ThumbnailKey != "" {
+			thumbnailUrl, err := store.SignedURL(ctx, asset.ThumbnailKey, expiry)
+			if err != nil {
+				return errors.WrapIf(err, "error in signing media thumbnail url")
+			}
+			asset.ThumbnailUrl = thumbnailUrl
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+const CategoryPathSeparator = "/"
+
+var (
+	slugInvalidChars = regexp.MustCompile(`[^a-z0-9]+`)
+	// CategorySlugRegex lower case words separated by `-`
+	CategorySlugRegex = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+)
+
+// Category model, categories are hierarchical and `Path` keeps the slugs of the category ancestors and the category itself joined by `/`
+type Category struct {
+	Id          uuid.UUID
+	Name        string
+	Slug        string
+	Description string
+	ParentId    *uuid.UUID
+	Path        string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewCategorySlug creates an url friendly slug from the category name
+func NewCategorySlug(name string) string {
+	return strings.Trim(slugInvalidChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// NewCategoryPath creates the category path from its parent path
+func NewCategoryPath(parentPath string, slug string) string {
+	if parentPath == "" {
+		return slug
+	}
+
+	return parentPath + CategoryPathSeparator + slug
+}
+
+// PathSlugs returns the slugs of the category ancestors and the category itself
+func (c *Category) PathSlugs() []string {
+	return strings.Split(c.Path, CategoryPathSeparator)
+}
+
+// IsSelfOrAncestorOf checks the category is the same as or an ancestor of the category with the given path
+func (c *Category) IsSelfOrAncestorOf(path string) bool {
+	return path == c.Path || strings.HasPrefix(path, c.Path+CategoryPathSeparator)
+}
@@ -0,0 +1,39 @@
+//go:build unit
+// +build unit
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CategoryTestSuite struct {
+	suite.Suite
+}
+
+func TestCategoryTestSuite(t *testing.T) {
+	suite.Run(t, new(CategoryTestSuite))
+}
+
+func (s *CategoryTestSuite) Test_NewCategorySlug() {
+	s.Assert().Equal("digital-art", NewCategorySlug("Digital Art"))
+	s.Assert().Equal("3d-generative-art", NewCategorySlug("  3D / Generative   Art! "))
+	s.Assert().Regexp(CategorySlugRegex, NewCategorySlug("Pixel_Art & Photography"))
+}
+
+func (s *CategoryTestSuite) Test_NewCategoryPath() {
+	s.Assert().Equal("art", NewCategoryPath("", "art"))
+	s.Assert().Equal("art/digital-art", NewCategoryPath("art", "digital-art"))
+}
+
+func (s *CategoryTestSuite) Test_IsSelfOrAncestorOf() {
+	category := &Category{Path: "art/digital"}
+
+	s.Assert().True(category.IsSelfOrAncestorOf("art/digital"))
+	s.Assert().True(category.IsSelfOrAncestorOf("art/digital/pixel"))
+	s.Assert().False(category.IsSelfOrAncestorOf("art"))
+	s.Assert().False(category.IsSelfOrAncestorOf("art/digitalism"))
+	s.Assert().Equal([]string{"art", "digital"}, category.PathSlugs())
+}
@@ -0,0 +1,294 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/reoden/go-NFT/pkg/core/domain"
+	"github.com/reoden/go-NFT/pkg/es/models"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	typeMapper "github.com/reoden/go-NFT/pkg/reflection/typemapper"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
+)
+
+// EditionStatus the sale status of an edition
+type EditionStatus string
+
+const (
+	EditionStatusOwned  EditionStatus = "owned"
+	EditionStatusListed EditionStatus = "listed"
+	EditionStatusBurned EditionStatus = "burned"
+)
+
+var (
+	ErrEditionBurned      = errors.New("edition is burned")
+	ErrEditionFrozen      = errors.New("edition is frozen")
+	ErrEditionNotFrozen   = errors.New("edition is not frozen")
+	ErrEditionListed      = errors.New("edition is listed")
+	ErrEditionNotListed   = errors.New("edition is not listed")
+	ErrEditionNotOwner    = errors.New("user is not the owner of edition")
+	ErrEditionSameOwner   = errors.New("user is already the owner of edition")
+	ErrEditionInvalidMint = errors.New("invalid edition mint")
+)
+
+// Edition an event sourced edition of a product (NFT), its events are the provenance of the edition
+type Edition struct {
+	*models.EventSourcedAggregateRoot
+	ProductId     uuid.UUID
+	TokenId       string
+	EditionNumber int
+	Owner         string
+	Status        EditionStatus
+	Frozen        bool
+	Price         decimal.Decimal
+	Currency      string
+}
+
+// NewEdition mints a new edition of the product to its first owner
+func NewEdition(
+	id uuid.UUID,
+	productId uuid.UUID,
+	tokenId string,
+	editionNumber int,
+	owner string,
+) (*Edition, error) {
+	owner = NormalizeOwner(owner)
+	if productId == uuid.Nil || strings.TrimSpace(tokenId) == "" || editionNumber < 1 || owner == "" {
+		return nil, customErrors.NewDomainErrorWrap(
+			ErrEditionInvalidMint,
+			"an edition needs a product, a token id, an edition number from 1 and an owner",
+		)
+	}
+
+	edition := &Edition{}
+	edition.EventSourcedAggregateRoot = models.NewEventSourcedAggregateRootWithId(
+		id,
+		typeMapper.GetTypeName(edition),
+		edition.When,
+	)
+
+	err := edition.Apply(&EditionMinted{
+		DomainEvent:   domain.NewDomainEvent(typeMapper.GetTypeName(&EditionMinted{})),
+		ProductId:     productId,
+		TokenId:       strings.TrimSpace(tokenId),
+		EditionNumber: editionNumber,
+		Owner:         owner,
+	}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return edition, nil
+}
+
+// NormalizeOwner trims and lower cases the owner, the user id of the owner, to compare it with the owners of events
+func NormalizeOwner(owner string) string {
+	return strings.ToLower(strings.TrimSpace(owner))
+}
+
+func (e *Edition) NewEmptyAggregate() {
+	e.EventSourcedAggregateRoot = models.NewEventSourcedAggregateRoot(typeMapper.GetTypeName(e), e.When)
+}
+
+// List lists the edition for sale with the price by its owner
+func (e *Edition) List(seller string, price decimal.Decimal, currency string) error {
+	seller = NormalizeOwner(seller)
+	if err := e.checkChangeable(); err != nil {
+		return err
+	}
+	if e.Status == EditionStatusListed {
+		return customErrors.NewDomainErrorWrap(ErrEditionListed, "edition is already listed")
+	}
+	if err := e.checkOwner(seller); err != nil {
+		return err
+	}
+	if !price.IsPositive() {
+		return customErrors.NewDomainError("listing price must be greater than zero")
+	}
+
+	return e.Apply(&EditionListed{
+		DomainEvent: domain.NewDomainEvent(typeMapper.GetTypeName(&EditionListed{})),
+		Seller:      seller,
+		Price:       price,
+		Currency:    NormalizeCurrency(currency),
+	}, true)
+}
+
+// Unlist withdraws the listed edition from sale, a frozen edition can be unlisted
+func (e *Edition) Unlist(seller string) error {
+	seller = NormalizeOwner(seller)
+	if e.Status != EditionStatusListed {
+		return customErrors.NewDomainErrorWrap(ErrEditionNotListed, "only a listed edition can be unlisted")
+	}
+	if err := e.checkOwner(seller); err != nil {
+		return err
+	}
+
+	return e.Apply(&EditionUnlisted{
+		DomainEvent: domain.NewDomainEvent(typeMapper.GetTypeName(&EditionUnlisted{})),
+		Seller:      seller,
+	}, true)
+}
+
+// Sell sells the listed edition to the buyer with its listing price
+func (e *Edition) Sell(buyer string) error {
+	buyer = NormalizeOwner(buyer)
+	if err := e.checkChangeable(); err != nil {
+		return err
+	}
+	if e.Status != EditionStatusListed {
+		return customErrors.NewDomainErrorWrap(ErrEditionNotListed, "only a listed edition can be sold")
+	}
+	if err := e.checkNewOwner(buyer); err != nil {
+		return err
+	}
+
+	return e.Apply(&EditionSold{
+		DomainEvent: domain.NewDomainEvent(typeMapper.GetTypeName(&EditionSold{})),
+		Seller:      e.Owner,
+		Buyer:       buyer,
+		Price:       e.Price,
+		Currency:    e.Currency,
+	}, true)
+}
+
+// Transfer transfers the edition to a new owner without a sale, a listed edition should be unlisted before the transfer
+func (e *Edition) Transfer(from string, to string) error {
+	from = NormalizeOwner(from)
+	to = NormalizeOwner(to)
+	if err := e.checkChangeable(); err != nil {
+		return err
+	}
+	if e.Status == EditionStatusListed {
+		return customErrors.NewDomainErrorWrap(ErrEditionListed, "a listed edition cannot be transferred")
+	}
+	if err := e.checkOwner(from); err != nil {
+		return err
+	}
+	if err := e.checkNewOwner(to); err != nil {
+		return err
+	}
+
+	return e.Apply(&EditionTransferred{
+		DomainEvent: domain.NewDomainEvent(typeMapper.GetTypeName(&EditionTransferred{})),
+		From:        from,
+		To:          to,
+	}, true)
+}
+
+// Freeze freezes the edition, for example during a dispute of its ownership
+func (e *Edition) Freeze(reason string) error {
+	if e.Status == EditionStatusBurned {
+		return customErrors.NewDomainErrorWrap(ErrEditionBurned, "a burned edition cannot be frozen")
+	}
+	if e.Frozen {
+		return customErrors.NewDomainErrorWrap(ErrEditionFrozen, "edition is already frozen")
+	}
+
+	return e.Apply(&EditionFrozen{
+		DomainEvent: domain.NewDomainEvent(typeMapper.GetTypeName(&EditionFrozen{})),
+		Reason:      strings.TrimSpace(reason),
+	}, true)
+}
+
+// Unfreeze lifts the freeze of edition
+func (e *Edition) Unfreeze() error {
+	if !e.Frozen {
+		return customErrors.NewDomainErrorWrap(ErrEditionNotFrozen, "only a frozen edition can be unfrozen")
+	}
+
+	return e.Apply(&EditionUnfrozen{
+		DomainEvent: domain.NewDomainEvent(typeMapper.GetTypeName(&EditionUnfrozen{})),
+	}, true)
+}
+
+// Burn burns the edition by its owner, a listed edition should be unlisted before the burn
+func (e *Edition) Burn(owner string) error {
+	owner = NormalizeOwner(owner)
+	if err := e.checkChangeable(); err != nil {
+		return err
+	}
+	if e.Status == EditionStatusListed {
+		return customErrors.NewDomainErrorWrap(ErrEditionListed, "a listed edition cannot be burned")
+	}
+	if err := e.checkOwner(owner); err != nil {
+		return err
+	}
+
+	return e.Apply(&EditionBurned{
+		DomainEvent: domain.NewDomainEvent(typeMapper.GetTypeName(&EditionBurned{})),
+		Owner:       owner,
+	}, true)
+}
+
+func (e *Edition) When(event domain.IDomainEvent) error {
+	switch evt := event.(type) {
+	case *EditionMinted:
+		if e.Id() == uuid.Nil {
+			e.SetId(evt.GetAggregateId())
+		}
+		e.ProductId = evt.ProductId
+		e.TokenId = evt.TokenId
+		e.EditionNumber = evt.EditionNumber
+		e.Owner = evt.Owner
+		e.Status = EditionStatusOwned
+	case *EditionListed:
+		e.Status = EditionStatusListed
+		e.Price = evt.Price
+		e.Currency = evt.Currency
+	case *EditionUnlisted:
+		e.Status = EditionStatusOwned
+		e.Price = decimal.Zero
+		e.Currency = ""
+	case *EditionSold:
+		e.Owner = evt.Buyer
+		e.Status = EditionStatusOwned
+		e.Price = decimal.Zero
+		e.Currency = ""
+	case *EditionTransferred:
+		e.Owner = evt.To
+	case *EditionFrozen:
+		e.Frozen = true
+	case *EditionUnfrozen:
+		e.Frozen = false
+	case *EditionBurned:
+		e.Status = EditionStatusBurned
+	default:
+		return errors.Errorf("unknown edition event %T", event)
+	}
+
+	return nil
+}
+
+// checkChangeable checks the edition is not burned or frozen
+func (e *Edition) checkChangeable() error {
+	if e.Status == EditionStatusBurned {
+		return customErrors.NewDomainErrorWrap(ErrEditionBurned, "a burned edition cannot be changed")
+	}
+	if e.Frozen {
+		return customErrors.NewDomainErrorWrap(ErrEditionFrozen, "a frozen edition cannot be changed")
+	}
+
+	return nil
+}
+
+func (e *Edition) checkOwner(owner string) error {
+	if owner != e.Owner {
+		return customErrors.NewDomainErrorWrap(ErrEditionNotOwner, "only the owner can change the edition")
+	}
+
+	return nil
+}
+
+func (e *Edition) checkNewOwner(owner string) error {
+	if owner == "" {
+		return customErrors.NewDomainError("new owner is required")
+	}
+	if owner == e.Owner {
+		return customErrors.NewDomainErrorWrap(ErrEditionSameOwner, "edition cannot be changed to its current owner")
+	}
+
+	return nil
+}
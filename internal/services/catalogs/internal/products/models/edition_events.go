@@ -0,0 +1,64 @@
+package models
+
+import (
+	"github.com/reoden/go-NFT/pkg/core/domain"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
+)
+
+// EditionMinted the edition is minted to its first owner
+type EditionMinted struct {
+	*domain.DomainEvent
+	ProductId     uuid.UUID `json:"productId"`
+	TokenId       string    `json:"tokenId"`
+	EditionNumber int       `json:"editionNumber"`
+	Owner         string    `json:"owner"`
+}
+
+// EditionListed the owner listed the edition for sale
+type EditionListed struct {
+	*domain.DomainEvent
+	Seller   string          `json:"seller"`
+	Price    decimal.Decimal `json:"price"`
+	Currency string          `json:"currency"`
+}
+
+// EditionUnlisted the owner withdrew the edition from sale
+type EditionUnlisted struct {
+	*domain.DomainEvent
+	Seller string `json:"seller"`
+}
+
+// EditionSold the listed edition is sold to the buyer with its listing price
+type EditionSold struct {
+	*domain.DomainEvent
+	Seller   string          `json:"seller"`
+	Buyer    string          `json:"buyer"`
+	Price    decimal.Decimal `json:"price"`
+	Currency string          `json:"currency"`
+}
+
+// EditionTransferred the edition is transferred to a new owner without a sale
+type EditionTransferred struct {
+	*domain.DomainEvent
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// EditionFrozen the edition is frozen, a frozen edition cannot be listed, sold, transferred or burned
+type EditionFrozen struct {
+	*domain.DomainEvent
+	Reason string `json:"reason"`
+}
+
+// EditionUnfrozen the freeze of edition is lifted
+type EditionUnfrozen struct {
+	*domain.DomainEvent
+}
+
+// EditionBurned the edition is burned by its owner, a burned edition has no further events
+type EditionBurned struct {
+	*domain.DomainEvent
+	Owner string `json:"owner"`
+}
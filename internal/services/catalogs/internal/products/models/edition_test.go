@@ -0,0 +1,161 @@
+//go:build unit
+// +build unit
+
+package models
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reoden/go-NFT/pkg/core/domain"
+	"github.com/reoden/go-NFT/pkg/es"
+	esErrors "github.com/reoden/go-NFT/pkg/es/errors"
+	"github.com/reoden/go-NFT/pkg/test/aggregate"
+
+	"emperror.dev/errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	artist    = "0xartist"
+	collector = "0xcollector"
+	friend    = "0xfriend"
+)
+
+type EditionTestSuite struct {
+	suite.Suite
+}
+
+func TestEditionTestSuite(t *testing.T) {
+	suite.Run(t, new(EditionTestSuite))
+}
+
+func (s *EditionTestSuite) newEdition() *Edition {
+	edition, err := NewEdition(uuid.NewV4(), uuid.NewV4(), "token-1", 1, " 0xARTIST ")
+	s.Require().NoError(err)
+
+	return edition
+}
+
+func (s *EditionTestSuite) Test_NewEdition_Mints_To_Normalized_Owner() {
+	edition := s.newEdition()
+
+	s.Equal(artist, edition.Owner)
+	s.Equal(EditionStatusOwned, edition.Status)
+	s.Len(edition.UncommittedEvents(), 1)
+	s.IsType(&EditionMinted{}, edition.UncommittedEvents()[0])
+
+	_, err := NewEdition(uuid.NewV4(), uuid.NewV4(), "token-1", 0, artist)
+	s.True(errors.Is(err, ErrEditionInvalidMint))
+}
+
+func (s *EditionTestSuite) Test_Edition_Lifecycle() {
+	edition := s.newEdition()
+
+	s.Require().NoError(edition.List(artist, decimal.NewFromInt(10), "eth"))
+	s.Equal(EditionStatusListed, edition.Status)
+	s.Equal("ETH", edition.Currency)
+
+	s.Require().NoError(edition.Sell(collector))
+	s.Equal(collector, edition.Owner)
+	s.Equal(EditionStatusOwned, edition.Status)
+
+	s.Require().NoError(edition.Transfer(collector, friend))
+	s.Equal(friend, edition.Owner)
+
+	s.Require().NoError(edition.Burn(friend))
+	s.Equal(EditionStatusBurned, edition.Status)
+
+	s.Len(edition.UncommittedEvents(), 5)
+	s.Equal(int64(4), edition.CurrentVersion())
+}
+
+func (s *EditionTestSuite) Test_Frozen_Edition_Cannot_Be_Transferred() {
+	edition := s.newEdition()
+	s.Require().NoError(edition.Freeze("ownership dispute"))
+
+	s.True(errors.Is(edition.Transfer(artist, collector), ErrEditionFrozen))
+	s.True(errors.Is(edition.List(artist, decimal.NewFromInt(10), "ETH"), ErrEditionFrozen))
+	s.True(errors.Is(edition.Burn(artist), ErrEditionFrozen))
+
+	s.Require().NoError(edition.Unfreeze())
+	s.NoError(edition.Transfer(artist, collector))
+}
+
+func (s *EditionTestSuite) Test_Edition_Invariants() {
+	edition := s.newEdition()
+
+	s.True(errors.Is(edition.Transfer(collector, friend), ErrEditionNotOwner))
+	s.True(errors.Is(edition.Transfer(artist, artist), ErrEditionSameOwner))
+	s.True(errors.Is(edition.Sell(collector), ErrEditionNotListed))
+	s.Error(edition.List(artist, decimal.Zero, "ETH"))
+
+	s.Require().NoError(edition.List(artist, decimal.NewFromInt(10), "ETH"))
+	s.True(errors.Is(edition.Transfer(artist, collector), ErrEditionListed))
+	s.True(errors.Is(edition.Sell(artist), ErrEditionSameOwner))
+
+	s.Require().NoError(edition.Unlist(artist))
+	s.Require().NoError(edition.Burn(artist))
+	s.True(errors.Is(edition.Transfer(artist, collector), ErrEditionBurned))
+	s.True(errors.Is(edition.Freeze("late"), ErrEditionBurned))
+}
+
+func (s *EditionTestSuite) Test_Edition_Is_Restored_From_History() {
+	edition := s.newEdition()
+	s.Require().NoError(edition.List(artist, decimal.NewFromInt(10), "ETH"))
+	s.Require().NoError(edition.Sell(collector))
+
+	restored := &Edition{}
+	restored.NewEmptyAggregate()
+	s.Require().NoError(restored.LoadFromHistory(append([]domain.IDomainEvent{}, edition.UncommittedEvents()...), nil))
+
+	s.Equal(edition.Id(), restored.Id())
+	s.Equal(collector, restored.Owner)
+	s.Equal(EditionStatusOwned, restored.Status)
+	s.Equal(int64(2), restored.OriginalVersion())
+}
+
+func (s *EditionTestSuite) Test_Frozen_Edition_Transfer_Scenario() {
+	aggregate.For[*Edition](s.T()).
+		Given(
+			&EditionMinted{ProductId: uuid.NewV4(), TokenId: "token-1", EditionNumber: 1, Owner: artist},
+			&EditionFrozen{Reason: "ownership dispute"},
+		).
+		When(func(edition *Edition) error { return edition.Transfer(artist, collector) }).
+		ThenError(ErrEditionFrozen)
+
+	aggregate.For[*Edition](s.T()).
+		Given(
+			&EditionMinted{ProductId: uuid.NewV4(), TokenId: "token-1", EditionNumber: 1, Owner: artist},
+			&EditionListed{Seller: artist, Price: decimal.NewFromInt(3), Currency: "ETH"},
+		).
+		When(func(edition *Edition) error { return edition.Sell(collector) }).
+		Then(&EditionSold{Seller: artist, Buyer: collector, Price: decimal.NewFromInt(3), Currency: "ETH"})
+}
+
+func (s *EditionTestSuite) Test_Edition_Is_Stored_And_Loaded() {
+	ctx := context.Background()
+	editionStore := es.NewInMemoryAggregateStore[*Edition](es.NewInMemoryEventStore())
+
+	edition := s.newEdition()
+	s.Require().NoError(edition.List(artist, decimal.NewFromInt(10), "ETH"))
+	_, err := editionStore.Store(edition, nil, ctx)
+	s.Require().NoError(err)
+
+	loaded, err := editionStore.Load(ctx, edition.Id())
+	s.Require().NoError(err)
+	stale, err := editionStore.Load(ctx, edition.Id())
+	s.Require().NoError(err)
+
+	s.Equal(EditionStatusListed, loaded.Status)
+	s.Require().NoError(loaded.Sell(collector))
+	_, err = editionStore.Store(loaded, nil, ctx)
+	s.Require().NoError(err)
+
+	// the stale edition is not stored over the sale
+	s.Require().NoError(stale.Unlist(artist))
+	_, err = editionStore.Store(stale, nil, ctx)
+	s.True(errors.Is(err, esErrors.ErrWrongExpectedVersion))
+}
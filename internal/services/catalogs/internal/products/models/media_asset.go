@@ -0,0 +1,82 @@
+package models
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// kinds of the product media
+const (
+	ImageMediaKind = "image"
+	VideoMediaKind = "video"
+	ModelMediaKind = "model"
+)
+
+// mediaContentTypes supported content types of the product media and the extension their objects are stored with
+var mediaContentTypes = map[string]struct {
+	Kind      string
+	Extension string
+}{
+	"image/png":         {ImageMediaKind, ".png"},
+	"image/jpeg":        {ImageMediaKind, ".jpg"},
+	"image/gif":         {ImageMediaKind, ".gif"},
+	"image/webp":        {ImageMediaKind, ".webp"},
+	"video/mp4":         {VideoMediaKind, ".mp4"},
+	"video/webm":        {VideoMediaKind, ".webm"},
+	"model/gltf-binary": {ModelMediaKind, ".glb"},
+	"model/gltf+json":   {ModelMediaKind, ".gltf"},
+}
+
+// modelExtensions 3D files can't be sniffed from their content, so their content type comes from the file extension
+var modelExtensions = map[string]string{
+	".glb":  "model/gltf-binary",
+	".gltf": "model/gltf+json",
+}
+
+// MediaAsset model, a media file (artwork image, video or 3D model) of a product, the file content is kept in the
+// object store under `StorageKey` and identical files are stored once by their content hash
+type MediaAsset struct {
+	Id           uuid.UUID
+	ProductId    uuid.UUID
+	Kind         string
+	FileName     string
+	ContentType  string
+	Size         int64
+	Hash         string
+	StorageKey   string
+	ThumbnailKey string
+	CreatedAt    time.Time
+}
+
+// ResolveMediaContentType returns the content type of a media file from its sniffed content type and its file name,
+// ok is false when the file type is not supported
+func ResolveMediaContentType(detectedContentType string, fileName string) (string, string, bool) {
+	contentType := strings.TrimSpace(strings.Split(detectedContentType, ";")[0])
+
+	if contentType == "application/octet-stream" || contentType == "text/plain" || contentType == "application/json" {
+		if modelContentType, ok := modelExtensions[strings.ToLower(path.Ext(fileName))]; ok {
+			contentType = modelContentType
+		}
+	}
+
+	mediaType, ok := mediaContentTypes[contentType]
+	if !ok {
+		return "", "", false
+	}
+
+	return contentType, mediaType.Kind, true
+}
+
+// MediaStorageKey object key of a media file, keys are content addressed so the same file is stored once
+func MediaStorageKey(hash string, contentType string) string {
+	return fmt.Sprintf("media/%s%s", hash, mediaContentTypes[contentType].Extension)
+}
+
+// ThumbnailStorageKey object key of the thumbnail of an image media file
+func ThumbnailStorageKey(hash string) string {
+	return fmt.Sprintf("thumbnails/%s.jpg", hash)
+}
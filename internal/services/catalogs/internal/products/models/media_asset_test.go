@@ -0,0 +1,40 @@
+//go:build unit
+// +build unit
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Resolve_Media_Content_Type(t *testing.T) {
+	contentType, kind, ok := ResolveMediaContentType("image/png", "ape.png")
+	assert.True(t, ok)
+	assert.Equal(t, "image/png", contentType)
+	assert.Equal(t, ImageMediaKind, kind)
+
+	contentType, kind, ok = ResolveMediaContentType("video/webm; codecs=vp9", "ape.webm")
+	assert.True(t, ok)
+	assert.Equal(t, "video/webm", contentType)
+	assert.Equal(t, VideoMediaKind, kind)
+
+	contentType, kind, ok = ResolveMediaContentType("application/octet-stream", "ape.GLB")
+	assert.True(t, ok)
+	assert.Equal(t, "model/gltf-binary", contentType)
+	assert.Equal(t, ModelMediaKind, kind)
+
+	_, _, ok = ResolveMediaContentType("application/octet-stream", "ape.exe")
+	assert.False(t, ok)
+
+	// the file extension can't change the type of a sniffed content
+	_, _, ok = ResolveMediaContentType("text/html; charset=utf-8", "ape.png")
+	assert.False(t, ok)
+}
+
+func Test_Media_Storage_Keys(t *testing.T) {
+	assert.Equal(t, "media/abc.png", MediaStorageKey("abc", "image/png"))
+	assert.Equal(t, "media/abc.glb", MediaStorageKey("abc", "model/gltf-binary"))
+	assert.Equal(t, "thumbnails/abc.jpg", ThumbnailStorageKey("abc"))
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+
+	"emperror.dev/errors"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/shopspring/decimal"
+)
+
+// DefaultCurrency currency of the prices that are set without a currency
+const DefaultCurrency = "USD"
+
+// CurrencyRegex currency codes are upper case like `USD` and `EUR` or token symbols like `ETH`
+var CurrencyRegex = regexp.MustCompile(`^[A-Z]{3,5}$`)
+
+// NormalizeCurrency trims and upper cases the currency code, an empty currency is DefaultCurrency
+func NormalizeCurrency(currency string) string {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		return DefaultCurrency
+	}
+
+	return currency
+}
+
+// PositiveAmount validation rule for a `decimal.Decimal` amount that must be greater than zero
+var PositiveAmount = validation.By(func(value interface{}) error {
+	amount, ok := value.(decimal.Decimal)
+	if !ok {
+		return errors.Errorf("cannot validate the amount of type %T", value)
+	}
+	if !amount.IsPositive() {
+		return errors.New("must be greater than zero")
+	}
+
+	return nil
+})
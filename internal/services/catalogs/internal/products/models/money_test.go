@@ -0,0 +1,25 @@
+//go:build unit
+// +build unit
+
+package models
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Normalize_Currency(t *testing.T) {
+	assert.Equal(t, "ETH", NormalizeCurrency(" eth "))
+	assert.Equal(t, DefaultCurrency, NormalizeCurrency(""))
+	assert.True(t, CurrencyRegex.MatchString(NormalizeCurrency("usdc")))
+	assert.False(t, CurrencyRegex.MatchString(NormalizeCurrency("us dollar")))
+}
+
+func Test_Positive_Amount(t *testing.T) {
+	assert.NoError(t, PositiveAmount.Validate(decimal.RequireFromString("0.000000000000000001")))
+	assert.Error(t, PositiveAmount.Validate(decimal.Zero))
+	assert.Error(t, PositiveAmount.Validate(decimal.RequireFromString("-12.50")))
+	assert.Error(t, PositiveAmount.Validate(12.5))
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
 )
 
 // Product model
@@ -11,7 +12,13 @@ type Product struct {
 	Id          uuid.UUID
 	Name        string
 	Description string
-	Price       float64
+	Artist      string
+	Price       decimal.Decimal
+	Currency    string
+	Version     int64
+	Categories  []*Category
+	Tags        []*Tag
+	Media       []*MediaAsset
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
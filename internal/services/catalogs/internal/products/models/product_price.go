@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
+)
+
+// reasons of a product price change
+const (
+	CreatedPriceChangeReason   = "created"
+	UpdatedPriceChangeReason   = "updated"
+	ScheduledPriceChangeReason = "scheduled"
+)
+
+// statuses of a scheduled product price change
+const (
+	PendingPriceScheduleStatus  = "pending"
+	AppliedPriceScheduleStatus  = "applied"
+	CanceledPriceScheduleStatus = "canceled"
+)
+
+// ProductPrice model, an entry of the product price history, a new entry is added every time the product price changes
+type ProductPrice struct {
+	Id          uuid.UUID
+	ProductId   uuid.UUID
+	Price       decimal.Decimal
+	Currency    string
+	Reason      string
+	EffectiveAt time.Time
+	CreatedAt   time.Time
+}
+
+// ProductPriceSchedule model, a price change that is applied to the product at `ApplyAt`
+type ProductPriceSchedule struct {
+	Id        uuid.UUID
+	ProductId uuid.UUID
+	Price     decimal.Decimal
+	Currency  string
+	ApplyAt   time.Time
+	Status    string
+	AppliedAt *time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,45 @@
+package models
+
+// ProductSearchCriteria full text search and facet filters for searching products
+type ProductSearchCriteria struct {
+	SearchText string
+	Artist     string
+	Category   string
+	MinPrice   *float64
+	MaxPrice   *float64
+	Page       int
+	Size       int
+}
+
+// ProductSearchHit a matched product with its relevance score and highlighted fragments
+type ProductSearchHit struct {
+	Product    *Product
+	Score      float64
+	Highlights map[string][]string
+}
+
+type FacetBucket struct {
+	Key   string
+	Count int64
+}
+
+type PriceRangeBucket struct {
+	Key   string
+	From  *float64
+	To    *float64
+	Count int64
+}
+
+type ProductSearchFacets struct {
+	Artists     []*FacetBucket
+	Categories  []*FacetBucket
+	PriceRanges []*PriceRangeBucket
+}
+
+type ProductSearchResult struct {
+	Hits       []*ProductSearchHit
+	Facets     *ProductSearchFacets
+	TotalItems int64
+	Page       int
+	Size       int
+}
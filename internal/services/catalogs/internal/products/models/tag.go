@@ -0,0 +1,20 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// Tag model, tags are free-form labels that are shared between products
+type Tag struct {
+	Id        uuid.UUID
+	Name      string
+	CreatedAt time.Time
+}
+
+// NormalizeTagName trims and lower cases the tag name, so the same tag typed differently is stored once
+func NormalizeTagName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
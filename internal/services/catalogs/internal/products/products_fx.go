@@ -1,17 +1,54 @@
 package products
 
 import (
+	"net/http"
+
 	"github.com/reoden/go-NFT/catalogs/internal/products/data/repositories"
+	applyingscheduledpricechangev1 "github.com/reoden/go-NFT/catalogs/internal/products/features/applyingscheduledpricechange/v1"
+	burningeditionv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/burningedition/v1"
+	creatingcategoryv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/creatingcategory/v1"
 	creatingproductv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/creatingproduct/v1"
+	creatingtagv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/creatingtag/v1"
+	deletingcategoryv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/deletingcategory/v1"
 	deletingproductv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/deletingproduct/v1"
+	deletingtagv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/deletingtag/v1"
+	downloadingmediav1 "github.com/reoden/go-NFT/catalogs/internal/products/features/downloadingmedia/v1"
+	freezingeditionv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/freezingedition/v1"
+	gettingcategoriesv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/gettingcategories/v1"
+	gettingdeadletterbyidv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/gettingdeadletterbyid/v1"
+	gettingdeadletterqueuesv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/gettingdeadletterqueues/v1"
+	gettingdeadlettersv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/gettingdeadletters/v1"
+	gettingeditionprovenancev1 "github.com/reoden/go-NFT/catalogs/internal/products/features/gettingeditionprovenance/v1"
+	gettingpricetimelinev1 "github.com/reoden/go-NFT/catalogs/internal/products/features/gettingpricetimeline/v1"
 	gettingproductbyidv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/gettingproductbyid/v1"
 	gettingproductsv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/gettingproducts/v1"
+	gettingprojectionrebuildprogressv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/gettingprojectionrebuildprogress/v1"
+	gettingtagsv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/gettingtags/v1"
+	listingeditionv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/listingedition/v1"
+	mintingeditionv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/mintingedition/v1"
+	purgingdeadlettersv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/purgingdeadletters/v1"
+	rebuildingprojectionv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/rebuildingprojection/v1"
+	reindexingproductsv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/reindexingproducts/v1"
+	replayingdeadlettersv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/replayingdeadletters/v1"
+	schedulingpricechangev1 "github.com/reoden/go-NFT/catalogs/internal/products/features/schedulingpricechange/v1"
 	searchingproductsv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/searchingproduct/v1"
+	sellingeditionv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/sellingedition/v1"
+	transferringeditionv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/transferringedition/v1"
+	unfreezingeditionv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/unfreezingedition/v1"
+	unlistingeditionv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/unlistingedition/v1"
+	updatingcategoryv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/updatingcategory/v1"
 	updatingoroductsv1 "github.com/reoden/go-NFT/catalogs/internal/products/features/updatingproduct/v1"
+	uploadingproductmediav1 "github.com/reoden/go-NFT/catalogs/internal/products/features/uploadingproductmedia/v1"
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
+	"github.com/reoden/go-NFT/catalogs/internal/products/projections"
 	"github.com/reoden/go-NFT/catalogs/internal/shared/grpc"
+	"github.com/reoden/go-NFT/pkg/constants"
 	"github.com/reoden/go-NFT/pkg/core/cqrs"
 	"github.com/reoden/go-NFT/pkg/core/web/route"
+	"github.com/reoden/go-NFT/pkg/es"
 	"github.com/reoden/go-NFT/pkg/http/customecho/contracts"
+	"github.com/reoden/go-NFT/pkg/http/customecho/middlewares/auth"
+	"github.com/reoden/go-NFT/pkg/postgreseventstore"
 
 	"github.com/labstack/echo/v4"
 	"go.uber.org/fx"
@@ -22,8 +59,13 @@ var Module = fx.Module(
 
 	// Other provides
 	fx.Provide(repositories.NewPostgresProductRepository),
+	fx.Provide(repositories.NewElasticProductSearchRepository),
 	fx.Provide(grpc.NewProductGrpcService),
 
+	// event sourced editions and the projection of their provenance
+	fx.Provide(postgreseventstore.NewPostgresAggregateStore[*models.Edition]),
+	fx.Provide(es.AsProjection(projections.NewEditionProvenanceProjection)),
+
 	fx.Provide(
 		fx.Annotate(func(catalogsServer contracts.EchoHttpServer) *echo.Group {
 			var g *echo.Group
@@ -35,6 +77,92 @@ var Module = fx.Module(
 
 			return g
 		}, fx.ResultTags(`name:"product-echo-group"`)),
+		fx.Annotate(func(catalogsServer contracts.EchoHttpServer) *echo.Group {
+			var g *echo.Group
+			catalogsServer.RouteBuilder().
+				RegisterGroupFunc("/api/v1", func(v1 *echo.Group) {
+					group := v1.Group("/categories")
+					g = group
+				})
+
+			return g
+		}, fx.ResultTags(`name:"category-echo-group"`)),
+		fx.Annotate(func(catalogsServer contracts.EchoHttpServer) *echo.Group {
+			var g *echo.Group
+			catalogsServer.RouteBuilder().
+				RegisterGroupFunc("/api/v1", func(v1 *echo.Group) {
+					group := v1.Group("/tags")
+					g = group
+				})
+
+			return g
+		}, fx.ResultTags(`name:"tag-echo-group"`)),
+		fx.Annotate(func(catalogsServer contracts.EchoHttpServer) *echo.Group {
+			var g *echo.Group
+			catalogsServer.RouteBuilder().
+				RegisterGroupFunc("/api/v1", func(v1 *echo.Group) {
+					group := v1.Group("/media")
+					g = group
+				})
+
+			return g
+		}, fx.ResultTags(`name:"media-echo-group"`)),
+		// the dead-lettered messages are inspected, replayed and purged by the administrators
+		fx.Annotate(func(
+			catalogsServer contracts.EchoHttpServer,
+			checker auth.TokenBlacklistChecker,
+		) *echo.Group {
+			var g *echo.Group
+			catalogsServer.RouteBuilder().
+				RegisterGroupFunc("/api/v1", func(v1 *echo.Group) {
+					group := v1.Group(
+						"/dead-letters",
+						auth.JWTWithBlacklist(auth.EchoAuth(nil), checker, nil),
+						auth.RequireRoles(constants.AdminRole),
+					)
+					g = group
+				})
+
+			return g
+		}, fx.ResultTags(`name:"dead-letter-echo-group"`)),
+		// the rebuilds of projections are run by the administrators
+		fx.Annotate(func(
+			catalogsServer contracts.EchoHttpServer,
+			checker auth.TokenBlacklistChecker,
+		) *echo.Group {
+			var g *echo.Group
+			catalogsServer.RouteBuilder().
+				RegisterGroupFunc("/api/v1", func(v1 *echo.Group) {
+					group := v1.Group(
+						"/projections",
+						auth.JWTWithBlacklist(auth.EchoAuth(nil), checker, nil),
+						auth.RequireRoles(constants.AdminRole),
+					)
+					g = group
+				})
+
+			return g
+		}, fx.ResultTags(`name:"projection-echo-group"`)),
+		// the changes of editions are made by the authenticated owners, the provenance can be read by anyone
+		fx.Annotate(func(
+			catalogsServer contracts.EchoHttpServer,
+			checker auth.TokenBlacklistChecker,
+		) *echo.Group {
+			var g *echo.Group
+			catalogsServer.RouteBuilder().
+				RegisterGroupFunc("/api/v1", func(v1 *echo.Group) {
+					skipReads := func(c echo.Context) bool {
+						return c.Request().Method == http.MethodGet
+					}
+					group := v1.Group(
+						"/editions",
+						auth.JWTWithBlacklist(auth.EchoAuth(skipReads), checker, skipReads),
+					)
+					g = group
+				})
+
+			return g
+		}, fx.ResultTags(`name:"edition-echo-group"`)),
 	),
 
 	// add cqrs handlers to DI
@@ -63,6 +191,122 @@ var Module = fx.Module(
 			updatingoroductsv1.NewUpdateProductHandler,
 			"product-handlers",
 		),
+		cqrs.AsHandler(
+			reindexingproductsv1.NewReindexProductsHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			creatingcategoryv1.NewCreateCategoryHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			updatingcategoryv1.NewUpdateCategoryHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			deletingcategoryv1.NewDeleteCategoryHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			gettingcategoriesv1.NewGetCategoriesHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			creatingtagv1.NewCreateTagHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			gettingtagsv1.NewGetTagsHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			deletingtagv1.NewDeleteTagHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			uploadingproductmediav1.NewUploadProductMediaHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			downloadingmediav1.NewDownloadMediaHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			schedulingpricechangev1.NewSchedulePriceChangeHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			applyingscheduledpricechangev1.NewApplyScheduledPriceChangeHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			gettingpricetimelinev1.NewGetPriceTimelineHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			gettingdeadletterqueuesv1.NewGetDeadLetterQueuesHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			gettingdeadlettersv1.NewGetDeadLettersHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			gettingdeadletterbyidv1.NewGetDeadLetterByIdHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			replayingdeadlettersv1.NewReplayDeadLettersHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			purgingdeadlettersv1.NewPurgeDeadLettersHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			rebuildingprojectionv1.NewRebuildProjectionHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			gettingprojectionrebuildprogressv1.NewGetProjectionRebuildProgressHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			mintingeditionv1.NewMintEditionHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			listingeditionv1.NewListEditionHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			unlistingeditionv1.NewUnlistEditionHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			sellingeditionv1.NewSellEditionHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			transferringeditionv1.NewTransferEditionHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			freezingeditionv1.NewFreezeEditionHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			unfreezingeditionv1.NewUnfreezeEditionHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			burningeditionv1.NewBurnEditionHandler,
+			"product-handlers",
+		),
+		cqrs.AsHandler(
+			gettingeditionprovenancev1.NewGetEditionProvenanceHandler,
+			"product-handlers",
+		),
 	),
 
 	// add endpoints to DI
@@ -91,5 +335,120 @@ var Module = fx.Module(
 			deletingproductv1.NewDeleteProductEndpoint,
 			"product-routes",
 		),
+		route.AsRoute(
+			reindexingproductsv1.NewReindexProductsEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			creatingcategoryv1.NewCreateCategoryEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			updatingcategoryv1.NewUpdateCategoryEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			deletingcategoryv1.NewDeleteCategoryEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			gettingcategoriesv1.NewGetCategoriesEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			creatingtagv1.NewCreateTagEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			gettingtagsv1.NewGetTagsEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			deletingtagv1.NewDeleteTagEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			uploadingproductmediav1.NewUploadProductMediaEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			downloadingmediav1.NewDownloadMediaEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			schedulingpricechangev1.NewSchedulePriceChangeEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			gettingpricetimelinev1.NewGetPriceTimelineEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			gettingdeadletterqueuesv1.NewGetDeadLetterQueuesEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			gettingdeadlettersv1.NewGetDeadLettersEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			gettingdeadletterbyidv1.NewGetDeadLetterByIdEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			replayingdeadlettersv1.NewReplayDeadLettersEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			purgingdeadlettersv1.NewPurgeDeadLettersEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			rebuildingprojectionv1.NewRebuildProjectionEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			gettingprojectionrebuildprogressv1.NewGetProjectionRebuildProgressEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			mintingeditionv1.NewMintEditionEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			listingeditionv1.NewListEditionEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			unlistingeditionv1.NewUnlistEditionEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			sellingeditionv1.NewSellEditionEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			transferringeditionv1.NewTransferEditionEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			freezingeditionv1.NewFreezeEditionEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			unfreezingeditionv1.NewUnfreezeEditionEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			burningeditionv1.NewBurnEditionEndpoint,
+			"product-routes",
+		),
+		route.AsRoute(
+			gettingeditionprovenancev1.NewGetEditionProvenanceEndpoint,
+			"product-routes",
+		),
 	),
+
+	// add asynq task handlers to the queue worker
+	fx.Invoke(applyingscheduledpricechangev1.RegisterApplyScheduledPriceChangeTaskHandler),
 )
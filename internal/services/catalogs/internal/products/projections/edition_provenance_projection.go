@@ -0,0 +1,198 @@
+package projections
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/datamodels"
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
+	"github.com/reoden/go-NFT/pkg/es/contracts/projection"
+	esModels "github.com/reoden/go-NFT/pkg/es/models"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/postgresgorm/helpers/gormextensions"
+
+	"emperror.dev/errors"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const EditionProvenanceProjectionName = "edition-provenance"
+
+// the event types of the provenance read model
+const (
+	ProvenanceMinted      = "minted"
+	ProvenanceListed      = "listed"
+	ProvenanceUnlisted    = "unlisted"
+	ProvenanceSold        = "sold"
+	ProvenanceTransferred = "transferred"
+	ProvenanceFrozen      = "frozen"
+	ProvenanceUnfrozen    = "unfrozen"
+	ProvenanceBurned      = "burned"
+)
+
+type editionProvenanceProjection struct {
+	log   logger.Logger
+	db    *gorm.DB
+	table string
+}
+
+// NewEditionProvenanceProjection projects the events of the editions into the `edition_provenance` read model, each event
+// is a row with the state of edition after the event
+func NewEditionProvenanceProjection(log logger.Logger, db *gorm.DB) projection.IProjection {
+	return &editionProvenanceProjection{
+		log:   log,
+		db:    db,
+		table: (&datamodels.EditionProvenanceDataModel{}).TableName(),
+	}
+}
+
+func (p *editionProvenanceProjection) ProcessEvent(ctx context.Context, streamEvent *esModels.StreamEvent) error {
+	if !isEditionEvent(streamEvent) {
+		return nil
+	}
+
+	editionId := streamEvent.Event.GetAggregateId()
+
+	var previous *datamodels.EditionProvenanceDataModel
+	if _, minted := streamEvent.Event.(*models.EditionMinted); !minted {
+		previous = &datamodels.EditionProvenanceDataModel{}
+		err := p.db.WithContext(ctx).
+			Table(p.table).
+			Where("edition_id = ?", editionId).
+			Order("version desc").
+			First(previous).
+			Error
+		if err != nil {
+			return errors.WrapIf(
+				err,
+				fmt.Sprintf("error in reading the provenance of edition '%s'", editionId),
+			)
+		}
+	}
+
+	row := newProvenanceRow(streamEvent, previous)
+
+	// the replayed events are ignored, so the projection is idempotent
+	err := p.db.WithContext(ctx).
+		Table(p.table).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(row).
+		Error
+	if err != nil {
+		return errors.WrapIf(
+			err,
+			fmt.Sprintf("error in projecting the event '%s' of edition '%s'", streamEvent.EventID, editionId),
+		)
+	}
+
+	p.log.Debugf("projected %s event of edition '%s' at version %d", row.EventType, editionId, row.Version)
+
+	return nil
+}
+
+func (p *editionProvenanceProjection) ProjectionName() string {
+	return EditionProvenanceProjectionName
+}
+
+func (p *editionProvenanceProjection) PrepareRebuild(ctx context.Context) (projection.IProjection, error) {
+	shadowTable := gormextensions.ShadowTableName(p.table)
+
+	err := gormextensions.CreateShadowTable(ctx, p.db, p.table, shadowTable)
+	if err != nil {
+		return nil, err
+	}
+
+	return &editionProvenanceProjection{log: p.log, db: p.db, table: shadowTable}, nil
+}
+
+func (p *editionProvenanceProjection) SwapRebuild(ctx context.Context) error {
+	return gormextensions.SwapShadowTable(ctx, p.db, p.table, gormextensions.ShadowTableName(p.table))
+}
+
+func (p *editionProvenanceProjection) CleanupRebuild(ctx context.Context) error {
+	return gormextensions.DropShadowTable(ctx, p.db, gormextensions.ShadowTableName(p.table))
+}
+
+func isEditionEvent(streamEvent *esModels.StreamEvent) bool {
+	switch streamEvent.Event.(type) {
+	case *models.EditionMinted, *models.EditionListed, *models.EditionUnlisted, *models.EditionSold,
+		*models.EditionTransferred, *models.EditionFrozen, *models.EditionUnfrozen, *models.EditionBurned:
+		return true
+	default:
+		return false
+	}
+}
+
+// newProvenanceRow creates the provenance row of the event from the state of edition after its previous event
+func newProvenanceRow(
+	streamEvent *esModels.StreamEvent,
+	previous *datamodels.EditionProvenanceDataModel,
+) *datamodels.EditionProvenanceDataModel {
+	row := &datamodels.EditionProvenanceDataModel{
+		Id:         streamEvent.EventID,
+		EditionId:  streamEvent.Event.GetAggregateId(),
+		Version:    streamEvent.Version,
+		Position:   streamEvent.Position,
+		OccurredAt: streamEvent.Event.GetOccurredOn(),
+	}
+	if previous != nil {
+		row.ProductId = previous.ProductId
+		row.TokenId = previous.TokenId
+		row.EditionNumber = previous.EditionNumber
+		row.Owner = previous.Owner
+		row.Status = previous.Status
+		row.Frozen = previous.Frozen
+	}
+
+	switch evt := streamEvent.Event.(type) {
+	case *models.EditionMinted:
+		row.EventType = ProvenanceMinted
+		row.ProductId = evt.ProductId
+		row.TokenId = evt.TokenId
+		row.EditionNumber = evt.EditionNumber
+		row.ToOwner = evt.Owner
+		row.Owner = evt.Owner
+		row.Status = string(models.EditionStatusOwned)
+	case *models.EditionListed:
+		row.EventType = ProvenanceListed
+		row.FromOwner = evt.Seller
+		row.Status = string(models.EditionStatusListed)
+		row.Price = decimalPtr(evt.Price)
+		row.Currency = evt.Currency
+	case *models.EditionUnlisted:
+		row.EventType = ProvenanceUnlisted
+		row.FromOwner = evt.Seller
+		row.Status = string(models.EditionStatusOwned)
+	case *models.EditionSold:
+		row.EventType = ProvenanceSold
+		row.FromOwner = evt.Seller
+		row.ToOwner = evt.Buyer
+		row.Owner = evt.Buyer
+		row.Status = string(models.EditionStatusOwned)
+		row.Price = decimalPtr(evt.Price)
+		row.Currency = evt.Currency
+	case *models.EditionTransferred:
+		row.EventType = ProvenanceTransferred
+		row.FromOwner = evt.From
+		row.ToOwner = evt.To
+		row.Owner = evt.To
+	case *models.EditionFrozen:
+		row.EventType = ProvenanceFrozen
+		row.Frozen = true
+		row.Reason = evt.Reason
+	case *models.EditionUnfrozen:
+		row.EventType = ProvenanceUnfrozen
+		row.Frozen = false
+	case *models.EditionBurned:
+		row.EventType = ProvenanceBurned
+		row.FromOwner = evt.Owner
+		row.Status = string(models.EditionStatusBurned)
+	}
+
+	return row
+}
+
+func decimalPtr(value decimal.Decimal) *decimal.Decimal {
+	return &value
+}
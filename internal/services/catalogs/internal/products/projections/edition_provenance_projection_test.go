@@ -0,0 +1,64 @@
+//go:build unit
+// +build unit
+
+package projections
+
+import (
+	"testing"
+
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/datamodels"
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
+	esModels "github.com/reoden/go-NFT/pkg/es/models"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Provenance_Rows_Keep_The_State_After_Each_Event(t *testing.T) {
+	edition, err := models.NewEdition(uuid.NewV4(), uuid.NewV4(), "token-7", 7, "0xartist")
+	require.NoError(t, err)
+	require.NoError(t, edition.List("0xartist", decimal.NewFromInt(2), "ETH"))
+	require.NoError(t, edition.Sell("0xcollector"))
+	require.NoError(t, edition.Freeze("dispute"))
+
+	var (
+		rows     []*datamodels.EditionProvenanceDataModel
+		previous *datamodels.EditionProvenanceDataModel
+	)
+	for i, event := range edition.UncommittedEvents() {
+		streamEvent := &esModels.StreamEvent{
+			EventID:  event.GetEventId(),
+			Version:  int64(i),
+			Position: int64(i + 10),
+			Event:    event,
+		}
+		require.True(t, isEditionEvent(streamEvent))
+
+		previous = newProvenanceRow(streamEvent, previous)
+		rows = append(rows, previous)
+	}
+
+	require.Len(t, rows, 4)
+
+	assert.Equal(t, ProvenanceMinted, rows[0].EventType)
+	assert.Equal(t, "0xartist", rows[0].ToOwner)
+	assert.Equal(t, "token-7", rows[0].TokenId)
+
+	assert.Equal(t, ProvenanceListed, rows[1].EventType)
+	assert.Equal(t, string(models.EditionStatusListed), rows[1].Status)
+	assert.True(t, decimal.NewFromInt(2).Equal(*rows[1].Price))
+
+	assert.Equal(t, ProvenanceSold, rows[2].EventType)
+	assert.Equal(t, "0xartist", rows[2].FromOwner)
+	assert.Equal(t, "0xcollector", rows[2].Owner)
+	assert.Equal(t, string(models.EditionStatusOwned), rows[2].Status)
+
+	assert.Equal(t, ProvenanceFrozen, rows[3].EventType)
+	assert.True(t, rows[3].Frozen)
+	assert.Equal(t, "0xcollector", rows[3].Owner)
+	assert.Equal(t, "dispute", rows[3].Reason)
+	assert.Equal(t, int64(13), rows[3].Position)
+	assert.Equal(t, edition.ProductId, rows[3].ProductId)
+}
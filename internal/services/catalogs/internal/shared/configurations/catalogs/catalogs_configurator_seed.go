@@ -1,14 +1,18 @@
 package catalogs
 
 import (
+	"context"
 	"time"
 
 	datamodel "github.com/reoden/go-NFT/catalogs/internal/products/data/datamodels"
+	"github.com/reoden/go-NFT/catalogs/internal/products/data/pricehistory"
+	"github.com/reoden/go-NFT/catalogs/internal/products/models"
 	"github.com/reoden/go-NFT/pkg/testfixture"
 
 	"emperror.dev/errors"
 	"github.com/brianvoe/gofakeit/v6"
 	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 )
 
@@ -38,14 +42,18 @@ func seedDataManually(gormDB *gorm.DB) error {
 			Name:        gofakeit.Name(),
 			CreatedAt:   time.Now(),
 			Description: gofakeit.AdjectiveDescriptive(),
-			Price:       gofakeit.Price(100, 1000),
+			Artist:      gofakeit.Name(),
+			Price:       decimal.NewFromFloat(gofakeit.Price(100, 1000)).Round(2),
+			Currency:    models.DefaultCurrency,
 		},
 		{
 			Id:          uuid.NewV4(),
 			Name:        gofakeit.Name(),
 			CreatedAt:   time.Now(),
 			Description: gofakeit.AdjectiveDescriptive(),
-			Price:       gofakeit.Price(100, 1000),
+			Artist:      gofakeit.Name(),
+			Price:       decimal.NewFromFloat(gofakeit.Price(100, 1000)).Round(2),
+			Currency:    models.DefaultCurrency,
 		},
 	}
 
@@ -54,6 +62,21 @@ func seedDataManually(gormDB *gorm.DB) error {
 		return errors.Wrap(err, "error in seed database")
 	}
 
+	for _, product := range products {
+		err = pricehistory.RecordPriceChange(
+			context.Background(),
+			gormDB,
+			product.Id,
+			product.Price,
+			product.Currency,
+			models.CreatedPriceChangeReason,
+			product.CreatedAt,
+		)
+		if err != nil {
+			return errors.Wrap(err, "error in seed database")
+		}
+	}
+
 	return nil
 }
 
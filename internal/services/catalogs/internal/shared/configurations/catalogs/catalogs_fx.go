@@ -8,6 +8,7 @@ import (
 	"github.com/reoden/go-NFT/catalogs/internal/shared/configurations/catalogs/infrastructure"
 	"github.com/reoden/go-NFT/catalogs/internal/shared/contracts"
 	"github.com/reoden/go-NFT/catalogs/internal/shared/data"
+	"github.com/reoden/go-NFT/pkg/postgresmessaging/messagepersistence"
 
 	"go.opentelemetry.io/otel/metric"
 	api "go.opentelemetry.io/otel/metric"
@@ -27,6 +28,9 @@ var CatalogsServiceModule = fx.Module(
 
 	// Other provides
 	fx.Provide(provideCatalogsMetrics),
+
+	// messages published inside the transactional requests are stored in the outbox of the same transaction
+	fx.Decorate(messagepersistence.NewOutboxProducer),
 )
 
 // ref: https://github.com/open-telemetry/opentelemetry-go/blob/main/example/prometheus/main.go
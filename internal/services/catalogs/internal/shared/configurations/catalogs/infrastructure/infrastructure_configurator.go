@@ -8,8 +8,10 @@ import (
 	metricspipelines "github.com/reoden/go-NFT/pkg/otel/metrics/mediatr/pipelines"
 	"github.com/reoden/go-NFT/pkg/otel/tracing"
 	tracingpipelines "github.com/reoden/go-NFT/pkg/otel/tracing/mediatr/pipelines"
+	postgrespipelines "github.com/reoden/go-NFT/pkg/postgresgorm/pipelines"
 
 	"github.com/mehdihadeli/go-mediatr"
+	"gorm.io/gorm"
 )
 
 type InfrastructureConfigurator struct {
@@ -26,7 +28,7 @@ func NewInfrastructureConfigurator(
 
 func (ic *InfrastructureConfigurator) ConfigInfrastructures() {
 	ic.ResolveFunc(
-		func(l logger.Logger, tracer tracing.AppTracer, metrics metrics.AppMetrics) error {
+		func(l logger.Logger, tracer tracing.AppTracer, metrics metrics.AppMetrics, db *gorm.DB) error {
 			err := mediatr.RegisterRequestPipelineBehaviors(
 				loggingpipelines.NewMediatorLoggingPipeline(l),
 				tracingpipelines.NewMediatorTracingPipeline(
@@ -37,6 +39,8 @@ func (ic *InfrastructureConfigurator) ConfigInfrastructures() {
 					metrics,
 					metricspipelines.WithLogger(l),
 				),
+				// the transactional requests are handled inside a transaction, so their messages go through the outbox
+				postgrespipelines.NewMediatorTransactionPipeline(l, db),
 			)
 
 			return err
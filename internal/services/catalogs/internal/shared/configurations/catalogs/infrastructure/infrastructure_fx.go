@@ -2,17 +2,27 @@ package infrastructure
 
 import (
 	rabbitmq2 "github.com/reoden/go-NFT/catalogs/internal/products/configurations/rabbitmq"
+	"github.com/reoden/go-NFT/catalogs/internal/products/contracts"
 	"github.com/reoden/go-NFT/pkg/core"
+	"github.com/reoden/go-NFT/pkg/core/messaging/pipeline"
+	"github.com/reoden/go-NFT/pkg/elasticsearch"
 	"github.com/reoden/go-NFT/pkg/grpc"
+	"github.com/reoden/go-NFT/pkg/grpc/interceptors"
 	"github.com/reoden/go-NFT/pkg/health"
 	customEcho "github.com/reoden/go-NFT/pkg/http/customecho"
+	"github.com/reoden/go-NFT/pkg/http/customecho/middlewares/auth"
+	"github.com/reoden/go-NFT/pkg/logger"
 	"github.com/reoden/go-NFT/pkg/migration/goose"
 	"github.com/reoden/go-NFT/pkg/otel/metrics"
 	"github.com/reoden/go-NFT/pkg/otel/tracing"
+	"github.com/reoden/go-NFT/pkg/postgreseventstore"
 	"github.com/reoden/go-NFT/pkg/postgresgorm"
 	"github.com/reoden/go-NFT/pkg/postgresmessaging"
+	"github.com/reoden/go-NFT/pkg/queue"
 	"github.com/reoden/go-NFT/pkg/rabbitmq"
 	"github.com/reoden/go-NFT/pkg/rabbitmq/configurations"
+	"github.com/reoden/go-NFT/pkg/redis"
+	"github.com/reoden/go-NFT/pkg/storage"
 
 	"github.com/go-playground/validator"
 	"go.uber.org/fx"
@@ -27,11 +37,28 @@ var Module = fx.Module(
 	grpc.Module,
 	postgresgorm.Module,
 	postgresmessaging.Module,
+	// dispatcher of the messages that are stored in the outbox by the transactional requests
+	postgresmessaging.OutboxModule,
+	// removes the expired messages of the consumers inbox
+	postgresmessaging.InboxModule,
+	// scheduled producer and the dispatcher of the delayed and the recurring messages
+	postgresmessaging.SchedulerModule,
+	// event store of the event sourced aggregates and the rebuilder of their projections on the catalogs database
+	postgreseventstore.Module,
 	goose.Module,
+	elasticsearch.Module,
+	storage.Module,
+	redis.Module,
+	// asynq worker of the scheduled tasks like the scheduled product price changes
+	queue.WorkerModule,
 	rabbitmq.ModuleFunc(
-		func() configurations.RabbitMQConfigurationBuilderFuc {
+		func(
+			log logger.Logger,
+			searchRepository contracts.ProductSearchRepository,
+			inboxPipelineFactory pipeline.InboxPipelineFactory,
+		) configurations.RabbitMQConfigurationBuilderFuc {
 			return func(builder configurations.RabbitMQConfigurationBuilder) {
-				rabbitmq2.ConfigProductsRabbitMQ(builder)
+				rabbitmq2.ConfigProductsRabbitMQ(builder, log, searchRepository, inboxPipelineFactory)
 			}
 		},
 	),
@@ -41,4 +68,10 @@ var Module = fx.Module(
 
 	// Other provides
 	fx.Provide(validator.New),
+	// the tokens logged out on the user service are rejected by the echo middlewares and the grpc auth interceptors
+	fx.Provide(fx.Annotate(
+		auth.NewRedisTokenBlacklistChecker,
+		fx.As(fx.Self()),
+		fx.As(new(interceptors.TokenBlacklistChecker)),
+	)),
 )
@@ -24,6 +24,7 @@ import (
 	"emperror.dev/errors"
 	"github.com/brianvoe/gofakeit/v6"
 	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/fx"
 	"go.uber.org/fx/fxtest"
@@ -131,7 +132,8 @@ func (s *DBContextTestSuite) Test_CreateProduct() {
 		Id:          uuid.NewV4(),
 		Name:        gofakeit.Name(),
 		Description: gofakeit.AdjectiveDescriptive(),
-		Price:       gofakeit.Price(100, 1000),
+		Price:       decimal.NewFromFloat(gofakeit.Price(100, 1000)).Round(2),
+		Currency:    models.DefaultCurrency,
 	}
 
 	res, err := gormdbcontext.AddModel[*datamodel.ProductDataModel, *models.Product](
@@ -151,6 +153,8 @@ func (s *DBContextTestSuite) Test_CreateProduct() {
 
 	s.Assert().Equal(p.Id, item.Id)
 	s.Assert().Equal(p.Id, res.Id)
+	s.Assert().True(p.Price.Equal(item.Price))
+	s.Assert().Equal(item.Currency, p.Currency)
 }
 
 func (s *DBContextTestSuite) Test_UpdateProduct() {
@@ -268,14 +272,16 @@ func seedData(gormDB *gorm.DB) ([]*datamodel.ProductDataModel, error) {
 			Name:        gofakeit.Name(),
 			CreatedAt:   time.Now(),
 			Description: gofakeit.AdjectiveDescriptive(),
-			Price:       gofakeit.Price(100, 1000),
+			Price:       decimal.NewFromFloat(gofakeit.Price(100, 1000)).Round(2),
+			Currency:    models.DefaultCurrency,
 		},
 		{
 			Id:          uuid.NewV4(),
 			Name:        gofakeit.Name(),
 			CreatedAt:   time.Now(),
 			Description: gofakeit.AdjectiveDescriptive(),
-			Price:       gofakeit.Price(100, 1000),
+			Price:       decimal.NewFromFloat(gofakeit.Price(100, 1000)).Round(2),
+			Currency:    models.DefaultCurrency,
 		},
 	}
 
@@ -22,21 +22,76 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Money exact amount of a price, `Amount` is a decimal string like `12.50` and `Currency` is a code like `USD` or `ETH`
+type Money struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        string                 `protobuf:"bytes,1,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=Currency,proto3" json:"Currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_products_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_products_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_products_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Money) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *Money) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
 type Product struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProductId     string                 `protobuf:"bytes,1,opt,name=ProductId,proto3" json:"ProductId,omitempty"`
 	Name          string                 `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
 	Description   string                 `protobuf:"bytes,3,opt,name=Description,proto3" json:"Description,omitempty"`
-	Price         float64                `protobuf:"fixed64,4,opt,name=Price,proto3" json:"Price,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=UpdatedAt,proto3" json:"UpdatedAt,omitempty"`
+	Artist        string                 `protobuf:"bytes,8,opt,name=Artist,proto3" json:"Artist,omitempty"`
+	Version       int64                  `protobuf:"varint,9,opt,name=Version,proto3" json:"Version,omitempty"`
+	Price         *Money                 `protobuf:"bytes,10,opt,name=Price,proto3" json:"Price,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Product) Reset() {
 	*x = Product{}
-	mi := &file_products_proto_msgTypes[0]
+	mi := &file_products_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -48,7 +103,7 @@ func (x *Product) String() string {
 func (*Product) ProtoMessage() {}
 
 func (x *Product) ProtoReflect() protoreflect.Message {
-	mi := &file_products_proto_msgTypes[0]
+	mi := &file_products_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -61,7 +116,7 @@ func (x *Product) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Product.ProtoReflect.Descriptor instead.
 func (*Product) Descriptor() ([]byte, []int) {
-	return file_products_proto_rawDescGZIP(), []int{0}
+	return file_products_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *Product) GetProductId() string {
@@ -85,13 +140,6 @@ func (x *Product) GetDescription() string {
 	return ""
 }
 
-func (x *Product) GetPrice() float64 {
-	if x != nil {
-		return x.Price
-	}
-	return 0
-}
-
 func (x *Product) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
 		return x.CreatedAt
@@ -106,18 +154,40 @@ func (x *Product) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Product) GetArtist() string {
+	if x != nil {
+		return x.Artist
+	}
+	return ""
+}
+
+func (x *Product) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Product) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
 type CreateProductReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
 	Description   string                 `protobuf:"bytes,2,opt,name=Description,proto3" json:"Description,omitempty"`
-	Price         float64                `protobuf:"fixed64,3,opt,name=Price,proto3" json:"Price,omitempty"`
+	Artist        string                 `protobuf:"bytes,4,opt,name=Artist,proto3" json:"Artist,omitempty"`
+	Price         *Money                 `protobuf:"bytes,5,opt,name=Price,proto3" json:"Price,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateProductReq) Reset() {
 	*x = CreateProductReq{}
-	mi := &file_products_proto_msgTypes[1]
+	mi := &file_products_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -129,7 +199,7 @@ func (x *CreateProductReq) String() string {
 func (*CreateProductReq) ProtoMessage() {}
 
 func (x *CreateProductReq) ProtoReflect() protoreflect.Message {
-	mi := &file_products_proto_msgTypes[1]
+	mi := &file_products_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -142,7 +212,7 @@ func (x *CreateProductReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateProductReq.ProtoReflect.Descriptor instead.
 func (*CreateProductReq) Descriptor() ([]byte, []int) {
-	return file_products_proto_rawDescGZIP(), []int{1}
+	return file_products_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *CreateProductReq) GetName() string {
@@ -159,11 +229,18 @@ func (x *CreateProductReq) GetDescription() string {
 	return ""
 }
 
-func (x *CreateProductReq) GetPrice() float64 {
+func (x *CreateProductReq) GetArtist() string {
+	if x != nil {
+		return x.Artist
+	}
+	return ""
+}
+
+func (x *CreateProductReq) GetPrice() *Money {
 	if x != nil {
 		return x.Price
 	}
-	return 0
+	return nil
 }
 
 type CreateProductRes struct {
@@ -175,7 +252,7 @@ type CreateProductRes struct {
 
 func (x *CreateProductRes) Reset() {
 	*x = CreateProductRes{}
-	mi := &file_products_proto_msgTypes[2]
+	mi := &file_products_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -187,7 +264,7 @@ func (x *CreateProductRes) String() string {
 func (*CreateProductRes) ProtoMessage() {}
 
 func (x *CreateProductRes) ProtoReflect() protoreflect.Message {
-	mi := &file_products_proto_msgTypes[2]
+	mi := &file_products_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -200,7 +277,7 @@ func (x *CreateProductRes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateProductRes.ProtoReflect.Descriptor instead.
 func (*CreateProductRes) Descriptor() ([]byte, []int) {
-	return file_products_proto_rawDescGZIP(), []int{2}
+	return file_products_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *CreateProductRes) GetProductId() string {
@@ -211,18 +288,21 @@ func (x *CreateProductRes) GetProductId() string {
 }
 
 type UpdateProductReq struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     string                 `protobuf:"bytes,1,opt,name=ProductId,proto3" json:"ProductId,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=Description,proto3" json:"Description,omitempty"`
-	Price         float64                `protobuf:"fixed64,4,opt,name=Price,proto3" json:"Price,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ProductId   string                 `protobuf:"bytes,1,opt,name=ProductId,proto3" json:"ProductId,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=Description,proto3" json:"Description,omitempty"`
+	Artist      string                 `protobuf:"bytes,5,opt,name=Artist,proto3" json:"Artist,omitempty"`
+	// Version of the product that the update is based on, the update fails with `Aborted` when it is outdated
+	Version       int64  `protobuf:"varint,6,opt,name=Version,proto3" json:"Version,omitempty"`
+	Price         *Money `protobuf:"bytes,7,opt,name=Price,proto3" json:"Price,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateProductReq) Reset() {
 	*x = UpdateProductReq{}
-	mi := &file_products_proto_msgTypes[3]
+	mi := &file_products_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -234,7 +314,7 @@ func (x *UpdateProductReq) String() string {
 func (*UpdateProductReq) ProtoMessage() {}
 
 func (x *UpdateProductReq) ProtoReflect() protoreflect.Message {
-	mi := &file_products_proto_msgTypes[3]
+	mi := &file_products_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -247,7 +327,7 @@ func (x *UpdateProductReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateProductReq.ProtoReflect.Descriptor instead.
 func (*UpdateProductReq) Descriptor() ([]byte, []int) {
-	return file_products_proto_rawDescGZIP(), []int{3}
+	return file_products_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *UpdateProductReq) GetProductId() string {
@@ -271,22 +351,37 @@ func (x *UpdateProductReq) GetDescription() string {
 	return ""
 }
 
-func (x *UpdateProductReq) GetPrice() float64 {
+func (x *UpdateProductReq) GetArtist() string {
 	if x != nil {
-		return x.Price
+		return x.Artist
+	}
+	return ""
+}
+
+func (x *UpdateProductReq) GetVersion() int64 {
+	if x != nil {
+		return x.Version
 	}
 	return 0
 }
 
+func (x *UpdateProductReq) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
 type UpdateProductRes struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int64                  `protobuf:"varint,1,opt,name=Version,proto3" json:"Version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateProductRes) Reset() {
 	*x = UpdateProductRes{}
-	mi := &file_products_proto_msgTypes[4]
+	mi := &file_products_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -298,7 +393,7 @@ func (x *UpdateProductRes) String() string {
 func (*UpdateProductRes) ProtoMessage() {}
 
 func (x *UpdateProductRes) ProtoReflect() protoreflect.Message {
-	mi := &file_products_proto_msgTypes[4]
+	mi := &file_products_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -311,7 +406,14 @@ func (x *UpdateProductRes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateProductRes.ProtoReflect.Descriptor instead.
 func (*UpdateProductRes) Descriptor() ([]byte, []int) {
-	return file_products_proto_rawDescGZIP(), []int{4}
+	return file_products_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateProductRes) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
 }
 
 type GetProductByIdReq struct {
@@ -323,7 +425,7 @@ type GetProductByIdReq struct {
 
 func (x *GetProductByIdReq) Reset() {
 	*x = GetProductByIdReq{}
-	mi := &file_products_proto_msgTypes[5]
+	mi := &file_products_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -335,7 +437,7 @@ func (x *GetProductByIdReq) String() string {
 func (*GetProductByIdReq) ProtoMessage() {}
 
 func (x *GetProductByIdReq) ProtoReflect() protoreflect.Message {
-	mi := &file_products_proto_msgTypes[5]
+	mi := &file_products_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -348,7 +450,7 @@ func (x *GetProductByIdReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetProductByIdReq.ProtoReflect.Descriptor instead.
 func (*GetProductByIdReq) Descriptor() ([]byte, []int) {
-	return file_products_proto_rawDescGZIP(), []int{5}
+	return file_products_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetProductByIdReq) GetProductId() string {
@@ -367,7 +469,7 @@ type GetProductByIdRes struct {
 
 func (x *GetProductByIdRes) Reset() {
 	*x = GetProductByIdRes{}
-	mi := &file_products_proto_msgTypes[6]
+	mi := &file_products_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -379,7 +481,7 @@ func (x *GetProductByIdRes) String() string {
 func (*GetProductByIdRes) ProtoMessage() {}
 
 func (x *GetProductByIdRes) ProtoReflect() protoreflect.Message {
-	mi := &file_products_proto_msgTypes[6]
+	mi := &file_products_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -392,7 +494,7 @@ func (x *GetProductByIdRes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetProductByIdRes.ProtoReflect.Descriptor instead.
 func (*GetProductByIdRes) Descriptor() ([]byte, []int) {
-	return file_products_proto_rawDescGZIP(), []int{6}
+	return file_products_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetProductByIdRes) GetProduct() *Product {
@@ -406,26 +508,36 @@ var File_products_proto protoreflect.FileDescriptor
 
 const file_products_proto_rawDesc = "" +
 	"\n" +
-	"\x0eproducts.proto\x12\x10products_service\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe7\x01\n" +
+	"\x0eproducts.proto\x12\x10products_service\x1a\x1fgoogle/protobuf/timestamp.proto\";\n" +
+	"\x05Money\x12\x16\n" +
+	"\x06Amount\x18\x01 \x01(\tR\x06Amount\x12\x1a\n" +
+	"\bCurrency\x18\x02 \x01(\tR\bCurrency\"\xb8\x02\n" +
 	"\aProduct\x12\x1c\n" +
 	"\tProductId\x18\x01 \x01(\tR\tProductId\x12\x12\n" +
 	"\x04Name\x18\x02 \x01(\tR\x04Name\x12 \n" +
-	"\vDescription\x18\x03 \x01(\tR\vDescription\x12\x14\n" +
-	"\x05Price\x18\x04 \x01(\x01R\x05Price\x128\n" +
+	"\vDescription\x18\x03 \x01(\tR\vDescription\x128\n" +
 	"\tCreatedAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tCreatedAt\x128\n" +
-	"\tUpdatedAt\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tUpdatedAt\"^\n" +
+	"\tUpdatedAt\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tUpdatedAt\x12\x16\n" +
+	"\x06Artist\x18\b \x01(\tR\x06Artist\x12\x18\n" +
+	"\aVersion\x18\t \x01(\x03R\aVersion\x12-\n" +
+	"\x05Price\x18\n" +
+	" \x01(\v2\x17.products_service.MoneyR\x05PriceJ\x04\b\x04\x10\x05\"\x95\x01\n" +
 	"\x10CreateProductReq\x12\x12\n" +
 	"\x04Name\x18\x01 \x01(\tR\x04Name\x12 \n" +
-	"\vDescription\x18\x02 \x01(\tR\vDescription\x12\x14\n" +
-	"\x05Price\x18\x03 \x01(\x01R\x05Price\"0\n" +
+	"\vDescription\x18\x02 \x01(\tR\vDescription\x12\x16\n" +
+	"\x06Artist\x18\x04 \x01(\tR\x06Artist\x12-\n" +
+	"\x05Price\x18\x05 \x01(\v2\x17.products_service.MoneyR\x05PriceJ\x04\b\x03\x10\x04\"0\n" +
 	"\x10CreateProductRes\x12\x1c\n" +
-	"\tProductId\x18\x01 \x01(\tR\tProductId\"|\n" +
+	"\tProductId\x18\x01 \x01(\tR\tProductId\"\xcd\x01\n" +
 	"\x10UpdateProductReq\x12\x1c\n" +
 	"\tProductId\x18\x01 \x01(\tR\tProductId\x12\x12\n" +
 	"\x04Name\x18\x02 \x01(\tR\x04Name\x12 \n" +
-	"\vDescription\x18\x03 \x01(\tR\vDescription\x12\x14\n" +
-	"\x05Price\x18\x04 \x01(\x01R\x05Price\"\x12\n" +
-	"\x10UpdateProductRes\"1\n" +
+	"\vDescription\x18\x03 \x01(\tR\vDescription\x12\x16\n" +
+	"\x06Artist\x18\x05 \x01(\tR\x06Artist\x12\x18\n" +
+	"\aVersion\x18\x06 \x01(\x03R\aVersion\x12-\n" +
+	"\x05Price\x18\a \x01(\v2\x17.products_service.MoneyR\x05PriceJ\x04\b\x04\x10\x05\",\n" +
+	"\x10UpdateProductRes\x12\x18\n" +
+	"\aVersion\x18\x01 \x01(\x03R\aVersion\"1\n" +
 	"\x11GetProductByIdReq\x12\x1c\n" +
 	"\tProductId\x18\x01 \x01(\tR\tProductId\"H\n" +
 	"\x11GetProductByIdRes\x123\n" +
@@ -447,32 +559,36 @@ func file_products_proto_rawDescGZIP() []byte {
 	return file_products_proto_rawDescData
 }
 
-var file_products_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_products_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_products_proto_goTypes = []any{
-	(*Product)(nil),               // 0: products_service.Product
-	(*CreateProductReq)(nil),      // 1: products_service.CreateProductReq
-	(*CreateProductRes)(nil),      // 2: products_service.CreateProductRes
-	(*UpdateProductReq)(nil),      // 3: products_service.UpdateProductReq
-	(*UpdateProductRes)(nil),      // 4: products_service.UpdateProductRes
-	(*GetProductByIdReq)(nil),     // 5: products_service.GetProductByIdReq
-	(*GetProductByIdRes)(nil),     // 6: products_service.GetProductByIdRes
-	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+	(*Money)(nil),                 // 0: products_service.Money
+	(*Product)(nil),               // 1: products_service.Product
+	(*CreateProductReq)(nil),      // 2: products_service.CreateProductReq
+	(*CreateProductRes)(nil),      // 3: products_service.CreateProductRes
+	(*UpdateProductReq)(nil),      // 4: products_service.UpdateProductReq
+	(*UpdateProductRes)(nil),      // 5: products_service.UpdateProductRes
+	(*GetProductByIdReq)(nil),     // 6: products_service.GetProductByIdReq
+	(*GetProductByIdRes)(nil),     // 7: products_service.GetProductByIdRes
+	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
 }
 var file_products_proto_depIdxs = []int32{
-	7, // 0: products_service.Product.CreatedAt:type_name -> google.protobuf.Timestamp
-	7, // 1: products_service.Product.UpdatedAt:type_name -> google.protobuf.Timestamp
-	0, // 2: products_service.GetProductByIdRes.Product:type_name -> products_service.Product
-	1, // 3: products_service.ProductsService.CreateProduct:input_type -> products_service.CreateProductReq
-	3, // 4: products_service.ProductsService.UpdateProduct:input_type -> products_service.UpdateProductReq
-	5, // 5: products_service.ProductsService.GetProductById:input_type -> products_service.GetProductByIdReq
-	2, // 6: products_service.ProductsService.CreateProduct:output_type -> products_service.CreateProductRes
-	4, // 7: products_service.ProductsService.UpdateProduct:output_type -> products_service.UpdateProductRes
-	6, // 8: products_service.ProductsService.GetProductById:output_type -> products_service.GetProductByIdRes
-	6, // [6:9] is the sub-list for method output_type
-	3, // [3:6] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	8, // 0: products_service.Product.CreatedAt:type_name -> google.protobuf.Timestamp
+	8, // 1: products_service.Product.UpdatedAt:type_name -> google.protobuf.Timestamp
+	0, // 2: products_service.Product.Price:type_name -> products_service.Money
+	0, // 3: products_service.CreateProductReq.Price:type_name -> products_service.Money
+	0, // 4: products_service.UpdateProductReq.Price:type_name -> products_service.Money
+	1, // 5: products_service.GetProductByIdRes.Product:type_name -> products_service.Product
+	2, // 6: products_service.ProductsService.CreateProduct:input_type -> products_service.CreateProductReq
+	4, // 7: products_service.ProductsService.UpdateProduct:input_type -> products_service.UpdateProductReq
+	6, // 8: products_service.ProductsService.GetProductById:input_type -> products_service.GetProductByIdReq
+	3, // 9: products_service.ProductsService.CreateProduct:output_type -> products_service.CreateProductRes
+	5, // 10: products_service.ProductsService.UpdateProduct:output_type -> products_service.UpdateProductRes
+	7, // 11: products_service.ProductsService.GetProductById:output_type -> products_service.GetProductByIdRes
+	9, // [9:12] is the sub-list for method output_type
+	6, // [6:9] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_products_proto_init() }
@@ -486,7 +602,7 @@ func file_products_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_products_proto_rawDesc), len(file_products_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
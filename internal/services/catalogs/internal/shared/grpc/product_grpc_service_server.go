@@ -9,6 +9,7 @@ import (
 	getProductByIdQueryV1 "github.com/reoden/go-NFT/catalogs/internal/products/features/gettingproductbyid/v1"
 	getProductByIdDtosV1 "github.com/reoden/go-NFT/catalogs/internal/products/features/gettingproductbyid/v1/dtos"
 	updateProductCommandV1 "github.com/reoden/go-NFT/catalogs/internal/products/features/updatingproduct/v1"
+	updateProductDtosV1 "github.com/reoden/go-NFT/catalogs/internal/products/features/updatingproduct/v1/dtos"
 	"github.com/reoden/go-NFT/catalogs/internal/shared/contracts"
 	productsService "github.com/reoden/go-NFT/catalogs/internal/shared/grpc/genproto"
 	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
@@ -19,6 +20,7 @@ import (
 	"emperror.dev/errors"
 	"github.com/mehdihadeli/go-mediatr"
 	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
 	attribute2 "go.opentelemetry.io/otel/attribute"
 	api "go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
@@ -53,10 +55,29 @@ func (s *ProductGrpcServiceServer) CreateProduct(
 	span.SetAttributes(attribute.Object("Request", req))
 	s.catalogsMetrics.CreateProductGrpcRequests.Add(ctx, 1, grpcMetricsAttr)
 
+	price, err := decimal.NewFromString(req.GetPrice().GetAmount())
+	if err != nil {
+		badRequestErr := customErrors.NewBadRequestErrorWrap(
+			err,
+			"[ProductGrpcServiceServer_CreateProduct.decimal.NewFromString] error in parsing the price amount",
+		)
+		s.logger.Errorf(
+			fmt.Sprintf(
+				"[ProductGrpcServiceServer_CreateProduct.decimal.NewFromString] err: %v",
+				badRequestErr,
+			),
+		)
+		return nil, badRequestErr
+	}
+
 	command, err := createProductCommandV1.NewCreateProductWithValidation(
 		req.GetName(),
 		req.GetDescription(),
-		req.GetPrice(),
+		req.GetArtist(),
+		price,
+		req.GetPrice().GetCurrency(),
+		nil,
+		nil,
 	)
 	if err != nil {
 		validationErr := customErrors.NewValidationErrorWrap(
@@ -120,11 +141,31 @@ func (s *ProductGrpcServiceServer) UpdateProduct(
 		return nil, badRequestErr
 	}
 
+	price, err := decimal.NewFromString(req.GetPrice().GetAmount())
+	if err != nil {
+		badRequestErr := customErrors.NewBadRequestErrorWrap(
+			err,
+			"[ProductGrpcServiceServer_UpdateProduct.decimal.NewFromString] error in parsing the price amount",
+		)
+		s.logger.Errorf(
+			fmt.Sprintf(
+				"[ProductGrpcServiceServer_UpdateProduct.decimal.NewFromString] err: %v",
+				badRequestErr,
+			),
+		)
+		return nil, badRequestErr
+	}
+
 	command, err := updateProductCommandV1.NewUpdateProductWithValidation(
 		productUUID,
 		req.GetName(),
 		req.GetDescription(),
-		req.GetPrice(),
+		req.GetArtist(),
+		price,
+		req.GetPrice().GetCurrency(),
+		req.GetVersion(),
+		nil,
+		nil,
 	)
 	if err != nil {
 		validationErr := customErrors.NewValidationErrorWrap(
@@ -140,7 +181,11 @@ func (s *ProductGrpcServiceServer) UpdateProduct(
 		return nil, validationErr
 	}
 
-	if _, err = mediatr.Send[*updateProductCommandV1.UpdateProduct, *mediatr.Unit](ctx, command); err != nil {
+	result, err := mediatr.Send[*updateProductCommandV1.UpdateProduct, *updateProductDtosV1.UpdateProductResponseDto](
+		ctx,
+		command,
+	)
+	if err != nil {
 		err = errors.WithMessage(
 			err,
 			"[ProductGrpcServiceServer_UpdateProduct.Send] error in sending CreateProduct",
@@ -156,7 +201,7 @@ func (s *ProductGrpcServiceServer) UpdateProduct(
 		return nil, err
 	}
 
-	return &productsService.UpdateProductRes{}, nil
+	return &productsService.UpdateProductRes{Version: result.Version}, nil
 }
 
 func (s *ProductGrpcServiceServer) GetProductById(
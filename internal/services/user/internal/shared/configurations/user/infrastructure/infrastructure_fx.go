@@ -6,6 +6,7 @@ import (
 	"github.com/reoden/go-NFT/pkg/bloom"
 	"github.com/reoden/go-NFT/pkg/core"
 	"github.com/reoden/go-NFT/pkg/grpc"
+	"github.com/reoden/go-NFT/pkg/grpc/interceptors"
 	"github.com/reoden/go-NFT/pkg/health"
 	customEcho "github.com/reoden/go-NFT/pkg/http/customecho"
 	"github.com/reoden/go-NFT/pkg/http/customecho/middlewares/auth"
@@ -37,5 +38,10 @@ var Module = fx.Module(
 
 	// Other provides
 	fx.Provide(validator.New),
-	fx.Provide(auth.NewRedisTokenBlacklistChecker),
+	// the logged out tokens are rejected by the echo middlewares and the grpc auth interceptors
+	fx.Provide(fx.Annotate(
+		auth.NewRedisTokenBlacklistChecker,
+		fx.As(fx.Self()),
+		fx.As(new(interceptors.TokenBlacklistChecker)),
+	)),
 )
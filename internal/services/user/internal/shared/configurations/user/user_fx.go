@@ -46,6 +46,46 @@ func provideUserMetrics(
 		return nil, err
 	}
 
+	getUserByIdGrpcRequests, err := meter.Float64Counter(
+		fmt.Sprintf("%s_get_user_by_id_grpc_requests_total", cfg.ServiceName),
+		api.WithDescription("The total number of get user by id grpc requests"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	batchGetUsersGrpcRequests, err := meter.Float64Counter(
+		fmt.Sprintf("%s_batch_get_users_grpc_requests_total", cfg.ServiceName),
+		api.WithDescription("The total number of batch get users grpc requests"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	checkAuthStateGrpcRequests, err := meter.Float64Counter(
+		fmt.Sprintf("%s_check_auth_state_grpc_requests_total", cfg.ServiceName),
+		api.WithDescription("The total number of check auth state grpc requests"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	validateTokenGrpcRequests, err := meter.Float64Counter(
+		fmt.Sprintf("%s_validate_token_grpc_requests_total", cfg.ServiceName),
+		api.WithDescription("The total number of validate token grpc requests"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	getUserRoleGrpcRequests, err := meter.Float64Counter(
+		fmt.Sprintf("%s_get_user_role_grpc_requests_total", cfg.ServiceName),
+		api.WithDescription("The total number of get user role grpc requests"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	//updateProductGrpcRequests, err := meter.Float64Counter(
 	//	fmt.Sprintf("%s_update_product_grpc_requests_total", cfg.ServiceName),
 	//	api.WithDescription("The total number of update product grpc requests"),
@@ -86,7 +126,12 @@ func provideUserMetrics(
 	return &contracts.UserMetrics{
 		//CreateProductRabbitMQMessages: createProductRabbitMQMessages,
 		//GetProductByIdGrpcRequests:    getProductByIdGrpcRequests,
-		CreateUserGrpcRequests: createUserGrpcRequests,
+		CreateUserGrpcRequests:     createUserGrpcRequests,
+		GetUserByIdGrpcRequests:    getUserByIdGrpcRequests,
+		BatchGetUsersGrpcRequests:  batchGetUsersGrpcRequests,
+		CheckAuthStateGrpcRequests: checkAuthStateGrpcRequests,
+		ValidateTokenGrpcRequests:  validateTokenGrpcRequests,
+		GetUserRoleGrpcRequests:    getUserRoleGrpcRequests,
 		//DeleteProductRabbitMQMessages: deleteProductRabbitMQMessages,
 		//DeleteProductGrpcRequests:     deleteProductGrpcRequests,
 		//ErrorRabbitMQMessages:         errorRabbitMQMessages,
@@ -6,6 +6,11 @@ import (
 
 type UserMetrics struct {
 	CreateUserGrpcRequests        metric.Float64Counter
+	GetUserByIdGrpcRequests       metric.Float64Counter
+	BatchGetUsersGrpcRequests     metric.Float64Counter
+	CheckAuthStateGrpcRequests    metric.Float64Counter
+	ValidateTokenGrpcRequests     metric.Float64Counter
+	GetUserRoleGrpcRequests       metric.Float64Counter
 	UpdateProductGrpcRequests     metric.Float64Counter
 	DeleteProductGrpcRequests     metric.Float64Counter
 	GetProductByIdGrpcRequests    metric.Float64Counter
@@ -242,6 +242,486 @@ func (x *CreateUserRes) GetUserId() string {
 	return ""
 }
 
+type GetUserByIdReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=UserId,proto3" json:"UserId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserByIdReq) Reset() {
+	*x = GetUserByIdReq{}
+	mi := &file_user_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserByIdReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserByIdReq) ProtoMessage() {}
+
+func (x *GetUserByIdReq) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserByIdReq.ProtoReflect.Descriptor instead.
+func (*GetUserByIdReq) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetUserByIdReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetUserByIdRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=User,proto3" json:"User,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserByIdRes) Reset() {
+	*x = GetUserByIdRes{}
+	mi := &file_user_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserByIdRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserByIdRes) ProtoMessage() {}
+
+func (x *GetUserByIdRes) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserByIdRes.ProtoReflect.Descriptor instead.
+func (*GetUserByIdRes) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetUserByIdRes) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type BatchGetUsersReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []string               `protobuf:"bytes,1,rep,name=UserIds,proto3" json:"UserIds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetUsersReq) Reset() {
+	*x = BatchGetUsersReq{}
+	mi := &file_user_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetUsersReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetUsersReq) ProtoMessage() {}
+
+func (x *BatchGetUsersReq) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetUsersReq.ProtoReflect.Descriptor instead.
+func (*BatchGetUsersReq) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BatchGetUsersReq) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type BatchGetUsersRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=Users,proto3" json:"Users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetUsersRes) Reset() {
+	*x = BatchGetUsersRes{}
+	mi := &file_user_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetUsersRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetUsersRes) ProtoMessage() {}
+
+func (x *BatchGetUsersRes) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetUsersRes.ProtoReflect.Descriptor instead.
+func (*BatchGetUsersRes) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BatchGetUsersRes) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type CheckAuthStateReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=UserId,proto3" json:"UserId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckAuthStateReq) Reset() {
+	*x = CheckAuthStateReq{}
+	mi := &file_user_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckAuthStateReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckAuthStateReq) ProtoMessage() {}
+
+func (x *CheckAuthStateReq) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckAuthStateReq.ProtoReflect.Descriptor instead.
+func (*CheckAuthStateReq) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CheckAuthStateReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type CheckAuthStateRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=UserId,proto3" json:"UserId,omitempty"`
+	State         string                 `protobuf:"bytes,2,opt,name=State,proto3" json:"State,omitempty"`
+	Certification bool                   `protobuf:"varint,3,opt,name=Certification,proto3" json:"Certification,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckAuthStateRes) Reset() {
+	*x = CheckAuthStateRes{}
+	mi := &file_user_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckAuthStateRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckAuthStateRes) ProtoMessage() {}
+
+func (x *CheckAuthStateRes) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckAuthStateRes.ProtoReflect.Descriptor instead.
+func (*CheckAuthStateRes) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CheckAuthStateRes) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CheckAuthStateRes) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *CheckAuthStateRes) GetCertification() bool {
+	if x != nil {
+		return x.Certification
+	}
+	return false
+}
+
+type ValidateTokenReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=Token,proto3" json:"Token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateTokenReq) Reset() {
+	*x = ValidateTokenReq{}
+	mi := &file_user_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateTokenReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateTokenReq) ProtoMessage() {}
+
+func (x *ValidateTokenReq) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateTokenReq.ProtoReflect.Descriptor instead.
+func (*ValidateTokenReq) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ValidateTokenReq) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type ValidateTokenRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=Valid,proto3" json:"Valid,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=UserId,proto3" json:"UserId,omitempty"`
+	UserRole      string                 `protobuf:"bytes,3,opt,name=UserRole,proto3" json:"UserRole,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateTokenRes) Reset() {
+	*x = ValidateTokenRes{}
+	mi := &file_user_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateTokenRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateTokenRes) ProtoMessage() {}
+
+func (x *ValidateTokenRes) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateTokenRes.ProtoReflect.Descriptor instead.
+func (*ValidateTokenRes) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ValidateTokenRes) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateTokenRes) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ValidateTokenRes) GetUserRole() string {
+	if x != nil {
+		return x.UserRole
+	}
+	return ""
+}
+
+type GetUserRoleReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=UserId,proto3" json:"UserId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserRoleReq) Reset() {
+	*x = GetUserRoleReq{}
+	mi := &file_user_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRoleReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRoleReq) ProtoMessage() {}
+
+func (x *GetUserRoleReq) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRoleReq.ProtoReflect.Descriptor instead.
+func (*GetUserRoleReq) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetUserRoleReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetUserRoleRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=UserId,proto3" json:"UserId,omitempty"`
+	UserRole      string                 `protobuf:"bytes,2,opt,name=UserRole,proto3" json:"UserRole,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserRoleRes) Reset() {
+	*x = GetUserRoleRes{}
+	mi := &file_user_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRoleRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRoleRes) ProtoMessage() {}
+
+func (x *GetUserRoleRes) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRoleRes.ProtoReflect.Descriptor instead.
+func (*GetUserRoleRes) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetUserRoleRes) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetUserRoleRes) GetUserRole() string {
+	if x != nil {
+		return x.UserRole
+	}
+	return ""
+}
+
 var File_user_proto protoreflect.FileDescriptor
 
 const file_user_proto_rawDesc = "" +
@@ -265,10 +745,40 @@ const file_user_proto_rawDesc = "" +
 	"\x05Phone\x18\x01 \x01(\tR\x05Phone\x12\x18\n" +
 	"\aCaptcha\x18\x02 \x01(\tR\aCaptcha\"'\n" +
 	"\rCreateUserRes\x12\x16\n" +
-	"\x06UserId\x18\x01 \x01(\tR\x06UserId2U\n" +
+	"\x06UserId\x18\x01 \x01(\tR\x06UserId\"(\n" +
+	"\x0eGetUserByIdReq\x12\x16\n" +
+	"\x06UserId\x18\x01 \x01(\tR\x06UserId\"8\n" +
+	"\x0eGetUserByIdRes\x12&\n" +
+	"\x04User\x18\x01 \x01(\v2\x12.user_service.UserR\x04User\",\n" +
+	"\x10BatchGetUsersReq\x12\x18\n" +
+	"\aUserIds\x18\x01 \x03(\tR\aUserIds\"<\n" +
+	"\x10BatchGetUsersRes\x12(\n" +
+	"\x05Users\x18\x01 \x03(\v2\x12.user_service.UserR\x05Users\"+\n" +
+	"\x11CheckAuthStateReq\x12\x16\n" +
+	"\x06UserId\x18\x01 \x01(\tR\x06UserId\"g\n" +
+	"\x11CheckAuthStateRes\x12\x16\n" +
+	"\x06UserId\x18\x01 \x01(\tR\x06UserId\x12\x14\n" +
+	"\x05State\x18\x02 \x01(\tR\x05State\x12$\n" +
+	"\rCertification\x18\x03 \x01(\bR\rCertification\"(\n" +
+	"\x10ValidateTokenReq\x12\x14\n" +
+	"\x05Token\x18\x01 \x01(\tR\x05Token\"\\\n" +
+	"\x10ValidateTokenRes\x12\x14\n" +
+	"\x05Valid\x18\x01 \x01(\bR\x05Valid\x12\x16\n" +
+	"\x06UserId\x18\x02 \x01(\tR\x06UserId\x12\x1a\n" +
+	"\bUserRole\x18\x03 \x01(\tR\bUserRole\"(\n" +
+	"\x0eGetUserRoleReq\x12\x16\n" +
+	"\x06UserId\x18\x01 \x01(\tR\x06UserId\"D\n" +
+	"\x0eGetUserRoleRes\x12\x16\n" +
+	"\x06UserId\x18\x01 \x01(\tR\x06UserId\x12\x1a\n" +
+	"\bUserRole\x18\x02 \x01(\tR\bUserRole2\xe1\x03\n" +
 	"\vUserService\x12F\n" +
 	"\n" +
-	"CreateUser\x12\x1b.user_service.CreateUserReq\x1a\x1b.user_service.CreateUserResB\x11Z\x0f./;user_serviceb\x06proto3"
+	"CreateUser\x12\x1b.user_service.CreateUserReq\x1a\x1b.user_service.CreateUserRes\x12I\n" +
+	"\vGetUserById\x12\x1c.user_service.GetUserByIdReq\x1a\x1c.user_service.GetUserByIdRes\x12O\n" +
+	"\rBatchGetUsers\x12\x1e.user_service.BatchGetUsersReq\x1a\x1e.user_service.BatchGetUsersRes\x12R\n" +
+	"\x0eCheckAuthState\x12\x1f.user_service.CheckAuthStateReq\x1a\x1f.user_service.CheckAuthStateRes\x12O\n" +
+	"\rValidateToken\x12\x1e.user_service.ValidateTokenReq\x1a\x1e.user_service.ValidateTokenRes\x12I\n" +
+	"\vGetUserRole\x12\x1c.user_service.GetUserRoleReq\x1a\x1c.user_service.GetUserRoleResB\x11Z\x0f./;user_serviceb\x06proto3"
 
 var (
 	file_user_proto_rawDescOnce sync.Once
@@ -282,23 +792,45 @@ func file_user_proto_rawDescGZIP() []byte {
 	return file_user_proto_rawDescData
 }
 
-var file_user_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_user_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_user_proto_goTypes = []any{
 	(*User)(nil),                  // 0: user_service.User
 	(*CreateUserReq)(nil),         // 1: user_service.CreateUserReq
 	(*CreateUserRes)(nil),         // 2: user_service.CreateUserRes
-	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+	(*GetUserByIdReq)(nil),        // 3: user_service.GetUserByIdReq
+	(*GetUserByIdRes)(nil),        // 4: user_service.GetUserByIdRes
+	(*BatchGetUsersReq)(nil),      // 5: user_service.BatchGetUsersReq
+	(*BatchGetUsersRes)(nil),      // 6: user_service.BatchGetUsersRes
+	(*CheckAuthStateReq)(nil),     // 7: user_service.CheckAuthStateReq
+	(*CheckAuthStateRes)(nil),     // 8: user_service.CheckAuthStateRes
+	(*ValidateTokenReq)(nil),      // 9: user_service.ValidateTokenReq
+	(*ValidateTokenRes)(nil),      // 10: user_service.ValidateTokenRes
+	(*GetUserRoleReq)(nil),        // 11: user_service.GetUserRoleReq
+	(*GetUserRoleRes)(nil),        // 12: user_service.GetUserRoleRes
+	(*timestamppb.Timestamp)(nil), // 13: google.protobuf.Timestamp
 }
 var file_user_proto_depIdxs = []int32{
-	3, // 0: user_service.User.CreatedAt:type_name -> google.protobuf.Timestamp
-	3, // 1: user_service.User.UpdatedAt:type_name -> google.protobuf.Timestamp
-	1, // 2: user_service.UserService.CreateUser:input_type -> user_service.CreateUserReq
-	2, // 3: user_service.UserService.CreateUser:output_type -> user_service.CreateUserRes
-	3, // [3:4] is the sub-list for method output_type
-	2, // [2:3] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	13, // 0: user_service.User.CreatedAt:type_name -> google.protobuf.Timestamp
+	13, // 1: user_service.User.UpdatedAt:type_name -> google.protobuf.Timestamp
+	0,  // 2: user_service.GetUserByIdRes.User:type_name -> user_service.User
+	0,  // 3: user_service.BatchGetUsersRes.Users:type_name -> user_service.User
+	1,  // 4: user_service.UserService.CreateUser:input_type -> user_service.CreateUserReq
+	3,  // 5: user_service.UserService.GetUserById:input_type -> user_service.GetUserByIdReq
+	5,  // 6: user_service.UserService.BatchGetUsers:input_type -> user_service.BatchGetUsersReq
+	7,  // 7: user_service.UserService.CheckAuthState:input_type -> user_service.CheckAuthStateReq
+	9,  // 8: user_service.UserService.ValidateToken:input_type -> user_service.ValidateTokenReq
+	11, // 9: user_service.UserService.GetUserRole:input_type -> user_service.GetUserRoleReq
+	2,  // 10: user_service.UserService.CreateUser:output_type -> user_service.CreateUserRes
+	4,  // 11: user_service.UserService.GetUserById:output_type -> user_service.GetUserByIdRes
+	6,  // 12: user_service.UserService.BatchGetUsers:output_type -> user_service.BatchGetUsersRes
+	8,  // 13: user_service.UserService.CheckAuthState:output_type -> user_service.CheckAuthStateRes
+	10, // 14: user_service.UserService.ValidateToken:output_type -> user_service.ValidateTokenRes
+	12, // 15: user_service.UserService.GetUserRole:output_type -> user_service.GetUserRoleRes
+	10, // [10:16] is the sub-list for method output_type
+	4,  // [4:10] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_user_proto_init() }
@@ -312,7 +844,7 @@ func file_user_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_user_proto_rawDesc), len(file_user_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
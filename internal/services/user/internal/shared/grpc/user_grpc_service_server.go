@@ -8,11 +8,17 @@ import (
 	"github.com/mehdihadeli/go-mediatr"
 	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
 	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/mapper"
 	"github.com/reoden/go-NFT/pkg/otel/tracing/attribute"
 	"github.com/reoden/go-NFT/user/internal/shared/contracts"
 	userService "github.com/reoden/go-NFT/user/internal/shared/grpc/genproto"
 	createUserCommandV1 "github.com/reoden/go-NFT/user/internal/user/features/creatinguser/v1/commands"
 	createUserDtosV1 "github.com/reoden/go-NFT/user/internal/user/features/creatinguser/v1/dtos"
+	findUserByIdDtosV1 "github.com/reoden/go-NFT/user/internal/user/features/finduserbyId/v1/dtos"
+	findUserByIdQueryV1 "github.com/reoden/go-NFT/user/internal/user/features/finduserbyId/v1/queries"
+	validateTokenDtosV1 "github.com/reoden/go-NFT/user/internal/user/features/validatetoken/v1/dtos"
+	validateTokenQueryV1 "github.com/reoden/go-NFT/user/internal/user/features/validatetoken/v1/queries"
+	uuid "github.com/satori/go.uuid"
 	attribute2 "go.opentelemetry.io/otel/attribute"
 	api "go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
@@ -22,6 +28,9 @@ var grpcMetricsAttr = api.WithAttributes(
 	attribute2.Key("MetricsType").String("Http"),
 )
 
+// maxBatchGetUsers the max number of the users of a BatchGetUsers request
+const maxBatchGetUsers = 100
+
 type UserGrpcServiceServer struct {
 	userMetrics *contracts.UserMetrics
 	logger      logger.Logger
@@ -90,6 +99,249 @@ func (s *UserGrpcServiceServer) CreateUser(
 	}, nil
 }
 
+func (s *UserGrpcServiceServer) GetUserById(
+	ctx context.Context,
+	req *userService.GetUserByIdReq,
+) (*userService.GetUserByIdRes, error) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Object("Request", req))
+	s.userMetrics.GetUserByIdGrpcRequests.Add(ctx, 1, grpcMetricsAttr)
+
+	queryResult, err := s.findUserById(ctx, "GetUserById", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.mapUser(queryResult, "GetUserById")
+	if err != nil {
+		return nil, err
+	}
+
+	return &userService.GetUserByIdRes{User: user}, nil
+}
+
+func (s *UserGrpcServiceServer) BatchGetUsers(
+	ctx context.Context,
+	req *userService.BatchGetUsersReq,
+) (*userService.BatchGetUsersRes, error) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Object("Request", req))
+	s.userMetrics.BatchGetUsersGrpcRequests.Add(ctx, 1, grpcMetricsAttr)
+
+	if len(req.GetUserIds()) > maxBatchGetUsers {
+		validationErr := customErrors.NewValidationError(
+			fmt.Sprintf(
+				"[UserGrpcServiceServer_BatchGetUsers.Validate] at most %d users can be requested in a batch",
+				maxBatchGetUsers,
+			),
+		)
+		s.logger.Errorf(
+			fmt.Sprintf(
+				"[UserGrpcServiceServer_BatchGetUsers.Validate] err: %v",
+				validationErr,
+			),
+		)
+		return nil, validationErr
+	}
+
+	users := make([]*userService.User, 0, len(req.GetUserIds()))
+	for _, userId := range req.GetUserIds() {
+		queryResult, err := s.findUserById(ctx, "BatchGetUsers", userId)
+		if err != nil {
+			return nil, err
+		}
+
+		user, err := s.mapUser(queryResult, "BatchGetUsers")
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return &userService.BatchGetUsersRes{Users: users}, nil
+}
+
+func (s *UserGrpcServiceServer) CheckAuthState(
+	ctx context.Context,
+	req *userService.CheckAuthStateReq,
+) (*userService.CheckAuthStateRes, error) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Object("Request", req))
+	s.userMetrics.CheckAuthStateGrpcRequests.Add(ctx, 1, grpcMetricsAttr)
+
+	queryResult, err := s.findUserById(ctx, "CheckAuthState", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &userService.CheckAuthStateRes{
+		UserId:        queryResult.User.UserId.String(),
+		State:         string(queryResult.User.State),
+		Certification: queryResult.User.Certification,
+	}, nil
+}
+
+func (s *UserGrpcServiceServer) ValidateToken(
+	ctx context.Context,
+	req *userService.ValidateTokenReq,
+) (*userService.ValidateTokenRes, error) {
+	// the token is a credential, it is not added to the span
+	s.userMetrics.ValidateTokenGrpcRequests.Add(ctx, 1, grpcMetricsAttr)
+
+	query, err := validateTokenQueryV1.NewValidateTokenWithValidation(req.GetToken())
+	if err != nil {
+		validationErr := customErrors.NewValidationErrorWrap(
+			err,
+			"[UserGrpcServiceServer_ValidateToken.StructCtx] query validation failed",
+		)
+		s.logger.Errorf(
+			fmt.Sprintf(
+				"[UserGrpcServiceServer_ValidateToken.StructCtx] err: %v",
+				validationErr,
+			),
+		)
+		return nil, validationErr
+	}
+
+	queryResult, err := mediatr.Send[*validateTokenQueryV1.ValidateToken, *validateTokenDtosV1.ValidateTokenResponseDto](
+		ctx,
+		query,
+	)
+	if err != nil {
+		err = errors.WithMessage(
+			err,
+			"[UserGrpcServiceServer_ValidateToken.Send] error in sending ValidateToken",
+		)
+		s.logger.Errorf(
+			fmt.Sprintf(
+				"[UserGrpcServiceServer_ValidateToken.Send] err: %v",
+				err,
+			),
+		)
+		return nil, err
+	}
+
+	if !queryResult.Valid {
+		return &userService.ValidateTokenRes{Valid: false}, nil
+	}
+
+	return &userService.ValidateTokenRes{
+		Valid:    true,
+		UserId:   queryResult.UserId.String(),
+		UserRole: string(queryResult.UserRole),
+	}, nil
+}
+
+func (s *UserGrpcServiceServer) GetUserRole(
+	ctx context.Context,
+	req *userService.GetUserRoleReq,
+) (*userService.GetUserRoleRes, error) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Object("Request", req))
+	s.userMetrics.GetUserRoleGrpcRequests.Add(ctx, 1, grpcMetricsAttr)
+
+	queryResult, err := s.findUserById(ctx, "GetUserRole", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &userService.GetUserRoleRes{
+		UserId:   queryResult.User.UserId.String(),
+		UserRole: string(queryResult.User.UserRole),
+	}, nil
+}
+
+// findUserById dispatches the FindUserById query of the rest api, the operation is the name of the calling rpc in the
+// errors and the logs
+func (s *UserGrpcServiceServer) findUserById(
+	ctx context.Context,
+	operation string,
+	id string,
+) (*findUserByIdDtosV1.FindUserByIdResponseDto, error) {
+	userUUID, err := uuid.FromString(id)
+	if err != nil {
+		badRequestErr := customErrors.NewBadRequestErrorWrap(
+			err,
+			fmt.Sprintf(
+				"[UserGrpcServiceServer_%s.uuid.FromString] error in converting uuid",
+				operation,
+			),
+		)
+		s.logger.Errorf(
+			fmt.Sprintf(
+				"[UserGrpcServiceServer_%s.uuid.FromString] err: %v",
+				operation,
+				badRequestErr,
+			),
+		)
+		return nil, badRequestErr
+	}
+
+	query, err := findUserByIdQueryV1.NewFindUserByIdWithValidation(userUUID)
+	if err != nil {
+		validationErr := customErrors.NewValidationErrorWrap(
+			err,
+			fmt.Sprintf(
+				"[UserGrpcServiceServer_%s.StructCtx] query validation failed",
+				operation,
+			),
+		)
+		s.logger.Errorf(
+			fmt.Sprintf(
+				"[UserGrpcServiceServer_%s.StructCtx] err: %v",
+				operation,
+				validationErr,
+			),
+		)
+		return nil, validationErr
+	}
+
+	queryResult, err := mediatr.Send[*findUserByIdQueryV1.FindUserById, *findUserByIdDtosV1.FindUserByIdResponseDto](
+		ctx,
+		query,
+	)
+	if err != nil {
+		err = errors.WithMessage(
+			err,
+			fmt.Sprintf(
+				"[UserGrpcServiceServer_%s.Send] error in sending FindUserById",
+				operation,
+			),
+		)
+		s.logger.Errorw(
+			fmt.Sprintf(
+				"[UserGrpcServiceServer_%s.Send] id: {%s}, err: %v",
+				operation,
+				query.Id,
+				err,
+			),
+			logger.Fields{"Id": query.Id},
+		)
+		return nil, err
+	}
+
+	return queryResult, nil
+}
+
+// mapUser maps the found user to its grpc model with the masked personal data
+func (s *UserGrpcServiceServer) mapUser(
+	queryResult *findUserByIdDtosV1.FindUserByIdResponseDto,
+	operation string,
+) (*userService.User, error) {
+	user, err := mapper.Map[*userService.User](queryResult.User)
+	if err != nil {
+		return nil, errors.WithMessage(
+			err,
+			fmt.Sprintf(
+				"[UserGrpcServiceServer_%s.Map] error in mapping user",
+				operation,
+			),
+		)
+	}
+
+	return maskUser(user), nil
+}
+
 //
 //func (s *UserGrpcServiceServer) UpdateProduct(
 //	ctx context.Context,
@@ -0,0 +1,53 @@
+package grpc
+
+import (
+	"strings"
+
+	userService "github.com/reoden/go-NFT/user/internal/shared/grpc/genproto"
+)
+
+const maskChar = "*"
+
+// maskUser masks the personal data of the user before it leaves the service, the other services only need to recognize
+// the phone, real name and id card number of a user, not to read them
+func maskUser(user *userService.User) *userService.User {
+	if user == nil {
+		return nil
+	}
+
+	user.Phone = maskPhone(user.Phone)
+	user.RealName = maskRealName(user.RealName)
+	user.IdCardNo = maskIdCardNo(user.IdCardNo)
+
+	return user
+}
+
+// maskPhone keeps the first 3 and the last 4 digits, 13812345678 -> 138****5678
+func maskPhone(phone string) string {
+	return maskMiddle(phone, 3, 4)
+}
+
+// maskRealName keeps the first character, 张三丰 -> 张**
+func maskRealName(realName string) string {
+	return maskMiddle(realName, 1, 0)
+}
+
+// maskIdCardNo keeps the first 3 and the last 4 characters, 110101199001011234 -> 110***********1234
+func maskIdCardNo(idCardNo string) string {
+	return maskMiddle(idCardNo, 3, 4)
+}
+
+func maskMiddle(value string, keepStart int, keepEnd int) string {
+	runes := []rune(value)
+	if len(runes) == 0 {
+		return value
+	}
+	// the short values are masked entirely, so the kept parts don't reveal the whole value
+	if len(runes) <= keepStart+keepEnd {
+		return strings.Repeat(maskChar, len(runes))
+	}
+
+	return string(runes[:keepStart]) +
+		strings.Repeat(maskChar, len(runes)-keepStart-keepEnd) +
+		string(runes[len(runes)-keepEnd:])
+}
@@ -0,0 +1,35 @@
+//go:build unit
+// +build unit
+
+package grpc
+
+import (
+	"testing"
+
+	userService "github.com/reoden/go-NFT/user/internal/shared/grpc/genproto"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Mask_User_Personal_Data(t *testing.T) {
+	user := maskUser(&userService.User{
+		UserId:   "ce1ea3b4-6a0a-4c3d-8f0e-52b0e4a8a0a1",
+		Nickname: "nft-user",
+		Phone:    "13812345678",
+		RealName: "张三丰",
+		IdCardNo: "110101199001011234",
+	})
+
+	assert.Equal(t, "138****5678", user.Phone)
+	assert.Equal(t, "张**", user.RealName)
+	assert.Equal(t, "110***********1234", user.IdCardNo)
+	assert.Equal(t, "nft-user", user.Nickname)
+	assert.Equal(t, "ce1ea3b4-6a0a-4c3d-8f0e-52b0e4a8a0a1", user.UserId)
+}
+
+func Test_Mask_Short_And_Empty_Values(t *testing.T) {
+	assert.Equal(t, "", maskPhone(""))
+	assert.Equal(t, "*****", maskPhone("12345"))
+	assert.Equal(t, "*", maskRealName("张"))
+	assert.Nil(t, maskUser(nil))
+}
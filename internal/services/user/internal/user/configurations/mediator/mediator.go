@@ -20,6 +20,8 @@ import (
 	logoutDtosV1 "github.com/reoden/go-NFT/user/internal/user/features/logout/v1/dtos"
 	sendCaptchaCommondV1 "github.com/reoden/go-NFT/user/internal/user/features/sendcaptcha/v1/commands"
 	sendCaptchaDtosV1 "github.com/reoden/go-NFT/user/internal/user/features/sendcaptcha/v1/dtos"
+	validateTokenDtosV1 "github.com/reoden/go-NFT/user/internal/user/features/validatetoken/v1/dtos"
+	validateTokenQueryV1 "github.com/reoden/go-NFT/user/internal/user/features/validatetoken/v1/queries"
 )
 
 func ConfigUserMediator(
@@ -111,6 +113,21 @@ func ConfigUserMediator(
 			tracer,
 		),
 	)
+	if err != nil {
+		return err
+	}
+
+	err = mediatr.RegisterRequestHandler[*validateTokenQueryV1.ValidateToken, *validateTokenDtosV1.ValidateTokenResponseDto](
+		validateTokenQueryV1.NewValidateTokenHandler(
+			logger,
+			userRepository,
+			cacheUserRepository,
+			tracer,
+		),
+	)
+	if err != nil {
+		return err
+	}
 	//
 	//err = mediatr.RegisterRequestHandler[*getOrdersQueryV1.GetOrders, *getOrdersDtosV1.GetOrdersResponseDto](
 	//	getOrdersQueryV1.NewGetOrdersHandler(logger, mongoOrderReadRepository, tracer),
@@ -13,6 +13,7 @@ type UserCacheRepository interface {
 	PutCaptcha(ctx context.Context, key string, captcha string) error
 	GetCaptcha(ctx context.Context, key string) (string, error)
 	AddTokenBlack(ctx context.Context, token string) error
+	IsTokenBlack(ctx context.Context, token string) (bool, error)
 	DelayedDelete(ctx context.Context, key string, delay time.Duration) error
 	DelUserById(ctx context.Context, key string) error
 }
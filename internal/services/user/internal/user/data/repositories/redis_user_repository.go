@@ -282,6 +282,33 @@ func (r *redisUserRepository) AddTokenBlack(ctx context.Context, token string) e
 	return nil
 }
 
+func (r *redisUserRepository) IsTokenBlack(ctx context.Context, token string) (bool, error) {
+	ctx, span := r.tracer.Start(ctx, "redisUserRepository.IsTokenBlack")
+	span.SetAttributes(
+		attribute2.String("PrefixKey", constants.RedisTokenBlackPrefixKey),
+	)
+
+	key := fmt.Sprintf("%s%s", constants.RedisTokenBlackPrefixKey, token)
+	span.SetAttributes(attribute2.String("Key", key))
+	defer span.End()
+
+	exists, err := r.redisClient.Exists(ctx, key).Result()
+	if err != nil {
+		return false, utils.TraceErrStatusFromSpan(
+			span,
+			errors.WrapIf(
+				err,
+				fmt.Sprintf(
+					"error in checking token invalid with key %s",
+					key,
+				),
+			),
+		)
+	}
+
+	return exists == 1, nil
+}
+
 func (r *redisUserRepository) DelayedDelete(ctx context.Context, key string, delay time.Duration) error {
 	ctx, span := r.tracer.Start(ctx, "redisRepository.DelayedDelete")
 	span.SetAttributes(
@@ -60,3 +60,10 @@ type CheckAuthHandlerParams struct {
 	AuthRepo                    authcertification.AuthCertificationService
 	Tracer                      tracing.AppTracer
 }
+
+type ValidateTokenHandlerParams struct {
+	Log             logger.Logger
+	UserRepository  contracts.UserRepository
+	RedisRepository contracts.UserCacheRepository
+	Tracer          tracing.AppTracer
+}
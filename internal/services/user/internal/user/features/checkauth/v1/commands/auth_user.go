@@ -43,7 +43,7 @@ func NewAuthUserWithValidation(
 	return command, nil
 }
 
-func (c *AuthUser) isTxRequest() {
+func (c *AuthUser) IsTxRequest() {
 }
 
 func (c *AuthUser) Validate() error {
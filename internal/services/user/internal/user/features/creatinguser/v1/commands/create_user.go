@@ -49,7 +49,7 @@ func NewCreateUserWithValidation(
 	return command, err
 }
 
-func (c *CreateUser) isTxRequest() {
+func (c *CreateUser) IsTxRequest() {
 }
 
 func (c *CreateUser) Validate() error {
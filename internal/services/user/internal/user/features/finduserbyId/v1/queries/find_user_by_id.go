@@ -38,7 +38,7 @@ func NewFindUserByIdWithValidation(
 	return command, err
 }
 
-func (c *FindUserById) isTxRequest() {
+func (c *FindUserById) IsTxRequest() {
 }
 
 func (c *FindUserById) Validate() error {
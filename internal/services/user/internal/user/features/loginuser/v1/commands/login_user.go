@@ -42,7 +42,7 @@ func NewLoginUserWithValidation(
 	return command, err
 }
 
-func (c *LoginUser) isTxRequest() {
+func (c *LoginUser) IsTxRequest() {
 }
 
 func (c *LoginUser) Validate() error {
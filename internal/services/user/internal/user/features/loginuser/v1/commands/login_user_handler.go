@@ -137,7 +137,8 @@ func (c *loginUserHandler) Handle(
 	)
 
 	loginUserResult = &dtos.LoginUserResponseDto{
-		UserId: userDataModelResult.UserId,
+		UserId:   userDataModelResult.UserId,
+		UserRole: userDataModelResult.UserRole,
 	}
 
 	c.Log.Infow(
@@ -2,12 +2,14 @@ package dtos
 
 import (
 	"github.com/reoden/go-NFT/pkg/core/serializer/json"
+	"github.com/reoden/go-NFT/user/internal/shared/constants"
 	uuid "github.com/satori/go.uuid"
 )
 
 // https://echo.labstack.com/guide/response/
 type LoginUserResponseDto struct {
-	UserId uuid.UUID
+	UserId   uuid.UUID
+	UserRole constants.UserRoleEnum
 }
 
 func (c *LoginUserResponseDto) String() string {
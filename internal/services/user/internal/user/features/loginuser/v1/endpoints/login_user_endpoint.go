@@ -72,7 +72,7 @@ func (ep *loginUserEndpoint) handler() echo.HandlerFunc {
 			)
 		}
 
-		token, err := utils.GenJWTToken(result.UserId)
+		token, err := utils.GenJWTToken(result.UserId, string(result.UserRole))
 		if err != nil {
 			return customErrors.NewApplicationErrorWrap(
 				err,
@@ -39,7 +39,7 @@ func NewLogoutUserWithValidation(
 	return command, err
 }
 
-func (l *LogoutUser) isTxRequest() {
+func (l *LogoutUser) IsTxRequest() {
 }
 func (l *LogoutUser) Validate() error {
 	err := validation.ValidateStruct(
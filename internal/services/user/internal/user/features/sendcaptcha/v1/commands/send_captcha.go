@@ -37,7 +37,7 @@ func NewSendCaptchaWithValidation(
 	return command, err
 }
 
-func (c *SendCaptcha) isTxRequest() {
+func (c *SendCaptcha) IsTxRequest() {
 }
 
 func (c *SendCaptcha) Validate() error {
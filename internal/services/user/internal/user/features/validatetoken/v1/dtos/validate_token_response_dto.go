@@ -0,0 +1,17 @@
+package dtos
+
+import (
+	"github.com/reoden/go-NFT/pkg/core/serializer/json"
+	"github.com/reoden/go-NFT/user/internal/shared/constants"
+	uuid "github.com/satori/go.uuid"
+)
+
+type ValidateTokenResponseDto struct {
+	Valid    bool                   `json:"valid"`
+	UserId   uuid.UUID              `json:"user_id"`
+	UserRole constants.UserRoleEnum `json:"user_role"`
+}
+
+func (v *ValidateTokenResponseDto) String() string {
+	return json.PrettyPrint(v)
+}
@@ -0,0 +1,47 @@
+package queries
+
+import (
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type ValidateToken struct {
+	cqrs.Query
+	Token string
+}
+
+// NewValidateToken validate a user token
+func NewValidateToken(
+	token string,
+) *ValidateToken {
+	query := &ValidateToken{
+		Query: cqrs.NewQueryByT[ValidateToken](),
+		Token: token,
+	}
+
+	return query
+}
+
+// NewValidateTokenWithValidation validate a user token with inline validation - for defensive programming and ensuring validation even without using middleware
+func NewValidateTokenWithValidation(
+	token string,
+) (*ValidateToken, error) {
+	query := NewValidateToken(token)
+	err := query.Validate()
+
+	return query, err
+}
+
+func (v *ValidateToken) Validate() error {
+	err := validation.ValidateStruct(
+		v,
+		validation.Field(&v.Token, validation.Required),
+	)
+	if err != nil {
+		return customErrors.NewValidationErrorWrap(err, "validation error")
+	}
+
+	return nil
+}
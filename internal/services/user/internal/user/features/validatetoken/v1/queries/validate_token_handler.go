@@ -0,0 +1,100 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/reoden/go-NFT/pkg/core/cqrs"
+	customErrors "github.com/reoden/go-NFT/pkg/http/httperrors/customerrors"
+	"github.com/reoden/go-NFT/pkg/logger"
+	"github.com/reoden/go-NFT/pkg/otel/tracing"
+	"github.com/reoden/go-NFT/pkg/utils"
+	"github.com/reoden/go-NFT/user/internal/shared/constants"
+	"github.com/reoden/go-NFT/user/internal/user/contracts"
+	"github.com/reoden/go-NFT/user/internal/user/dtos/v1/fxparams"
+	"github.com/reoden/go-NFT/user/internal/user/features/validatetoken/v1/dtos"
+
+	"github.com/mehdihadeli/go-mediatr"
+)
+
+type validateTokenHandler struct {
+	fxparams.ValidateTokenHandlerParams
+}
+
+func NewValidateTokenHandler(
+	logger logger.Logger,
+	userRepository contracts.UserRepository,
+	cacheUserRepository contracts.UserCacheRepository,
+	tracer tracing.AppTracer,
+) cqrs.RequestHandlerWithRegisterer[*ValidateToken, *dtos.ValidateTokenResponseDto] {
+	return &validateTokenHandler{
+		ValidateTokenHandlerParams: fxparams.ValidateTokenHandlerParams{
+			Log:             logger,
+			UserRepository:  userRepository,
+			RedisRepository: cacheUserRepository,
+			Tracer:          tracer,
+		},
+	}
+}
+
+func (c *validateTokenHandler) RegisterHandler() error {
+	return mediatr.RegisterRequestHandler[*ValidateToken, *dtos.ValidateTokenResponseDto](
+		c,
+	)
+}
+
+// Handle returns an invalid result for the malformed, expired and logged out tokens and the tokens of the missing or
+// frozen users, the errors are kept for the failures of the repositories
+func (c *validateTokenHandler) Handle(
+	ctx context.Context,
+	query *ValidateToken,
+) (*dtos.ValidateTokenResponseDto, error) {
+	userId, err := utils.ValidateJWTToken(query.Token)
+	if err != nil {
+		// the token is a credential, so it is never logged
+		c.Log.Infof("[Validate_Token_Handler] token is invalid err=%+v", err)
+
+		return &dtos.ValidateTokenResponseDto{Valid: false}, nil
+	}
+
+	black, err := c.RedisRepository.IsTokenBlack(ctx, query.Token)
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			fmt.Sprintf("[Validate_Token_Handler] check token black of user %v from redis err=%+v", userId, err),
+		)
+	}
+	if black {
+		return &dtos.ValidateTokenResponseDto{Valid: false, UserId: userId}, nil
+	}
+
+	user, err := c.UserRepository.FindUserById(ctx, userId)
+	if err != nil && customErrors.IsNotFoundError(err) {
+		return &dtos.ValidateTokenResponseDto{Valid: false, UserId: userId}, nil
+	}
+	if err != nil {
+		return nil, customErrors.NewApplicationErrorWrap(
+			err,
+			fmt.Sprintf("[Validate_Token_Handler] error in getting user with id %v in the postgres repository", userId),
+		)
+	}
+	if user == nil || user.State == constants.User_FROZEN {
+		return &dtos.ValidateTokenResponseDto{Valid: false, UserId: userId}, nil
+	}
+
+	c.Log.Infow(
+		fmt.Sprintf(
+			"token of user with userId '%v' validated",
+			userId,
+		),
+		logger.Fields{
+			"UserId": userId,
+		},
+	)
+
+	return &dtos.ValidateTokenResponseDto{
+		Valid:    true,
+		UserId:   userId,
+		UserRole: user.UserRole,
+	}, nil
+}